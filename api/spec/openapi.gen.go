@@ -19,100 +19,209 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w9a3PbRpJ/ZQp3VbGr+NA6ye6t7ssqpLLhnW1pJVlbV7GLNQKa5EQgBpkZiGJc+u9X",
-	"8wIGwAwA6uE4tfspjjDPfndPd/NzFNNtTjPIBI+OP0c83sAWq3+exDFwfkVvIbsAntOMg/xzAjxmJBeE",
-	"ZtFx9I4mkKIVZUgPR2o8shMm0SjKGc2BCQJqVayGLYUc1l7uagNIj0BqBCKcF5Cgmz0S8lMhNpSR37Ac",
-	"jjiwO2ByC7HPITqOuGAkW0cPoyheZjSLPee9VENQTDOBSSb/iZEaigRFN4AKDon8Z8wAC0AY5YzSFaIr",
-	"lFPOgXO5MV2hW9ijLRbACE7RbgMZYvBrAVzoJWMGCWSC4LTreEu4zwkDviQeUCwyAWtgKIGMqlUlAFKy",
-	"AkG2gIi8fkyzhMvTyE9mTWc/oleQG3ZtdNW9rosO/+IMVgz4pgunZoheZYR2GxJvUIwzF+T0RqIEZbCr",
-	"7cm9EOQxzT3oPTu/Wpy9P3k7QmSFiEJBjFO5uryKmmQRVVFVnBLIxH8jKjbAdoTDCF2c/uPD4uJ07t1b",
-	"HWup/+y7rPxioedSsWcxBb1fC8IgiY5/rjNHbaNPo0gQkcq5Pr4sF6Y3v0AsolF0PxZ4zeWilCTxd3dx",
-	"9OlhFM1KurwUWBS8fYFqBOJqSJuFeTm1DRoDlO5rmgXMcOdqreN13UsBlXmvdZYLD22of3AlrORcJQdq",
-	"fFq/5rC79F1BHmXgLeYkmdFsRdbts88Xc6S/IRYUrX+TUg3uPVc3H7zUnJLsFpJlQhIPNZwz4JAJLW9J",
-	"hn7Z8Vd66mtEGfqF0yxNXulrvZaQ3WIhsUYEbNVyNIOzVXT8c5tWPjeA8vCppJ8IM4b3CtQWrCVsBpF8",
-	"Anc4Jwqop/fxBmdrOHFVx4wmcKHFQJdKAz1XifRCbFBME0ArRreaoRmi8s8tPNB8KSl8APGUIx0C6j3w",
-	"QGrqWCekzO0XtH0qCMT9kiT++w+45wAMOxf9CXAqNrMNxLcHXW2j5qFYTgzKurhgDDJxRbaeRWf6I1Ka",
-	"0wj8yijR3BAdRwkWMJZjvIosIIe19ECEo48RL5TI/xhJraY3kB+KHOEsQazIpCHTr13MVg6t+UDXBXUN",
-	"MgUxBfpFRgTBAs4W89l317MBLGVnIDkFOcpmwXmBpR1mFvFYji6hLBMQmKQ+BVZwQbfkN+Bot8EC3ZIs",
-	"kcgxNsVCk+0OZ0IaGGhN7pTtdz279JtqKSbbJWRJTknmudpMfkf2u6UCs4tilN0GGDiaBqklUYIFRhvM",
-	"jeFZWSV4JYAhg/NVkaZ7hGOJRcWEvZaRtmaWxAB6SQxglwVL28f/cPHWntkORGaqFPnuvTD6J05TEBN0",
-	"hW+Bo5xBLO8UA6KSIs3GO0jT24zuSosY5ZjhLQhgE7RYoRsqma7jkIqoW4thBiijQtrjdySRFqM2ug1b",
-	"25WqW8ib7UiaWlsfxYowAiNJZrQXojlkJBnbYWM77Hg67YJ3edIhPsdOAXK6oWkCDOE8T0msAa7YQi+J",
-	"qsvHSvUVTI/5cPHWf5KSxJYCtnmqAJt4bFTzsfQcKtLUtGicrt2GpFAnxJhmcVok2h0hHEkvgOFYLjwp",
-	"jWZlfMuFc0ZXcgnCyxtoU7+QgrhIBcnT+vbmZH7KXjOciYDdbRhOuhSGQiy+1Sxlk3MkNowW640+u0OW",
-	"V/L/q4EOWyrnRAPCVYVZ3UuVkq3umyo9STIkb8MQF5BzRf1tEk5ghYtUyP3qEk4u4YWDa194Ke0OpwUY",
-	"F7b0chqyVtKdFIw5/rUA6yBpBkdCyk2pYIxndiNFqFJuxc2YS67OhDqs9q/UhS2z74jYBPaTN0TGFEUc",
-	"hFRgSaFOnDO4I7TgDqQqzwxJQUPugCNsribhXcfhCBGB3n24vEJEUSjI/yeZPbU99En90EbX2Ot7QMTV",
-	"Bwvxaj99kIne8v3ZVUkrJEM1iwbNlMWU0p0OE+QMxhbPkCw1nShhKg1tL76tkAuQ/kzLFV4JQ0XDBonq",
-	"GnCfQyy4VHKW/TRN58Ck2JMoUJKnTsQGpxM01zSqmKIZCOj1ycvzqe982MHcaEqbsST+Ky1aP5+W3xPX",
-	"CQm4qR43I2DQDLREW7P7o2ZD7KGQvzdQxavTzBYBxeeIOSMoKm7NMZdUlcKdlIwk0xpSYqEhL6hncUny",
-	"E3RZ5Dllgmu1/9PV1Tn6++mVEj3qfy4gIQxiMTHbcrTFe8sN6B8XGt+O6rRyRplPEoIFl7MERVwKf2Vx",
-	"iQ0Qhrb0RlKSOSPOc38w596vI2tgsdKgUtQ6jBBTxiA13vEKZQDJkDCPH3H2LJ86yPEwz6g+/XyOBZY3",
-	"rVNR7rj4c1ips9FskXgZJy9YTjl0e3a+bZsnbp/O5ZYOw9/xMTy4XMz7nW7vcmZyEPbnQdjLm0iQV7xr",
-	"4RzieSP7umJP1bfOCN3QiIzc4MBIDNXRMrn9fzJYRcfRf0yrp4KpeSeYNq5uYmwtmDv3cUHsgdtQ0vbv",
-	"++SgX7yR4iJb+/TYBqc4Wyt1jZNEm0bGzKWrkEUuTTB/mDhxTHC9hDR76JYIabXxPRew1aEF5cYYcdRj",
-	"+Vdh3S6s+YKUD6MooVvseyGYq78fcO87YGRlJOU7EBsaAMGHi4WFQHuKlr7a1PNBaEUYFwiSN99//6e/",
-	"ory4SUms3mXoCs0Xc/TKSG3K0LlxROaL+es+aD4E6dMS2UASPZN24Lk1GHlIIiiDWR5bm7Y5Joy7Cq80",
-	"ObVLUpA0MV4iZeA32NCrix9nf/7Ld399rW0MDQc1yfgeWr1r4894o0qRNtZTLpGHSYz37tcR5iuHmIFf",
-	"TrcM2rApeYAN58qb+g4j58TN89m9HLHURNxAfJ8zyDEDFRCSouwkoLZCasHMRzqiJFdoeBKHR8aMDJhI",
-	"GbCl2WSPt6lXINQ2mpsFGq7moX7JtaJn5SDdgPL3BEUfI2nQfYy6HYhnwrovvD4IS8+D8X7rfwDKg+/o",
-	"NZyH45Oa+b/hDfav87md7sVKfSdWEXKXhmnykLId+QaSpXe5wy9wfnLRfeyQYc9wxnXECi3m6qnfGPGA",
-	"ijym27bL6b64HWBalqAahZDlsfiHkdRQ+iz4xsfZQ4RRwTcNWjSTy6jA1yGGQi9qo8BxXFj3gOcAKENy",
-	"OO+raYP5vSuD4wT9z+XZe5QV2xup2TdYIAbGr+P1vBFjbVlDQRpgTsoH5gijnHIiyB0gk+IxQVeNGVW2",
-	"CEdYqAUTwqU6NdkxoVwddFMIbX+JfU5inKZ7/fYhfeg7SPeIbygT6BVM1pMRugGxA8jQ9ype8OejI3vQ",
-	"16FEFC1MCkZCaSjVJRTbS2jraDb1HLp8wKBcQGLCxApkEk6cZOsUxgVX6S3AwGQRafjyHGIFxVrAoh2R",
-	"9EfceiWNe9Vaek+DvkOEOTRh5FJQ9qi3cy4oO/TVWA7zav1H8b9azQFH91UGMntokQPenR8DmQHv6T0n",
-	"G3i/D7l0S5v+YRDfncNL0ueCFbHQ0To5Qd7+ehZ+cy+X84Zznu7udoQnFvPIs75DRd0AGgjla+ns7qtF",
-	"7At8kXpgrAe7z2RloN9xE1eYpAUDk85gtJ0vvgHxrS+2IWepO3qNKWCMsva0U/lntAXO8RoeHQm4dsag",
-	"rRrULwD1RezJvBs5WOsCeBfO9KoBrPXF+RyMuaf7A0f7mhA4LNznhd+joT8o5HfX5J2Xjvg9UwjtIQy1",
-	"IVGoTsANMU5LCUNXDvB4Hx1LruI1B/4QanKZsuuRLnihA0HiJjcOkcDuS8kfRwZ3ys0Wd4Zg8gTQ9onJ",
-	"Gli7CewgMeWeoRRUo9p71zMlvB4scBs4qR2pEyWPEZk+OAwRmu6pDhab6tNXIDd9l38C/A6VnQfQ9qOE",
-	"Z4hd+8Wn91YDISNXI9mKap8tEzhWQhO2mKTRcbSBNKV/E6zg4ial8SSBu2gUZXgLKv5WcPFDSmMkAG8l",
-	"GFTSQrQRIufH02l9mqSMRvjOTr+eXSKuswtcS6vMP8BZUoM4KqTDjv757Qxdz8Yn5wuEU5qt9fvLWQ7Z",
-	"Yv7dtQowChpT96V2aq/uprvpeSYjMhpFKYnBEIa56UmO4w2M30yOWpfc7XYTrD5PKFtPzVw+fbuYnb6/",
-	"PJVzJuJeo9HFGsE3aS1N6RLYHYkBvbqeXb7WVjDXgDqayI2VaQcZzkl0HH07OVJnybHYKPqauvm8x5+j",
-	"NQifBysKlnEbwQlkTUtKxjZxIPo7iJ+cpaunArXtm6MjSzmgI75OYsVUitiqEq2PDXwZzIo+G+LtfxUL",
-	"8GK7xWxfZj6jmTmfP8H5YRRNDQk4mOdTkwlYJXKpk49t+CKnPlfZ5rt785maAYAyzt2G7YD6AON3/0CT",
-	"/bMBunfbh4eHhxdEdH+5wBC0Pw4JDoGUTnyINnIdwB+r9LRxggVWVPLb2Hk48ROICf1zpN5O/G9/7muw",
-	"k0pSexppk4xZOfDU9RLUMuiV7YUpZthTyhCqGfoyexCdFHzTkB+9FPLBpKNJ1adRrpMe3LcwlUapYlRI",
-	"OE9dSlvVid7xTxrkEngXeSla6XmGCZNJH9qCb1iHIIoLyg6T9CoSy58q5/vC1S+Biu49X5hfewLYQxj1",
-	"MZDvoQVTw8Cnn82/FvOHqRMS0eMUMTipPj8H6hNsItJCVRZLh0dZY5V5XG4SuS6iYAWMHDA2vZ5PowAp",
-	"Lpqphj52p1w0Mp1eitV9CX/PQFUN/3oAoaiDOGB5KhFUVXxfIxXoNwvuukkhy10Sg0MHZRn2S1BD91PK",
-	"70IXnZB6BgqZftb/XcwfuhwuRuAOeLM4q8Pb8qHsd6TEkb+aVa3i2YRXXw+i9i9MHAMQczCJ1EyNsuaQ",
-	"kiT+aoWJUypCylIR4taxLLwBGTd4QjJVcGsyS+qRdB4q5vQ1DSmHyuOoKitX07sFG22+sRn+FfPYopuX",
-	"Un/+6qIXtqtCRSSD9GRfeVKI5iUFT8s6t6Cs067um8mR36GynWIMMnTBtirTLMsvm4V7bk5gHd1nJIlP",
-	"yhP1yMfe5FXFaL8WwPYVpzXzT58gMK+q6kfdxmZFtJPg29fNbn7CnieoDOqjBBi5g6Ssp9JWdBkItRWi",
-	"qkrLJDV5M5lGpsDMzEwQXksWF7owNnghmsCyemF44q10SoE+8w5XZa36jqbCzG427EhLvWZ0ME69WXHM",
-	"FMFpxVJwYGO8hqysS9X4/YaXA2ul+bZ2Nt0j4ALfpEQlFpaFuN4tTd1trch2TbgwJeY5o4q/KNNVq1t8",
-	"a4cHE9b8HKEPbPLUDgSW7tdU70PVs6FOIT+MQDJbBa0rMNxaSAMbQdEWE91GQBcC29REN5lS9S3AaXqD",
-	"41ut2rygNwXKXFcw6z1NBzGDXQNphxDkknVq0BtU9ciXP519eDsvVaN5WL2TokOVPFHOx5yI6rQrytbA",
-	"9kFAmiy6p9C3TbqVmv0O9pq87d/wDS1Ew5LSI0w9StmiQzcGm6B3tmNAYBPHMtDEr/pwqXLgZb3HQYmx",
-	"Gn5IhmKs3+08zQl4CFL+POODIKefkb7h5h0KzWiWQSxs5c6Hi7ca3bZ5CUlTVSttM2/pHbB9ybRKtAlg",
-	"W5KBA9BvJIhyfENSIghwRa5WiPAJujidnb17d/p+fjqXkJjvM7wlsataL7pZT++yNGbAI1lQFSFtVByz",
-	"ooR3J/+nriu5r8qctaxmqr4F2ZLfoGScb7gqLmcEshie4XZyzeVGZ+0f5Pg4HRmMJt+bHoHAlEAxaLMt",
-	"QuBe2KTqhhUNbIJOgh0QpDqusqpzzE03Apx5O7uUYsAq+MqWryBvUp5bjVzc5hCqSFxOqbok6CPWZFb7",
-	"JlfVntuCCyTwrfIRqJT0tMhMG4pyUcJV84x1gaUBCKZnICNrksnP5h6Em0VHKKZFmkiJgDOEhZBCOYBb",
-	"J2n48f7nt0dvOkz2+/FutxuvKNuOC5ZCJs2JpG7D+3OhG7EB25fQo16UHbOGTNq8PS0zQ7OVvauTyXUm",
-	"fro37YiIMvdMYxipDokga+t3McJvpdRMAd8G2jX6+5XY69iGMR/1wI+RQ2rSYrP9AIylabRyoFmFvBvc",
-	"41gYOjTdQ1xbVmvQ/lwpm0HeFyr4kRZZ0nCdlMfU90xUpdqXTlOOWfhpYaZvziFL7Duhv2RBWxLpvtVW",
-	"xlohUvqvQfBmKUjVRERyn6tTMW/XOdiiBkcss6qnSrjIrO2ZeYsVDnvrOJjHBnYU+xewa4JdxAKF217X",
-	"tL1I3Y07/joczp5jWtfu+Bkcyce2bvq3ofD7GwqecmfHlz3+F3Puv2Dx98FxgKHWxr8d/RakKq/m+Cv3",
-	"yVpHr7ubx394l7qv3rOjoUVdzfoM1vb7wp+eNX8nVGbqsZVnpifOwyj67uh7TyK1VrLvqUAnaUp3Zuif",
-	"vvW9lGoKP80EEXt0RSl6i9ka1IQ3f/V19aLoHc72Fu7cZ7MHCrMHmO6Wr8PPHXJ9O0o3IcZZkuq+Zsbu",
-	"dt6v2l0bpOChktMLQLRg9aZ9puea17y2fdb63j2cotQqvdBJlQmFxp8Wo7fed1cg8imeuZd2DEA8FOAA",
-	"qwPb5c8ddKTe8HqfUN1xUw5Qtheu/y5C2evI5herrrttL117ZlphbTA3foSnc3BHH4s2hVyZHx14IV+r",
-	"w6Fo9Y213oU25NxGozZf/7AfA/GGUgIhkHa04KB9lmWZhM/bYftc0DXD+cZY3wxnCd0ivUar72rVmSTc",
-	"m8DYCpqAuoyiru65AWut3Y82YLt1m8Yt/H6sTWhFe4zdlPT4eVg3pSWmFysvz1/+7ke/WnWAMrJyrI7G",
-	"Ydr0+V7rfb/uEXiplwrUkwf7A05QlVrQEm72B3q69ZmB8lhfevq5KEjy0FsjYolSz2rLGbPrmfr8w/5D",
-	"YZ6sD07tbXYY0BtKY77Qa3p+CaJT78lpUtzWF/Qn1BTFgS/tcveyiKie8tPMAHd6YBqtUoegKlgJtfR4",
-	"IdFNktBP+yzmhpyUVNT9Y7J2Z+gYSK69ptIl2oLAKnhYhcuuz/Vih7h0l6J06f3isdGl1duiIA9dz56o",
-	"OjbNQLrNW8oAORVZbjEdD5QlDhQijfsVUhrIU37v+/yjLjhuJjEav7YMntX61taStbRvVKtSVElUtlH6",
-	"9ezSYSa3BDBI0Z/Fvcp9TDHZOgKjKQh0Kt3CmamqK55aK1aHj2qLpBtFO2XabuZeYfn8EbmifWBeg9Cb",
-	"OyaaCc1oYZvX+uf7Ad2XxzlXcZGq0sMvslRz28eLrN7sVl0y3J+yONdFsWqNF8pWbOczN/sFvFQ+s7e/",
-	"xQubC8FeCEMouNVZZgCvP3sW6xcniTIfkiSxI3++SM7n+ZegiVDn6mcRas+tO7z05C76hxAurgHwotKl",
-	"1Qzii8gXb7OAAyRMXgdPgCYsBVztc3jwE8YO0nSsfhNnmpBkHJc/l9fpllRD2y5J9aN7LwjFapNhdQb2",
-	"Db+84eEOjS0LuNK+bZgZrp6cq1xWICTPynUKKCraou9XtVA4nk5TGuN0Q7k4/q+jvxxFkkcNhJqn0zHM",
-	"sQ6kJLoPXyNSXx3VvCa072hJdeA6JWV7Yp3tPgrVPLf/wMOnh/8PAAD//49LctSedwAA",
+	"H4sIAAAAAAAC/+x9+3PcNtLgv4Kau6rEdaOR7DjZjb76qj5HchIltqWVZPuuNq4piMTMIOIQDABKnnXp",
+	"f7/qBkCCJPgaafzY9U+2hng2Go1+94dJJNaZSFmq1eTwwySjkq6ZZhL/epbHXD+LtJDwF08nh5O/ciY3",
+	"k+kkpWs2OZxQ/DidqGjF1hRa6U0GH5SWPF1O7u6mZpQjyWKWak6Tk+O2wSK/zYAxf5ZiDZ9jpiLJM80F",
+	"DHrOdC5TRViqJWeKSBYJGbOYUE2EJHShmSR6xciS37CUaL5ms8k0uKAFTOAvZCHkmurJ4SSmmu1B18m0",
+	"bXWnGZPUrCm8XVE08Kdgab6eHP5zwpXK2bwEyWQ6yTOY1vttrjTVuZq8a13EmRQLnrB2mGdFgwEAvxQj",
+	"wH3FFkKygZDWYjyc71wPg6tRxJS6FNcsPWcqE6lizcW+FDFLyEJIYpoTbE9cB1hdJuFcNGc4KsVmcw3N",
+	"msNdrhgxLQi2IHhoMbna4LZprldC8n/hIRPF5A2Ts+ZGppNonoo0Cqz3ApuQSKSa8hT+Swk2JVqQK0Zy",
+	"xWL4byQZ1YxQkkkhFkQsSCaUYkrBxGJBrtmGrKlmktOE3K5YSiT7K2dKmyFLjOpa3py9z7hkas4DoDhJ",
+	"NVsySWKWChwVAJDwBYPDIxy2H4k0VrAa+GTH9ObjZgSYsGuiy+5x/eMIDy7ZQjK16jpT28SMMiW3Kx6t",
+	"SERTH+TiCo6EpOy2MqcKQlBFIgsc7+nZ5cnpq2cvpoQvCMcjiGgCo8NWsJM7qBKrooSzVP8XEXrF5C1X",
+	"bErOn//j9cn58+Pg3Lisud6EFgCbhS8Oej4Wz8hPjEomgWgen4kzwqttyJXI09gt9pYmCdOAaLMgSYRt",
+	"cMliIG2VS1VZIBAyrhPoG7rPxcDi6k8W6cl08n5P06WCQQWPo6c30eSdI1bPDUHyiUH1bluKBf/lmq3x",
+	"P/9bssXkcPK/9stncd8Smf1i1M3krlgJlZJuGjt0Q/v7Ca2pviF/7ZvmceE3koglUtvNjPxK1YpE4oZJ",
+	"RWiSGJwgC86S8kZgU8LTKMljuJgr6GO/ZZLdcJErO16A/Nm3v0kTau95owFME/wg/Hex+VUuWwaEtf7a",
+	"Nmjmv3ONr/YOtXxV7K/Km8NT/cPTIOmwz21wEPz0git9ksbsfbANkCyl6TobwUr4WAUL9UeZVliIAj4W",
+	"9iHc27Ri3Bsm+YJHONg5U3mimxeGptFK4FoadETmzNEHQE+uSLRi0TWLCV1SnipNFF+m8CeOAfjHtSIJ",
+	"VbrEPbuyKyESRlNYmndFqxO+ytdXDIe5wYWz2DEgMNCAo1xwCUd1QxMeX7C/mjNcAM7AS5sWU8HusJ+9",
+	"U3pFNbmSjF4r/BatKE8Hzo8Te1hSbLp25qZdCYlpeQr1Aw4cYfC0PabEEaK2E5cs5pJFep5L3gTR6/MT",
+	"nzWRdjASiZj5j4JaiTyJiRsMnlBJtJiRC6bxCadrthezGx4xskjELRFp0vKMlBtu30Xfpi+QEXvJNI2p",
+	"poFXGVqTJ7MDUulGTD/iOpJvz38+In9/+vjpowDh9HvOWRpngqc6TElFzObRCkCVLtl8zfRKxGqu8iwT",
+	"UrO48j41elefoelkKWmq8THdegieailUxqL+tSOnEH4gslytWDyvAsIS4u5BJVtypeUA0DmMu99+JbsR",
+	"0YDZDJ8yoglu/n4HWqMGFt7TNvxqLLIDRu9ablPtenTxXHY5wHL9RHW0KoX8c3PQXRLY6cnxEcFupOxH",
+	"bMfmjfKkXotFwzm35rr6AB2azQNYy26H8qeN7v3yahu02gTXygZMo+3gZZc2CmBuvi6IjWTpj1DqOfdI",
+	"w3MphRwMuuNNStc8ImYc4g9EcKQOWDL43pzBdAPyPSVstpwRbtiJuf9qTotfjdw2X9uLRYQsPimx0LdU",
+	"MtTnsDVLdVCSw3XMK4v40MMymqV759AHx+3PY+iV7zoJO4Z5Wv/2/Y+PA0+rhaNRHIXeU/PdcizNz8Cw",
+	"RDXiMeZZbW7wF/iIcrTyJHSSK6Zm5JgtaJ5oRbSoKoTmgDmwvTEvVYlYauwj5z8DITWe+T5kG+MX3qL/",
+	"uMhoxPYUA62zZjFJuNLAaGPzyiJA+WIJcXUtImMpj8NKF3erKox24Htx65or/O3tpZNcrjaEEi1z1MWY",
+	"l89IAFQpJrVyS3UXvHtNILK3ycDtXERA8oK2xLVFDDNqJPhOTK9D0BmyqV3gXLFIMj2/oopHQIWqP2ei",
+	"DuJAt27mvJ023Ie6DCT0pis5SY0QZqiK6VuSlSmx/3vSTmBasKb4OjdqsjkNoM0lXzMffU+OyS1VTj1M",
+	"9dTXXCqeRoy8Tvl7wjIRrcJqy4Fkz5xSc0UWLObzjBitPYtR1DIaYViu+Uy4W2pYHVzBB6erHQIGO7zt",
+	"gnA4QNWnJrFgiqTCffRWeMv1qjpAJ4C+0v1aV0+Y6rFmwM/1jcDyJaPxlBjrE6FpTGKWMM1Qg+OPX0cr",
+	"kVY/h5Cpsr7qEdaVDS8K24F9E0S64Mvc9C1IYMssX1+/r6/fbl+/ijRUPCHvup/Frbju13gTh/Lbpg8x",
+	"nRoc9tYP4Ff++yv//ZUCfYkUqEo+BpMepya6KIxgNWTC390bHbOMpTFLo01IN8XR0B0Yw6jYuWEFJaPx",
+	"BvhD18EbFcyYZEF5UmFSfdNRl9JGOj7F2dTBWBVEmIRqmO5lYMPHjvUQi3IQwlOy5knCLXc/0BzkqGnN",
+	"ykXXLAzQFvvjuFNxrkbiejKdxGwpacxiWC+CdfKuD8dw0cXU0/JcfbD56FdDoS7UWzGa6BWC1KBfoToc",
+	"p/cr+z2cmk+pnII/DMASDF+eJq/udzJOeYe2FyZvQlfjJU/5Ol8TuhZ5ilS67gHTtLjdUq6dH1YmksT5",
+	"5cRswaRksef445GwpbVi1rG0V7XYckSDKUzpUmAh3EVqiovhjoNoSVNFIydq1A53kJhagkMsFkx6kmrl",
+	"HneY7M11YNusmRy1TW8t62/N4caCqfQb7TyQKg5ontOdN4QhU87/rrj20AAvvOOL8A/z+hn5f+I7epS/",
+	"mXXhV9hFEqYW04l+b9/+mq/Ycd/ZddMdM6yDdBADaxg00IhV7d9pI85YenKMZMaY3elVwvwDdAsgpb6/",
+	"3DKTxDqs9FmOjdNgAIQ4z4KXjgml1TrkdlhHb7sKyRLOFAjqsJNKx7Dqp0QG3w7aLqk36UvfuKVJuabA",
+	"aiyeFzDoGbPN9tplArtwPcp5Q4x1zFWW0M3gYY9N+7PyyEcY1grrb+gY2jbcej3G23jL/qdAOjrPyFCf",
+	"jCrrLunRr6sNkWzBJAP/T3JkHEjM86WA4Sl90AlVhKdcc6o9WgH4ZQe+2pAbmuTd9s8W10eUsyyeWlLo",
+	"4asaJ2C1Ya13F2rEgUkfJs7IXLs1ioALehC9RTZveWneAET8wV++vri0DoDIMMAnlV8pmDPVNS8XpyCg",
+	"Zi1umvJU+ulzCE+xh7fqIFoatBqNjYM1IQPcDNwbXx/qZ/zdnWXplFtucUr+vNXzGxTpkjib30Qzcord",
+	"gWQtnEq0xmX4/vGbjBGuHDIGTx39u/uIzG9vL8+wXYGoIeTv2suAN9icp1lP8DBHC5jj/SEGeUIAf5Rp",
+	"ZNg7Fd81x+7a8wX3sJd3th5ti5JWcVV06HmkAm+8sRZ5p0J+uzh9RQwcyaLAM9TJo6YEx8UvFhcNOs/I",
+	"K6GJ6lrb3XQg6vP6qgJ434s9dqoWvNlabmiTF8oWxAirTSzpcO11V6h7S6rCaQZ3ti0zavqdZjrEh8J/",
+	"FB459IXjr17j6jaH7aVvC7CUgbs4thh2lFC+Pg7y0uWljqAR8bll75KZKIwCe2+pj75NFTbl6xAm4O+B",
+	"0VWO25iFvEcH8RKBMfFPMyGVDFj+Gx6zGLY6QIHXeDvtnrxzaQJ36Knw+AitZwGt1skxMd8Kh94meP8H",
+	"tPjsfQAh7YewLo2n1yyexzwOnMyZZIql2jAhPCV/3qpvTddHQFn+VCJN4m/Nth5ZuqZ8Hk2k7HQxOfxn",
+	"8wZ/qB/puxDTXQDVwWYQIYrZDc24BWqdt28C1zQhJTRrYiFcAu/RAaGMREzii5TQdJnTZeA4rmh0vZQQ",
+	"jjOPRNISsdGnekpERJMAcv90dPb0b8XkRNNl+HDFUvQxJi+gjaftbAwCqNO6hZD20b8MDfAPvAzP30cr",
+	"mi5ZhQ8+EvEQCxszfTGMDuRvdH1HXsEdKPzcODCfee/eZYhh7l3w/TfexoC5L2R9XxAMEPmbSo6mBsN+",
+	"8PRHhWqFIBOsatGQiqXaqf2r2tJcMcILwYfmMWfVCMWAYqvdCaofuANP6VfUhB+BJnzUuRgNurVKtLE9",
+	"US4lSzVoQAOPpfloFM0W0GUU63BtaIddgivyx0TlqML8YwLsqSpMQHmGjK3MUwgv6X8xvWBoewYh0I0x",
+	"OpxY3QMIG0/fHA2gB65HQz4p9IGtkmdV8RczTXkS4mBypcWa/4spcgvWz2uexnA4Vg1sVQu3NDXGQxAt",
+	"4d83RxctzlyUrzuUechalMYBiwV2FrzltysmKzfT4+LASGcilUsp0wTg2zNf5EmyITSCU0QK0htK6wyb",
+	"FtDzwhqTy6RT/1LKPaar7zSEALTqkhm5pNdMkUyyCPYUMQIBls4Me8uS5DoVt4U93NOMkJMFuRKFu1p4",
+	"kYjUjcGoZGjzLJhEbaK07bUOa8FueZK4CCwSIWK0tOSpZZmswX7PNdtzzQ7397vgXax0SJC6oav7K5HE",
+	"TBKaZYkziOO1MEOScvNVT67X5y/6lMSoIplfbeaFIjHweiyIljmbNq06XBGlhWQxgOXN0QWeSBhukdNO",
+	"NibPJfd1bAumo5X1mTCzoOYO3qA4j5iCR0ZqJmFUhRMmTCkSMzjdf5zjq6nClmxvas3WWYIIFXJFsB9D",
+	"kgjeQSu63654wqoXMBJFeLBecUXQDOnMUi66fOrsUPaBBTi6kzOqE3hB13mieZZUp7crMxsP70c5q79i",
+	"vTYCv1s7HAJiXqFcM2rQ+k4vS54gomlhYEucom5NcoUG37SWaIAcBRcHW6JxXGrA0cGGWyIBV95udhtH",
+	"qBaFkcSlWzLiiMKy4iElRb5cme17tOsS/i4berQ7VwXW+MxezfTYsCAZThAFF6T4mmUKSWSTzsXG3aXd",
+	"R2uE+tuSI7QL2MQYhZ9HUwcPCCky+lfOalYKdC3iymwe4ADvrNWc7znVec3dx70I6LIcng92SKyQDKcP",
+	"XE6c44qLYPwSUp4HlmQR4zdwhezWAN7VM5wSXlf26xZ9v7dop/DXomXJrZYAa3sxU746vSxwhac1o8KR",
+	"iG1oL7qaZ5LtlTbnucETfHEVG+LBG2BTHJ2Wvh+dO0TcBnufsUgrJPiWVhmczpiEtxGOAJ+nKhIHvd7a",
+	"3TxaPO2K9RWudQMW5udoaV6sqsKszStvRKCp45pbuN6B4kqjd78ufwjT3BrnWGNP5i1ePE4x3/LKg/wH",
+	"ZAk5VSWSGxYb7rY+vnkkHL3qYKZwYEc/8BJSeFUUG8bb9PrAt5haq8ZV2DXanfjC6xFgn1CdWvDowSXe",
+	"24OGXJbU1Yrcw11YmBoucw6UDRBDj05acMJ7+uzjUVJwgDWRLGE38FpyL9So9oaIwOBABmfE+hcowwr8",
+	"enl5Rn55fonPEf5xbl2NZ3ZaRdZ04yikYxir2RcMGUe5C25VrqCXFkQBQ+DsV1yStbjiSbFGmmXdKo66",
+	"rcwHi3shSg7fmCIiISVL8AdAvZSxOIhVdcNx8ODcWt51kKhxKpVq9zNnlqhSlsxTSB+zBa5NpCdxS7YD",
+	"mQkVVmo6r/HX5ychxU2ywcefSr0hkFHDU0xxVSTNMEyIkZ3rD5STERUxtk4Wz8jzFDySVCO3Rsvjirhz",
+	"ikD7aYMOBAENvcF6bOqMkDxtrMZ8d54ZhKdKM4oqCvsNaFtY0qms43kayQ3O/TsL5EKy6JvlVwmPMMEZ",
+	"T8lvb38vTJ4nC6KYnjr38frCFWFmAsedc1XLXlUagByI4cnq0b15io7CZHxr9Qr+C41u3p4zHvw9mU5s",
+	"fEHzr9mftzrskRu+E2dBc1AT99vTY9FqipVC9RWgFMHESrW7HRzOdm692WddmbJO0izXx/YohHxJdbRq",
+	"bsNPVKVCvogoJvoiosqv1lxrY6gjHGYhcTHNOHENXKlzOSL1Au7iZ9MrNGDQn7K2RHISzgS3hrHZkKxD",
+	"6F7pmldOJwDy4NEUUnVpk6+eC45+zuARHAQT27RDpexN6vTd5My6aOep5kkH4bTSVfPS+lfUjDWZGoU1",
+	"G+cs39RON4EUhmSZjKedmTYuLJXMPeX+TKzXDz+EYr1g/pvAgG9XDHPJVTL+mcYtWqrOoDH2PvN+9wIf",
+	"ONXhD4Ug1ZPQsC/VIG6vCvUAQIf6miDDU0oofQ4NVsLr8sjo8gAqJxpsEYcJRlrCBU7XS5lqW7eeJx1+",
+	"CxWoB+A2lFkLz3tvV5gi5VYA7isK9m7W0N+hu1ubAEd1MDUeiIueVtYMAdyJsK+M2ijN1sbKhhp9y2D3",
+	"CIolYR2Wyqd03QFnALGmoeyqx/j7iH3feEnnXrbFyK0YcrYWAs0uuF2r0ApByCbdi598//3jH32WTywI",
+	"+Kt8e1J4T9iEx/Dzoz5o3rXip0OygShaOFwGvFqC+Xhhm5WcvEHPOj/JahN/gSFsqjkMs/vb20sjbl/z",
+	"mKwYjY3VgyK0QLpFwV+ivt+csLXVXLPNtGlpd0vDtlTDKO3eqQN8pOwJo97wiqEaVAvyB2zpj0m/mOhN",
+	"M504vtgeZHEUQyn6C7G8yFgUyn14w6R+1qbtcIpMo7KFpJcJu2GJcfWSzBiYKo6XNkWkpSsZk2uaWnXn",
+	"QFu6XWd1NS/KqSH2UMR5wh7/N/7y+ND8+cT8+eTQ6q1ewF/2xZhaJw66zhJGDGSy/47ZVb48BM1G/3Hg",
+	"srwjcBANcDMvrJNQjQdJ9Nx5kzU2bVU33UuARtUViKF3t8JwN7lUphRdhvmQjOpVIGIZXHThE4oVIlVa",
+	"Up5qk5TXXJ9G2KoviVMlAnT5NMXLk4o5SidISMrUYv5vt1Kky7k7W+vWOOdqngptPW2mBFtDM1jU1C5o",
+	"vuDJIP9+u0gP4BUgBs79ZZXZb8jPqEtdEF/lQspNEZQVikyylVZxoZlpUkhelVaGC2FBMScgjA0XqFzL",
+	"aXNRdTBaMAWgiCFeZ5UKDSHGE61P8LoYBUxGuVS+ptCLrwElZ86T2NrlhWQt6hwQIX7429MfHxmFvXlu",
+	"sZM15Bm9qLGkOPUV5nmtjof2xS0zTpSpjfqtQ+12mS0zb1Zn8MWd+vrcXN651g9uIGk6kyyjkhXevc9a",
+	"NDJt0oftT3AAAiOEY31G+CLVr88Mrs9apLMNXSctWdO9oY7tSIG71OES2Wv9MxFPDXYiEjEL8hMPjw4h",
+	"N81Bx/cwqNBvYxuAC60BNK25jEPuB9+oGl2odO8OAw0mC+4j1vXLNSb38KANnD077152m6nE80k9OTaJ",
+	"W4xZhJE8i8S6adj1Pe5HqFMLUHUlBq7bUIah1Ej87FBmlLiYYeOqfF51zOkJ5h8VkDEwvm06iUOHeFwG",
+	"q1sRqUM6Cg77mUcUdtJdZC/BIAdThfQkLLq2zgw1sNhqKRY0zUNFLkXk2kMGsH2CsQb+z3VIy9jmGx+K",
+	"NI3D2O7XU9oOs8tc+d343UC4r2GJX15YYtvpj0OeIk5re/I4KvothFv9JXkqPZowCGxiKBRytQqxgUNY",
+	"2FytaoyK7Vw4Zn3mzGs7zQqv0wd8D9xGgJ/F4zlG7DaYS+yq2fXMUAhbSQWfC8msGK+qlcKqzyGqjb0U",
+	"R1QRSjKhOFh1iLUWGU2n36MYjStin6aYq0gyP11XME3KVa6N1lhvMh5R8I1Gl7CEwozJxrhxk28hEdSU",
+	"XDF9y1hKvkdS98PBgVvoo7bSY4UDRFvhsXITyCwCtGNPTRuWzjOBXlvGhRdBBnACDU/C9nLFSpcwN45k",
+	"xgR2w6qOQ01v0eCMQ3RE5VYrBd1q+N2GmEPVt+eMxjxlSo2KjZKuV3tgVKUsZltOtSKfmi08NKy6Qi0N",
+	"WoCefLKwrNMbJsHfPQSg+6aLK/PEebub+pD2cKN5rmMCty60kFvFcyot5OhIRusVNIa17uBkYTQPEN1b",
+	"GfgEtA0y4spsA5kBYZI9Kxu6v0C2pi5rejMRFwos+FN3tPtAfRGscyn2bHXTI9sfOetNpsVS0mzFozkE",
+	"FYAe/p5Fn6qDqpzr7ash7SCj1VAefiTz3uIMddwcrghA6rFabg20IqnvlupECx83kH/9A5g98FKYPK91",
+	"B4BWItjZvOASlJa5lfIwITyQhDdH7c9oX5nI+/ozdPifYE3jxvgebLsBNArK1ubpATdoTF5oJruKGRZ8",
+	"LjS0Ml+vjXkynaxNctDJ4eNg2covw24chGTADIYJFzflsbn47qBWxjT2aYFXprAwiVk3TRssb0WxkMsQ",
+	"MBhhNRjBbbSnfm1LLGstzFs71/hVH22+6X7Qm424lQUn8g6mC+Bdt8SMau9JfZDeXEDlifmr+4Id6OoQ",
+	"GOdBF4Tf1tAf5EV3U787u3aieyCvtLt2qA1x7OoE3BDNSUFhao7lPXiMyvTBfFfXpeyK7mvd0EiQ+Pma",
+	"hlDgitfGF0ODO+lm43a2weQeoO0jkxWwdiPYKDLlr6EgVNNKUNQD5fAaTXAb3oDekjqPZBuSGYLDEKLp",
+	"r2o02cRPnwHdDG3+HvAbSztH4PZWxLPtuvaTz+CuBkIGGHIW5ZLrzQUsx2yAZvx3tgFFSEB9fnaC/lwu",
+	"6pKnmkmwAYPWj0c2rUbMskRs1rDPwrga0nHP0AltcjgxfsETl45t8n/3np2d7EGwXbl/XBUA5IpRyaRb",
+	"n/nrZ6dq/O3t5WTaWkW7minj1JWsVyYbBmovy3oz2Iqsc4Wx2OguxuIpEXJJU7cProim0KywfppeJkHi",
+	"DD2wALCTQ7vQckMrrbPJ3R0GCS+E0d5hPSL4L1tTnkAjliTif7DUzFUiolnMbkooXcLPPyUiIppRTM2M",
+	"Hqo4sjrc3692q+fd87pDuL9VN9TzmqGJD87UR3qbe+Ttd0fkzREcFaGJSJfG3c/ko3/6Bt1WtIiEH3+y",
+	"77DPz3Zi+r0topwSHjF7N+1On2U0WrG9J7ODxiZvb29nFD/PhFzu275q/8XJ0fNXF8+hz0wbhVfl4jTS",
+	"5F8YDCbfvjm6eGQEEVOWZ3Iwg4mRu2YpzfjkcPLd7ADXAt61eGn2abzm6T7NY673XYX8ww+TpXETFA7V",
+	"IGh38gvTWCb/uW0H45SulP8ME4yyyT52tuEFJ8f4NA3pUeD74B5HvgZjaKdnkRZycOufpVgPbnwpsGld",
+	"Q6xzmSpioW6wydiMIuYsfUvJqDYWv9RzSxEpm5HXLuQjo0ub3g2p0l85k5vyuqEm5IL95S419ZN0txbU",
+	"aa73JX2P5VPSQtfiVq6FLQdUDdB9fHDQtqaEr7muLGhtRp8cPj44OOhWx9y9K90ZEVmfHBw4OmRLYHnh",
+	"9Pt/Wnfwcq6ud81H8LJ0912DCp3+PvHfIUR//wX65zuAoE/z/znBu3aId23yDnah8vWayo2HDPgR9VQO",
+	"uE4VC2RGVqg48h/2dcShzdNYvdLvncd48EY/x89fL/W2l3ocJr7fS+MmNtZZyF2jmjnzcahGqCIpu014",
+	"ykjM8PZCjNTF6ashSGgY+lYkNAwhwvSFWE52fbl9dZ9jV3cOdDMpU2RFFWQUBaGi8KJw57DtVU/E0imo",
+	"297tMqJoZ8B1UzwoMN3WWsiltYP7iv2KOd8q5VUIfuDkHIylyRIaMVUbE0z6drAyOZl5jGGBGGzpmSgI",
+	"VyZ3Rp/twRoqytHSuikDpfvKeVbU/JPCKeYnEW8e7DSDpoS7u7u7T4dB08lTM1ktuzaNSbnAh8SyIww1",
+	"VFthF9xO36eivJthLtCO2JJyuHGff/WG3uGJhNL/DrrfFTiaUciRXW3Y5aQGsP3EJlXohBo08n1sSFn+",
+	"Ej7Bs4USHlWEa/gVpSctyIqmcZkfLgzjF3bwzx/AbqUjQYzVQXthXHdkIlosTWYLI7EUrlyMRqtafcwG",
+	"TAunpF0Cten5FABpiSkIhhnx82k73yyTUDsV6V6ogCpXJE/pDeUJopWQRGF+qLvp5PuD7z7Vdsq6r1SR",
+	"/mXPOjGrmHsAallVSamJ2cOoCbX/Af89Ob7rxbVodPkzwtNwZuEyx6ZoSVrYUS7SZgnzk/NmTHIRB5G6",
+	"WX5rZ6hdn2o8rajv2TtSzyejJgMOcBDCwQp5HyO7C3Hfnv/ENztgZukOMeidh1Ke0k3t2wS6Zf5ThOOe",
+	"8yHExF9NK5XtFEwDWvfCKwLXmqc9oHDELrix3ml3zJn1l2LYjuuvCabbnFEIf9tQx4YV7aGaey+mmiIS",
+	"/WvPC5QM44+N4FC2SGi44F95afzURZVQyCZG2ZFbQlt3gUyDomp3jFDDQicfAKmGRnBvhUYVv5kw4ry2",
+	"FiewVRhMMLl3AkFmqDTF+TAksS/1er30cVkmq5ZA0eJhVK1DQdO4Nj7KrCb7jc3X2qy0G8TdimPlLjG2",
+	"nOcjoWc9aO0BETLygTYa9Vzk2r1wsLOIeYGLA0PoSMHDiTTGdCJ7qdB7a6YJ+npgAuVqXROTLpqlZSr7",
+	"Kt5tmG7FuWAs3Q5xLzDfl4+DAQQYhYy5WtW4sN6HtA0X/RQBmMMd9U+NeOcab+D5ONWwpCXwb1dI0hNn",
+	"2I4q9zzI1uDOMeeImshR7DTGnKj7MtN9gTm7OKnuOXd8pXtCdR7gZm9zMD2oYl9/tf8hc1a+u/0ZpPPf",
+	"w3z++zhaDX3Kqvud8n7p4lJFYqvNcOXVTf7Vvz99/PQRsEVYcCgUe1qL9K+UwiqSI/YxNacwdGU5ZjW2",
+	"3OdOzVONSR0EthD0OyG6jdRv4ObSQ54ctwj7BZZsLe73Y5wpGuFpmsoCiN34hk4+iAphb5qiXsbaoV61",
+	"uqjDGr+P/cTLWovceGLVSqd1Ih3uyDNxY/vdo1x9xnvgW7PszZeLbJ7QZdrhU/gJFzxteYhP6umRQ7yQ",
+	"ULqWnXVXfFAoSfEDvKk1B+aHMTCb8z/Ef+qWv5Oa5LPNE+njUBks/jkikbH1VrTvbaZHwCUPjYqs67sz",
+	"QbcFF37maOWS1VfxqhPSD4Bh+x/Mvz22FsnZjfUWHnDmv7DgkX9CTJ6G80mcHIcnUeXXUbflYyNX3cWl",
+	"75hGI0xF6itKbgkeR58taVINrZBfEgo0CCdB329vq7ZGqUtyU42bUm2FYVHVZXOwObmiaKpXphZQRary",
+	"8CFwi1xZlirHBfPt6i0OF6HbsYjbVlfqYzzafUXw7n1lPkCtnbv9CAZLPvGVmbZbZQtErqWADMyt34+c",
+	"duRNNaAKrmhKIAVUta6eNcODYlmxtKioiRm5ScakcjVm3xY1Zr/BSqgRY7FREJoZExb7c82In0rU9Lpi",
+	"Xltj8A8mpRSy+dX3Fgj4BBzhuC03/QF0gKOuhVlMiEg+2H0ozKlf78SAO1FUFi6tIU13ig5TC7liEc1V",
+	"ZYBba04pvZGwuoJXCa80ClZnqtRsNooztV2OzLB+tzCcOFPvjp675jwPqHQfdeFw2x6oilN6qPv2lyzU",
+	"853aLlfjkq/pkhnqGGZ2LPpVCGaoMKVfQJNrW2p3RInMhlzhCOM/JCjCe4UK3MeikvHI7rEt0KhIC1Qi",
+	"kc3EAtc+XXpJ2cxf6mYZzMPWqO7FY7BGpTFZMb5cFes5e/WLhTdPScbfswTqUC9T9PUGvePFm1/aFqv4",
+	"v1h4qU++/2Hqh0g9eeqFSP3wdKsYKVzlPuy6cseKyLArnlK5CcY4m67qZvl/3q+TjxDNMojrKzDaosRn",
+	"p3X8VK/TNiSmVFh1e61rry5XcBMk/AZqSaNreAmXkilVH6FHB+Gohqd9+ijqcW/Oj4DYRwGptkgA+RWz",
+	"HWaD9mK/KIXfirFtlkYrFk8tw+UCAgBHMXS+NCLWa/u38z2nPI6eFSvqOZneyhuhl6JePOMeRwXcnym/",
+	"4lmw2ub1a7bcY85npEjfQWImq2VDYeekiLe3Zf6NOGj93oIJdae23rjtGRfFjhKqOzYkYjYvFnPfXZnk",
+	"YWbNwJE7vZHZo9lZMdmwJdnElpPRZxo0kLta2IbDzxWTe3TpCdrmfL8pi2b7BUeKWLZkQ5jS9CrhmN+6",
+	"CIcLThnnpkheiWaSLbnS5r4Y2R1eAGkkljW9ds1b8yaHb4RZsE2XPBJYmD6jCMg0N75nQuwybqZnKREZ",
+	"/Stntq6UXxrfwkYLAgln0H3BPK4uQ7af0xvYTogSvaLRtZHfgqDnaZTkMWqQubJzIpCL002XdUSAIavY",
+	"YCYowykufj19/eK4UIvaFEo3LNUm4b5Qak9xXa52IeSSyU0rIG3a3vvgt8v9DlrdG7ZRVlg1v9ErkeuG",
+	"L60nGt9S63xrxN8ZeZknmmdJ6ySeVtggPxZLQRP+vJwDRixOrHI+HHRQJkPP2k1VM+GGIBVczTjIGXns",
+	"m8IT4kikKYu0k/YwKgaga//GRO65YkUCeHHD5Ka4tEjaNJNrnjIPoN8AiDJ6xROuuc2tUzg5z8j586PT",
+	"ly+fvzp+fgyQON6kdM0j/2k97756ZhZbim/bKwg4T1bobVhiwstn/w+3y1M/gbu7agZHMs3X/F+suDjf",
+	"KFAEMskZcK733x2MOV+ZkkOjTGDwxd5y+5Jv4BdKIiaRoNhjgx9tPmbSLDtjoDojz+xQxm2KK48CcOUl",
+	"97cRWjwlNC25Patn8Ah3+cB7UoBX2h8z78t6JAXM5V5RmAm7EJfs2y6xQrOaO7ks58SsSJD5iPBUC6D0",
+	"IkcMoLoc1MbQLXMKDCAzkwvJlzyFz3YfTkEspyQSeQJaOYAA1RqIcsvZelnKt7dEfnfwpDPJxO3t7R5I",
+	"8Xu5TFgK7ERclXjCyddrEt7zf7w+OX9+HHpeoAdZspRJqssXLJgpq6038rtGf24KQiQbq2U3cbpludw1",
+	"13zpbG6Sq2ugmgmj12rWlpa/YzuuiuwfpuEfEw/VgGMrtFup/yqHORHcG3tPI23xsFkC376g/VkRXcr6",
+	"PqPxz1DMuNNujMavvhCXsv5DIUJdUR2t5kPCCfBtUEQxrHQwMEDF56yKaviuNDO0FykrIXvUTDasyEIk",
+	"YHKFgYSMTWBnJSuxjeWGVAwFIVoYFgBRS4l1lQeg0pU9mppSYF4dJJEy08CkNmJxETFj3wpQhto5XUEQ",
+	"bYQOi/W1zZaEsno6trg0FmYx2gGX6O34TJztGRi1DIbcBzQjeLtZLTYbv2CuQnyK4C8zW1hm/QlQYOcB",
+	"FbVZPpJdujFrt116SK4JaPM4YPRJC23EgJuK6yJHIU+zwCUdcT07K9pteU+/VMTeOU5/dHT+DDF5KA47",
+	"w9h8O2TuNIxu6jxgWcDPYa6p0WYRrqDt7T23w3CnIHfrnGfMVKEysXB5qnkSCL80qSiCONwS8Pb54NcD",
+	"z4t5pbsmD2Pwx17FuDviDnHwZeGplgILjbXfkaoxBriUevCCJbJCEskWkqmVRX8Mq/nbDz88eQTxwwWr",
+	"CwuL0E+1UO1ZRrjC68kiltl8PDmekZ+FbKPuU/PP3JVUhWa42ihdzP681qVHHkz429vfiV7l66tMwiW0",
+	"GzEkHzL54tJ/fPr0x0fh63JSAm4oyR8vOfk118PyxtQJHBZGVrb0oMxdAtpWGSpYMLml4GmRyReZageC",
+	"9iHwNIy2IVBzEH731GfaqwmMvQ/JHxNzxKbm5R8TwIo/JhbL3I/98k9ZMbMuAO3WV9EBaEDSgY/2jpqi",
+	"tZW1ddOIjMp24mCvtWJp7MLtwhUQjUYYZCAR1ibDVV0yreqVJd3xGC2Krxulqlk20dVI9NRrbrzGxN0W",
+	"tmDtw3Gho6NvfGth1P84/XSzDFU7MWw1MTYHqZrjDj8Pw2HPMp2J7vABDIKdFRC/Knw/a4Vv4+wqNsnD",
+	"/zAjbQAavs/C4Wg/iMaAxgJ7uIU9d6jW+KvBtgGp0jp1+Jnb1hpLr5oND79402hf+eiqi5DvulN7Zofx",
+	"3Y8fNFtKW9XqAPd9JBnVLDbs9feB0jfmkX0lNHkGFgLb9PF3IVHZYPjzVHO9IZdCkBdULhl2ePJjgJgI",
+	"QV7SdOPgbmrN1Tj2ljrvA4wu7l63u63B+K4VXl3MXAsXt+C7vRg0wF4/3ZwhPAJues6IyG02ukIcwSC2",
+	"MHt97pbW47/mVTMuc955qUXaXJzu52vlrKhdDiX3sbAGcccCpFeC80DXefbwwjLZpdgxLZzgZp8csfAI",
+	"itXhfP/j40czYjrIosZPzBXEgsQkTxOmXKhjUlW6C0mUWOhbKo1/KVtbRYWD34ychPptpRe14mihoHLV",
+	"8k3aucA6gHdDs2zEYpQjgcxin/oY3vsewmYDSjP/rqwAOLh/CJ3WgMc7nXggPT3Y4SI+qQp3/GqqahpY",
+	"znefcjk/C3nF45ilA9TKjk8wt8ufZhgN2v9gaIvNGxCzhIX44WMmC5Lk8cCOBD2pk6B704sWSwiszrvJ",
+	"lVv1NFAdWpAje4ifG5b1GtUMhM2Wg2c57cve7p2HWHzCY4MQjpYzO/hElPD09y8VISAvews6dHJrR85U",
+	"Ew7BcFRgdPhrZyWVujnq0+CfTSezewbATPSxHAG2Qvivr/79rp854r639UZcsy7uHr6rAQbag4Mf8Yrc",
+	"iKhCx6ttaaIEkXbQaupD69KGApquj5SnmFQPJqeJKRxhRontwCqPIsZiFb5WZhv/sUZWA6p/PwPrJzeA",
+	"lkjafc2KQ+p0IKo8KaAUMaW80aBBaxfJJQp1hSbgRAIuzMbcaTBuRZU1zhW5RPDWKLXIk4aO3EB7CkcZ",
+	"mqt5tb/FUETjOdE4/Efo8Op5xJWuEdXH03OTm3a741ZdLWgaB1w3ZhBMKhbKmFis74ZfHCsVacT+C66m",
+	"celec2OJEkXilCnoT+dxJrI5NnaJwlXpl4WXlkLtQTOe28feK/yry+kP0ejfki51e+zjrB7CYEGjqqHY",
+	"GlXanPObfuyjljYvSnU/xBqfkUhuMi2WkmYra4KUNI3FmphpC0uvMxtGQkqWuJCBsLeDRXLnBdhuNCgX",
+	"OdhkFdrHULLdZzRsoNYflQ6NeAZrUYp7LODWtZ1LY3RSxaaMXTmiSctavf2MPO06LGx2Np/slUEgvnHr",
+	"vNKmoFlI3YBcucrnNpSj4kxsiFq1yIWiawYd+x/Rcgsf3VXpGZJqJGkP4ag09AnuNmFY9NkzINj/kOc8",
+	"vhtQT85cQdOrSbvtrKf4+afN69xGm49P41PLp2imrU4PSJCbGdweY3ZDM94vSkM3eK6rA4bl6jwfGTIP",
+	"sxdF56sZOuoFFyw2eBxQLRkZlGgLG9t29jjyuI0mnBxb5MJHRKHwnlaeRKRLEeOZMZsXNvFCi1Dyy2/O",
+	"zGCd/gbNNfz29tIxLPB+uMVOyU02LzlEtEHY/CVIQSyZBSjP/rzVpQFuDQmIyJHxumIpvlcsJt/+9vb5",
+	"I0e9kJGKbwBEqiTHzbsw1DUB8pBY01/4hQPO0r2ElomuD+22G3jZLGBL6GNFIknWQjI/dfqZIbJl0ssm",
+	"Bf24dDKE5+2FUy5yIKumYGOApPxMeZJL1klXXH6bwvGskjGrkqzU+BVkHshMElG9kiJfriBJqUeH3ICW",
+	"2IaJgZ+Br6q5rxIBo0A+8bpinrSdJSR0qz3E/2waeZD7gJblclmLS6oEAEWm2lUhc1kHJvM+ZX7211kY",
+	"pNNwqWyXdTcEqc7X4LzMK+tz0FiqMkG3vIxKvYGLDwzHXsxueGTt7+TcvgsoLJUzWw7OoI8lQo7be31+",
+	"0pscxprZd5fjuEZWL05fmYPxqaufuTh3T+ROMm/fF+eWTD8EXg0pnVni9UOmQuokEc2kgY0MWyFf6q+Z",
+	"+b5m5tvxnQukqQyzNGLx73ARG6n1+l+g3Se682Z72Ax3D4EgZYnxwPHXGIMKd6XyK9Q6CnBZ1pjeLRNS",
+	"f84Y01vowcLx8MOQ1doxdpSqv1kaBCWDze7rzNTneahCM2PmfOjU9j03xUwfrknTJio8eBGIj45URTkB",
+	"E9LpFv5RSiacfQysqk35kZHqowuvQYz0B/0iCJyv9dgphfMn+ng0zp/101C5rArgFqxyOHS5ydhdGLW8",
+	"ooExVAw0BfX6VNRl06Z6+pjHuy/LV07SAfaGatvb4Xh1tksNfLnJuq/T5b1TzrqpTuKHLeo3EhcN6qEt",
+	"0wAkl8nkcLLSOjvc309ERJOVUPrw7wd/O5jcvStAWt+OiXnYMzbHGLXBSS2yp9ybaTxpAsXh9sBxXPPA",
+	"SGZLYBtPQFMG6v+yn/nV/NjsSmOQXQtHvMC82GJy9+7u/w8Ac2s0f/ggAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -25,8 +25,11 @@ import (
 	ariesapi "github.com/hyperledger/aries-framework-go/spi/storage"
 	jsonld "github.com/piprate/json-gold/ld"
 
+	vcstls "github.com/trustbloc/vcs/internal/pkg/tls"
 	tlsutils "github.com/trustbloc/vcs/internal/pkg/utils/tls"
 	"github.com/trustbloc/vcs/pkg/ld"
+	metricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 )
 
 // mode in which to run the vc-rest service
@@ -46,6 +49,7 @@ type Configuration struct {
 	VDR               vdrapi.Registry
 	DocumentLoader    jsonld.DocumentLoader
 	LDContextStore    *ld.StoreProvider
+	ServerTLSConfig   *vcstls.ServerConfig
 	StartupParameters *startupParameters
 }
 
@@ -55,6 +59,11 @@ func prepareConfiguration(parameters *startupParameters) (*Configuration, error)
 		return nil, err
 	}
 
+	serverTLSConfig, err := createServerTLSConfig(parameters.tlsParameters)
+	if err != nil {
+		return nil, fmt.Errorf("server tls config: %w", err)
+	}
+
 	edgeStoreProviders, err := createEdgeStoreProviders(parameters)
 	if err != nil {
 		return nil, err
@@ -77,16 +86,44 @@ func prepareConfiguration(parameters *startupParameters) (*Configuration, error)
 		return nil, err
 	}
 
+	metrics, err := NewMetrics(parameters)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Configuration{
 		RootCAs:           rootCAs,
 		Storage:           edgeStoreProviders,
-		VDR:               vdr,
+		VDR:               tracing.WrapVDR(metricsProvider.WrapVDR(vdr, metrics)),
 		DocumentLoader:    loader,
 		LDContextStore:    ldStore,
+		ServerTLSConfig:   serverTLSConfig,
 		StartupParameters: parameters,
 	}, nil
 }
 
+// createServerTLSConfig loads server TLS configuration. Returns nil if server doesn't serve TLS.
+func createServerTLSConfig(params *tlsParameters) (*vcstls.ServerConfig, error) {
+	if params.serveCertPath == "" || params.serveKeyPath == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	clientAuth := tls.NoClientCert
+	if params.clientAuth != tlsClientAuthNone {
+		// Certificate requirement is enforced per route by client certificate authentication middleware,
+		// so that public routes like health check stay accessible without certificate.
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return vcstls.NewServerConfig(vcstls.ServerConfigParams{
+		CertFile:      params.serveCertPath,
+		KeyFile:       params.serveKeyPath,
+		ClientAuth:    clientAuth,
+		ClientCAFiles: params.clientCACerts,
+		DenyListFile:  params.clientDenyList,
+	})
+}
+
 type vcStorageProviders struct {
 	provider ariesapi.Provider
 }
@@ -122,9 +159,9 @@ func createVDRI(universalResolver string, tlsConfig *tls.Config) (vdrapi.Registr
 	if universalResolver != "" {
 		universalResolverVDRI, err := httpbinding.New(universalResolver,
 			httpbinding.WithAccept(acceptsDID), httpbinding.WithHTTPClient(&http.Client{
-				Transport: &http.Transport{
+				Transport: tracing.NewTransport(&http.Transport{
 					TLSClientConfig: tlsConfig,
-				},
+				}),
 			}))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create new universal resolver vdr: %w", err)
@@ -9,32 +9,42 @@ package startcmd
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ory/fosite"
 	"github.com/ory/fosite/compose"
 	fositeoauth2 "github.com/ory/fosite/handler/oauth2"
 	"github.com/ory/fosite/token/hmac"
+	"github.com/samber/lo"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/trustbloc/vcs/component/oidc/fositemongo"
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/service/clientregistration"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb"
 )
 
 func bootstrapOAuthProvider(
 	ctx context.Context,
 	secret string,
+	refreshTokenLifespan time.Duration,
 	mongoClient *mongodb.Client,
 	oauth2Clients []fositemongo.Client,
-) (fosite.OAuth2Provider, error) {
+) (fosite.OAuth2Provider, *fositemongo.Store, error) {
 	if len(secret) == 0 {
-		return nil, errors.New("invalid secret")
+		return nil, nil, errors.New("invalid secret")
 	}
 
 	config := new(fosite.Config)
 	config.GlobalSecret = []byte(secret)
 	config.AuthorizeCodeLifespan = 30 * time.Minute
 	config.AccessTokenLifespan = 30 * time.Minute
+	config.RefreshTokenLifespan = refreshTokenLifespan
+	// refresh tokens are issued to the clients allowed to use refresh_token grant, offline_access scope is not
+	// required as VCS does not grant scopes
+	config.RefreshTokenScopes = []string{}
 	config.SendDebugMessagesToClients = true // TODO: Disable before moving to production.
 
 	var hmacStrategy = &fositeoauth2.HMACSHAStrategy{
@@ -46,7 +56,7 @@ func bootstrapOAuthProvider(
 
 	store, err := fositemongo.NewStore(ctx, mongoClient)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, c := range oauth2Clients {
@@ -55,13 +65,142 @@ func bootstrapOAuthProvider(
 				continue
 			}
 
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	return compose.Compose(config, store, hmacStrategy,
 		compose.OAuth2AuthorizeExplicitFactory,
+		compose.OAuth2RefreshTokenGrantFactory,
 		compose.OAuth2PKCEFactory,
 		compose.PushedAuthorizeHandlerFactory,
-	), nil
+		compose.OAuth2TokenIntrospectionFactory,
+		compose.OAuth2TokenRevocationFactory,
+	), store, nil
+}
+
+type sessionStore interface {
+	DeleteExpiredSessions(ctx context.Context) (int64, error)
+}
+
+// startSessionCleanup deletes expired OAuth sessions with the given interval until the returned stop function
+// is called.
+func startSessionCleanup(store sessionStore, interval time.Duration) func() error {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+
+				if _, err := store.DeleteExpiredSessions(ctx); err != nil {
+					logger.Warn("Failed to delete expired oauth sessions", log.WithError(err))
+				}
+
+				cancel()
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() error {
+		once.Do(func() { close(stop) })
+
+		<-done
+
+		return nil
+	}
+}
+
+// clientRegistrationStore stores dynamically registered clients as fosite clients, so that they can use
+// VCS OAuth provider right after registration.
+type clientRegistrationStore struct {
+	store *fositemongo.Store
+}
+
+func (s *clientRegistrationStore) InsertClient(ctx context.Context, client *clientregistration.Client) error {
+	_, err := s.store.InsertClient(ctx, toFositeClient(client))
+
+	return err
+}
+
+func (s *clientRegistrationStore) GetClient(ctx context.Context, id string) (*clientregistration.Client, error) {
+	c, err := s.store.GetClient(ctx, id)
+	if err != nil {
+		if errors.Is(err, fositemongo.ErrDataNotFound) {
+			return nil, clientregistration.ErrDataNotFound
+		}
+
+		return nil, err
+	}
+
+	client, ok := c.(*fositemongo.Client)
+	if !ok {
+		return nil, fmt.Errorf("unexpected client type %T", c)
+	}
+
+	return &clientregistration.Client{
+		ID:                          client.ID,
+		SecretHash:                  client.Secret,
+		RegistrationAccessTokenHash: client.RegistrationAccessTokenHash,
+		RedirectURIs:                client.RedirectURIs,
+		GrantTypes:                  client.GrantTypes,
+		ResponseTypes:               client.ResponseTypes,
+		Scopes:                      client.Scopes,
+		TokenEndpointAuthMethod:     client.TokenEndpointAuthMethod,
+		Name:                        client.Name,
+		URI:                         client.URI,
+		Contacts:                    client.Contacts,
+		SoftwareID:                  client.SoftwareID,
+		SoftwareVersion:             client.SoftwareVersion,
+		CreatedAt:                   lo.FromPtr(client.CreatedAt),
+	}, nil
+}
+
+func (s *clientRegistrationStore) UpdateClient(ctx context.Context, client *clientregistration.Client) error {
+	err := s.store.UpdateClient(ctx, toFositeClient(client))
+	if errors.Is(err, fositemongo.ErrDataNotFound) {
+		return clientregistration.ErrDataNotFound
+	}
+
+	return err
+}
+
+func (s *clientRegistrationStore) DeleteClient(ctx context.Context, id string) error {
+	err := s.store.DeleteClient(ctx, id)
+	if errors.Is(err, fositemongo.ErrDataNotFound) {
+		return clientregistration.ErrDataNotFound
+	}
+
+	return err
+}
+
+func toFositeClient(client *clientregistration.Client) fositemongo.Client {
+	return fositemongo.Client{
+		ID:                          client.ID,
+		Secret:                      client.SecretHash,
+		RedirectURIs:                client.RedirectURIs,
+		GrantTypes:                  client.GrantTypes,
+		ResponseTypes:               client.ResponseTypes,
+		Scopes:                      client.Scopes,
+		Public:                      client.TokenEndpointAuthMethod == clientregistration.AuthMethodNone,
+		Name:                        client.Name,
+		URI:                         client.URI,
+		Contacts:                    client.Contacts,
+		TokenEndpointAuthMethod:     client.TokenEndpointAuthMethod,
+		SoftwareID:                  client.SoftwareID,
+		SoftwareVersion:             client.SoftwareVersion,
+		RegistrationAccessTokenHash: client.RegistrationAccessTokenHash,
+		CreatedAt:                   lo.ToPtr(client.CreatedAt),
+	}
 }
@@ -8,6 +8,7 @@ package startcmd
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/vcs/component/oidc/fositemongo"
+	"github.com/trustbloc/vcs/pkg/service/clientregistration"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb"
 )
 
@@ -41,7 +43,8 @@ func TestBoostrapOidc(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		provider, err := bootstrapOAuthProvider(context.TODO(), secret, client, []fositemongo.Client{oauthClient})
+		provider, _, err := bootstrapOAuthProvider(context.TODO(), secret, time.Hour, client,
+			[]fositemongo.Client{oauthClient})
 		assert.NoError(t, err)
 		assert.NotNil(t, provider)
 	})
@@ -52,14 +55,14 @@ func TestBoostrapOidc(t *testing.T) {
 			{ID: oauthClient.ID},
 		}
 
-		provider, err := bootstrapOAuthProvider(context.TODO(), secret, client, oauthClients)
+		provider, _, err := bootstrapOAuthProvider(context.TODO(), secret, time.Hour, client, oauthClients)
 		assert.NoError(t, err)
 		assert.NotNil(t, provider)
 	})
 }
 
 func TestBoostrapWithInvalidSecret(t *testing.T) {
-	provider, err := bootstrapOAuthProvider(context.TODO(), "", nil, []fositemongo.Client{})
+	provider, _, err := bootstrapOAuthProvider(context.TODO(), "", time.Hour, nil, []fositemongo.Client{})
 	assert.Nil(t, provider)
 	assert.ErrorContains(t, err, "invalid secret")
 }
@@ -78,8 +81,105 @@ func TestBoostrapOidcWithExpiredContext(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.TODO())
 	cancel()
 
-	provider, err := bootstrapOAuthProvider(ctx, secret, client, []fositemongo.Client{})
+	provider, _, err := bootstrapOAuthProvider(ctx, secret, time.Hour, client, []fositemongo.Client{})
 
 	assert.Nil(t, provider)
 	assert.ErrorContains(t, err, "context canceled")
 }
+
+func TestClientRegistrationStore(t *testing.T) {
+	pool, mongoDBResource := startMongoDBContainer(t)
+	defer func() {
+		require.NoError(t, pool.Purge(mongoDBResource), "failed to purge MongoDB resource")
+	}()
+
+	client, err := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
+	require.NoError(t, err)
+
+	provider, fositeStore, err := bootstrapOAuthProvider(context.TODO(), uuid.NewString(), time.Hour, client, nil)
+	require.NoError(t, err)
+	require.NotNil(t, provider)
+
+	store := &clientRegistrationStore{store: fositeStore}
+
+	registered := &clientregistration.Client{
+		ID:                          uuid.NewString(),
+		RegistrationAccessTokenHash: []byte("token-hash"),
+		RedirectURIs:                []string{"https://wallet.example.com/callback"},
+		GrantTypes:                  []string{"authorization_code"},
+		ResponseTypes:               []string{"code"},
+		Scopes:                      []string{"openid"},
+		TokenEndpointAuthMethod:     clientregistration.AuthMethodNone,
+		Name:                        "Wallet",
+		CreatedAt:                   time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	require.NoError(t, store.InsertClient(context.TODO(), registered))
+
+	fositeClient, err := fositeStore.GetClient(context.TODO(), registered.ID)
+	require.NoError(t, err)
+	require.True(t, fositeClient.IsPublic())
+
+	stored, err := store.GetClient(context.TODO(), registered.ID)
+	require.NoError(t, err)
+	require.Equal(t, registered, stored)
+
+	registered.Name = "Updated Wallet"
+	require.NoError(t, store.UpdateClient(context.TODO(), registered))
+
+	stored, err = store.GetClient(context.TODO(), registered.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Updated Wallet", stored.Name)
+
+	require.NoError(t, store.DeleteClient(context.TODO(), registered.ID))
+
+	_, err = store.GetClient(context.TODO(), registered.ID)
+	require.ErrorIs(t, err, clientregistration.ErrDataNotFound)
+	require.ErrorIs(t, store.UpdateClient(context.TODO(), registered), clientregistration.ErrDataNotFound)
+	require.ErrorIs(t, store.DeleteClient(context.TODO(), registered.ID), clientregistration.ErrDataNotFound)
+}
+
+type mockSessionStore struct {
+	calls chan struct{}
+	err   error
+}
+
+func (m *mockSessionStore) DeleteExpiredSessions(context.Context) (int64, error) {
+	select {
+	case m.calls <- struct{}{}:
+	default:
+	}
+
+	return 0, m.err
+}
+
+func TestSessionCleanup(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		store := &mockSessionStore{calls: make(chan struct{}, 1)}
+
+		stop := startSessionCleanup(store, 10*time.Millisecond)
+
+		select {
+		case <-store.calls:
+		case <-time.After(time.Second):
+			require.Fail(t, "expired sessions are not deleted")
+		}
+
+		require.NoError(t, stop())
+		require.NoError(t, stop())
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		store := &mockSessionStore{calls: make(chan struct{}, 1), err: errors.New("delete error")}
+
+		stop := startSessionCleanup(store, 10*time.Millisecond)
+
+		select {
+		case <-store.calls:
+		case <-time.After(time.Second):
+			require.Fail(t, "expired sessions are not deleted")
+		}
+
+		require.NoError(t, stop())
+	})
+}
@@ -18,7 +18,10 @@ import (
 	"github.com/trustbloc/vcs/internal/pkg/log"
 	cmdutils "github.com/trustbloc/vcs/internal/pkg/utils/cmd"
 	"github.com/trustbloc/vcs/pkg/kms"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 	profilereader "github.com/trustbloc/vcs/pkg/profile/reader"
+	"github.com/trustbloc/vcs/pkg/ratelimit"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
 )
 
 // kms params
@@ -141,6 +144,40 @@ const (
 	tlsKeyFlagUsage = "TLS key for vcs server. " + commonEnvVarUsageText + tlsKeyEnvKey
 	tlsKeyEnvKey    = "VC_REST_TLS_KEY"
 
+	tlsClientAuthFlagName  = "tls-client-auth"
+	tlsClientAuthEnvKey    = "VC_REST_TLS_CLIENT_AUTH"
+	tlsClientAuthFlagUsage = "TLS client certificate authentication of issuer and verifier APIs." +
+		" Supported values: none, optional (certificate is used if presented, other authentication methods" +
+		" are accepted otherwise), require. Defaults to none. Requires tls-certificate and tls-key. " +
+		commonEnvVarUsageText + tlsClientAuthEnvKey
+
+	tlsClientCACertsFlagName  = "tls-client-cacerts"
+	tlsClientCACertsEnvKey    = "VC_REST_TLS_CLIENT_CACERTS"
+	tlsClientCACertsFlagUsage = "Comma-Separated list of paths to CA certs used to verify TLS client certificates. " +
+		commonEnvVarUsageText + tlsClientCACertsEnvKey
+
+	tlsClientOrgIDSourceFlagName  = "tls-client-org-id-source"
+	tlsClientOrgIDSourceEnvKey    = "VC_REST_TLS_CLIENT_ORG_ID_SOURCE"
+	tlsClientOrgIDSourceFlagUsage = "Field of TLS client certificate organization ID is taken from." +
+		" Supported values: subject-cn, subject-o, san-dns, san-uri, san-email. Defaults to subject-cn. " +
+		commonEnvVarUsageText + tlsClientOrgIDSourceEnvKey
+
+	tlsClientDenyListFlagName  = "tls-client-denylist"
+	tlsClientDenyListEnvKey    = "VC_REST_TLS_CLIENT_DENYLIST"
+	tlsClientDenyListFlagUsage = "Path to a file with SHA-256 fingerprints (hex) of revoked TLS client certificates," +
+		" one per line. " + commonEnvVarUsageText + tlsClientDenyListEnvKey
+
+	tlsReloadIntervalFlagName  = "tls-reload-interval"
+	tlsReloadIntervalEnvKey    = "VC_REST_TLS_RELOAD_INTERVAL"
+	tlsReloadIntervalFlagUsage = "Interval of checking server certificate, client CA certs and deny list files" +
+		" for changes. Changed files are reloaded without restart. Set to 0 to disable. Defaults to 1m. " +
+		commonEnvVarUsageText + tlsReloadIntervalEnvKey
+	tlsReloadIntervalDefault = time.Minute
+
+	tlsClientAuthNone     = "none"
+	tlsClientAuthOptional = "optional"
+	tlsClientAuthRequire  = "require"
+
 	tokenFlagName  = "api-token"
 	tokenEnvKey    = "VC_REST_API_TOKEN" //nolint: gosec
 	tokenFlagUsage = "Check for bearer token in the authorization header (optional). " +
@@ -161,11 +198,263 @@ const (
 	oAuthClientsFilePathFlagUsage = "Path to file with oauth clients. " +
 		commonEnvVarUsageText + oAuthClientsFilePathEnvKey
 
+	oAuthRefreshTokenLifespanFlagName  = "oauth-refresh-token-lifespan"
+	oAuthRefreshTokenLifespanEnvKey    = "VC_OAUTH_REFRESH_TOKEN_LIFESPAN" //nolint: gosec
+	oAuthRefreshTokenLifespanFlagUsage = "Lifespan of refresh tokens issued to the clients allowed to use " +
+		"refresh_token grant, for example 24h. Defaults to 24h. " +
+		commonEnvVarUsageText + oAuthRefreshTokenLifespanEnvKey
+
+	oAuthRefreshTokenLifespanDefault = 24 * time.Hour
+
+	oAuthSessionCleanupIntervalFlagName  = "oauth-session-cleanup-interval"
+	oAuthSessionCleanupIntervalEnvKey    = "VC_OAUTH_SESSION_CLEANUP_INTERVAL"
+	oAuthSessionCleanupIntervalFlagUsage = "Interval of background cleanup of expired OAuth sessions, for example " +
+		"10m. Defaults to 10m. Set to 0s to rely on MongoDB TTL indexes only. " +
+		commonEnvVarUsageText + oAuthSessionCleanupIntervalEnvKey
+
+	oAuthSessionCleanupIntervalDefault = 10 * time.Minute
+
 	metricsProviderFlagName         = "metrics-provider-name"
 	metricsProviderEnvKey           = "VC_METRICS_PROVIDER_NAME"
 	allowedMetricsProviderFlagUsage = "The metrics provider name (for example: 'prometheus' etc.). " +
 		commonEnvVarUsageText + metricsProviderEnvKey
 
+	claimsEncryptionKeyPathFlagName  = "claims-encryption-key-path"
+	claimsEncryptionKeyPathEnvKey    = "VC_REST_CLAIMS_ENCRYPTION_KEY_PATH"
+	claimsEncryptionKeyPathFlagUsage = "Path to the file with base64 encoded AES key used to encrypt claims " +
+		"received during oidc4vp interaction before they are stored. If not set, claims are stored unencrypted. " +
+		commonEnvVarUsageText + claimsEncryptionKeyPathEnvKey
+
+	auditAnchorKeyPathFlagName  = "audit-anchor-key-path"
+	auditAnchorKeyPathEnvKey    = "VC_REST_AUDIT_ANCHOR_KEY_PATH"
+	auditAnchorKeyPathFlagUsage = "Path to the file with base64 encoded key used to sign anchors of the last " +
+		"audit log entries, so removal of the last entries is detected by audit log verification. " +
+		"If not set, anchors are not stored. " + commonEnvVarUsageText + auditAnchorKeyPathEnvKey
+
+	authTokenIssuerFlagName  = "auth-token-issuer"
+	authTokenIssuerEnvKey    = "VC_REST_AUTH_TOKEN_ISSUER"
+	authTokenIssuerFlagUsage = "Issuer of JWT access tokens accepted in the authorization header. " +
+		"If set, requests are authenticated with bearer tokens and organization is taken from the token. " +
+		commonEnvVarUsageText + authTokenIssuerEnvKey
+
+	authTokenJWKSURLFlagName  = "auth-token-jwks-url"
+	authTokenJWKSURLEnvKey    = "VC_REST_AUTH_TOKEN_JWKS_URL"
+	authTokenJWKSURLFlagUsage = "URL of JWKS used to verify JWT access tokens. " +
+		"If not set, it is discovered from token issuer OpenID configuration. " +
+		commonEnvVarUsageText + authTokenJWKSURLEnvKey
+
+	authTokenAudienceFlagName  = "auth-token-audience"
+	authTokenAudienceEnvKey    = "VC_REST_AUTH_TOKEN_AUDIENCE"
+	authTokenAudienceFlagUsage = "Expected audience of JWT access tokens (optional). " +
+		commonEnvVarUsageText + authTokenAudienceEnvKey
+
+	authTokenOrgClaimFlagName  = "auth-token-org-claim"
+	authTokenOrgClaimEnvKey    = "VC_REST_AUTH_TOKEN_ORG_CLAIM"
+	authTokenOrgClaimFlagUsage = "Access token claim that contains organization ID. Defaults to org_id. " +
+		commonEnvVarUsageText + authTokenOrgClaimEnvKey
+
+	authTokenIntrospectionURLFlagName  = "auth-token-introspection-url"
+	authTokenIntrospectionURLEnvKey    = "VC_REST_AUTH_TOKEN_INTROSPECTION_URL"
+	authTokenIntrospectionURLFlagUsage = "Token introspection endpoint used to validate opaque access tokens. " +
+		"Takes precedence over JWT validation. " + commonEnvVarUsageText + authTokenIntrospectionURLEnvKey
+
+	authTokenIntrospectionClientIDFlagName  = "auth-token-introspection-client-id"
+	authTokenIntrospectionClientIDEnvKey    = "VC_REST_AUTH_TOKEN_INTROSPECTION_CLIENT_ID"
+	authTokenIntrospectionClientIDFlagUsage = "Client ID used to authenticate at token introspection endpoint. " +
+		commonEnvVarUsageText + authTokenIntrospectionClientIDEnvKey
+
+	authTokenIntrospectionClientSecretFlagName  = "auth-token-introspection-client-secret"
+	authTokenIntrospectionClientSecretEnvKey    = "VC_REST_AUTH_TOKEN_INTROSPECTION_CLIENT_SECRET" //nolint: gosec
+	authTokenIntrospectionClientSecretFlagUsage = "Client secret used to authenticate at token introspection " +
+		"endpoint. " + commonEnvVarUsageText + authTokenIntrospectionClientSecretEnvKey
+
+	rateLimitStoreFlagName  = "rate-limit-store"
+	rateLimitStoreEnvKey    = "VC_REST_RATE_LIMIT_STORE"
+	rateLimitStoreFlagUsage = "Storage of rate limit counters. Supported options: memory, mongodb. Counters " +
+		"in memory are local to VCS instance, use mongodb to share them between replicas. Defaults to memory. " +
+		commonEnvVarUsageText + rateLimitStoreEnvKey
+
+	rateLimitClientDefaultFlagName  = "rate-limit-client-default"
+	rateLimitClientDefaultEnvKey    = "VC_REST_RATE_LIMIT_CLIENT_DEFAULT"
+	rateLimitClientDefaultFlagUsage = "Default rate limit of a client (organization or IP address of " +
+		"unauthenticated caller). Format: <requests per second>[:<burst>]. If not set, clients are not limited. " +
+		commonEnvVarUsageText + rateLimitClientDefaultEnvKey
+
+	rateLimitIPFlagName  = "rate-limit-ip"
+	rateLimitIPEnvKey    = "VC_REST_RATE_LIMIT_IP"
+	rateLimitIPFlagUsage = "Rate limit of requests from an IP address. The limit is checked before " +
+		"authentication, so it applies to requests with invalid credentials as well. " +
+		"Format: <requests per second>[:<burst>]. If not set, IP addresses are not limited. " +
+		commonEnvVarUsageText + rateLimitIPEnvKey
+
+	rateLimitClientsFlagName  = "rate-limit-clients"
+	rateLimitClientsEnvKey    = "VC_REST_RATE_LIMIT_CLIENTS"
+	rateLimitClientsFlagUsage = "Rate limits of particular clients. Format: " +
+		"<organization ID>=<requests per second>[:<burst>]. Organization ID is taken from the access token, " +
+		"callers authenticated with API key are identified as \"api-key\". " +
+		"Multiple values can be separated by comma. " + commonEnvVarUsageText + rateLimitClientsEnvKey
+
+	rateLimitStoreMemoryOption = "memory"
+
+	tracingProviderFlagName  = "tracing-provider"
+	tracingProviderEnvKey    = "VC_REST_TRACING_PROVIDER"
+	tracingProviderFlagUsage = "Exporter of OpenTelemetry spans. Supported options: otlp-grpc, otlp-http. " +
+		"If not set, spans are not exported. " + commonEnvVarUsageText + tracingProviderEnvKey
+
+	tracingCollectorURLFlagName  = "tracing-collector-url"
+	tracingCollectorURLEnvKey    = "VC_REST_TRACING_COLLECTOR_URL"
+	tracingCollectorURLFlagUsage = "Host and port of OTLP collector. If not set, standard " +
+		"OTEL_EXPORTER_OTLP_ENDPOINT environment variable is used. " + commonEnvVarUsageText +
+		tracingCollectorURLEnvKey
+
+	tracingServiceNameFlagName  = "tracing-service-name"
+	tracingServiceNameEnvKey    = "VC_REST_TRACING_SERVICE_NAME"
+	tracingServiceNameFlagUsage = "Service name reported with spans. Defaults to vcs. " +
+		commonEnvVarUsageText + tracingServiceNameEnvKey
+
+	tracingSampleRateFlagName  = "tracing-sample-rate"
+	tracingSampleRateEnvKey    = "VC_REST_TRACING_SAMPLE_RATE"
+	tracingSampleRateFlagUsage = "Fraction of traces that are sampled, from 0 to 1. Defaults to 1. " +
+		commonEnvVarUsageText + tracingSampleRateEnvKey
+
+	tracingServiceNameDefault = "vcs"
+
+	shutdownTimeoutFlagName  = "shutdown-timeout"
+	shutdownTimeoutEnvKey    = "VC_REST_SHUTDOWN_TIMEOUT"
+	shutdownTimeoutFlagUsage = "Maximum time to wait for in-flight requests and pending event deliveries " +
+		"on shutdown, for example 30s. The timeout starts after the shutdown drain delay. Defaults to 30s. " +
+		commonEnvVarUsageText + shutdownTimeoutEnvKey
+
+	shutdownDrainDelayFlagName  = "shutdown-drain-delay"
+	shutdownDrainDelayEnvKey    = "VC_REST_SHUTDOWN_DRAIN_DELAY"
+	shutdownDrainDelayFlagUsage = "Time between receiving a termination signal and closing the listener. " +
+		"During this time the server keeps serving requests, but readiness check reports the server as " +
+		"draining, so that load balancers stop routing new requests to it. Defaults to 0s. " +
+		commonEnvVarUsageText + shutdownDrainDelayEnvKey
+
+	shutdownTimeoutDefault = 30 * time.Second
+
+	credentialOfferTTLFlagName  = "credential-offer-ttl"
+	credentialOfferTTLEnvKey    = "VC_REST_CREDENTIAL_OFFER_TTL"
+	credentialOfferTTLFlagUsage = "Time after which credential offer passed to the wallet by reference expires, " +
+		"for example 15m. Defaults to 15m. " + commonEnvVarUsageText + credentialOfferTTLEnvKey
+
+	credentialOfferTTLDefault = 15 * time.Minute
+
+	wellKnownCacheTTLFlagName  = "wellknown-cache-ttl"
+	wellKnownCacheTTLEnvKey    = "VC_REST_WELLKNOWN_CACHE_TTL"
+	wellKnownCacheTTLFlagUsage = "Time OIDC configuration fetched from well-known endpoints is cached for, " +
+		"for example 5m. Lower Cache-Control max-age returned by the provider takes precedence. " +
+		"Defaults to 5m. " + commonEnvVarUsageText + wellKnownCacheTTLEnvKey
+
+	wellKnownCacheTTLDefault = 5 * time.Minute
+
+	wellKnownRefreshIntervalFlagName  = "wellknown-refresh-interval"
+	wellKnownRefreshIntervalEnvKey    = "VC_REST_WELLKNOWN_REFRESH_INTERVAL"
+	wellKnownRefreshIntervalFlagUsage = "Interval of background refresh of the cached OIDC configurations, " +
+		"for example 1m. Defaults to 1m. Set to 0s to disable background refresh. " +
+		commonEnvVarUsageText + wellKnownRefreshIntervalEnvKey
+
+	wellKnownRefreshIntervalDefault = time.Minute
+
+	dpopRequiredFlagName  = "dpop-required"
+	dpopRequiredEnvKey    = "VC_REST_DPOP_REQUIRED"
+	dpopRequiredFlagUsage = "Requires wallets to bind access tokens to their keys with DPoP proofs (RFC 9449). " +
+		"If false, DPoP is used only if the wallet sends DPoP proof to the token endpoint. Defaults to false. " +
+		commonEnvVarUsageText + dpopRequiredEnvKey
+
+	dpopNonceTTLFlagName  = "dpop-nonce-ttl"
+	dpopNonceTTLEnvKey    = "VC_REST_DPOP_NONCE_TTL"
+	dpopNonceTTLFlagUsage = "Time DPoP nonce issued by the server is valid for, for example 5m. Defaults to 5m. " +
+		commonEnvVarUsageText + dpopNonceTTLEnvKey
+
+	dpopNonceTTLDefault = 5 * time.Minute
+
+	clientRegistrationInitialAccessTokenFlagName  = "client-registration-initial-access-token"
+	clientRegistrationInitialAccessTokenEnvKey    = "VC_REST_CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN" //nolint: gosec
+	clientRegistrationInitialAccessTokenFlagUsage = "Initial access token wallets present to register OAuth " +
+		"clients dynamically (RFC 7591). Registration is disabled unless either initial access token is set or " +
+		"software statement is required. " +
+		commonEnvVarUsageText + clientRegistrationInitialAccessTokenEnvKey
+
+	clientRegistrationTrustedIssuersFlagName  = "client-registration-trusted-issuers"
+	clientRegistrationTrustedIssuersEnvKey    = "VC_REST_CLIENT_REGISTRATION_TRUSTED_ISSUERS"
+	clientRegistrationTrustedIssuersFlagUsage = "Comma-separated list of DIDs of issuers whose software " +
+		"statements are accepted on dynamic client registration. " +
+		commonEnvVarUsageText + clientRegistrationTrustedIssuersEnvKey
+
+	clientRegistrationStatementRequiredFlagName  = "client-registration-software-statement-required"
+	clientRegistrationStatementRequiredEnvKey    = "VC_REST_CLIENT_REGISTRATION_SOFTWARE_STATEMENT_REQUIRED"
+	clientRegistrationStatementRequiredFlagUsage = "Requires wallets to present software statement signed by " +
+		"a trusted issuer on dynamic client registration. Defaults to false. " +
+		commonEnvVarUsageText + clientRegistrationStatementRequiredEnvKey
+
+	clientRegistrationAllowedScopesFlagName  = "client-registration-allowed-scopes"
+	clientRegistrationAllowedScopesEnvKey    = "VC_REST_CLIENT_REGISTRATION_ALLOWED_SCOPES"
+	clientRegistrationAllowedScopesFlagUsage = "Comma-separated list of scopes dynamically registered clients " +
+		"are allowed to use. Defaults to openid. " +
+		commonEnvVarUsageText + clientRegistrationAllowedScopesEnvKey
+
+	secretProviderFlagName  = "secret-provider"
+	secretProviderEnvKey    = "VC_REST_SECRET_PROVIDER"
+	secretProviderFlagUsage = "Provider of secrets referred by handles in profiles, e.g. OIDC client secret. " +
+		"Supported options: file, env, aws, vault. If not set, secret handles are not resolved. " +
+		commonEnvVarUsageText + secretProviderEnvKey
+
+	secretFileDirFlagName  = "secret-file-dir"
+	secretFileDirEnvKey    = "VC_REST_SECRET_FILE_DIR"
+	secretFileDirFlagUsage = "Directory with secret files for file secret provider, one file per secret named " +
+		"by its handle. " + commonEnvVarUsageText + secretFileDirEnvKey
+
+	secretEnvPrefixFlagName  = "secret-env-prefix"
+	secretEnvPrefixEnvKey    = "VC_REST_SECRET_ENV_PREFIX"
+	secretEnvPrefixFlagUsage = "Prefix of environment variables for env secret provider. Variable name is the " +
+		"prefix followed by the upper-cased handle with characters other than letters and digits replaced by " +
+		"underscore. Defaults to VC_REST_SECRET_. " + commonEnvVarUsageText + secretEnvPrefixEnvKey
+
+	secretEnvPrefixDefault = "VC_REST_SECRET_"
+
+	secretAWSRegionFlagName  = "secret-aws-region"
+	secretAWSRegionEnvKey    = "VC_REST_SECRET_AWS_REGION"
+	secretAWSRegionFlagUsage = "Region of AWS Secrets Manager for aws secret provider. " +
+		commonEnvVarUsageText + secretAWSRegionEnvKey
+
+	secretAWSEndpointFlagName  = "secret-aws-endpoint"
+	secretAWSEndpointEnvKey    = "VC_REST_SECRET_AWS_ENDPOINT"
+	secretAWSEndpointFlagUsage = "Endpoint of AWS Secrets Manager for aws secret provider. If not set, " +
+		"default endpoint of the region is used. " + commonEnvVarUsageText + secretAWSEndpointEnvKey
+
+	secretVaultURLFlagName  = "secret-vault-url"
+	secretVaultURLEnvKey    = "VC_REST_SECRET_VAULT_URL"
+	secretVaultURLFlagUsage = "URL of HashiCorp Vault server for vault secret provider. " +
+		commonEnvVarUsageText + secretVaultURLEnvKey
+
+	secretVaultTokenFlagName  = "secret-vault-token"
+	secretVaultTokenEnvKey    = "VC_REST_SECRET_VAULT_TOKEN" //nolint: gosec
+	secretVaultTokenFlagUsage = "Token used to authenticate at Vault server. " +
+		commonEnvVarUsageText + secretVaultTokenEnvKey
+
+	secretVaultMountPathFlagName  = "secret-vault-mount-path"
+	secretVaultMountPathEnvKey    = "VC_REST_SECRET_VAULT_MOUNT_PATH"
+	secretVaultMountPathFlagUsage = "Mount path of Vault KV version 2 secrets engine. Defaults to secret. " +
+		commonEnvVarUsageText + secretVaultMountPathEnvKey
+
+	secretVaultKeyFlagName  = "secret-vault-key"
+	secretVaultKeyEnvKey    = "VC_REST_SECRET_VAULT_KEY"
+	secretVaultKeyFlagUsage = "Key of Vault secret data field holding the secret value. Defaults to value. " +
+		commonEnvVarUsageText + secretVaultKeyEnvKey
+
+	secretCacheTTLFlagName  = "secret-cache-ttl"
+	secretCacheTTLEnvKey    = "VC_REST_SECRET_CACHE_TTL"
+	secretCacheTTLFlagUsage = "Time resolved secrets are cached for, for example 5m. Rotated secret is picked up " +
+		"when cached value expires. Defaults to 0s, secrets are resolved on every use. " +
+		commonEnvVarUsageText + secretCacheTTLEnvKey
+
+	secretProviderFileOption  = "file"
+	secretProviderEnvOption   = "env"
+	secretProviderAWSOption   = "aws"
+	secretProviderVaultOption = "vault"
+
 	promHttpUrlFlagName             = "prom-http-url"
 	promHttpUrlEnvKey               = "VC_PROM_HTTP_URL"
 	allowedPromHttpUrlFlagNameUsage = "URL that exposes the prometheus metrics endpoint. Format: HostName:Port. "
@@ -200,8 +489,69 @@ type startupParameters struct {
 	devMode                         bool
 	oAuthSecret                     string
 	oAuthClientsFilePath            string
+	oAuthRefreshTokenLifespan       time.Duration
+	oAuthSessionCleanupInterval     time.Duration
 	metricsProviderName             string
 	prometheusMetricsProviderParams *prometheusMetricsProviderParams
+	claimsEncryptionKeyPath         string
+	auditAnchorKeyPath              string
+	authTokenParameters             *authTokenParameters
+	rateLimitParameters             *rateLimitParameters
+	tracingParameters               *tracing.Config
+	shutdownParameters              *shutdownParameters
+	credentialOfferTTL              time.Duration
+	secretParameters                *secretParameters
+	wellKnownCacheTTL               time.Duration
+	wellKnownRefreshInterval        time.Duration
+	dpopParameters                  *dpopParameters
+	clientRegistrationParameters    *clientRegistrationParameters
+}
+
+type clientRegistrationParameters struct {
+	initialAccessToken        string
+	trustedIssuers            []string
+	softwareStatementRequired bool
+	allowedScopes             []string
+}
+
+type dpopParameters struct {
+	required bool
+	nonceTTL time.Duration
+}
+
+type secretParameters struct {
+	provider       string
+	fileDir        string
+	envPrefix      string
+	awsRegion      string
+	awsEndpoint    string
+	vaultURL       string
+	vaultToken     string
+	vaultMountPath string
+	vaultKey       string
+	cacheTTL       time.Duration
+}
+
+type shutdownParameters struct {
+	timeout    time.Duration
+	drainDelay time.Duration
+}
+
+type authTokenParameters struct {
+	issuer                    string
+	jwksURL                   string
+	audience                  string
+	orgClaim                  string
+	introspectionURL          string
+	introspectionClientID     string
+	introspectionClientSecret string
+}
+
+type rateLimitParameters struct {
+	store              string
+	defaultClientLimit *ratelimit.Limit
+	clientLimits       map[string]ratelimit.Limit
+	ipLimit            *ratelimit.Limit
 }
 
 type prometheusMetricsProviderParams struct {
@@ -215,10 +565,15 @@ type dbParameters struct {
 }
 
 type tlsParameters struct {
-	systemCertPool bool
-	caCerts        []string
-	serveCertPath  string
-	serveKeyPath   string
+	systemCertPool    bool
+	caCerts           []string
+	serveCertPath     string
+	serveKeyPath      string
+	clientAuth        string
+	clientCACerts     []string
+	clientOrgIDSource mw.CertOrgIDSource
+	clientDenyList    string
+	reloadInterval    time.Duration
 }
 
 type kmsParameters struct {
@@ -331,6 +686,72 @@ func getStartupParameters(cmd *cobra.Command) (*startupParameters, error) {
 		return nil, err
 	}
 
+	oAuthRefreshTokenLifespan, err := getDuration(cmd, oAuthRefreshTokenLifespanFlagName,
+		oAuthRefreshTokenLifespanEnvKey, oAuthRefreshTokenLifespanDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oauth refresh token lifespan: %w", err)
+	}
+
+	oAuthSessionCleanupInterval, err := getDuration(cmd, oAuthSessionCleanupIntervalFlagName,
+		oAuthSessionCleanupIntervalEnvKey, oAuthSessionCleanupIntervalDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oauth session cleanup interval: %w", err)
+	}
+
+	claimsEncryptionKeyPath := cmdutils.GetUserSetOptionalVarFromString(cmd, claimsEncryptionKeyPathFlagName,
+		claimsEncryptionKeyPathEnvKey)
+
+	auditAnchorKeyPath := cmdutils.GetUserSetOptionalVarFromString(cmd, auditAnchorKeyPathFlagName,
+		auditAnchorKeyPathEnvKey)
+
+	rateLimitParams, err := getRateLimitParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	tracingParams, err := getTracingParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownParams, err := getShutdownParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialOfferTTL, err := getDuration(cmd, credentialOfferTTLFlagName, credentialOfferTTLEnvKey,
+		credentialOfferTTLDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential offer ttl: %w", err)
+	}
+
+	secretParams, err := getSecretParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	wellKnownCacheTTL, err := getDuration(cmd, wellKnownCacheTTLFlagName, wellKnownCacheTTLEnvKey,
+		wellKnownCacheTTLDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid well-known cache ttl: %w", err)
+	}
+
+	wellKnownRefreshInterval, err := getDuration(cmd, wellKnownRefreshIntervalFlagName,
+		wellKnownRefreshIntervalEnvKey, wellKnownRefreshIntervalDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid well-known refresh interval: %w", err)
+	}
+
+	dpopParams, err := getDPoPParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	clientRegistrationParams, err := getClientRegistrationParameters(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	return &startupParameters{
 		hostURL:                         hostURL,
 		hostURLExternal:                 hostURLExternal,
@@ -347,11 +768,256 @@ func getStartupParameters(cmd *cobra.Command) (*startupParameters, error) {
 		devMode:                         devMode,
 		oAuthSecret:                     oAuthSecret,
 		oAuthClientsFilePath:            oAuthClientsFilePath,
+		oAuthRefreshTokenLifespan:       oAuthRefreshTokenLifespan,
+		oAuthSessionCleanupInterval:     oAuthSessionCleanupInterval,
 		metricsProviderName:             metricsProviderName,
 		prometheusMetricsProviderParams: prometheusMetricsProviderParams,
+		claimsEncryptionKeyPath:         claimsEncryptionKeyPath,
+		auditAnchorKeyPath:              auditAnchorKeyPath,
+		authTokenParameters:             getAuthTokenParameters(cmd),
+		rateLimitParameters:             rateLimitParams,
+		tracingParameters:               tracingParams,
+		shutdownParameters:              shutdownParams,
+		credentialOfferTTL:              credentialOfferTTL,
+		secretParameters:                secretParams,
+		wellKnownCacheTTL:               wellKnownCacheTTL,
+		wellKnownRefreshInterval:        wellKnownRefreshInterval,
+		dpopParameters:                  dpopParams,
+		clientRegistrationParameters:    clientRegistrationParams,
 	}, nil
 }
 
+func getDPoPParameters(cmd *cobra.Command) (*dpopParameters, error) {
+	params := &dpopParameters{}
+
+	if required := cmdutils.GetUserSetOptionalVarFromString(cmd, dpopRequiredFlagName,
+		dpopRequiredEnvKey); required != "" {
+		var err error
+
+		params.required, err = strconv.ParseBool(required)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dpop required: %w", err)
+		}
+	}
+
+	nonceTTL, err := getDuration(cmd, dpopNonceTTLFlagName, dpopNonceTTLEnvKey, dpopNonceTTLDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dpop nonce ttl: %w", err)
+	}
+
+	params.nonceTTL = nonceTTL
+
+	return params, nil
+}
+
+func getClientRegistrationParameters(cmd *cobra.Command) (*clientRegistrationParameters, error) {
+	params := &clientRegistrationParameters{
+		initialAccessToken: cmdutils.GetUserSetOptionalVarFromString(cmd,
+			clientRegistrationInitialAccessTokenFlagName, clientRegistrationInitialAccessTokenEnvKey),
+		trustedIssuers: cmdutils.GetUserSetOptionalCSVVar(cmd, clientRegistrationTrustedIssuersFlagName,
+			clientRegistrationTrustedIssuersEnvKey),
+		allowedScopes: cmdutils.GetUserSetOptionalCSVVar(cmd, clientRegistrationAllowedScopesFlagName,
+			clientRegistrationAllowedScopesEnvKey),
+	}
+
+	if required := cmdutils.GetUserSetOptionalVarFromString(cmd, clientRegistrationStatementRequiredFlagName,
+		clientRegistrationStatementRequiredEnvKey); required != "" {
+		var err error
+
+		params.softwareStatementRequired, err = strconv.ParseBool(required)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client registration software statement required: %w", err)
+		}
+	}
+
+	return params, nil
+}
+
+func getSecretParameters(cmd *cobra.Command) (*secretParameters, error) {
+	params := &secretParameters{
+		provider:    cmdutils.GetUserSetOptionalVarFromString(cmd, secretProviderFlagName, secretProviderEnvKey),
+		fileDir:     cmdutils.GetUserSetOptionalVarFromString(cmd, secretFileDirFlagName, secretFileDirEnvKey),
+		envPrefix:   cmdutils.GetUserSetOptionalVarFromString(cmd, secretEnvPrefixFlagName, secretEnvPrefixEnvKey),
+		awsRegion:   cmdutils.GetUserSetOptionalVarFromString(cmd, secretAWSRegionFlagName, secretAWSRegionEnvKey),
+		awsEndpoint: cmdutils.GetUserSetOptionalVarFromString(cmd, secretAWSEndpointFlagName, secretAWSEndpointEnvKey),
+		vaultURL:    cmdutils.GetUserSetOptionalVarFromString(cmd, secretVaultURLFlagName, secretVaultURLEnvKey),
+		vaultToken:  cmdutils.GetUserSetOptionalVarFromString(cmd, secretVaultTokenFlagName, secretVaultTokenEnvKey),
+		vaultMountPath: cmdutils.GetUserSetOptionalVarFromString(cmd, secretVaultMountPathFlagName,
+			secretVaultMountPathEnvKey),
+		vaultKey: cmdutils.GetUserSetOptionalVarFromString(cmd, secretVaultKeyFlagName, secretVaultKeyEnvKey),
+	}
+
+	switch params.provider {
+	case "", secretProviderEnvOption, secretProviderAWSOption:
+	case secretProviderFileOption:
+		if params.fileDir == "" {
+			return nil, fmt.Errorf("%s is required for file secret provider", secretFileDirFlagName)
+		}
+	case secretProviderVaultOption:
+		if params.vaultURL == "" {
+			return nil, fmt.Errorf("%s is required for vault secret provider", secretVaultURLFlagName)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported secret provider: %s", params.provider)
+	}
+
+	if params.envPrefix == "" {
+		params.envPrefix = secretEnvPrefixDefault
+	}
+
+	cacheTTL, err := getDuration(cmd, secretCacheTTLFlagName, secretCacheTTLEnvKey, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret cache ttl: %w", err)
+	}
+
+	params.cacheTTL = cacheTTL
+
+	return params, nil
+}
+
+func getShutdownParameters(cmd *cobra.Command) (*shutdownParameters, error) {
+	timeout, err := getDuration(cmd, shutdownTimeoutFlagName, shutdownTimeoutEnvKey, shutdownTimeoutDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shutdown timeout: %w", err)
+	}
+
+	drainDelay, err := getDuration(cmd, shutdownDrainDelayFlagName, shutdownDrainDelayEnvKey, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shutdown drain delay: %w", err)
+	}
+
+	return &shutdownParameters{
+		timeout:    timeout,
+		drainDelay: drainDelay,
+	}, nil
+}
+
+func getTracingParameters(cmd *cobra.Command) (*tracing.Config, error) {
+	params := &tracing.Config{
+		Provider: tracing.ProviderType(
+			cmdutils.GetUserSetOptionalVarFromString(cmd, tracingProviderFlagName, tracingProviderEnvKey)),
+		CollectorURL: cmdutils.GetUserSetOptionalVarFromString(cmd, tracingCollectorURLFlagName,
+			tracingCollectorURLEnvKey),
+		ServiceName: cmdutils.GetUserSetOptionalVarFromString(cmd, tracingServiceNameFlagName,
+			tracingServiceNameEnvKey),
+	}
+
+	switch params.Provider {
+	case tracing.ProviderNone, tracing.ProviderOTLPGRPC, tracing.ProviderOTLPHTTP:
+	default:
+		return nil, fmt.Errorf("unsupported tracing provider: %s", params.Provider)
+	}
+
+	if params.ServiceName == "" {
+		params.ServiceName = tracingServiceNameDefault
+	}
+
+	if sampleRate := cmdutils.GetUserSetOptionalVarFromString(cmd, tracingSampleRateFlagName,
+		tracingSampleRateEnvKey); sampleRate != "" {
+		rate, err := strconv.ParseFloat(sampleRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid tracing sample rate: %s", sampleRate)
+		}
+
+		params.SampleRate = rate
+	}
+
+	return params, nil
+}
+
+func getRateLimitParameters(cmd *cobra.Command) (*rateLimitParameters, error) {
+	params := &rateLimitParameters{
+		store:        cmdutils.GetUserSetOptionalVarFromString(cmd, rateLimitStoreFlagName, rateLimitStoreEnvKey),
+		clientLimits: make(map[string]ratelimit.Limit),
+	}
+
+	switch params.store {
+	case "":
+		params.store = rateLimitStoreMemoryOption
+	case rateLimitStoreMemoryOption, databaseTypeMongoDBOption:
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store: %s", params.store)
+	}
+
+	if defaultLimit := cmdutils.GetUserSetOptionalVarFromString(cmd, rateLimitClientDefaultFlagName,
+		rateLimitClientDefaultEnvKey); defaultLimit != "" {
+		limit, err := parseRateLimit(defaultLimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default client rate limit: %w", err)
+		}
+
+		params.defaultClientLimit = limit
+	}
+
+	if ipLimit := cmdutils.GetUserSetOptionalVarFromString(cmd, rateLimitIPFlagName,
+		rateLimitIPEnvKey); ipLimit != "" {
+		limit, err := parseRateLimit(ipLimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP rate limit: %w", err)
+		}
+
+		params.ipLimit = limit
+	}
+
+	for _, clientLimit := range cmdutils.GetUserSetOptionalCSVVar(cmd, rateLimitClientsFlagName,
+		rateLimitClientsEnvKey) {
+		clientID, value, found := strings.Cut(clientLimit, "=")
+		if !found || clientID == "" {
+			return nil, fmt.Errorf("invalid client rate limit: %s", clientLimit)
+		}
+
+		limit, err := parseRateLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit of client %s: %w", clientID, err)
+		}
+
+		params.clientLimits[clientID] = *limit
+	}
+
+	return params, nil
+}
+
+// parseRateLimit parses rate limit in <requests per second>[:<burst>] format.
+func parseRateLimit(value string) (*ratelimit.Limit, error) {
+	rps, burst, hasBurst := strings.Cut(value, ":")
+
+	limit := &ratelimit.Limit{}
+
+	var err error
+
+	limit.RequestsPerSecond, err = strconv.ParseFloat(rps, 64)
+	if err != nil || limit.RequestsPerSecond <= 0 {
+		return nil, fmt.Errorf("requests per second must be a positive number: %s", rps)
+	}
+
+	if hasBurst {
+		limit.Burst, err = strconv.Atoi(burst)
+		if err != nil || limit.Burst <= 0 {
+			return nil, fmt.Errorf("burst must be a positive integer: %s", burst)
+		}
+	}
+
+	return limit, nil
+}
+
+func getAuthTokenParameters(cmd *cobra.Command) *authTokenParameters {
+	return &authTokenParameters{
+		issuer: cmdutils.GetUserSetOptionalVarFromString(cmd, authTokenIssuerFlagName, authTokenIssuerEnvKey),
+		jwksURL: cmdutils.GetUserSetOptionalVarFromString(cmd, authTokenJWKSURLFlagName,
+			authTokenJWKSURLEnvKey),
+		audience: cmdutils.GetUserSetOptionalVarFromString(cmd, authTokenAudienceFlagName,
+			authTokenAudienceEnvKey),
+		orgClaim: cmdutils.GetUserSetOptionalVarFromString(cmd, authTokenOrgClaimFlagName,
+			authTokenOrgClaimEnvKey),
+		introspectionURL: cmdutils.GetUserSetOptionalVarFromString(cmd, authTokenIntrospectionURLFlagName,
+			authTokenIntrospectionURLEnvKey),
+		introspectionClientID: cmdutils.GetUserSetOptionalVarFromString(cmd,
+			authTokenIntrospectionClientIDFlagName, authTokenIntrospectionClientIDEnvKey),
+		introspectionClientSecret: cmdutils.GetUserSetOptionalVarFromString(cmd,
+			authTokenIntrospectionClientSecretFlagName, authTokenIntrospectionClientSecretEnvKey),
+	}
+}
+
 func getMetricsProviderName(cmd *cobra.Command) (string, error) {
 	metricsProvider, err := cmdutils.GetUserSetVarFromString(cmd, metricsProviderFlagName, metricsProviderEnvKey, true)
 	if err != nil {
@@ -429,12 +1095,69 @@ func getTLS(cmd *cobra.Command) (*tlsParameters, error) {
 
 	tlsServeKeyPath := cmdutils.GetUserSetOptionalVarFromString(cmd, tlsKeyFlagName, tlsKeyEnvKey)
 
-	return &tlsParameters{
+	reloadInterval, err := getDuration(cmd, tlsReloadIntervalFlagName, tlsReloadIntervalEnvKey,
+		tlsReloadIntervalDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tls reload interval: %w", err)
+	}
+
+	params := &tlsParameters{
 		systemCertPool: tlsSystemCertPool,
 		caCerts:        tlsCACerts,
 		serveCertPath:  tlsServeCertPath,
 		serveKeyPath:   tlsServeKeyPath,
-	}, nil
+		clientAuth:     tlsClientAuthNone,
+		reloadInterval: reloadInterval,
+	}
+
+	if err = getTLSClientAuth(cmd, params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+func getTLSClientAuth(cmd *cobra.Command, params *tlsParameters) error {
+	if clientAuth := cmdutils.GetUserSetOptionalVarFromString(cmd, tlsClientAuthFlagName,
+		tlsClientAuthEnvKey); clientAuth != "" {
+		params.clientAuth = clientAuth
+	}
+
+	switch params.clientAuth {
+	case tlsClientAuthNone:
+		return nil
+	case tlsClientAuthOptional, tlsClientAuthRequire:
+	default:
+		return fmt.Errorf("unsupported tls client auth: %s", params.clientAuth)
+	}
+
+	if params.serveCertPath == "" || params.serveKeyPath == "" {
+		return fmt.Errorf("tls client auth requires %s and %s", tlsCertificateFlagName, tlsKeyFlagName)
+	}
+
+	params.clientCACerts = cmdutils.GetUserSetOptionalVarFromArrayString(cmd, tlsClientCACertsFlagName,
+		tlsClientCACertsEnvKey)
+	if len(params.clientCACerts) == 0 {
+		return fmt.Errorf("tls client auth requires %s", tlsClientCACertsFlagName)
+	}
+
+	orgIDSource := cmdutils.GetUserSetOptionalVarFromString(cmd, tlsClientOrgIDSourceFlagName,
+		tlsClientOrgIDSourceEnvKey)
+	if orgIDSource == "" {
+		orgIDSource = string(mw.CertOrgIDSubjectCN)
+	}
+
+	var err error
+
+	params.clientOrgIDSource, err = mw.ParseCertOrgIDSource(orgIDSource)
+	if err != nil {
+		return err
+	}
+
+	params.clientDenyList = cmdutils.GetUserSetOptionalVarFromString(cmd, tlsClientDenyListFlagName,
+		tlsClientDenyListEnvKey)
+
+	return nil
 }
 
 func getKMSParameters(cmd *cobra.Command) (*kmsParameters, error) {
@@ -576,8 +1299,59 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().String(kmsRegionFlagName, "", kmsRegionFlagUsage)
 	startCmd.Flags().StringP(tlsCertificateFlagName, "", "", tlsCertificateFlagUsage)
 	startCmd.Flags().StringP(tlsKeyFlagName, "", "", tlsKeyFlagUsage)
+	startCmd.Flags().StringP(tlsClientAuthFlagName, "", "", tlsClientAuthFlagUsage)
+	startCmd.Flags().StringSliceP(tlsClientCACertsFlagName, "", []string{}, tlsClientCACertsFlagUsage)
+	startCmd.Flags().StringP(tlsClientOrgIDSourceFlagName, "", "", tlsClientOrgIDSourceFlagUsage)
+	startCmd.Flags().StringP(tlsClientDenyListFlagName, "", "", tlsClientDenyListFlagUsage)
+	startCmd.Flags().StringP(tlsReloadIntervalFlagName, "", "", tlsReloadIntervalFlagUsage)
 	startCmd.Flags().StringP(metricsProviderFlagName, "", "", allowedMetricsProviderFlagUsage)
 	startCmd.Flags().StringP(promHttpUrlFlagName, "", "", allowedPromHttpUrlFlagNameUsage)
 	startCmd.Flags().StringP(oAuthClientsFilePathFlagName, "", "", oAuthClientsFilePathFlagUsage)
+	startCmd.Flags().StringP(oAuthRefreshTokenLifespanFlagName, "", "", oAuthRefreshTokenLifespanFlagUsage)
+	startCmd.Flags().StringP(oAuthSessionCleanupIntervalFlagName, "", "", oAuthSessionCleanupIntervalFlagUsage)
+	startCmd.Flags().StringP(claimsEncryptionKeyPathFlagName, "", "", claimsEncryptionKeyPathFlagUsage)
+	startCmd.Flags().StringP(auditAnchorKeyPathFlagName, "", "", auditAnchorKeyPathFlagUsage)
+	startCmd.Flags().StringP(authTokenIssuerFlagName, "", "", authTokenIssuerFlagUsage)
+	startCmd.Flags().StringP(authTokenJWKSURLFlagName, "", "", authTokenJWKSURLFlagUsage)
+	startCmd.Flags().StringP(authTokenAudienceFlagName, "", "", authTokenAudienceFlagUsage)
+	startCmd.Flags().StringP(authTokenOrgClaimFlagName, "", "", authTokenOrgClaimFlagUsage)
+	startCmd.Flags().StringP(authTokenIntrospectionURLFlagName, "", "", authTokenIntrospectionURLFlagUsage)
+	startCmd.Flags().StringP(authTokenIntrospectionClientIDFlagName, "", "",
+		authTokenIntrospectionClientIDFlagUsage)
+	startCmd.Flags().StringP(authTokenIntrospectionClientSecretFlagName, "", "",
+		authTokenIntrospectionClientSecretFlagUsage)
+	startCmd.Flags().StringP(rateLimitStoreFlagName, "", "", rateLimitStoreFlagUsage)
+	startCmd.Flags().StringP(rateLimitClientDefaultFlagName, "", "", rateLimitClientDefaultFlagUsage)
+	startCmd.Flags().StringP(rateLimitIPFlagName, "", "", rateLimitIPFlagUsage)
+	startCmd.Flags().StringSliceP(rateLimitClientsFlagName, "", []string{}, rateLimitClientsFlagUsage)
+	startCmd.Flags().StringP(tracingProviderFlagName, "", "", tracingProviderFlagUsage)
+	startCmd.Flags().StringP(tracingCollectorURLFlagName, "", "", tracingCollectorURLFlagUsage)
+	startCmd.Flags().StringP(tracingServiceNameFlagName, "", "", tracingServiceNameFlagUsage)
+	startCmd.Flags().StringP(tracingSampleRateFlagName, "", "", tracingSampleRateFlagUsage)
+	startCmd.Flags().StringP(shutdownTimeoutFlagName, "", "", shutdownTimeoutFlagUsage)
+	startCmd.Flags().StringP(shutdownDrainDelayFlagName, "", "", shutdownDrainDelayFlagUsage)
+	startCmd.Flags().StringP(credentialOfferTTLFlagName, "", "", credentialOfferTTLFlagUsage)
+	startCmd.Flags().StringP(secretProviderFlagName, "", "", secretProviderFlagUsage)
+	startCmd.Flags().StringP(secretFileDirFlagName, "", "", secretFileDirFlagUsage)
+	startCmd.Flags().StringP(secretEnvPrefixFlagName, "", "", secretEnvPrefixFlagUsage)
+	startCmd.Flags().StringP(secretAWSRegionFlagName, "", "", secretAWSRegionFlagUsage)
+	startCmd.Flags().StringP(secretAWSEndpointFlagName, "", "", secretAWSEndpointFlagUsage)
+	startCmd.Flags().StringP(secretVaultURLFlagName, "", "", secretVaultURLFlagUsage)
+	startCmd.Flags().StringP(secretVaultTokenFlagName, "", "", secretVaultTokenFlagUsage)
+	startCmd.Flags().StringP(secretVaultMountPathFlagName, "", "", secretVaultMountPathFlagUsage)
+	startCmd.Flags().StringP(secretVaultKeyFlagName, "", "", secretVaultKeyFlagUsage)
+	startCmd.Flags().StringP(secretCacheTTLFlagName, "", "", secretCacheTTLFlagUsage)
+	startCmd.Flags().StringP(wellKnownCacheTTLFlagName, "", "", wellKnownCacheTTLFlagUsage)
+	startCmd.Flags().StringP(wellKnownRefreshIntervalFlagName, "", "", wellKnownRefreshIntervalFlagUsage)
+	startCmd.Flags().StringP(dpopRequiredFlagName, "", "", dpopRequiredFlagUsage)
+	startCmd.Flags().StringP(dpopNonceTTLFlagName, "", "", dpopNonceTTLFlagUsage)
+	startCmd.Flags().StringP(clientRegistrationInitialAccessTokenFlagName, "", "",
+		clientRegistrationInitialAccessTokenFlagUsage)
+	startCmd.Flags().StringSliceP(clientRegistrationTrustedIssuersFlagName, "", []string{},
+		clientRegistrationTrustedIssuersFlagUsage)
+	startCmd.Flags().StringP(clientRegistrationStatementRequiredFlagName, "", "",
+		clientRegistrationStatementRequiredFlagUsage)
+	startCmd.Flags().StringSliceP(clientRegistrationAllowedScopesFlagName, "", []string{},
+		clientRegistrationAllowedScopesFlagUsage)
 	profilereader.AddFlags(startCmd)
 }
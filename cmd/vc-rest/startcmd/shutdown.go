@@ -0,0 +1,161 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+)
+
+type resourceCloser struct {
+	name  string
+	close func() error
+}
+
+// shutdownManager coordinates graceful shutdown of the server. It holds the draining state reported by the
+// readiness check and the resources that are closed after in-flight requests are drained.
+type shutdownManager struct {
+	draining atomic.Bool
+	mutex    sync.Mutex
+	closers  []resourceCloser
+}
+
+func newShutdownManager() *shutdownManager {
+	return &shutdownManager{}
+}
+
+// IsDraining returns true once shutdown has started.
+func (m *shutdownManager) IsDraining() bool {
+	return m.draining.Load()
+}
+
+// register adds a resource that is closed on shutdown.
+func (m *shutdownManager) register(name string, close func() error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.closers = append(m.closers, resourceCloser{name: name, close: close})
+}
+
+// shutdown marks the server as draining and, after drainDelay, stops accepting requests and waits for
+// in-flight requests. Registered resources are closed afterwards. The timeout applies to waiting for
+// in-flight requests and closing resources and starts after drainDelay, so that a long drain delay doesn't
+// leave no time for the shutdown itself.
+func (m *shutdownManager) shutdown(server httpServer, drainDelay, timeout time.Duration) error {
+	m.draining.Store(true)
+
+	if drainDelay > 0 {
+		logger.Info("Draining vc-rest server before shutdown", log.WithDuration(drainDelay))
+
+		time.Sleep(drainDelay)
+	}
+
+	logger.Info("Shutting down vc-rest server", log.WithDuration(timeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	drainErr := server.Shutdown(ctx)
+	if drainErr != nil {
+		// Resources are closed anyway, requests that are still in-flight will fail.
+		logger.Warn("Failed to drain in-flight requests", log.WithError(drainErr))
+	}
+
+	if err := m.closeAll(ctx); err != nil {
+		return err
+	}
+
+	if drainErr != nil {
+		return fmt.Errorf("drain in-flight requests: %w", drainErr)
+	}
+
+	return nil
+}
+
+// closeAll closes registered resources in reverse order of registration, so that each resource is closed
+// before the resources it was created from. Returns when all resources are closed or ctx is done.
+func (m *shutdownManager) closeAll(ctx context.Context) error {
+	m.draining.Store(true)
+
+	m.mutex.Lock()
+	closers := m.closers
+	m.closers = nil
+	m.mutex.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := len(closers) - 1; i >= 0; i-- {
+			logger.Debug("Closing resource", log.WithName(closers[i].name))
+
+			if err := closers[i].close(); err != nil {
+				logger.Warn("Failed to close resource", log.WithName(closers[i].name), log.WithError(err))
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("close resources: %w", ctx.Err())
+	}
+}
+
+// serve runs the server until it fails or ctx is done, then shuts it down gracefully.
+func serve(ctx context.Context, conf *Configuration, server httpServer, m *shutdownManager) error {
+	serverErr := make(chan error, 1)
+
+	go func() {
+		if conf.StartupParameters.tlsParameters.serveKeyPath != "" &&
+			conf.StartupParameters.tlsParameters.serveCertPath != "" {
+			serverErr <- server.ListenAndServeTLS(conf.StartupParameters.tlsParameters.serveCertPath,
+				conf.StartupParameters.tlsParameters.serveKeyPath)
+
+			return
+		}
+
+		serverErr <- server.ListenAndServe()
+	}()
+
+	shutdownParams := conf.StartupParameters.shutdownParameters
+
+	select {
+	case err := <-serverErr:
+		// Server has stopped on its own, so there is nothing to drain.
+		closeCtx, cancel := context.WithTimeout(context.Background(), shutdownParams.timeout)
+		defer cancel()
+
+		if errClose := m.closeAll(closeCtx); errClose != nil {
+			logger.Warn("Failed to close resources", log.WithError(errClose))
+		}
+
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	case <-ctx.Done():
+	}
+
+	if err := m.shutdown(server, shutdownParams.drainDelay, shutdownParams.timeout); err != nil {
+		return fmt.Errorf("graceful shutdown: %w", err)
+	}
+
+	logger.Info("vc-rest server stopped")
+
+	return nil
+}
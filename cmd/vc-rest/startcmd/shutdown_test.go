@@ -0,0 +1,230 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startcmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type blockingServer struct {
+	stopped     chan struct{}
+	stopOnce    sync.Once
+	shutdownErr error
+	// shutdownCtxErr is the error of the shutdown context at the time Shutdown is called.
+	shutdownCtxErr error
+}
+
+func newBlockingServer() *blockingServer {
+	return &blockingServer{stopped: make(chan struct{})}
+}
+
+func (s *blockingServer) ListenAndServe() error {
+	<-s.stopped
+
+	return http.ErrServerClosed
+}
+
+func (s *blockingServer) ListenAndServeTLS(string, string) error {
+	return s.ListenAndServe()
+}
+
+func (s *blockingServer) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		s.shutdownCtxErr = ctx.Err()
+		close(s.stopped)
+	})
+
+	return s.shutdownErr
+}
+
+func newShutdownTestConfiguration(drainDelay time.Duration) *Configuration {
+	return newShutdownTestConfigurationWithTimeout(drainDelay, 5*time.Second)
+}
+
+func newShutdownTestConfigurationWithTimeout(drainDelay, timeout time.Duration) *Configuration {
+	return &Configuration{
+		StartupParameters: &startupParameters{
+			tlsParameters: &tlsParameters{},
+			shutdownParameters: &shutdownParameters{
+				timeout:    timeout,
+				drainDelay: drainDelay,
+			},
+		},
+	}
+}
+
+func TestShutdownManager_CloseAll(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := newShutdownManager()
+
+		var closed []string
+
+		m.register("first", func() error {
+			closed = append(closed, "first")
+
+			return nil
+		})
+		m.register("second", func() error {
+			closed = append(closed, "second")
+
+			return errors.New("close error")
+		})
+		m.register("third", func() error {
+			closed = append(closed, "third")
+
+			return nil
+		})
+
+		require.False(t, m.IsDraining())
+		require.NoError(t, m.closeAll(context.Background()))
+		require.True(t, m.IsDraining())
+
+		// Resources are closed in reverse order and close error doesn't stop closing of other resources.
+		require.Equal(t, []string{"third", "second", "first"}, closed)
+
+		// Resources are closed only once.
+		require.NoError(t, m.closeAll(context.Background()))
+		require.Len(t, closed, 3)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		m := newShutdownManager()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		m.register("slow", func() error {
+			<-release
+
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, m.closeAll(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Run("Graceful shutdown", func(t *testing.T) {
+		m := newShutdownManager()
+
+		var closed []string
+
+		m.register("mongodb", func() error {
+			closed = append(closed, "mongodb")
+
+			return nil
+		})
+		m.register("event-bus", func() error {
+			closed = append(closed, "event-bus")
+
+			return nil
+		})
+
+		server := newBlockingServer()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			errCh <- serve(ctx, newShutdownTestConfiguration(0), server, m)
+		}()
+
+		cancel()
+
+		require.NoError(t, <-errCh)
+		require.True(t, m.IsDraining())
+		require.Equal(t, []string{"event-bus", "mongodb"}, closed)
+	})
+
+	t.Run("Draining is reported before listener is closed", func(t *testing.T) {
+		m := newShutdownManager()
+		server := newBlockingServer()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			errCh <- serve(ctx, newShutdownTestConfiguration(200*time.Millisecond), server, m)
+		}()
+
+		cancel()
+
+		require.Eventually(t, m.IsDraining, time.Second, 10*time.Millisecond)
+
+		select {
+		case <-server.stopped:
+			require.Fail(t, "server stopped before drain delay has passed")
+		default:
+		}
+
+		require.NoError(t, <-errCh)
+	})
+
+	t.Run("Drain delay doesn't use shutdown timeout", func(t *testing.T) {
+		m := newShutdownManager()
+		server := newBlockingServer()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		conf := newShutdownTestConfigurationWithTimeout(200*time.Millisecond, 100*time.Millisecond)
+
+		require.NoError(t, serve(ctx, conf, server, m))
+		require.NoError(t, server.shutdownCtxErr)
+	})
+
+	t.Run("Drain error", func(t *testing.T) {
+		m := newShutdownManager()
+
+		server := newBlockingServer()
+		server.shutdownErr = errors.New("shutdown error")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.ErrorContains(t, serve(ctx, newShutdownTestConfiguration(0), server, m), "shutdown error")
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		m := newShutdownManager()
+
+		closed := false
+
+		m.register("mongodb", func() error {
+			closed = true
+
+			return nil
+		})
+
+		err := serve(context.Background(), newShutdownTestConfiguration(0),
+			&failingServer{err: errors.New("listen error")}, m)
+
+		require.EqualError(t, err, "listen error")
+		require.True(t, closed)
+	})
+}
+
+type failingServer struct {
+	mockServer
+	err error
+}
+
+func (s *failingServer) ListenAndServe() error {
+	return s.err
+}
@@ -10,14 +10,22 @@ import (
 	"context"
 	"crypto/subtle"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	oapimw "github.com/deepmap/oapi-codegen/pkg/middleware"
 	"github.com/deepmap/oapi-codegen/pkg/securityprovider"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/labstack/echo/v4"
 	echomw "github.com/labstack/echo/v4/middleware"
@@ -28,19 +36,29 @@ import (
 	"github.com/trustbloc/vcs/component/oidc/fositemongo"
 	"github.com/trustbloc/vcs/component/oidc/vp"
 	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/accesstoken"
+	"github.com/trustbloc/vcs/pkg/audit"
+	"github.com/trustbloc/vcs/pkg/dataprotect"
 	"github.com/trustbloc/vcs/pkg/doc/vc/crypto"
+	"github.com/trustbloc/vcs/pkg/dpop"
 	"github.com/trustbloc/vcs/pkg/kms"
+	"github.com/trustbloc/vcs/pkg/observability/health"
 	metricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics"
 	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
 	promMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/prometheus"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 	profilereader "github.com/trustbloc/vcs/pkg/profile/reader"
+	"github.com/trustbloc/vcs/pkg/ratelimit"
 	"github.com/trustbloc/vcs/pkg/restapi/resterr"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/admin"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/devapi"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/healthcheck"
 	issuerv1 "github.com/trustbloc/vcs/pkg/restapi/v1/issuer"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
 	oidc4vc2 "github.com/trustbloc/vcs/pkg/restapi/v1/oidc4vc"
 	verifierv1 "github.com/trustbloc/vcs/pkg/restapi/v1/verifier"
+	"github.com/trustbloc/vcs/pkg/secret"
+	"github.com/trustbloc/vcs/pkg/service/clientregistration"
 	"github.com/trustbloc/vcs/pkg/service/credentialstatus"
 	"github.com/trustbloc/vcs/pkg/service/didconfiguration"
 	"github.com/trustbloc/vcs/pkg/service/issuecredential"
@@ -51,11 +69,15 @@ import (
 	"github.com/trustbloc/vcs/pkg/service/verifypresentation"
 	"github.com/trustbloc/vcs/pkg/service/wellknown"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb/auditstore"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb/credentialofferstore"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/cslstore"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb/dpopstore"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/oidc4vcstatestore"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/oidc4vcstore"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/oidc4vptxstore"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/oidcnoncestore"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb/ratelimitstore"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/requestobjectstore"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/vcstore"
 )
@@ -66,11 +88,16 @@ const (
 	cslSize              = 1000
 )
 
+// clientCertScopes are scopes granted to clients authenticated with TLS client certificate. Admin APIs require
+// access token.
+var clientCertScopes = []string{"issuer:issue", "issuer:status", "verifier:verify"}
+
 var logger = log.New("vc-rest")
 
 type httpServer interface {
 	ListenAndServe() error
 	ListenAndServeTLS(certFile, keyFile string) error
+	Shutdown(ctx context.Context) error
 }
 
 type startOpts struct {
@@ -120,16 +147,39 @@ func createStartCmd(opts ...StartOpts) *cobra.Command {
 				return fmt.Errorf("failed to prepare configuration: %w", err)
 			}
 
+			shutdownTracing, err := tracing.Initialize(params.tracingParameters)
+			if err != nil {
+				return fmt.Errorf("failed to initialize tracing: %w", err)
+			}
+
+			defer func() {
+				if errShutdown := shutdownTracing(context.Background()); errShutdown != nil {
+					logger.Warn("failed to shutdown tracing", log.WithError(errShutdown))
+				}
+			}()
+
+			shutdown := newShutdownManager()
+			shutdown.register("storage", conf.Storage.provider.Close)
+
+			if conf.ServerTLSConfig != nil {
+				conf.ServerTLSConfig.Watch(params.tlsParameters.reloadInterval)
+				shutdown.register("tls-config-watcher", conf.ServerTLSConfig.Close)
+			}
+
 			var e *echo.Echo
 
-			e, err = buildEchoHandler(conf, cmd)
+			e, err = buildEchoHandler(conf, cmd, shutdown)
 			if err != nil {
+				if errClose := shutdown.closeAll(context.Background()); errClose != nil {
+					logger.Warn("failed to close resources", log.WithError(errClose))
+				}
+
 				return fmt.Errorf("failed to build echo handler: %w", err)
 			}
 
 			opts = append(opts, WithHTTPHandler(e))
 
-			return startServer(conf, opts...)
+			return startServer(conf, shutdown, opts...)
 		},
 	}
 }
@@ -143,22 +193,27 @@ func createEcho() *echo.Echo {
 	e.Use(echomw.Logger())
 	e.Use(echomw.Recover())
 	e.Use(echomw.CORS())
+	e.Use(echomw.RequestID())
 
 	return e
 }
 
 // buildEchoHandler builds an HTTP handler based on Echo web framework (https://echo.labstack.com).
-func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, error) {
+func buildEchoHandler(conf *Configuration, cmd *cobra.Command, shutdown *shutdownManager) (*echo.Echo, error) {
 	e := createEcho()
 	eMetrics := createEcho()
 
+	e.Use(mw.Tracing())
+	e.Use(mw.CorrelationID())
+
 	metrics, err := NewMetrics(conf.StartupParameters)
 	if err != nil {
 		return nil, err
 	}
 
-	if conf.StartupParameters.token != "" {
-		e.Use(mw.APIKeyAuth(conf.StartupParameters.token))
+	tokenValidator, err := createAccessTokenValidator(conf.StartupParameters.authTokenParameters, conf.RootCAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access token validator: %w", err)
 	}
 
 	swagger, err := spec.GetSwagger()
@@ -166,13 +221,44 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 		return nil, fmt.Errorf("failed to get openapi spec: %w", err)
 	}
 
+	routes := mw.NewRouteRegistry(swagger)
+
+	mongodbClient, err := mongodb.New(conf.StartupParameters.dbParameters.databaseURL,
+		conf.StartupParameters.dbParameters.databasePrefix+"vcs",
+		15*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mongodb client: %w", err)
+	}
+
+	shutdown.register("mongodb", mongodbClient.Close)
+
+	rateLimitStore, err := createRateLimitStore(conf.StartupParameters.rateLimitParameters, mongodbClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rate limit store: %w", err)
+	}
+
+	// Requests are limited per IP address before authentication, so that requests with invalid credentials
+	// are limited as well. Limits of authenticated clients are checked after authentication.
+	e.Use(mw.RateLimitByIP(&mw.RateLimitConfig{
+		Store:   rateLimitStore,
+		IPLimit: conf.StartupParameters.rateLimitParameters.ipLimit,
+		Metrics: metrics,
+	}))
+
+	if auth := createAuthMiddleware(conf, tokenValidator, routes); auth != nil {
+		e.Use(auth)
+	}
+
 	swagger.Servers = nil // skip validating server names matching
 
-	e.Use(oapimw.OapiRequestValidator(swagger))
+	e.Use(oapimw.OapiRequestValidatorWithOptions(swagger, &oapimw.Options{
+		Options: openapi3filter.Options{
+			// Security requirements are enforced by authentication middleware.
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}))
 
 	// Handlers
-	healthcheck.RegisterHandlers(e, &healthcheck.Controller{})
-
 	tlsConfig := &tls.Config{RootCAs: conf.RootCAs, MinVersion: tls.VersionTLS12}
 
 	defaultVCSKeyManager, err := kms.NewAriesKeyManager(&kms.Config{
@@ -189,24 +275,23 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 		return nil, fmt.Errorf("failed to create default kms: %w", err)
 	}
 
-	kmsRegistry := kms.NewRegistry(defaultVCSKeyManager)
+	shutdown.register("kms", defaultVCSKeyManager.Close)
 
-	mongodbClient, err := mongodb.New(conf.StartupParameters.dbParameters.databaseURL,
-		conf.StartupParameters.dbParameters.databasePrefix+"vcs",
-		15*time.Second)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create mongodb client: %w", err)
-	}
+	kmsRegistry := kms.NewRegistry(defaultVCSKeyManager)
 
 	// Create event service
 	eventSvc, err := event.Initialize(event.Config{
 		TLSConfig: tlsConfig,
 		CMD:       cmd,
+		Metrics:   metrics,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Event bus is closed before MongoDB and KMS, so that pending events are delivered first.
+	shutdown.register("event-bus", eventSvc.Close)
+
 	// Issuer Profile Management API
 	issuerProfileSvc, err := profilereader.NewIssuerReader(&profilereader.Config{
 		TLSConfig:   tlsConfig,
@@ -238,16 +323,45 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 
 	httpClient := &http.Client{
 		Timeout: time.Minute,
-		Transport: &http.Transport{
+		Transport: tracing.NewTransport(&http.Transport{
 			TLSClientConfig: tlsConfig,
-		},
+		}),
+	}
+
+	healthcheck.RegisterHandlers(e, healthcheck.NewController(&healthcheck.Config{
+		HealthChecker: health.NewChecker(&health.Config{
+			Checks: createHealthChecks(conf, mongodbClient, kmsRegistry, eventSvc, httpClient, shutdown),
+		}),
+	}))
+
+	credentialOfferStore, err := credentialofferstore.New(context.Background(), mongodbClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate credential offer store: %w", err)
+	}
+
+	secretProvider, err := createSecretProvider(conf.StartupParameters.secretParameters, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret provider: %w", err)
 	}
 
+	wellKnownService := wellknown.NewService(httpClient,
+		wellknown.WithCacheTTL(conf.StartupParameters.wellKnownCacheTTL),
+		wellknown.WithRefreshInterval(conf.StartupParameters.wellKnownRefreshInterval),
+	)
+
+	shutdown.register("wellknown-service", wellKnownService.Close)
+
 	oidc4vcService, err := oidc4vc.NewService(&oidc4vc.Config{
-		TransactionStore:    oidc4vcStore,
-		IssuerVCSPublicHost: conf.StartupParameters.hostURL,
-		WellKnownService:    wellknown.NewService(httpClient),
-		OAuth2ClientFactory: oidc4vc.NewOAuth2ClientFactory(),
+		TransactionStore:        oidc4vcStore,
+		IssuerVCSPublicHost:     conf.StartupParameters.hostURL,
+		WellKnownService:        wellKnownService,
+		OAuth2ClientFactory:     oidc4vc.NewOAuth2ClientFactory(),
+		CredentialOfferStore:    credentialOfferStore,
+		CredentialOfferEndpoint: conf.StartupParameters.hostURLExternal + "/issuer/credential-offers/",
+		CredentialOfferTTL:      conf.StartupParameters.credentialOfferTTL,
+		HTTPClient:              httpClient,
+		SecretProvider:          secretProvider,
+		Metrics:                 metrics,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to instantiate new oidc4 vc service: %w", err)
@@ -284,9 +398,10 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 		}
 	}
 
-	provider, err := bootstrapOAuthProvider(
+	provider, fositeStore, err := bootstrapOAuthProvider(
 		context.Background(),
 		conf.StartupParameters.oAuthSecret,
+		conf.StartupParameters.oAuthRefreshTokenLifespan,
 		mongodbClient,
 		oauth2Clients,
 	)
@@ -294,13 +409,66 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 		return nil, fmt.Errorf("failed to instantiate new oauth provider: %w", err)
 	}
 
+	if conf.StartupParameters.oAuthSessionCleanupInterval > 0 {
+		shutdown.register("oauth-session-cleanup",
+			startSessionCleanup(fositeStore, conf.StartupParameters.oAuthSessionCleanupInterval))
+	}
+
+	dpopStore, err := dpopstore.New(context.Background(), mongodbClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate dpop store: %w", err)
+	}
+
+	// nonces are signed with OAuth secret shared by all instances, so that any instance accepts the nonce
+	dpopVerifier, err := dpop.NewVerifier(&dpop.Config{
+		Store:       dpopStore,
+		NonceSecret: []byte(conf.StartupParameters.oAuthSecret),
+		NonceTTL:    conf.StartupParameters.dpopParameters.nonceTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate dpop verifier: %w", err)
+	}
+
+	jwtVerifier := jwt.NewVerifier(jwt.KeyResolverFunc(verifiable.NewVDRKeyResolver(conf.VDR).PublicKeyFetcher()))
+	registrationParams := conf.StartupParameters.clientRegistrationParameters
+
 	oidc4vc2.RegisterHandlers(e, oidc4vc2.NewController(&oidc4vc2.Config{
 		OAuth2Provider:          provider,
 		StateStore:              oidc4StateStore,
 		IssuerInteractionClient: issuerInteractionClient,
 		IssuerVCSPublicHost:     conf.StartupParameters.hostURLExternal,
+		JWTVerifier:             jwtVerifier,
+		DPoPVerifier:            dpopVerifier,
+		DPoPRequired:            conf.StartupParameters.dpopParameters.required,
+		ClientRegistrationService: clientregistration.New(&clientregistration.Config{
+			Store:                           &clientRegistrationStore{store: fositeStore},
+			InitialAccessToken:              registrationParams.initialAccessToken,
+			SoftwareStatementVerifier:       jwtVerifier,
+			TrustedSoftwareStatementIssuers: registrationParams.trustedIssuers,
+			SoftwareStatementRequired:       registrationParams.softwareStatementRequired,
+			AllowedScopes:                   registrationParams.allowedScopes,
+		}),
 	}))
 
+	auditStore, err := auditstore.New(context.Background(), mongodbClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate audit store: %w", err)
+	}
+
+	var auditAnchorKey []byte
+
+	if conf.StartupParameters.auditAnchorKeyPath != "" {
+		auditAnchorKey, err = dataprotect.ReadKeyFile(conf.StartupParameters.auditAnchorKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit anchor key: %w", err)
+		}
+	}
+
+	auditSvc := audit.NewService(&audit.Config{
+		Store:     auditStore,
+		AnchorKey: auditAnchorKey,
+	})
+
 	issuerv1.RegisterHandlers(e, issuerv1.NewController(&issuerv1.Config{
 		EventSvc:               eventSvc,
 		ProfileSvc:             issuerProfileSvc,
@@ -309,6 +477,13 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 		IssueCredentialService: issueCredentialSvc,
 		VcStatusManager:        vcStatusManager,
 		OIDC4VCService:         oidc4vcService,
+		AuditLog:               auditSvc,
+		Metrics:                metrics,
+		ExternalHostURL:        conf.StartupParameters.hostURLExternal,
+	}))
+
+	admin.RegisterHandlers(e, admin.NewController(&admin.Config{
+		AuditService: auditSvc,
 	}))
 
 	// Verifier Profile Management API
@@ -322,6 +497,17 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 		return nil, err
 	}
 
+	e.Use(mw.RateLimit(&mw.RateLimitConfig{
+		Store: rateLimitStore,
+		ProfileLimits: &profileRateLimits{
+			issuerProfileSvc:   issuerProfileSvc,
+			verifierProfileSvc: verifierProfileSvc,
+		},
+		ClientLimits:       conf.StartupParameters.rateLimitParameters.clientLimits,
+		DefaultClientLimit: conf.StartupParameters.rateLimitParameters.defaultClientLimit,
+		Metrics:            metrics,
+	}))
+
 	revocationListGetterSvc := revocation.New(&revocation.Config{
 		VDR:            conf.VDR,
 		TLSConfig:      tlsConfig,
@@ -333,13 +519,30 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 		RevocationVCGetter: revocationListGetterSvc,
 		DocumentLoader:     conf.DocumentLoader,
 		VDR:                conf.VDR,
+		Metrics:            metrics,
 	})
 	verifyPresentationSvc := verifypresentation.New(&verifypresentation.Config{
 		VcVerifier:     verifyCredentialSvc,
 		DocumentLoader: conf.DocumentLoader,
 		VDR:            conf.VDR,
+		Metrics:        metrics,
 	})
-	oidc4vpTxStore := oidc4vptxstore.NewTxStore(mongodbClient, conf.DocumentLoader)
+	var oidc4vpTxStoreOpts []oidc4vptxstore.Opt
+
+	if conf.StartupParameters.claimsEncryptionKeyPath != "" {
+		claimsProtector, protectorErr := dataprotect.NewAESProtectorFromFile(
+			conf.StartupParameters.claimsEncryptionKeyPath)
+		if protectorErr != nil {
+			return nil, fmt.Errorf("failed to create claims protector: %w", protectorErr)
+		}
+
+		oidc4vpTxStoreOpts = append(oidc4vpTxStoreOpts, oidc4vptxstore.WithClaimsProtector(claimsProtector))
+	}
+
+	oidc4vpTxStore, err := oidc4vptxstore.NewTxStore(mongodbClient, conf.DocumentLoader, oidc4vpTxStoreOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate new oidc4vp tx store: %w", err)
+	}
 
 	oidcNonceStore, err := oidcnoncestore.New(mongodbClient)
 	if err != nil {
@@ -398,10 +601,16 @@ func buildEchoHandler(conf *Configuration, cmd *cobra.Command) (*echo.Echo, erro
 	}
 
 	if metricsProvider != nil {
+		metricsHandler := promMetricsProvider.NewHandler()
+		eMetrics.Add(metricsHandler.Method(), metricsHandler.Path(),
+			echo.WrapHandler(http.HandlerFunc(metricsHandler.Handler())))
+
 		err = metricsProvider.Create()
 		if err != nil {
 			return nil, err
 		}
+
+		shutdown.register("metrics-server", metricsProvider.Destroy)
 	}
 
 	return e, nil
@@ -429,7 +638,76 @@ func NewMetricsProvider(parameters *startupParameters, e *echo.Echo) (metricsPro
 	}
 }
 
-func startServer(conf *Configuration, opts ...StartOpts) error {
+type accessTokenValidator interface {
+	Validate(ctx context.Context, token string) (*accesstoken.Info, error)
+}
+
+// createAccessTokenValidator creates validator of bearer access tokens. Returns nil if bearer authentication
+// is not configured.
+func createAccessTokenValidator(params *authTokenParameters, rootCAs *x509.CertPool) (accessTokenValidator, error) {
+	if params == nil || (params.issuer == "" && params.introspectionURL == "") {
+		return nil, nil //nolint:nilnil
+	}
+
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second, //nolint:gomnd
+		Transport: tracing.NewTransport(&http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+		}),
+	}
+
+	if params.introspectionURL != "" {
+		return accesstoken.NewIntrospectionValidator(&accesstoken.IntrospectionValidatorConfig{
+			Endpoint:     params.introspectionURL,
+			ClientID:     params.introspectionClientID,
+			ClientSecret: params.introspectionClientSecret,
+			OrgClaim:     params.orgClaim,
+			HTTPClient:   httpClient,
+		})
+	}
+
+	return accesstoken.NewJWTValidator(&accesstoken.JWTValidatorConfig{
+		Issuer:     params.issuer,
+		JWKSURL:    params.jwksURL,
+		Audience:   params.audience,
+		OrgClaim:   params.orgClaim,
+		HTTPClient: httpClient,
+	})
+}
+
+// createAuthMiddleware returns a middleware that authenticates requests with TLS client certificate, access token
+// or API key, depending on the configuration. Returns nil if authentication is not configured.
+func createAuthMiddleware(conf *Configuration, tokenValidator accessTokenValidator,
+	routes *mw.RouteRegistry) echo.MiddlewareFunc {
+	var auth echo.MiddlewareFunc
+
+	switch {
+	case tokenValidator != nil:
+		auth = mw.BearerAuth(&mw.BearerAuthConfig{
+			TokenValidator: tokenValidator,
+			Routes:         routes,
+			APIKey:         conf.StartupParameters.token,
+		})
+	case conf.StartupParameters.token != "":
+		auth = mw.APIKeyAuth(conf.StartupParameters.token, routes)
+	}
+
+	tlsParams := conf.StartupParameters.tlsParameters
+
+	if tlsParams.clientAuth == tlsClientAuthNone || conf.ServerTLSConfig == nil {
+		return auth
+	}
+
+	return mw.ClientCertAuth(&mw.ClientCertAuthConfig{
+		Routes:      routes,
+		Scopes:      clientCertScopes,
+		Required:    tlsParams.clientAuth == tlsClientAuthRequire,
+		OrgIDSource: tlsParams.clientOrgIDSource,
+		DenyList:    conf.ServerTLSConfig,
+	}, auth)
+}
+
+func startServer(conf *Configuration, shutdown *shutdownManager, opts ...StartOpts) error {
 	o := &startOpts{}
 
 	for _, opt := range opts {
@@ -437,21 +715,24 @@ func startServer(conf *Configuration, opts ...StartOpts) error {
 	}
 
 	if o.server == nil {
-		o.server = &http.Server{
+		server := &http.Server{
 			Addr:    conf.StartupParameters.hostURL,
 			Handler: o.handler,
 		}
+
+		if conf.ServerTLSConfig != nil {
+			server.TLSConfig = conf.ServerTLSConfig.TLSConfig()
+		}
+
+		o.server = server
 	}
 
 	logger.Info("Starting vc-rest server on host", log.WithHostURL(conf.StartupParameters.hostURL))
 
-	if conf.StartupParameters.tlsParameters.serveKeyPath != "" &&
-		conf.StartupParameters.tlsParameters.serveCertPath != "" {
-		return o.server.ListenAndServeTLS(conf.StartupParameters.tlsParameters.serveCertPath,
-			conf.StartupParameters.tlsParameters.serveKeyPath)
-	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	return o.server.ListenAndServe()
+	return serve(ctx, conf, o.server, shutdown)
 }
 
 func validateAuthorizationBearerToken(w http.ResponseWriter, r *http.Request, token string) bool {
@@ -487,3 +768,111 @@ func getOAuth2Clients(path string) ([]fositemongo.Client, error) {
 
 	return clients, nil
 }
+
+type rateLimitStore interface {
+	Take(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error)
+}
+
+func createHealthChecks(conf *Configuration, mongodbClient *mongodb.Client, kmsRegistry *kms.Registry,
+	eventSvc *event.Bus, httpClient *http.Client, shutdown *shutdownManager) []health.Check {
+	checks := []health.Check{
+		{Name: "shutdown", Fn: health.DrainCheck(shutdown), Critical: true},
+		{Name: "mongodb", Fn: health.MongoDBCheck(mongodbClient), Critical: true},
+		{Name: "kms", Fn: health.KMSCheck(kmsRegistry), Critical: true},
+		{Name: "event-bus", Fn: health.EventBusCheck(eventSvc), Critical: true},
+		{
+			Name:     "ld-context-store",
+			Fn:       health.LDContextStoreCheck(conf.LDContextStore.JSONLDContextStore(), verifiable.ContextURI),
+			Critical: true,
+		},
+	}
+
+	if conf.StartupParameters.universalResolverURL != "" {
+		// DIDs of methods supported locally are still resolved if universal resolver is down.
+		checks = append(checks, health.Check{
+			Name: "universal-resolver",
+			Fn:   health.HTTPCheck(httpClient, conf.StartupParameters.universalResolverURL),
+		})
+	}
+
+	return checks
+}
+
+func createRateLimitStore(params *rateLimitParameters, mongodbClient *mongodb.Client) (rateLimitStore, error) {
+	if params.store == databaseTypeMongoDBOption {
+		return ratelimitstore.New(context.Background(), mongodbClient)
+	}
+
+	return ratelimit.NewMemoryStore(), nil
+}
+
+type secretProvider interface {
+	GetSecret(ctx context.Context, handle string) (string, error)
+}
+
+// createSecretProvider creates provider of secrets referred by handles in profiles. Nil is returned if provider
+// is not configured.
+func createSecretProvider(params *secretParameters, httpClient *http.Client) (secretProvider, error) {
+	var provider secretProvider
+
+	switch params.provider {
+	case secretProviderFileOption:
+		provider = secret.NewFileProvider(params.fileDir)
+	case secretProviderEnvOption:
+		provider = secret.NewEnvProvider(params.envPrefix)
+	case secretProviderAWSOption:
+		awsSession, err := session.NewSession(&aws.Config{
+			Endpoint:                      &params.awsEndpoint,
+			Region:                        aws.String(params.awsRegion),
+			CredentialsChainVerboseErrors: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create aws session: %w", err)
+		}
+
+		provider = secret.NewAWSProvider(secretsmanager.New(awsSession))
+	case secretProviderVaultOption:
+		provider = secret.NewVaultProvider(&secret.VaultConfig{
+			Address:    params.vaultURL,
+			Token:      params.vaultToken,
+			MountPath:  params.vaultMountPath,
+			Key:        params.vaultKey,
+			HTTPClient: httpClient,
+		})
+	default:
+		return nil, nil
+	}
+
+	if params.cacheTTL > 0 {
+		provider = secret.NewCachingProvider(provider, params.cacheTTL)
+	}
+
+	return provider, nil
+}
+
+// profileRateLimits provides rate limits configured in issuer and verifier profiles.
+type profileRateLimits struct {
+	issuerProfileSvc   *profilereader.IssuerReader
+	verifierProfileSvc *profilereader.VerifierReader
+}
+
+func (p *profileRateLimits) RateLimit(profileType, profileID string) (*ratelimit.Limit, error) {
+	switch profileType {
+	case "issuer":
+		issuer, err := p.issuerProfileSvc.GetProfile(profileID)
+		if err != nil || issuer == nil {
+			return nil, err
+		}
+
+		return issuer.RateLimit, nil
+	case "verifier":
+		verifier, err := p.verifierProfileSvc.GetProfile(profileID)
+		if err != nil || verifier == nil {
+			return nil, err
+		}
+
+		return verifier.RateLimit, nil
+	default:
+		return nil, nil //nolint:nilnil
+	}
+}
@@ -29,6 +29,8 @@ import (
 
 	"github.com/trustbloc/vcs/cmd/common"
 	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/ratelimit"
+	"github.com/trustbloc/vcs/pkg/secret"
 )
 
 const (
@@ -180,6 +182,10 @@ func (s *mockServer) ListenAndServeTLS(certFile, keyFile string) error {
 	return nil
 }
 
+func (s *mockServer) Shutdown(ctx context.Context) error {
+	return nil
+}
+
 func TestStartCmdValidArgs(t *testing.T) {
 	pool, mongoDBResource := startMongoDBContainer(t)
 	defer func() {
@@ -391,6 +397,8 @@ func TestTLSSystemCertPoolInvalidArgsEnvVar(t *testing.T) {
 	defer unsetEnvVars(t)
 	require.NoError(t, os.Setenv(tlsSystemCertPoolEnvKey, "wrongvalue"))
 
+	defer func() { require.NoError(t, os.Unsetenv(tlsSystemCertPoolEnvKey)) }()
+
 	err := startCmd.Execute()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid syntax")
@@ -420,11 +428,270 @@ func TestContextEnableRemoteInvalidArgsEnvVar(t *testing.T) {
 	defer unsetEnvVars(t)
 	require.NoError(t, os.Setenv(contextEnableRemoteEnvKey, "not bool"))
 
+	defer func() { require.NoError(t, os.Unsetenv(contextEnableRemoteEnvKey)) }()
+
 	err := startCmd.Execute()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "invalid syntax")
 }
 
+func TestDPoPRequiredInvalidArgsEnvVar(t *testing.T) {
+	startCmd := GetStartCmd()
+
+	setEnvVars(t, databaseTypeMongoDBOption, "")
+
+	defer unsetEnvVars(t)
+	require.NoError(t, os.Setenv(dpopRequiredEnvKey, "not bool"))
+
+	defer func() { require.NoError(t, os.Unsetenv(dpopRequiredEnvKey)) }()
+
+	err := startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid dpop required")
+}
+
+func TestClientRegistrationStatementRequiredInvalidArgsEnvVar(t *testing.T) {
+	startCmd := GetStartCmd()
+
+	setEnvVars(t, databaseTypeMongoDBOption, "")
+
+	defer unsetEnvVars(t)
+	require.NoError(t, os.Setenv(clientRegistrationStatementRequiredEnvKey, "not bool"))
+
+	defer func() { require.NoError(t, os.Unsetenv(clientRegistrationStatementRequiredEnvKey)) }()
+
+	err := startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid client registration software statement required")
+}
+
+func TestRateLimitInvalidArgsEnvVar(t *testing.T) {
+	for _, tc := range []struct {
+		envKey string
+		value  string
+		err    string
+	}{
+		{rateLimitStoreEnvKey, "redis", "unsupported rate limit store: redis"},
+		{rateLimitClientDefaultEnvKey, "ten", "invalid default client rate limit"},
+		{rateLimitIPEnvKey, "ten", "invalid IP rate limit"},
+		{rateLimitClientsEnvKey, "org1", "invalid client rate limit: org1"},
+		{rateLimitClientsEnvKey, "org1=1:0", "invalid rate limit of client org1"},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			startCmd := GetStartCmd()
+
+			setEnvVars(t, databaseTypeMongoDBOption, "")
+
+			defer unsetEnvVars(t)
+			require.NoError(t, os.Setenv(tc.envKey, tc.value))
+
+			defer func() { require.NoError(t, os.Unsetenv(tc.envKey)) }()
+
+			err := startCmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestTracingInvalidArgsEnvVar(t *testing.T) {
+	for _, tc := range []struct {
+		envKey string
+		value  string
+		err    string
+	}{
+		{tracingProviderEnvKey, "jaeger", "unsupported tracing provider: jaeger"},
+		{tracingSampleRateEnvKey, "1.5", "invalid tracing sample rate: 1.5"},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			startCmd := GetStartCmd()
+
+			setEnvVars(t, databaseTypeMongoDBOption, "")
+
+			defer unsetEnvVars(t)
+			require.NoError(t, os.Setenv(tc.envKey, tc.value))
+
+			defer func() { require.NoError(t, os.Unsetenv(tc.envKey)) }()
+
+			err := startCmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestDurationInvalidArgsEnvVar(t *testing.T) {
+	for _, tc := range []struct {
+		envKey string
+		err    string
+	}{
+		{shutdownTimeoutEnvKey, "invalid shutdown timeout"},
+		{shutdownDrainDelayEnvKey, "invalid shutdown drain delay"},
+		{credentialOfferTTLEnvKey, "invalid credential offer ttl"},
+		{secretCacheTTLEnvKey, "invalid secret cache ttl"},
+		{wellKnownCacheTTLEnvKey, "invalid well-known cache ttl"},
+		{wellKnownRefreshIntervalEnvKey, "invalid well-known refresh interval"},
+		{dpopNonceTTLEnvKey, "invalid dpop nonce ttl"},
+		{oAuthRefreshTokenLifespanEnvKey, "invalid oauth refresh token lifespan"},
+		{oAuthSessionCleanupIntervalEnvKey, "invalid oauth session cleanup interval"},
+	} {
+		t.Run(tc.envKey, func(t *testing.T) {
+			startCmd := GetStartCmd()
+
+			setEnvVars(t, databaseTypeMongoDBOption, "")
+
+			defer unsetEnvVars(t)
+			require.NoError(t, os.Setenv(tc.envKey, "abc"))
+
+			defer func() { require.NoError(t, os.Unsetenv(tc.envKey)) }()
+
+			err := startCmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestSecretProviderInvalidArgsEnvVar(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		err   string
+	}{
+		{"keychain", "unsupported secret provider: keychain"},
+		{secretProviderFileOption, "secret-file-dir is required for file secret provider"},
+		{secretProviderVaultOption, "secret-vault-url is required for vault secret provider"},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			startCmd := GetStartCmd()
+
+			setEnvVars(t, databaseTypeMongoDBOption, "")
+
+			defer unsetEnvVars(t)
+			require.NoError(t, os.Setenv(secretProviderEnvKey, tc.value))
+
+			defer func() { require.NoError(t, os.Unsetenv(secretProviderEnvKey)) }()
+
+			err := startCmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestCreateSecretProvider(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		params   *secretParameters
+		expected interface{}
+	}{
+		{"not configured", &secretParameters{}, nil},
+		{"file", &secretParameters{provider: secretProviderFileOption, fileDir: "/secrets"},
+			&secret.FileProvider{}},
+		{"env", &secretParameters{provider: secretProviderEnvOption, envPrefix: "PREFIX_"}, &secret.EnvProvider{}},
+		{"aws", &secretParameters{provider: secretProviderAWSOption, awsRegion: "us-east-1"},
+			&secret.AWSProvider{}},
+		{"vault", &secretParameters{provider: secretProviderVaultOption, vaultURL: "http://vault:8200"},
+			&secret.VaultProvider{}},
+		{"cached", &secretParameters{provider: secretProviderEnvOption, cacheTTL: time.Minute},
+			&secret.CachingProvider{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := createSecretProvider(tc.params, http.DefaultClient)
+			require.NoError(t, err)
+
+			if tc.expected == nil {
+				require.Nil(t, provider)
+
+				return
+			}
+
+			require.IsType(t, tc.expected, provider)
+		})
+	}
+}
+
+func TestTLSClientAuthInvalidArgsEnvVar(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		err  string
+	}{
+		{
+			name: "unsupported client auth",
+			env:  map[string]string{tlsClientAuthEnvKey: "always"},
+			err:  "unsupported tls client auth: always",
+		},
+		{
+			name: "missing server certificate",
+			env:  map[string]string{tlsClientAuthEnvKey: tlsClientAuthRequire},
+			err:  "tls client auth requires tls-certificate and tls-key",
+		},
+		{
+			name: "missing client ca certs",
+			env: map[string]string{
+				tlsClientAuthEnvKey:   tlsClientAuthOptional,
+				tlsCertificateLEnvKey: "server.crt",
+				tlsKeyEnvKey:          "server.key",
+			},
+			err: "tls client auth requires tls-client-cacerts",
+		},
+		{
+			name: "unsupported org id source",
+			env: map[string]string{
+				tlsClientAuthEnvKey:        tlsClientAuthOptional,
+				tlsCertificateLEnvKey:      "server.crt",
+				tlsKeyEnvKey:               "server.key",
+				tlsClientCACertsEnvKey:     "ca.crt",
+				tlsClientOrgIDSourceEnvKey: "serial",
+			},
+			err: "unsupported client certificate org id source: serial",
+		},
+		{
+			name: "invalid reload interval",
+			env:  map[string]string{tlsReloadIntervalEnvKey: "abc"},
+			err:  "invalid tls reload interval",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			startCmd := GetStartCmd()
+
+			setEnvVars(t, databaseTypeMongoDBOption, "")
+
+			defer unsetEnvVars(t)
+
+			for k, v := range tc.env {
+				require.NoError(t, os.Setenv(k, v))
+			}
+
+			defer func() {
+				for k := range tc.env {
+					require.NoError(t, os.Unsetenv(k))
+				}
+			}()
+
+			err := startCmd.Execute()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	limit, err := parseRateLimit("2.5")
+	require.NoError(t, err)
+	require.Equal(t, &ratelimit.Limit{RequestsPerSecond: 2.5}, limit)
+
+	limit, err = parseRateLimit("10:20")
+	require.NoError(t, err)
+	require.Equal(t, &ratelimit.Limit{RequestsPerSecond: 10, Burst: 20}, limit)
+
+	_, err = parseRateLimit("-1")
+	require.ErrorContains(t, err, "requests per second must be a positive number")
+
+	_, err = parseRateLimit("1:x")
+	require.ErrorContains(t, err, "burst must be a positive integer")
+}
+
 func TestDidWeb(t *testing.T) {
 	v := webVDR{}
 
@@ -16,6 +16,7 @@ import (
 	"github.com/trustbloc/vcs/internal/pkg/log"
 	"github.com/trustbloc/vcs/pkg/event/spi"
 	"github.com/trustbloc/vcs/pkg/lifecycle"
+	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
 )
 
 var logger = log.New("event-bus")
@@ -28,6 +29,11 @@ const (
 type Config struct {
 	TLSConfig *tls.Config
 	CMD       *cobra.Command
+	Metrics   metricsProvider
+}
+
+type metricsProvider interface {
+	WebhookDelivery(profileID string, success bool)
 }
 
 // Bus implements a publisher/subscriber using Go channels. This implementation
@@ -41,6 +47,8 @@ type Bus struct {
 	subscribers map[string][]chan *spi.Event
 	mutex       sync.RWMutex
 
+	eventSubscribers []*Subscriber
+
 	publishChan chan *entry
 	doneChan    chan struct{}
 }
@@ -52,6 +60,10 @@ type entry struct {
 
 // NewEventBus returns in-memory event bus.
 func NewEventBus(cfg Config) *Bus {
+	if cfg.Metrics == nil {
+		cfg.Metrics = &noopMetricsProvider.NoMetrics{}
+	}
+
 	m := &Bus{
 		Config:      cfg,
 		subscribers: make(map[string][]chan *spi.Event),
@@ -69,10 +81,15 @@ func NewEventBus(cfg Config) *Bus {
 	return m
 }
 
-// Close closes all resources.
+// Close stops the bus and waits until subscribers registered with Initialize have handled events
+// that were published before Close was called.
 func (b *Bus) Close() error {
 	b.Stop()
 
+	for _, s := range b.eventSubscribers {
+		s.Stop()
+	}
+
 	return nil
 }
 
@@ -153,6 +170,8 @@ func (b *Bus) processMessages() {
 			b.publish(entry)
 
 		case <-b.doneChan:
+			b.drain()
+
 			b.doneChan <- struct{}{}
 
 			logger.Debug("... publisher has stopped")
@@ -162,6 +181,18 @@ func (b *Bus) processMessages() {
 	}
 }
 
+// drain publishes messages that are waiting in the publish channel.
+func (b *Bus) drain() {
+	for {
+		select {
+		case entry := <-b.publishChan:
+			b.publish(entry)
+		default:
+			return
+		}
+	}
+}
+
 func (b *Bus) publish(entry *entry) {
 	b.mutex.RLock()
 	subscribers := b.subscribers[entry.topic]
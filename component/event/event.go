@@ -8,14 +8,23 @@ package event
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/trustbloc/vcs/internal/pkg/log"
 	"github.com/trustbloc/vcs/pkg/event/spi"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 )
 
+var tracer = otel.Tracer("github.com/trustbloc/vcs/component/event")
+
 // Initialize event.
 func Initialize(cfg Config) (*Bus, error) {
 	eventBus := NewEventBus(cfg)
@@ -27,17 +36,35 @@ func Initialize(cfg Config) (*Bus, error) {
 
 	subscriber.Start()
 
+	eventBus.eventSubscribers = append(eventBus.eventSubscribers, subscriber)
+
 	return eventBus, nil
 }
 
 type eventPayload struct {
-	WebHook string `json:"webHook"`
+	ProfileID string `json:"profileID"`
+	WebHook   string `json:"webHook"`
 }
 
-func (b *Bus) handleEvent(e *spi.Event) error { //nolint:gocognit
+func (b *Bus) handleEvent(e *spi.Event) error {
 	logger.Info("handling event", log.WithEvent(e))
 
-	//nolint:nestif
+	ctx, span := tracer.Start(tracing.ExtractEvent(context.Background(), e), "event.handle "+string(e.Type),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("event.id", e.ID), attribute.String("event.type", string(e.Type))),
+	)
+	defer span.End()
+
+	err := b.processEvent(ctx, e)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+func (b *Bus) processEvent(ctx context.Context, e *spi.Event) error {
 	if e.Type == spi.VerifierOIDCInteractionInitiated ||
 		e.Type == spi.VerifierOIDCInteractionSucceeded ||
 		e.Type == spi.VerifierOIDCInteractionQRScanned {
@@ -48,30 +75,48 @@ func (b *Bus) handleEvent(e *spi.Event) error { //nolint:gocognit
 		}
 
 		if payload.WebHook != "" {
-			req, err := json.Marshal(e)
-			if err != nil {
-				return err
-			}
-
-			httpClient := http.Client{Transport: &http.Transport{TLSClientConfig: b.TLSConfig}}
-
-			//nolint:noctx
-			resp, err := httpClient.Post(payload.WebHook, "application/json", bytes.NewReader(req))
-			if err != nil {
-				return err
-			}
-
-			defer func() {
-				if errClose := resp.Body.Close(); errClose != nil {
-					logger.Error("error close", log.WithError(errClose))
-				}
-			}()
-
-			if resp.StatusCode != http.StatusOK {
-				return fmt.Errorf("%s webhook return %d", payload.WebHook, resp.StatusCode)
-			}
+			err := b.deliverWebhook(ctx, payload.WebHook, e)
+
+			b.Metrics.WebhookDelivery(payload.ProfileID, err == nil)
+
+			return err
 		}
 	}
 
 	return nil
 }
+
+func (b *Bus) deliverWebhook(ctx context.Context, webHook string, e *spi.Event) error {
+	req, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	httpClient := http.Client{
+		Transport: tracing.NewTransport(&http.Transport{TLSClientConfig: b.TLSConfig}),
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, webHook, bytes.NewReader(req))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if errClose := resp.Body.Close(); errClose != nil {
+			logger.Error("error close", log.WithError(errClose))
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s webhook return %d", webHook, resp.StatusCode)
+	}
+
+	return nil
+}
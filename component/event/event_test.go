@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package event
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/event/spi"
+)
+
+type webhookDelivery struct {
+	profileID string
+	success   bool
+}
+
+type mockMetrics struct {
+	deliveries []webhookDelivery
+}
+
+func (m *mockMetrics) WebhookDelivery(profileID string, success bool) {
+	m.deliveries = append(m.deliveries, webhookDelivery{profileID: profileID, success: success})
+}
+
+func TestBus_HandleEvent(t *testing.T) {
+	newEvent := func(t *testing.T, webHook string) *spi.Event {
+		t.Helper()
+
+		data, err := json.Marshal(&eventPayload{ProfileID: "profile1", WebHook: webHook})
+		require.NoError(t, err)
+
+		return spi.NewEvent(uuid, sourceURL, spi.VerifierOIDCInteractionSucceeded, data)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		}))
+		defer srv.Close()
+
+		metrics := &mockMetrics{}
+
+		eb := NewEventBus(Config{Metrics: metrics})
+		defer eb.Stop()
+
+		require.NoError(t, eb.handleEvent(newEvent(t, srv.URL)))
+		require.Equal(t, []webhookDelivery{{profileID: "profile1", success: true}}, metrics.deliveries)
+	})
+
+	t.Run("Webhook error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		metrics := &mockMetrics{}
+
+		eb := NewEventBus(Config{Metrics: metrics})
+		defer eb.Stop()
+
+		require.ErrorContains(t, eb.handleEvent(newEvent(t, srv.URL)), "webhook return 500")
+		require.Equal(t, []webhookDelivery{{profileID: "profile1", success: false}}, metrics.deliveries)
+	})
+
+	t.Run("No webhook", func(t *testing.T) {
+		metrics := &mockMetrics{}
+
+		eb := NewEventBus(Config{Metrics: metrics})
+		defer eb.Stop()
+
+		require.NoError(t, eb.handleEvent(newEvent(t, "")))
+		require.Empty(t, metrics.deliveries)
+	})
+}
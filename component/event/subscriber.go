@@ -31,6 +31,7 @@ type Subscriber struct {
 	handler    eventHandler
 
 	eventChan <-chan *spi.Event
+	done      chan struct{}
 }
 
 // NewEventSubscriber returns a new subscriber.
@@ -38,10 +39,12 @@ func NewEventSubscriber(sub eventSubscriber, topic string, handler eventHandler)
 	h := &Subscriber{
 		subscriber: sub,
 		handler:    handler,
+		done:       make(chan struct{}),
 	}
 
 	h.Lifecycle = lifecycle.New("event-subscriber",
 		lifecycle.WithStart(h.start),
+		lifecycle.WithStop(h.stop),
 	)
 
 	logger.Debug("subscribing to topic", log.WithTopic(topic))
@@ -60,9 +63,17 @@ func (h *Subscriber) start() {
 	go h.listen()
 }
 
+// stop waits until events that are left in the event channel are handled. Event channel must be closed
+// by the publisher before the subscriber is stopped.
+func (h *Subscriber) stop() {
+	<-h.done
+}
+
 func (h *Subscriber) listen() {
 	logger.Debug("starting event listener...")
 
+	defer close(h.done)
+
 	for { //nolint:gosimple
 		select {
 		case e, ok := <-h.eventChan:
@@ -57,6 +57,34 @@ func TestEventSubscriber(t *testing.T) {
 		time.Sleep(1 * time.Second)
 	})
 
+	t.Run("success - pending events are handled on close", func(t *testing.T) {
+		eventBus := NewEventBus(Config{})
+
+		var handled []string
+
+		subscriber, err := NewEventSubscriber(eventBus, topic, func(e *spi.Event) error {
+			time.Sleep(10 * time.Millisecond)
+
+			handled = append(handled, e.ID)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		subscriber.Start()
+
+		eventBus.eventSubscribers = append(eventBus.eventSubscribers, subscriber)
+
+		publisher := NewEventPublisher(eventBus)
+
+		require.NoError(t, publisher.Publish(topic, spi.NewEvent("id-1", sourceURL, eventType, []byte(jsonMsg))))
+		require.NoError(t, publisher.Publish(topic, spi.NewEvent("id-2", sourceURL, eventType, []byte(jsonMsg))))
+		require.NoError(t, publisher.Publish(topic, spi.NewEvent("id-3", sourceURL, eventType, []byte(jsonMsg))))
+
+		require.NoError(t, eventBus.Close())
+		require.Equal(t, []string{"id-1", "id-2", "id-3"}, handled)
+	})
+
 	t.Run("error - event handler error", func(t *testing.T) {
 		eventBus := NewEventBus(Config{})
 
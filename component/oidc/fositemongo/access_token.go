@@ -8,7 +8,7 @@ import (
 )
 
 func (s *Store) CreateAccessTokenSession(ctx context.Context, signature string, request fosite.Requester) error {
-	return s.createSession(ctx, accessTokenCollection, signature, request, defaultTTL)
+	return s.createSession(ctx, accessTokenCollection, signature, request, sessionTTL(request, fosite.AccessToken))
 }
 
 func (s *Store) GetAccessTokenSession(
@@ -29,6 +29,7 @@ func (s *Store) DeleteAccessTokenSession(ctx context.Context, signature string)
 func (s *Store) RevokeAccessToken(ctx context.Context, requestID string) error {
 	collection := s.mongoClient.Database().Collection(accessTokenCollection)
 
-	_, err := collection.DeleteOne(ctx, bson.M{"record.id": requestID})
+	// refresh issues new access token for the same request, so all tokens of the request are revoked
+	_, err := collection.DeleteMany(ctx, bson.M{"record.id": requestID})
 	return err
 }
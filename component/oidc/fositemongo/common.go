@@ -39,6 +39,7 @@ func (s *Store) createSession(
 		Lang:              mapped.Lang,
 		ClientID:          mapped.Client.GetID(),
 		SessionExtra:      mapped.Session.(*fosite.DefaultSession).Extra,
+		SessionExpiresAt:  mapped.Session.(*fosite.DefaultSession).ExpiresAt,
 	}
 
 	collection := s.mongoClient.Database().Collection(collectionStr)
@@ -78,6 +79,10 @@ func (s *Store) getSession(
 		mappedSession.Extra[k] = v
 	}
 
+	if len(resp.SessionExpiresAt) > 0 {
+		mappedSession.ExpiresAt = resp.SessionExpiresAt
+	}
+
 	client, err := s.GetClient(ctx, resp.ClientID)
 	if err != nil {
 		return nil, err
@@ -97,6 +102,17 @@ func (s *Store) getSession(
 	}, nil
 }
 
+// sessionTTL returns the time token session is stored for. The session is kept until the token expires,
+// defaultTTL is used if expiration time of the token is not set.
+func sessionTTL(requester fosite.Requester, tokenType fosite.TokenType) time.Duration {
+	expiresAt := requester.GetSession().GetExpiresAt(tokenType)
+	if expiresAt.IsZero() {
+		return defaultTTL
+	}
+
+	return time.Until(expiresAt)
+}
+
 func getInternal[T any](
 	ctx context.Context,
 	mongoClient *mongodb.Client,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -56,6 +57,13 @@ func (s *Store) migrate(ctx context.Context) error {
 		},
 	}
 
+	// tokens are revoked by request id
+	tokenSessionIndexes := append(append([]mongo.IndexModel{}, baseSessionIndexes...), mongo.IndexModel{
+		Keys: map[string]interface{}{
+			"record.id": 1,
+		},
+	})
+
 	indexes := map[string][]mongo.IndexModel{
 		clientsCollection: {
 			{
@@ -68,7 +76,8 @@ func (s *Store) migrate(ctx context.Context) error {
 		parCollection:             baseSessionIndexes,
 		authCodeCollection:        baseSessionIndexes,
 		pkceSessionCollection:     baseSessionIndexes,
-		refreshTokenCollection:    baseSessionIndexes,
+		refreshTokenCollection:    tokenSessionIndexes,
+		accessTokenCollection:     tokenSessionIndexes,
 		blacklistedJTIsCollection: baseSessionIndexes,
 	}
 
@@ -81,3 +90,29 @@ func (s *Store) migrate(ctx context.Context) error {
 
 	return nil
 }
+
+// DeleteExpiredSessions deletes sessions and blacklisted JTIs that have expired. MongoDB removes expired documents
+// with TTL index in background, but only once a minute and not in every MongoDB-compatible database, so
+// the cleanup can be run periodically to keep the collections small.
+func (s *Store) DeleteExpiredSessions(ctx context.Context) (int64, error) {
+	var deleted int64
+
+	for _, collection := range []string{
+		parCollection,
+		authCodeCollection,
+		pkceSessionCollection,
+		refreshTokenCollection,
+		accessTokenCollection,
+		blacklistedJTIsCollection,
+	} {
+		result, err := s.mongoClient.Database().Collection(collection).DeleteMany(ctx,
+			bson.M{"expireAt": bson.M{"$lt": time.Now().UTC()}})
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += result.DeletedCount
+	}
+
+	return deleted, nil
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ory/fosite"
+	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/trustbloc/vcs/pkg/storage/mongodb"
@@ -27,3 +29,71 @@ func TestFailMigration(t *testing.T) {
 	assert.Nil(t, s)
 	assert.ErrorContains(t, err, "context canceled")
 }
+
+func TestDeleteExpiredSessions(t *testing.T) {
+	pool, mongoDBResource := startMongoDBContainer(t)
+
+	defer func() {
+		assert.NoError(t, pool.Purge(mongoDBResource), "failed to purge MongoDB resource")
+	}()
+
+	client, mongoErr := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
+	assert.NoError(t, mongoErr)
+
+	s, err := NewStore(context.Background(), client)
+	assert.NoError(t, err)
+
+	dbClient := &Client{
+		ID:     uuid.New(),
+		Scopes: []string{"awesome"},
+	}
+
+	_, err = s.InsertClient(context.Background(), *dbClient)
+	assert.NoError(t, err)
+
+	newRequest := func(expiresAt time.Time) *fosite.Request {
+		return &fosite.Request{
+			ID:     uuid.New(),
+			Client: dbClient,
+			Session: &fosite.DefaultSession{
+				Extra: map[string]interface{}{},
+				ExpiresAt: map[fosite.TokenType]time.Time{
+					fosite.AccessToken:  expiresAt,
+					fosite.RefreshToken: expiresAt,
+				},
+			},
+		}
+	}
+
+	valid := newRequest(time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond))
+
+	assert.NoError(t, s.CreateAccessTokenSession(context.Background(), "valid", valid))
+	assert.NoError(t, s.CreateRefreshTokenSession(context.Background(), "valid", valid))
+
+	// session expiring right away simulates the session not yet removed by TTL index
+	expiring := newRequest(time.Now().UTC().Add(time.Millisecond))
+
+	assert.NoError(t, s.CreateAccessTokenSession(context.Background(), "expired", expiring))
+	assert.NoError(t, s.CreateRefreshTokenSession(context.Background(), "expired", expiring))
+	assert.NoError(t, s.SetClientAssertionJWT(context.Background(), "jti", time.Now().UTC().Add(-time.Hour)))
+
+	time.Sleep(10 * time.Millisecond)
+
+	deleted, err := s.DeleteExpiredSessions(context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, deleted)
+
+	dbSes, err := s.GetAccessTokenSession(context.Background(), "valid", new(fosite.DefaultSession))
+	assert.NoError(t, err)
+	assert.Equal(t, valid.Session.(*fosite.DefaultSession).ExpiresAt,
+		dbSes.GetSession().(*fosite.DefaultSession).ExpiresAt)
+
+	_, err = s.GetRefreshTokenSession(context.Background(), "valid", new(fosite.DefaultSession))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = s.DeleteExpiredSessions(ctx)
+	assert.ErrorContains(t, err, "context canceled")
+}
@@ -8,7 +8,8 @@ import (
 )
 
 func (s *Store) CreateRefreshTokenSession(ctx context.Context, signature string, request fosite.Requester) error {
-	return s.createSession(ctx, refreshTokenCollection, signature, request, defaultTTL)
+	return s.createSession(ctx, refreshTokenCollection, signature, request,
+		sessionTTL(request, fosite.RefreshToken))
 }
 
 func (s *Store) GetRefreshTokenSession(
@@ -29,7 +30,8 @@ func (s *Store) DeleteRefreshTokenSession(ctx context.Context, signature string)
 func (s *Store) RevokeRefreshToken(ctx context.Context, requestID string) error {
 	collection := s.mongoClient.Database().Collection(refreshTokenCollection)
 
-	_, err := collection.DeleteOne(ctx, bson.M{"record.id": requestID})
+	// refresh issues new refresh token for the same request, so all tokens of the request are revoked
+	_, err := collection.DeleteMany(ctx, bson.M{"record.id": requestID})
 	return err
 }
 
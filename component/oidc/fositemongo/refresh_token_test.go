@@ -126,3 +126,59 @@ func TestFailGracePeriod(t *testing.T) {
 
 	assert.ErrorContains(t, s.RevokeRefreshTokenMaybeGracePeriod(ctx, "2131", "214123"), "context canceled")
 }
+
+func TestRevokeAfterRefresh(t *testing.T) {
+	pool, mongoDBResource := startMongoDBContainer(t)
+
+	defer func() {
+		assert.NoError(t, pool.Purge(mongoDBResource), "failed to purge MongoDB resource")
+	}()
+
+	client, mongoErr := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
+	assert.NoError(t, mongoErr)
+
+	s, err := NewStore(context.Background(), client)
+	assert.NoError(t, err)
+
+	dbClient := &Client{
+		ID:     uuid.New(),
+		Scopes: []string{"awesome"},
+	}
+
+	_, err = s.InsertClient(context.Background(), *dbClient)
+	assert.NoError(t, err)
+
+	ses := &fosite.Request{
+		ID:             uuid.New(),
+		Client:         dbClient,
+		RequestedScope: []string{"scope1"},
+		GrantedScope:   []string{"scope1"},
+		Session:        &fosite.DefaultSession{},
+	}
+
+	// refresh keeps request id, so each refresh adds token sessions of the same request
+	var accessSigns, refreshSigns []string
+
+	for i := 0; i < 3; i++ {
+		accessSign, refreshSign := uuid.New(), uuid.New()
+
+		assert.NoError(t, s.CreateAccessTokenSession(context.TODO(), accessSign, ses))
+		assert.NoError(t, s.CreateRefreshTokenSession(context.TODO(), refreshSign, ses))
+
+		accessSigns = append(accessSigns, accessSign)
+		refreshSigns = append(refreshSigns, refreshSign)
+	}
+
+	assert.NoError(t, s.RevokeAccessToken(context.TODO(), ses.ID))
+	assert.NoError(t, s.RevokeRefreshToken(context.TODO(), ses.ID))
+
+	for _, sign := range accessSigns {
+		_, err = s.GetAccessTokenSession(context.TODO(), sign, ses.Session)
+		assert.ErrorIs(t, err, ErrDataNotFound)
+	}
+
+	for _, sign := range refreshSigns {
+		_, err = s.GetRefreshTokenSession(context.TODO(), sign, ses.Session)
+		assert.ErrorIs(t, err, ErrDataNotFound)
+	}
+}
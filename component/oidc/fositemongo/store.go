@@ -3,6 +3,7 @@ package fositemongo
 import (
 	"context"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -25,3 +26,39 @@ func (s *Store) InsertClient(ctx context.Context, client Client) (string, error)
 
 	return insertedID.Hex(), nil
 }
+
+// UpdateClient replaces the stored client with the given one. ErrDataNotFound is returned if the client
+// does not exist.
+func (s *Store) UpdateClient(ctx context.Context, client Client) error {
+	collection := s.mongoClient.Database().Collection(clientsCollection)
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_lookupId": client.ID},
+		bson.M{"$set": bson.M{"record": client}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrDataNotFound
+	}
+
+	return nil
+}
+
+// DeleteClient deletes the client by its ID. ErrDataNotFound is returned if the client does not exist.
+func (s *Store) DeleteClient(ctx context.Context, id string) error {
+	collection := s.mongoClient.Database().Collection(clientsCollection)
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_lookupId": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrDataNotFound
+	}
+
+	return nil
+}
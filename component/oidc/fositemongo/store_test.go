@@ -34,3 +34,45 @@ func TestStoreFail(t *testing.T) {
 
 	assert.ErrorContains(t, err, "context canceled")
 }
+
+func TestUpdateAndDeleteClient(t *testing.T) {
+	pool, mongoDBResource := startMongoDBContainer(t)
+
+	defer func() {
+		assert.NoError(t, pool.Purge(mongoDBResource), "failed to purge MongoDB resource")
+	}()
+
+	client, mongoErr := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
+	assert.NoError(t, mongoErr)
+
+	s, err := NewStore(context.Background(), client)
+	assert.NoError(t, err)
+
+	oauth2Client := Client{
+		ID:           uuid.New(),
+		Scopes:       []string{"openid"},
+		RedirectURIs: []string{"https://example.com/callback"},
+		Name:         "wallet",
+	}
+
+	_, err = s.InsertClient(context.Background(), oauth2Client)
+	assert.NoError(t, err)
+
+	oauth2Client.Name = "updated wallet"
+	oauth2Client.RedirectURIs = []string{"https://example.com/callback2"}
+
+	assert.NoError(t, s.UpdateClient(context.Background(), oauth2Client))
+
+	stored, err := s.GetClient(context.Background(), oauth2Client.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated wallet", stored.(*Client).Name)
+	assert.Equal(t, []string{"https://example.com/callback2"}, stored.GetRedirectURIs())
+
+	assert.NoError(t, s.DeleteClient(context.Background(), oauth2Client.ID))
+
+	_, err = s.GetClient(context.Background(), oauth2Client.ID)
+	assert.ErrorIs(t, err, ErrDataNotFound)
+
+	assert.ErrorIs(t, s.DeleteClient(context.Background(), oauth2Client.ID), ErrDataNotFound)
+	assert.ErrorIs(t, s.UpdateClient(context.Background(), oauth2Client), ErrDataNotFound)
+}
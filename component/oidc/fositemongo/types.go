@@ -40,6 +40,7 @@ type request struct {
 	Lang              language.Tag
 	ClientID          string
 	SessionExtra      map[string]interface{}
+	SessionExpiresAt  map[fosite.TokenType]time.Time
 }
 
 type Client struct {
@@ -52,6 +53,16 @@ type Client struct {
 	Scopes         []string `json:"scopes"`
 	Audience       []string `json:"audience"`
 	Public         bool     `json:"public"`
+
+	// Metadata of dynamically registered clients (RFC 7591).
+	Name                        string     `json:"client_name,omitempty"`
+	URI                         string     `json:"client_uri,omitempty"`
+	Contacts                    []string   `json:"contacts,omitempty"`
+	TokenEndpointAuthMethod     string     `json:"token_endpoint_auth_method,omitempty"`
+	SoftwareID                  string     `json:"software_id,omitempty"`
+	SoftwareVersion             string     `json:"software_version,omitempty"`
+	RegistrationAccessTokenHash []byte     `json:"-"`
+	CreatedAt                   *time.Time `json:"created_at,omitempty"`
 }
 
 func (c *Client) GetID() string {
@@ -9,6 +9,7 @@ package file
 import (
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -199,6 +200,12 @@ func NewVerifierReader(config *Config) (*VerifierReader, error) {
 			}
 		}
 
+		if v.Data.OIDCConfig != nil && v.Data.OIDCConfig.ResponseEncryption != nil {
+			if err := validateResponseEncryption(v.Data.OIDCConfig.ResponseEncryption); err != nil {
+				return nil, fmt.Errorf("verifier profile service: create profile failed: %w", err)
+			}
+		}
+
 		logger.Info("create verifier profile successfully", log.WithID(v.Data.ID))
 
 		r.verifiers[v.Data.ID] = v.Data
@@ -207,6 +214,20 @@ func NewVerifierReader(config *Config) (*VerifierReader, error) {
 	return &r, nil
 }
 
+// validateResponseEncryption checks that response encryption key is configured. The key is created in profile's
+// KMS beforehand, so that the same key is advertised after restart and by every instance.
+func validateResponseEncryption(encryption *profileapi.ResponseEncryption) error {
+	if encryption.KeyID == "" {
+		return errors.New("response encryption keyID is not configured")
+	}
+
+	if encryption.JWK == nil {
+		return errors.New("response encryption jwk is not configured")
+	}
+
+	return nil
+}
+
 // GetProfile returns profile with given id.
 func (p *VerifierReader) GetProfile(profileID profileapi.ID) (*profileapi.Verifier, error) {
 	return p.verifiers[profileID], nil
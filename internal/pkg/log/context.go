@@ -0,0 +1,40 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"context"
+)
+
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a copy of ctx that carries the correlation ID. Loggers obtained
+// with Log.WithContext add the correlation ID to every log line.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by ctx or an empty string.
+func CorrelationIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string) //nolint:errcheck
+
+	return id
+}
+
+// WithContext returns a logger that adds request-scoped fields from ctx, such as the correlation ID,
+// to every log line. The logger itself is returned if ctx carries no such fields.
+func (l *Log) WithContext(ctx context.Context) *Log {
+	id := CorrelationIDFromContext(ctx)
+	if id == "" {
+		return l
+	}
+
+	return &Log{
+		Logger: l.Logger.With(WithCorrelationID(id)),
+		module: l.module,
+	}
+}
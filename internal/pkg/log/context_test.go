@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog_WithContext(t *testing.T) {
+	const module = "context-module"
+
+	t.Run("Correlation ID", func(t *testing.T) {
+		stdOut := newMockWriter()
+
+		logger := New(module, WithStdOut(stdOut), WithEncoding(JSON))
+
+		ctx := ContextWithCorrelationID(context.Background(), "correlation1")
+		require.Equal(t, "correlation1", CorrelationIDFromContext(ctx))
+
+		logger.WithContext(ctx).Info("Sample info log")
+		logger.Info("Sample info log without context")
+
+		require.Contains(t, stdOut.Buffer.String(), `"correlationID":"correlation1"`)
+		require.Equal(t, 1, bytes.Count(stdOut.Bytes(), []byte("correlation1")))
+	})
+
+	t.Run("No correlation ID", func(t *testing.T) {
+		logger := New(module)
+
+		require.Empty(t, CorrelationIDFromContext(context.Background()))
+		require.Same(t, logger, logger.WithContext(context.Background()))
+	})
+}
@@ -44,6 +44,8 @@ const (
 	FieldPresDefID           = "presDefinitionID"
 	FieldState               = "state"
 	FieldProfileID           = "profileID"
+	FieldCorrelationID       = "correlationID"
+	FieldSubject             = "subject"
 )
 
 // ObjectMarshaller uses reflection to marshal an object's fields.
@@ -211,3 +213,13 @@ func WithState(state string) zap.Field {
 func WithProfileID(id string) zap.Field {
 	return zap.String(FieldProfileID, id)
 }
+
+// WithCorrelationID sets the correlationID field.
+func WithCorrelationID(id string) zap.Field {
+	return zap.String(FieldCorrelationID, id)
+}
+
+// WithSubject sets the subject field.
+func WithSubject(subject string) zap.Field {
+	return zap.String(FieldSubject, subject)
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -184,6 +185,32 @@ func GetLevel(module string) Level {
 //
 //	module1=error:module2=debug:module3=warning:info
 func SetSpec(spec string) error {
+	specLevels, err := parseSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	for module, level := range specLevels {
+		levels.Set(module, level)
+	}
+
+	return nil
+}
+
+// ReplaceSpec sets the log levels from the spec in the same format as SetSpec. Unlike SetSpec, the levels
+// of modules that are not in the spec are reset, so that GetSpec returns exactly the given spec afterwards.
+func ReplaceSpec(spec string) error {
+	specLevels, err := parseSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	levels.Replace(specLevels)
+
+	return nil
+}
+
+func parseSpec(spec string) (map[string]Level, error) {
 	logLevelByModule := strings.Split(spec, ":")
 
 	defaultLogLevel := minLogLevel - 1
@@ -196,36 +223,38 @@ func SetSpec(spec string) error {
 
 			logLevel, err := ParseLevel(moduleAndLevelPair[1])
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			moduleLevelPairs = append(moduleLevelPairs,
 				moduleLevelPair{moduleAndLevelPair[0], logLevel})
 		} else {
 			if defaultLogLevel >= minLogLevel {
-				return errors.New("multiple default values found")
+				return nil, errors.New("multiple default values found")
 			}
 
 			level, err := ParseLevel(logLevelByModulePart)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			defaultLogLevel = level
 		}
 	}
 
+	specLevels := make(map[string]Level, len(moduleLevelPairs)+1)
+
 	if defaultLogLevel >= minLogLevel {
-		levels.Set("", defaultLogLevel)
+		specLevels[defaultModuleName] = defaultLogLevel
 	} else {
-		levels.Set("", INFO)
+		specLevels[defaultModuleName] = INFO
 	}
 
 	for _, moduleLevelPair := range moduleLevelPairs {
-		levels.Set(moduleLevelPair.module, moduleLevelPair.logLevel)
+		specLevels[moduleLevelPair.module] = moduleLevelPair.logLevel
 	}
 
-	return nil
+	return specLevels, nil
 }
 
 // GetSpec returns the log spec which specifies the log level of each individual module. The spec is
@@ -239,13 +268,24 @@ func SetSpec(spec string) error {
 func GetSpec() string {
 	var spec string
 
-	var defaultDebugLevel string
+	defaultDebugLevel := defaultLevel.String()
+
+	allLevels := getAllLevels()
+
+	modules := make([]string, 0, len(allLevels))
+
+	for module := range allLevels {
+		modules = append(modules, module)
+	}
+
+	// Sort modules, so that the spec is stable.
+	sort.Strings(modules)
 
-	for module, level := range getAllLevels() {
+	for _, module := range modules {
 		if module == "" {
-			defaultDebugLevel = level.String()
+			defaultDebugLevel = allLevels[module].String()
 		} else {
-			spec += fmt.Sprintf("%s=%s:", module, level.String())
+			spec += fmt.Sprintf("%s=%s:", module, allLevels[module].String())
 		}
 	}
 
@@ -317,6 +357,13 @@ func (l *moduleLevels) Set(module string, level Level) {
 	l.rwmutex.Unlock()
 }
 
+// Replace replaces all log levels with the given ones.
+func (l *moduleLevels) Replace(levels map[string]Level) {
+	l.rwmutex.Lock()
+	l.levels = levels
+	l.rwmutex.Unlock()
+}
+
 func (l *moduleLevels) SetDefault(level Level) {
 	l.Set(defaultModuleName, level)
 }
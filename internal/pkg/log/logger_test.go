@@ -259,6 +259,35 @@ func TestSetSpecLogSpecPut(t *testing.T) {
 	})
 }
 
+func TestReplaceSpec(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		resetLoggingLevels()
+
+		require.NoError(t, ReplaceSpec("module3=debug:warning"))
+
+		require.Equal(t, DEBUG, GetLevel("module3"))
+		require.Equal(t, WARNING, GetLevel("module1"))
+		require.Equal(t, WARNING, GetLevel(""))
+		require.Equal(t, "module3=DEBUG:WARN", GetSpec())
+
+		require.NoError(t, ReplaceSpec("info"))
+
+		require.Equal(t, INFO, GetLevel("module3"))
+		require.Equal(t, "INFO", GetSpec())
+	})
+
+	t.Run("Invalid log spec", func(t *testing.T) {
+		resetLoggingLevels()
+
+		err := ReplaceSpec("module3=InvalidLogLevel")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid log level")
+
+		require.Equal(t, INFO, GetLevel("module1"))
+		require.Equal(t, INFO, GetLevel("module3"))
+	})
+}
+
 func TestLogSpecGet(t *testing.T) {
 	resetLoggingLevels()
 
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tls
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+)
+
+// ServerConfigParams defines files the server TLS configuration is loaded from.
+type ServerConfigParams struct {
+	CertFile string
+	KeyFile  string
+	// ClientAuth is a policy for TLS client authentication.
+	ClientAuth tls.ClientAuthType
+	// ClientCAFiles are PEM files with CA certificates used to verify client certificates.
+	ClientCAFiles []string
+	// DenyListFile is a file with SHA-256 fingerprints (hex) of revoked client certificates, one per line.
+	// Lines starting with # are ignored.
+	DenyListFile string
+}
+
+// ServerConfig is a server TLS configuration that can be reloaded from files without server restart.
+// New configuration applies to new TLS connections, deny list is checked on every call to IsDenied.
+type ServerConfig struct {
+	params ServerConfigParams
+
+	lock     sync.RWMutex
+	config   *tls.Config
+	denyList map[string]struct{}
+	modTimes map[string]time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewServerConfig loads server TLS configuration from files.
+func NewServerConfig(params ServerConfigParams) (*ServerConfig, error) {
+	s := &ServerConfig{
+		params: params,
+		stop:   make(chan struct{}),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// TLSConfig returns TLS configuration for http.Server. Each TLS connection uses the latest loaded configuration.
+func (s *ServerConfig) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			s.lock.RLock()
+			defer s.lock.RUnlock()
+
+			return s.config, nil
+		},
+	}
+}
+
+// IsDenied returns true if the certificate is in the deny list.
+func (s *ServerConfig) IsDenied(cert *x509.Certificate) bool {
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.denyList[hex.EncodeToString(fingerprint[:])]
+
+	return ok
+}
+
+// Reload reloads the configuration if any of the files has been modified since the last load.
+// Current configuration is kept if new one fails to load.
+func (s *ServerConfig) Reload() error {
+	modTimes, err := s.getModTimes()
+	if err != nil {
+		return err
+	}
+
+	s.lock.RLock()
+	changed := !equalModTimes(s.modTimes, modTimes)
+	s.lock.RUnlock()
+
+	if !changed {
+		return nil
+	}
+
+	if err = s.load(); err != nil {
+		return err
+	}
+
+	logger.Info("Server TLS configuration reloaded")
+
+	return nil
+}
+
+// Watch periodically reloads the configuration until Close is called.
+func (s *ServerConfig) Watch(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Reload(); err != nil {
+					logger.Warn("Failed to reload server TLS configuration", log.WithError(err))
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops watching for configuration changes.
+func (s *ServerConfig) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	return nil
+}
+
+func (s *ServerConfig) load() error {
+	// Modification times are taken before reading files, so that changes made while loading are picked up
+	// on the next reload.
+	modTimes, err := s.getModTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.params.CertFile, s.params.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load server key pair: %w", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+		ClientAuth:   s.params.ClientAuth,
+	}
+
+	if len(s.params.ClientCAFiles) > 0 {
+		config.ClientCAs = x509.NewCertPool()
+
+		for _, file := range s.params.ClientCAFiles {
+			pemCerts, errRead := os.ReadFile(filepath.Clean(file))
+			if errRead != nil {
+				return fmt.Errorf("read client ca certs: %w", errRead)
+			}
+
+			if !config.ClientCAs.AppendCertsFromPEM(pemCerts) {
+				return fmt.Errorf("no client ca certs found in %s", file)
+			}
+		}
+	}
+
+	denyList, err := s.loadDenyList()
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.config = config
+	s.denyList = denyList
+	s.modTimes = modTimes
+
+	return nil
+}
+
+func (s *ServerConfig) loadDenyList() (map[string]struct{}, error) {
+	denyList := map[string]struct{}{}
+
+	if s.params.DenyListFile == "" {
+		return denyList, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(s.params.DenyListFile))
+	if err != nil {
+		return nil, fmt.Errorf("read deny list: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fingerprint := strings.ToLower(strings.ReplaceAll(line, ":", ""))
+
+		if b, errDecode := hex.DecodeString(fingerprint); errDecode != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("invalid certificate fingerprint in deny list: %s", line)
+		}
+
+		denyList[fingerprint] = struct{}{}
+	}
+
+	return denyList, scanner.Err()
+}
+
+func (s *ServerConfig) getModTimes() (map[string]time.Time, error) {
+	files := append([]string{s.params.CertFile, s.params.KeyFile}, s.params.ClientCAFiles...)
+
+	if s.params.DenyListFile != "" {
+		files = append(files, s.params.DenyListFile)
+	}
+
+	modTimes := make(map[string]time.Time, len(files))
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", file, err)
+		}
+
+		modTimes[file] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+func equalModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for file, t := range a {
+		if !b[file].Equal(t) {
+			return false
+		}
+	}
+
+	return true
+}
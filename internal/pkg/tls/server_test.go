@@ -0,0 +1,210 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tls // nolint:testpackage // references internal implementation details
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, caKey := createCert(t, nil, nil, "ca", true)
+	serverCert, serverKey := createCert(t, ca, caKey, "localhost", false)
+	clientCert, clientKey := createCert(t, ca, caKey, "client", false)
+
+	params := ServerConfigParams{
+		CertFile:      writePEM(t, dir, "server.crt", "CERTIFICATE", serverCert.Raw),
+		KeyFile:       writeKey(t, dir, "server.key", serverKey),
+		ClientAuth:    tls.VerifyClientCertIfGiven,
+		ClientCAFiles: []string{writePEM(t, dir, "ca.crt", "CERTIFICATE", ca.Raw)},
+		DenyListFile:  filepath.Join(dir, "denylist"),
+	}
+
+	require.NoError(t, os.WriteFile(params.DenyListFile, []byte("# revoked certificates\n"), 0600))
+
+	t.Run("Client certificate is verified", func(t *testing.T) {
+		s, err := NewServerConfig(params)
+		require.NoError(t, err)
+
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Len(t, r.TLS.VerifiedChains, 1)
+			require.Equal(t, "client", r.TLS.VerifiedChains[0][0].Subject.CommonName)
+		}))
+		srv.TLS = s.TLSConfig()
+		srv.StartTLS()
+		defer srv.Close()
+
+		roots := x509.NewCertPool()
+		roots.AddCert(ca)
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    roots,
+			ServerName: "localhost",
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{clientCert.Raw},
+				PrivateKey:  clientKey,
+			}},
+		}}}
+
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Deny list is reloaded", func(t *testing.T) {
+		s, err := NewServerConfig(params)
+		require.NoError(t, err)
+
+		require.False(t, s.IsDenied(clientCert))
+
+		fingerprint := sha256.Sum256(clientCert.Raw)
+
+		writeDenyList(t, params.DenyListFile, hex.EncodeToString(fingerprint[:]))
+		require.NoError(t, s.Reload())
+		require.True(t, s.IsDenied(clientCert))
+
+		// Invalid deny list is not applied.
+		writeDenyList(t, params.DenyListFile, "invalid")
+		require.ErrorContains(t, s.Reload(), "invalid certificate fingerprint in deny list")
+		require.True(t, s.IsDenied(clientCert))
+
+		writeDenyList(t, params.DenyListFile, "")
+		require.NoError(t, s.Reload())
+		require.False(t, s.IsDenied(clientCert))
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		watchParams := params
+		watchParams.DenyListFile = filepath.Join(dir, "watch-denylist")
+
+		writeDenyList(t, watchParams.DenyListFile, "")
+
+		s, err := NewServerConfig(watchParams)
+		require.NoError(t, err)
+
+		s.Watch(10 * time.Millisecond)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		fingerprint := sha256.Sum256(clientCert.Raw)
+		writeDenyList(t, watchParams.DenyListFile, hex.EncodeToString(fingerprint[:]))
+
+		require.Eventually(t, func() bool { return s.IsDenied(clientCert) }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Missing files", func(t *testing.T) {
+		invalidParams := params
+		invalidParams.ClientCAFiles = []string{filepath.Join(dir, "missing.crt")}
+
+		_, err := NewServerConfig(invalidParams)
+		require.ErrorContains(t, err, "missing.crt")
+
+		invalidParams = params
+		invalidParams.KeyFile = params.CertFile
+
+		_, err = NewServerConfig(invalidParams)
+		require.ErrorContains(t, err, "load server key pair")
+	})
+
+	t.Run("Invalid client CA file", func(t *testing.T) {
+		invalidParams := params
+		invalidParams.ClientCAFiles = []string{params.DenyListFile}
+
+		_, err := NewServerConfig(invalidParams)
+		require.ErrorContains(t, err, "no client ca certs found")
+	})
+}
+
+func createCert(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, cn string,
+	isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{cn},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+
+	file := filepath.Join(dir, name)
+
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+
+	return file
+}
+
+func writeKey(t *testing.T, dir, name string, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return writePEM(t, dir, name, "EC PRIVATE KEY", der)
+}
+
+// writeDenyList writes the deny list and moves its modification time forward, so that the change is detected
+// on file systems with coarse time resolution.
+func writeDenyList(t *testing.T, file, content string) {
+	t.Helper()
+
+	var modTime time.Time
+
+	if info, err := os.Stat(file); err == nil {
+		modTime = info.ModTime()
+	}
+
+	require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+
+	if modTime.IsZero() {
+		return
+	}
+
+	modTime = modTime.Add(time.Second)
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+}
@@ -0,0 +1,80 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accesstoken
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// DefaultOrgClaim is a name of the access token claim that contains organization ID.
+const DefaultOrgClaim = "org_id"
+
+// ErrInvalidToken is returned when access token is malformed, expired, revoked or signed by unknown key.
+var ErrInvalidToken = errors.New("invalid access token")
+
+// Info contains data extracted from validated access token.
+type Info struct {
+	Subject string
+	OrgID   string
+	Scopes  []string
+}
+
+// HasScope checks whether access token is granted the given scope.
+func (i *Info) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// parseScopes parses scopes from space-delimited "scope" claim (RFC 8693) or "scp" array claim.
+func parseScopes(scope interface{}) []string {
+	switch s := scope.(type) {
+	case string:
+		return strings.Fields(s)
+	case []interface{}:
+		scopes := make([]string, 0, len(s))
+
+		for _, v := range s {
+			if str, ok := v.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+
+		return scopes
+	default:
+		return nil
+	}
+}
+
+func stringClaim(claims map[string]interface{}, name string) string {
+	v, _ := claims[name].(string) //nolint:errcheck
+
+	return v
+}
+
+func infoFromClaims(claims map[string]interface{}, orgClaim string) *Info {
+	scopes := parseScopes(claims["scope"])
+	if scopes == nil {
+		scopes = parseScopes(claims["scp"])
+	}
+
+	return &Info{
+		Subject: stringClaim(claims, "sub"),
+		OrgID:   stringClaim(claims, orgClaim),
+		Scopes:  scopes,
+	}
+}
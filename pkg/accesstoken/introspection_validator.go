@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accesstoken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// IntrospectionValidatorConfig configures IntrospectionValidator.
+type IntrospectionValidatorConfig struct {
+	// Endpoint is OAuth 2.0 token introspection endpoint (RFC 7662).
+	Endpoint     string
+	ClientID     string
+	ClientSecret string
+	// OrgClaim is a name of the introspection response member that contains organization ID.
+	// Defaults to DefaultOrgClaim.
+	OrgClaim   string
+	HTTPClient httpClient
+}
+
+// IntrospectionValidator validates opaque access tokens using token introspection endpoint.
+type IntrospectionValidator struct {
+	endpoint     string
+	clientID     string
+	clientSecret string
+	orgClaim     string
+	httpClient   httpClient
+}
+
+// NewIntrospectionValidator creates IntrospectionValidator.
+func NewIntrospectionValidator(cfg *IntrospectionValidatorConfig) (*IntrospectionValidator, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("introspection endpoint is required")
+	}
+
+	orgClaim := cfg.OrgClaim
+	if orgClaim == "" {
+		orgClaim = DefaultOrgClaim
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &IntrospectionValidator{
+		endpoint:     cfg.Endpoint,
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		orgClaim:     orgClaim,
+		httpClient:   client,
+	}, nil
+}
+
+// Validate introspects access token and returns token info if token is active.
+func (v *IntrospectionValidator) Validate(ctx context.Context, token string) (*Info, error) {
+	form := url.Values{
+		"token":           {token},
+		"token_type_hint": {"access_token"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create introspection request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if v.clientID != "" {
+		req.SetBasicAuth(url.QueryEscape(v.clientID), url.QueryEscape(v.clientSecret))
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspect token: %w", err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspect token: unexpected status code %d", resp.StatusCode)
+	}
+
+	claims := map[string]interface{}{}
+
+	if err = json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("introspect token: decode response: %w", err)
+	}
+
+	if active, _ := claims["active"].(bool); !active { //nolint:errcheck
+		return nil, fmt.Errorf("%w: token is not active", ErrInvalidToken)
+	}
+
+	// Authorization servers are expected to report expired tokens as inactive, double-check if exp is returned.
+	if exp, ok := claims["exp"].(float64); ok && time.Unix(int64(exp), 0).Before(time.Now()) {
+		return nil, fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	}
+
+	return infoFromClaims(claims, v.orgClaim), nil
+}
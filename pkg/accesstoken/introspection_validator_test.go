@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accesstoken_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/accesstoken"
+)
+
+func TestIntrospectionValidator_Validate(t *testing.T) {
+	responses := map[string]map[string]interface{}{
+		"active": {
+			"active": true,
+			"sub":    "subject",
+			"scope":  "issuer:issue",
+			"org_id": "org1",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		},
+		"inactive": {
+			"active": false,
+		},
+		"expired": {
+			"active": true,
+			"exp":    time.Now().Add(-time.Hour).Unix(),
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "vcs" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "access_token", r.PostForm.Get("token_type_hint"))
+
+		resp, found := responses[r.PostForm.Get("token")]
+		if !found {
+			resp = responses["inactive"]
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	validator, err := accesstoken.NewIntrospectionValidator(&accesstoken.IntrospectionValidatorConfig{
+		Endpoint:     srv.URL,
+		ClientID:     "vcs",
+		ClientSecret: "secret",
+		HTTPClient:   srv.Client(),
+	})
+	require.NoError(t, err)
+
+	t.Run("Active token", func(t *testing.T) {
+		info, err := validator.Validate(context.Background(), "active")
+		require.NoError(t, err)
+		require.Equal(t, &accesstoken.Info{
+			Subject: "subject",
+			OrgID:   "org1",
+			Scopes:  []string{"issuer:issue"},
+		}, info)
+	})
+
+	t.Run("Inactive token", func(t *testing.T) {
+		_, err := validator.Validate(context.Background(), "inactive")
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		_, err := validator.Validate(context.Background(), "expired")
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+	})
+
+	t.Run("Introspection endpoint rejects client", func(t *testing.T) {
+		unauthorized, err := accesstoken.NewIntrospectionValidator(&accesstoken.IntrospectionValidatorConfig{
+			Endpoint:   srv.URL,
+			HTTPClient: srv.Client(),
+		})
+		require.NoError(t, err)
+
+		_, err = unauthorized.Validate(context.Background(), "active")
+		require.ErrorContains(t, err, "unexpected status code 401")
+		require.NotErrorIs(t, err, accesstoken.ErrInvalidToken)
+	})
+}
+
+func TestNewIntrospectionValidator(t *testing.T) {
+	_, err := accesstoken.NewIntrospectionValidator(&accesstoken.IntrospectionValidatorConfig{})
+	require.ErrorContains(t, err, "introspection endpoint is required")
+}
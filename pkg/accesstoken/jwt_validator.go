@@ -0,0 +1,247 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accesstoken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	openIDConfigurationPath = "/.well-known/openid-configuration"
+	// minJWKSRefreshInterval limits how often JWKS is re-fetched when token is signed with unknown key.
+	minJWKSRefreshInterval = time.Minute
+)
+
+// JWTValidatorConfig configures JWTValidator.
+type JWTValidatorConfig struct {
+	// Issuer is an expected "iss" claim of access tokens.
+	Issuer string
+	// JWKSURL is a location of issuer signing keys. If not set, it is discovered from issuer OpenID configuration.
+	JWKSURL string
+	// Audience is an expected "aud" claim of access tokens. Audience is not checked if empty.
+	Audience string
+	// OrgClaim is a name of the claim that contains organization ID. Defaults to DefaultOrgClaim.
+	OrgClaim   string
+	HTTPClient httpClient
+}
+
+// JWTValidator validates JWT access tokens signed by the configured issuer.
+type JWTValidator struct {
+	issuer     string
+	jwksURL    string
+	audience   string
+	orgClaim   string
+	httpClient httpClient
+
+	mutex       sync.Mutex
+	keys        *jose.JSONWebKeySet
+	refreshedAt time.Time
+	refreshes   singleflight.Group
+}
+
+// NewJWTValidator creates JWTValidator.
+func NewJWTValidator(cfg *JWTValidatorConfig) (*JWTValidator, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("issuer is required")
+	}
+
+	orgClaim := cfg.OrgClaim
+	if orgClaim == "" {
+		orgClaim = DefaultOrgClaim
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &JWTValidator{
+		issuer:     cfg.Issuer,
+		jwksURL:    cfg.JWKSURL,
+		audience:   cfg.Audience,
+		orgClaim:   orgClaim,
+		httpClient: client,
+	}, nil
+}
+
+// Validate verifies signature and standard claims of JWT access token and returns token info.
+func (v *JWTValidator) Validate(ctx context.Context, token string) (*Info, error) {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	if len(parsed.Headers) != 1 {
+		return nil, fmt.Errorf("%w: exactly one signature is expected", ErrInvalidToken)
+	}
+
+	key, err := v.signingKey(ctx, parsed.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		std    jwt.Claims
+		claims map[string]interface{}
+	)
+
+	if err = parsed.Claims(key, &std, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	if std.Expiry == nil {
+		return nil, fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+
+	expected := jwt.Expected{Issuer: v.issuer, Time: time.Now()}
+	if v.audience != "" {
+		expected.Audience = jwt.Audience{v.audience}
+	}
+
+	if err = std.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	return infoFromClaims(claims, v.orgClaim), nil
+}
+
+func (v *JWTValidator) signingKey(ctx context.Context, kid string) (*jose.JSONWebKey, error) {
+	v.mutex.Lock()
+	key := findKey(v.keys, kid)
+	refreshedAt := v.refreshedAt
+	v.mutex.Unlock()
+
+	if key != nil {
+		return key, nil
+	}
+
+	if time.Since(refreshedAt) < minJWKSRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+
+	// Keys are fetched without holding the lock, so that tokens signed with known keys are validated while
+	// the issuer is slow to respond. Concurrent refreshes are merged into one request.
+	keys, err, _ := v.refreshes.Do("jwks", func() (interface{}, error) {
+		return v.refreshKeys(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch issuer jwks: %w", err)
+	}
+
+	if key = findKey(keys.(*jose.JSONWebKeySet), kid); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+}
+
+// refreshKeys fetches issuer keys unless they were refreshed recently by another request.
+func (v *JWTValidator) refreshKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	v.mutex.Lock()
+	keys, refreshedAt := v.keys, v.refreshedAt
+	v.mutex.Unlock()
+
+	if time.Since(refreshedAt) < minJWKSRefreshInterval {
+		return keys, nil
+	}
+
+	keys, err := v.fetchKeys(ctx)
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	// Refresh time is updated on failure as well, so that the issuer is not requested for every token while
+	// it is unavailable.
+	v.refreshedAt = time.Now()
+
+	if err != nil {
+		return nil, err
+	}
+
+	v.keys = keys
+
+	return keys, nil
+}
+
+func findKey(keys *jose.JSONWebKeySet, kid string) *jose.JSONWebKey {
+	if keys == nil {
+		return nil
+	}
+
+	if kid == "" {
+		if len(keys.Keys) == 1 {
+			return &keys.Keys[0]
+		}
+
+		return nil
+	}
+
+	if found := keys.Key(kid); len(found) > 0 {
+		return &found[0]
+	}
+
+	return nil
+}
+
+func (v *JWTValidator) fetchKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	jwksURL := v.jwksURL
+
+	if jwksURL == "" {
+		var config struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+
+		if err := v.getJSON(ctx, strings.TrimSuffix(v.issuer, "/")+openIDConfigurationPath, &config); err != nil {
+			return nil, fmt.Errorf("get openid configuration: %w", err)
+		}
+
+		if config.JWKSURI == "" {
+			return nil, errors.New("jwks_uri is missing in openid configuration")
+		}
+
+		jwksURL = config.JWKSURI
+	}
+
+	keys := &jose.JSONWebKeySet{}
+
+	if err := v.getJSON(ctx, jwksURL, keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (v *JWTValidator) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,271 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accesstoken_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/accesstoken"
+)
+
+func TestJWTValidator_Validate(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       privateKey.Public(),
+		KeyID:     "key1",
+		Algorithm: string(jose.ES256),
+		Use:       "sig",
+	}}}
+
+	jwksRequests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{
+				"jwks_uri": "http://" + r.Host + "/jwks",
+			}))
+		case "/jwks":
+			jwksRequests++
+			require.NoError(t, json.NewEncoder(w).Encode(jwks))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	validator, err := accesstoken.NewJWTValidator(&accesstoken.JWTValidatorConfig{
+		Issuer:     srv.URL,
+		Audience:   "vcs",
+		HTTPClient: srv.Client(),
+	})
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		token := signToken(t, privateKey, "key1", validClaims(srv.URL), map[string]interface{}{
+			"scope":  "issuer:issue verifier:verify",
+			"org_id": "org1",
+		})
+
+		info, err := validator.Validate(context.Background(), token)
+		require.NoError(t, err)
+		require.Equal(t, "subject", info.Subject)
+		require.Equal(t, "org1", info.OrgID)
+		require.Equal(t, []string{"issuer:issue", "verifier:verify"}, info.Scopes)
+		require.True(t, info.HasScope("verifier:verify"))
+		require.False(t, info.HasScope("issuer:status"))
+	})
+
+	t.Run("Success with scp claim and custom org claim", func(t *testing.T) {
+		customValidator, err := accesstoken.NewJWTValidator(&accesstoken.JWTValidatorConfig{
+			Issuer:     srv.URL,
+			JWKSURL:    srv.URL + "/jwks",
+			OrgClaim:   "tenant",
+			HTTPClient: srv.Client(),
+		})
+		require.NoError(t, err)
+
+		token := signToken(t, privateKey, "key1", validClaims(srv.URL), map[string]interface{}{
+			"scp":    []string{"issuer:status"},
+			"tenant": "org2",
+		})
+
+		info, err := customValidator.Validate(context.Background(), token)
+		require.NoError(t, err)
+		require.Equal(t, "org2", info.OrgID)
+		require.Equal(t, []string{"issuer:status"}, info.Scopes)
+	})
+
+	t.Run("Keys are cached", func(t *testing.T) {
+		requests := jwksRequests
+
+		token := signToken(t, privateKey, "key1", validClaims(srv.URL), nil)
+
+		_, err := validator.Validate(context.Background(), token)
+		require.NoError(t, err)
+		require.Equal(t, requests, jwksRequests)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		claims := validClaims(srv.URL)
+		claims.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+		_, err := validator.Validate(context.Background(), signToken(t, privateKey, "key1", claims, nil))
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+		require.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("Missing exp", func(t *testing.T) {
+		claims := validClaims(srv.URL)
+		claims.Expiry = nil
+
+		_, err := validator.Validate(context.Background(), signToken(t, privateKey, "key1", claims, nil))
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+		require.ErrorContains(t, err, "missing exp")
+	})
+
+	t.Run("Invalid issuer", func(t *testing.T) {
+		_, err := validator.Validate(context.Background(),
+			signToken(t, privateKey, "key1", validClaims("https://other.example.com"), nil))
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+	})
+
+	t.Run("Invalid audience", func(t *testing.T) {
+		claims := validClaims(srv.URL)
+		claims.Audience = jwt.Audience{"other"}
+
+		_, err := validator.Validate(context.Background(), signToken(t, privateKey, "key1", claims, nil))
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		_, err = validator.Validate(context.Background(), signToken(t, otherKey, "key1", validClaims(srv.URL), nil))
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		_, err := validator.Validate(context.Background(),
+			signToken(t, privateKey, "unknown", validClaims(srv.URL), nil))
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+		require.ErrorContains(t, err, "unknown signing key")
+	})
+
+	t.Run("Malformed token", func(t *testing.T) {
+		_, err := validator.Validate(context.Background(), "not a jwt")
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+	})
+
+	t.Run("Discovery failed", func(t *testing.T) {
+		failingValidator, err := accesstoken.NewJWTValidator(&accesstoken.JWTValidatorConfig{
+			Issuer:     srv.URL + "/unknown",
+			HTTPClient: srv.Client(),
+		})
+		require.NoError(t, err)
+
+		_, err = failingValidator.Validate(context.Background(),
+			signToken(t, privateKey, "key1", validClaims(srv.URL+"/unknown"), nil))
+		require.ErrorContains(t, err, "get openid configuration")
+		require.NotErrorIs(t, err, accesstoken.ErrInvalidToken)
+
+		// Issuer is not requested again until refresh interval passes.
+		_, err = failingValidator.Validate(context.Background(),
+			signToken(t, privateKey, "key1", validClaims(srv.URL+"/unknown"), nil))
+		require.ErrorIs(t, err, accesstoken.ErrInvalidToken)
+		require.ErrorContains(t, err, "unknown signing key")
+	})
+}
+
+func TestJWTValidator_ConcurrentKeysRefresh(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       privateKey.Public(),
+		KeyID:     "key1",
+		Algorithm: string(jose.ES256),
+		Use:       "sig",
+	}}}
+
+	var jwksRequests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&jwksRequests, 1)
+
+		// Slow issuer, so that concurrent validations wait for the same refresh.
+		time.Sleep(100 * time.Millisecond)
+
+		require.NoError(t, json.NewEncoder(w).Encode(jwks))
+	}))
+	defer srv.Close()
+
+	validator, err := accesstoken.NewJWTValidator(&accesstoken.JWTValidatorConfig{
+		Issuer:     srv.URL,
+		JWKSURL:    srv.URL + "/jwks",
+		Audience:   "vcs",
+		HTTPClient: srv.Client(),
+	})
+	require.NoError(t, err)
+
+	token := signToken(t, privateKey, "key1", validClaims(srv.URL), nil)
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 10)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := validator.Validate(context.Background(), token)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.EqualValues(t, 1, atomic.LoadInt32(&jwksRequests))
+}
+
+func TestNewJWTValidator(t *testing.T) {
+	_, err := accesstoken.NewJWTValidator(&accesstoken.JWTValidatorConfig{})
+	require.ErrorContains(t, err, "issuer is required")
+}
+
+func validClaims(issuer string) jwt.Claims {
+	return jwt.Claims{
+		Issuer:   issuer,
+		Subject:  "subject",
+		Audience: jwt.Audience{"vcs"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}
+}
+
+func signToken(t *testing.T, key *ecdsa.PrivateKey, kid string, claims jwt.Claims,
+	customClaims map[string]interface{}) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", kid))
+	require.NoError(t, err)
+
+	builder := jwt.Signed(signer).Claims(claims)
+	if customClaims != nil {
+		builder = builder.Claims(customClaims)
+	}
+
+	token, err := builder.CompactSerialize()
+	require.NoError(t, err)
+
+	return token
+}
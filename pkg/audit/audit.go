@@ -0,0 +1,323 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Operation is a type of audited operation.
+type Operation string
+
+// Audited operations.
+const (
+	OperationIssueCredential Operation = "issue_credential"
+	OperationUpdateStatus    Operation = "update_credential_status"
+)
+
+// maxAppendAttempts limits retries of the append that races with appends of other VCS instances.
+const maxAppendAttempts = 10
+
+// ErrDuplicateSequence is returned by the store if entry with the same sequence number already exists
+// in the organization audit log.
+var ErrDuplicateSequence = errors.New("duplicate audit entry sequence")
+
+var errInvalidAnchorSignature = errors.New("audit anchor signature is invalid")
+
+// Entry is an audit log entry. Every organization has its own audit log. Entries are chained: hash of the
+// entry covers all its fields together with the hash of the previous entry of the organization, so any change
+// or removal of an entry breaks the chain.
+type Entry struct {
+	Seq             int64     `json:"seq"`
+	Timestamp       time.Time `json:"timestamp"`
+	Actor           string    `json:"actor,omitempty"`
+	OrgID           string    `json:"orgID,omitempty"`
+	ProfileID       string    `json:"profileID,omitempty"`
+	Operation       Operation `json:"operation"`
+	CredentialID    string    `json:"credentialID,omitempty"`
+	StatusListIndex string    `json:"statusListIndex,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	RequestID       string    `json:"requestID,omitempty"`
+	PrevHash        string    `json:"prevHash"`
+	Hash            string    `json:"hash"`
+}
+
+// Filter selects audit log entries. Empty fields are not used for filtering.
+type Filter struct {
+	OrgID        string
+	ProfileID    string
+	Operation    Operation
+	CredentialID string
+	Actor        string
+	From         *time.Time
+	To           *time.Time
+	// AfterSeq selects entries with sequence number greater than the given one.
+	AfterSeq int64
+	// Limit is a maximum number of entries to select. No limit if zero.
+	Limit int64
+}
+
+// VerificationResult is a result of audit log hash chain verification.
+type VerificationResult struct {
+	Valid bool `json:"valid"`
+	// Entries is a number of verified entries.
+	Entries int64 `json:"entries"`
+	// FirstInvalidSeq is a sequence number of the first entry that breaks the chain.
+	FirstInvalidSeq int64 `json:"firstInvalidSeq,omitempty"`
+	// Anchored is true if the log is checked against signed anchor of its last entry.
+	Anchored bool `json:"anchored"`
+}
+
+// Anchor is a signed reference to the last entry of the organization audit log. Anchor is kept apart from
+// entries, so removal of the last entries, which doesn't break the chain, is detected by verification.
+type Anchor struct {
+	OrgID     string `json:"orgID"`
+	Seq       int64  `json:"seq"`
+	Hash      string `json:"hash"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type store interface {
+	// Append stores entry. Returns ErrDuplicateSequence if entry with the same sequence number exists
+	// in the organization audit log.
+	Append(ctx context.Context, entry *Entry) error
+	// Last returns the entry of the organization with the greatest sequence number or nil if log is empty.
+	Last(ctx context.Context, orgID string) (*Entry, error)
+	// Iterate calls fn for entries selected by filter in ascending order of sequence numbers.
+	Iterate(ctx context.Context, filter *Filter, fn func(entry *Entry) error) error
+	// SaveAnchor stores anchor of the organization audit log unless anchor of a later entry is stored.
+	SaveAnchor(ctx context.Context, anchor *Anchor) error
+	// GetAnchor returns anchor of the organization audit log or nil if there is none.
+	GetAnchor(ctx context.Context, orgID string) (*Anchor, error)
+}
+
+// Config defines configuration for audit Service.
+type Config struct {
+	Store store
+	// AnchorKey is a key used to sign anchors of audit logs. Anchors are not stored if key is not set.
+	AnchorKey []byte
+}
+
+// Service appends entries to the audit log and queries them.
+type Service struct {
+	store     store
+	anchorKey []byte
+	timeNowFn func() time.Time
+}
+
+// NewService creates audit Service.
+func NewService(config *Config) *Service {
+	return &Service{
+		store:     config.Store,
+		anchorKey: config.AnchorKey,
+		timeNowFn: time.Now,
+	}
+}
+
+// Record appends entry to the audit log of the entry organization and updates the log anchor. Sequence number,
+// timestamp and hashes are set by the service. Entries appended concurrently are detected by the store,
+// in which case the entry is chained to the new last entry.
+func (s *Service) Record(ctx context.Context, entry *Entry) error {
+	for attempt := 1; ; attempt++ {
+		last, err := s.store.Last(ctx, entry.OrgID)
+		if err != nil {
+			return fmt.Errorf("get last audit entry: %w", err)
+		}
+
+		entry.Seq, entry.PrevHash = 1, ""
+		if last != nil {
+			entry.Seq, entry.PrevHash = last.Seq+1, last.Hash
+		}
+
+		// Timestamps are stored with millisecond precision.
+		entry.Timestamp = s.timeNowFn().UTC().Truncate(time.Millisecond)
+
+		entry.Hash, err = computeHash(entry)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.Append(ctx, entry)
+		if errors.Is(err, ErrDuplicateSequence) && attempt < maxAppendAttempts {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("append audit entry: %w", err)
+		}
+
+		return s.saveAnchor(ctx, entry)
+	}
+}
+
+func (s *Service) saveAnchor(ctx context.Context, entry *Entry) error {
+	if s.anchorKey == nil {
+		return nil
+	}
+
+	anchor := &Anchor{
+		OrgID: entry.OrgID,
+		Seq:   entry.Seq,
+		Hash:  entry.Hash,
+	}
+
+	anchor.Signature = s.signAnchor(anchor)
+
+	if err := s.store.SaveAnchor(ctx, anchor); err != nil {
+		return fmt.Errorf("save audit anchor: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) signAnchor(anchor *Anchor) string {
+	mac := hmac.New(sha256.New, s.anchorKey)
+	// Fields are separated by zero byte that is not allowed in any of them.
+	mac.Write([]byte(fmt.Sprintf("%s\x00%d\x00%s", anchor.OrgID, anchor.Seq, anchor.Hash)))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Iterate calls fn for entries selected by filter in ascending order of sequence numbers.
+func (s *Service) Iterate(ctx context.Context, filter *Filter, fn func(entry *Entry) error) error {
+	return s.store.Iterate(ctx, filter, fn)
+}
+
+// Query returns entries selected by filter.
+func (s *Service) Query(ctx context.Context, filter *Filter) ([]*Entry, error) {
+	var entries []*Entry
+
+	err := s.store.Iterate(ctx, filter, func(entry *Entry) error {
+		entries = append(entries, entry)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Verify checks hash chain of the organization audit log. If anchors are signed, the log must contain
+// the entry referred by the anchor.
+func (s *Service) Verify(ctx context.Context, orgID string) (*VerificationResult, error) {
+	anchor, err := s.getAnchor(ctx, orgID)
+	if errors.Is(err, errInvalidAnchorSignature) {
+		return &VerificationResult{Valid: false}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerificationResult{Valid: true, Anchored: anchor != nil}
+
+	var prev *Entry
+
+	errChainBroken := errors.New("chain broken")
+
+	err = s.store.Iterate(ctx, &Filter{OrgID: orgID}, func(entry *Entry) error {
+		if err := verifyEntry(entry, prev); err != nil {
+			result.Valid = false
+			result.FirstInvalidSeq = entry.Seq
+
+			return errChainBroken
+		}
+
+		if anchor != nil && entry.Seq == anchor.Seq && entry.Hash != anchor.Hash {
+			result.Valid = false
+			result.FirstInvalidSeq = entry.Seq
+
+			return errChainBroken
+		}
+
+		result.Entries++
+		prev = entry
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+
+	// Entries up to the anchored one were removed.
+	if result.Valid && anchor != nil && result.Entries < anchor.Seq {
+		result.Valid = false
+		result.FirstInvalidSeq = result.Entries + 1
+	}
+
+	return result, nil
+}
+
+// getAnchor returns anchor of the organization audit log. Anchor with invalid signature is rejected,
+// anchors are not used if the service has no anchor key.
+func (s *Service) getAnchor(ctx context.Context, orgID string) (*Anchor, error) {
+	if s.anchorKey == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	anchor, err := s.store.GetAnchor(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("get audit anchor: %w", err)
+	}
+
+	if anchor == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	if !hmac.Equal([]byte(anchor.Signature), []byte(s.signAnchor(anchor))) {
+		return nil, errInvalidAnchorSignature
+	}
+
+	return anchor, nil
+}
+
+func verifyEntry(entry, prev *Entry) error {
+	expectedSeq, expectedPrevHash := int64(1), ""
+	if prev != nil {
+		expectedSeq, expectedPrevHash = prev.Seq+1, prev.Hash
+	}
+
+	if entry.Seq != expectedSeq || entry.PrevHash != expectedPrevHash {
+		return errors.New("entry is not chained to the previous one")
+	}
+
+	hash, err := computeHash(entry)
+	if err != nil {
+		return err
+	}
+
+	if hash != entry.Hash {
+		return errors.New("entry hash mismatch")
+	}
+
+	return nil
+}
+
+func computeHash(entry *Entry) (string, error) {
+	e := *entry
+	e.Hash = ""
+	e.Timestamp = e.Timestamp.UTC()
+
+	data, err := json.Marshal(&e)
+	if err != nil {
+		return "", fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/audit"
+)
+
+type memStore struct {
+	entries []*audit.Entry
+	anchors map[string]*audit.Anchor
+	// conflicts is a number of Append calls that fail with duplicate sequence error.
+	conflicts int
+	err       error
+}
+
+func (s *memStore) Append(_ context.Context, entry *audit.Entry) error {
+	if s.conflicts > 0 {
+		s.conflicts--
+
+		// Simulates entry appended by another instance.
+		s.entries = append(s.entries, &audit.Entry{OrgID: entry.OrgID, Seq: entry.Seq, Hash: "other"})
+
+		return audit.ErrDuplicateSequence
+	}
+
+	e := *entry
+	s.entries = append(s.entries, &e)
+
+	return s.err
+}
+
+func (s *memStore) Last(_ context.Context, orgID string) (*audit.Entry, error) {
+	var last *audit.Entry
+
+	for _, e := range s.entries {
+		if e.OrgID == orgID {
+			last = e
+		}
+	}
+
+	return last, s.err
+}
+
+func (s *memStore) SaveAnchor(_ context.Context, anchor *audit.Anchor) error {
+	if s.anchors == nil {
+		s.anchors = map[string]*audit.Anchor{}
+	}
+
+	if stored, ok := s.anchors[anchor.OrgID]; !ok || stored.Seq < anchor.Seq {
+		a := *anchor
+		s.anchors[anchor.OrgID] = &a
+	}
+
+	return nil
+}
+
+func (s *memStore) GetAnchor(_ context.Context, orgID string) (*audit.Anchor, error) {
+	return s.anchors[orgID], s.err
+}
+
+func (s *memStore) Iterate(_ context.Context, filter *audit.Filter, fn func(entry *audit.Entry) error) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	for _, e := range s.entries {
+		if filter.OrgID != "" && e.OrgID != filter.OrgID {
+			continue
+		}
+
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestService_Record(t *testing.T) {
+	store := &memStore{}
+	svc := audit.NewService(&audit.Config{Store: store})
+
+	require.NoError(t, svc.Record(context.Background(), &audit.Entry{
+		OrgID:        "org1",
+		ProfileID:    "profile1",
+		Operation:    audit.OperationIssueCredential,
+		CredentialID: "urn:uuid:1",
+	}))
+
+	require.NoError(t, svc.Record(context.Background(), &audit.Entry{
+		OrgID:        "org2",
+		ProfileID:    "profile2",
+		Operation:    audit.OperationUpdateStatus,
+		CredentialID: "urn:uuid:1",
+		Status:       "true",
+	}))
+
+	require.NoError(t, svc.Record(context.Background(), &audit.Entry{
+		OrgID:        "org1",
+		ProfileID:    "profile1",
+		Operation:    audit.OperationUpdateStatus,
+		CredentialID: "urn:uuid:1",
+		Status:       "true",
+	}))
+
+	require.Len(t, store.entries, 3)
+	require.Equal(t, int64(1), store.entries[0].Seq)
+	require.Empty(t, store.entries[0].PrevHash)
+	require.NotEmpty(t, store.entries[0].Hash)
+	require.False(t, store.entries[0].Timestamp.IsZero())
+	require.Equal(t, int64(1), store.entries[1].Seq)
+	require.Empty(t, store.entries[1].PrevHash)
+	require.Equal(t, int64(2), store.entries[2].Seq)
+	require.Equal(t, store.entries[0].Hash, store.entries[2].PrevHash)
+	require.Empty(t, store.anchors)
+
+	entries, err := svc.Query(context.Background(), &audit.Filter{OrgID: "org2"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, audit.OperationUpdateStatus, entries[0].Operation)
+
+	result, err := svc.Verify(context.Background(), "org1")
+	require.NoError(t, err)
+	require.Equal(t, &audit.VerificationResult{Valid: true, Entries: 2}, result)
+
+	t.Run("Concurrent append", func(t *testing.T) {
+		conflictStore := &memStore{conflicts: 1}
+
+		require.NoError(t, audit.NewService(&audit.Config{Store: conflictStore}).Record(context.Background(),
+			&audit.Entry{Operation: audit.OperationIssueCredential}))
+
+		require.Len(t, conflictStore.entries, 2)
+		require.Equal(t, int64(2), conflictStore.entries[1].Seq)
+		require.Equal(t, "other", conflictStore.entries[1].PrevHash)
+	})
+
+	t.Run("Too many concurrent appends", func(t *testing.T) {
+		conflictStore := &memStore{conflicts: 100}
+
+		err := audit.NewService(&audit.Config{Store: conflictStore}).Record(context.Background(),
+			&audit.Entry{Operation: audit.OperationIssueCredential})
+		require.ErrorIs(t, err, audit.ErrDuplicateSequence)
+	})
+
+	t.Run("Anchor", func(t *testing.T) {
+		anchorStore := &memStore{}
+		anchorSvc := audit.NewService(&audit.Config{Store: anchorStore, AnchorKey: []byte("key")})
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, anchorSvc.Record(context.Background(), &audit.Entry{
+				OrgID:     "org1",
+				Operation: audit.OperationIssueCredential,
+			}))
+		}
+
+		anchor := anchorStore.anchors["org1"]
+		require.NotNil(t, anchor)
+		require.Equal(t, int64(2), anchor.Seq)
+		require.Equal(t, anchorStore.entries[1].Hash, anchor.Hash)
+		require.NotEmpty(t, anchor.Signature)
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		failingSvc := audit.NewService(&audit.Config{Store: &memStore{err: errors.New("store error")}})
+
+		err := failingSvc.Record(context.Background(), &audit.Entry{Operation: audit.OperationIssueCredential})
+		require.ErrorContains(t, err, "get last audit entry")
+
+		_, err = failingSvc.Query(context.Background(), &audit.Filter{})
+		require.ErrorContains(t, err, "store error")
+
+		_, err = failingSvc.Verify(context.Background(), "org1")
+		require.ErrorContains(t, err, "store error")
+	})
+}
+
+func TestService_Verify(t *testing.T) {
+	newLog := func(t *testing.T) (*memStore, *audit.Service) {
+		t.Helper()
+
+		store := &memStore{}
+		svc := audit.NewService(&audit.Config{Store: store, AnchorKey: []byte("key")})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, svc.Record(context.Background(), &audit.Entry{
+				OrgID:     "org1",
+				Operation: audit.OperationIssueCredential,
+			}))
+		}
+
+		require.NoError(t, svc.Record(context.Background(), &audit.Entry{
+			OrgID:     "org2",
+			Operation: audit.OperationIssueCredential,
+		}))
+
+		return store, svc
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		_, svc := newLog(t)
+
+		result, err := svc.Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.Equal(t, &audit.VerificationResult{Valid: true, Entries: 3, Anchored: true}, result)
+	})
+
+	t.Run("Modified entry", func(t *testing.T) {
+		store, svc := newLog(t)
+
+		store.entries[1].Actor = "actor"
+
+		result, err := svc.Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.Equal(t, &audit.VerificationResult{Valid: false, Entries: 1, FirstInvalidSeq: 2, Anchored: true},
+			result)
+
+		result, err = svc.Verify(context.Background(), "org2")
+		require.NoError(t, err)
+		require.True(t, result.Valid)
+	})
+
+	t.Run("Removed entry", func(t *testing.T) {
+		store, svc := newLog(t)
+
+		store.entries = append(store.entries[:1], store.entries[2:]...)
+
+		result, err := svc.Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.False(t, result.Valid)
+		require.Equal(t, int64(3), result.FirstInvalidSeq)
+	})
+
+	t.Run("Removed last entry", func(t *testing.T) {
+		store, svc := newLog(t)
+
+		store.entries = append(store.entries[:2], store.entries[3:]...)
+
+		result, err := svc.Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.Equal(t, &audit.VerificationResult{Valid: false, Entries: 2, FirstInvalidSeq: 3, Anchored: true},
+			result)
+	})
+
+	t.Run("Replaced last entry", func(t *testing.T) {
+		store, svc := newLog(t)
+
+		store.entries = append(store.entries[:2], store.entries[3:]...)
+
+		require.NoError(t, audit.NewService(&audit.Config{Store: store}).Record(context.Background(),
+			&audit.Entry{OrgID: "org1", Operation: audit.OperationUpdateStatus}))
+
+		result, err := svc.Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.False(t, result.Valid)
+		require.Equal(t, int64(3), result.FirstInvalidSeq)
+	})
+
+	t.Run("Forged anchor", func(t *testing.T) {
+		store, svc := newLog(t)
+
+		store.anchors["org1"].Seq = 2
+		store.anchors["org1"].Hash = store.entries[1].Hash
+
+		result, err := svc.Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.False(t, result.Valid)
+	})
+
+	t.Run("Empty log", func(t *testing.T) {
+		result, err := audit.NewService(&audit.Config{Store: &memStore{}}).Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.True(t, result.Valid)
+	})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dataprotect
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AESProtector encrypts and decrypts data at rest using AES-GCM with a static key.
+type AESProtector struct {
+	aead cipher.AEAD
+}
+
+// NewAESProtector creates AESProtector. Key length must be 16, 24 or 32 bytes.
+func NewAESProtector(key []byte) (*AESProtector, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create aes cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+
+	return &AESProtector{aead: aead}, nil
+}
+
+// NewAESProtectorFromFile creates AESProtector with base64 encoded key read from the given file.
+func NewAESProtectorFromFile(path string) (*AESProtector, error) {
+	key, err := ReadKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAESProtector(key)
+}
+
+// ReadKeyFile reads base64 encoded key from the given file.
+func ReadKeyFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Encrypt encrypts data. Random nonce is prepended to the returned cipher text.
+func (p *AESProtector) Encrypt(data []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	return p.aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// Decrypt decrypts data previously encrypted by Encrypt.
+func (p *AESProtector) Decrypt(data []byte) ([]byte, error) {
+	nonceSize := p.aead.NonceSize()
+
+	if len(data) < nonceSize {
+		return nil, errors.New("cipher text is too short")
+	}
+
+	plain, err := p.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+
+	return plain, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dataprotect
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESProtector(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("Encrypt and decrypt", func(t *testing.T) {
+		p, err := NewAESProtector(key)
+		require.NoError(t, err)
+
+		encrypted, err := p.Encrypt([]byte("claims"))
+		require.NoError(t, err)
+		require.NotContains(t, string(encrypted), "claims")
+
+		decrypted, err := p.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, "claims", string(decrypted))
+	})
+
+	t.Run("Invalid key size", func(t *testing.T) {
+		_, err := NewAESProtector([]byte("short"))
+		require.ErrorContains(t, err, "create aes cipher")
+	})
+
+	t.Run("Decrypt tampered data", func(t *testing.T) {
+		p, err := NewAESProtector(key)
+		require.NoError(t, err)
+
+		encrypted, err := p.Encrypt([]byte("claims"))
+		require.NoError(t, err)
+
+		encrypted[len(encrypted)-1] ^= 0xff
+
+		_, err = p.Decrypt(encrypted)
+		require.ErrorContains(t, err, "decrypt")
+
+		_, err = p.Decrypt([]byte("1"))
+		require.ErrorContains(t, err, "cipher text is too short")
+	})
+
+	t.Run("From file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+
+		p, err := NewAESProtectorFromFile(path)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		_, err = NewAESProtectorFromFile(filepath.Join(t.TempDir(), "missing"))
+		require.ErrorContains(t, err, "read key file")
+
+		require.NoError(t, os.WriteFile(path, []byte("not base64!"), 0600))
+
+		_, err = NewAESProtectorFromFile(path)
+		require.ErrorContains(t, err, "decode key")
+	})
+}
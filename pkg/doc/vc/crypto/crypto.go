@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package crypto
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -23,6 +24,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/piprate/json-gold/ld"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/trustbloc/vcs/pkg/doc/vc"
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
@@ -72,6 +77,8 @@ const (
 	VerificationMethod = "verificationMethod"
 )
 
+var tracer = otel.Tracer("github.com/trustbloc/vcs/pkg/doc/vc/crypto")
+
 type keyManager interface {
 	NewVCSigner(creator string, signatureType vcsverifiable.SignatureType) (vc.SignerAlgorithm, error)
 }
@@ -150,20 +157,37 @@ type Crypto struct {
 	documentLoader ld.DocumentLoader
 }
 
-func (c *Crypto) SignCredential(
+func (c *Crypto) SignCredential(ctx context.Context,
 	signerData *vc.Signer, vc *verifiable.Credential, opts ...SigningOpts) (*verifiable.Credential, error) {
+	ctx, span := tracer.Start(ctx, "crypto.SignCredential", trace.WithAttributes(
+		attribute.String("vc.format", string(signerData.Format)),
+	))
+	defer span.End()
+
+	var (
+		signedVC *verifiable.Credential
+		err      error
+	)
+
 	switch signerData.Format {
 	case vcsverifiable.Jwt:
-		return c.signCredentialJWT(signerData, vc, opts...)
+		signedVC, err = c.signCredentialJWT(ctx, signerData, vc, opts...)
 	case vcsverifiable.Ldp:
-		return c.signCredentialLDP(signerData, vc, opts...)
+		signedVC, err = c.signCredentialLDP(ctx, signerData, vc, opts...)
 	default:
-		return nil, fmt.Errorf("unknown signature format %s", signerData.Format)
+		err = fmt.Errorf("unknown signature format %s", signerData.Format)
 	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return signedVC, err
 }
 
 // signCredentialLDP adds verifiable.LinkedDataProofContext to the VC.
-func (c *Crypto) signCredentialLDP(
+func (c *Crypto) signCredentialLDP(ctx context.Context,
 	signerData *vc.Signer, vc *verifiable.Credential, opts ...SigningOpts) (*verifiable.Credential, error) {
 	signOpts := &signingOpts{}
 	// apply opts
@@ -176,8 +200,8 @@ func (c *Crypto) signCredentialLDP(
 		signatureType = signOpts.SignatureType
 	}
 
-	signingCtx, err := c.getLinkedDataProofContext(signerData.Creator, signerData.KMS, signatureType, AssertionMethod,
-		signerData.SignatureRepresentation, signOpts)
+	signingCtx, err := c.getLinkedDataProofContext(ctx, signerData.Creator, signerData.KMS, signatureType,
+		AssertionMethod, signerData.SignatureRepresentation, signOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +215,7 @@ func (c *Crypto) signCredentialLDP(
 }
 
 // signCredentialJWT returns vc in JWT format including the signature section.
-func (c *Crypto) signCredentialJWT(
+func (c *Crypto) signCredentialJWT(ctx context.Context,
 	signerData *vc.Signer, vc *verifiable.Credential, opts ...SigningOpts) (*verifiable.Credential, error) {
 	signOpts := &signingOpts{}
 	// apply opts
@@ -204,7 +228,7 @@ func (c *Crypto) signCredentialJWT(
 		signatureType = signOpts.SignatureType
 	}
 
-	s, method, err := c.getSigner(signerData.Creator, signerData.KMS, signOpts, signatureType)
+	s, method, err := c.getSigner(ctx, signerData.Creator, signerData.KMS, signOpts, signatureType)
 	if err != nil {
 		return nil, fmt.Errorf("getting signer for JWS: %w", err)
 	}
@@ -245,8 +269,11 @@ func (c *Crypto) signCredentialJWT(
 }
 
 // SignPresentation signs a presentation.
-func (c *Crypto) SignPresentation(signerData *vc.Signer, vp *verifiable.Presentation,
+func (c *Crypto) SignPresentation(ctx context.Context, signerData *vc.Signer, vp *verifiable.Presentation,
 	opts ...SigningOpts) (*verifiable.Presentation, error) {
+	ctx, span := tracer.Start(ctx, "crypto.SignPresentation")
+	defer span.End()
+
 	signOpts := &signingOpts{}
 	// apply opts
 	for _, opt := range opts {
@@ -258,7 +285,7 @@ func (c *Crypto) SignPresentation(signerData *vc.Signer, vp *verifiable.Presenta
 		signatureType = signOpts.SignatureType
 	}
 
-	signingCtx, err := c.getLinkedDataProofContext(
+	signingCtx, err := c.getLinkedDataProofContext(ctx,
 		signerData.Creator, signerData.KMS, signatureType, Authentication, signerData.SignatureRepresentation, signOpts)
 	if err != nil {
 		return nil, err
@@ -276,10 +303,10 @@ func (c *Crypto) SignPresentation(signerData *vc.Signer, vp *verifiable.Presenta
 	return vp, nil
 }
 
-func (c *Crypto) getLinkedDataProofContext(creator string, km keyManager,
+func (c *Crypto) getLinkedDataProofContext(ctx context.Context, creator string, km keyManager,
 	signatureType vcsverifiable.SignatureType, proofPurpose string,
 	signRep verifiable.SignatureRepresentation, opts *signingOpts) (*verifiable.LinkedDataProofContext, error) {
-	s, method, err := c.getSigner(creator, km, opts, signatureType)
+	s, method, err := c.getSigner(ctx, creator, km, opts, signatureType)
 	if err != nil {
 		return nil, err
 	}
@@ -344,7 +371,7 @@ func (c *Crypto) getLinkedDataProofContext(creator string, km keyManager,
 
 // getSigner returns signer and verification method based on profile and signing opts
 // verificationMethod from opts takes priority to create signer and verification method.
-func (c *Crypto) getSigner(creator string, km keyManager, opts *signingOpts,
+func (c *Crypto) getSigner(ctx context.Context, creator string, km keyManager, opts *signingOpts,
 	signatureType vcsverifiable.SignatureType) (vc.SignerAlgorithm, string, error) {
 	verificationMethod := creator
 	if opts.VerificationMethod != "" {
@@ -352,8 +379,31 @@ func (c *Crypto) getSigner(creator string, km keyManager, opts *signingOpts,
 	}
 
 	s, err := km.NewVCSigner(verificationMethod, signatureType)
+	if err != nil {
+		return nil, verificationMethod, err
+	}
+
+	return &tracingSigner{SignerAlgorithm: s, ctx: ctx}, verificationMethod, nil
+}
+
+// tracingSigner records a span for each KMS signing call. Signer is called by aries proof creators that
+// don't pass context, so context of the signing operation is kept in the signer.
+type tracingSigner struct {
+	vc.SignerAlgorithm
+	ctx context.Context //nolint:containedctx
+}
+
+func (s *tracingSigner) Sign(data []byte) ([]byte, error) {
+	_, span := tracer.Start(s.ctx, "kms.Sign", trace.WithAttributes(attribute.String("alg", s.Alg())))
+	defer span.End()
+
+	sig, err := s.SignerAlgorithm.Sign(data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
 
-	return s, verificationMethod, err
+	return sig, err
 }
 
 // ValidateProofPurpose validates the proof purpose.
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package crypto
 
 import (
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"errors"
@@ -41,7 +42,7 @@ func TestCrypto_SignCredentialLDP(t *testing.T) { //nolint:gocognit
 			testutil.DocumentLoader(t),
 		)
 
-		signedVC, err := c.signCredentialLDP(
+		signedVC, err := c.signCredentialLDP(context.Background(),
 			getTestSigner(), &verifiable.Credential{ID: "http://example.edu/credentials/1872"})
 		require.NoError(t, err)
 		require.Equal(t, 1, len(signedVC.Proofs))
@@ -181,7 +182,7 @@ func TestCrypto_SignCredentialLDP(t *testing.T) { //nolint:gocognit
 					vcSigner = tc.vcSigner
 				}
 
-				signedVC, err := c.signCredentialLDP(
+				signedVC, err := c.signCredentialLDP(context.Background(),
 					vcSigner, &verifiable.Credential{ID: "http://example.edu/credentials/1872"},
 					tc.signingOpts...)
 
@@ -227,7 +228,7 @@ func TestCrypto_SignCredentialLDP(t *testing.T) { //nolint:gocognit
 		)
 		p := getTestSigner()
 		p.Creator = "wrongValue"
-		signedVC, err := c.signCredentialLDP(
+		signedVC, err := c.signCredentialLDP(context.Background(),
 			p, &verifiable.Credential{ID: "http://example.edu/credentials/1872"})
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "verificationMethod value wrongValue should be in did#keyID format")
@@ -239,7 +240,7 @@ func TestCrypto_SignCredentialLDP(t *testing.T) { //nolint:gocognit
 			&vdrmock.MockVDRegistry{ResolveValue: createDIDDoc("did:trustbloc:abc")},
 			testutil.DocumentLoader(t),
 		)
-		signedVC, err := c.signCredentialLDP(
+		signedVC, err := c.signCredentialLDP(context.Background(),
 			getTestSignerWithCrypto(
 				&cryptomock.Crypto{SignErr: fmt.Errorf("failed to sign")}),
 			&verifiable.Credential{ID: "http://example.edu/credentials/1872"})
@@ -254,7 +255,7 @@ func TestCrypto_SignCredentialLDP(t *testing.T) { //nolint:gocognit
 
 		p := getTestSigner()
 
-		signedVC, err := c.signCredentialLDP(
+		signedVC, err := c.signCredentialLDP(context.Background(),
 			p, &verifiable.Credential{ID: "http://example.edu/credentials/1872"},
 			WithPurpose("invalid"))
 		require.Error(t, err)
@@ -268,7 +269,7 @@ func TestCrypto_SignCredentialLDP(t *testing.T) { //nolint:gocognit
 
 		p := getTestSigner()
 
-		signedVC, err := c.signCredentialLDP(
+		signedVC, err := c.signCredentialLDP(context.Background(),
 			p, &verifiable.Credential{ID: "http://example.edu/credentials/1872"},
 			WithPurpose(CapabilityInvocation))
 		require.NoError(t, err)
@@ -281,7 +282,7 @@ func TestCrypto_SignCredentialLDP(t *testing.T) { //nolint:gocognit
 
 		p := getTestSigner()
 
-		signedVC, err := c.signCredentialLDP(
+		signedVC, err := c.signCredentialLDP(context.Background(),
 			p, &verifiable.Credential{ID: "http://example.edu/credentials/1872"},
 			WithPurpose(CapabilityInvocation))
 		require.NoError(t, err)
@@ -296,7 +297,7 @@ func TestCrypto_SignCredentialBBS(t *testing.T) {
 			testutil.DocumentLoader(t),
 		)
 
-		signedVC, err := c.signCredentialLDP(
+		signedVC, err := c.signCredentialLDP(context.Background(),
 			&vc.Signer{
 				DID:           "did:trustbloc:abc",
 				SignatureType: "BbsBlsSignature2020",
@@ -315,7 +316,7 @@ func TestSignPresentation(t *testing.T) {
 			testutil.DocumentLoader(t),
 		)
 
-		signedVP, err := c.SignPresentation(getTestSigner(),
+		signedVP, err := c.SignPresentation(context.Background(), getTestSigner(),
 			&verifiable.Presentation{ID: "http://example.edu/presentation/1872"},
 		)
 		require.NoError(t, err)
@@ -328,7 +329,7 @@ func TestSignPresentation(t *testing.T) {
 			testutil.DocumentLoader(t),
 		)
 
-		signedVP, err := c.SignPresentation(getTestSigner(),
+		signedVP, err := c.SignPresentation(context.Background(), getTestSigner(),
 			&verifiable.Presentation{ID: "http://example.edu/presentation/1872"},
 			WithSignatureType(vcsverifiable.Ed25519Signature2020),
 		)
@@ -342,7 +343,7 @@ func TestSignPresentation(t *testing.T) {
 			testutil.DocumentLoader(t),
 		)
 
-		signedVP, err := c.SignPresentation(getTestSigner(),
+		signedVP, err := c.SignPresentation(context.Background(), getTestSigner(),
 			&verifiable.Presentation{ID: "http://example.edu/presentation/1872"},
 			WithSignatureType("invalid"),
 		)
@@ -357,7 +358,7 @@ func TestSignPresentation(t *testing.T) {
 			nil,
 		)
 
-		signedVP, err := c.SignPresentation(getTestSigner(),
+		signedVP, err := c.SignPresentation(context.Background(), getTestSigner(),
 			&verifiable.Presentation{ID: "http://example.edu/presentation/1872"},
 			WithSignatureType(vcsverifiable.Ed25519Signature2018),
 		)
@@ -683,7 +684,7 @@ func TestCrypto_SignCredentialJWT(t *testing.T) {
 				vdr:            tt.fields.getVDR(),
 				documentLoader: testutil.DocumentLoader(t),
 			}
-			got, err := c.signCredentialJWT(tt.args.signerData, tt.args.getVC(), tt.args.opts...)
+			got, err := c.signCredentialJWT(context.Background(), tt.args.signerData, tt.args.getVC(), tt.args.opts...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SignCredentialJWT() error = %v, wantErr %v", err, tt.wantErr)
 				return
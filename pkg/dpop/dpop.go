@@ -0,0 +1,291 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+//go:generate mockgen -destination dpop_mocks_test.go -package dpop_test -source=dpop.go -mock_names store=MockStore
+
+package dpop
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/square/go-jose/v3"
+)
+
+const (
+	// HeaderName is a name of HTTP header that carries DPoP proof.
+	HeaderName = "DPoP"
+	// NonceHeaderName is a name of HTTP header the server passes DPoP nonce in.
+	NonceHeaderName = "DPoP-Nonce"
+	// AuthScheme is an authorization scheme (and token type) of DPoP-bound access tokens.
+	AuthScheme = "DPoP"
+
+	// ErrorInvalidProof is an OAuth error code returned when DPoP proof is invalid.
+	ErrorInvalidProof = "invalid_dpop_proof"
+	// ErrorUseNonce is an OAuth error code returned when DPoP proof does not contain valid server nonce.
+	ErrorUseNonce = "use_dpop_nonce"
+
+	proofType = "dpop+jwt"
+
+	defaultNonceTTL      = 5 * time.Minute
+	defaultProofLifetime = time.Minute
+	clockSkew            = 30 * time.Second
+	nonceTimeSize        = 8
+	nonceSecretSize      = 32
+)
+
+var (
+	// ErrInvalidProof is returned when DPoP proof is malformed, has invalid signature or claims, or is replayed.
+	ErrInvalidProof = errors.New("invalid dpop proof")
+	// ErrUseNonce is returned when DPoP proof has no nonce or the nonce is invalid or expired. The client should
+	// retry with a proof that contains the nonce passed in DPoP-Nonce header.
+	ErrUseNonce = errors.New("dpop proof must contain valid server nonce")
+)
+
+// supportedAlgs are asymmetric signature algorithms allowed for DPoP proofs.
+var supportedAlgs = map[string]struct{}{ //nolint:gochecknoglobals
+	string(jose.ES256): {},
+	string(jose.ES384): {},
+	string(jose.ES512): {},
+	string(jose.RS256): {},
+	string(jose.RS384): {},
+	string(jose.RS512): {},
+	string(jose.PS256): {},
+	string(jose.PS384): {},
+	string(jose.PS512): {},
+	string(jose.EdDSA): {},
+}
+
+type store interface {
+	// SaveProofID stores ID of the used proof, false is returned if the proof ID is already stored.
+	SaveProofID(ctx context.Context, id string, ttl time.Duration) (bool, error)
+}
+
+// Config configures Verifier.
+type Config struct {
+	Store store
+	// NonceSecret is the key server nonces are signed with. It must be the same on all server instances, so that
+	// a nonce issued by one instance is accepted by the others. Random secret is generated if not set.
+	NonceSecret []byte
+	// NonceTTL is the time server nonce is valid for. Defaults to 5m.
+	NonceTTL time.Duration
+	// ProofLifetime is the maximum age of DPoP proof based on its iat claim. Defaults to 1m.
+	ProofLifetime time.Duration
+}
+
+// Verifier issues server nonces and verifies DPoP proofs (RFC 9449). Server nonces are stateless: a nonce
+// consists of its issue time and HMAC of it, so issuing a nonce doesn't require storage.
+type Verifier struct {
+	store         store
+	nonceSecret   []byte
+	nonceTTL      time.Duration
+	proofLifetime time.Duration
+	now           func() time.Time
+}
+
+// proofClaims are claims of DPoP proof JWT.
+type proofClaims struct {
+	JTI             string `json:"jti"`
+	HTM             string `json:"htm"`
+	HTU             string `json:"htu"`
+	IssuedAt        int64  `json:"iat"`
+	AccessTokenHash string `json:"ath,omitempty"`
+	Nonce           string `json:"nonce,omitempty"`
+}
+
+// NewVerifier creates Verifier.
+func NewVerifier(config *Config) (*Verifier, error) {
+	v := &Verifier{
+		store:         config.Store,
+		nonceSecret:   config.NonceSecret,
+		nonceTTL:      config.NonceTTL,
+		proofLifetime: config.ProofLifetime,
+		now:           time.Now,
+	}
+
+	if v.nonceTTL == 0 {
+		v.nonceTTL = defaultNonceTTL
+	}
+
+	if v.proofLifetime == 0 {
+		v.proofLifetime = defaultProofLifetime
+	}
+
+	if len(v.nonceSecret) == 0 {
+		v.nonceSecret = make([]byte, nonceSecretSize)
+
+		if _, err := rand.Read(v.nonceSecret); err != nil {
+			return nil, fmt.Errorf("generate nonce secret: %w", err)
+		}
+	}
+
+	return v, nil
+}
+
+// NewNonce creates server nonce the client includes into subsequent DPoP proofs.
+func (v *Verifier) NewNonce() string {
+	issuedAt := make([]byte, nonceTimeSize)
+	binary.BigEndian.PutUint64(issuedAt, uint64(v.now().UnixNano()))
+
+	return base64.RawURLEncoding.EncodeToString(append(issuedAt, v.nonceMAC(issuedAt)...))
+}
+
+// nonceMAC returns HMAC of the nonce issue time. Nonce secret may be shared with other components, so the MAC
+// is bound to DPoP by the proof type prefix.
+func (v *Verifier) nonceMAC(issuedAt []byte) []byte {
+	mac := hmac.New(sha256.New, v.nonceSecret)
+	mac.Write([]byte(proofType))
+	mac.Write(issuedAt)
+
+	return mac.Sum(nil)
+}
+
+// Verify verifies DPoP proof sent with HTTP request of the given method and URL and returns JWK SHA-256
+// thumbprint of the proof key. If accessToken is set, the proof must contain its hash in ath claim.
+func (v *Verifier) Verify(ctx context.Context, proof, method, requestURL, accessToken string) (string, error) {
+	jws, err := jose.ParseSigned(proof)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+
+	if len(jws.Signatures) != 1 {
+		return "", fmt.Errorf("%w: exactly one signature is expected", ErrInvalidProof)
+	}
+
+	header := jws.Signatures[0].Protected
+
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != proofType {
+		return "", fmt.Errorf("%w: typ header must be %q", ErrInvalidProof, proofType)
+	}
+
+	if _, ok := supportedAlgs[header.Algorithm]; !ok {
+		return "", fmt.Errorf("%w: unsupported alg %q", ErrInvalidProof, header.Algorithm)
+	}
+
+	if header.JSONWebKey == nil || !header.JSONWebKey.IsPublic() || !header.JSONWebKey.Valid() {
+		return "", fmt.Errorf("%w: jwk header must contain public key", ErrInvalidProof)
+	}
+
+	payload, err := jws.Verify(header.JSONWebKey)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+
+	var claims proofClaims
+
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+
+	if err = v.validateClaims(&claims, method, requestURL, accessToken); err != nil {
+		return "", err
+	}
+
+	if err = v.validateNonce(claims.Nonce); err != nil {
+		return "", err
+	}
+
+	thumbprint, err := header.JSONWebKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidProof, err.Error())
+	}
+
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	// proof ID is scoped to the key, so that proofs of different clients do not collide
+	saved, err := v.store.SaveProofID(ctx, jkt+":"+claims.JTI, v.proofLifetime+2*clockSkew)
+	if err != nil {
+		return "", fmt.Errorf("save proof id: %w", err)
+	}
+
+	if !saved {
+		return "", fmt.Errorf("%w: proof is replayed", ErrInvalidProof)
+	}
+
+	return jkt, nil
+}
+
+func (v *Verifier) validateClaims(claims *proofClaims, method, requestURL, accessToken string) error {
+	if claims.JTI == "" {
+		return fmt.Errorf("%w: missing jti claim", ErrInvalidProof)
+	}
+
+	if claims.HTM != method {
+		return fmt.Errorf("%w: htm claim does not match request method", ErrInvalidProof)
+	}
+
+	if !sameURL(claims.HTU, requestURL) {
+		return fmt.Errorf("%w: htu claim does not match request url", ErrInvalidProof)
+	}
+
+	now := v.now()
+	iat := time.Unix(claims.IssuedAt, 0)
+
+	if iat.Before(now.Add(-v.proofLifetime-clockSkew)) || iat.After(now.Add(clockSkew)) {
+		return fmt.Errorf("%w: iat claim is out of acceptable range", ErrInvalidProof)
+	}
+
+	if accessToken != "" {
+		hash := sha256.Sum256([]byte(accessToken))
+
+		if claims.AccessTokenHash != base64.RawURLEncoding.EncodeToString(hash[:]) {
+			return fmt.Errorf("%w: ath claim does not match access token", ErrInvalidProof)
+		}
+	}
+
+	return nil
+}
+
+// validateNonce checks that the nonce was issued by the server (with the same nonce secret) and is not expired.
+func (v *Verifier) validateNonce(nonce string) error {
+	b, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(b) != nonceTimeSize+sha256.Size {
+		return ErrUseNonce
+	}
+
+	issuedAt := b[:nonceTimeSize]
+
+	if !hmac.Equal(b[nonceTimeSize:], v.nonceMAC(issuedAt)) {
+		return ErrUseNonce
+	}
+
+	now := v.now()
+	issued := time.Unix(0, int64(binary.BigEndian.Uint64(issuedAt)))
+
+	if issued.After(now.Add(clockSkew)) || now.Sub(issued) > v.nonceTTL {
+		return ErrUseNonce
+	}
+
+	return nil
+}
+
+// sameURL compares URLs ignoring query and fragment parts, scheme and host are compared case-insensitively.
+func sameURL(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(ua.Scheme, ub.Scheme) &&
+		strings.EqualFold(ua.Host, ub.Host) &&
+		ua.Path == ub.Path
+}
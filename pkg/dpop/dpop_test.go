@@ -0,0 +1,388 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dpop_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/square/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/dpop"
+)
+
+const (
+	tokenURL    = "https://vcs.example.com/oidc/token"
+	accessToken = "access-token"
+	nonceSecret = "nonce-secret"
+)
+
+func TestVerifier_NewNonce(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	verify := func(v *dpop.Verifier, nonce string) error {
+		claims := map[string]interface{}{
+			"jti":   "proof-id",
+			"htm":   "POST",
+			"htu":   tokenURL,
+			"iat":   time.Now().Unix(),
+			"nonce": nonce,
+		}
+
+		_, verifyErr := v.Verify(context.Background(), createProof(t, privateKey, jose.ES256, "dpop+jwt", claims),
+			"POST", tokenURL, "")
+
+		return verifyErr
+	}
+
+	newVerifier := func(t *testing.T, config *dpop.Config) *dpop.Verifier {
+		t.Helper()
+
+		store := NewMockStore(gomock.NewController(t))
+		store.EXPECT().SaveProofID(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+
+		config.Store = store
+
+		v, verifierErr := dpop.NewVerifier(config)
+		require.NoError(t, verifierErr)
+
+		return v
+	}
+
+	t.Run("nonce is accepted by verifier with the same secret", func(t *testing.T) {
+		v1 := newVerifier(t, &dpop.Config{NonceSecret: []byte(nonceSecret)})
+		v2 := newVerifier(t, &dpop.Config{NonceSecret: []byte(nonceSecret)})
+
+		n := v1.NewNonce()
+		require.NotEmpty(t, n)
+		require.NotEqual(t, n, v1.NewNonce())
+
+		require.NoError(t, verify(v1, n))
+		require.NoError(t, verify(v2, n))
+	})
+
+	t.Run("nonce is rejected by verifier with another secret", func(t *testing.T) {
+		v1 := newVerifier(t, &dpop.Config{NonceSecret: []byte(nonceSecret)})
+		v2 := newVerifier(t, &dpop.Config{})
+
+		require.ErrorIs(t, verify(v2, v1.NewNonce()), dpop.ErrUseNonce)
+	})
+
+	t.Run("tampered nonce", func(t *testing.T) {
+		v := newVerifier(t, &dpop.Config{NonceSecret: []byte(nonceSecret)})
+
+		raw, decodeErr := base64.RawURLEncoding.DecodeString(v.NewNonce())
+		require.NoError(t, decodeErr)
+
+		raw[0] ^= 0xff
+
+		require.ErrorIs(t, verify(v, base64.RawURLEncoding.EncodeToString(raw)), dpop.ErrUseNonce)
+	})
+
+	t.Run("malformed nonce", func(t *testing.T) {
+		v := newVerifier(t, &dpop.Config{NonceSecret: []byte(nonceSecret)})
+
+		require.ErrorIs(t, verify(v, "server-nonce"), dpop.ErrUseNonce)
+		require.ErrorIs(t, verify(v, "!"), dpop.ErrUseNonce)
+	})
+
+	t.Run("expired nonce", func(t *testing.T) {
+		v := newVerifier(t, &dpop.Config{NonceSecret: []byte(nonceSecret), NonceTTL: time.Nanosecond})
+
+		n := v.NewNonce()
+
+		time.Sleep(time.Millisecond)
+
+		require.ErrorIs(t, verify(v, n), dpop.ErrUseNonce)
+	})
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk := jose.JSONWebKey{Key: privateKey.Public()}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	jkt := base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	ath := sha256.Sum256([]byte(accessToken))
+
+	nonceVerifier, err := dpop.NewVerifier(&dpop.Config{NonceSecret: []byte(nonceSecret)})
+	require.NoError(t, err)
+
+	nonce := nonceVerifier.NewNonce()
+
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"jti":   "proof-id",
+			"htm":   "POST",
+			"htu":   tokenURL,
+			"iat":   time.Now().Unix(),
+			"nonce": nonce,
+			"ath":   base64.RawURLEncoding.EncodeToString(ath[:]),
+		}
+	}
+
+	var (
+		store *MockStore
+		proof string
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, jkt string, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				store.EXPECT().SaveProofID(gomock.Any(), jkt+":proof-id", 2*time.Minute).Return(true, nil)
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", validClaims())
+			},
+			check: func(t *testing.T, result string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, jkt, result)
+			},
+		},
+		{
+			name: "Success with query in request url",
+			setup: func() {
+				store.EXPECT().SaveProofID(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+
+				claims := validClaims()
+				claims["htu"] = "HTTPS://VCS.example.com/oidc/token?param=value"
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, result string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, jkt, result)
+			},
+		},
+		{
+			name: "Malformed proof",
+			setup: func() {
+				proof = "invalid"
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+			},
+		},
+		{
+			name: "Invalid typ header",
+			setup: func() {
+				proof = createProof(t, privateKey, jose.ES256, "JWT", validClaims())
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "typ header")
+			},
+		},
+		{
+			name: "Symmetric alg",
+			setup: func() {
+				signer, signerErr := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("secret")},
+					(&jose.SignerOptions{}).WithType("dpop+jwt"))
+				require.NoError(t, signerErr)
+
+				proof = sign(t, signer, validClaims())
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "unsupported alg")
+			},
+		},
+		{
+			name: "Missing jwk header",
+			setup: func() {
+				signer, signerErr := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: privateKey},
+					(&jose.SignerOptions{}).WithType("dpop+jwt"))
+				require.NoError(t, signerErr)
+
+				proof = sign(t, signer, validClaims())
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "jwk header")
+			},
+		},
+		{
+			name: "Method mismatch",
+			setup: func() {
+				claims := validClaims()
+				claims["htm"] = "GET"
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "htm claim")
+			},
+		},
+		{
+			name: "URL mismatch",
+			setup: func() {
+				claims := validClaims()
+				claims["htu"] = "https://vcs.example.com/oidc/credential"
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "htu claim")
+			},
+		},
+		{
+			name: "Missing jti",
+			setup: func() {
+				claims := validClaims()
+				delete(claims, "jti")
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "missing jti")
+			},
+		},
+		{
+			name: "Proof is too old",
+			setup: func() {
+				claims := validClaims()
+				claims["iat"] = time.Now().Add(-time.Hour).Unix()
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "iat claim")
+			},
+		},
+		{
+			name: "Access token hash mismatch",
+			setup: func() {
+				claims := validClaims()
+				claims["ath"] = "invalid"
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "ath claim")
+			},
+		},
+		{
+			name: "Missing nonce",
+			setup: func() {
+				claims := validClaims()
+				delete(claims, "nonce")
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrUseNonce)
+			},
+		},
+		{
+			name: "Invalid nonce",
+			setup: func() {
+				claims := validClaims()
+				claims["nonce"] = "server-nonce"
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", claims)
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrUseNonce)
+			},
+		},
+		{
+			name: "Replayed proof",
+			setup: func() {
+				store.EXPECT().SaveProofID(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", validClaims())
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, dpop.ErrInvalidProof)
+				require.ErrorContains(t, err, "replayed")
+			},
+		},
+		{
+			name: "Fail to save proof id",
+			setup: func() {
+				store.EXPECT().SaveProofID(gomock.Any(), gomock.Any(), gomock.Any()).Return(false,
+					errors.New("store error"))
+
+				proof = createProof(t, privateKey, jose.ES256, "dpop+jwt", validClaims())
+			},
+			check: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "save proof id: store error")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store = NewMockStore(gomock.NewController(t))
+
+			tt.setup()
+
+			v, verifierErr := dpop.NewVerifier(&dpop.Config{Store: store, NonceSecret: []byte(nonceSecret)})
+			require.NoError(t, verifierErr)
+
+			result, verifyErr := v.Verify(context.Background(), proof, "POST", tokenURL, accessToken)
+			tt.check(t, result, verifyErr)
+		})
+	}
+}
+
+func createProof(
+	t *testing.T,
+	privateKey *ecdsa.PrivateKey,
+	alg jose.SignatureAlgorithm,
+	typ string,
+	claims map[string]interface{},
+) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: privateKey},
+		(&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ)))
+	require.NoError(t, err)
+
+	return sign(t, signer, claims)
+}
+
+func sign(t *testing.T, signer jose.Signer, claims map[string]interface{}) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+
+	proof, err := jws.CompactSerialize()
+	require.NoError(t, err)
+
+	return proof
+}
@@ -28,6 +28,8 @@ const (
 	VerifierOIDCInteractionQRScanned = "oidc_interaction_qr_scanned"
 	// VerifierOIDCInteractionSucceeded verifier oidc event.
 	VerifierOIDCInteractionSucceeded = "oidc_interaction_succeeded"
+	// VerifierOIDCInteractionFailed verifier oidc event.
+	VerifierOIDCInteractionFailed = "oidc_interaction_failed"
 )
 
 type Payload []byte
@@ -53,6 +55,13 @@ type Event struct {
 
 	// Data defines message(required).
 	Data *json.RawMessage `json:"data"`
+
+	// TraceParent is a W3C trace context of the operation that produced the event (distributed tracing
+	// extension).
+	TraceParent string `json:"traceparent,omitempty"`
+
+	// TraceState is a W3C trace state of the operation that produced the event (distributed tracing extension).
+	TraceState string `json:"tracestate,omitempty"`
 }
 
 // Copy an event.
@@ -65,6 +74,8 @@ func (m *Event) Copy() *Event {
 		Type:            m.Type,
 		Time:            m.Time,
 		Data:            m.Data,
+		TraceParent:     m.TraceParent,
+		TraceState:      m.TraceState,
 	}
 }
 
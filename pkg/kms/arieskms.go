@@ -15,9 +15,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/hyperledger/aries-framework-go-ext/component/storage/mongodb"
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	webcrypto "github.com/hyperledger/aries-framework-go/pkg/crypto/webkms"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/kid/resolver"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
@@ -30,6 +33,7 @@ import (
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	"github.com/trustbloc/vcs/pkg/kms/key"
 	"github.com/trustbloc/vcs/pkg/kms/signer"
+	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
 )
 
 // nolint: gochecknoglobals
@@ -68,28 +72,37 @@ type crypto interface {
 
 type metricsProvider interface {
 	SignTime(value time.Duration)
+	KMSError(operation string)
 }
 
+const operationCreateKey = "create_key"
+
 type KeyManager struct {
-	keyManager keyManager
-	crypto     crypto
-	kmsType    Type
-	metrics    metricsProvider
+	keyManager    keyManager
+	crypto        crypto
+	kmsType       Type
+	metrics       metricsProvider
+	storeProvider storage.Provider
 }
 
 func NewAriesKeyManager(cfg *Config, metrics metricsProvider) (*KeyManager, error) {
+	if metrics == nil {
+		metrics = &noopMetricsProvider.NoMetrics{}
+	}
+
 	switch cfg.KMSType {
 	case Local:
-		km, cr, err := createLocalKMS(cfg)
+		km, cr, storeProvider, err := createLocalKMS(cfg)
 		if err != nil {
 			return nil, err
 		}
 
 		return &KeyManager{
-			kmsType:    cfg.KMSType,
-			keyManager: km,
-			crypto:     cr,
-			metrics:    metrics,
+			kmsType:       cfg.KMSType,
+			keyManager:    km,
+			crypto:        cr,
+			metrics:       metrics,
+			storeProvider: storeProvider,
 		}, nil
 	case Web:
 		return &KeyManager{
@@ -121,20 +134,20 @@ func NewAriesKeyManager(cfg *Config, metrics metricsProvider) (*KeyManager, erro
 	return nil, fmt.Errorf("unsupported kms type: %s", cfg.KMSType)
 }
 
-func createLocalKMS(cfg *Config) (keyManager, crypto, error) {
+func createLocalKMS(cfg *Config) (keyManager, crypto, storage.Provider, error) {
 	secretLockService, err := createLocalSecretLock(cfg.SecretLockKeyPath)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	storeProvider, err := createStoreProvider(cfg.DBType, cfg.DBURL, cfg.DBPrefix)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	kmsStore, err := kms.NewAriesProviderWrapper(storeProvider)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	kmsProv := kmsProvider{
@@ -144,15 +157,15 @@ func createLocalKMS(cfg *Config) (keyManager, crypto, error) {
 
 	localKms, err := localkms.New(keystoreLocalPrimaryKeyURI, kmsProv)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	crypto, err := tinkcrypto.New()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return localKms, crypto, nil
+	return localKms, crypto, storeProvider, nil
 }
 
 func (km *KeyManager) SupportedKeyTypes() []kms.KeyType {
@@ -164,11 +177,34 @@ func (km *KeyManager) SupportedKeyTypes() []kms.KeyType {
 }
 
 func (km *KeyManager) CreateJWKKey(keyType kms.KeyType) (string, *jwk.JWK, error) {
-	return key.JWKKeyCreator(keyType)(km.keyManager)
+	keyID, j, err := key.JWKKeyCreator(keyType)(km.keyManager)
+	if err != nil {
+		km.metrics.KMSError(operationCreateKey)
+
+		return "", nil, err
+	}
+
+	return keyID, j, nil
 }
 
 func (km *KeyManager) CreateCryptoKey(keyType kms.KeyType) (string, interface{}, error) {
-	return key.CryptoKeyCreator(keyType)(km.keyManager)
+	keyID, pubKey, err := key.CryptoKeyCreator(keyType)(km.keyManager)
+	if err != nil {
+		km.metrics.KMSError(operationCreateKey)
+
+		return "", nil, err
+	}
+
+	return keyID, pubKey, nil
+}
+
+// Close closes the storage of local KMS keys. Remote KMS has nothing to close.
+func (km *KeyManager) Close() error {
+	if km.storeProvider == nil {
+		return nil
+	}
+
+	return km.storeProvider.Close()
 }
 
 func (km *KeyManager) NewVCSigner(
@@ -176,6 +212,41 @@ func (km *KeyManager) NewVCSigner(
 	return signer.NewKMSSigner(km.keyManager, km.crypto, creator, signatureType, km.metrics)
 }
 
+// NewJWEDecrypter creates decrypter for JWE messages encrypted with ECDH-ES key wrapping to the KMS key with
+// the given ID. Recipient kid has "<prefix>#<KMS key ID>" format, JWE encrypted to other keys is rejected.
+func (km *KeyManager) NewJWEDecrypter(keyID string) (JWEDecrypter, error) {
+	keyManager, ok := km.keyManager.(kms.KeyManager)
+	if !ok {
+		return nil, fmt.Errorf("jwe decryption is not supported by %s kms", km.kmsType)
+	}
+
+	cr, ok := km.crypto.(cryptoapi.Crypto)
+	if !ok {
+		return nil, fmt.Errorf("jwe decryption is not supported by %s kms", km.kmsType)
+	}
+
+	return jose.NewJWEDecrypt([]resolver.KIDResolver{&keyIDResolver{keyID: keyID}}, cr, keyManager), nil
+}
+
+// keyIDResolver extracts KMS key ID from kid in "<prefix>#<KMS key ID>" format. Only the configured key is resolved,
+// so that the sender can't make VCS decrypt with any key the KMS holds.
+type keyIDResolver struct {
+	keyID string
+}
+
+func (r *keyIDResolver) Resolve(kid string) (*cryptoapi.PublicKey, error) {
+	idx := strings.LastIndex(kid, "#")
+	if idx < 0 || idx == len(kid)-1 {
+		return nil, fmt.Errorf("kid %s should be in prefix#keyID format", kid)
+	}
+
+	if kid[idx+1:] != r.keyID {
+		return nil, fmt.Errorf("kid %s does not refer to the decryption key", kid)
+	}
+
+	return &cryptoapi.PublicKey{KID: r.keyID}, nil
+}
+
 func createLocalSecretLock(keyPath string) (secretlock.Service, error) {
 	if keyPath == "" {
 		return nil, fmt.Errorf("no key defined for local secret lock")
@@ -8,9 +8,11 @@ package kms_test
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -19,6 +21,9 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	arieskms "github.com/hyperledger/aries-framework-go/pkg/kms"
 	dctest "github.com/ory/dockertest/v3"
 	dc "github.com/ory/dockertest/v3/docker"
@@ -70,6 +75,8 @@ func TestNewLocalKeyManager(t *testing.T) {
 		_, err = km.NewVCSigner("did", "EdDSA")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "verificationMethod value did should be in did#keyID format")
+
+		require.NoError(t, km.Close())
 	})
 
 	t.Run("Success mongodb", func(t *testing.T) {
@@ -116,6 +123,66 @@ func TestNewLocalKeyManager(t *testing.T) {
 	})
 }
 
+func TestKeyManager_NewJWEDecrypter(t *testing.T) {
+	km, err := kms.NewAriesKeyManager(&kms.Config{
+		KMSType:           kms.Local,
+		SecretLockKeyPath: secretLockKeyFile,
+		DBType:            "mem",
+	}, nil)
+	require.NoError(t, err)
+
+	keyID, jwk, err := km.CreateJWKKey(arieskms.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	ecKey, ok := jwk.Key.(*ecdsa.PublicKey)
+	require.True(t, ok)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	encrypt := func(kid string) *jose.JSONWebEncryption {
+		encrypter, err := jose.NewJWEEncrypt(jose.A256GCM, "", "", "", nil, []*cryptoapi.PublicKey{{
+			KID:   kid,
+			X:     ecKey.X.Bytes(),
+			Y:     ecKey.Y.Bytes(),
+			Curve: "NIST_P256",
+			Type:  "EC",
+		}}, cr)
+		require.NoError(t, err)
+
+		jwe, err := encrypter.Encrypt([]byte("secret message"))
+		require.NoError(t, err)
+
+		serialized, err := jwe.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		deserialized, err := jose.Deserialize(serialized)
+		require.NoError(t, err)
+
+		return deserialized
+	}
+
+	t.Run("success", func(t *testing.T) {
+		decrypter, err := km.NewJWEDecrypter(keyID)
+		require.NoError(t, err)
+
+		plaintext, err := decrypter.Decrypt(encrypt("profileID#" + keyID))
+		require.NoError(t, err)
+		require.Equal(t, "secret message", string(plaintext))
+	})
+
+	t.Run("kid refers to another key", func(t *testing.T) {
+		otherKeyID, _, err := km.CreateJWKKey(arieskms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		decrypter, err := km.NewJWEDecrypter(otherKeyID)
+		require.NoError(t, err)
+
+		_, err = decrypter.Decrypt(encrypt("profileID#" + keyID))
+		require.Error(t, err)
+	})
+}
+
 func TestNewWebKeyManager(t *testing.T) {
 	t.Run("wrong endpoint for kms web", func(t *testing.T) {
 		km, err := kms.NewAriesKeyManager(&kms.Config{
@@ -133,6 +200,8 @@ func TestNewWebKeyManager(t *testing.T) {
 
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unsupported protocol scheme")
+
+		require.NoError(t, km.Close())
 	})
 }
 
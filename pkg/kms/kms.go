@@ -11,6 +11,7 @@ package kms
 import (
 	"net/http"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 
@@ -44,4 +45,10 @@ type VCSKeyManager interface {
 	CreateJWKKey(keyType kms.KeyType) (string, *jwk.JWK, error)
 	CreateCryptoKey(keyType kms.KeyType) (string, interface{}, error)
 	NewVCSigner(creator string, signatureType vcsverifiable.SignatureType) (vc.SignerAlgorithm, error)
+	NewJWEDecrypter(keyID string) (JWEDecrypter, error)
+}
+
+// JWEDecrypter decrypts JWE encrypted to the key held in KMS.
+type JWEDecrypter interface {
+	Decrypt(jwe *jose.JSONWebEncryption) ([]byte, error)
 }
@@ -17,8 +17,15 @@ import (
 
 type metricsProvider interface {
 	SignTime(value time.Duration)
+	KMSError(operation string)
 }
 
+// KMS operations reported in metrics.
+const (
+	operationGetKey = "get_key"
+	operationSign   = "sign"
+)
+
 // KMSSigner to crypto sign a message.
 // Note: do not create an instance of KMSSigner directly. Use NewKMSSigner() instead.
 type KMSSigner struct {
@@ -40,6 +47,10 @@ type crypto interface {
 
 func NewKMSSigner(keyManager keyManager, c crypto, creator string,
 	signatureType vcsverifiable.SignatureType, metrics metricsProvider) (*KMSSigner, error) {
+	if metrics == nil {
+		metrics = &noopMetricsProvider.NoMetrics{}
+	}
+
 	// creator will contain didID#keyID
 	keyID, err := diddoc.GetKeyIDFromVerificationMethod(creator)
 	if err != nil {
@@ -48,11 +59,9 @@ func NewKMSSigner(keyManager keyManager, c crypto, creator string,
 
 	kh, err := keyManager.Get(keyID)
 	if err != nil {
-		return nil, err
-	}
+		metrics.KMSError(operationGetKey)
 
-	if metrics == nil {
-		metrics = &noopMetricsProvider.NoMetrics{}
+		return nil, err
 	}
 
 	return &KMSSigner{
@@ -70,12 +79,20 @@ func (s *KMSSigner) Sign(data []byte) ([]byte, error) {
 		s.metrics.SignTime(time.Since(startTime))
 	}()
 
+	var (
+		v   []byte
+		err error
+	)
+
 	if s.bbs {
-		return s.crypto.SignMulti(s.textToLines(string(data)), s.keyHandle)
+		v, err = s.crypto.SignMulti(s.textToLines(string(data)), s.keyHandle)
+	} else {
+		v, err = s.crypto.Sign(data, s.keyHandle)
 	}
 
-	v, err := s.crypto.Sign(data, s.keyHandle)
 	if err != nil {
+		s.metrics.KMSError(operationSign)
+
 		return nil, err
 	}
 
@@ -40,6 +40,15 @@ func TestKMSSigner_Alg(t *testing.T) {
 	}
 }
 
+type kmsErrorsMetrics struct {
+	noopMetricsProvider.NoMetrics
+	kmsErrors []string
+}
+
+func (m *kmsErrorsMetrics) KMSError(operation string) {
+	m.kmsErrors = append(m.kmsErrors, operation)
+}
+
 func TestKMSSigner_Sign(t *testing.T) {
 	type fields struct {
 		keyHandle interface{}
@@ -131,17 +140,21 @@ func TestKMSSigner_Sign(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			metrics := &kmsErrorsMetrics{}
 			s := &KMSSigner{
 				keyHandle: tt.fields.keyHandle,
 				crypto:    tt.fields.getCrypto(),
 				bbs:       tt.fields.bbs,
-				metrics:   &noopMetricsProvider.NoMetrics{},
+				metrics:   metrics,
 			}
 			got, err := s.Sign(tt.args.data)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Sign() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr && !reflect.DeepEqual(metrics.kmsErrors, []string{"sign"}) {
+				t.Errorf("Sign() kms errors = %v, want [sign]", metrics.kmsErrors)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Sign() got = %v, want %v", got, tt.want)
 			}
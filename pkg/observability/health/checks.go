@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hyperledger/aries-framework-go/pkg/store/ld"
+
+	"github.com/trustbloc/vcs/pkg/kms"
+	"github.com/trustbloc/vcs/pkg/lifecycle"
+)
+
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// MongoDBCheck checks MongoDB connectivity.
+func MongoDBCheck(client pinger) CheckFunc {
+	return client.Ping
+}
+
+type keyManagerProvider interface {
+	GetKeyManager(config *kms.Config) (kms.VCSKeyManager, error)
+}
+
+// KMSCheck checks that default key manager is available.
+func KMSCheck(registry keyManagerProvider) CheckFunc {
+	return func(context.Context) error {
+		keyManager, err := registry.GetKeyManager(nil)
+		if err != nil {
+			return fmt.Errorf("get key manager: %w", err)
+		}
+
+		if keyManager == nil {
+			return errors.New("key manager is not configured")
+		}
+
+		if len(keyManager.SupportedKeyTypes()) == 0 {
+			return errors.New("key manager supports no key types")
+		}
+
+		return nil
+	}
+}
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPCheck checks that service at the given URL is reachable. Any response other than server error is
+// considered successful.
+func HTTPCheck(client httpClient, url string) CheckFunc {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return fmt.Errorf("create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		defer resp.Body.Close() //nolint:errcheck
+
+		_, _ = io.Copy(io.Discard, resp.Body) //nolint:errcheck
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+type eventBus interface {
+	IsConnected() bool
+	State() lifecycle.State
+}
+
+// EventBusCheck checks that event bus is started and connected.
+func EventBusCheck(bus eventBus) CheckFunc {
+	return func(context.Context) error {
+		if state := bus.State(); state != lifecycle.StateStarted {
+			return fmt.Errorf("event bus is not started: state %d", state)
+		}
+
+		if !bus.IsConnected() {
+			return errors.New("event bus is not connected")
+		}
+
+		return nil
+	}
+}
+
+type drainer interface {
+	IsDraining() bool
+}
+
+// DrainCheck fails while the server is draining before shutdown, so that load balancers stop routing new
+// requests to it.
+func DrainCheck(d drainer) CheckFunc {
+	return func(context.Context) error {
+		if d.IsDraining() {
+			return errors.New("server is shutting down")
+		}
+
+		return nil
+	}
+}
+
+// LDContextStoreCheck checks that JSON-LD context store is readable and holds the given context.
+func LDContextStoreCheck(store ld.ContextStore, contextURL string) CheckFunc {
+	return func(context.Context) error {
+		if _, err := store.Get(contextURL); err != nil {
+			return fmt.Errorf("get context %s: %w", contextURL, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status is a health status of the service or its dependency.
+type Status string
+
+// Health statuses.
+const (
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded"
+	StatusFailed   Status = "failed"
+)
+
+const (
+	defaultTimeout       = 5 * time.Second
+	defaultSlowThreshold = time.Second
+)
+
+// CheckFunc checks availability of the dependency.
+type CheckFunc func(ctx context.Context) error
+
+// Check is a named dependency check.
+type Check struct {
+	Name string
+	Fn   CheckFunc
+	// Critical dependency failure makes the service not ready. Failure of non-critical dependency only
+	// degrades the service.
+	Critical bool
+}
+
+// ComponentResult is a result of the dependency check.
+type ComponentResult struct {
+	Name     string
+	Status   Status
+	Critical bool
+	Latency  time.Duration
+	Error    string
+}
+
+// Report is a result of all dependency checks.
+type Report struct {
+	Status     Status
+	Components []ComponentResult
+}
+
+// Config defines configuration for Checker.
+type Config struct {
+	Checks []Check
+	// Timeout of each check. Defaults to 5 seconds.
+	Timeout time.Duration
+	// SlowThreshold is a latency above which dependency is reported as degraded. Defaults to 1 second.
+	SlowThreshold time.Duration
+}
+
+// Checker runs dependency checks.
+type Checker struct {
+	checks        []Check
+	timeout       time.Duration
+	slowThreshold time.Duration
+}
+
+// NewChecker creates Checker.
+func NewChecker(config *Config) *Checker {
+	c := &Checker{
+		checks:        config.Checks,
+		timeout:       config.Timeout,
+		slowThreshold: config.SlowThreshold,
+	}
+
+	if c.timeout == 0 {
+		c.timeout = defaultTimeout
+	}
+
+	if c.slowThreshold == 0 {
+		c.slowThreshold = defaultSlowThreshold
+	}
+
+	return c
+}
+
+// Run runs all checks concurrently. Overall status is failed if any critical check has failed, degraded if
+// any non-critical check has failed or any check is slow.
+func (c *Checker) Run(ctx context.Context) *Report {
+	report := &Report{
+		Status:     StatusOK,
+		Components: make([]ComponentResult, len(c.checks)),
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range c.checks {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			report.Components[i] = c.run(ctx, &c.checks[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, r := range report.Components {
+		switch {
+		case r.Status == StatusFailed && r.Critical:
+			report.Status = StatusFailed
+		case r.Status != StatusOK && report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check *Check) ComponentResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Fn(ctx)
+	latency := time.Since(start)
+
+	result := ComponentResult{
+		Name:     check.Name,
+		Status:   StatusOK,
+		Critical: check.Critical,
+		Latency:  latency,
+	}
+
+	switch {
+	case err != nil:
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	case latency > c.slowThreshold:
+		result.Status = StatusDegraded
+	}
+
+	return result
+}
@@ -0,0 +1,220 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	arieskms "github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/doc/vc"
+	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
+	"github.com/trustbloc/vcs/pkg/kms"
+	"github.com/trustbloc/vcs/pkg/lifecycle"
+	"github.com/trustbloc/vcs/pkg/observability/health"
+)
+
+func TestChecker_Run(t *testing.T) {
+	okCheck := func(context.Context) error { return nil }
+	failingCheck := func(context.Context) error { return errors.New("unavailable") }
+	slowCheck := func(ctx context.Context) error {
+		select {
+		case <-time.After(20 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		report := health.NewChecker(&health.Config{
+			Checks: []health.Check{
+				{Name: "a", Fn: okCheck, Critical: true},
+				{Name: "b", Fn: okCheck},
+			},
+		}).Run(context.Background())
+
+		require.Equal(t, health.StatusOK, report.Status)
+		require.Len(t, report.Components, 2)
+		require.Equal(t, "a", report.Components[0].Name)
+		require.True(t, report.Components[0].Critical)
+		require.Equal(t, health.StatusOK, report.Components[1].Status)
+	})
+
+	t.Run("Non-critical failure", func(t *testing.T) {
+		report := health.NewChecker(&health.Config{
+			Checks: []health.Check{
+				{Name: "a", Fn: okCheck, Critical: true},
+				{Name: "b", Fn: failingCheck},
+			},
+		}).Run(context.Background())
+
+		require.Equal(t, health.StatusDegraded, report.Status)
+		require.Equal(t, health.StatusFailed, report.Components[1].Status)
+		require.Equal(t, "unavailable", report.Components[1].Error)
+	})
+
+	t.Run("Critical failure", func(t *testing.T) {
+		report := health.NewChecker(&health.Config{
+			Checks: []health.Check{
+				{Name: "a", Fn: failingCheck, Critical: true},
+				{Name: "b", Fn: failingCheck},
+			},
+		}).Run(context.Background())
+
+		require.Equal(t, health.StatusFailed, report.Status)
+	})
+
+	t.Run("Slow check", func(t *testing.T) {
+		report := health.NewChecker(&health.Config{
+			Checks:        []health.Check{{Name: "a", Fn: slowCheck, Critical: true}},
+			SlowThreshold: time.Millisecond,
+		}).Run(context.Background())
+
+		require.Equal(t, health.StatusDegraded, report.Status)
+		require.Equal(t, health.StatusDegraded, report.Components[0].Status)
+		require.GreaterOrEqual(t, report.Components[0].Latency, 20*time.Millisecond)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		report := health.NewChecker(&health.Config{
+			Checks:  []health.Check{{Name: "a", Fn: slowCheck, Critical: true}},
+			Timeout: time.Millisecond,
+		}).Run(context.Background())
+
+		require.Equal(t, health.StatusFailed, report.Status)
+		require.Equal(t, context.DeadlineExceeded.Error(), report.Components[0].Error)
+	})
+}
+
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestMongoDBCheck(t *testing.T) {
+	require.NoError(t, health.MongoDBCheck(pingerFunc(func(context.Context) error { return nil }))(
+		context.Background()))
+	require.ErrorContains(t, health.MongoDBCheck(pingerFunc(func(context.Context) error {
+		return errors.New("ping error")
+	}))(context.Background()), "ping error")
+}
+
+type keyManager struct {
+	keyTypes []arieskms.KeyType
+}
+
+func (m *keyManager) SupportedKeyTypes() []arieskms.KeyType {
+	return m.keyTypes
+}
+
+func (m *keyManager) CreateJWKKey(arieskms.KeyType) (string, *jwk.JWK, error) {
+	return "", nil, nil
+}
+
+func (m *keyManager) CreateCryptoKey(arieskms.KeyType) (string, interface{}, error) {
+	return "", nil, nil
+}
+
+func (m *keyManager) NewVCSigner(string, vcsverifiable.SignatureType) (vc.SignerAlgorithm, error) {
+	return nil, nil
+}
+
+func (m *keyManager) NewJWEDecrypter(string) (kms.JWEDecrypter, error) {
+	return nil, nil
+}
+
+func TestKMSCheck(t *testing.T) {
+	require.NoError(t, health.KMSCheck(kms.NewRegistry(
+		&keyManager{keyTypes: []arieskms.KeyType{arieskms.ED25519Type}}))(context.Background()))
+
+	require.ErrorContains(t, health.KMSCheck(kms.NewRegistry(&keyManager{}))(context.Background()),
+		"key manager supports no key types")
+
+	require.ErrorContains(t, health.KMSCheck(kms.NewRegistry(nil))(context.Background()),
+		"key manager is not configured")
+}
+
+func TestHTTPCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	require.NoError(t, health.HTTPCheck(srv.Client(), srv.URL)(context.Background()))
+	require.ErrorContains(t, health.HTTPCheck(srv.Client(), srv.URL+"/error")(context.Background()),
+		"unexpected status code: 502")
+	require.Error(t, health.HTTPCheck(srv.Client(), "http://invalid host")(context.Background()))
+}
+
+type eventBus struct {
+	*lifecycle.Lifecycle
+	connected bool
+}
+
+func (b *eventBus) IsConnected() bool {
+	return b.connected
+}
+
+func TestEventBusCheck(t *testing.T) {
+	bus := &eventBus{Lifecycle: lifecycle.New("test"), connected: true}
+
+	require.ErrorContains(t, health.EventBusCheck(bus)(context.Background()), "event bus is not started")
+
+	bus.Start()
+
+	require.NoError(t, health.EventBusCheck(bus)(context.Background()))
+
+	bus.connected = false
+
+	require.ErrorContains(t, health.EventBusCheck(bus)(context.Background()), "event bus is not connected")
+}
+
+type drainerFunc func() bool
+
+func (f drainerFunc) IsDraining() bool {
+	return f()
+}
+
+func TestDrainCheck(t *testing.T) {
+	require.NoError(t, health.DrainCheck(drainerFunc(func() bool { return false }))(context.Background()))
+	require.ErrorContains(t, health.DrainCheck(drainerFunc(func() bool { return true }))(context.Background()),
+		"server is shutting down")
+}
+
+func TestLDContextStoreCheck(t *testing.T) {
+	store, err := ldstore.NewContextStore(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	require.ErrorContains(t, health.LDContextStoreCheck(store, verifiable.ContextURI)(context.Background()),
+		"get context "+verifiable.ContextURI)
+
+	require.NoError(t, store.Import([]ldcontext.Document{{
+		URL:     verifiable.ContextURI,
+		Content: []byte(`{"@context":{}}`),
+	}}))
+
+	require.NoError(t, health.LDContextStoreCheck(store, verifiable.ContextURI)(context.Background()))
+}
@@ -20,6 +20,15 @@ func GetMetrics() metrics.Metrics {
 	return &NoMetrics{}
 }
 
-func (n *NoMetrics) SignTime(_ time.Duration)                             {}
-func (n *NoMetrics) CheckAuthorizationResponseTime(_ time.Duration)       {}
-func (n *NoMetrics) VerifyOIDCVerifiablePresentationTime(_ time.Duration) {}
+func (n *NoMetrics) SignTime(_ time.Duration)                                   {}
+func (n *NoMetrics) CheckAuthorizationResponseTime(_ time.Duration)             {}
+func (n *NoMetrics) VerifyOIDCVerifiablePresentationTime(_ time.Duration)       {}
+func (n *NoMetrics) ThrottledRequest(_ string)                                  {}
+func (n *NoMetrics) CredentialIssued(_, _ string)                               {}
+func (n *NoMetrics) CredentialStatusUpdated(_ string, _ bool)                   {}
+func (n *NoMetrics) VerificationCheck(_, _ string, _ bool)                      {}
+func (n *NoMetrics) OIDC4VCIOperationTime(_, _ string, _ bool, _ time.Duration) {}
+func (n *NoMetrics) OIDC4VPOperationTime(_, _ string, _ bool, _ time.Duration)  {}
+func (n *NoMetrics) WebhookDelivery(_ string, _ bool)                           {}
+func (n *NoMetrics) DIDResolutionTime(_ string, _ bool, _ time.Duration)        {}
+func (n *NoMetrics) KMSError(_ string)                                          {}
@@ -21,5 +21,14 @@ func TestMetrics(t *testing.T) {
 		require.NotPanics(t, func() { m.SignTime(time.Second) })
 		require.NotPanics(t, func() { m.CheckAuthorizationResponseTime(time.Second) })
 		require.NotPanics(t, func() { m.VerifyOIDCVerifiablePresentationTime(time.Second) })
+		require.NotPanics(t, func() { m.ThrottledRequest("client") })
+		require.NotPanics(t, func() { m.CredentialIssued("profile", "ldp") })
+		require.NotPanics(t, func() { m.CredentialStatusUpdated("profile", true) })
+		require.NotPanics(t, func() { m.VerificationCheck("profile", "proof", true) })
+		require.NotPanics(t, func() { m.OIDC4VCIOperationTime("profile", "initiate", true, time.Second) })
+		require.NotPanics(t, func() { m.OIDC4VPOperationTime("profile", "initiate", true, time.Second) })
+		require.NotPanics(t, func() { m.WebhookDelivery("profile", true) })
+		require.NotPanics(t, func() { m.DIDResolutionTime("key", true, time.Second) })
+		require.NotPanics(t, func() { m.KMSError("sign") })
 	})
 }
@@ -8,7 +8,7 @@ package prometheus
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"net/http"
 	"sync"
 	"time"
@@ -37,13 +37,15 @@ func NewPrometheusProvider(httpServer *http.Server) metrics.Provider {
 
 // Create creates/initializes the prometheus metrics provider.
 func (pp *promProvider) Create() error {
-	if pp.httpServer != nil {
+	if pp.httpServer == nil {
 		return nil
 	}
 
-	if err := pp.httpServer.ListenAndServe(); err != nil {
-		return fmt.Errorf("start metrics HTTP server: %w", err)
-	}
+	go func() {
+		if err := pp.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("metrics HTTP server failed", log.WithError(err))
+		}
+	}()
 
 	return nil
 }
@@ -73,17 +75,35 @@ func GetMetrics() metrics.Metrics {
 
 // PromMetrics manages the metrics for VCS.
 type PromMetrics struct {
-	signTime          prometheus.Histogram
-	checkAuthRespTime prometheus.Histogram
-	verifyOIDCVPTime  prometheus.Histogram
+	signTime                prometheus.Histogram
+	checkAuthRespTime       prometheus.Histogram
+	verifyOIDCVPTime        prometheus.Histogram
+	throttledRequests       *prometheus.CounterVec
+	credentialsIssued       *prometheus.CounterVec
+	credentialStatusUpdates *prometheus.CounterVec
+	verificationChecks      *prometheus.CounterVec
+	oidc4vciOperationTime   *prometheus.HistogramVec
+	oidc4vpOperationTime    *prometheus.HistogramVec
+	webhookDeliveries       *prometheus.CounterVec
+	didResolutionTime       *prometheus.HistogramVec
+	kmsErrors               *prometheus.CounterVec
 }
 
 // NewMetrics creates instance of prometheus metrics.
 func NewMetrics() metrics.Metrics {
 	pm := &PromMetrics{
-		signTime:          newSignTime(),
-		checkAuthRespTime: newCheckAuthRespTime(),
-		verifyOIDCVPTime:  newVerifyOIDCVPTime(),
+		signTime:                newSignTime(),
+		checkAuthRespTime:       newCheckAuthRespTime(),
+		verifyOIDCVPTime:        newVerifyOIDCVPTime(),
+		throttledRequests:       newThrottledRequests(),
+		credentialsIssued:       newCredentialsIssued(),
+		credentialStatusUpdates: newCredentialStatusUpdates(),
+		verificationChecks:      newVerificationChecks(),
+		oidc4vciOperationTime:   newOIDC4VCIOperationTime(),
+		oidc4vpOperationTime:    newOIDC4VPOperationTime(),
+		webhookDeliveries:       newWebhookDeliveries(),
+		didResolutionTime:       newDIDResolutionTime(),
+		kmsErrors:               newKMSErrors(),
 	}
 
 	registerMetrics(pm)
@@ -111,9 +131,64 @@ func (pm *PromMetrics) VerifyOIDCVerifiablePresentationTime(value time.Duration)
 	logger.Debug("VerifyOIDCVerifiablePresentation service call time", log.WithDuration(value))
 }
 
+// ThrottledRequest increments the number of requests rejected by the rate limit of the given type.
+func (pm *PromMetrics) ThrottledRequest(limitType string) {
+	pm.throttledRequests.WithLabelValues(limitType).Inc()
+}
+
+// CredentialIssued increments the number of credentials issued by the profile in the given format.
+func (pm *PromMetrics) CredentialIssued(profileID, format string) {
+	pm.credentialsIssued.WithLabelValues(profileID, format).Inc()
+}
+
+// CredentialStatusUpdated increments the number of credential status list updates of the profile.
+func (pm *PromMetrics) CredentialStatusUpdated(profileID string, success bool) {
+	pm.credentialStatusUpdates.WithLabelValues(profileID, result(success)).Inc()
+}
+
+// VerificationCheck increments the number of performed verification checks of the given type.
+func (pm *PromMetrics) VerificationCheck(profileID, check string, success bool) {
+	pm.verificationChecks.WithLabelValues(profileID, check, result(success)).Inc()
+}
+
+// OIDC4VCIOperationTime records the time and outcome of OIDC4VCI transaction operation.
+func (pm *PromMetrics) OIDC4VCIOperationTime(profileID, operation string, success bool, value time.Duration) {
+	pm.oidc4vciOperationTime.WithLabelValues(profileID, operation, result(success)).Observe(value.Seconds())
+}
+
+// OIDC4VPOperationTime records the time and outcome of OIDC4VP transaction operation.
+func (pm *PromMetrics) OIDC4VPOperationTime(profileID, operation string, success bool, value time.Duration) {
+	pm.oidc4vpOperationTime.WithLabelValues(profileID, operation, result(success)).Observe(value.Seconds())
+}
+
+// WebhookDelivery increments the number of webhook notifications sent for the profile.
+func (pm *PromMetrics) WebhookDelivery(profileID string, success bool) {
+	pm.webhookDeliveries.WithLabelValues(profileID, result(success)).Inc()
+}
+
+// DIDResolutionTime records the time of DID resolution.
+func (pm *PromMetrics) DIDResolutionTime(method string, success bool, value time.Duration) {
+	pm.didResolutionTime.WithLabelValues(method, result(success)).Observe(value.Seconds())
+}
+
+// KMSError increments the number of failed KMS operations.
+func (pm *PromMetrics) KMSError(operation string) {
+	pm.kmsErrors.WithLabelValues(operation).Inc()
+}
+
+func result(success bool) string {
+	if success {
+		return metrics.ResultSuccess
+	}
+
+	return metrics.ResultFailure
+}
+
 func registerMetrics(pm *PromMetrics) {
 	prometheus.MustRegister(
-		pm.signTime, pm.checkAuthRespTime, pm.verifyOIDCVPTime,
+		pm.signTime, pm.checkAuthRespTime, pm.verifyOIDCVPTime, pm.throttledRequests,
+		pm.credentialsIssued, pm.credentialStatusUpdates, pm.verificationChecks, pm.oidc4vciOperationTime,
+		pm.oidc4vpOperationTime, pm.webhookDeliveries, pm.didResolutionTime, pm.kmsErrors,
 	)
 }
 
@@ -127,6 +202,15 @@ func newCounter(subsystem, name, help string, labels prometheus.Labels) promethe
 	})
 }
 
+func newCounterVec(subsystem, name, help string, labelNames []string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labelNames)
+}
+
 func newGauge(subsystem, name, help string, labels prometheus.Labels) prometheus.Gauge {
 	return prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace:   metrics.Namespace,
@@ -147,6 +231,15 @@ func newHistogram(subsystem, name, help string, labels prometheus.Labels) promet
 	})
 }
 
+func newHistogramVec(subsystem, name, help string, labelNames []string) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labelNames)
+}
+
 func newSignTime() prometheus.Histogram {
 	return newHistogram(
 		metrics.Crypto, metrics.CryptoSignTimeMetric,
@@ -170,3 +263,75 @@ func newVerifyOIDCVPTime() prometheus.Histogram {
 		nil,
 	)
 }
+
+func newThrottledRequests() *prometheus.CounterVec {
+	return newCounterVec(
+		metrics.HTTP, metrics.HTTPThrottledRequests,
+		"The number of requests rejected by rate limits.",
+		[]string{"limit"},
+	)
+}
+
+func newCredentialsIssued() *prometheus.CounterVec {
+	return newCounterVec(
+		metrics.Issuer, metrics.IssuerCredentialsIssued,
+		"The number of issued credentials.",
+		[]string{"profile", "format"},
+	)
+}
+
+func newCredentialStatusUpdates() *prometheus.CounterVec {
+	return newCounterVec(
+		metrics.Issuer, metrics.IssuerCredentialStatusUpdates,
+		"The number of credential status list updates.",
+		[]string{"profile", "result"},
+	)
+}
+
+func newVerificationChecks() *prometheus.CounterVec {
+	return newCounterVec(
+		metrics.Verifier, metrics.VerifierChecks,
+		"The number of performed credential and presentation verification checks.",
+		[]string{"profile", "check", "result"},
+	)
+}
+
+func newOIDC4VCIOperationTime() *prometheus.HistogramVec {
+	return newHistogramVec(
+		metrics.OIDC4VCI, metrics.OIDC4VCIOperationTimeMetric,
+		"The time (in seconds) it takes to execute OIDC4VCI transaction operation.",
+		[]string{"profile", "operation", "result"},
+	)
+}
+
+func newOIDC4VPOperationTime() *prometheus.HistogramVec {
+	return newHistogramVec(
+		metrics.OIDC4VP, metrics.OIDC4VPOperationTimeMetric,
+		"The time (in seconds) it takes to execute OIDC4VP transaction operation.",
+		[]string{"profile", "operation", "result"},
+	)
+}
+
+func newWebhookDeliveries() *prometheus.CounterVec {
+	return newCounterVec(
+		metrics.Webhook, metrics.WebhookDeliveries,
+		"The number of webhook notifications sent.",
+		[]string{"profile", "result"},
+	)
+}
+
+func newDIDResolutionTime() *prometheus.HistogramVec {
+	return newHistogramVec(
+		metrics.VDR, metrics.VDRResolveTimeMetric,
+		"The time (in seconds) it takes to resolve DID.",
+		[]string{"method", "result"},
+	)
+}
+
+func newKMSErrors() *prometheus.CounterVec {
+	return newCounterVec(
+		metrics.KMS, metrics.KMSErrors,
+		"The number of failed KMS operations.",
+		[]string{"operation"},
+	)
+}
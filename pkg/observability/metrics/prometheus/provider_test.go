@@ -12,11 +12,12 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
 func TestPromProvider(t *testing.T) {
-	provider := NewPrometheusProvider(&http.Server{})
+	provider := NewPrometheusProvider(&http.Server{Addr: "127.0.0.1:0"})
 	require.NotNil(t, provider)
 
 	err := provider.Create()
@@ -38,6 +39,32 @@ func TestMetrics(t *testing.T) {
 		require.NotPanics(t, func() { m.SignTime(time.Second) })
 		require.NotPanics(t, func() { m.CheckAuthorizationResponseTime(time.Second) })
 		require.NotPanics(t, func() { m.CheckAuthorizationResponseTime(time.Second) })
+		require.NotPanics(t, func() { m.ThrottledRequest("profile") })
+	})
+
+	t.Run("Service Activity", func(t *testing.T) {
+		pm, ok := m.(*PromMetrics)
+		require.True(t, ok)
+
+		m.CredentialIssued("profile1", "ldp")
+		m.CredentialIssued("profile1", "ldp")
+		require.Equal(t, 2.0, testutil.ToFloat64(pm.credentialsIssued.WithLabelValues("profile1", "ldp")))
+
+		m.CredentialStatusUpdated("profile1", false)
+		require.Equal(t, 1.0, testutil.ToFloat64(pm.credentialStatusUpdates.WithLabelValues("profile1", "failure")))
+
+		m.VerificationCheck("profile2", "proof", true)
+		require.Equal(t, 1.0, testutil.ToFloat64(pm.verificationChecks.WithLabelValues("profile2", "proof", "success")))
+
+		m.WebhookDelivery("profile2", true)
+		require.Equal(t, 1.0, testutil.ToFloat64(pm.webhookDeliveries.WithLabelValues("profile2", "success")))
+
+		m.KMSError("sign")
+		require.Equal(t, 1.0, testutil.ToFloat64(pm.kmsErrors.WithLabelValues("sign")))
+
+		require.NotPanics(t, func() { m.OIDC4VCIOperationTime("profile1", "initiate", true, time.Second) })
+		require.NotPanics(t, func() { m.OIDC4VPOperationTime("profile2", "initiate", false, time.Second) })
+		require.NotPanics(t, func() { m.DIDResolutionTime("key", true, time.Second) })
 	})
 }
 
@@ -31,6 +31,41 @@ const (
 	// Service operations.
 	Service      = "service"
 	VerifyOIDCVP = "service_verifyOIDCVerifiablePresentation_seconds"
+
+	// HTTP server operations.
+	HTTP                  = "http"
+	HTTPThrottledRequests = "http_throttled_requests_total"
+
+	// Issuer operations.
+	Issuer                        = "issuer"
+	IssuerCredentialsIssued       = "issuer_credentials_issued_total"
+	IssuerCredentialStatusUpdates = "issuer_credential_status_updates_total"
+
+	// Verifier operations.
+	Verifier       = "verifier"
+	VerifierChecks = "verifier_checks_total"
+
+	// OIDC4VCI and OIDC4VP flows.
+	OIDC4VCI                    = "oidc4vci"
+	OIDC4VCIOperationTimeMetric = "oidc4vci_operation_seconds"
+	OIDC4VP                     = "oidc4vp"
+	OIDC4VPOperationTimeMetric  = "oidc4vp_operation_seconds"
+
+	// Webhook notifications.
+	Webhook           = "webhook"
+	WebhookDeliveries = "webhook_deliveries_total"
+
+	// DID resolution.
+	VDR                  = "vdr"
+	VDRResolveTimeMetric = "vdr_resolve_seconds"
+
+	// KMS operations.
+	KMS       = "kms"
+	KMSErrors = "kms_errors_total"
+
+	// Label values of operation result.
+	ResultSuccess = "success"
+	ResultFailure = "failure"
 )
 
 // Provider is an interface for metrics provider.
@@ -50,4 +85,13 @@ type Metrics interface {
 	SignTime(value time.Duration)
 	CheckAuthorizationResponseTime(value time.Duration)
 	VerifyOIDCVerifiablePresentationTime(value time.Duration)
+	ThrottledRequest(limitType string)
+	CredentialIssued(profileID, format string)
+	CredentialStatusUpdated(profileID string, success bool)
+	VerificationCheck(profileID, check string, success bool)
+	OIDC4VCIOperationTime(profileID, operation string, success bool, value time.Duration)
+	OIDC4VPOperationTime(profileID, operation string, success bool, value time.Duration)
+	WebhookDelivery(profileID string, success bool)
+	DIDResolutionTime(method string, success bool, value time.Duration)
+	KMSError(operation string)
 }
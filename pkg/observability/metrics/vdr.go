@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+type didResolutionMetrics interface {
+	DIDResolutionTime(method string, success bool, value time.Duration)
+}
+
+// MeteredVDR is a DID registry that records DID resolution time.
+type MeteredVDR struct {
+	vdrapi.Registry
+	metrics didResolutionMetrics
+}
+
+// WrapVDR wraps DID registry.
+func WrapVDR(registry vdrapi.Registry, metrics didResolutionMetrics) *MeteredVDR {
+	return &MeteredVDR{Registry: registry, metrics: metrics}
+}
+
+// Resolve resolves DID and records resolution time labeled by DID method.
+func (v *MeteredVDR) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	startTime := time.Now()
+
+	docResolution, err := v.Registry.Resolve(didID, opts...)
+
+	var method string
+
+	if parts := strings.SplitN(didID, ":", 3); len(parts) == 3 { //nolint:gomnd
+		method = parts[1]
+	}
+
+	v.metrics.DIDResolutionTime(method, err == nil, time.Since(startTime))
+
+	return docResolution, err
+}
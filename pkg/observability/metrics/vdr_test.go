@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/observability/metrics"
+)
+
+type mockRegistry struct {
+	vdrapi.Registry
+	err error
+}
+
+func (m *mockRegistry) Resolve(string, ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return &did.DocResolution{DIDDocument: &did.Doc{ID: "did:example:123"}}, nil
+}
+
+type mockMetrics struct {
+	method  string
+	success bool
+	calls   int
+}
+
+func (m *mockMetrics) DIDResolutionTime(method string, success bool, _ time.Duration) {
+	m.method = method
+	m.success = success
+	m.calls++
+}
+
+func TestVDR_Resolve(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := &mockMetrics{}
+
+		docResolution, err := metrics.WrapVDR(&mockRegistry{}, m).Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+
+		require.Equal(t, 1, m.calls)
+		require.Equal(t, "example", m.method)
+		require.True(t, m.success)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		m := &mockMetrics{}
+
+		_, err := metrics.WrapVDR(&mockRegistry{err: errors.New("not found")}, m).Resolve("invalid")
+		require.EqualError(t, err, "not found")
+
+		require.Equal(t, 1, m.calls)
+		require.Empty(t, m.method)
+		require.False(t, m.success)
+	})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/trustbloc/vcs/pkg/event/spi"
+)
+
+const (
+	traceParentKey = "traceparent"
+	traceStateKey  = "tracestate"
+)
+
+// eventCarrier adapts event distributed tracing extension attributes to propagation.TextMapCarrier.
+type eventCarrier struct {
+	event *spi.Event
+}
+
+func (c eventCarrier) Get(key string) string {
+	switch key {
+	case traceParentKey:
+		return c.event.TraceParent
+	case traceStateKey:
+		return c.event.TraceState
+	}
+
+	return ""
+}
+
+func (c eventCarrier) Set(key, value string) {
+	switch key {
+	case traceParentKey:
+		c.event.TraceParent = value
+	case traceStateKey:
+		c.event.TraceState = value
+	}
+}
+
+func (c eventCarrier) Keys() []string {
+	return []string{traceParentKey, traceStateKey}
+}
+
+// InjectEvent sets trace context from ctx to the event, so that event processing is linked to the operation
+// that produced the event.
+func InjectEvent(ctx context.Context, event *spi.Event) {
+	otel.GetTextMapPropagator().Inject(ctx, eventCarrier{event: event})
+}
+
+// ExtractEvent returns ctx with trace context extracted from the event.
+func ExtractEvent(ctx context.Context, event *spi.Event) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, eventCarrier{event: event})
+}
@@ -0,0 +1,41 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/trustbloc/vcs/pkg/event/spi"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
+)
+
+func TestInjectExtractEvent(t *testing.T) {
+	ctx, span := tracerProvider.Tracer("test").Start(context.Background(), "producer")
+	defer span.End()
+
+	event := spi.NewEvent("id", "source", spi.VerifierOIDCInteractionSucceeded, nil)
+
+	tracing.InjectEvent(ctx, event)
+	require.NotEmpty(t, event.TraceParent)
+
+	copied := event.Copy()
+	require.Equal(t, event.TraceParent, copied.TraceParent)
+
+	spanCtx := trace.SpanContextFromContext(tracing.ExtractEvent(context.Background(), copied))
+	require.True(t, spanCtx.IsRemote())
+	require.Equal(t, span.SpanContext().TraceID(), spanCtx.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), spanCtx.SpanID())
+
+	t.Run("no trace context", func(t *testing.T) {
+		spanCtx := trace.SpanContextFromContext(tracing.ExtractEvent(context.Background(), &spi.Event{}))
+		require.False(t, spanCtx.IsValid())
+	})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+// ProviderType is a type of spans exporter.
+type ProviderType string
+
+// Supported spans exporters.
+const (
+	ProviderNone     ProviderType = ""
+	ProviderOTLPGRPC ProviderType = "otlp-grpc"
+	ProviderOTLPHTTP ProviderType = "otlp-http"
+)
+
+// Config defines configuration for tracing.
+type Config struct {
+	Provider ProviderType
+	// CollectorURL is an endpoint of OTLP collector. If empty, endpoint is taken from standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable.
+	CollectorURL string
+	ServiceName  string
+	// SampleRate is a fraction of traces that are sampled, unless parent span is sampled. Defaults to 1.
+	SampleRate float64
+}
+
+// Initialize sets up global tracer provider and W3C trace context propagator. Returned function flushes
+// and stops the exporter.
+func Initialize(cfg *Config) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Provider == ProviderNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := createExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sampleRate := cfg.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 1
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRate))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(cfg.ServiceName),
+		)),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+func createExporter(cfg *Config) (*otlptrace.Exporter, error) {
+	var client otlptrace.Client
+
+	switch cfg.Provider { //nolint:exhaustive
+	case ProviderOTLPGRPC:
+		var opts []otlptracegrpc.Option
+		if cfg.CollectorURL != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.CollectorURL), otlptracegrpc.WithInsecure())
+		}
+
+		client = otlptracegrpc.NewClient(opts...)
+	case ProviderOTLPHTTP:
+		var opts []otlptracehttp.Option
+		if cfg.CollectorURL != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.CollectorURL), otlptracehttp.WithInsecure())
+		}
+
+		client = otlptracehttp.NewClient(opts...)
+	default:
+		return nil, fmt.Errorf("unsupported tracing provider: %s", cfg.Provider)
+	}
+
+	exporter, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+
+	return exporter, nil
+}
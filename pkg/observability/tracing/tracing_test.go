@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
+)
+
+// Tracer provider is registered once, since tracers obtained before the first registration of global
+// tracer provider stay bound to it.
+var (
+	spanRecorder   = tracetest.NewSpanRecorder()
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+)
+
+func init() {
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func TestInitialize(t *testing.T) {
+	t.Run("no provider", func(t *testing.T) {
+		shutdown, err := tracing.Initialize(&tracing.Config{})
+		require.NoError(t, err)
+		require.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("otlp grpc", func(t *testing.T) {
+		shutdown, err := tracing.Initialize(&tracing.Config{
+			Provider:     tracing.ProviderOTLPGRPC,
+			CollectorURL: "localhost:4317",
+			ServiceName:  "vcs",
+		})
+		require.NoError(t, err)
+		require.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("otlp http", func(t *testing.T) {
+		shutdown, err := tracing.Initialize(&tracing.Config{
+			Provider:     tracing.ProviderOTLPHTTP,
+			CollectorURL: "localhost:4318",
+			ServiceName:  "vcs",
+			SampleRate:   0.5,
+		})
+		require.NoError(t, err)
+		require.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		_, err := tracing.Initialize(&tracing.Config{Provider: "jaeger"})
+		require.EqualError(t, err, "unsupported tracing provider: jaeger")
+	})
+}
+
+func findSpan(t *testing.T, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	spans := spanRecorder.Ended()
+
+	for i := len(spans) - 1; i >= 0; i-- {
+		if spans[i].Name() == name {
+			return spans[i]
+		}
+	}
+
+	require.Failf(t, "span not found", "name: %s", name)
+
+	return nil
+}
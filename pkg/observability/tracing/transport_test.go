@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
+)
+
+func TestTransport(t *testing.T) {
+	var traceParent string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("traceparent")
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: tracing.NewTransport(nil)}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+		defer parent.End()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, http.NoBody)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		span := findSpan(t, "HTTP GET")
+		require.Equal(t, trace.SpanKindClient, span.SpanKind())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Contains(t, traceParent, span.SpanContext().SpanID().String())
+		require.Empty(t, req.Header.Get("traceparent"), "original request must not be modified")
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		resp, err := client.Post(srv.URL+"/fail", "application/json", http.NoBody) //nolint:noctx
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		span := findSpan(t, "HTTP POST")
+		require.Equal(t, codes.Error, span.Status().Code)
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		_, err := client.Get("http://localhost:0") //nolint:noctx
+		require.Error(t, err)
+
+		span := findSpan(t, "HTTP GET")
+		require.Equal(t, codes.Error, span.Status().Code)
+		require.NotEmpty(t, span.Events())
+	})
+}
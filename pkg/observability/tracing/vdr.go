@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const didMethodKey = attribute.Key("did.method")
+
+// VDR is a DID registry that records a span for each DID resolution.
+type VDR struct {
+	vdrapi.Registry
+	ctx context.Context //nolint:containedctx
+}
+
+// WrapVDR wraps DID registry.
+func WrapVDR(registry vdrapi.Registry) *VDR {
+	return &VDR{Registry: registry}
+}
+
+// WithContext returns DID registry that records resolution spans as children of the span of the given context.
+// Registry API has no context, so the caller context is bound to the registry. Registry is returned as is
+// if it doesn't record spans.
+func WithContext(ctx context.Context, registry vdrapi.Registry) vdrapi.Registry {
+	v, ok := registry.(*VDR)
+	if !ok {
+		return registry
+	}
+
+	return &VDR{Registry: v.Registry, ctx: ctx}
+}
+
+// Resolve resolves DID within a span.
+func (v *VDR) Resolve(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	ctx := v.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, span := tracer.Start(ctx, "vdr.Resolve")
+	defer span.End()
+
+	if parts := strings.SplitN(didID, ":", 3); len(parts) == 3 { //nolint:gomnd
+		span.SetAttributes(didMethodKey.String(parts[1]))
+	}
+
+	docResolution, err := v.Registry.Resolve(didID, opts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return docResolution, err
+}
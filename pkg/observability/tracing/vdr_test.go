@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
+)
+
+type mockRegistry struct {
+	vdrapi.Registry
+	err error
+}
+
+func (m *mockRegistry) Resolve(string, ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return &did.DocResolution{DIDDocument: &did.Doc{ID: "did:example:123"}}, nil
+}
+
+func TestVDR_Resolve(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		docResolution, err := tracing.WrapVDR(&mockRegistry{}).Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+
+		span := findSpan(t, "vdr.Resolve")
+		require.Contains(t, span.Attributes(), attribute.String("did.method", "example"))
+		require.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := tracing.WrapVDR(&mockRegistry{err: errors.New("not found")}).Resolve("did:example:123")
+		require.EqualError(t, err, "not found")
+
+		span := findSpan(t, "vdr.Resolve")
+		require.Equal(t, codes.Error, span.Status().Code)
+	})
+
+	t.Run("caller context", func(t *testing.T) {
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "caller")
+		defer parent.End()
+
+		_, err := tracing.WithContext(ctx, tracing.WrapVDR(&mockRegistry{})).Resolve("did:example:123")
+		require.NoError(t, err)
+
+		span := findSpan(t, "vdr.Resolve")
+		require.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	})
+
+	t.Run("registry without tracing", func(t *testing.T) {
+		registry := &mockRegistry{}
+
+		require.Equal(t, registry, tracing.WithContext(context.Background(), registry))
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 
 	"github.com/hyperledger/aries-framework-go-ext/component/vdr/orb"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -17,6 +18,7 @@ import (
 
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	vcskms "github.com/trustbloc/vcs/pkg/kms"
+	"github.com/trustbloc/vcs/pkg/ratelimit"
 )
 
 type ID = string
@@ -41,6 +43,9 @@ type Issuer struct {
 	KMSConfig           *vcskms.Config        `json:"kmsConfig"`
 	SigningDID          *SigningDID           `json:"signingDID"`
 	CredentialTemplates []*CredentialTemplate `json:"credentialTemplates,omitempty"`
+	RateLimit           *ratelimit.Limit      `json:"rateLimit,omitempty"`
+	// Display properties of the issuer advertised to wallets in credential issuer metadata.
+	Display []*DisplayProperties `json:"display,omitempty"`
 }
 
 type CredentialTemplate struct {
@@ -49,6 +54,27 @@ type CredentialTemplate struct {
 	Type              string          `json:"type"`
 	Issuer            string          `json:"issuer"`
 	CredentialSubject json.RawMessage `json:"credentialSubject"`
+	// Format of the credential issued from the template. Defaults to the format of the profile.
+	Format vcsverifiable.Format `json:"format,omitempty"`
+	// Display properties of the credential advertised to wallets in credential issuer metadata.
+	Display []*DisplayProperties `json:"display,omitempty"`
+}
+
+// DisplayProperties describes how the issuer or credential is displayed by the wallet for a certain language.
+type DisplayProperties struct {
+	Name string `json:"name"`
+	// Locale is a BCP47 language tag.
+	Locale          string `json:"locale,omitempty"`
+	Logo            *Logo  `json:"logo,omitempty"`
+	Description     string `json:"description,omitempty"`
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+	TextColor       string `json:"textColor,omitempty"`
+}
+
+// Logo of the issuer or credential.
+type Logo struct {
+	URL     string `json:"url"`
+	AltText string `json:"altText,omitempty"`
 }
 
 // OIDC4VCConfig is issuer's OIDC configuration used during OIDC4VC issuance flow.
@@ -56,6 +82,8 @@ type OIDC4VCConfig struct {
 	IssuerWellKnownURL string `json:"issuer_well_known"`
 	ClientID           string `json:"client_id"`
 	ClientSecretHandle string `json:"client_secret_handle"`
+	// OfferTTL is lifetime of credential offer in seconds. If not set, default offer lifetime is used.
+	OfferTTL int32 `json:"offer_ttl,omitempty"`
 }
 
 // VCConfig describes how to sign verifiable credentials.
@@ -82,6 +110,7 @@ type Verifier struct {
 	SigningDID              *SigningDID                        `json:"signingDID,omitempty"`
 	PresentationDefinitions []*presexch.PresentationDefinition `json:"presentationDefinitions,omitempty"`
 	WebHook                 string                             `json:"webHook,omitempty"`
+	RateLimit               *ratelimit.Limit                   `json:"rateLimit,omitempty"`
 }
 
 // OIDC4VPConfig store config for verifier did that used to sign request object in oidc4vp process.
@@ -89,6 +118,26 @@ type OIDC4VPConfig struct {
 	ROSigningAlgorithm vcsverifiable.SignatureType `json:"roSigningAlgorithm,omitempty"`
 	DIDMethod          Method                      `json:"didMethod,omitempty"`
 	KeyType            kms.KeyType                 `json:"keyType,omitempty"`
+	ClaimsRetention    *ClaimsRetention            `json:"claimsRetention,omitempty"`
+	ResponseEncryption *ResponseEncryption         `json:"responseEncryption,omitempty"`
+}
+
+// ResponseEncryption defines key the wallet uses to encrypt authorization response (JARM).
+type ResponseEncryption struct {
+	// KeyType of the encryption key.
+	KeyType kms.KeyType `json:"keyType,omitempty"`
+	// KeyID is an ID of the key in profile's KMS. The key must be created beforehand, so that it doesn't change
+	// between restarts and instances.
+	KeyID string `json:"keyID,omitempty"`
+	// JWK is a public part of the encryption key advertised to the wallet. Required with KeyID.
+	JWK *jwk.JWK `json:"jwk,omitempty"`
+}
+
+// ClaimsRetention defines how long claims received during oidc4vp interaction are kept by VCS.
+type ClaimsRetention struct {
+	// TTL of received claims in seconds. If not set, claims are kept until they are purged.
+	TTL                  int32 `json:"ttl,omitempty"`
+	DeleteAfterRetrieval bool  `json:"deleteAfterRetrieval,omitempty"`
 }
 
 // VerificationChecks are checks to be performed for verifying credentials and presentations.
@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// pruneInterval defines how often idle buckets are removed from the memory store.
+const pruneInterval = time.Minute
+
+// Limit defines token bucket rate limit. Bucket holds up to Burst tokens and is refilled at RequestsPerSecond rate,
+// every request takes one token from the bucket.
+type Limit struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Burst is a maximum number of requests allowed at once. Defaults to RequestsPerSecond rounded up.
+	Burst int `json:"burst,omitempty"`
+}
+
+// Capacity returns the bucket size.
+func (l Limit) Capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+
+	return math.Max(1, math.Ceil(l.RequestsPerSecond))
+}
+
+// Result is a result of taking a token from the bucket.
+type Result struct {
+	Allowed bool
+	// RetryAfter is a time after which the next token is available. Set if request is not allowed.
+	RetryAfter time.Duration
+}
+
+// NewResult creates result of taking a token from the bucket that has the given number of tokens left.
+func NewResult(allowed bool, tokens float64, limit Limit) *Result {
+	if allowed {
+		return &Result{Allowed: true}
+	}
+
+	return &Result{
+		RetryAfter: time.Duration((1 - tokens) / limit.RequestsPerSecond * float64(time.Second)),
+	}
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryStore keeps token buckets in memory. Limits are enforced per VCS instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	prunedAt  time.Time
+	timeNowFn func() time.Time
+}
+
+// NewMemoryStore creates MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		prunedAt:  time.Now(),
+		timeNowFn: time.Now,
+	}
+}
+
+// Take takes a token from the bucket identified by the key.
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNowFn()
+
+	if now.Sub(s.prunedAt) > pruneInterval {
+		s.prune(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.Capacity(), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(limit.Capacity(), b.tokens+now.Sub(b.updatedAt).Seconds()*limit.RequestsPerSecond)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return NewResult(false, b.tokens, limit), nil
+	}
+
+	b.tokens--
+
+	return NewResult(true, b.tokens, limit), nil
+}
+
+// prune removes buckets that were not used during prune interval. Such buckets are either full or
+// belong to the limits with very low rate, for which a new full bucket is an acceptable approximation.
+func (s *MemoryStore) prune(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.updatedAt) > pruneInterval {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.prunedAt = now
+}
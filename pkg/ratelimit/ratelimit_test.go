@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Now()
+
+	store := NewMemoryStore()
+	store.timeNowFn = func() time.Time { return now }
+
+	limit := Limit{RequestsPerSecond: 2, Burst: 3}
+
+	t.Run("Burst is allowed", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			res, err := store.Take(context.Background(), "key1", limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+		}
+
+		res, err := store.Take(context.Background(), "key1", limit)
+		require.NoError(t, err)
+		require.False(t, res.Allowed)
+		require.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	})
+
+	t.Run("Bucket is refilled", func(t *testing.T) {
+		now = now.Add(500 * time.Millisecond)
+
+		res, err := store.Take(context.Background(), "key1", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+
+		res, err = store.Take(context.Background(), "key1", limit)
+		require.NoError(t, err)
+		require.False(t, res.Allowed)
+	})
+
+	t.Run("Buckets are independent", func(t *testing.T) {
+		res, err := store.Take(context.Background(), "key2", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+	})
+
+	t.Run("Idle buckets are pruned", func(t *testing.T) {
+		now = now.Add(2 * pruneInterval)
+
+		res, err := store.Take(context.Background(), "key1", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Len(t, store.buckets, 1)
+	})
+}
+
+func TestLimit_Capacity(t *testing.T) {
+	require.Equal(t, 5.0, Limit{RequestsPerSecond: 1, Burst: 5}.Capacity())
+	require.Equal(t, 3.0, Limit{RequestsPerSecond: 2.5}.Capacity())
+	require.Equal(t, 1.0, Limit{RequestsPerSecond: 0.1}.Capacity())
+}
@@ -20,6 +20,7 @@ const (
 	AlreadyExist    ErrorCode = "already-exist"
 	DoesntExist     ErrorCode = "doesnt-exist"
 	ConditionNotMet ErrorCode = "condition-not-met"
+	Conflict        ErrorCode = "conflict"
 )
 
 func (c ErrorCode) Name() string {
@@ -85,7 +86,7 @@ func (e *CustomError) HTTPCodeMsg() (int, interface{}) {
 			"code":    Unauthorized.Name(),
 			"message": e.Err.Error(),
 		}
-	case AlreadyExist:
+	case AlreadyExist, Conflict:
 		code = http.StatusConflict
 
 	case DoesntExist:
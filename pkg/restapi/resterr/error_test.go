@@ -59,6 +59,17 @@ func TestNewValidationError(t *testing.T) {
 		requireMessage(t, resp, "some error")
 	})
 
+	t.Run("conflict error", func(t *testing.T) {
+		err := NewValidationError(Conflict, "test.value1", errors.New("some error"))
+		require.Equal(t, "conflict[test.value1]: some error", err.Error())
+
+		httpCode, resp := err.HTTPCodeMsg()
+
+		require.Equal(t, http.StatusConflict, httpCode)
+		requireCode(t, resp, Conflict.Name())
+		requireMessage(t, resp, "some error")
+	})
+
 	t.Run("doesn't exist error", func(t *testing.T) {
 		err := NewValidationError(DoesntExist, "test.value1", errors.New("some error"))
 		require.Equal(t, "doesnt-exist[test.value1]: some error", err.Error())
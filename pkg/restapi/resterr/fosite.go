@@ -22,6 +22,7 @@ const (
 	FositeAccessError
 	FositeIntrospectionError
 	FositePARError
+	FositeRevocationError
 )
 
 type FositeErrorCode int
@@ -31,6 +32,7 @@ type fositeErrorWriter interface {
 	WriteAccessError(ctx context.Context, rw http.ResponseWriter, requester fosite.AccessRequester, err error)
 	WriteIntrospectionError(ctx context.Context, rw http.ResponseWriter, err error)
 	WritePushedAuthorizeError(ctx context.Context, rw http.ResponseWriter, ar fosite.AuthorizeRequester, err error)
+	WriteRevocationResponse(ctx context.Context, rw http.ResponseWriter, err error)
 }
 
 type FositeError struct {
@@ -81,6 +83,9 @@ func (e *FositeError) Write() error {
 	case FositePARError:
 		e.writer.WritePushedAuthorizeError(e.ctx.Request().Context(), e.ctx.Response().Writer, e.authorizeRequester, e.err)
 		return nil
+	case FositeRevocationError:
+		e.writer.WriteRevocationResponse(e.ctx.Request().Context(), e.ctx.Response().Writer, e.err)
+		return nil
 	default:
 		return fmt.Errorf("usupported fosite error code %d, err %w", e.code, e.err)
 	}
@@ -119,6 +119,18 @@ func TestFositeError_Write(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "OK FositeRevocationError",
+			fields: fields{
+				code: FositeRevocationError,
+				getWriter: func() fositeErrorWriter {
+					mockFositeErrWriter.EXPECT().
+						WriteRevocationResponse(gomock.Any(), rw, gomock.Any()).Times(1)
+					return mockFositeErrWriter
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error",
 			fields: fields{
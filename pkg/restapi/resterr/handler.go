@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
 
 	"github.com/trustbloc/vcs/internal/pkg/log"
 )
@@ -28,22 +29,35 @@ func HTTPErrorHandler(err error, c echo.Context) {
 	}
 
 	code, message := processError(err)
-	logger.Error("HTTP Error Handler", log.WithHostURL(c.Request().RequestURI), log.WithHTTPStatus(code),
+	logger.WithContext(c.Request().Context()).Error("HTTP Error Handler",
+		log.WithHostURL(c.Request().RequestURI), log.WithHTTPStatus(code),
 		log.WithAdditionalMessage(fmt.Sprintf("%s", message)))
 	sendResponse(c, code, message)
 }
 
+// HTTPStatus returns HTTP status code that HTTPErrorHandler responds with for the given error.
+func HTTPStatus(err error) int {
+	var fositeError *FositeError
+	if errors.As(err, &fositeError) {
+		return fosite.ErrorToRFC6749Error(fositeError.err).StatusCode()
+	}
+
+	code, _ := processError(err)
+
+	return code
+}
+
 func sendResponse(c echo.Context, code int, message interface{}) {
 	var err error
 	if !c.Response().Committed {
 		if c.Request().Method == http.MethodHead {
-			logger.Error("head error msg", log.WithError(fmt.Errorf("%v", message)))
+			logger.WithContext(c.Request().Context()).Error("head error msg", log.WithError(fmt.Errorf("%v", message)))
 			err = c.NoContent(code)
 		} else {
 			err = c.JSON(code, message)
 		}
 		if err != nil {
-			logger.Error("write http response", log.WithError(err))
+			logger.WithContext(c.Request().Context()).Error("write http response", log.WithError(err))
 		}
 	}
 }
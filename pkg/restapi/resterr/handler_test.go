@@ -15,6 +15,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
+	"github.com/ory/fosite"
 	"github.com/stretchr/testify/require"
 )
 
@@ -94,6 +95,14 @@ func TestHTTPErrorHandler(t *testing.T) {
 	})
 }
 
+func TestHTTPStatus(t *testing.T) {
+	require.Equal(t, http.StatusForbidden, HTTPStatus(echo.NewHTTPError(http.StatusForbidden, "forbidden")))
+	require.Equal(t, http.StatusUnauthorized, HTTPStatus(NewUnauthorizedError(errors.New("unauthorized"))))
+	require.Equal(t, http.StatusInternalServerError, HTTPStatus(errors.New("generic error")))
+	require.Equal(t, http.StatusBadRequest,
+		HTTPStatus(NewFositeError(FositeAccessError, nil, nil, fosite.ErrInvalidRequest)))
+}
+
 func createContext(method string) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
 
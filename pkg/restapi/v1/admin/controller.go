@@ -0,0 +1,312 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+//go:generate oapi-codegen --config=openapi.cfg.yaml ../../../../docs/v1/openapi.yaml
+//go:generate mockgen -destination controller_mocks_test.go -self_package mocks -package admin -source=controller.go -mock_names auditService=MockAuditService
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/audit"
+	"github.com/trustbloc/vcs/pkg/restapi/resterr"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+const (
+	defaultAuditEntriesLimit = 100
+	auditServiceComponent    = "audit.Service"
+	mimeApplicationNDJSON    = "application/x-ndjson"
+)
+
+var logger = log.New("admin-rest")
+
+var _ ServerInterface = (*Controller)(nil) // make sure Controller implements ServerInterface
+
+type auditService interface {
+	Query(ctx context.Context, filter *audit.Filter) ([]*audit.Entry, error)
+	Iterate(ctx context.Context, filter *audit.Filter, fn func(entry *audit.Entry) error) error
+	Verify(ctx context.Context, orgID string) (*audit.VerificationResult, error)
+}
+
+// Config holds configuration for admin Controller.
+type Config struct {
+	AuditService auditService
+}
+
+// Controller for administration API.
+type Controller struct {
+	auditService auditService
+
+	logSpecMutex  sync.Mutex
+	logSpecRevert *logSpecRevert
+}
+
+// logSpecRevert is a pending restore of log levels that were in effect before a temporary log spec change.
+type logSpecRevert struct {
+	spec  string
+	at    time.Time
+	timer *time.Timer
+}
+
+// NewController creates a new controller for administration API.
+func NewController(config *Config) *Controller {
+	return &Controller{
+		auditService: config.AuditService,
+	}
+}
+
+// GetAuditEntries returns audit log entries of the caller organization.
+// GET /admin/audit/entries.
+func (c *Controller) GetAuditEntries(ctx echo.Context, params GetAuditEntriesParams) error {
+	orgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := auditFilter(orgID, params.ProfileID, (*string)(params.Operation), params.CredentialID, params.Actor,
+		params.From, params.To)
+	filter.AfterSeq = lo.FromPtr(params.AfterSeq)
+	filter.Limit = defaultAuditEntriesLimit
+
+	if params.Limit != nil {
+		filter.Limit = int64(*params.Limit)
+	}
+
+	entries, err := c.auditService.Query(ctx.Request().Context(), filter)
+	if err != nil {
+		return resterr.NewSystemError(auditServiceComponent, "Query", err)
+	}
+
+	resp := AuditEntriesResponse{
+		Entries: make([]AuditEntry, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, mapAuditEntry(entry))
+	}
+
+	return util.WriteOutput(ctx)(resp, nil)
+}
+
+// ExportAuditEntries exports audit log entries of the caller organization as newline delimited JSON.
+// GET /admin/audit/export.
+func (c *Controller) ExportAuditEntries(ctx echo.Context, params ExportAuditEntriesParams) error {
+	orgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := auditFilter(orgID, params.ProfileID, (*string)(params.Operation), params.CredentialID, params.Actor,
+		params.From, params.To)
+
+	ctx.Response().Header().Set(echo.HeaderContentType, mimeApplicationNDJSON)
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit.ndjson"`)
+
+	encoder := json.NewEncoder(ctx.Response())
+	started := false
+
+	err = c.auditService.Iterate(ctx.Request().Context(), filter, func(entry *audit.Entry) error {
+		if !started {
+			ctx.Response().WriteHeader(http.StatusOK)
+			started = true
+		}
+
+		return encoder.Encode(mapAuditEntry(entry))
+	})
+	if err != nil {
+		if started {
+			// Response is partially written, so the error can't be reported with status code.
+			return nil
+		}
+
+		return resterr.NewSystemError(auditServiceComponent, "Iterate", err)
+	}
+
+	if !started {
+		ctx.Response().WriteHeader(http.StatusOK)
+	}
+
+	return nil
+}
+
+// VerifyAuditLog verifies hash chain of the audit log of the caller organization.
+// GET /admin/audit/verify.
+func (c *Controller) VerifyAuditLog(ctx echo.Context) error {
+	orgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.auditService.Verify(ctx.Request().Context(), orgID)
+	if err != nil {
+		return resterr.NewSystemError(auditServiceComponent, "Verify", err)
+	}
+
+	resp := AuditVerificationResult{
+		Valid:    result.Valid,
+		Entries:  result.Entries,
+		Anchored: result.Anchored,
+	}
+
+	if !result.Valid {
+		resp.FirstInvalidSeq = lo.ToPtr(result.FirstInvalidSeq)
+	}
+
+	return util.WriteOutput(ctx)(resp, nil)
+}
+
+// GetLogSpec returns current log levels of the server modules.
+// GET /admin/logspec.
+func (c *Controller) GetLogSpec(ctx echo.Context) error {
+	if _, err := util.GetOrgIDFromOIDC(ctx); err != nil {
+		return err
+	}
+
+	c.logSpecMutex.Lock()
+	defer c.logSpecMutex.Unlock()
+
+	return util.WriteOutput(ctx)(c.logSpec(), nil)
+}
+
+// UpdateLogSpec changes log levels of the server modules. Previous levels are restored after revertAfter
+// seconds if set. Previous levels of the first temporary change are kept for restore if temporary changes
+// follow each other.
+// PUT /admin/logspec.
+func (c *Controller) UpdateLogSpec(ctx echo.Context) error {
+	orgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body UpdateLogSpecRequest
+
+	if err = util.ReadBody(ctx, &body); err != nil {
+		return err
+	}
+
+	if body.RevertAfter != nil && *body.RevertAfter < 1 {
+		return resterr.NewValidationError(resterr.InvalidValue, "revertAfter",
+			errors.New("must be a positive number of seconds"))
+	}
+
+	c.logSpecMutex.Lock()
+	defer c.logSpecMutex.Unlock()
+
+	previousSpec := log.GetSpec()
+
+	if c.logSpecRevert != nil {
+		previousSpec = c.logSpecRevert.spec
+	}
+
+	if err = log.ReplaceSpec(body.Spec); err != nil {
+		return resterr.NewValidationError(resterr.InvalidValue, "spec", err)
+	}
+
+	if c.logSpecRevert != nil {
+		c.logSpecRevert.timer.Stop()
+		c.logSpecRevert = nil
+	}
+
+	if body.RevertAfter != nil {
+		revertAfter := time.Duration(*body.RevertAfter) * time.Second
+
+		revert := &logSpecRevert{
+			spec: previousSpec,
+			at:   time.Now().Add(revertAfter),
+		}
+
+		revert.timer = time.AfterFunc(revertAfter, func() { c.revertLogSpec(revert) })
+
+		c.logSpecRevert = revert
+	}
+
+	logger.WithContext(ctx.Request().Context()).Info("Log spec updated", log.WithID(orgID),
+		log.WithUserLogLevel(body.Spec))
+
+	return util.WriteOutput(ctx)(c.logSpec(), nil)
+}
+
+func (c *Controller) revertLogSpec(revert *logSpecRevert) {
+	c.logSpecMutex.Lock()
+	defer c.logSpecMutex.Unlock()
+
+	// Revert was superseded by a later log spec change.
+	if c.logSpecRevert != revert {
+		return
+	}
+
+	c.logSpecRevert = nil
+
+	if err := log.ReplaceSpec(revert.spec); err != nil {
+		logger.Error("Failed to revert log spec", log.WithUserLogLevel(revert.spec), log.WithError(err))
+
+		return
+	}
+
+	logger.Info("Log spec reverted", log.WithUserLogLevel(revert.spec))
+}
+
+// logSpec must be called with logSpecMutex held.
+func (c *Controller) logSpec() LogSpec {
+	spec := LogSpec{
+		Spec: log.GetSpec(),
+	}
+
+	if c.logSpecRevert != nil {
+		spec.RevertAt = lo.ToPtr(c.logSpecRevert.at.UTC())
+	}
+
+	return spec
+}
+
+func auditFilter(orgID string, profileID, operation, credentialID, actor *string,
+	from, to *time.Time) *audit.Filter {
+	return &audit.Filter{
+		OrgID:        orgID,
+		ProfileID:    lo.FromPtr(profileID),
+		Operation:    audit.Operation(lo.FromPtr(operation)),
+		CredentialID: lo.FromPtr(credentialID),
+		Actor:        lo.FromPtr(actor),
+		From:         from,
+		To:           to,
+	}
+}
+
+func mapAuditEntry(entry *audit.Entry) AuditEntry {
+	return AuditEntry{
+		Seq:             entry.Seq,
+		Timestamp:       entry.Timestamp,
+		Actor:           strPtr(entry.Actor),
+		OrgID:           strPtr(entry.OrgID),
+		ProfileID:       strPtr(entry.ProfileID),
+		Operation:       string(entry.Operation),
+		CredentialID:    strPtr(entry.CredentialID),
+		StatusListIndex: strPtr(entry.StatusListIndex),
+		Status:          strPtr(entry.Status),
+		RequestID:       strPtr(entry.RequestID),
+		PrevHash:        entry.PrevHash,
+		Hash:            entry.Hash,
+	}
+}
+
+func strPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
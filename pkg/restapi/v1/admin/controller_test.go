@@ -0,0 +1,408 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/audit"
+)
+
+const (
+	orgID      = "orgID1"
+	userHeader = "X-User"
+)
+
+var testEntries = []*audit.Entry{
+	{
+		Seq:          1,
+		Timestamp:    time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		Actor:        orgID,
+		OrgID:        orgID,
+		ProfileID:    "profile1",
+		Operation:    audit.OperationIssueCredential,
+		CredentialID: "urn:uuid:1",
+		Hash:         "hash1",
+	},
+	{
+		Seq:          2,
+		Timestamp:    time.Date(2022, 10, 1, 0, 0, 1, 0, time.UTC),
+		Actor:        orgID,
+		OrgID:        orgID,
+		ProfileID:    "profile1",
+		Operation:    audit.OperationUpdateStatus,
+		CredentialID: "urn:uuid:1",
+		Status:       "true",
+		PrevHash:     "hash1",
+		Hash:         "hash2",
+	},
+}
+
+func createContext(orgID string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if orgID != "" {
+		req.Header.Set(userHeader, orgID)
+	}
+
+	rec := httptest.NewRecorder()
+
+	return e.NewContext(req, rec), rec
+}
+
+func TestController_GetAuditEntries(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Query(gomock.Any(), &audit.Filter{
+			OrgID:     orgID,
+			ProfileID: "profile1",
+			Operation: audit.OperationUpdateStatus,
+			AfterSeq:  1,
+			Limit:     defaultAuditEntriesLimit,
+		}).Return(testEntries[1:], nil)
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, rec := createContext(orgID)
+
+		err := c.GetAuditEntries(ctx, GetAuditEntriesParams{
+			ProfileID: lo.ToPtr("profile1"),
+			Operation: lo.ToPtr(GetAuditEntriesParamsOperation(audit.OperationUpdateStatus)),
+			AfterSeq:  lo.ToPtr(int64(1)),
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp AuditEntriesResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Entries, 1)
+		require.Equal(t, int64(2), resp.Entries[0].Seq)
+		require.Equal(t, "true", lo.FromPtr(resp.Entries[0].Status))
+		require.Equal(t, "hash1", resp.Entries[0].PrevHash)
+		require.Nil(t, resp.Entries[0].StatusListIndex)
+	})
+
+	t.Run("Custom limit", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Query(gomock.Any(), &audit.Filter{OrgID: orgID, Limit: 10}).Return(nil, nil)
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, rec := createContext(orgID)
+
+		require.NoError(t, c.GetAuditEntries(ctx, GetAuditEntriesParams{Limit: lo.ToPtr(10)}))
+		require.JSONEq(t, `{"entries":[]}`, rec.Body.String())
+	})
+
+	t.Run("Missing authorization", func(t *testing.T) {
+		c := NewController(&Config{AuditService: NewMockAuditService(gomock.NewController(t))})
+
+		ctx, _ := createContext("")
+
+		require.ErrorContains(t, c.GetAuditEntries(ctx, GetAuditEntriesParams{}), "missing authorization")
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, errors.New("query error"))
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, _ := createContext(orgID)
+
+		require.ErrorContains(t, c.GetAuditEntries(ctx, GetAuditEntriesParams{}), "query error")
+	})
+}
+
+func TestController_ExportAuditEntries(t *testing.T) {
+	iterateFn := func(entries []*audit.Entry) func(_ interface{}, _ *audit.Filter, fn func(*audit.Entry) error) error {
+		return func(_ interface{}, _ *audit.Filter, fn func(*audit.Entry) error) error {
+			for _, entry := range entries {
+				if err := fn(entry); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Iterate(gomock.Any(), &audit.Filter{OrgID: orgID, CredentialID: "urn:uuid:1"},
+			gomock.Any()).DoAndReturn(iterateFn(testEntries))
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, rec := createContext(orgID)
+
+		err := c.ExportAuditEntries(ctx, ExportAuditEntriesParams{CredentialID: lo.ToPtr("urn:uuid:1")})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, mimeApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+
+		var seqs []int64
+
+		scanner := bufio.NewScanner(rec.Body)
+		for scanner.Scan() {
+			var entry AuditEntry
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+
+			seqs = append(seqs, entry.Seq)
+		}
+
+		require.Equal(t, []int64{1, 2}, seqs)
+	})
+
+	t.Run("Empty log", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Iterate(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(iterateFn(nil))
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, rec := createContext(orgID)
+
+		require.NoError(t, c.ExportAuditEntries(ctx, ExportAuditEntriesParams{}))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Empty(t, rec.Body.String())
+	})
+
+	t.Run("Missing authorization", func(t *testing.T) {
+		c := NewController(&Config{AuditService: NewMockAuditService(gomock.NewController(t))})
+
+		ctx, _ := createContext("")
+
+		require.ErrorContains(t, c.ExportAuditEntries(ctx, ExportAuditEntriesParams{}), "missing authorization")
+	})
+
+	t.Run("Iterate error", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Iterate(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("iterate error"))
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, _ := createContext(orgID)
+
+		require.ErrorContains(t, c.ExportAuditEntries(ctx, ExportAuditEntriesParams{}), "iterate error")
+	})
+}
+
+func TestController_VerifyAuditLog(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Verify(gomock.Any(), orgID).
+			Return(&audit.VerificationResult{Valid: true, Entries: 2, Anchored: true}, nil)
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, rec := createContext(orgID)
+
+		require.NoError(t, c.VerifyAuditLog(ctx))
+		require.JSONEq(t, `{"valid":true,"entries":2,"anchored":true}`, rec.Body.String())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Verify(gomock.Any(), orgID).
+			Return(&audit.VerificationResult{Valid: false, Entries: 1, FirstInvalidSeq: 2}, nil)
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, rec := createContext(orgID)
+
+		require.NoError(t, c.VerifyAuditLog(ctx))
+		require.JSONEq(t, `{"valid":false,"entries":1,"firstInvalidSeq":2,"anchored":false}`, rec.Body.String())
+	})
+
+	t.Run("Missing authorization", func(t *testing.T) {
+		c := NewController(&Config{AuditService: NewMockAuditService(gomock.NewController(t))})
+
+		ctx, _ := createContext("")
+
+		require.ErrorContains(t, c.VerifyAuditLog(ctx), "missing authorization")
+	})
+
+	t.Run("Verify error", func(t *testing.T) {
+		mockAuditSvc := NewMockAuditService(gomock.NewController(t))
+		mockAuditSvc.EXPECT().Verify(gomock.Any(), orgID).Return(nil, errors.New("verify error"))
+
+		c := NewController(&Config{AuditService: mockAuditSvc})
+
+		ctx, _ := createContext(orgID)
+
+		require.ErrorContains(t, c.VerifyAuditLog(ctx), "verify error")
+	})
+}
+
+func createLogSpecContext(orgID, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(userHeader, orgID)
+
+	rec := httptest.NewRecorder()
+
+	return e.NewContext(req, rec), rec
+}
+
+func restoreLogSpec(t *testing.T) {
+	t.Helper()
+
+	spec := log.GetSpec()
+
+	t.Cleanup(func() {
+		require.NoError(t, log.ReplaceSpec(spec))
+	})
+}
+
+func TestController_GetLogSpec(t *testing.T) {
+	restoreLogSpec(t)
+
+	t.Run("Success", func(t *testing.T) {
+		require.NoError(t, log.ReplaceSpec("module1=debug:info"))
+
+		c := NewController(&Config{})
+
+		ctx, rec := createContext(orgID)
+
+		require.NoError(t, c.GetLogSpec(ctx))
+
+		var spec LogSpec
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+		require.Equal(t, "module1=DEBUG:INFO", spec.Spec)
+		require.Nil(t, spec.RevertAt)
+	})
+
+	t.Run("Missing authorization", func(t *testing.T) {
+		c := NewController(&Config{})
+
+		ctx, _ := createContext("")
+
+		require.ErrorContains(t, c.GetLogSpec(ctx), "missing authorization")
+	})
+}
+
+func TestController_UpdateLogSpec(t *testing.T) {
+	restoreLogSpec(t)
+
+	t.Run("Success", func(t *testing.T) {
+		require.NoError(t, log.ReplaceSpec("info"))
+
+		c := NewController(&Config{})
+
+		ctx, rec := createLogSpecContext(orgID, `{"spec":"module1=debug:warning"}`)
+
+		require.NoError(t, c.UpdateLogSpec(ctx))
+
+		var spec LogSpec
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+		require.Equal(t, "module1=DEBUG:WARN", spec.Spec)
+		require.Nil(t, spec.RevertAt)
+		require.Equal(t, log.DEBUG, log.GetLevel("module1"))
+		require.Equal(t, log.WARNING, log.GetLevel("module2"))
+	})
+
+	t.Run("Success with revert", func(t *testing.T) {
+		require.NoError(t, log.ReplaceSpec("info"))
+
+		c := NewController(&Config{})
+
+		ctx, rec := createLogSpecContext(orgID, `{"spec":"module1=debug:info","revertAfter":1}`)
+
+		require.NoError(t, c.UpdateLogSpec(ctx))
+
+		var spec LogSpec
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+		require.Equal(t, "module1=DEBUG:INFO", spec.Spec)
+		require.NotNil(t, spec.RevertAt)
+
+		// Second temporary change keeps levels that were in effect before the first one.
+		ctx, _ = createLogSpecContext(orgID, `{"spec":"module2=debug:info","revertAfter":1}`)
+
+		require.NoError(t, c.UpdateLogSpec(ctx))
+		require.Equal(t, log.INFO, log.GetLevel("module1"))
+		require.Equal(t, log.DEBUG, log.GetLevel("module2"))
+
+		require.Eventually(t, func() bool {
+			return log.GetSpec() == "INFO"
+		}, 3*time.Second, 50*time.Millisecond)
+
+		ctx, rec = createContext(orgID)
+
+		require.NoError(t, c.GetLogSpec(ctx))
+		require.JSONEq(t, `{"spec":"INFO"}`, rec.Body.String())
+	})
+
+	t.Run("Permanent change cancels revert", func(t *testing.T) {
+		require.NoError(t, log.ReplaceSpec("info"))
+
+		c := NewController(&Config{})
+
+		ctx, _ := createLogSpecContext(orgID, `{"spec":"debug","revertAfter":1}`)
+		require.NoError(t, c.UpdateLogSpec(ctx))
+
+		ctx, _ = createLogSpecContext(orgID, `{"spec":"warning"}`)
+		require.NoError(t, c.UpdateLogSpec(ctx))
+
+		time.Sleep(1500 * time.Millisecond)
+
+		require.Equal(t, "WARN", log.GetSpec())
+	})
+
+	t.Run("Invalid spec", func(t *testing.T) {
+		require.NoError(t, log.ReplaceSpec("info"))
+
+		c := NewController(&Config{})
+
+		ctx, _ := createLogSpecContext(orgID, `{"spec":"module1=invalid"}`)
+
+		require.ErrorContains(t, c.UpdateLogSpec(ctx), "invalid log level")
+		require.Equal(t, "INFO", log.GetSpec())
+	})
+
+	t.Run("Invalid revertAfter", func(t *testing.T) {
+		c := NewController(&Config{})
+
+		ctx, _ := createLogSpecContext(orgID, `{"spec":"debug","revertAfter":0}`)
+
+		require.ErrorContains(t, c.UpdateLogSpec(ctx), "revertAfter")
+	})
+
+	t.Run("Invalid body", func(t *testing.T) {
+		c := NewController(&Config{})
+
+		ctx, _ := createLogSpecContext(orgID, `{`)
+
+		require.ErrorContains(t, c.UpdateLogSpec(ctx), "requestBody")
+	})
+
+	t.Run("Missing authorization", func(t *testing.T) {
+		c := NewController(&Config{})
+
+		ctx, _ := createLogSpecContext("", `{"spec":"debug"}`)
+
+		require.ErrorContains(t, c.UpdateLogSpec(ctx), "missing authorization")
+	})
+}
@@ -0,0 +1,367 @@
+// Package admin provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.11.0 DO NOT EDIT.
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AuditOperation.
+const (
+	IssueCredential        AuditOperation = "issue_credential"
+	UpdateCredentialStatus AuditOperation = "update_credential_status"
+)
+
+// AuditEntriesResponse defines model for AuditEntriesResponse.
+type AuditEntriesResponse struct {
+	Entries []AuditEntry `json:"entries"`
+}
+
+// Audit log entry. Hash covers all other fields of the entry including hash of the previous entry.
+type AuditEntry struct {
+	Actor           *string   `json:"actor,omitempty"`
+	CredentialID    *string   `json:"credentialID,omitempty"`
+	Hash            string    `json:"hash"`
+	Operation       string    `json:"operation"`
+	OrgID           *string   `json:"orgID,omitempty"`
+	PrevHash        string    `json:"prevHash"`
+	ProfileID       *string   `json:"profileID,omitempty"`
+	RequestID       *string   `json:"requestID,omitempty"`
+	Seq             int64     `json:"seq"`
+	Status          *string   `json:"status,omitempty"`
+	StatusListIndex *string   `json:"statusListIndex,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// AuditVerificationResult defines model for AuditVerificationResult.
+type AuditVerificationResult struct {
+	// True if the log is checked against signed anchor of its last entry.
+	Anchored bool `json:"anchored"`
+
+	// Number of verified entries.
+	Entries int64 `json:"entries"`
+
+	// Sequence number of the first entry that breaks the chain.
+	FirstInvalidSeq *int64 `json:"firstInvalidSeq,omitempty"`
+	Valid           bool   `json:"valid"`
+}
+
+// LogSpec defines model for LogSpec.
+type LogSpec struct {
+	// Time when previous log levels are restored. Not set if the change is permanent.
+	RevertAt *time.Time `json:"revertAt,omitempty"`
+
+	// Log levels in module1=level1:module2=level2:defaultLevel format, for example oidc4vp=debug:info.
+	Spec string `json:"spec"`
+}
+
+// UpdateLogSpecRequest defines model for UpdateLogSpecRequest.
+type UpdateLogSpecRequest struct {
+	// Number of seconds after which previous log levels are restored.
+	RevertAfter *int `json:"revertAfter,omitempty"`
+
+	// Log levels in module1=level1:module2=level2:defaultLevel format, for example oidc4vp=debug:info.
+	Spec string `json:"spec"`
+}
+
+// AuditActor defines model for AuditActor.
+type AuditActor = string
+
+// AuditCredentialID defines model for AuditCredentialID.
+type AuditCredentialID = string
+
+// AuditFrom defines model for AuditFrom.
+type AuditFrom = time.Time
+
+// AuditOperation defines model for AuditOperation.
+type AuditOperation string
+
+// AuditProfileID defines model for AuditProfileID.
+type AuditProfileID = string
+
+// AuditTo defines model for AuditTo.
+type AuditTo = time.Time
+
+// GetAuditEntriesParams defines parameters for GetAuditEntries.
+type GetAuditEntriesParams struct {
+	ProfileID    *AuditProfileID                 `form:"profileID,omitempty" json:"profileID,omitempty"`
+	Operation    *GetAuditEntriesParamsOperation `form:"operation,omitempty" json:"operation,omitempty"`
+	CredentialID *AuditCredentialID              `form:"credentialID,omitempty" json:"credentialID,omitempty"`
+	Actor        *AuditActor                     `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// Returns entries recorded at or after the given time.
+	From *AuditFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// Returns entries recorded before the given time.
+	To *AuditTo `form:"to,omitempty" json:"to,omitempty"`
+
+	// Returns entries with sequence number greater than the given one. Used for paging.
+	AfterSeq *int64 `form:"afterSeq,omitempty" json:"afterSeq,omitempty"`
+
+	// Maximum number of entries to return. Defaults to 100.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAuditEntriesParamsOperation defines parameters for GetAuditEntries.
+type GetAuditEntriesParamsOperation string
+
+// ExportAuditEntriesParams defines parameters for ExportAuditEntries.
+type ExportAuditEntriesParams struct {
+	ProfileID    *AuditProfileID                    `form:"profileID,omitempty" json:"profileID,omitempty"`
+	Operation    *ExportAuditEntriesParamsOperation `form:"operation,omitempty" json:"operation,omitempty"`
+	CredentialID *AuditCredentialID                 `form:"credentialID,omitempty" json:"credentialID,omitempty"`
+	Actor        *AuditActor                        `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// Returns entries recorded at or after the given time.
+	From *AuditFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// Returns entries recorded before the given time.
+	To *AuditTo `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportAuditEntriesParamsOperation defines parameters for ExportAuditEntries.
+type ExportAuditEntriesParamsOperation string
+
+// UpdateLogSpecJSONBody defines parameters for UpdateLogSpec.
+type UpdateLogSpecJSONBody = UpdateLogSpecRequest
+
+// UpdateLogSpecJSONRequestBody defines body for UpdateLogSpec for application/json ContentType.
+type UpdateLogSpecJSONRequestBody = UpdateLogSpecJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Returns audit log entries of the caller organization.
+	// (GET /admin/audit/entries)
+	GetAuditEntries(ctx echo.Context, params GetAuditEntriesParams) error
+	// Exports audit log entries of the caller organization as newline delimited JSON.
+	// (GET /admin/audit/export)
+	ExportAuditEntries(ctx echo.Context, params ExportAuditEntriesParams) error
+	// Verifies hash chain of the audit log of the caller organization.
+	// (GET /admin/audit/verify)
+	VerifyAuditLog(ctx echo.Context) error
+	// Returns current log levels of the server modules.
+	// (GET /admin/logspec)
+	GetLogSpec(ctx echo.Context) error
+	// Changes log levels of the server modules.
+	// (PUT /admin/logspec)
+	UpdateLogSpec(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetAuditEntries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAuditEntries(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"admin:audit"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditEntriesParams
+	// ------------- Optional query parameter "profileID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "profileID", ctx.QueryParams(), &params.ProfileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// ------------- Optional query parameter "operation" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "operation", ctx.QueryParams(), &params.Operation)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter operation: %s", err))
+	}
+
+	// ------------- Optional query parameter "credentialID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "credentialID", ctx.QueryParams(), &params.CredentialID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialID: %s", err))
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", ctx.QueryParams(), &params.Actor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter actor: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "afterSeq" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "afterSeq", ctx.QueryParams(), &params.AfterSeq)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter afterSeq: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetAuditEntries(ctx, params)
+	return err
+}
+
+// ExportAuditEntries converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportAuditEntries(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"admin:audit"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportAuditEntriesParams
+	// ------------- Optional query parameter "profileID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "profileID", ctx.QueryParams(), &params.ProfileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// ------------- Optional query parameter "operation" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "operation", ctx.QueryParams(), &params.Operation)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter operation: %s", err))
+	}
+
+	// ------------- Optional query parameter "credentialID" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "credentialID", ctx.QueryParams(), &params.CredentialID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter credentialID: %s", err))
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", ctx.QueryParams(), &params.Actor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter actor: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.ExportAuditEntries(ctx, params)
+	return err
+}
+
+// VerifyAuditLog converts echo context to params.
+func (w *ServerInterfaceWrapper) VerifyAuditLog(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"admin:audit"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.VerifyAuditLog(ctx)
+	return err
+}
+
+// GetLogSpec converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLogSpec(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"admin:logspec"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetLogSpec(ctx)
+	return err
+}
+
+// UpdateLogSpec converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateLogSpec(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"admin:logspec"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.UpdateLogSpec(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/admin/audit/entries", wrapper.GetAuditEntries)
+	router.GET(baseURL+"/admin/audit/export", wrapper.ExportAuditEntries)
+	router.GET(baseURL+"/admin/audit/verify", wrapper.VerifyAuditLog)
+	router.GET(baseURL+"/admin/logspec", wrapper.GetLogSpec)
+	router.PUT(baseURL+"/admin/logspec", wrapper.UpdateLogSpec)
+
+}
@@ -11,6 +11,11 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
 // DID Config response.
 type DidConfig struct {
 	// context.
@@ -70,6 +75,10 @@ func (w *ServerInterfaceWrapper) DidConfig(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.DidConfig(ctx, profileType, profileID)
 	return err
@@ -9,14 +9,36 @@ SPDX-License-Identifier: Apache-2.0
 package healthcheck
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"github.com/trustbloc/vcs/pkg/observability/health"
 )
 
+type healthChecker interface {
+	Run(ctx context.Context) *health.Report
+}
+
+// Config holds configuration for health check Controller.
+type Config struct {
+	HealthChecker healthChecker
+}
+
 // Controller for health check API.
-type Controller struct{}
+type Controller struct {
+	healthChecker healthChecker
+}
+
+// NewController creates a new controller for health check API.
+func NewController(config *Config) *Controller {
+	return &Controller{
+		healthChecker: config.HealthChecker,
+	}
+}
 
 // GetHealthcheck returns the health check status.
 // GET /healthcheck.
@@ -25,3 +47,49 @@ func (c *Controller) GetHealthcheck(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, HealthCheckResponse{Status: "success", CurrentTime: &currentTime})
 }
+
+// GetLiveness returns the liveness status. Server is live as long as it is able to handle requests.
+// GET /healthcheck/live.
+func (c *Controller) GetLiveness(ctx echo.Context) error {
+	currentTime := time.Now()
+
+	return ctx.JSON(http.StatusOK, HealthCheckResponse{Status: string(health.StatusOK), CurrentTime: &currentTime})
+}
+
+// GetReadiness returns the readiness status together with status of each dependency.
+// GET /healthcheck/ready.
+func (c *Controller) GetReadiness(ctx echo.Context) error {
+	resp := ReadinessResponse{
+		Status:      ReadinessResponseStatusOk,
+		CurrentTime: time.Now(),
+		Components:  []ComponentStatus{},
+	}
+
+	if c.healthChecker != nil {
+		report := c.healthChecker.Run(ctx.Request().Context())
+
+		resp.Status = ReadinessResponseStatus(report.Status)
+
+		for _, r := range report.Components {
+			component := ComponentStatus{
+				Name:      r.Name,
+				Status:    ComponentStatusStatus(r.Status),
+				Critical:  r.Critical,
+				LatencyMs: r.Latency.Milliseconds(),
+			}
+
+			if r.Error != "" {
+				component.Error = lo.ToPtr(r.Error)
+			}
+
+			resp.Components = append(resp.Components, component)
+		}
+	}
+
+	code := http.StatusOK
+	if resp.Status == ReadinessResponseStatusFailed {
+		code = http.StatusServiceUnavailable
+	}
+
+	return ctx.JSON(code, resp)
+}
@@ -7,13 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package healthcheck_test
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/vcs/pkg/observability/health"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/healthcheck"
 )
 
@@ -34,3 +38,73 @@ func TestController_GetHealthcheck(t *testing.T) {
 		require.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+type healthChecker struct {
+	report *health.Report
+}
+
+func (c *healthChecker) Run(context.Context) *health.Report {
+	return c.report
+}
+
+func TestController_GetLiveness(t *testing.T) {
+	e := echo.New()
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	require.NoError(t, healthcheck.NewController(&healthcheck.Config{}).GetLiveness(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp healthcheck.HealthCheckResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "ok", resp.Status)
+}
+
+func TestController_GetReadiness(t *testing.T) {
+	getReadiness := func(t *testing.T, checker *healthChecker) (int, *healthcheck.ReadinessResponse) {
+		t.Helper()
+
+		e := echo.New()
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+		require.NoError(t, healthcheck.NewController(&healthcheck.Config{HealthChecker: checker}).GetReadiness(c))
+
+		var resp healthcheck.ReadinessResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+		return rec.Code, &resp
+	}
+
+	t.Run("Ready", func(t *testing.T) {
+		code, resp := getReadiness(t, &healthChecker{report: &health.Report{
+			Status: health.StatusDegraded,
+			Components: []health.ComponentResult{
+				{Name: "mongodb", Status: health.StatusOK, Critical: true, Latency: 5 * time.Millisecond},
+				{Name: "universal-resolver", Status: health.StatusFailed, Error: "connection refused"},
+			},
+		}})
+
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, healthcheck.ReadinessResponseStatusDegraded, resp.Status)
+		require.Len(t, resp.Components, 2)
+		require.Equal(t, int64(5), resp.Components[0].LatencyMs)
+		require.Nil(t, resp.Components[0].Error)
+		require.Equal(t, healthcheck.ComponentStatusStatusFailed, resp.Components[1].Status)
+		require.Equal(t, "connection refused", *resp.Components[1].Error)
+	})
+
+	t.Run("Not ready", func(t *testing.T) {
+		code, resp := getReadiness(t, &healthChecker{report: &health.Report{
+			Status: health.StatusFailed,
+			Components: []health.ComponentResult{
+				{Name: "mongodb", Status: health.StatusFailed, Critical: true, Error: "server selection timeout"},
+			},
+		}})
+
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Equal(t, healthcheck.ReadinessResponseStatusFailed, resp.Status)
+	})
+}
@@ -9,6 +9,41 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// Defines values for ComponentStatusStatus.
+const (
+	ComponentStatusStatusDegraded ComponentStatusStatus = "degraded"
+	ComponentStatusStatusFailed   ComponentStatusStatus = "failed"
+	ComponentStatusStatusOk       ComponentStatusStatus = "ok"
+)
+
+// Defines values for ReadinessResponseStatus.
+const (
+	ReadinessResponseStatusDegraded ReadinessResponseStatus = "degraded"
+	ReadinessResponseStatusFailed   ReadinessResponseStatus = "failed"
+	ReadinessResponseStatusOk       ReadinessResponseStatus = "ok"
+)
+
+// Status of the dependency.
+type ComponentStatus struct {
+	// Server is not ready if critical dependency has failed.
+	Critical bool `json:"critical"`
+
+	// Error returned by the check.
+	Error *string `json:"error,omitempty"`
+
+	// Duration of the check in milliseconds.
+	LatencyMs int64 `json:"latencyMs"`
+
+	// Name of the dependency.
+	Name string `json:"name"`
+
+	// Status of the dependency.
+	Status ComponentStatusStatus `json:"status"`
+}
+
+// Status of the dependency.
+type ComponentStatusStatus string
+
 // Response model for health check status.
 type HealthCheckResponse struct {
 	// Current time of the server.
@@ -18,11 +53,32 @@ type HealthCheckResponse struct {
 	Status string `json:"status"`
 }
 
+// Response model for readiness status.
+type ReadinessResponse struct {
+	// Status of dependencies.
+	Components []ComponentStatus `json:"components"`
+
+	// Current time of the server.
+	CurrentTime time.Time `json:"currentTime"`
+
+	// Overall readiness status.
+	Status ReadinessResponseStatus `json:"status"`
+}
+
+// Overall readiness status.
+type ReadinessResponseStatus string
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Health Check
 	// (GET /healthcheck)
 	GetHealthcheck(ctx echo.Context) error
+	// Liveness Check
+	// (GET /healthcheck/live)
+	GetLiveness(ctx echo.Context) error
+	// Readiness Check
+	// (GET /healthcheck/ready)
+	GetReadiness(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -39,6 +95,24 @@ func (w *ServerInterfaceWrapper) GetHealthcheck(ctx echo.Context) error {
 	return err
 }
 
+// GetLiveness converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLiveness(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetLiveness(ctx)
+	return err
+}
+
+// GetReadiness converts echo context to params.
+func (w *ServerInterfaceWrapper) GetReadiness(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetReadiness(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -68,5 +142,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/healthcheck", wrapper.GetHealthcheck)
+	router.GET(baseURL+"/healthcheck/live", wrapper.GetLiveness)
+	router.GET(baseURL+"/healthcheck/ready", wrapper.GetReadiness)
 
 }
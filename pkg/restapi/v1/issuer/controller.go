@@ -5,12 +5,13 @@ SPDX-License-Identifier: Apache-2.0
 */
 
 //go:generate oapi-codegen --config=openapi.cfg.yaml ../../../../docs/v1/openapi.yaml
-//go:generate mockgen -destination controller_mocks_test.go -self_package mocks -package issuer -source=controller.go -mock_names profileService=MockProfileService,kmsRegistry=MockKMSRegistry,issueCredentialService=MockIssueCredentialService,oidc4vcService=MockOIDC4VCService,vcStatusManager=MockVCStatusManager
+//go:generate mockgen -destination controller_mocks_test.go -self_package mocks -package issuer -source=controller.go -mock_names auditLog=MockAuditLog,profileService=MockProfileService,kmsRegistry=MockKMSRegistry,issueCredentialService=MockIssueCredentialService,oidc4vcService=MockOIDC4VCService,vcStatusManager=MockVCStatusManager,metricsProvider=MockMetricsProvider
 
 package issuer
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -22,23 +23,41 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/samber/lo"
 
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/audit"
 	"github.com/trustbloc/vcs/pkg/doc/vc"
 	"github.com/trustbloc/vcs/pkg/doc/vc/crypto"
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	"github.com/trustbloc/vcs/pkg/event/spi"
 	"github.com/trustbloc/vcs/pkg/kms"
+	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 	"github.com/trustbloc/vcs/pkg/restapi/resterr"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/common"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
 	"github.com/trustbloc/vcs/pkg/service/credentialstatus"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
+	"github.com/trustbloc/vcs/pkg/service/wellknown"
 )
 
 const (
 	issuerProfileSvcComponent = "issuer.ProfileService"
+
+	// Endpoints of VCS OAuth 2.0 authorization server and credential endpoint used by wallets.
+	authorizationEndpointPath = "/oidc/authorize"
+	tokenEndpointPath         = "/oidc/token"
+	parEndpointPath           = "/oidc/par"
+	registrationEndpointPath  = "/oidc/register"
+	introspectionEndpointPath = "/oidc/introspect"
+	revocationEndpointPath    = "/oidc/revoke"
+	credentialEndpointPath    = "/oidc/credential"
+
+	// authorizationServerMetadataPath is a path suffix the authorization server metadata is served at.
+	authorizationServerMetadataPath = "/.well-known/oauth-authorization-server"
 )
 
+var logger = log.New("issuer-rest")
+
 var _ ServerInterface = (*Controller)(nil) // make sure Controller implements ServerInterface
 
 type kmsManager = kms.VCSKeyManager
@@ -56,7 +75,7 @@ type eventService interface {
 }
 
 type issueCredentialService interface {
-	IssueCredential(credential *verifiable.Credential,
+	IssueCredential(ctx context.Context, credential *verifiable.Credential,
 		issuerSigningOpts []crypto.SigningOpts,
 		profile *profileapi.Issuer) (*verifiable.Credential, error)
 }
@@ -71,7 +90,7 @@ type oidc4vcService interface {
 	PushAuthorizationDetails(
 		ctx context.Context,
 		opState string,
-		ad *oidc4vc.AuthorizationDetails,
+		ad []*oidc4vc.AuthorizationDetails,
 	) error
 
 	PrepareClaimDataAuthorizationRequest(
@@ -88,13 +107,60 @@ type oidc4vcService interface {
 	ExchangeAuthorizationCode(
 		ctx context.Context,
 		opState string,
-	) (oidc4vc.TxID, error)
+	) (*oidc4vc.ExchangeAuthorizationCodeResult, error)
+
+	PrepareCredential(
+		ctx context.Context,
+		req *oidc4vc.PrepareCredentialRequest,
+	) (*oidc4vc.PrepareCredentialResponse, error)
+
+	StoreDeferredClaimData(
+		ctx context.Context,
+		req *oidc4vc.StoreDeferredClaimDataRequest,
+	) error
+
+	PrepareDeferredCredential(
+		ctx context.Context,
+		acceptanceToken string,
+	) (*oidc4vc.PrepareCredentialResponse, error)
+
+	GetCredentialOffer(
+		ctx context.Context,
+		offerID string,
+	) (*oidc4vc.CredentialOffer, error)
+
+	GetInitiateIssuanceURL(
+		ctx context.Context,
+		profileID string,
+		txID oidc4vc.TxID,
+	) (string, error)
+
+	GetIssuanceStatus(
+		ctx context.Context,
+		profileID string,
+		txID oidc4vc.TxID,
+	) (*oidc4vc.TransactionStatus, error)
+
+	CancelIssuance(
+		ctx context.Context,
+		profileID string,
+		txID oidc4vc.TxID,
+	) error
 }
 
 type vcStatusManager interface {
 	GetRevocationListVC(id string) (*verifiable.Credential, error)
 	GetCredentialStatusURL(issuerProfileURL, issuerProfileID, statusID string) (string, error)
-	UpdateVCStatus(signer *vc.Signer, profileName, CredentialID, status string) error
+	UpdateVCStatus(ctx context.Context, signer *vc.Signer, profileName, CredentialID, status string) error
+}
+
+type auditLog interface {
+	Record(ctx context.Context, entry *audit.Entry) error
+}
+
+type metricsProvider interface {
+	CredentialIssued(profileID, format string)
+	CredentialStatusUpdated(profileID string, success bool)
 }
 
 type Config struct {
@@ -105,6 +171,10 @@ type Config struct {
 	IssueCredentialService issueCredentialService
 	OIDC4VCService         oidc4vcService
 	VcStatusManager        vcStatusManager
+	AuditLog               auditLog
+	Metrics                metricsProvider
+	// ExternalHostURL is the URL of VCS as seen by wallets. Used in credential issuer metadata.
+	ExternalHostURL string
 }
 
 // Controller for Issuer Profile Management API.
@@ -115,10 +185,19 @@ type Controller struct {
 	issueCredentialService issueCredentialService
 	oidc4vcService         oidc4vcService
 	vcStatusManager        vcStatusManager
+	auditLog               auditLog
+	metrics                metricsProvider
+	externalHostURL        string
 }
 
 // NewController creates a new controller for Issuer Profile Management API.
 func NewController(config *Config) *Controller {
+	metrics := config.Metrics
+
+	if metrics == nil {
+		metrics = &noopMetricsProvider.NoMetrics{}
+	}
+
 	return &Controller{
 		profileSvc:             config.ProfileSvc,
 		kmsRegistry:            config.KMSRegistry,
@@ -126,6 +205,9 @@ func NewController(config *Config) *Controller {
 		issueCredentialService: config.IssueCredentialService,
 		oidc4vcService:         config.OIDC4VCService,
 		vcStatusManager:        config.VcStatusManager,
+		auditLog:               config.AuditLog,
+		metrics:                metrics,
+		externalHostURL:        config.ExternalHostURL,
 	}
 }
 
@@ -168,14 +250,45 @@ func (c *Controller) issueCredential(ctx echo.Context, body *IssueCredentialData
 		return nil, err
 	}
 
-	signedVC, err := c.issueCredentialService.IssueCredential(credential, credOpts, profile)
+	signedVC, err := c.issueCredentialService.IssueCredential(ctx.Request().Context(), credential, credOpts, profile)
 	if err != nil {
 		return nil, resterr.NewSystemError("IssueCredentialService", "IssueCredential", err)
 	}
 
+	c.metrics.CredentialIssued(profile.ID, string(profile.VCConfig.Format))
+
+	entry := &audit.Entry{
+		OrgID:        oidcOrgID,
+		ProfileID:    profile.ID,
+		Operation:    audit.OperationIssueCredential,
+		CredentialID: signedVC.ID,
+	}
+
+	if signedVC.Status != nil {
+		entry.StatusListIndex, _ = signedVC.Status.CustomFields[credentialstatus.StatusListIndex].(string)
+	}
+
+	c.recordAudit(ctx, entry)
+
 	return signedVC, nil
 }
 
+// recordAudit appends entry to the audit log. Operation has already been completed at this point,
+// so failure to record it is logged and not returned to the caller.
+func (c *Controller) recordAudit(ctx echo.Context, entry *audit.Entry) {
+	if c.auditLog == nil {
+		return
+	}
+
+	entry.Actor = util.GetActor(ctx)
+	entry.RequestID = util.GetRequestID(ctx)
+
+	if err := c.auditLog.Record(ctx.Request().Context(), entry); err != nil {
+		logger.WithContext(ctx.Request().Context()).Error("Failed to record audit entry", log.WithError(err),
+			log.WithAdditionalMessage(string(entry.Operation)))
+	}
+}
+
 func validateIssueCredOptions(options *IssueCredentialOptions) ([]crypto.SigningOpts, error) {
 	var signingOpts []crypto.SigningOpts
 
@@ -276,11 +389,23 @@ func (c *Controller) updateCredentialStatus(ctx echo.Context, body *UpdateCreden
 		SignatureRepresentation: profile.VCConfig.SignatureRepresentation,
 	}
 
-	err = c.vcStatusManager.UpdateVCStatus(signer, profile.Name, body.CredentialID, body.CredentialStatus.Status)
+	err = c.vcStatusManager.UpdateVCStatus(ctx.Request().Context(), signer, profile.Name, body.CredentialID,
+		body.CredentialStatus.Status)
+
+	c.metrics.CredentialStatusUpdated(profile.ID, err == nil)
+
 	if err != nil {
 		return resterr.NewSystemError("VCStatusManager", "UpdateVCStatus", err)
 	}
 
+	c.recordAudit(ctx, &audit.Entry{
+		OrgID:        oidcOrgID,
+		ProfileID:    profile.ID,
+		Operation:    audit.OperationUpdateStatus,
+		CredentialID: body.CredentialID,
+		Status:       body.CredentialStatus.Status,
+	})
+
 	return nil
 }
 
@@ -311,31 +436,195 @@ func (c *Controller) initiateIssuance(
 	req *InitiateOIDC4VCRequest,
 	profile *profileapi.Issuer,
 ) (*InitiateOIDC4VCResponse, error) {
+	templateIDs := lo.FromPtr(req.CredentialTemplateIds)
+	if req.CredentialTemplateId != nil && !lo.Contains(templateIDs, *req.CredentialTemplateId) {
+		templateIDs = append([]string{*req.CredentialTemplateId}, templateIDs...)
+	}
+
 	issuanceReq := &oidc4vc.InitiateIssuanceRequest{
-		CredentialTemplateID:      lo.FromPtr(req.CredentialTemplateId),
-		ClientInitiateIssuanceURL: lo.FromPtr(req.ClientInitiateIssuanceUrl),
-		ClientWellKnownURL:        lo.FromPtr(req.ClientWellknown),
-		ClaimEndpoint:             lo.FromPtr(req.ClaimEndpoint),
-		GrantType:                 lo.FromPtr(req.GrantType),
-		ResponseType:              lo.FromPtr(req.ResponseType),
-		Scope:                     lo.FromPtr(req.Scope),
-		OpState:                   lo.FromPtr(req.OpState),
+		CredentialTemplateIDs:      templateIDs,
+		ClientInitiateIssuanceURL:  lo.FromPtr(req.ClientInitiateIssuanceUrl),
+		ClientWellKnownURL:         lo.FromPtr(req.ClientWellknown),
+		ClaimEndpoint:              lo.FromPtr(req.ClaimEndpoint),
+		GrantType:                  lo.FromPtr(req.GrantType),
+		ResponseType:               lo.FromPtr(req.ResponseType),
+		Scope:                      lo.FromPtr(req.Scope),
+		OpState:                    lo.FromPtr(req.OpState),
+		CredentialOfferByReference: lo.FromPtr(req.CredentialOfferByReference),
 	}
 
 	resp, err := c.oidc4vcService.InitiateIssuance(ctx, issuanceReq, profile)
 	if err != nil {
 		if errors.Is(err, oidc4vc.ErrCredentialTemplateNotFound) ||
-			errors.Is(err, oidc4vc.ErrCredentialTemplateIDRequired) {
+			errors.Is(err, oidc4vc.ErrCredentialTemplateIDRequired) ||
+			errors.Is(err, oidc4vc.ErrCredentialTemplateDuplicate) {
 			return nil, resterr.NewValidationError(resterr.InvalidValue, "credential_template_id", err)
 		}
 
+		if errors.Is(err, oidc4vc.ErrIssuerOIDCConfigurationInvalid) ||
+			errors.Is(err, wellknown.ErrInvalidConfiguration) {
+			return nil, resterr.NewValidationError(resterr.ConditionNotMet, "profile.oidcConfig.issuer_well_known", err)
+		}
+
 		return nil, resterr.NewSystemError("OIDC4VCService", "InitiateIssuance", err)
 	}
 
-	return &InitiateOIDC4VCResponse{
+	result := &InitiateOIDC4VCResponse{
 		InitiateIssuanceUrl: resp.InitiateIssuanceURL,
 		TxId:                string(resp.TxID),
-	}, nil
+	}
+
+	if resp.CredentialOfferURI != "" {
+		result.CredentialOfferUri = &resp.CredentialOfferURI
+	}
+
+	if !resp.ExpiresAt.IsZero() {
+		result.ExpiresAt = &resp.ExpiresAt
+	}
+
+	if resp.ClientWellKnownError != "" {
+		result.ClientWellknownError = &resp.ClientWellKnownError
+	}
+
+	return result, nil
+}
+
+// GetCredentialOffer returns credential offer passed to the wallet by reference.
+// GET /issuer/credential-offers/{offerID}.
+func (c *Controller) GetCredentialOffer(ctx echo.Context, offerID string) error {
+	offer, err := c.oidc4vcService.GetCredentialOffer(ctx.Request().Context(), offerID)
+	if err != nil {
+		if errors.Is(err, oidc4vc.ErrDataNotFound) {
+			return resterr.NewValidationError(resterr.DoesntExist, "offerID", err)
+		}
+
+		return resterr.NewSystemError("OIDC4VCService", "GetCredentialOffer", err)
+	}
+
+	return util.WriteOutput(ctx)(&CredentialOffer{
+		Issuer:         offer.Issuer,
+		CredentialType: offer.CredentialTypes,
+		OpState:        offer.OpState,
+	}, nil)
+}
+
+// GetIssuanceQrCode returns QR code image with initiate issuance URL of the transaction.
+// GET /issuer/profiles/{profileID}/interactions/{txID}/qr-code.
+func (c *Controller) GetIssuanceQrCode(
+	ctx echo.Context,
+	profileID string,
+	txID string,
+	params GetIssuanceQrCodeParams,
+) error {
+	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	profile, err := c.accessOIDCProfile(profileID, oidcOrgID)
+	if err != nil {
+		return err
+	}
+
+	initiateURL, err := c.oidc4vcService.GetInitiateIssuanceURL(ctx.Request().Context(), profile.ID,
+		oidc4vc.TxID(txID))
+	if err != nil {
+		if errors.Is(err, oidc4vc.ErrDataNotFound) {
+			return resterr.NewValidationError(resterr.DoesntExist, "txID", err)
+		}
+
+		return resterr.NewSystemError("OIDC4VCService", "GetInitiateIssuanceURL", err)
+	}
+
+	return util.WriteQRCode(ctx, initiateURL, (*string)(params.Format), params.Size)
+}
+
+// GetCredentialIssuanceStatus returns state of the issuance transaction.
+// GET /issuer/profiles/{profileID}/interactions/{txID}/status.
+func (c *Controller) GetCredentialIssuanceStatus(ctx echo.Context, profileID string, txID string) error {
+	return util.WriteOutput(ctx)(c.getCredentialIssuanceStatus(ctx, profileID, txID))
+}
+
+func (c *Controller) getCredentialIssuanceStatus(
+	ctx echo.Context,
+	profileID string,
+	txID string,
+) (*CredentialIssuanceStatus, error) {
+	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := c.accessOIDCProfile(profileID, oidcOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := c.oidc4vcService.GetIssuanceStatus(ctx.Request().Context(), profile.ID, oidc4vc.TxID(txID))
+	if err != nil {
+		if errors.Is(err, oidc4vc.ErrDataNotFound) {
+			return nil, resterr.NewValidationError(resterr.DoesntExist, "txID", err)
+		}
+
+		return nil, resterr.NewSystemError("OIDC4VCService", "GetIssuanceStatus", err)
+	}
+
+	result := &CredentialIssuanceStatus{
+		TxId:  string(status.TxID),
+		State: CredentialIssuanceStatusState(status.State),
+	}
+
+	if !status.ExpiresAt.IsZero() {
+		result.ExpiresAt = &status.ExpiresAt
+	}
+
+	return result, nil
+}
+
+// CancelCredentialIssuance cancels the issuance transaction.
+// POST /issuer/profiles/{profileID}/interactions/{txID}/cancel.
+func (c *Controller) CancelCredentialIssuance(ctx echo.Context, profileID string, txID string) error {
+	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	profile, err := c.accessOIDCProfile(profileID, oidcOrgID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.oidc4vcService.CancelIssuance(ctx.Request().Context(), profile.ID, oidc4vc.TxID(txID)); err != nil {
+		if errors.Is(err, oidc4vc.ErrDataNotFound) {
+			return resterr.NewValidationError(resterr.DoesntExist, "txID", err)
+		}
+
+		if errors.Is(err, oidc4vc.ErrTransactionNotCancellable) {
+			return resterr.NewValidationError(resterr.ConditionNotMet, "txID", err)
+		}
+
+		if stateErr := transactionStateError(err, "txID"); stateErr != nil {
+			return stateErr
+		}
+
+		return resterr.NewSystemError("OIDC4VCService", "CancelIssuance", err)
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+// transactionStateError returns validation error of the given field if the transaction was cancelled, its
+// credential offer expired or its state was changed by a concurrent request, nil otherwise.
+func transactionStateError(err error, field string) error {
+	if errors.Is(err, oidc4vc.ErrTransactionExpired) || errors.Is(err, oidc4vc.ErrTransactionCancelled) {
+		return resterr.NewValidationError(resterr.ConditionNotMet, field, err)
+	}
+
+	if errors.Is(err, oidc4vc.ErrTransactionStateChanged) {
+		return resterr.NewValidationError(resterr.Conflict, field, err)
+	}
+
+	return nil
 }
 
 // PushAuthorizationDetails updates authorization details.
@@ -347,7 +636,7 @@ func (c *Controller) PushAuthorizationDetails(ctx echo.Context) error {
 		return err
 	}
 
-	ad, err := common.ValidateAuthorizationDetails(&body.AuthorizationDetails)
+	ad, err := validateAuthorizationDetails(body.AuthorizationDetails)
 	if err != nil {
 		return err
 	}
@@ -361,6 +650,10 @@ func (c *Controller) PushAuthorizationDetails(ctx echo.Context) error {
 			return resterr.NewValidationError(resterr.InvalidValue, "authorization_details.format", err)
 		}
 
+		if stateErr := transactionStateError(err, "op_state"); stateErr != nil {
+			return stateErr
+		}
+
 		return resterr.NewSystemError("OIDC4VCService", "PushAuthorizationRequest", err)
 	}
 
@@ -383,7 +676,7 @@ func (c *Controller) prepareClaimDataAuthorizationRequest(
 	ctx context.Context,
 	body *PrepareClaimDataAuthorizationRequest,
 ) (*PrepareClaimDataAuthorizationResponse, error) {
-	ad, err := common.ValidateAuthorizationDetails(body.AuthorizationDetails)
+	ad, err := validateAuthorizationDetails(lo.FromPtr(body.AuthorizationDetails))
 	if err != nil {
 		return nil, err
 	}
@@ -397,6 +690,10 @@ func (c *Controller) prepareClaimDataAuthorizationRequest(
 		},
 	)
 	if err != nil {
+		if stateErr := transactionStateError(err, "op_state"); stateErr != nil {
+			return nil, stateErr
+		}
+
 		return nil, resterr.NewSystemError("OIDC4VCService", "PrepareClaimDataAuthorizationRequest", err)
 	}
 
@@ -413,6 +710,21 @@ func (c *Controller) prepareClaimDataAuthorizationRequest(
 	}, nil
 }
 
+func validateAuthorizationDetails(details []common.AuthorizationDetails) ([]*oidc4vc.AuthorizationDetails, error) {
+	result := make([]*oidc4vc.AuthorizationDetails, 0, len(details))
+
+	for i := range details {
+		ad, err := common.ValidateAuthorizationDetails(&details[i])
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, ad)
+	}
+
+	return result, nil
+}
+
 func (c *Controller) accessProfile(profileID string) (*profileapi.Issuer, error) {
 	profile, err := c.profileSvc.GetProfile(profileID)
 	if err != nil {
@@ -451,7 +763,23 @@ func (c *Controller) StoreAuthorizationCodeRequest(ctx echo.Context) error {
 		return err
 	}
 
-	return util.WriteOutput(ctx)(c.oidc4vcService.StoreAuthorizationCode(ctx.Request().Context(), body.OpState, body.Code))
+	return util.WriteOutput(ctx)(c.storeAuthorizationCode(ctx.Request().Context(), &body))
+}
+
+func (c *Controller) storeAuthorizationCode(
+	ctx context.Context,
+	body *StoreAuthorizationCodeRequest,
+) (oidc4vc.TxID, error) {
+	txID, err := c.oidc4vcService.StoreAuthorizationCode(ctx, body.OpState, body.Code)
+	if err != nil {
+		if stateErr := transactionStateError(err, "op_state"); stateErr != nil {
+			return "", stateErr
+		}
+
+		return "", resterr.NewSystemError("OIDC4VCService", "StoreAuthorizationCode", err)
+	}
+
+	return txID, nil
 }
 
 // ExchangeAuthorizationCodeRequest Exchanges authorization code.
@@ -463,5 +791,434 @@ func (c *Controller) ExchangeAuthorizationCodeRequest(ctx echo.Context) error {
 		return err
 	}
 
-	return util.WriteOutput(ctx)(c.oidc4vcService.ExchangeAuthorizationCode(ctx.Request().Context(), body.OpState))
+	return util.WriteOutput(ctx)(c.exchangeAuthorizationCode(ctx, body.OpState))
+}
+
+func (c *Controller) exchangeAuthorizationCode(
+	ctx echo.Context,
+	opState string,
+) (*ExchangeAuthorizationCodeResponse, error) {
+	result, err := c.oidc4vcService.ExchangeAuthorizationCode(ctx.Request().Context(), opState)
+	if err != nil {
+		if stateErr := transactionStateError(err, "op_state"); stateErr != nil {
+			return nil, stateErr
+		}
+
+		return nil, resterr.NewSystemError("OIDC4VCService", "ExchangeAuthorizationCode", err)
+	}
+
+	return &ExchangeAuthorizationCodeResponse{
+		TxId:             strPtr(string(result.TxID)),
+		CredentialIssuer: strPtr(c.credentialIssuerID(result.ProfileID)),
+	}, nil
+}
+
+// PrepareCredential issues credential offered in OIDC4VC issuance transaction.
+// POST /issuer/interactions/prepare-credential.
+func (c *Controller) PrepareCredential(ctx echo.Context) error {
+	var body PrepareCredential
+
+	if err := util.ReadBody(ctx, &body); err != nil {
+		return err
+	}
+
+	return util.WriteOutput(ctx)(c.prepareCredential(ctx, &body))
+}
+
+func (c *Controller) prepareCredential(ctx echo.Context, body *PrepareCredential) (*PrepareCredentialResult, error) {
+	req := &oidc4vc.PrepareCredentialRequest{
+		OpState:        body.OpState,
+		CredentialType: body.CredentialType,
+		DID:            body.Did,
+		ValidateOnly:   lo.FromPtr(body.ValidateOnly),
+	}
+
+	if body.Format != nil {
+		format, err := common.ValidateVCFormat(common.VCFormat(*body.Format))
+		if err != nil {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "format", err)
+		}
+
+		req.Format = format
+	}
+
+	resp, err := c.oidc4vcService.PrepareCredential(ctx.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, oidc4vc.ErrCredentialTypeNotSupported) ||
+			errors.Is(err, oidc4vc.ErrCredentialNotAuthorized) {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "credential_type", err)
+		}
+
+		if errors.Is(err, oidc4vc.ErrCredentialFormatNotSupported) {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "format", err)
+		}
+
+		if stateErr := transactionStateError(err, "op_state"); stateErr != nil {
+			return nil, stateErr
+		}
+
+		return nil, resterr.NewSystemError("OIDC4VCService", "PrepareCredential", err)
+	}
+
+	if req.ValidateOnly {
+		format, formatErr := common.MapToVCFormat(resp.Format)
+		if formatErr != nil {
+			return nil, resterr.NewSystemError("OIDC4VCService", "PrepareCredential", formatErr)
+		}
+
+		return &PrepareCredentialResult{Format: string(format)}, nil
+	}
+
+	return c.signPreparedCredential(ctx, resp)
+}
+
+// StoreDeferredClaimData stores claim data of the credential which issuance was deferred.
+// POST /issuer/profiles/{profileID}/interactions/{txID}/claim-data.
+func (c *Controller) StoreDeferredClaimData(ctx echo.Context, profileID string, txID string) error {
+	var body DeferredClaimData
+
+	if err := util.ReadBody(ctx, &body); err != nil {
+		return err
+	}
+
+	if err := c.storeDeferredClaimData(ctx, &body, profileID, txID); err != nil {
+		return err
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (c *Controller) storeDeferredClaimData(
+	ctx echo.Context,
+	body *DeferredClaimData,
+	profileID string,
+	txID string,
+) error {
+	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	profile, err := c.accessOIDCProfile(profileID, oidcOrgID)
+	if err != nil {
+		return err
+	}
+
+	claimData, err := json.Marshal(body.Claims)
+	if err != nil {
+		return resterr.NewValidationError(resterr.InvalidValue, "claims", err)
+	}
+
+	err = c.oidc4vcService.StoreDeferredClaimData(ctx.Request().Context(), &oidc4vc.StoreDeferredClaimDataRequest{
+		ProfileID:      profile.ID,
+		TxID:           oidc4vc.TxID(txID),
+		CredentialType: body.CredentialType,
+		ClaimData:      claimData,
+	})
+	if err != nil {
+		if errors.Is(err, oidc4vc.ErrDataNotFound) {
+			return resterr.NewValidationError(resterr.DoesntExist, "txID", err)
+		}
+
+		if errors.Is(err, oidc4vc.ErrDeferredCredentialNotFound) {
+			return resterr.NewValidationError(resterr.InvalidValue, "credential_type", err)
+		}
+
+		if errors.Is(err, oidc4vc.ErrInvalidClaimData) {
+			return resterr.NewValidationError(resterr.InvalidValue, "claims", err)
+		}
+
+		if stateErr := transactionStateError(err, "txID"); stateErr != nil {
+			return stateErr
+		}
+
+		return resterr.NewSystemError("OIDC4VCService", "StoreDeferredClaimData", err)
+	}
+
+	return nil
+}
+
+// PrepareDeferredCredential issues credential which issuance was deferred.
+// POST /issuer/interactions/prepare-deferred-credential.
+func (c *Controller) PrepareDeferredCredential(ctx echo.Context) error {
+	var body PrepareDeferredCredential
+
+	if err := util.ReadBody(ctx, &body); err != nil {
+		return err
+	}
+
+	return util.WriteOutput(ctx)(c.prepareDeferredCredential(ctx, &body))
+}
+
+func (c *Controller) prepareDeferredCredential(
+	ctx echo.Context,
+	body *PrepareDeferredCredential,
+) (*PrepareCredentialResult, error) {
+	resp, err := c.oidc4vcService.PrepareDeferredCredential(ctx.Request().Context(), body.AcceptanceToken)
+	if err != nil {
+		if errors.Is(err, oidc4vc.ErrDeferredCredentialNotFound) {
+			return nil, resterr.NewValidationError(resterr.DoesntExist, "acceptance_token", err)
+		}
+
+		if errors.Is(err, oidc4vc.ErrCredentialIssuancePending) {
+			return nil, resterr.NewValidationError(resterr.ConditionNotMet, "acceptance_token", err)
+		}
+
+		if stateErr := transactionStateError(err, "acceptance_token"); stateErr != nil {
+			return nil, stateErr
+		}
+
+		return nil, resterr.NewSystemError("OIDC4VCService", "PrepareDeferredCredential", err)
+	}
+
+	return c.signPreparedCredential(ctx, resp)
+}
+
+// signPreparedCredential signs credential prepared by OIDC4VC service with the issuer profile. If issuance is
+// deferred, acceptance token is returned instead of the credential.
+func (c *Controller) signPreparedCredential(
+	ctx echo.Context,
+	resp *oidc4vc.PrepareCredentialResponse,
+) (*PrepareCredentialResult, error) {
+	format, err := common.MapToVCFormat(resp.Format)
+	if err != nil {
+		return nil, resterr.NewSystemError("OIDC4VCService", "PrepareCredential", err)
+	}
+
+	if resp.Credential == nil {
+		return &PrepareCredentialResult{
+			Format:          string(format),
+			AcceptanceToken: strPtr(resp.AcceptanceToken),
+		}, nil
+	}
+
+	profile, err := c.accessProfile(resp.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	signingProfile, err := profileForFormat(profile, resp.Format)
+	if err != nil {
+		return nil, resterr.NewValidationError(resterr.InvalidValue, "format", err)
+	}
+
+	signedVC, err := c.issueCredentialService.IssueCredential(ctx.Request().Context(), resp.Credential, nil,
+		signingProfile)
+	if err != nil {
+		return nil, resterr.NewSystemError("IssueCredentialService", "IssueCredential", err)
+	}
+
+	c.metrics.CredentialIssued(profile.ID, string(resp.Format))
+
+	entry := &audit.Entry{
+		OrgID:        profile.OrganizationID,
+		ProfileID:    profile.ID,
+		Operation:    audit.OperationIssueCredential,
+		CredentialID: signedVC.ID,
+	}
+
+	if signedVC.Status != nil {
+		entry.StatusListIndex, _ = signedVC.Status.CustomFields[credentialstatus.StatusListIndex].(string)
+	}
+
+	c.recordAudit(ctx, entry)
+
+	return &PrepareCredentialResult{
+		Format:     string(format),
+		Credential: lo.ToPtr[interface{}](signedVC),
+	}, nil
+}
+
+// profileForFormat returns profile used to sign credential in the given format. Credential templates can use
+// format other than the format of the profile, in this case signature type is chosen by the key type of the profile.
+func profileForFormat(profile *profileapi.Issuer, format vcsverifiable.Format) (*profileapi.Issuer, error) {
+	if format == "" || format == profile.VCConfig.Format {
+		return profile, nil
+	}
+
+	vcConfig, err := vcConfigForFormat(profile.VCConfig, format)
+	if err != nil {
+		return nil, err
+	}
+
+	p := *profile
+	p.VCConfig = vcConfig
+
+	return &p, nil
+}
+
+func vcConfigForFormat(vcConfig *profileapi.VCConfig, format vcsverifiable.Format) (*profileapi.VCConfig, error) {
+	config := *vcConfig
+	config.Format = format
+
+	switch format {
+	case vcsverifiable.Jwt:
+		signatureType, err := vcsverifiable.GetJWTSignatureTypeByKey(vcConfig.KeyType)
+		if err != nil {
+			return nil, err
+		}
+
+		config.SigningAlgorithm = signatureType
+	case vcsverifiable.Ldp:
+		// JsonWebSignature2020 suite supports all key types used for JWT signatures.
+		config.SigningAlgorithm = vcsverifiable.JSONWebSignature2020
+	default:
+		return nil, fmt.Errorf("unsupported vc format %s", format)
+	}
+
+	return &config, nil
+}
+
+// OpenidCredentialIssuerConfig returns credential issuer metadata of the profile.
+// GET /issuer/profiles/{profileID}/.well-known/openid-credential-issuer.
+func (c *Controller) OpenidCredentialIssuerConfig(ctx echo.Context, profileID string) error {
+	profile, err := c.accessOIDC4VCIProfile(profileID)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteOutput(ctx)(c.buildCredentialIssuerMetadata(ctx.Request().Context(), profile), nil)
+}
+
+// OauthAuthorizationServerConfig returns metadata of VCS authorization server used by the profile.
+// GET /issuer/profiles/{profileID}/.well-known/oauth-authorization-server.
+func (c *Controller) OauthAuthorizationServerConfig(ctx echo.Context, profileID string) error {
+	if _, err := c.accessOIDC4VCIProfile(profileID); err != nil {
+		return err
+	}
+
+	// issuer identifier is the URL the metadata is served for (RFC 8414, section 3.3), wallets compare it with
+	// the credential issuer the metadata is resolved relative to
+	issuer := c.externalHostURL + strings.TrimSuffix(ctx.Request().URL.Path, authorizationServerMetadataPath)
+
+	return util.WriteOutput(ctx)(&AuthorizationServerMetadata{
+		Issuer:                             issuer,
+		AuthorizationEndpoint:              c.externalHostURL + authorizationEndpointPath,
+		TokenEndpoint:                      c.externalHostURL + tokenEndpointPath,
+		PushedAuthorizationRequestEndpoint: lo.ToPtr(c.externalHostURL + parEndpointPath),
+		RegistrationEndpoint:               lo.ToPtr(c.externalHostURL + registrationEndpointPath),
+		IntrospectionEndpoint:              lo.ToPtr(c.externalHostURL + introspectionEndpointPath),
+		RevocationEndpoint:                 lo.ToPtr(c.externalHostURL + revocationEndpointPath),
+		ResponseTypesSupported:             []string{"code"},
+		GrantTypesSupported:                &[]string{"authorization_code", "refresh_token"},
+		TokenEndpointAuthMethodsSupported:  &[]string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:      &[]string{"S256"},
+	}, nil)
+}
+
+// accessOIDC4VCIProfile returns profile that supports OIDC4VC issuance. Metadata of other profiles isn't public.
+func (c *Controller) accessOIDC4VCIProfile(profileID string) (*profileapi.Issuer, error) {
+	profile, err := c.accessProfile(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !profile.Active || profile.OIDCConfig == nil || profile.VCConfig == nil {
+		return nil, resterr.NewValidationError(resterr.DoesntExist, "profile",
+			fmt.Errorf("profile with given id %s, dosn't exists", profileID))
+	}
+
+	return profile, nil
+}
+
+// credentialIssuerID returns credential issuer identifier of the profile. Wallets resolve issuer metadata
+// relative to it.
+func (c *Controller) credentialIssuerID(profileID string) string {
+	return c.externalHostURL + "/issuer/profiles/" + profileID
+}
+
+func (c *Controller) buildCredentialIssuerMetadata(
+	ctx context.Context,
+	profile *profileapi.Issuer,
+) *CredentialIssuerMetadata {
+	credentials := make([]SupportedCredential, 0, len(profile.CredentialTemplates))
+
+	for _, t := range profile.CredentialTemplates {
+		vcConfig := profile.VCConfig
+
+		if t.Format != "" && t.Format != vcConfig.Format {
+			var err error
+
+			vcConfig, err = vcConfigForFormat(profile.VCConfig, t.Format)
+			if err != nil {
+				logger.WithContext(ctx).Warn("Credential template format is not supported by the profile",
+					log.WithProfileID(profile.ID), log.WithError(err))
+
+				continue
+			}
+		}
+
+		format := string(common.LdpVc)
+		if vcConfig.Format == vcsverifiable.Jwt {
+			format = string(common.JwtVc)
+		}
+
+		credential := SupportedCredential{
+			Id:                                   lo.ToPtr(t.ID),
+			Format:                               format,
+			Types:                                []string{"VerifiableCredential", t.Type},
+			CryptographicBindingMethodsSupported: &[]string{"did"},
+			ProofTypesSupported:                  &[]string{"jwt"},
+			Display:                              mapDisplay(t.Display),
+		}
+
+		if vcConfig.SigningAlgorithm != "" {
+			credential.CryptographicSuitesSupported = &[]string{string(vcConfig.SigningAlgorithm)}
+		}
+
+		if vcConfig.Format == vcsverifiable.Ldp {
+			credential.Context = lo.ToPtr(t.Contexts)
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	display := profile.Display
+	if len(display) == 0 && profile.Name != "" {
+		display = []*profileapi.DisplayProperties{{Name: profile.Name}}
+	}
+
+	return &CredentialIssuerMetadata{
+		CredentialIssuer:     c.credentialIssuerID(profile.ID),
+		CredentialEndpoint:   c.externalHostURL + credentialEndpointPath,
+		CredentialsSupported: credentials,
+		Display:              mapDisplay(display),
+	}
+}
+
+func mapDisplay(display []*profileapi.DisplayProperties) *[]DisplayProperties {
+	if len(display) == 0 {
+		return nil
+	}
+
+	result := make([]DisplayProperties, 0, len(display))
+
+	for _, d := range display {
+		p := DisplayProperties{
+			Name:            d.Name,
+			Locale:          strPtr(d.Locale),
+			Description:     strPtr(d.Description),
+			BackgroundColor: strPtr(d.BackgroundColor),
+			TextColor:       strPtr(d.TextColor),
+		}
+
+		if d.Logo != nil {
+			p.Logo = &Logo{
+				Url:     d.Logo.URL,
+				AltText: strPtr(d.Logo.AltText),
+			}
+		}
+
+		result = append(result, p)
+	}
+
+	return &result
+}
+
+func strPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
 }
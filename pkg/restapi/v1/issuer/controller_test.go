@@ -13,10 +13,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image/png"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -27,6 +30,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/vcs/pkg/audit"
+	"github.com/trustbloc/vcs/pkg/doc/vc/crypto"
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	"github.com/trustbloc/vcs/pkg/internal/testutil"
 	"github.com/trustbloc/vcs/pkg/kms/mocks"
@@ -35,6 +40,7 @@ import (
 	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
 	"github.com/trustbloc/vcs/pkg/service/credentialstatus"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
+	"github.com/trustbloc/vcs/pkg/service/wellknown"
 )
 
 const (
@@ -62,8 +68,8 @@ var ariesSupportedKeyTypes = []kms.KeyType{
 func TestController_PostIssueCredentials(t *testing.T) {
 	mockProfileSvc := NewMockProfileService(gomock.NewController(t))
 	mockIssueCredentialSvc := NewMockIssueCredentialService(gomock.NewController(t))
-	mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
-		Return(nil, nil)
+	mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		Return(&verifiable.Credential{}, nil)
 
 	t.Run("Success JSON-LD", func(t *testing.T) {
 		mockProfileSvc.EXPECT().GetProfile("testId").Times(1).
@@ -75,10 +81,14 @@ func TestController_PostIssueCredentials(t *testing.T) {
 				},
 			}, nil)
 
+		metrics := NewMockMetricsProvider(gomock.NewController(t))
+		metrics.EXPECT().CredentialIssued("testId", "ldp").Times(1)
+
 		controller := NewController(&Config{
 			ProfileSvc:             mockProfileSvc,
 			DocumentLoader:         testutil.DocumentLoader(t),
 			IssueCredentialService: mockIssueCredentialSvc,
+			Metrics:                metrics,
 		})
 
 		c := echoContext(withRequestBody([]byte(sampleVCJsonLD)))
@@ -121,7 +131,7 @@ func TestController_PostIssueCredentials(t *testing.T) {
 func TestController_IssueCredentials(t *testing.T) {
 	mockProfileSvc := NewMockProfileService(gomock.NewController(t))
 	mockIssueCredentialSvc := NewMockIssueCredentialService(gomock.NewController(t))
-	mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+	mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
 		Return(&verifiable.Credential{}, nil)
 
 	t.Run("Success JSON-LD", func(t *testing.T) {
@@ -276,7 +286,7 @@ func TestController_IssueCredentials(t *testing.T) {
 				},
 				getIssueCredentialService: func() issueCredentialService {
 					mockFailedIssueCredentialSvc := NewMockIssueCredentialService(gomock.NewController(t))
-					mockFailedIssueCredentialSvc.EXPECT().IssueCredential(
+					mockFailedIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any()).AnyTimes().
@@ -419,7 +429,7 @@ func TestController_PostCredentialsStatus(t *testing.T) {
 	kmsRegistry.EXPECT().GetKeyManager(gomock.Any()).AnyTimes().Return(keyManager, nil)
 
 	mockVCStatusManager := NewMockVCStatusManager(gomock.NewController(t))
-	mockVCStatusManager.EXPECT().UpdateVCStatus(
+	mockVCStatusManager.EXPECT().UpdateVCStatus(gomock.Any(),
 		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 	t.Run("Success", func(t *testing.T) {
@@ -431,11 +441,15 @@ func TestController_PostCredentialsStatus(t *testing.T) {
 				SigningDID:     &profileapi.SigningDID{},
 			}, nil)
 
+		metrics := NewMockMetricsProvider(gomock.NewController(t))
+		metrics.EXPECT().CredentialStatusUpdated("testId", true).Times(1)
+
 		controller := NewController(&Config{
 			KMSRegistry:     kmsRegistry,
 			ProfileSvc:      mockProfileSvc,
 			DocumentLoader:  testutil.DocumentLoader(t),
 			VcStatusManager: mockVCStatusManager,
+			Metrics:         metrics,
 		})
 
 		c := echoContext(withRequestBody(
@@ -454,6 +468,89 @@ func TestController_PostCredentialsStatus(t *testing.T) {
 	})
 }
 
+func TestController_AuditLog(t *testing.T) {
+	profile := &profileapi.Issuer{
+		OrganizationID: orgID,
+		ID:             "testId",
+		VCConfig: &profileapi.VCConfig{
+			Format: vcsverifiable.Ldp,
+		},
+		SigningDID: &profileapi.SigningDID{},
+	}
+
+	t.Run("Issue credential", func(t *testing.T) {
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+		mockProfileSvc.EXPECT().GetProfile("testId").Return(profile, nil)
+
+		mockIssueCredentialSvc := NewMockIssueCredentialService(gomock.NewController(t))
+		mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&verifiable.Credential{
+				ID: "urn:uuid:123",
+				Status: &verifiable.TypedID{
+					CustomFields: verifiable.CustomFields{credentialstatus.StatusListIndex: "42"},
+				},
+			}, nil)
+
+		mockAuditLog := NewMockAuditLog(gomock.NewController(t))
+		mockAuditLog.EXPECT().Record(gomock.Any(), &audit.Entry{
+			Actor:           orgID,
+			OrgID:           orgID,
+			ProfileID:       "testId",
+			Operation:       audit.OperationIssueCredential,
+			CredentialID:    "urn:uuid:123",
+			StatusListIndex: "42",
+			RequestID:       "request-id",
+		}).Return(nil)
+
+		controller := NewController(&Config{
+			ProfileSvc:             mockProfileSvc,
+			DocumentLoader:         testutil.DocumentLoader(t),
+			IssueCredentialService: mockIssueCredentialSvc,
+			AuditLog:               mockAuditLog,
+		})
+
+		c := echoContext(withRequestBody([]byte(sampleVCJsonLD)))
+		c.Request().Header.Set(echo.HeaderXRequestID, "request-id")
+
+		require.NoError(t, controller.PostIssueCredentials(c, "testId"))
+	})
+
+	t.Run("Update credential status", func(t *testing.T) {
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+		mockProfileSvc.EXPECT().GetProfile("testId").Return(profile, nil)
+
+		kmsRegistry := NewMockKMSRegistry(gomock.NewController(t))
+		kmsRegistry.EXPECT().GetKeyManager(gomock.Any()).Return(nil, nil)
+
+		mockVCStatusManager := NewMockVCStatusManager(gomock.NewController(t))
+		mockVCStatusManager.EXPECT().UpdateVCStatus(gomock.Any(), gomock.Any(), gomock.Any(), "urn:uuid:123", "true").
+			Return(nil)
+
+		mockAuditLog := NewMockAuditLog(gomock.NewController(t))
+		mockAuditLog.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, entry *audit.Entry) error {
+				require.Equal(t, audit.OperationUpdateStatus, entry.Operation)
+				require.Equal(t, "urn:uuid:123", entry.CredentialID)
+				require.Equal(t, "true", entry.Status)
+				require.Equal(t, orgID, entry.OrgID)
+
+				return errors.New("audit log error")
+			})
+
+		controller := NewController(&Config{
+			KMSRegistry:     kmsRegistry,
+			ProfileSvc:      mockProfileSvc,
+			VcStatusManager: mockVCStatusManager,
+			AuditLog:        mockAuditLog,
+		})
+
+		c := echoContext(withRequestBody(
+			[]byte(`{"credentialID":"urn:uuid:123","credentialStatus":{"type":"StatusList2021Entry","status":"true"}}`)))
+
+		require.NoError(t, controller.PostCredentialsStatus(c, "testId"))
+	})
+}
+
 func TestController_UpdateCredentialStatus(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
@@ -469,7 +566,7 @@ func TestController_UpdateCredentialStatus(t *testing.T) {
 		kmsRegistry.EXPECT().GetKeyManager(gomock.Any()).AnyTimes().Return(nil, nil)
 
 		mockVCStatusManager := NewMockVCStatusManager(gomock.NewController(t))
-		mockVCStatusManager.EXPECT().UpdateVCStatus(
+		mockVCStatusManager.EXPECT().UpdateVCStatus(gomock.Any(),
 			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 		controller := NewController(&Config{
@@ -638,7 +735,7 @@ func TestController_UpdateCredentialStatus(t *testing.T) {
 					},
 					getVCStatusManager: func() vcStatusManager {
 						mockVCStatusManager := NewMockVCStatusManager(gomock.NewController(t))
-						mockVCStatusManager.EXPECT().UpdateVCStatus(
+						mockVCStatusManager.EXPECT().UpdateVCStatus(gomock.Any(),
 							gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 							Return(errors.New("some error"))
 						return mockVCStatusManager
@@ -658,11 +755,11 @@ func TestController_UpdateCredentialStatus(t *testing.T) {
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				c := &Controller{
-					profileSvc:      tt.fields.getProfileSvc(),
-					vcStatusManager: tt.fields.getVCStatusManager(),
-					kmsRegistry:     tt.fields.getKMSRegistry(),
-				}
+				c := NewController(&Config{
+					ProfileSvc:      tt.fields.getProfileSvc(),
+					VcStatusManager: tt.fields.getVCStatusManager(),
+					KMSRegistry:     tt.fields.getKMSRegistry(),
+				})
 				err := c.updateCredentialStatus(tt.args.ctx, tt.args.body, tt.args.profileID)
 				require.Error(t, err)
 				require.ErrorContains(t, err, tt.wantErr)
@@ -722,6 +819,98 @@ func TestController_InitiateCredentialIssuance(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Success with multiple credential templates", func(t *testing.T) {
+		mockProfileSvc.EXPECT().GetProfile("profileID").Times(1).Return(issuerProfile, nil)
+		mockOIDC4VCSvc.EXPECT().InitiateIssuance(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(
+				ctx context.Context,
+				req *oidc4vc.InitiateIssuanceRequest,
+				profile *profileapi.Issuer,
+			) (*oidc4vc.InitiateIssuanceResponse, error) {
+				require.Equal(t, []string{"templateID2", "templateID", "templateID3"}, req.CredentialTemplateIDs)
+
+				return resp, nil
+			})
+
+		controller := NewController(&Config{
+			ProfileSvc:     mockProfileSvc,
+			OIDC4VCService: mockOIDC4VCSvc,
+		})
+
+		r, marshalErr := json.Marshal(&InitiateOIDC4VCRequest{
+			CredentialTemplateId:  lo.ToPtr("templateID"),
+			CredentialTemplateIds: lo.ToPtr([]string{"templateID2", "templateID", "templateID3"}),
+		})
+		require.NoError(t, marshalErr)
+
+		c = echoContext(withRequestBody(r))
+
+		require.NoError(t, controller.InitiateCredentialIssuance(c, "profileID"))
+	})
+
+	t.Run("Success with credential offer by reference", func(t *testing.T) {
+		mockProfileSvc.EXPECT().GetProfile("profileID").Times(1).Return(issuerProfile, nil)
+		mockOIDC4VCSvc.EXPECT().InitiateIssuance(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(
+				ctx context.Context,
+				req *oidc4vc.InitiateIssuanceRequest,
+				profile *profileapi.Issuer,
+			) (*oidc4vc.InitiateIssuanceResponse, error) {
+				require.True(t, req.CredentialOfferByReference)
+
+				return &oidc4vc.InitiateIssuanceResponse{
+					InitiateIssuanceURL: "openid-initiate-issuance://?credential_offer_uri=" +
+						"https%3A%2F%2Fvcs.example.com%2Fissuer%2Fcredential-offers%2FofferID",
+					TxID:               "txID",
+					CredentialOfferURI: "https://vcs.example.com/issuer/credential-offers/offerID",
+				}, nil
+			})
+
+		controller := NewController(&Config{
+			ProfileSvc:     mockProfileSvc,
+			OIDC4VCService: mockOIDC4VCSvc,
+		})
+
+		r, marshalErr := json.Marshal(&InitiateOIDC4VCRequest{
+			CredentialTemplateId:       lo.ToPtr("templateID"),
+			CredentialOfferByReference: lo.ToPtr(true),
+		})
+		require.NoError(t, marshalErr)
+
+		c = echoContext(withRequestBody(r))
+
+		require.NoError(t, controller.InitiateCredentialIssuance(c, "profileID"))
+
+		var result InitiateOIDC4VCResponse
+
+		require.NoError(t, json.Unmarshal(c.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &result))
+		require.Equal(t, "https://vcs.example.com/issuer/credential-offers/offerID", *result.CredentialOfferUri)
+	})
+
+	t.Run("Success with client well-known error", func(t *testing.T) {
+		mockProfileSvc.EXPECT().GetProfile("profileID").Times(1).Return(issuerProfile, nil)
+		mockOIDC4VCSvc.EXPECT().InitiateIssuance(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(
+			&oidc4vc.InitiateIssuanceResponse{
+				InitiateIssuanceURL:  "openid-initiate-issuance://?credential_type=PermanentResidentCard",
+				TxID:                 "txID",
+				ClientWellKnownError: "get oidc configuration from client well-known: not found",
+			}, nil)
+
+		controller := NewController(&Config{
+			ProfileSvc:     mockProfileSvc,
+			OIDC4VCService: mockOIDC4VCSvc,
+		})
+
+		c = echoContext(withRequestBody(req))
+
+		require.NoError(t, controller.InitiateCredentialIssuance(c, "profileID"))
+
+		var result InitiateOIDC4VCResponse
+
+		require.NoError(t, json.Unmarshal(c.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &result))
+		require.Equal(t, "get oidc configuration from client well-known: not found", *result.ClientWellknownError)
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		tests := []struct {
 			name  string
@@ -807,6 +996,32 @@ func TestController_InitiateCredentialIssuance(t *testing.T) {
 					require.Contains(t, err.Error(), "credential template not found")
 				},
 			},
+			{
+				name: "Issuer OIDC configuration invalid",
+				setup: func() {
+					mockProfileSvc.EXPECT().GetProfile(gomock.Any()).Times(1).Return(issuerProfile, nil)
+					mockOIDC4VCSvc.EXPECT().InitiateIssuance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil,
+						fmt.Errorf("%w: token_endpoint is required", oidc4vc.ErrIssuerOIDCConfigurationInvalid))
+
+					c = echoContext(withRequestBody(req))
+				},
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.ConditionNotMet, "profile.oidcConfig.issuer_well_known", err)
+				},
+			},
+			{
+				name: "Issuer well-known configuration invalid",
+				setup: func() {
+					mockProfileSvc.EXPECT().GetProfile(gomock.Any()).Times(1).Return(issuerProfile, nil)
+					mockOIDC4VCSvc.EXPECT().InitiateIssuance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil,
+						fmt.Errorf("get oidc configuration from well-known: %w", wellknown.ErrInvalidConfiguration))
+
+					c = echoContext(withRequestBody(req))
+				},
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.ConditionNotMet, "profile.oidcConfig.issuer_well_known", err)
+				},
+			},
 			{
 				name: "Service error",
 				setup: func() {
@@ -843,13 +1058,21 @@ func TestController_PushAuthorizationDetails(t *testing.T) {
 	)
 
 	t.Run("Success", func(t *testing.T) {
-		mockOIDC4VCSvc.EXPECT().PushAuthorizationDetails(gomock.Any(), "opState", gomock.Any()).Return(nil)
+		mockOIDC4VCSvc.EXPECT().PushAuthorizationDetails(gomock.Any(), "opState", gomock.Any()).DoAndReturn(
+			func(ctx context.Context, opState string, ad []*oidc4vc.AuthorizationDetails) error {
+				require.Len(t, ad, 2)
+				require.Equal(t, vcsverifiable.Ldp, ad[0].Format)
+				require.Equal(t, "DriversLicense", ad[1].CredentialType)
+				require.Equal(t, vcsverifiable.Jwt, ad[1].Format)
+
+				return nil
+			})
 
 		controller := NewController(&Config{
 			OIDC4VCService: mockOIDC4VCSvc,
 		})
 
-		req = `{"op_state":"opState","authorization_details":{"type":"openid_credential","credential_type":"UniversityDegreeCredential","format":"ldp_vc"}}` //nolint:lll
+		req = `{"op_state":"opState","authorization_details":[{"type":"openid_credential","credential_type":"UniversityDegreeCredential","format":"ldp_vc"},{"type":"openid_credential","credential_type":"DriversLicense","format":"jwt_vc"}]}` //nolint:lll
 		c := echoContext(withRequestBody([]byte(req)))
 
 		err := controller.PushAuthorizationDetails(c)
@@ -867,7 +1090,7 @@ func TestController_PushAuthorizationDetails(t *testing.T) {
 				setup: func() {
 					mockOIDC4VCSvc.EXPECT().PushAuthorizationDetails(gomock.Any(), "opState", gomock.Any()).Times(0)
 
-					req = `{"op_state":"opState","authorization_details":{"type":"invalid","credential_type":"UniversityDegreeCredential","format":"ldp_vc"}}` //nolint:lll
+					req = `{"op_state":"opState","authorization_details":[{"type":"invalid","credential_type":"UniversityDegreeCredential","format":"ldp_vc"}]}` //nolint:lll
 				},
 				check: func(t *testing.T, err error) {
 					require.ErrorContains(t, err, "type should be 'openid_credential'")
@@ -879,7 +1102,7 @@ func TestController_PushAuthorizationDetails(t *testing.T) {
 					mockOIDC4VCSvc.EXPECT().PushAuthorizationDetails(gomock.Any(), "opState", gomock.Any()).Return(
 						oidc4vc.ErrCredentialTypeNotSupported)
 
-					req = `{"op_state":"opState","authorization_details":{"type":"openid_credential"}}`
+					req = `{"op_state":"opState","authorization_details":[{"type":"openid_credential"}]}`
 				},
 				check: func(t *testing.T, err error) {
 					require.ErrorContains(t, err, "credential type not supported")
@@ -891,19 +1114,31 @@ func TestController_PushAuthorizationDetails(t *testing.T) {
 					mockOIDC4VCSvc.EXPECT().PushAuthorizationDetails(gomock.Any(), "opState", gomock.Any()).Return(
 						oidc4vc.ErrCredentialFormatNotSupported)
 
-					req = `{"op_state":"opState","authorization_details":{"type":"openid_credential"}}`
+					req = `{"op_state":"opState","authorization_details":[{"type":"openid_credential"}]}`
 				},
 				check: func(t *testing.T, err error) {
 					require.ErrorContains(t, err, "credential format not supported")
 				},
 			},
+			{
+				name: "Transaction expired",
+				setup: func() {
+					mockOIDC4VCSvc.EXPECT().PushAuthorizationDetails(gomock.Any(), "opState", gomock.Any()).Return(
+						oidc4vc.ErrTransactionExpired)
+
+					req = `{"op_state":"opState","authorization_details":[{"type":"openid_credential"}]}`
+				},
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.ConditionNotMet, "op_state", err)
+				},
+			},
 			{
 				name: "Service error",
 				setup: func() {
 					mockOIDC4VCSvc.EXPECT().PushAuthorizationDetails(gomock.Any(), "opState", gomock.Any()).Return(
 						errors.New("service error"))
 
-					req = `{"op_state":"opState","authorization_details":{"type":"openid_credential","credential_type":"UniversityDegreeCredential","format":"ldp_vc"}}` //nolint:lll
+					req = `{"op_state":"opState","authorization_details":[{"type":"openid_credential","credential_type":"UniversityDegreeCredential","format":"ldp_vc"}]}` //nolint:lll
 				},
 				check: func(t *testing.T, err error) {
 					require.ErrorContains(t, err, "service error")
@@ -947,7 +1182,7 @@ func TestController_PrepareAuthorizationRequest(t *testing.T) {
 			oidc4vcService: mockOIDC4VCService,
 		}
 
-		req := `{"response_type":"code","op_state":"123","authorization_details":{"type":"openid_credential","credential_type":"https://did.example.org/healthCard","format":"ldp_vc","locations":[]}}` //nolint:lll
+		req := `{"response_type":"code","op_state":"123","authorization_details":[{"type":"openid_credential","credential_type":"https://did.example.org/healthCard","format":"ldp_vc","locations":[]}]}` //nolint:lll
 		ctx := echoContext(withRequestBody([]byte(req)))
 		assert.NoError(t, c.PrepareAuthorizationRequest(ctx))
 	})
@@ -960,7 +1195,7 @@ func TestController_PrepareAuthorizationRequest(t *testing.T) {
 			oidc4vcService: mockOIDC4VCService,
 		}
 
-		req := `{"response_type":"code","op_state":"123","authorization_details":{"type":"invalid","credential_type":"https://did.example.org/healthCard","format":"ldp_vc","locations":[]}}` //nolint:lll
+		req := `{"response_type":"code","op_state":"123","authorization_details":[{"type":"invalid","credential_type":"https://did.example.org/healthCard","format":"ldp_vc","locations":[]}]}` //nolint:lll
 		ctx := echoContext(withRequestBody([]byte(req)))
 		assert.ErrorContains(t, c.PrepareAuthorizationRequest(ctx), "authorization_details.type")
 	})
@@ -973,7 +1208,7 @@ func TestController_PrepareAuthorizationRequest(t *testing.T) {
 			oidc4vcService: mockOIDC4VCService,
 		}
 
-		req := `{"response_type":"code","op_state":"123","authorization_details":{"type":"openid_credential","credential_type":"https://did.example.org/healthCard","format":"invalid","locations":[]}}` //nolint:lll
+		req := `{"response_type":"code","op_state":"123","authorization_details":[{"type":"openid_credential","credential_type":"https://did.example.org/healthCard","format":"invalid","locations":[]}]}` //nolint:lll
 		ctx := echoContext(withRequestBody([]byte(req)))
 		assert.ErrorContains(t, c.PrepareAuthorizationRequest(ctx), "authorization_details.format")
 	})
@@ -987,7 +1222,7 @@ func TestController_PrepareAuthorizationRequest(t *testing.T) {
 			oidc4vcService: mockOIDC4VCService,
 		}
 
-		req := `{"response_type":"code","op_state":"123","authorization_details":{"type":"openid_credential","credential_type":"https://did.example.org/healthCard","format":"ldp_vc","locations":[]}}` //nolint:lll
+		req := `{"response_type":"code","op_state":"123","authorization_details":[{"type":"openid_credential","credential_type":"https://did.example.org/healthCard","format":"ldp_vc","locations":[]}]}` //nolint:lll
 		ctx := echoContext(withRequestBody([]byte(req)))
 		assert.ErrorContains(t, c.PrepareAuthorizationRequest(ctx), "service error")
 	})
@@ -1015,21 +1250,80 @@ func TestController_StoreAuthZCode(t *testing.T) {
 		ctx := echoContext(withRequestBody([]byte(req)))
 		assert.ErrorContains(t, c.StoreAuthorizationCodeRequest(ctx), "unexpected EOF")
 	})
+
+	t.Run("service error", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			err   error
+			check func(t *testing.T, err error)
+		}{
+			{
+				name: "transaction expired",
+				err:  oidc4vc.ErrTransactionExpired,
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.ConditionNotMet, "op_state", err)
+				},
+			},
+			{
+				name: "transaction cancelled",
+				err:  oidc4vc.ErrTransactionCancelled,
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.ConditionNotMet, "op_state", err)
+				},
+			},
+			{
+				name: "transaction state changed",
+				err:  oidc4vc.ErrTransactionStateChanged,
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.Conflict, "op_state", err)
+				},
+			},
+			{
+				name: "system error",
+				err:  errors.New("store error"),
+				check: func(t *testing.T, err error) {
+					requireSystemError(t, "OIDC4VCService", "StoreAuthorizationCode", err)
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockOIDC4VCService := NewMockOIDC4VCService(gomock.NewController(t))
+				mockOIDC4VCService.EXPECT().StoreAuthorizationCode(gomock.Any(), "opState", "code").Return(
+					oidc4vc.TxID(""), tt.err)
+
+				c := &Controller{
+					oidc4vcService: mockOIDC4VCService,
+				}
+
+				ctx := echoContext(withRequestBody([]byte(`{"op_state":"opState","code":"code"}`)))
+				tt.check(t, c.StoreAuthorizationCodeRequest(ctx))
+			})
+		}
+	})
 }
 
 func TestController_ExchangeAuthorizationCode(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		opState := uuid.NewString()
 		mockOIDC4VCService := NewMockOIDC4VCService(gomock.NewController(t))
-		mockOIDC4VCService.EXPECT().ExchangeAuthorizationCode(gomock.Any(), opState).Return(oidc4vc.TxID("1234"), nil)
+		mockOIDC4VCService.EXPECT().ExchangeAuthorizationCode(gomock.Any(), opState).Return(
+			&oidc4vc.ExchangeAuthorizationCodeResult{TxID: "1234", ProfileID: "profileID"}, nil)
 
 		c := &Controller{
-			oidc4vcService: mockOIDC4VCService,
+			oidc4vcService:  mockOIDC4VCService,
+			externalHostURL: "https://vcs.pb.example.com",
 		}
 
 		req := fmt.Sprintf(`{"op_state":"%s"}`, opState) //nolint:lll
 		ctx := echoContext(withRequestBody([]byte(req)))
 		assert.NoError(t, c.ExchangeAuthorizationCodeRequest(ctx))
+
+		var resp ExchangeAuthorizationCodeResponse
+
+		assert.NoError(t, json.Unmarshal(ctx.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &resp))
+		assert.Equal(t, "1234", *resp.TxId)
+		assert.Equal(t, "https://vcs.pb.example.com/issuer/profiles/profileID", *resp.CredentialIssuer)
 	})
 
 	t.Run("invalid body", func(t *testing.T) {
@@ -1039,69 +1333,1212 @@ func TestController_ExchangeAuthorizationCode(t *testing.T) {
 		ctx := echoContext(withRequestBody([]byte(req)))
 		assert.ErrorContains(t, c.ExchangeAuthorizationCodeRequest(ctx), "unexpected EOF")
 	})
-}
 
-type options struct {
-	orgID       string
-	requestBody []byte
-}
+	t.Run("service error", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			err   error
+			check func(t *testing.T, err error)
+		}{
+			{
+				name: "transaction expired",
+				err:  oidc4vc.ErrTransactionExpired,
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.ConditionNotMet, "op_state", err)
+				},
+			},
+			{
+				name: "transaction cancelled",
+				err:  oidc4vc.ErrTransactionCancelled,
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.ConditionNotMet, "op_state", err)
+				},
+			},
+			{
+				name: "transaction state changed",
+				err:  oidc4vc.ErrTransactionStateChanged,
+				check: func(t *testing.T, err error) {
+					requireValidationError(t, resterr.Conflict, "op_state", err)
+				},
+			},
+			{
+				name: "system error",
+				err:  errors.New("exchange error"),
+				check: func(t *testing.T, err error) {
+					requireSystemError(t, "OIDC4VCService", "ExchangeAuthorizationCode", err)
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockOIDC4VCService := NewMockOIDC4VCService(gomock.NewController(t))
+				mockOIDC4VCService.EXPECT().ExchangeAuthorizationCode(gomock.Any(), "opState").Return(nil, tt.err)
 
-type contextOpt func(*options)
+				c := &Controller{
+					oidc4vcService: mockOIDC4VCService,
+				}
 
-func withOrgID(orgID string) contextOpt {
-	return func(o *options) {
-		o.orgID = orgID
-	}
+				ctx := echoContext(withRequestBody([]byte(`{"op_state":"opState"}`)))
+				tt.check(t, c.ExchangeAuthorizationCodeRequest(ctx))
+			})
+		}
+	})
 }
 
-func withRequestBody(body []byte) contextOpt {
-	return func(o *options) {
-		o.requestBody = body
-	}
-}
+func TestController_PrepareCredential(t *testing.T) {
+	var (
+		mockProfileSvc         = NewMockProfileService(gomock.NewController(t))
+		mockOIDC4VCSvc         = NewMockOIDC4VCService(gomock.NewController(t))
+		mockIssueCredentialSvc = NewMockIssueCredentialService(gomock.NewController(t))
+		req                    string
+	)
 
-func echoContext(opts ...contextOpt) echo.Context {
-	o := &options{
-		orgID: orgID,
+	issuerProfile := &profileapi.Issuer{
+		OrganizationID: orgID,
+		ID:             "profileID",
+		Active:         true,
+		VCConfig: &profileapi.VCConfig{
+			Format:           vcsverifiable.Ldp,
+			SigningAlgorithm: vcsverifiable.Ed25519Signature2018,
+			KeyType:          kms.ECDSASecp256k1TypeIEEEP1363,
+		},
 	}
 
-	for _, fn := range opts {
-		fn(o)
+	newResponse := func(format vcsverifiable.Format) *oidc4vc.PrepareCredentialResponse {
+		return &oidc4vc.PrepareCredentialResponse{
+			ProfileID: "profileID",
+			TxID:      "txID",
+			Format:    format,
+			Credential: &verifiable.Credential{
+				ID:    "urn:uuid:credentialID",
+				Types: []string{"VerifiableCredential", "DriversLicense"},
+			},
+		}
 	}
 
-	e := echo.New()
-
-	var body io.Reader = http.NoBody
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+					Format:         vcsverifiable.Ldp,
+					DID:            "did:example:holder",
+				}).Return(newResponse(vcsverifiable.Ldp), nil)
+
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+
+				mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), issuerProfile).
+					DoAndReturn(func(
+						ctx context.Context,
+						credential *verifiable.Credential,
+						issuerSigningOpts []crypto.SigningOpts,
+						profile *profileapi.Issuer,
+					) (*verifiable.Credential, error) {
+						return credential, nil
+					})
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense","format":"ldp_vc","did":"did:example:holder"}` //nolint:lll
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
 
-	if o.requestBody != nil {
-		body = bytes.NewReader(o.requestBody)
-	}
+				var result PrepareCredentialResult
 
-	req := httptest.NewRequest(http.MethodPost, "/", body)
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+				require.Equal(t, "ldp_vc", result.Format)
+				require.NotNil(t, result.Credential)
+			},
+		},
+		{
+			name: "Success with credential format other than format of the profile",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(newResponse(vcsverifiable.Jwt), nil)
+
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+
+				mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						ctx context.Context,
+						credential *verifiable.Credential,
+						issuerSigningOpts []crypto.SigningOpts,
+						profile *profileapi.Issuer,
+					) (*verifiable.Credential, error) {
+						require.Equal(t, vcsverifiable.Jwt, profile.VCConfig.Format)
+						require.Equal(t, vcsverifiable.ES256K, profile.VCConfig.SigningAlgorithm)
+						require.Equal(t, vcsverifiable.Ldp, issuerProfile.VCConfig.Format)
+
+						return credential, nil
+					})
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense","did":"did:example:holder"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
 
-	if o.orgID != "" {
-		req.Header.Set("X-User", o.orgID)
-	}
+				var result PrepareCredentialResult
 
-	rec := httptest.NewRecorder()
-	return e.NewContext(req, rec)
-}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+				require.Equal(t, "jwt_vc", result.Format)
+			},
+		},
+		{
+			name: "Validate only",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+					DID:            "did:example:holder",
+					ValidateOnly:   true,
+				}).Return(&oidc4vc.PrepareCredentialResponse{
+					ProfileID: "profileID",
+					TxID:      "txID",
+					Format:    vcsverifiable.Ldp,
+				}, nil)
+
+				mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense","did":"did:example:holder","validate_only":true}` //nolint:lll
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
 
-func requireValidationError(t *testing.T, expectedCode resterr.ErrorCode, incorrectValueName string, actual error) {
-	require.IsType(t, &resterr.CustomError{}, actual)
-	actualErr := &resterr.CustomError{}
-	require.True(t, errors.As(actual, &actualErr))
+				var result PrepareCredentialResult
 
-	require.Equal(t, expectedCode, actualErr.Code)
-	require.Equal(t, incorrectValueName, actualErr.IncorrectValue)
-	require.Error(t, actualErr.Err)
-}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+				require.Equal(t, "ldp_vc", result.Format)
+				require.Nil(t, result.Credential)
+				require.Nil(t, result.AcceptanceToken)
+			},
+		},
+		{
+			name: "Issuance deferred",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(&oidc4vc.PrepareCredentialResponse{
+						ProfileID:       "profileID",
+						TxID:            "txID",
+						Format:          vcsverifiable.Jwt,
+						AcceptanceToken: "acceptanceToken",
+					}, nil)
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense","did":"did:example:holder"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
 
-func requireAuthError(t *testing.T, actual error) {
-	require.IsType(t, &resterr.CustomError{}, actual)
-	actualErr := &resterr.CustomError{}
-	require.True(t, errors.As(actual, &actualErr))
+				var result PrepareCredentialResult
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+				require.Equal(t, "jwt_vc", result.Format)
+				require.Equal(t, lo.ToPtr("acceptanceToken"), result.AcceptanceToken)
+				require.Nil(t, result.Credential)
+			},
+		},
+		{
+			name: "Invalid format",
+			setup: func() {
+				req = `{"op_state":"opState","credential_type":"DriversLicense","format":"mso_mdoc"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				requireValidationError(t, resterr.InvalidValue, "format", err)
+			},
+		},
+		{
+			name: "Credential type not supported",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(nil, oidc4vc.ErrCredentialTypeNotSupported)
+
+				req = `{"op_state":"opState","credential_type":"UniversityDegreeCredential"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				requireValidationError(t, resterr.InvalidValue, "credential_type", err)
+			},
+		},
+		{
+			name: "Credential is not authorized",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(nil, oidc4vc.ErrCredentialNotAuthorized)
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				requireValidationError(t, resterr.InvalidValue, "credential_type", err)
+			},
+		},
+		{
+			name: "Credential format not supported",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(nil, oidc4vc.ErrCredentialFormatNotSupported)
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense","format":"jwt_vc"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				requireValidationError(t, resterr.InvalidValue, "format", err)
+			},
+		},
+		{
+			name: "Transaction cancelled",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(nil, oidc4vc.ErrTransactionCancelled)
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				requireValidationError(t, resterr.ConditionNotMet, "op_state", err)
+			},
+		},
+		{
+			name: "Service error",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("prepare credential error"))
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "prepare credential error")
+			},
+		},
+		{
+			name: "Issue credential error",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).
+					Return(newResponse(vcsverifiable.Ldp), nil)
+
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+
+				mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("issue credential error"))
+
+				req = `{"op_state":"opState","credential_type":"DriversLicense"}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "issue credential error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := NewController(&Config{
+				ProfileSvc:             mockProfileSvc,
+				OIDC4VCService:         mockOIDC4VCSvc,
+				IssueCredentialService: mockIssueCredentialSvc,
+			})
+
+			ctx := echoContext(withRequestBody([]byte(req)))
+
+			tt.check(t, ctx.Response().Writer.(*httptest.ResponseRecorder), controller.PrepareCredential(ctx))
+		})
+	}
+}
+
+func TestController_StoreDeferredClaimData(t *testing.T) {
+	var (
+		mockProfileSvc = NewMockProfileService(gomock.NewController(t))
+		mockOIDC4VCSvc = NewMockOIDC4VCService(gomock.NewController(t))
+		ctx            echo.Context
+	)
+
+	issuerProfile := &profileapi.Issuer{
+		OrganizationID: orgID,
+		ID:             "profileID",
+	}
+
+	req := `{"credential_type":"DriversLicense","claims":{"name":"John Doe"}}`
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().StoreDeferredClaimData(gomock.Any(), &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID:      "profileID",
+					TxID:           "txID",
+					CredentialType: "DriversLicense",
+					ClaimData:      []byte(`{"name":"John Doe"}`),
+				}).Return(nil)
+
+				ctx = echoContext(withRequestBody([]byte(req)))
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, ctx.Response().Status)
+			},
+		},
+		{
+			name: "Missing authorization",
+			setup: func() {
+				ctx = echoContext(withRequestBody([]byte(req)), withOrgID(""))
+			},
+			check: func(t *testing.T, err error) {
+				requireAuthError(t, err)
+			},
+		},
+		{
+			name: "Profile of another organization",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+
+				ctx = echoContext(withRequestBody([]byte(req)), withOrgID("orgID2"))
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "profile", err)
+			},
+		},
+		{
+			name: "Transaction not found",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().StoreDeferredClaimData(gomock.Any(), gomock.Any()).
+					Return(oidc4vc.ErrDataNotFound)
+
+				ctx = echoContext(withRequestBody([]byte(req)))
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "txID", err)
+			},
+		},
+		{
+			name: "Credential is not deferred",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().StoreDeferredClaimData(gomock.Any(), gomock.Any()).
+					Return(oidc4vc.ErrDeferredCredentialNotFound)
+
+				ctx = echoContext(withRequestBody([]byte(req)))
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.InvalidValue, "credential_type", err)
+			},
+		},
+		{
+			name: "Invalid claim data",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().StoreDeferredClaimData(gomock.Any(), gomock.Any()).
+					Return(oidc4vc.ErrInvalidClaimData)
+
+				ctx = echoContext(withRequestBody([]byte(`{"credential_type":"DriversLicense"}`)))
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.InvalidValue, "claims", err)
+			},
+		},
+		{
+			name: "Service error",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().StoreDeferredClaimData(gomock.Any(), gomock.Any()).
+					Return(errors.New("store error"))
+
+				ctx = echoContext(withRequestBody([]byte(req)))
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "store error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := NewController(&Config{
+				ProfileSvc:     mockProfileSvc,
+				OIDC4VCService: mockOIDC4VCSvc,
+			})
+
+			tt.check(t, controller.StoreDeferredClaimData(ctx, "profileID", "txID"))
+		})
+	}
+}
+
+func TestController_PrepareDeferredCredential(t *testing.T) {
+	var (
+		mockProfileSvc         = NewMockProfileService(gomock.NewController(t))
+		mockOIDC4VCSvc         = NewMockOIDC4VCService(gomock.NewController(t))
+		mockIssueCredentialSvc = NewMockIssueCredentialService(gomock.NewController(t))
+	)
+
+	req := `{"acceptance_token":"acceptanceToken"}`
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareDeferredCredential(gomock.Any(), "acceptanceToken").
+					Return(&oidc4vc.PrepareCredentialResponse{
+						ProfileID:  "profileID",
+						TxID:       "txID",
+						Format:     vcsverifiable.Jwt,
+						Credential: &verifiable.Credential{ID: "urn:uuid:credentialID"},
+					}, nil)
+
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(&profileapi.Issuer{
+					OrganizationID: orgID,
+					ID:             "profileID",
+					VCConfig: &profileapi.VCConfig{
+						Format:           vcsverifiable.Jwt,
+						SigningAlgorithm: vcsverifiable.EdDSA,
+					},
+				}, nil)
+
+				mockIssueCredentialSvc.EXPECT().IssueCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&verifiable.Credential{ID: "urn:uuid:credentialID"}, nil)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+
+				var result PrepareCredentialResult
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+				require.Equal(t, "jwt_vc", result.Format)
+				require.NotNil(t, result.Credential)
+				require.Nil(t, result.AcceptanceToken)
+			},
+		},
+		{
+			name: "Issuance pending",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareDeferredCredential(gomock.Any(), "acceptanceToken").
+					Return(nil, oidc4vc.ErrCredentialIssuancePending)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				requireValidationError(t, resterr.ConditionNotMet, "acceptance_token", err)
+			},
+		},
+		{
+			name: "Deferred credential not found",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareDeferredCredential(gomock.Any(), "acceptanceToken").
+					Return(nil, oidc4vc.ErrDeferredCredentialNotFound)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				requireValidationError(t, resterr.DoesntExist, "acceptance_token", err)
+			},
+		},
+		{
+			name: "Service error",
+			setup: func() {
+				mockOIDC4VCSvc.EXPECT().PrepareDeferredCredential(gomock.Any(), "acceptanceToken").
+					Return(nil, errors.New("prepare deferred credential error"))
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "prepare deferred credential error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := NewController(&Config{
+				ProfileSvc:             mockProfileSvc,
+				OIDC4VCService:         mockOIDC4VCSvc,
+				IssueCredentialService: mockIssueCredentialSvc,
+			})
+
+			ctx := echoContext(withRequestBody([]byte(req)))
+
+			tt.check(t, ctx.Response().Writer.(*httptest.ResponseRecorder), controller.PrepareDeferredCredential(ctx))
+		})
+	}
+}
+
+func TestController_GetCredentialOffer(t *testing.T) {
+	mockOIDC4VCSvc := NewMockOIDC4VCService(gomock.NewController(t))
+
+	controller := NewController(&Config{
+		OIDC4VCService: mockOIDC4VCSvc,
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockOIDC4VCSvc.EXPECT().GetCredentialOffer(gomock.Any(), "offerID").Return(&oidc4vc.CredentialOffer{
+			Issuer:          "https://vcs.example.com/txID",
+			CredentialTypes: []string{"PermanentResidentCard"},
+			OpState:         "eyJhbGciOiJSU0Et",
+		}, nil)
+
+		ctx := echoContext()
+
+		require.NoError(t, controller.GetCredentialOffer(ctx, "offerID"))
+		require.JSONEq(t, `{"issuer":"https://vcs.example.com/txID","credential_type":["PermanentResidentCard"],`+
+			`"op_state":"eyJhbGciOiJSU0Et"}`, ctx.Response().Writer.(*httptest.ResponseRecorder).Body.String())
+	})
+
+	t.Run("Offer not found", func(t *testing.T) {
+		mockOIDC4VCSvc.EXPECT().GetCredentialOffer(gomock.Any(), "offerID").Return(nil, oidc4vc.ErrDataNotFound)
+
+		requireValidationError(t, resterr.DoesntExist, "offerID", controller.GetCredentialOffer(echoContext(), "offerID"))
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		mockOIDC4VCSvc.EXPECT().GetCredentialOffer(gomock.Any(), "offerID").Return(nil, errors.New("find error"))
+
+		require.ErrorContains(t, controller.GetCredentialOffer(echoContext(), "offerID"), "find error")
+	})
+}
+
+func TestController_GetIssuanceQrCode(t *testing.T) {
+	var (
+		mockProfileSvc = NewMockProfileService(gomock.NewController(t))
+		mockOIDC4VCSvc = NewMockOIDC4VCService(gomock.NewController(t))
+		ctx            echo.Context
+		params         GetIssuanceQrCodeParams
+	)
+
+	issuerProfile := &profileapi.Issuer{
+		OrganizationID: orgID,
+		ID:             "profileID",
+	}
+
+	initiateURL := "openid-initiate-issuance://?op_state=eyJhbGciOiJSU0Et"
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "Success PNG",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetInitiateIssuanceURL(gomock.Any(), "profileID", oidc4vc.TxID("txID")).
+					Return(initiateURL, nil)
+
+				ctx = echoContext()
+				params = GetIssuanceQrCodeParams{}
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+
+				rec := ctx.Response().Writer.(*httptest.ResponseRecorder)
+				require.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+
+				img, decodeErr := png.Decode(rec.Body)
+				require.NoError(t, decodeErr)
+				require.Equal(t, 256, img.Bounds().Dx())
+			},
+		},
+		{
+			name: "Success SVG",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetInitiateIssuanceURL(gomock.Any(), "profileID", oidc4vc.TxID("txID")).
+					Return(initiateURL, nil)
+
+				ctx = echoContext()
+				params = GetIssuanceQrCodeParams{Format: lo.ToPtr(GetIssuanceQrCodeParamsFormat("svg"))}
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+
+				rec := ctx.Response().Writer.(*httptest.ResponseRecorder)
+				require.Equal(t, "image/svg+xml", rec.Header().Get(echo.HeaderContentType))
+				require.True(t, strings.HasPrefix(rec.Body.String(), "<svg "))
+			},
+		},
+		{
+			name: "Invalid size",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetInitiateIssuanceURL(gomock.Any(), "profileID", oidc4vc.TxID("txID")).
+					Return(initiateURL, nil)
+
+				ctx = echoContext()
+				params = GetIssuanceQrCodeParams{Size: lo.ToPtr(4096)}
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.InvalidValue, "size", err)
+			},
+		},
+		{
+			name: "Unsupported format",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetInitiateIssuanceURL(gomock.Any(), "profileID", oidc4vc.TxID("txID")).
+					Return(initiateURL, nil)
+
+				ctx = echoContext()
+				params = GetIssuanceQrCodeParams{Format: lo.ToPtr(GetIssuanceQrCodeParamsFormat("gif"))}
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.InvalidValue, "format", err)
+			},
+		},
+		{
+			name: "Missing authorization",
+			setup: func() {
+				ctx = echoContext(withOrgID(""))
+			},
+			check: func(t *testing.T, err error) {
+				requireAuthError(t, err)
+			},
+		},
+		{
+			name: "Profile of another organization",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+
+				ctx = echoContext(withOrgID("orgID2"))
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "profile", err)
+			},
+		},
+		{
+			name: "Transaction not found",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetInitiateIssuanceURL(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", oidc4vc.ErrDataNotFound)
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "txID", err)
+			},
+		},
+		{
+			name: "Service error",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetInitiateIssuanceURL(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", errors.New("get error"))
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "get error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := NewController(&Config{
+				ProfileSvc:     mockProfileSvc,
+				OIDC4VCService: mockOIDC4VCSvc,
+			})
+
+			tt.check(t, controller.GetIssuanceQrCode(ctx, "profileID", "txID", params))
+		})
+	}
+}
+
+func TestController_GetCredentialIssuanceStatus(t *testing.T) {
+	var (
+		mockProfileSvc = NewMockProfileService(gomock.NewController(t))
+		mockOIDC4VCSvc = NewMockOIDC4VCService(gomock.NewController(t))
+		ctx            echo.Context
+	)
+
+	issuerProfile := &profileapi.Issuer{
+		OrganizationID: orgID,
+		ID:             "profileID",
+	}
+
+	expiresAt := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetIssuanceStatus(gomock.Any(), "profileID", oidc4vc.TxID("txID")).
+					Return(&oidc4vc.TransactionStatus{
+						TxID:      "txID",
+						State:     oidc4vc.TransactionStateTokenIssued,
+						ExpiresAt: expiresAt,
+					}, nil)
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+
+				var status CredentialIssuanceStatus
+
+				rec := ctx.Response().Writer.(*httptest.ResponseRecorder)
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+				require.Equal(t, "txID", status.TxId)
+				require.Equal(t, TokenIssued, status.State)
+				require.NotNil(t, status.ExpiresAt)
+				require.True(t, expiresAt.Equal(*status.ExpiresAt))
+			},
+		},
+		{
+			name: "Missing authorization",
+			setup: func() {
+				ctx = echoContext(withOrgID(""))
+			},
+			check: func(t *testing.T, err error) {
+				requireAuthError(t, err)
+			},
+		},
+		{
+			name: "Profile of another organization",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+
+				ctx = echoContext(withOrgID("orgID2"))
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "profile", err)
+			},
+		},
+		{
+			name: "Transaction not found",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetIssuanceStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, oidc4vc.ErrDataNotFound)
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "txID", err)
+			},
+		},
+		{
+			name: "Service error",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().GetIssuanceStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("get error"))
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "get error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := NewController(&Config{
+				ProfileSvc:     mockProfileSvc,
+				OIDC4VCService: mockOIDC4VCSvc,
+			})
+
+			tt.check(t, controller.GetCredentialIssuanceStatus(ctx, "profileID", "txID"))
+		})
+	}
+}
+
+func TestController_CancelCredentialIssuance(t *testing.T) {
+	var (
+		mockProfileSvc = NewMockProfileService(gomock.NewController(t))
+		mockOIDC4VCSvc = NewMockOIDC4VCService(gomock.NewController(t))
+		ctx            echo.Context
+	)
+
+	issuerProfile := &profileapi.Issuer{
+		OrganizationID: orgID,
+		ID:             "profileID",
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().CancelIssuance(gomock.Any(), "profileID", oidc4vc.TxID("txID")).Return(nil)
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, ctx.Response().Status)
+			},
+		},
+		{
+			name: "Missing authorization",
+			setup: func() {
+				ctx = echoContext(withOrgID(""))
+			},
+			check: func(t *testing.T, err error) {
+				requireAuthError(t, err)
+			},
+		},
+		{
+			name: "Profile of another organization",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+
+				ctx = echoContext(withOrgID("orgID2"))
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "profile", err)
+			},
+		},
+		{
+			name: "Transaction not found",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().CancelIssuance(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(oidc4vc.ErrDataNotFound)
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.DoesntExist, "txID", err)
+			},
+		},
+		{
+			name: "Transaction not cancellable",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().CancelIssuance(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(oidc4vc.ErrTransactionNotCancellable)
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.ConditionNotMet, "txID", err)
+			},
+		},
+		{
+			name: "Transaction state changed concurrently",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().CancelIssuance(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(oidc4vc.ErrTransactionStateChanged)
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				requireValidationError(t, resterr.Conflict, "txID", err)
+			},
+		},
+		{
+			name: "Service error",
+			setup: func() {
+				mockProfileSvc.EXPECT().GetProfile("profileID").Return(issuerProfile, nil)
+				mockOIDC4VCSvc.EXPECT().CancelIssuance(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("cancel error"))
+
+				ctx = echoContext()
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "cancel error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := NewController(&Config{
+				ProfileSvc:     mockProfileSvc,
+				OIDC4VCService: mockOIDC4VCSvc,
+			})
+
+			tt.check(t, controller.CancelCredentialIssuance(ctx, "profileID", "txID"))
+		})
+	}
+}
+
+func TestController_OpenidCredentialIssuerConfig(t *testing.T) {
+	newProfile := func() *profileapi.Issuer {
+		return &profileapi.Issuer{
+			ID:         "profile1",
+			Name:       "Test Issuer",
+			Active:     true,
+			OIDCConfig: &profileapi.OIDC4VCConfig{},
+			VCConfig: &profileapi.VCConfig{
+				Format:           vcsverifiable.Ldp,
+				SigningAlgorithm: vcsverifiable.JSONWebSignature2020,
+			},
+			CredentialTemplates: []*profileapi.CredentialTemplate{
+				{
+					ID:       "templateID",
+					Type:     "PermanentResidentCard",
+					Contexts: []string{"https://www.w3.org/2018/credentials/v1"},
+					Display: []*profileapi.DisplayProperties{
+						{Name: "Permanent Resident Card", Locale: "en-US", BackgroundColor: "#12107c"},
+					},
+				},
+			},
+		}
+	}
+
+	getMetadata := func(t *testing.T, profile *profileapi.Issuer) *CredentialIssuerMetadata {
+		t.Helper()
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+		mockProfileSvc.EXPECT().GetProfile(profile.ID).Return(profile, nil)
+
+		c := NewController(&Config{
+			ProfileSvc:      mockProfileSvc,
+			ExternalHostURL: "https://vcs.example.com",
+		})
+
+		ctx := echoContext()
+		require.NoError(t, c.OpenidCredentialIssuerConfig(ctx, profile.ID))
+
+		var metadata CredentialIssuerMetadata
+
+		require.NoError(t, json.Unmarshal(ctx.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &metadata))
+
+		return &metadata
+	}
+
+	t.Run("Success ldp", func(t *testing.T) {
+		profile := newProfile()
+		profile.Display = []*profileapi.DisplayProperties{
+			{
+				Name:   "Test Bank",
+				Locale: "en-US",
+				Logo:   &profileapi.Logo{URL: "https://example.com/logo.png", AltText: "logo"},
+			},
+		}
+
+		metadata := getMetadata(t, profile)
+
+		require.Equal(t, &CredentialIssuerMetadata{
+			CredentialIssuer:   "https://vcs.example.com/issuer/profiles/profile1",
+			CredentialEndpoint: "https://vcs.example.com/oidc/credential",
+			CredentialsSupported: []SupportedCredential{
+				{
+					Id:                                   lo.ToPtr("templateID"),
+					Format:                               "ldp_vc",
+					Types:                                []string{"VerifiableCredential", "PermanentResidentCard"},
+					Context:                              &[]string{"https://www.w3.org/2018/credentials/v1"},
+					CryptographicBindingMethodsSupported: &[]string{"did"},
+					CryptographicSuitesSupported:         &[]string{"JsonWebSignature2020"},
+					ProofTypesSupported:                  &[]string{"jwt"},
+					Display: &[]DisplayProperties{
+						{
+							Name:            "Permanent Resident Card",
+							Locale:          lo.ToPtr("en-US"),
+							BackgroundColor: lo.ToPtr("#12107c"),
+						},
+					},
+				},
+			},
+			Display: &[]DisplayProperties{
+				{
+					Name:   "Test Bank",
+					Locale: lo.ToPtr("en-US"),
+					Logo:   &Logo{Url: "https://example.com/logo.png", AltText: lo.ToPtr("logo")},
+				},
+			},
+		}, metadata)
+	})
+
+	t.Run("Success jwt", func(t *testing.T) {
+		profile := newProfile()
+		profile.VCConfig.Format = vcsverifiable.Jwt
+		profile.VCConfig.SigningAlgorithm = vcsverifiable.ES256
+
+		metadata := getMetadata(t, profile)
+
+		require.Len(t, metadata.CredentialsSupported, 1)
+		require.Equal(t, "jwt_vc", metadata.CredentialsSupported[0].Format)
+		require.Equal(t, &[]string{"ES256"}, metadata.CredentialsSupported[0].CryptographicSuitesSupported)
+		require.Nil(t, metadata.CredentialsSupported[0].Context)
+		require.Equal(t, &[]DisplayProperties{{Name: "Test Issuer"}}, metadata.Display)
+	})
+
+	t.Run("Success with credential template format", func(t *testing.T) {
+		profile := newProfile()
+		profile.VCConfig.KeyType = kms.ECDSAP256TypeDER
+		profile.CredentialTemplates = append(profile.CredentialTemplates,
+			&profileapi.CredentialTemplate{ID: "templateID2", Type: "DriversLicense", Format: vcsverifiable.Jwt},
+			&profileapi.CredentialTemplate{ID: "templateID3", Type: "VehicleRegistration", Format: "mso_mdoc"},
+		)
+
+		metadata := getMetadata(t, profile)
+
+		require.Len(t, metadata.CredentialsSupported, 2)
+		require.Equal(t, "ldp_vc", metadata.CredentialsSupported[0].Format)
+		require.Equal(t, "jwt_vc", metadata.CredentialsSupported[1].Format)
+		require.Equal(t, &[]string{"ES256"}, metadata.CredentialsSupported[1].CryptographicSuitesSupported)
+	})
+
+	t.Run("Profile doesn't support OIDC4VCI", func(t *testing.T) {
+		for _, profile := range []*profileapi.Issuer{
+			{ID: "profile1", Active: false, OIDCConfig: &profileapi.OIDC4VCConfig{}, VCConfig: &profileapi.VCConfig{}},
+			{ID: "profile1", Active: true, VCConfig: &profileapi.VCConfig{}},
+		} {
+			mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+			mockProfileSvc.EXPECT().GetProfile(profile.ID).Return(profile, nil)
+
+			c := NewController(&Config{ProfileSvc: mockProfileSvc})
+
+			requireValidationError(t, resterr.DoesntExist, "profile",
+				c.OpenidCredentialIssuerConfig(echoContext(), profile.ID))
+		}
+	})
+
+	t.Run("Profile not found", func(t *testing.T) {
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+		mockProfileSvc.EXPECT().GetProfile("profile1").Return(nil, errors.New("profile not found"))
+
+		c := NewController(&Config{ProfileSvc: mockProfileSvc})
+
+		requireValidationError(t, resterr.DoesntExist, "profile",
+			c.OpenidCredentialIssuerConfig(echoContext(), "profile1"))
+	})
+}
+
+func TestController_OauthAuthorizationServerConfig(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+		mockProfileSvc.EXPECT().GetProfile("profile1").Return(&profileapi.Issuer{
+			ID:         "profile1",
+			Active:     true,
+			OIDCConfig: &profileapi.OIDC4VCConfig{},
+			VCConfig:   &profileapi.VCConfig{},
+		}, nil)
+
+		c := NewController(&Config{
+			ProfileSvc:      mockProfileSvc,
+			ExternalHostURL: "https://vcs.example.com",
+		})
+
+		ctx := echoContext(withPath("/issuer/profiles/profile1/.well-known/oauth-authorization-server"))
+		require.NoError(t, c.OauthAuthorizationServerConfig(ctx, "profile1"))
+
+		var metadata AuthorizationServerMetadata
+
+		require.NoError(t, json.Unmarshal(ctx.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &metadata))
+
+		require.Equal(t, "https://vcs.example.com/issuer/profiles/profile1", metadata.Issuer)
+		require.Equal(t, "https://vcs.example.com/oidc/authorize", metadata.AuthorizationEndpoint)
+		require.Equal(t, "https://vcs.example.com/oidc/token", metadata.TokenEndpoint)
+		require.Equal(t, "https://vcs.example.com/oidc/par", lo.FromPtr(metadata.PushedAuthorizationRequestEndpoint))
+		require.Equal(t, "https://vcs.example.com/oidc/register", lo.FromPtr(metadata.RegistrationEndpoint))
+		require.Equal(t, "https://vcs.example.com/oidc/introspect", lo.FromPtr(metadata.IntrospectionEndpoint))
+		require.Equal(t, "https://vcs.example.com/oidc/revoke", lo.FromPtr(metadata.RevocationEndpoint))
+		require.Equal(t, []string{"code"}, metadata.ResponseTypesSupported)
+		require.Equal(t, &[]string{"authorization_code", "refresh_token"}, metadata.GrantTypesSupported)
+		require.Equal(t, &[]string{"S256"}, metadata.CodeChallengeMethodsSupported)
+	})
+
+	t.Run("Profile not found", func(t *testing.T) {
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+		mockProfileSvc.EXPECT().GetProfile("profile1").Return(nil, errors.New("profile not found"))
+
+		c := NewController(&Config{ProfileSvc: mockProfileSvc})
+
+		requireValidationError(t, resterr.DoesntExist, "profile",
+			c.OauthAuthorizationServerConfig(echoContext(), "profile1"))
+	})
+}
+
+type options struct {
+	orgID       string
+	requestBody []byte
+	path        string
+}
+
+type contextOpt func(*options)
+
+func withOrgID(orgID string) contextOpt {
+	return func(o *options) {
+		o.orgID = orgID
+	}
+}
+
+func withRequestBody(body []byte) contextOpt {
+	return func(o *options) {
+		o.requestBody = body
+	}
+}
+
+func withPath(path string) contextOpt {
+	return func(o *options) {
+		o.path = path
+	}
+}
+
+func echoContext(opts ...contextOpt) echo.Context {
+	o := &options{
+		orgID: orgID,
+		path:  "/",
+	}
+
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	e := echo.New()
+
+	var body io.Reader = http.NoBody
+
+	if o.requestBody != nil {
+		body = bytes.NewReader(o.requestBody)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, o.path, body)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	if o.orgID != "" {
+		req.Header.Set("X-User", o.orgID)
+	}
+
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec)
+}
+
+func requireValidationError(t *testing.T, expectedCode resterr.ErrorCode, incorrectValueName string, actual error) {
+	require.IsType(t, &resterr.CustomError{}, actual)
+	actualErr := &resterr.CustomError{}
+	require.True(t, errors.As(actual, &actualErr))
+
+	require.Equal(t, expectedCode, actualErr.Code)
+	require.Equal(t, incorrectValueName, actualErr.IncorrectValue)
+	require.Error(t, actualErr.Err)
+}
+
+func requireAuthError(t *testing.T, actual error) {
+	require.IsType(t, &resterr.CustomError{}, actual)
+	actualErr := &resterr.CustomError{}
+	require.True(t, errors.As(actual, &actualErr))
 
 	require.Equal(t, resterr.Unauthorized, actualErr.Code)
 }
+
+func requireSystemError(t *testing.T, component, failedOperation string, actual error) {
+	require.IsType(t, &resterr.CustomError{}, actual)
+	actualErr := &resterr.CustomError{}
+	require.True(t, errors.As(actual, &actualErr))
+
+	require.Equal(t, resterr.SystemError, actualErr.Code)
+	require.Equal(t, component, actualErr.Component)
+	require.Equal(t, failedOperation, actualErr.FailedOperation)
+}
@@ -13,12 +13,84 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/deepmap/oapi-codegen/pkg/runtime"
 	"github.com/labstack/echo/v4"
 	externalRef0 "github.com/trustbloc/vcs/pkg/restapi/v1/common"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for CredentialIssuanceStatusState.
+const (
+	Authorized       CredentialIssuanceStatusState = "authorized"
+	Cancelled        CredentialIssuanceStatusState = "cancelled"
+	CredentialIssued CredentialIssuanceStatusState = "credential_issued"
+	Expired          CredentialIssuanceStatusState = "expired"
+	Offered          CredentialIssuanceStatusState = "offered"
+	TokenIssued      CredentialIssuanceStatusState = "token_issued"
+)
+
+// OAuth 2.0 Authorization Server Metadata (RFC 8414).
+type AuthorizationServerMetadata struct {
+	AuthorizationEndpoint              string    `json:"authorization_endpoint"`
+	CodeChallengeMethodsSupported      *[]string `json:"code_challenge_methods_supported,omitempty"`
+	GrantTypesSupported                *[]string `json:"grant_types_supported,omitempty"`
+	IntrospectionEndpoint              *string   `json:"introspection_endpoint,omitempty"`
+	Issuer                             string    `json:"issuer"`
+	PushedAuthorizationRequestEndpoint *string   `json:"pushed_authorization_request_endpoint,omitempty"`
+	RegistrationEndpoint               *string   `json:"registration_endpoint,omitempty"`
+	ResponseTypesSupported             []string  `json:"response_types_supported"`
+	RevocationEndpoint                 *string   `json:"revocation_endpoint,omitempty"`
+	TokenEndpoint                      string    `json:"token_endpoint"`
+	TokenEndpointAuthMethodsSupported  *[]string `json:"token_endpoint_auth_methods_supported,omitempty"`
+}
+
+// State of the issuance transaction.
+type CredentialIssuanceStatus struct {
+	// Time the credential offer expires at.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// State of the issuance transaction. Credential offer expires if the Wallet doesn't obtain access token before the offer expiration time.
+	State CredentialIssuanceStatusState `json:"state"`
+
+	// ID of the issuance transaction.
+	TxId string `json:"tx_id"`
+}
+
+// State of the issuance transaction. Credential offer expires if the Wallet doesn't obtain access token before the offer expiration time.
+type CredentialIssuanceStatusState string
+
+// OpenID for Verifiable Credential Issuance metadata of the issuer profile.
+type CredentialIssuerMetadata struct {
+	// Identifier of the OAuth 2.0 authorization server the credential issuer relies on for authorization.
+	AuthorizationServer *string `json:"authorization_server,omitempty"`
+
+	// URL of the credential endpoint.
+	CredentialEndpoint string `json:"credential_endpoint"`
+
+	// Credential issuer identifier.
+	CredentialIssuer     string                `json:"credential_issuer"`
+	CredentialsSupported []SupportedCredential `json:"credentials_supported"`
+	Display              *[]DisplayProperties  `json:"display,omitempty"`
+}
+
+// Credential offer passed to the Wallet by reference. Contains the same parameters as initiate issuance URL passed by value.
+type CredentialOffer struct {
+	// Types of the offered credentials.
+	CredentialType []string `json:"credential_type"`
+
+	// URL of the Credential Issuer the Wallet requests the credentials from.
+	Issuer string `json:"issuer"`
+
+	// Value the Wallet MUST include in the subsequent Authorization Request as the op_state parameter.
+	OpState string `json:"op_state"`
+}
+
 // Credential status.
 type CredentialStatus struct {
 	Status string `json:"status"`
@@ -30,6 +102,27 @@ type CredentialStatusOpt struct {
 	Type string `json:"type"`
 }
 
+// Model for claim data of the credential which issuance was deferred.
+type DeferredClaimData struct {
+	// Claims of the credential subject.
+	Claims map[string]interface{} `json:"claims"`
+
+	// Type of the credential the claims are provided for.
+	CredentialType string `json:"credential_type"`
+}
+
+// Display properties of the issuer or credential for a certain language.
+type DisplayProperties struct {
+	BackgroundColor *string `json:"background_color,omitempty"`
+	Description     *string `json:"description,omitempty"`
+
+	// BCP47 language tag.
+	Locale    *string `json:"locale,omitempty"`
+	Logo      *Logo   `json:"logo,omitempty"`
+	Name      string  `json:"name"`
+	TextColor *string `json:"text_color,omitempty"`
+}
+
 // Model for exchanging auth code from issuer oauth
 type ExchangeAuthorizationCodeRequest struct {
 	OpState string `json:"op_state"`
@@ -37,7 +130,9 @@ type ExchangeAuthorizationCodeRequest struct {
 
 // Response model for exchanging auth code from issuer oauth
 type ExchangeAuthorizationCodeResponse struct {
-	TxId *string `json:"tx_id,omitempty"`
+	// Identifier of the credential issuer of the transaction profile. Proofs of possession sent by the wallet should use it as the audience.
+	CredentialIssuer *string `json:"credential_issuer,omitempty"`
+	TxId             *string `json:"tx_id,omitempty"`
 }
 
 // Model for Initiate OIDC Credential Issuance Request.
@@ -54,9 +149,15 @@ type InitiateOIDC4VCRequest struct {
 	// String containing wallet/holder application OIDC client wellknown configuration URL.
 	ClientWellknown *string `json:"client_wellknown,omitempty"`
 
-	// Template of the credential to be issued while successfully concluding this interaction. REQUIRED, if the profile is configured to use multiple credential templates.
+	// If true, credential offer is stored in VCS and initiate issuance URL contains credential_offer_uri the Wallet fetches the offer from. Produces shorter URLs and less dense QR codes.
+	CredentialOfferByReference *bool `json:"credential_offer_by_reference,omitempty"`
+
+	// Template of the credential to be issued while successfully concluding this interaction. REQUIRED, if the profile is configured to use multiple credential templates and credential_template_ids is not set.
 	CredentialTemplateId *string `json:"credential_template_id,omitempty"`
 
+	// Templates of the credentials offered in this interaction. The wallet can obtain all of them using one access token. Credential_template_id is added to the list if both are set.
+	CredentialTemplateIds *[]string `json:"credential_template_ids,omitempty"`
+
 	// Issuer can provide custom grant types through this parameter. This grant type has to be used while exchanging an access token for authorization code in later steps. If not provided then default to authorization_code.
 	GrantType *string `json:"grant_type,omitempty"`
 
@@ -72,6 +173,15 @@ type InitiateOIDC4VCRequest struct {
 
 // Model for Initiate OIDC Credential Issuance Response.
 type InitiateOIDC4VCResponse struct {
+	// Set if initiate issuance URL could not be resolved from client_wellknown. The default openid-initiate-issuance:// URL is used in that case.
+	ClientWellknownError *string `json:"client_wellknown_error,omitempty"`
+
+	// URL of the credential offer passed by reference. Set only if credential_offer_by_reference was requested.
+	CredentialOfferUri *string `json:"credential_offer_uri,omitempty"`
+
+	// Time the credential offer expires at. The Wallet should obtain access token before the offer expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
 	// OIDC4CI initiate issuance URL to be used by the Issuer to pass relevant information to the Wallet to initiate issuance flow. Supports both HTTP GET and HTTP Redirect. Issuers may present QR code containing request data for users to scan from their mobile Wallet app.
 	InitiateIssuanceUrl string `json:"initiate_issuance_url"`
 
@@ -106,6 +216,12 @@ type IssueCredentialOptions struct {
 	VerificationMethod *string `json:"verificationMethod,omitempty"`
 }
 
+// Logo defines model for Logo.
+type Logo struct {
+	AltText *string `json:"alt_text,omitempty"`
+	Url     string  `json:"url"`
+}
+
 // Model with key value pairs containing parameters to build OIDC core authorization request (RFC6749) for Issuer OIDC provider to perform wallet user authorization grant.
 type OAuthParameters struct {
 	ClientId     string   `json:"client_id"`
@@ -116,9 +232,8 @@ type OAuthParameters struct {
 
 // Model for Prepare Claim Data Authorization Request.
 type PrepareClaimDataAuthorizationRequest struct {
-	// Model to convey the details about the Credentials the Client wants to obtain.
-	AuthorizationDetails *externalRef0.AuthorizationDetails `json:"authorization_details,omitempty"`
-	OpState              string                             `json:"op_state"`
+	AuthorizationDetails *[]externalRef0.AuthorizationDetails `json:"authorization_details,omitempty"`
+	OpState              string                               `json:"op_state"`
 
 	// Value MUST be set to "code".
 	ResponseType string    `json:"response_type"`
@@ -140,11 +255,43 @@ type PrepareClaimDataAuthorizationResponse struct {
 	TxId string `json:"tx_id"`
 }
 
+// Model for preparing credential offered in the transaction.
+type PrepareCredential struct {
+	// Type of the requested credential.
+	CredentialType string `json:"credential_type"`
+
+	// DID of the holder the credential is issued to.
+	Did string `json:"did"`
+
+	// Format of the requested credential, jwt_vc or ldp_vc. Optional if only one credential of the given type is offered.
+	Format  *string `json:"format,omitempty"`
+	OpState string  `json:"op_state"`
+
+	// Checks that the credential can be issued in the transaction without preparing and signing it.
+	ValidateOnly *bool `json:"validate_only,omitempty"`
+}
+
+// Model for prepared credential.
+type PrepareCredentialResult struct {
+	// Token used to obtain the credential from the deferred credential endpoint. Set if issuance is deferred.
+	AcceptanceToken *string `json:"acceptance_token,omitempty"`
+
+	// Issued credential. JSON object for ldp_vc and JWT string for jwt_vc format. Not set if issuance is deferred.
+	Credential *interface{} `json:"credential,omitempty"`
+
+	// Format of the issued credential, jwt_vc or ldp_vc.
+	Format string `json:"format"`
+}
+
+// Model for preparing credential which issuance was deferred.
+type PrepareDeferredCredential struct {
+	AcceptanceToken string `json:"acceptance_token"`
+}
+
 // Model for Push Authorization Details request.
 type PushAuthorizationDetailsRequest struct {
-	// Model to convey the details about the Credentials the Client wants to obtain.
-	AuthorizationDetails externalRef0.AuthorizationDetails `json:"authorization_details"`
-	OpState              string                            `json:"op_state"`
+	AuthorizationDetails []externalRef0.AuthorizationDetails `json:"authorization_details"`
+	OpState              string                              `json:"op_state"`
 }
 
 // Model for storing auth code from issuer oauth
@@ -158,6 +305,22 @@ type StoreAuthorizationCodeResponse struct {
 	TxId *string `json:"tx_id,omitempty"`
 }
 
+// Credential the issuer profile can issue.
+type SupportedCredential struct {
+	Context                              *[]string            `json:"@context,omitempty"`
+	CryptographicBindingMethodsSupported *[]string            `json:"cryptographic_binding_methods_supported,omitempty"`
+	CryptographicSuitesSupported         *[]string            `json:"cryptographic_suites_supported,omitempty"`
+	Display                              *[]DisplayProperties `json:"display,omitempty"`
+
+	// Format of the credential, jwt_vc or ldp_vc.
+	Format string `json:"format"`
+
+	// ID of the credential template.
+	Id                  *string   `json:"id,omitempty"`
+	ProofTypesSupported *[]string `json:"proof_types_supported,omitempty"`
+	Types               []string  `json:"types"`
+}
+
 // UpdateCredentialStatusRequest request struct for updating VC status.
 type UpdateCredentialStatusRequest struct {
 	CredentialID string `json:"credentialID"`
@@ -172,6 +335,12 @@ type ExchangeAuthorizationCodeRequestJSONBody = ExchangeAuthorizationCodeRequest
 // PrepareAuthorizationRequestJSONBody defines parameters for PrepareAuthorizationRequest.
 type PrepareAuthorizationRequestJSONBody = PrepareClaimDataAuthorizationRequest
 
+// PrepareCredentialJSONBody defines parameters for PrepareCredential.
+type PrepareCredentialJSONBody = PrepareCredential
+
+// PrepareDeferredCredentialJSONBody defines parameters for PrepareDeferredCredential.
+type PrepareDeferredCredentialJSONBody = PrepareDeferredCredential
+
 // PushAuthorizationDetailsJSONBody defines parameters for PushAuthorizationDetails.
 type PushAuthorizationDetailsJSONBody = PushAuthorizationDetailsRequest
 
@@ -187,12 +356,33 @@ type PostCredentialsStatusJSONBody = UpdateCredentialStatusRequest
 // InitiateCredentialIssuanceJSONBody defines parameters for InitiateCredentialIssuance.
 type InitiateCredentialIssuanceJSONBody = InitiateOIDC4VCRequest
 
+// StoreDeferredClaimDataJSONBody defines parameters for StoreDeferredClaimData.
+type StoreDeferredClaimDataJSONBody = DeferredClaimData
+
+// GetIssuanceQrCodeParams defines parameters for GetIssuanceQrCode.
+type GetIssuanceQrCodeParams struct {
+	// Image format of the QR code.
+	Format *GetIssuanceQrCodeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Width and height of the PNG image in pixels. Ignored for SVG.
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// GetIssuanceQrCodeParamsFormat defines parameters for GetIssuanceQrCode.
+type GetIssuanceQrCodeParamsFormat string
+
 // ExchangeAuthorizationCodeRequestJSONRequestBody defines body for ExchangeAuthorizationCodeRequest for application/json ContentType.
 type ExchangeAuthorizationCodeRequestJSONRequestBody = ExchangeAuthorizationCodeRequestJSONBody
 
 // PrepareAuthorizationRequestJSONRequestBody defines body for PrepareAuthorizationRequest for application/json ContentType.
 type PrepareAuthorizationRequestJSONRequestBody = PrepareAuthorizationRequestJSONBody
 
+// PrepareCredentialJSONRequestBody defines body for PrepareCredential for application/json ContentType.
+type PrepareCredentialJSONRequestBody = PrepareCredentialJSONBody
+
+// PrepareDeferredCredentialJSONRequestBody defines body for PrepareDeferredCredential for application/json ContentType.
+type PrepareDeferredCredentialJSONRequestBody = PrepareDeferredCredentialJSONBody
+
 // PushAuthorizationDetailsJSONRequestBody defines body for PushAuthorizationDetails for application/json ContentType.
 type PushAuthorizationDetailsJSONRequestBody = PushAuthorizationDetailsJSONBody
 
@@ -208,6 +398,9 @@ type PostCredentialsStatusJSONRequestBody = PostCredentialsStatusJSONBody
 // InitiateCredentialIssuanceJSONRequestBody defines body for InitiateCredentialIssuance for application/json ContentType.
 type InitiateCredentialIssuanceJSONRequestBody = InitiateCredentialIssuanceJSONBody
 
+// StoreDeferredClaimDataJSONRequestBody defines body for StoreDeferredClaimData for application/json ContentType.
+type StoreDeferredClaimDataJSONRequestBody = StoreDeferredClaimDataJSONBody
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -281,6 +474,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetCredentialOffer request
+	GetCredentialOffer(ctx context.Context, offerID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ExchangeAuthorizationCodeRequest request with any body
 	ExchangeAuthorizationCodeRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -291,6 +487,16 @@ type ClientInterface interface {
 
 	PrepareAuthorizationRequest(ctx context.Context, body PrepareAuthorizationRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PrepareCredential request with any body
+	PrepareCredentialWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PrepareCredential(ctx context.Context, body PrepareCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PrepareDeferredCredential request with any body
+	PrepareDeferredCredentialWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PrepareDeferredCredential(ctx context.Context, body PrepareDeferredCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PushAuthorizationDetails request with any body
 	PushAuthorizationDetailsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -301,6 +507,12 @@ type ClientInterface interface {
 
 	StoreAuthorizationCodeRequest(ctx context.Context, body StoreAuthorizationCodeRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// OauthAuthorizationServerConfig request
+	OauthAuthorizationServerConfig(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// OpenidCredentialIssuerConfig request
+	OpenidCredentialIssuerConfig(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostIssueCredentials request with any body
 	PostIssueCredentialsWithBody(ctx context.Context, profileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -318,6 +530,32 @@ type ClientInterface interface {
 	InitiateCredentialIssuanceWithBody(ctx context.Context, profileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	InitiateCredentialIssuance(ctx context.Context, profileID string, body InitiateCredentialIssuanceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelCredentialIssuance request
+	CancelCredentialIssuance(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StoreDeferredClaimData request with any body
+	StoreDeferredClaimDataWithBody(ctx context.Context, profileID string, txID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StoreDeferredClaimData(ctx context.Context, profileID string, txID string, body StoreDeferredClaimDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIssuanceQrCode request
+	GetIssuanceQrCode(ctx context.Context, profileID string, txID string, params *GetIssuanceQrCodeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCredentialIssuanceStatus request
+	GetCredentialIssuanceStatus(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetCredentialOffer(ctx context.Context, offerID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCredentialOfferRequest(c.Server, offerID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) ExchangeAuthorizationCodeRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -368,6 +606,54 @@ func (c *Client) PrepareAuthorizationRequest(ctx context.Context, body PrepareAu
 	return c.Client.Do(req)
 }
 
+func (c *Client) PrepareCredentialWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrepareCredentialRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PrepareCredential(ctx context.Context, body PrepareCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrepareCredentialRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PrepareDeferredCredentialWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrepareDeferredCredentialRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PrepareDeferredCredential(ctx context.Context, body PrepareDeferredCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPrepareDeferredCredentialRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PushAuthorizationDetailsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPushAuthorizationDetailsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -416,6 +702,30 @@ func (c *Client) StoreAuthorizationCodeRequest(ctx context.Context, body StoreAu
 	return c.Client.Do(req)
 }
 
+func (c *Client) OauthAuthorizationServerConfig(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOauthAuthorizationServerConfigRequest(c.Server, profileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) OpenidCredentialIssuerConfig(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOpenidCredentialIssuerConfigRequest(c.Server, profileID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostIssueCredentialsWithBody(ctx context.Context, profileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostIssueCredentialsRequestWithBody(c.Server, profileID, contentType, body)
 	if err != nil {
@@ -500,6 +810,100 @@ func (c *Client) InitiateCredentialIssuance(ctx context.Context, profileID strin
 	return c.Client.Do(req)
 }
 
+func (c *Client) CancelCredentialIssuance(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelCredentialIssuanceRequest(c.Server, profileID, txID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StoreDeferredClaimDataWithBody(ctx context.Context, profileID string, txID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStoreDeferredClaimDataRequestWithBody(c.Server, profileID, txID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StoreDeferredClaimData(ctx context.Context, profileID string, txID string, body StoreDeferredClaimDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStoreDeferredClaimDataRequest(c.Server, profileID, txID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetIssuanceQrCode(ctx context.Context, profileID string, txID string, params *GetIssuanceQrCodeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIssuanceQrCodeRequest(c.Server, profileID, txID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCredentialIssuanceStatus(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCredentialIssuanceStatusRequest(c.Server, profileID, txID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetCredentialOfferRequest generates requests for GetCredentialOffer
+func NewGetCredentialOfferRequest(server string, offerID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "offerID", runtime.ParamLocationPath, offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/credential-offers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewExchangeAuthorizationCodeRequestRequest calls the generic ExchangeAuthorizationCodeRequest builder with application/json body
 func NewExchangeAuthorizationCodeRequestRequest(server string, body ExchangeAuthorizationCodeRequestJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -580,19 +984,19 @@ func NewPrepareAuthorizationRequestRequestWithBody(server string, contentType st
 	return req, nil
 }
 
-// NewPushAuthorizationDetailsRequest calls the generic PushAuthorizationDetails builder with application/json body
-func NewPushAuthorizationDetailsRequest(server string, body PushAuthorizationDetailsJSONRequestBody) (*http.Request, error) {
+// NewPrepareCredentialRequest calls the generic PrepareCredential builder with application/json body
+func NewPrepareCredentialRequest(server string, body PrepareCredentialJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPushAuthorizationDetailsRequestWithBody(server, "application/json", bodyReader)
+	return NewPrepareCredentialRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPushAuthorizationDetailsRequestWithBody generates requests for PushAuthorizationDetails with any type of body
-func NewPushAuthorizationDetailsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPrepareCredentialRequestWithBody generates requests for PrepareCredential with any type of body
+func NewPrepareCredentialRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -600,7 +1004,7 @@ func NewPushAuthorizationDetailsRequestWithBody(server string, contentType strin
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/issuer/interactions/push-authorization-request")
+	operationPath := fmt.Sprintf("/issuer/interactions/prepare-credential")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -620,19 +1024,19 @@ func NewPushAuthorizationDetailsRequestWithBody(server string, contentType strin
 	return req, nil
 }
 
-// NewStoreAuthorizationCodeRequestRequest calls the generic StoreAuthorizationCodeRequest builder with application/json body
-func NewStoreAuthorizationCodeRequestRequest(server string, body StoreAuthorizationCodeRequestJSONRequestBody) (*http.Request, error) {
+// NewPrepareDeferredCredentialRequest calls the generic PrepareDeferredCredential builder with application/json body
+func NewPrepareDeferredCredentialRequest(server string, body PrepareDeferredCredentialJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewStoreAuthorizationCodeRequestRequestWithBody(server, "application/json", bodyReader)
+	return NewPrepareDeferredCredentialRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewStoreAuthorizationCodeRequestRequestWithBody generates requests for StoreAuthorizationCodeRequest with any type of body
-func NewStoreAuthorizationCodeRequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPrepareDeferredCredentialRequestWithBody generates requests for PrepareDeferredCredential with any type of body
+func NewPrepareDeferredCredentialRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -640,7 +1044,7 @@ func NewStoreAuthorizationCodeRequestRequestWithBody(server string, contentType
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/issuer/interactions/store-authorization-code")
+	operationPath := fmt.Sprintf("/issuer/interactions/prepare-deferred-credential")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -660,34 +1064,27 @@ func NewStoreAuthorizationCodeRequestRequestWithBody(server string, contentType
 	return req, nil
 }
 
-// NewPostIssueCredentialsRequest calls the generic PostIssueCredentials builder with application/json body
-func NewPostIssueCredentialsRequest(server string, profileID string, body PostIssueCredentialsJSONRequestBody) (*http.Request, error) {
+// NewPushAuthorizationDetailsRequest calls the generic PushAuthorizationDetails builder with application/json body
+func NewPushAuthorizationDetailsRequest(server string, body PushAuthorizationDetailsJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostIssueCredentialsRequestWithBody(server, profileID, "application/json", bodyReader)
+	return NewPushAuthorizationDetailsRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostIssueCredentialsRequestWithBody generates requests for PostIssueCredentials with any type of body
-func NewPostIssueCredentialsRequestWithBody(server string, profileID string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPushAuthorizationDetailsRequestWithBody generates requests for PushAuthorizationDetails with any type of body
+func NewPushAuthorizationDetailsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/issuer/profiles/%s/credentials/issue", pathParam0)
+	operationPath := fmt.Sprintf("/issuer/interactions/push-authorization-request")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -707,18 +1104,173 @@ func NewPostIssueCredentialsRequestWithBody(server string, profileID string, con
 	return req, nil
 }
 
-// NewPostCredentialsStatusRequest calls the generic PostCredentialsStatus builder with application/json body
-func NewPostCredentialsStatusRequest(server string, profileID string, body PostCredentialsStatusJSONRequestBody) (*http.Request, error) {
+// NewStoreAuthorizationCodeRequestRequest calls the generic StoreAuthorizationCodeRequest builder with application/json body
+func NewStoreAuthorizationCodeRequestRequest(server string, body StoreAuthorizationCodeRequestJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostCredentialsStatusRequestWithBody(server, profileID, "application/json", bodyReader)
+	return NewStoreAuthorizationCodeRequestRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostCredentialsStatusRequestWithBody generates requests for PostCredentialsStatus with any type of body
+// NewStoreAuthorizationCodeRequestRequestWithBody generates requests for StoreAuthorizationCodeRequest with any type of body
+func NewStoreAuthorizationCodeRequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/interactions/store-authorization-code")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewOauthAuthorizationServerConfigRequest generates requests for OauthAuthorizationServerConfig
+func NewOauthAuthorizationServerConfigRequest(server string, profileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/profiles/%s/.well-known/oauth-authorization-server", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOpenidCredentialIssuerConfigRequest generates requests for OpenidCredentialIssuerConfig
+func NewOpenidCredentialIssuerConfigRequest(server string, profileID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/profiles/%s/.well-known/openid-credential-issuer", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostIssueCredentialsRequest calls the generic PostIssueCredentials builder with application/json body
+func NewPostIssueCredentialsRequest(server string, profileID string, body PostIssueCredentialsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostIssueCredentialsRequestWithBody(server, profileID, "application/json", bodyReader)
+}
+
+// NewPostIssueCredentialsRequestWithBody generates requests for PostIssueCredentials with any type of body
+func NewPostIssueCredentialsRequestWithBody(server string, profileID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/profiles/%s/credentials/issue", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostCredentialsStatusRequest calls the generic PostCredentialsStatus builder with application/json body
+func NewPostCredentialsStatusRequest(server string, profileID string, body PostCredentialsStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostCredentialsStatusRequestWithBody(server, profileID, "application/json", bodyReader)
+}
+
+// NewPostCredentialsStatusRequestWithBody generates requests for PostCredentialsStatus with any type of body
 func NewPostCredentialsStatusRequestWithBody(server string, profileID string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
@@ -842,6 +1394,219 @@ func NewInitiateCredentialIssuanceRequestWithBody(server string, profileID strin
 	return req, nil
 }
 
+// NewCancelCredentialIssuanceRequest generates requests for CancelCredentialIssuance
+func NewCancelCredentialIssuanceRequest(server string, profileID string, txID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "txID", runtime.ParamLocationPath, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/profiles/%s/interactions/%s/cancel", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStoreDeferredClaimDataRequest calls the generic StoreDeferredClaimData builder with application/json body
+func NewStoreDeferredClaimDataRequest(server string, profileID string, txID string, body StoreDeferredClaimDataJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStoreDeferredClaimDataRequestWithBody(server, profileID, txID, "application/json", bodyReader)
+}
+
+// NewStoreDeferredClaimDataRequestWithBody generates requests for StoreDeferredClaimData with any type of body
+func NewStoreDeferredClaimDataRequestWithBody(server string, profileID string, txID string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "txID", runtime.ParamLocationPath, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/profiles/%s/interactions/%s/claim-data", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetIssuanceQrCodeRequest generates requests for GetIssuanceQrCode
+func NewGetIssuanceQrCodeRequest(server string, profileID string, txID string, params *GetIssuanceQrCodeParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "txID", runtime.ParamLocationPath, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/profiles/%s/interactions/%s/qr-code", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	queryValues := queryURL.Query()
+
+	if params.Format != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	if params.Size != nil {
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "size", runtime.ParamLocationQuery, *params.Size); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+	}
+
+	queryURL.RawQuery = queryValues.Encode()
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCredentialIssuanceStatusRequest generates requests for GetCredentialIssuanceStatus
+func NewGetCredentialIssuanceStatusRequest(server string, profileID string, txID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "profileID", runtime.ParamLocationPath, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "txID", runtime.ParamLocationPath, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/issuer/profiles/%s/interactions/%s/status", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -885,6 +1650,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// GetCredentialOffer request
+	GetCredentialOfferWithResponse(ctx context.Context, offerID string, reqEditors ...RequestEditorFn) (*GetCredentialOfferResponse, error)
+
 	// ExchangeAuthorizationCodeRequest request with any body
 	ExchangeAuthorizationCodeRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExchangeAuthorizationCodeRequestResponse, error)
 
@@ -895,6 +1663,16 @@ type ClientWithResponsesInterface interface {
 
 	PrepareAuthorizationRequestWithResponse(ctx context.Context, body PrepareAuthorizationRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*PrepareAuthorizationRequestResponse, error)
 
+	// PrepareCredential request with any body
+	PrepareCredentialWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrepareCredentialResponse, error)
+
+	PrepareCredentialWithResponse(ctx context.Context, body PrepareCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*PrepareCredentialResponse, error)
+
+	// PrepareDeferredCredential request with any body
+	PrepareDeferredCredentialWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrepareDeferredCredentialResponse, error)
+
+	PrepareDeferredCredentialWithResponse(ctx context.Context, body PrepareDeferredCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*PrepareDeferredCredentialResponse, error)
+
 	// PushAuthorizationDetails request with any body
 	PushAuthorizationDetailsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PushAuthorizationDetailsResponse, error)
 
@@ -905,6 +1683,12 @@ type ClientWithResponsesInterface interface {
 
 	StoreAuthorizationCodeRequestWithResponse(ctx context.Context, body StoreAuthorizationCodeRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*StoreAuthorizationCodeRequestResponse, error)
 
+	// OauthAuthorizationServerConfig request
+	OauthAuthorizationServerConfigWithResponse(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*OauthAuthorizationServerConfigResponse, error)
+
+	// OpenidCredentialIssuerConfig request
+	OpenidCredentialIssuerConfigWithResponse(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*OpenidCredentialIssuerConfigResponse, error)
+
 	// PostIssueCredentials request with any body
 	PostIssueCredentialsWithBodyWithResponse(ctx context.Context, profileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIssueCredentialsResponse, error)
 
@@ -922,16 +1706,96 @@ type ClientWithResponsesInterface interface {
 	InitiateCredentialIssuanceWithBodyWithResponse(ctx context.Context, profileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*InitiateCredentialIssuanceResponse, error)
 
 	InitiateCredentialIssuanceWithResponse(ctx context.Context, profileID string, body InitiateCredentialIssuanceJSONRequestBody, reqEditors ...RequestEditorFn) (*InitiateCredentialIssuanceResponse, error)
+
+	// CancelCredentialIssuance request
+	CancelCredentialIssuanceWithResponse(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*CancelCredentialIssuanceResponse, error)
+
+	// StoreDeferredClaimData request with any body
+	StoreDeferredClaimDataWithBodyWithResponse(ctx context.Context, profileID string, txID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StoreDeferredClaimDataResponse, error)
+
+	StoreDeferredClaimDataWithResponse(ctx context.Context, profileID string, txID string, body StoreDeferredClaimDataJSONRequestBody, reqEditors ...RequestEditorFn) (*StoreDeferredClaimDataResponse, error)
+
+	// GetIssuanceQrCode request
+	GetIssuanceQrCodeWithResponse(ctx context.Context, profileID string, txID string, params *GetIssuanceQrCodeParams, reqEditors ...RequestEditorFn) (*GetIssuanceQrCodeResponse, error)
+
+	// GetCredentialIssuanceStatus request
+	GetCredentialIssuanceStatusWithResponse(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*GetCredentialIssuanceStatusResponse, error)
+}
+
+type GetCredentialOfferResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CredentialOffer
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCredentialOfferResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCredentialOfferResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExchangeAuthorizationCodeRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ExchangeAuthorizationCodeResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExchangeAuthorizationCodeRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExchangeAuthorizationCodeRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PrepareAuthorizationRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PrepareClaimDataAuthorizationResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PrepareAuthorizationRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PrepareAuthorizationRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-type ExchangeAuthorizationCodeRequestResponse struct {
+type PrepareCredentialResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *ExchangeAuthorizationCodeResponse
+	JSON200      *PrepareCredentialResult
 }
 
 // Status returns HTTPResponse.Status
-func (r ExchangeAuthorizationCodeRequestResponse) Status() string {
+func (r PrepareCredentialResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -939,21 +1803,21 @@ func (r ExchangeAuthorizationCodeRequestResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ExchangeAuthorizationCodeRequestResponse) StatusCode() int {
+func (r PrepareCredentialResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PrepareAuthorizationRequestResponse struct {
+type PrepareDeferredCredentialResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *PrepareClaimDataAuthorizationResponse
+	JSON200      *PrepareCredentialResult
 }
 
 // Status returns HTTPResponse.Status
-func (r PrepareAuthorizationRequestResponse) Status() string {
+func (r PrepareDeferredCredentialResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -961,7 +1825,7 @@ func (r PrepareAuthorizationRequestResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PrepareAuthorizationRequestResponse) StatusCode() int {
+func (r PrepareDeferredCredentialResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -1011,6 +1875,50 @@ func (r StoreAuthorizationCodeRequestResponse) StatusCode() int {
 	return 0
 }
 
+type OauthAuthorizationServerConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthorizationServerMetadata
+}
+
+// Status returns HTTPResponse.Status
+func (r OauthAuthorizationServerConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OauthAuthorizationServerConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type OpenidCredentialIssuerConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CredentialIssuerMetadata
+}
+
+// Status returns HTTPResponse.Status
+func (r OpenidCredentialIssuerConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OpenidCredentialIssuerConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PostIssueCredentialsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1099,6 +2007,100 @@ func (r InitiateCredentialIssuanceResponse) StatusCode() int {
 	return 0
 }
 
+type CancelCredentialIssuanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelCredentialIssuanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelCredentialIssuanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StoreDeferredClaimDataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r StoreDeferredClaimDataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StoreDeferredClaimDataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIssuanceQrCodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIssuanceQrCodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIssuanceQrCodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCredentialIssuanceStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CredentialIssuanceStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCredentialIssuanceStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCredentialIssuanceStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetCredentialOfferWithResponse request returning *GetCredentialOfferResponse
+func (c *ClientWithResponses) GetCredentialOfferWithResponse(ctx context.Context, offerID string, reqEditors ...RequestEditorFn) (*GetCredentialOfferResponse, error) {
+	rsp, err := c.GetCredentialOffer(ctx, offerID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCredentialOfferResponse(rsp)
+}
+
 // ExchangeAuthorizationCodeRequestWithBodyWithResponse request with arbitrary body returning *ExchangeAuthorizationCodeRequestResponse
 func (c *ClientWithResponses) ExchangeAuthorizationCodeRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExchangeAuthorizationCodeRequestResponse, error) {
 	rsp, err := c.ExchangeAuthorizationCodeRequestWithBody(ctx, contentType, body, reqEditors...)
@@ -1133,6 +2135,40 @@ func (c *ClientWithResponses) PrepareAuthorizationRequestWithResponse(ctx contex
 	return ParsePrepareAuthorizationRequestResponse(rsp)
 }
 
+// PrepareCredentialWithBodyWithResponse request with arbitrary body returning *PrepareCredentialResponse
+func (c *ClientWithResponses) PrepareCredentialWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrepareCredentialResponse, error) {
+	rsp, err := c.PrepareCredentialWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrepareCredentialResponse(rsp)
+}
+
+func (c *ClientWithResponses) PrepareCredentialWithResponse(ctx context.Context, body PrepareCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*PrepareCredentialResponse, error) {
+	rsp, err := c.PrepareCredential(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrepareCredentialResponse(rsp)
+}
+
+// PrepareDeferredCredentialWithBodyWithResponse request with arbitrary body returning *PrepareDeferredCredentialResponse
+func (c *ClientWithResponses) PrepareDeferredCredentialWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PrepareDeferredCredentialResponse, error) {
+	rsp, err := c.PrepareDeferredCredentialWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrepareDeferredCredentialResponse(rsp)
+}
+
+func (c *ClientWithResponses) PrepareDeferredCredentialWithResponse(ctx context.Context, body PrepareDeferredCredentialJSONRequestBody, reqEditors ...RequestEditorFn) (*PrepareDeferredCredentialResponse, error) {
+	rsp, err := c.PrepareDeferredCredential(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrepareDeferredCredentialResponse(rsp)
+}
+
 // PushAuthorizationDetailsWithBodyWithResponse request with arbitrary body returning *PushAuthorizationDetailsResponse
 func (c *ClientWithResponses) PushAuthorizationDetailsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PushAuthorizationDetailsResponse, error) {
 	rsp, err := c.PushAuthorizationDetailsWithBody(ctx, contentType, body, reqEditors...)
@@ -1167,6 +2203,24 @@ func (c *ClientWithResponses) StoreAuthorizationCodeRequestWithResponse(ctx cont
 	return ParseStoreAuthorizationCodeRequestResponse(rsp)
 }
 
+// OauthAuthorizationServerConfigWithResponse request returning *OauthAuthorizationServerConfigResponse
+func (c *ClientWithResponses) OauthAuthorizationServerConfigWithResponse(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*OauthAuthorizationServerConfigResponse, error) {
+	rsp, err := c.OauthAuthorizationServerConfig(ctx, profileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOauthAuthorizationServerConfigResponse(rsp)
+}
+
+// OpenidCredentialIssuerConfigWithResponse request returning *OpenidCredentialIssuerConfigResponse
+func (c *ClientWithResponses) OpenidCredentialIssuerConfigWithResponse(ctx context.Context, profileID string, reqEditors ...RequestEditorFn) (*OpenidCredentialIssuerConfigResponse, error) {
+	rsp, err := c.OpenidCredentialIssuerConfig(ctx, profileID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenidCredentialIssuerConfigResponse(rsp)
+}
+
 // PostIssueCredentialsWithBodyWithResponse request with arbitrary body returning *PostIssueCredentialsResponse
 func (c *ClientWithResponses) PostIssueCredentialsWithBodyWithResponse(ctx context.Context, profileID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostIssueCredentialsResponse, error) {
 	rsp, err := c.PostIssueCredentialsWithBody(ctx, profileID, contentType, body, reqEditors...)
@@ -1227,22 +2281,144 @@ func (c *ClientWithResponses) InitiateCredentialIssuanceWithResponse(ctx context
 	return ParseInitiateCredentialIssuanceResponse(rsp)
 }
 
-// ParseExchangeAuthorizationCodeRequestResponse parses an HTTP response from a ExchangeAuthorizationCodeRequestWithResponse call
-func ParseExchangeAuthorizationCodeRequestResponse(rsp *http.Response) (*ExchangeAuthorizationCodeRequestResponse, error) {
+// CancelCredentialIssuanceWithResponse request returning *CancelCredentialIssuanceResponse
+func (c *ClientWithResponses) CancelCredentialIssuanceWithResponse(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*CancelCredentialIssuanceResponse, error) {
+	rsp, err := c.CancelCredentialIssuance(ctx, profileID, txID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelCredentialIssuanceResponse(rsp)
+}
+
+// StoreDeferredClaimDataWithBodyWithResponse request with arbitrary body returning *StoreDeferredClaimDataResponse
+func (c *ClientWithResponses) StoreDeferredClaimDataWithBodyWithResponse(ctx context.Context, profileID string, txID string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StoreDeferredClaimDataResponse, error) {
+	rsp, err := c.StoreDeferredClaimDataWithBody(ctx, profileID, txID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStoreDeferredClaimDataResponse(rsp)
+}
+
+func (c *ClientWithResponses) StoreDeferredClaimDataWithResponse(ctx context.Context, profileID string, txID string, body StoreDeferredClaimDataJSONRequestBody, reqEditors ...RequestEditorFn) (*StoreDeferredClaimDataResponse, error) {
+	rsp, err := c.StoreDeferredClaimData(ctx, profileID, txID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStoreDeferredClaimDataResponse(rsp)
+}
+
+// GetIssuanceQrCodeWithResponse request returning *GetIssuanceQrCodeResponse
+func (c *ClientWithResponses) GetIssuanceQrCodeWithResponse(ctx context.Context, profileID string, txID string, params *GetIssuanceQrCodeParams, reqEditors ...RequestEditorFn) (*GetIssuanceQrCodeResponse, error) {
+	rsp, err := c.GetIssuanceQrCode(ctx, profileID, txID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIssuanceQrCodeResponse(rsp)
+}
+
+// GetCredentialIssuanceStatusWithResponse request returning *GetCredentialIssuanceStatusResponse
+func (c *ClientWithResponses) GetCredentialIssuanceStatusWithResponse(ctx context.Context, profileID string, txID string, reqEditors ...RequestEditorFn) (*GetCredentialIssuanceStatusResponse, error) {
+	rsp, err := c.GetCredentialIssuanceStatus(ctx, profileID, txID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCredentialIssuanceStatusResponse(rsp)
+}
+
+// ParseGetCredentialOfferResponse parses an HTTP response from a GetCredentialOfferWithResponse call
+func ParseGetCredentialOfferResponse(rsp *http.Response) (*GetCredentialOfferResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCredentialOfferResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CredentialOffer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExchangeAuthorizationCodeRequestResponse parses an HTTP response from a ExchangeAuthorizationCodeRequestWithResponse call
+func ParseExchangeAuthorizationCodeRequestResponse(rsp *http.Response) (*ExchangeAuthorizationCodeRequestResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExchangeAuthorizationCodeRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ExchangeAuthorizationCodeResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePrepareAuthorizationRequestResponse parses an HTTP response from a PrepareAuthorizationRequestWithResponse call
+func ParsePrepareAuthorizationRequestResponse(rsp *http.Response) (*PrepareAuthorizationRequestResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PrepareAuthorizationRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PrepareClaimDataAuthorizationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePrepareCredentialResponse parses an HTTP response from a PrepareCredentialWithResponse call
+func ParsePrepareCredentialResponse(rsp *http.Response) (*PrepareCredentialResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ExchangeAuthorizationCodeRequestResponse{
+	response := &PrepareCredentialResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ExchangeAuthorizationCodeResponse
+		var dest PrepareCredentialResult
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1253,22 +2429,22 @@ func ParseExchangeAuthorizationCodeRequestResponse(rsp *http.Response) (*Exchang
 	return response, nil
 }
 
-// ParsePrepareAuthorizationRequestResponse parses an HTTP response from a PrepareAuthorizationRequestWithResponse call
-func ParsePrepareAuthorizationRequestResponse(rsp *http.Response) (*PrepareAuthorizationRequestResponse, error) {
+// ParsePrepareDeferredCredentialResponse parses an HTTP response from a PrepareDeferredCredentialWithResponse call
+func ParsePrepareDeferredCredentialResponse(rsp *http.Response) (*PrepareDeferredCredentialResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PrepareAuthorizationRequestResponse{
+	response := &PrepareDeferredCredentialResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest PrepareClaimDataAuthorizationResponse
+		var dest PrepareCredentialResult
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1321,6 +2497,58 @@ func ParseStoreAuthorizationCodeRequestResponse(rsp *http.Response) (*StoreAutho
 	return response, nil
 }
 
+// ParseOauthAuthorizationServerConfigResponse parses an HTTP response from a OauthAuthorizationServerConfigWithResponse call
+func ParseOauthAuthorizationServerConfigResponse(rsp *http.Response) (*OauthAuthorizationServerConfigResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OauthAuthorizationServerConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuthorizationServerMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseOpenidCredentialIssuerConfigResponse parses an HTTP response from a OpenidCredentialIssuerConfigWithResponse call
+func ParseOpenidCredentialIssuerConfigResponse(rsp *http.Response) (*OpenidCredentialIssuerConfigResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OpenidCredentialIssuerConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CredentialIssuerMetadata
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostIssueCredentialsResponse parses an HTTP response from a PostIssueCredentialsWithResponse call
 func ParsePostIssueCredentialsResponse(rsp *http.Response) (*PostIssueCredentialsResponse, error) {
 	bodyBytes, err := ioutil.ReadAll(rsp.Body)
@@ -1425,20 +2653,109 @@ func ParseInitiateCredentialIssuanceResponse(rsp *http.Response) (*InitiateCrede
 	return response, nil
 }
 
+// ParseCancelCredentialIssuanceResponse parses an HTTP response from a CancelCredentialIssuanceWithResponse call
+func ParseCancelCredentialIssuanceResponse(rsp *http.Response) (*CancelCredentialIssuanceResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelCredentialIssuanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseStoreDeferredClaimDataResponse parses an HTTP response from a StoreDeferredClaimDataWithResponse call
+func ParseStoreDeferredClaimDataResponse(rsp *http.Response) (*StoreDeferredClaimDataResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StoreDeferredClaimDataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetIssuanceQrCodeResponse parses an HTTP response from a GetIssuanceQrCodeWithResponse call
+func ParseGetIssuanceQrCodeResponse(rsp *http.Response) (*GetIssuanceQrCodeResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetIssuanceQrCodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetCredentialIssuanceStatusResponse parses an HTTP response from a GetCredentialIssuanceStatusWithResponse call
+func ParseGetCredentialIssuanceStatusResponse(rsp *http.Response) (*GetCredentialIssuanceStatusResponse, error) {
+	bodyBytes, err := ioutil.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCredentialIssuanceStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CredentialIssuanceStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Credential offer
+	// (GET /issuer/credential-offers/{offerID})
+	GetCredentialOffer(ctx echo.Context, offerID string) error
 	// Exchange authorization code from issuer oauth provider
 	// (POST /issuer/interactions/exchange-authorization-code)
 	ExchangeAuthorizationCodeRequest(ctx echo.Context) error
 	// Prepare Claim Data Authorization Request
 	// (POST /issuer/interactions/prepare-claim-data-authz-request)
 	PrepareAuthorizationRequest(ctx echo.Context) error
+	// Prepare credential
+	// (POST /issuer/interactions/prepare-credential)
+	PrepareCredential(ctx echo.Context) error
+	// Prepare deferred credential
+	// (POST /issuer/interactions/prepare-deferred-credential)
+	PrepareDeferredCredential(ctx echo.Context) error
 	// Push Authorization Details
 	// (POST /issuer/interactions/push-authorization-request)
 	PushAuthorizationDetails(ctx echo.Context) error
 	// Stores authorization code from issuer oauth provider
 	// (POST /issuer/interactions/store-authorization-code)
 	StoreAuthorizationCodeRequest(ctx echo.Context) error
+	// Authorization Server Metadata
+	// (GET /issuer/profiles/{profileID}/.well-known/oauth-authorization-server)
+	OauthAuthorizationServerConfig(ctx echo.Context, profileID string) error
+	// Credential Issuer Metadata
+	// (GET /issuer/profiles/{profileID}/.well-known/openid-credential-issuer)
+	OpenidCredentialIssuerConfig(ctx echo.Context, profileID string) error
 	// Issue credential
 	// (POST /issuer/profiles/{profileID}/credentials/issue)
 	PostIssueCredentials(ctx echo.Context, profileID string) error
@@ -1451,6 +2768,18 @@ type ServerInterface interface {
 	// Initiate OIDC Credential Issuance
 	// (POST /issuer/profiles/{profileID}/interactions/initiate-oidc)
 	InitiateCredentialIssuance(ctx echo.Context, profileID string) error
+	// Cancel credential issuance
+	// (POST /issuer/profiles/{profileID}/interactions/{txID}/cancel)
+	CancelCredentialIssuance(ctx echo.Context, profileID string, txID string) error
+	// Store deferred claim data
+	// (POST /issuer/profiles/{profileID}/interactions/{txID}/claim-data)
+	StoreDeferredClaimData(ctx echo.Context, profileID string, txID string) error
+	// Initiate issuance QR code
+	// (GET /issuer/profiles/{profileID}/interactions/{txID}/qr-code)
+	GetIssuanceQrCode(ctx echo.Context, profileID string, txID string, params GetIssuanceQrCodeParams) error
+	// Credential issuance status
+	// (GET /issuer/profiles/{profileID}/interactions/{txID}/status)
+	GetCredentialIssuanceStatus(ctx echo.Context, profileID string, txID string) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -1458,10 +2787,28 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetCredentialOffer converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCredentialOffer(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "offerID" -------------
+	var offerID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "offerID", runtime.ParamLocationPath, ctx.Param("offerID"), &offerID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offerID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetCredentialOffer(ctx, offerID)
+	return err
+}
+
 // ExchangeAuthorizationCodeRequest converts echo context to params.
 func (w *ServerInterfaceWrapper) ExchangeAuthorizationCodeRequest(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.ExchangeAuthorizationCodeRequest(ctx)
 	return err
@@ -1471,15 +2818,41 @@ func (w *ServerInterfaceWrapper) ExchangeAuthorizationCodeRequest(ctx echo.Conte
 func (w *ServerInterfaceWrapper) PrepareAuthorizationRequest(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PrepareAuthorizationRequest(ctx)
 	return err
 }
 
+// PrepareCredential converts echo context to params.
+func (w *ServerInterfaceWrapper) PrepareCredential(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PrepareCredential(ctx)
+	return err
+}
+
+// PrepareDeferredCredential converts echo context to params.
+func (w *ServerInterfaceWrapper) PrepareDeferredCredential(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.PrepareDeferredCredential(ctx)
+	return err
+}
+
 // PushAuthorizationDetails converts echo context to params.
 func (w *ServerInterfaceWrapper) PushAuthorizationDetails(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PushAuthorizationDetails(ctx)
 	return err
@@ -1489,11 +2862,45 @@ func (w *ServerInterfaceWrapper) PushAuthorizationDetails(ctx echo.Context) erro
 func (w *ServerInterfaceWrapper) StoreAuthorizationCodeRequest(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.StoreAuthorizationCodeRequest(ctx)
 	return err
 }
 
+// OauthAuthorizationServerConfig converts echo context to params.
+func (w *ServerInterfaceWrapper) OauthAuthorizationServerConfig(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "profileID", runtime.ParamLocationPath, ctx.Param("profileID"), &profileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OauthAuthorizationServerConfig(ctx, profileID)
+	return err
+}
+
+// OpenidCredentialIssuerConfig converts echo context to params.
+func (w *ServerInterfaceWrapper) OpenidCredentialIssuerConfig(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "profileID", runtime.ParamLocationPath, ctx.Param("profileID"), &profileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OpenidCredentialIssuerConfig(ctx, profileID)
+	return err
+}
+
 // PostIssueCredentials converts echo context to params.
 func (w *ServerInterfaceWrapper) PostIssueCredentials(ctx echo.Context) error {
 	var err error
@@ -1505,6 +2912,10 @@ func (w *ServerInterfaceWrapper) PostIssueCredentials(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"issuer:issue"})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostIssueCredentials(ctx, profileID)
 	return err
@@ -1521,6 +2932,10 @@ func (w *ServerInterfaceWrapper) PostCredentialsStatus(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"issuer:status"})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostCredentialsStatus(ctx, profileID)
 	return err
@@ -1561,11 +2976,143 @@ func (w *ServerInterfaceWrapper) InitiateCredentialIssuance(ctx echo.Context) er
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"issuer:issue"})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.InitiateCredentialIssuance(ctx, profileID)
 	return err
 }
 
+// CancelCredentialIssuance converts echo context to params.
+func (w *ServerInterfaceWrapper) CancelCredentialIssuance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "profileID", runtime.ParamLocationPath, ctx.Param("profileID"), &profileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// ------------- Path parameter "txID" -------------
+	var txID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txID", runtime.ParamLocationPath, ctx.Param("txID"), &txID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"issuer:issue"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.CancelCredentialIssuance(ctx, profileID, txID)
+	return err
+}
+
+// StoreDeferredClaimData converts echo context to params.
+func (w *ServerInterfaceWrapper) StoreDeferredClaimData(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "profileID", runtime.ParamLocationPath, ctx.Param("profileID"), &profileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// ------------- Path parameter "txID" -------------
+	var txID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txID", runtime.ParamLocationPath, ctx.Param("txID"), &txID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"issuer:issue"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.StoreDeferredClaimData(ctx, profileID, txID)
+	return err
+}
+
+// GetIssuanceQrCode converts echo context to params.
+func (w *ServerInterfaceWrapper) GetIssuanceQrCode(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "profileID", runtime.ParamLocationPath, ctx.Param("profileID"), &profileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// ------------- Path parameter "txID" -------------
+	var txID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txID", runtime.ParamLocationPath, ctx.Param("txID"), &txID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"issuer:issue"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIssuanceQrCodeParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", ctx.QueryParams(), &params.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter size: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetIssuanceQrCode(ctx, profileID, txID, params)
+	return err
+}
+
+// GetCredentialIssuanceStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCredentialIssuanceStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "profileID", runtime.ParamLocationPath, ctx.Param("profileID"), &profileID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// ------------- Path parameter "txID" -------------
+	var txID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txID", runtime.ParamLocationPath, ctx.Param("txID"), &txID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"issuer:issue"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetCredentialIssuanceStatus(ctx, profileID, txID)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1594,13 +3141,22 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/issuer/credential-offers/:offerID", wrapper.GetCredentialOffer)
 	router.POST(baseURL+"/issuer/interactions/exchange-authorization-code", wrapper.ExchangeAuthorizationCodeRequest)
 	router.POST(baseURL+"/issuer/interactions/prepare-claim-data-authz-request", wrapper.PrepareAuthorizationRequest)
+	router.POST(baseURL+"/issuer/interactions/prepare-credential", wrapper.PrepareCredential)
+	router.POST(baseURL+"/issuer/interactions/prepare-deferred-credential", wrapper.PrepareDeferredCredential)
 	router.POST(baseURL+"/issuer/interactions/push-authorization-request", wrapper.PushAuthorizationDetails)
 	router.POST(baseURL+"/issuer/interactions/store-authorization-code", wrapper.StoreAuthorizationCodeRequest)
+	router.GET(baseURL+"/issuer/profiles/:profileID/.well-known/oauth-authorization-server", wrapper.OauthAuthorizationServerConfig)
+	router.GET(baseURL+"/issuer/profiles/:profileID/.well-known/openid-credential-issuer", wrapper.OpenidCredentialIssuerConfig)
 	router.POST(baseURL+"/issuer/profiles/:profileID/credentials/issue", wrapper.PostIssueCredentials)
 	router.POST(baseURL+"/issuer/profiles/:profileID/credentials/status", wrapper.PostCredentialsStatus)
 	router.GET(baseURL+"/issuer/profiles/:profileID/credentials/status/:statusID", wrapper.GetCredentialsStatus)
 	router.POST(baseURL+"/issuer/profiles/:profileID/interactions/initiate-oidc", wrapper.InitiateCredentialIssuance)
+	router.POST(baseURL+"/issuer/profiles/:profileID/interactions/:txID/cancel", wrapper.CancelCredentialIssuance)
+	router.POST(baseURL+"/issuer/profiles/:profileID/interactions/:txID/claim-data", wrapper.StoreDeferredClaimData)
+	router.GET(baseURL+"/issuer/profiles/:profileID/interactions/:txID/qr-code", wrapper.GetIssuanceQrCode)
+	router.GET(baseURL+"/issuer/profiles/:profileID/interactions/:txID/status", wrapper.GetCredentialIssuanceStatus)
 
 }
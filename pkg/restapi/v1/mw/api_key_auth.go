@@ -9,62 +9,39 @@ package mw
 import (
 	"crypto/subtle"
 	"net/http"
-	"strings"
 
 	"github.com/labstack/echo/v4"
-)
 
-//nolint:gosec
-const (
-	header                     = "X-API-Key"
-	healthCheckPath            = "/healthcheck"
-	statusCheckPath            = "/credentials/status/"
-	requestObjectPath          = "/request-object/"
-	checkAuthorizationResponse = "/verifier/interactions/authorization-response"
-	oidcAuthorize              = "/oidc/authorize"
-	oidcRedirect               = "/oidc/redirect"
-	oidcToken                  = "/oidc/token"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
 )
 
+const header = "X-API-Key" //nolint:gosec
+
 // APIKeyAuth returns a middleware that authenticates requests using the API key from X-API-Key header.
-func APIKeyAuth(apiKey string) echo.MiddlewareFunc {
+// Routes declared as public in the registry are not authenticated.
+func APIKeyAuth(apiKey string, routes *RouteRegistry) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			currentPath := strings.ToLower(c.Request().URL.Path)
-
-			// TODO: Implement a better way to support public API.
-
-			if strings.HasSuffix(currentPath, healthCheckPath) {
-				return next(c)
-			}
-
-			if strings.HasPrefix(currentPath, requestObjectPath) {
-				return next(c)
-			}
-
-			if strings.Contains(currentPath, statusCheckPath) {
+			if routes.IsPublic(c.Request().Method, c.Path()) {
 				return next(c)
 			}
 
-			if strings.HasPrefix(currentPath, checkAuthorizationResponse) {
-				return next(c)
-			}
-
-			if strings.HasPrefix(currentPath, oidcAuthorize) ||
-				strings.HasPrefix(currentPath, oidcRedirect) ||
-				strings.HasPrefix(currentPath, oidcToken) {
-				return next(c)
-			}
-
-			apiKeyHeader := c.Request().Header.Get(header)
-			if subtle.ConstantTimeCompare([]byte(apiKeyHeader), []byte(apiKey)) != 1 {
+			if !validAPIKey(c, apiKey) {
 				return &echo.HTTPError{
 					Code:    http.StatusUnauthorized,
 					Message: "Unauthorized",
 				}
 			}
 
+			util.SetAPIKeyAuthenticated(c)
+
 			return next(c)
 		}
 	}
 }
+
+func validAPIKey(c echo.Context, apiKey string) bool {
+	apiKeyHeader := c.Request().Header.Get(header)
+
+	return subtle.ConstantTimeCompare([]byte(apiKeyHeader), []byte(apiKey)) == 1
+}
@@ -15,9 +15,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
 )
 
 func TestApiKeyAuth(t *testing.T) {
+	routes := newRouteRegistry(t)
+
 	t.Run("Success", func(t *testing.T) {
 		handlerCalled := false
 		handler := func(c echo.Context) error {
@@ -25,7 +28,7 @@ func TestApiKeyAuth(t *testing.T) {
 			return c.String(http.StatusOK, "test")
 		}
 
-		middlewareChain := mw.APIKeyAuth("test-api-key")(handler)
+		middlewareChain := mw.APIKeyAuth("test-api-key", routes)(handler)
 
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -37,6 +40,7 @@ func TestApiKeyAuth(t *testing.T) {
 
 		require.NoError(t, err)
 		require.True(t, handlerCalled)
+		require.True(t, util.IsAPIKeyAuthenticated(c))
 	})
 
 	t.Run("401 Unauthorized", func(t *testing.T) {
@@ -46,7 +50,7 @@ func TestApiKeyAuth(t *testing.T) {
 			return c.String(http.StatusOK, "test")
 		}
 
-		middlewareChain := mw.APIKeyAuth("test-api-key")(handler)
+		middlewareChain := mw.APIKeyAuth("test-api-key", routes)(handler)
 
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodGet, "/", nil)
@@ -68,16 +72,39 @@ func TestApiKeyAuth(t *testing.T) {
 			return c.String(http.StatusOK, "test")
 		}
 
-		middlewareChain := mw.APIKeyAuth("test-api-key")(handler)
+		middlewareChain := mw.APIKeyAuth("test-api-key", routes)(handler)
 
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodGet, "/healthcheck", nil)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
+		c.SetPath("/healthcheck")
 
 		err := middlewareChain(c)
 
 		require.NoError(t, err)
 		require.True(t, handlerCalled)
 	})
+
+	t.Run("route that is not declared public requires API key", func(t *testing.T) {
+		handlerCalled := false
+		handler := func(c echo.Context) error {
+			handlerCalled = true
+			return c.String(http.StatusOK, "test")
+		}
+
+		middlewareChain := mw.APIKeyAuth("test-api-key", routes)(handler)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/issuer/profiles/credentials/status/credentials/status", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/issuer/profiles/:profileID/credentials/status")
+
+		err := middlewareChain(c)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Unauthorized")
+		require.False(t, handlerCalled)
+	})
 }
@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/trustbloc/vcs/pkg/accesstoken"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	userHeader          = "X-User"
+	bearerPrefix        = "Bearer "
+)
+
+type accessTokenValidator interface {
+	Validate(ctx context.Context, token string) (*accesstoken.Info, error)
+}
+
+// BearerAuthConfig configures bearer authentication middleware.
+type BearerAuthConfig struct {
+	TokenValidator accessTokenValidator
+	// Routes declares public routes and scopes required to call the others.
+	Routes *RouteRegistry
+	// APIKey, if set, authenticates internal service-to-service requests that pass X-API-Key header
+	// instead of access token.
+	APIKey string
+}
+
+// BearerAuth returns a middleware that authenticates requests using access token from Authorization header.
+// Organization ID is taken from the access token and X-User header passed by the caller is ignored.
+func BearerAuth(cfg *BearerAuthConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if cfg.Routes.IsPublic(c.Request().Method, c.Path()) {
+				return next(c)
+			}
+
+			if cfg.APIKey != "" && validAPIKey(c, cfg.APIKey) {
+				util.SetAPIKeyAuthenticated(c)
+
+				return next(c)
+			}
+
+			if cfg.Routes.IsAPIKeyOnly(c.Request().Method, c.Path()) {
+				return unauthorized()
+			}
+
+			c.Request().Header.Del(userHeader)
+
+			authHeader := c.Request().Header.Get(authorizationHeader)
+
+			token := strings.TrimSpace(strings.TrimPrefix(authHeader, bearerPrefix))
+			if !strings.HasPrefix(authHeader, bearerPrefix) || token == "" {
+				return bearerError(c, http.StatusUnauthorized, "", "Unauthorized")
+			}
+
+			info, err := cfg.TokenValidator.Validate(c.Request().Context(), token)
+			if errors.Is(err, accesstoken.ErrInvalidToken) {
+				return bearerError(c, http.StatusUnauthorized, "invalid_token", "Unauthorized")
+			}
+
+			if err != nil {
+				return &echo.HTTPError{
+					Code:     http.StatusServiceUnavailable,
+					Message:  "access token validation failed",
+					Internal: err,
+				}
+			}
+
+			for _, scope := range cfg.Routes.Scopes(c.Request().Method, c.Path()) {
+				if !info.HasScope(scope) {
+					return bearerError(c, http.StatusForbidden, "insufficient_scope", "Forbidden")
+				}
+			}
+
+			if info.OrgID != "" {
+				util.SetOrgID(c, info.OrgID)
+			}
+
+			util.SetSubject(c, info.Subject)
+
+			return next(c)
+		}
+	}
+}
+
+// bearerError returns an error with WWW-Authenticate challenge defined in RFC 6750.
+func bearerError(c echo.Context, code int, errorCode, message string) error {
+	challenge := "Bearer"
+	if errorCode != "" {
+		challenge += ` error="` + errorCode + `"`
+	}
+
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, challenge)
+
+	return &echo.HTTPError{
+		Code:    code,
+		Message: message,
+	}
+}
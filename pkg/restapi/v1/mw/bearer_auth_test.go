@@ -0,0 +1,229 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/accesstoken"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+type mockTokenValidator struct {
+	info *accesstoken.Info
+	err  error
+}
+
+func (m *mockTokenValidator) Validate(_ context.Context, _ string) (*accesstoken.Info, error) {
+	return m.info, m.err
+}
+
+func TestBearerAuth(t *testing.T) {
+	const claimPath = "/verifier/interactions/:txID/claim"
+
+	routes := newRouteRegistry(t)
+
+	validator := &mockTokenValidator{info: &accesstoken.Info{
+		Subject: "subject",
+		OrgID:   "org1",
+		Scopes:  []string{"verifier:verify"},
+	}}
+
+	newContext := func(method, path, routePath string, headers map[string]string) (echo.Context,
+		*httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		rec := httptest.NewRecorder()
+
+		c := echo.New().NewContext(req, rec)
+		c.SetPath(routePath)
+
+		return c, rec
+	}
+
+	run := func(cfg *mw.BearerAuthConfig, c echo.Context) (string, bool, error) {
+		var (
+			orgID         string
+			handlerCalled bool
+		)
+
+		err := mw.BearerAuth(cfg)(func(c echo.Context) error {
+			handlerCalled = true
+			orgID, _ = util.GetOrgIDFromOIDC(c) //nolint:errcheck
+
+			return c.NoContent(http.StatusOK)
+		})(c)
+
+		return orgID, handlerCalled, err
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		c, _ := newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, map[string]string{
+			"Authorization": "Bearer token",
+			"X-User":        "org2",
+		})
+
+		orgID, handlerCalled, err := run(&mw.BearerAuthConfig{
+			TokenValidator: validator,
+			Routes:         routes,
+		}, c)
+
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+		require.Equal(t, "org1", orgID)
+	})
+
+	t.Run("Organization header is ignored", func(t *testing.T) {
+		c, _ := newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, map[string]string{
+			"Authorization": "Bearer token",
+			"X-User":        "org2",
+		})
+
+		orgID, handlerCalled, err := run(&mw.BearerAuthConfig{
+			TokenValidator: &mockTokenValidator{info: &accesstoken.Info{Scopes: []string{"verifier:verify"}}},
+			Routes:         routes,
+		}, c)
+
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+		require.Empty(t, orgID)
+	})
+
+	t.Run("Public path", func(t *testing.T) {
+		c, _ := newContext(http.MethodGet, "/healthcheck", "/healthcheck", nil)
+
+		_, handlerCalled, err := run(&mw.BearerAuthConfig{TokenValidator: validator, Routes: routes}, c)
+
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+	})
+
+	t.Run("API key", func(t *testing.T) {
+		c, _ := newContext(http.MethodPost, "/issuer/interactions/push-authorization-request",
+			"/issuer/interactions/push-authorization-request", map[string]string{
+				"X-API-Key": "api-key",
+			})
+
+		_, handlerCalled, err := run(&mw.BearerAuthConfig{
+			TokenValidator: &mockTokenValidator{err: errors.New("must not be called")},
+			Routes:         routes,
+			APIKey:         "api-key",
+		}, c)
+
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+	})
+
+	t.Run("Access token on API key only route", func(t *testing.T) {
+		for _, path := range internalRoutes {
+			c, _ := newContext(http.MethodPost, path, path, map[string]string{
+				"Authorization": "Bearer token",
+			})
+
+			_, handlerCalled, err := run(&mw.BearerAuthConfig{
+				TokenValidator: &mockTokenValidator{info: &accesstoken.Info{OrgID: "org1"}},
+				Routes:         routes,
+				APIKey:         "api-key",
+			}, c)
+
+			requireHTTPError(t, http.StatusUnauthorized, err)
+			require.False(t, handlerCalled, path)
+		}
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		c, rec := newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, map[string]string{
+			"X-API-Key": "invalid-api-key",
+		})
+
+		_, handlerCalled, err := run(&mw.BearerAuthConfig{
+			TokenValidator: validator,
+			Routes:         routes,
+			APIKey:         "api-key",
+		}, c)
+
+		requireHTTPError(t, http.StatusUnauthorized, err)
+		require.False(t, handlerCalled)
+		require.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+
+	t.Run("Not a bearer token", func(t *testing.T) {
+		c, _ := newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, map[string]string{
+			"Authorization": "Basic dXNlcjpwYXNz",
+		})
+
+		_, handlerCalled, err := run(&mw.BearerAuthConfig{TokenValidator: validator, Routes: routes}, c)
+
+		requireHTTPError(t, http.StatusUnauthorized, err)
+		require.False(t, handlerCalled)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		c, rec := newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, map[string]string{
+			"Authorization": "Bearer token",
+		})
+
+		_, handlerCalled, err := run(&mw.BearerAuthConfig{
+			TokenValidator: &mockTokenValidator{err: accesstoken.ErrInvalidToken},
+			Routes:         routes,
+		}, c)
+
+		requireHTTPError(t, http.StatusUnauthorized, err)
+		require.False(t, handlerCalled)
+		require.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+
+	t.Run("Token validation failed", func(t *testing.T) {
+		c, _ := newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, map[string]string{
+			"Authorization": "Bearer token",
+		})
+
+		_, handlerCalled, err := run(&mw.BearerAuthConfig{
+			TokenValidator: &mockTokenValidator{err: errors.New("jwks is not available")},
+			Routes:         routes,
+		}, c)
+
+		requireHTTPError(t, http.StatusServiceUnavailable, err)
+		require.False(t, handlerCalled)
+	})
+
+	t.Run("Insufficient scope", func(t *testing.T) {
+		c, rec := newContext(http.MethodPost, "/issuer/profiles/p1/credentials/issue",
+			"/issuer/profiles/:profileID/credentials/issue", map[string]string{
+				"Authorization": "Bearer token",
+			})
+
+		_, handlerCalled, err := run(&mw.BearerAuthConfig{
+			TokenValidator: validator,
+			Routes:         routes,
+		}, c)
+
+		requireHTTPError(t, http.StatusForbidden, err)
+		require.False(t, handlerCalled)
+		require.Equal(t, `Bearer error="insufficient_scope"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+}
+
+func requireHTTPError(t *testing.T, code int, err error) {
+	t.Helper()
+
+	var httpErr *echo.HTTPError
+
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, code, httpErr.Code)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+// CertOrgIDSource defines a field of the client certificate organization ID is taken from.
+type CertOrgIDSource string
+
+const (
+	// CertOrgIDSubjectCN takes organization ID from the subject common name.
+	CertOrgIDSubjectCN CertOrgIDSource = "subject-cn"
+	// CertOrgIDSubjectO takes organization ID from the first subject organization.
+	CertOrgIDSubjectO CertOrgIDSource = "subject-o"
+	// CertOrgIDSANDNS takes organization ID from the first DNS name in subject alternative names.
+	CertOrgIDSANDNS CertOrgIDSource = "san-dns"
+	// CertOrgIDSANURI takes organization ID from the first URI in subject alternative names.
+	CertOrgIDSANURI CertOrgIDSource = "san-uri"
+	// CertOrgIDSANEmail takes organization ID from the first email address in subject alternative names.
+	CertOrgIDSANEmail CertOrgIDSource = "san-email"
+)
+
+// ParseCertOrgIDSource parses organization ID source of the client certificate.
+func ParseCertOrgIDSource(s string) (CertOrgIDSource, error) {
+	switch source := CertOrgIDSource(s); source {
+	case CertOrgIDSubjectCN, CertOrgIDSubjectO, CertOrgIDSANDNS, CertOrgIDSANURI, CertOrgIDSANEmail:
+		return source, nil
+	default:
+		return "", fmt.Errorf("unsupported client certificate org id source: %s", s)
+	}
+}
+
+type certDenyList interface {
+	IsDenied(cert *x509.Certificate) bool
+}
+
+// ClientCertAuthConfig configures client certificate authentication middleware.
+type ClientCertAuthConfig struct {
+	// Routes declares public routes and scopes required to call the others.
+	Routes *RouteRegistry
+	// Scopes are granted to clients authenticated with certificate. Routes that require other scopes
+	// are authenticated by the fallback middleware.
+	Scopes []string
+	// Required rejects requests without client certificate instead of passing them to the fallback middleware.
+	// It applies only to routes accessible with certificate.
+	Required    bool
+	OrgIDSource CertOrgIDSource
+	DenyList    certDenyList
+}
+
+// ClientCertAuth returns a middleware that authenticates requests using TLS client certificate verified by
+// the server. Organization ID is taken from the certificate and X-User header passed by the caller is ignored.
+// Requests without client certificate are passed to the fallback middleware, if any.
+func ClientCertAuth(cfg *ClientCertAuthConfig, fallback echo.MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		fallbackHandler := next
+		if fallback != nil {
+			fallbackHandler = fallback(next)
+		}
+
+		return func(c echo.Context) error {
+			if cfg.Routes.IsPublic(c.Request().Method, c.Path()) {
+				return next(c)
+			}
+
+			// Routes that require scopes not granted to certificate clients accept only fallback authentication.
+			if !cfg.allowed(c) {
+				return fallbackHandler(c)
+			}
+
+			tlsState := c.Request().TLS
+			if tlsState == nil || len(tlsState.VerifiedChains) == 0 {
+				if cfg.Required {
+					return unauthorized()
+				}
+
+				return fallbackHandler(c)
+			}
+
+			chain := tlsState.VerifiedChains[0]
+
+			if cfg.DenyList != nil {
+				for _, cert := range chain {
+					if cfg.DenyList.IsDenied(cert) {
+						logger.WithContext(c.Request().Context()).Warn("Client certificate is denied",
+							log.WithSubject(chain[0].Subject.String()))
+
+						return unauthorized()
+					}
+				}
+			}
+
+			orgID := getCertOrgID(chain[0], cfg.OrgIDSource)
+			if orgID == "" {
+				return unauthorized()
+			}
+
+			c.Request().Header.Del(userHeader)
+
+			util.SetOrgID(c, orgID)
+			util.SetSubject(c, chain[0].Subject.String())
+
+			return next(c)
+		}
+	}
+}
+
+// allowed returns true if scopes granted to certificate clients cover scopes required by the route. Internal
+// routes that accept only API key are never allowed.
+func (cfg *ClientCertAuthConfig) allowed(c echo.Context) bool {
+	if cfg.Routes.IsAPIKeyOnly(c.Request().Method, c.Path()) {
+		return false
+	}
+
+	for _, required := range cfg.Routes.Scopes(c.Request().Method, c.Path()) {
+		granted := false
+
+		for _, scope := range cfg.Scopes {
+			if scope == required {
+				granted = true
+
+				break
+			}
+		}
+
+		if !granted {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getCertOrgID(cert *x509.Certificate, source CertOrgIDSource) string {
+	switch source {
+	case CertOrgIDSubjectO:
+		if len(cert.Subject.Organization) > 0 {
+			return cert.Subject.Organization[0]
+		}
+	case CertOrgIDSANDNS:
+		if len(cert.DNSNames) > 0 {
+			return cert.DNSNames[0]
+		}
+	case CertOrgIDSANURI:
+		if len(cert.URIs) > 0 {
+			return cert.URIs[0].String()
+		}
+	case CertOrgIDSANEmail:
+		if len(cert.EmailAddresses) > 0 {
+			return cert.EmailAddresses[0]
+		}
+	default: // CertOrgIDSubjectCN
+		return cert.Subject.CommonName
+	}
+
+	return ""
+}
+
+func unauthorized() error {
+	return &echo.HTTPError{
+		Code:    http.StatusUnauthorized,
+		Message: "Unauthorized",
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+type mockDenyList struct {
+	denied *x509.Certificate
+}
+
+func (m *mockDenyList) IsDenied(cert *x509.Certificate) bool {
+	return m.denied == cert
+}
+
+func TestClientCertAuth(t *testing.T) {
+	const (
+		claimPath = "/verifier/interactions/:txID/claim"
+		auditPath = "/admin/audit/entries"
+	)
+
+	routes := newRouteRegistry(t)
+
+	clientCert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "org1", Organization: []string{"Org One"}},
+		DNSNames:       []string{"org1.example.com"},
+		URIs:           []*url.URL{{Scheme: "https", Host: "org1.example.com"}},
+		EmailAddresses: []string{"admin@org1.example.com"},
+	}
+	caCert := &x509.Certificate{Subject: pkix.Name{CommonName: "ca"}}
+
+	newConfig := func() *mw.ClientCertAuthConfig {
+		return &mw.ClientCertAuthConfig{
+			Routes:      routes,
+			Scopes:      []string{"verifier:verify"},
+			OrgIDSource: mw.CertOrgIDSubjectCN,
+			DenyList:    &mockDenyList{},
+		}
+	}
+
+	newContext := func(method, path, routePath string, cert *x509.Certificate) echo.Context {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-User", "org2")
+
+		if cert != nil {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert, caCert}}}
+		}
+
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		c.SetPath(routePath)
+
+		return c
+	}
+
+	fallback := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			util.SetOrgID(c, "fallback")
+
+			return next(c)
+		}
+	}
+
+	run := func(cfg *mw.ClientCertAuthConfig, fallback echo.MiddlewareFunc, c echo.Context) (string, bool, error) {
+		var (
+			orgID         string
+			handlerCalled bool
+		)
+
+		err := mw.ClientCertAuth(cfg, fallback)(func(c echo.Context) error {
+			handlerCalled = true
+			orgID, _ = util.GetOrgIDFromOIDC(c) //nolint:errcheck
+
+			return c.NoContent(http.StatusOK)
+		})(c)
+
+		return orgID, handlerCalled, err
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		c := newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, clientCert)
+
+		orgID, handlerCalled, err := run(newConfig(), fallback, c)
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+		require.Equal(t, "org1", orgID)
+		require.Equal(t, "CN=org1,O=Org One", util.GetActor(c))
+	})
+
+	t.Run("Org ID source", func(t *testing.T) {
+		for source, expected := range map[mw.CertOrgIDSource]string{
+			mw.CertOrgIDSubjectCN: "org1",
+			mw.CertOrgIDSubjectO:  "Org One",
+			mw.CertOrgIDSANDNS:    "org1.example.com",
+			mw.CertOrgIDSANURI:    "https://org1.example.com",
+			mw.CertOrgIDSANEmail:  "admin@org1.example.com",
+		} {
+			cfg := newConfig()
+			cfg.OrgIDSource = source
+
+			orgID, _, err := run(cfg, nil,
+				newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, clientCert))
+			require.NoError(t, err)
+			require.Equal(t, expected, orgID, source)
+		}
+	})
+
+	t.Run("Org ID is missing in certificate", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.OrgIDSource = mw.CertOrgIDSANDNS
+
+		_, handlerCalled, err := run(cfg, fallback,
+			newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, caCert))
+		requireHTTPError(t, http.StatusUnauthorized, err)
+		require.False(t, handlerCalled)
+	})
+
+	t.Run("Denied certificate", func(t *testing.T) {
+		for _, denied := range []*x509.Certificate{clientCert, caCert} {
+			cfg := newConfig()
+			cfg.DenyList = &mockDenyList{denied: denied}
+
+			_, handlerCalled, err := run(cfg, fallback,
+				newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, clientCert))
+			requireHTTPError(t, http.StatusUnauthorized, err)
+			require.False(t, handlerCalled)
+		}
+	})
+
+	t.Run("No certificate", func(t *testing.T) {
+		orgID, handlerCalled, err := run(newConfig(), fallback,
+			newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, nil))
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+		require.Equal(t, "fallback", orgID)
+	})
+
+	t.Run("No certificate and no fallback", func(t *testing.T) {
+		orgID, handlerCalled, err := run(newConfig(), nil,
+			newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, nil))
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+		require.Equal(t, "org2", orgID)
+	})
+
+	t.Run("Certificate is required", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Required = true
+
+		_, handlerCalled, err := run(cfg, fallback,
+			newContext(http.MethodGet, "/verifier/interactions/tx1/claim", claimPath, nil))
+		requireHTTPError(t, http.StatusUnauthorized, err)
+		require.False(t, handlerCalled)
+	})
+
+	t.Run("Route requires scope not granted to certificate clients", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Required = true
+
+		orgID, handlerCalled, err := run(cfg, fallback,
+			newContext(http.MethodGet, "/admin/audit/entries", auditPath, clientCert))
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+		require.Equal(t, "fallback", orgID)
+	})
+
+	t.Run("API key only route", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Required = true
+
+		for _, path := range internalRoutes {
+			orgID, handlerCalled, err := run(cfg, fallback, newContext(http.MethodPost, path, path, clientCert))
+			require.NoError(t, err)
+			require.True(t, handlerCalled)
+			require.Equal(t, "fallback", orgID, path)
+		}
+	})
+
+	t.Run("Public route", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Required = true
+
+		_, handlerCalled, err := run(cfg, fallback, newContext(http.MethodGet, "/healthcheck", "/healthcheck", nil))
+		require.NoError(t, err)
+		require.True(t, handlerCalled)
+	})
+}
+
+func TestParseCertOrgIDSource(t *testing.T) {
+	source, err := mw.ParseCertOrgIDSource("san-uri")
+	require.NoError(t, err)
+	require.Equal(t, mw.CertOrgIDSANURI, source)
+
+	_, err = mw.ParseCertOrgIDSource("serial")
+	require.EqualError(t, err, "unsupported client certificate org id source: serial")
+}
@@ -0,0 +1,30 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+// CorrelationID returns a middleware that puts the request ID into the request context as a correlation ID,
+// so that it is added to log lines of the request. Must be used after echo RequestID middleware.
+func CorrelationID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if id := util.GetRequestID(c); id != "" {
+				req := c.Request()
+
+				c.SetRequest(req.WithContext(log.ContextWithCorrelationID(req.Context(), id)))
+			}
+
+			return next(c)
+		}
+	}
+}
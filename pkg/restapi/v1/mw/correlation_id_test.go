@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
+)
+
+func TestCorrelationID(t *testing.T) {
+	e := echo.New()
+	e.Use(echomw.RequestID())
+	e.Use(mw.CorrelationID())
+
+	var correlationID string
+
+	e.GET("/test", func(c echo.Context) error {
+		correlationID = log.CorrelationIDFromContext(c.Request().Context())
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	t.Run("request ID from header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+		req.Header.Set(echo.HeaderXRequestID, "request1")
+
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "request1", correlationID)
+	})
+
+	t.Run("generated request ID", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NotEmpty(t, correlationID)
+		require.Equal(t, rec.Header().Get(echo.HeaderXRequestID), correlationID)
+	})
+}
@@ -0,0 +1,185 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/ratelimit"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+// Rate limit types reported in metrics.
+const (
+	ProfileRateLimit = "profile"
+	ClientRateLimit  = "client"
+	IPRateLimit      = "ip"
+)
+
+// APIKeyClientID identifies clients authenticated with API key in client rate limits.
+const APIKeyClientID = "api-key"
+
+var logger = log.New("rest-mw")
+
+type rateLimitStore interface {
+	Take(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error)
+}
+
+type profileRateLimits interface {
+	// RateLimit returns rate limit of the issuer or verifier profile. Returns nil if profile is not limited.
+	RateLimit(profileType, profileID string) (*ratelimit.Limit, error)
+}
+
+type throttleMetrics interface {
+	ThrottledRequest(limitType string)
+}
+
+// RateLimitConfig configures rate limit middleware.
+type RateLimitConfig struct {
+	Store         rateLimitStore
+	ProfileLimits profileRateLimits
+	// ClientLimits defines rate limits of particular clients identified by organization ID of the access
+	// token or by APIKeyClientID.
+	ClientLimits map[string]ratelimit.Limit
+	// DefaultClientLimit, if set, limits clients that have no limit in ClientLimits. Unauthenticated clients
+	// are identified by remote IP address.
+	DefaultClientLimit *ratelimit.Limit
+	// IPLimit, if set, limits requests from each remote IP address in RateLimitByIP middleware.
+	IPLimit *ratelimit.Limit
+	Metrics throttleMetrics
+}
+
+// RateLimit returns a middleware that limits request rates per client and per issuer/verifier profile.
+// Requests over the limit are rejected with 429 status code and Retry-After header. Requests are not
+// limited if the store fails.
+func RateLimit(cfg *RateLimitConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if clientID, limit := cfg.clientLimit(c); limit != nil {
+				if err := cfg.take(c, ClientRateLimit, "client:"+clientID, *limit); err != nil {
+					return err
+				}
+			}
+
+			if profileKey, limit := cfg.profileLimit(c); limit != nil {
+				if err := cfg.take(c, ProfileRateLimit, "profile:"+profileKey, *limit); err != nil {
+					return err
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RateLimitByIP returns a middleware that limits request rate per remote IP address. It doesn't depend on
+// authentication, so it is used in front of authentication middleware to limit requests with invalid
+// credentials as well.
+func RateLimitByIP(cfg *RateLimitConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if cfg.IPLimit != nil {
+				if err := cfg.take(c, IPRateLimit, "ip:"+remoteIP(c), *cfg.IPLimit); err != nil {
+					return err
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (cfg *RateLimitConfig) clientLimit(c echo.Context) (string, *ratelimit.Limit) {
+	clientID, authenticated := clientIdentity(c)
+	if !authenticated {
+		return clientID, cfg.DefaultClientLimit
+	}
+
+	if limit, ok := cfg.ClientLimits[clientID]; ok {
+		return clientID, &limit
+	}
+
+	return clientID, cfg.DefaultClientLimit
+}
+
+// clientIdentity identifies client by organization ID of the access token, by API key or by remote IP address.
+// Request headers are not trusted, so the client can't pick the limit it is accounted against.
+func clientIdentity(c echo.Context) (string, bool) {
+	if orgID, ok := util.GetAuthenticatedOrgID(c); ok {
+		return orgID, true
+	}
+
+	if util.IsAPIKeyAuthenticated(c) {
+		return APIKeyClientID, true
+	}
+
+	return "ip:" + remoteIP(c), false
+}
+
+// remoteIP returns IP address of the client. Proxy headers are taken into account only if IP extractor
+// is configured for the server.
+func remoteIP(c echo.Context) string {
+	if c.Echo().IPExtractor != nil {
+		return c.RealIP()
+	}
+
+	return echo.ExtractIPDirect()(c.Request())
+}
+
+func (cfg *RateLimitConfig) profileLimit(c echo.Context) (string, *ratelimit.Limit) {
+	profileID := c.Param("profileID")
+	if profileID == "" || cfg.ProfileLimits == nil {
+		return "", nil
+	}
+
+	profileType := c.Param("profileType")
+	if profileType == "" {
+		// Profile routes start with "/issuer/" or "/verifier/".
+		profileType = strings.SplitN(strings.TrimPrefix(c.Path(), "/"), "/", 2)[0]
+	}
+
+	limit, err := cfg.ProfileLimits.RateLimit(profileType, profileID)
+	if err != nil {
+		logger.WithContext(c.Request().Context()).Warn("Failed to get profile rate limit",
+			log.WithProfileID(profileID), log.WithError(err))
+
+		return "", nil
+	}
+
+	return profileType + ":" + profileID, limit
+}
+
+func (cfg *RateLimitConfig) take(c echo.Context, limitType, key string, limit ratelimit.Limit) error {
+	res, err := cfg.Store.Take(c.Request().Context(), key, limit)
+	if err != nil {
+		logger.WithContext(c.Request().Context()).Warn("Failed to check rate limit",
+			log.WithID(key), log.WithError(err))
+
+		return nil
+	}
+
+	if res.Allowed {
+		return nil
+	}
+
+	cfg.Metrics.ThrottledRequest(limitType)
+
+	c.Response().Header().Set(echo.HeaderRetryAfter,
+		strconv.Itoa(int(math.Max(1, math.Ceil(res.RetryAfter.Seconds())))))
+
+	return &echo.HTTPError{
+		Code:    http.StatusTooManyRequests,
+		Message: "Too Many Requests",
+	}
+}
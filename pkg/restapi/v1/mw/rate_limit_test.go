@@ -0,0 +1,271 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/ratelimit"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
+)
+
+type mockProfileRateLimits map[string]*ratelimit.Limit
+
+func (m mockProfileRateLimits) RateLimit(profileType, profileID string) (*ratelimit.Limit, error) {
+	if profileID == "unknown" {
+		return nil, errors.New("profile not found")
+	}
+
+	return m[profileType+":"+profileID], nil
+}
+
+type mockThrottleMetrics struct {
+	throttled map[string]int
+}
+
+func (m *mockThrottleMetrics) ThrottledRequest(limitType string) {
+	m.throttled[limitType]++
+}
+
+type failingRateLimitStore struct{}
+
+func (s *failingRateLimitStore) Take(context.Context, string, ratelimit.Limit) (*ratelimit.Result, error) {
+	return nil, errors.New("store error")
+}
+
+func TestRateLimit(t *testing.T) {
+	const issuePath = "/issuer/profiles/:profileID/credentials/issue"
+
+	serve := func(cfg *mw.RateLimitConfig, path, routePath, orgID string, params ...string) (
+		*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		rec := httptest.NewRecorder()
+
+		c := echo.New().NewContext(req, rec)
+		c.SetPath(routePath)
+
+		if orgID != "" {
+			util.SetOrgID(c, orgID)
+		}
+
+		if len(params) > 0 {
+			c.SetParamNames(params[0])
+			c.SetParamValues(params[1])
+		}
+
+		return rec, mw.RateLimit(cfg)(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})(c)
+	}
+
+	t.Run("Profile limit", func(t *testing.T) {
+		metrics := &mockThrottleMetrics{throttled: map[string]int{}}
+
+		cfg := &mw.RateLimitConfig{
+			Store: ratelimit.NewMemoryStore(),
+			ProfileLimits: mockProfileRateLimits{
+				"issuer:profile1": {RequestsPerSecond: 0.1, Burst: 2},
+			},
+			Metrics: metrics,
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := serve(cfg, "/issuer/profiles/profile1/credentials/issue", issuePath, "org1",
+				"profileID", "profile1")
+			require.NoError(t, err)
+		}
+
+		rec, err := serve(cfg, "/issuer/profiles/profile1/credentials/issue", issuePath, "org2",
+			"profileID", "profile1")
+		requireHTTPError(t, http.StatusTooManyRequests, err)
+		require.Equal(t, "10", rec.Header().Get(echo.HeaderRetryAfter))
+		require.Equal(t, 1, metrics.throttled[mw.ProfileRateLimit])
+
+		_, err = serve(cfg, "/issuer/profiles/profile2/credentials/issue", issuePath, "org1",
+			"profileID", "profile2")
+		require.NoError(t, err)
+	})
+
+	t.Run("Profile type from path parameter", func(t *testing.T) {
+		cfg := &mw.RateLimitConfig{
+			Store: ratelimit.NewMemoryStore(),
+			ProfileLimits: mockProfileRateLimits{
+				"verifier:profile1": {RequestsPerSecond: 0.1, Burst: 1},
+			},
+			Metrics: &mockThrottleMetrics{throttled: map[string]int{}},
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/verifier/profiles/profile1/well-known/did-config", nil)
+
+		for _, expectedErr := range []bool{false, true} {
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			c.SetPath("/:profileType/profiles/:profileID/well-known/did-config")
+			c.SetParamNames("profileType", "profileID")
+			c.SetParamValues("verifier", "profile1")
+
+			err := mw.RateLimit(cfg)(func(c echo.Context) error { return nil })(c)
+			require.Equal(t, expectedErr, err != nil)
+		}
+	})
+
+	t.Run("Client limit", func(t *testing.T) {
+		metrics := &mockThrottleMetrics{throttled: map[string]int{}}
+
+		cfg := &mw.RateLimitConfig{
+			Store: ratelimit.NewMemoryStore(),
+			ClientLimits: map[string]ratelimit.Limit{
+				"org1": {RequestsPerSecond: 0.5, Burst: 1},
+			},
+			DefaultClientLimit: &ratelimit.Limit{RequestsPerSecond: 0.1, Burst: 2},
+			Metrics:            metrics,
+		}
+
+		_, err := serve(cfg, "/healthcheck", "/healthcheck", "org1")
+		require.NoError(t, err)
+
+		rec, err := serve(cfg, "/healthcheck", "/healthcheck", "org1")
+		requireHTTPError(t, http.StatusTooManyRequests, err)
+		require.Equal(t, "2", rec.Header().Get(echo.HeaderRetryAfter))
+
+		for i := 0; i < 2; i++ {
+			_, err = serve(cfg, "/healthcheck", "/healthcheck", "org2")
+			require.NoError(t, err)
+		}
+
+		_, err = serve(cfg, "/healthcheck", "/healthcheck", "org2")
+		requireHTTPError(t, http.StatusTooManyRequests, err)
+
+		_, err = serve(cfg, "/healthcheck", "/healthcheck", "")
+		require.NoError(t, err)
+
+		require.Equal(t, 2, metrics.throttled[mw.ClientRateLimit])
+	})
+
+	t.Run("Organization header doesn't identify client", func(t *testing.T) {
+		cfg := &mw.RateLimitConfig{
+			Store: ratelimit.NewMemoryStore(),
+			ClientLimits: map[string]ratelimit.Limit{
+				"org1": {RequestsPerSecond: 100, Burst: 100},
+			},
+			DefaultClientLimit: &ratelimit.Limit{RequestsPerSecond: 0.1, Burst: 1},
+			Metrics:            &mockThrottleMetrics{throttled: map[string]int{}},
+		}
+
+		for _, expectedErr := range []bool{false, true} {
+			req := httptest.NewRequest(http.MethodGet, "/healthcheck", nil)
+			req.Header.Set("X-User", "org1")
+			req.Header.Set(echo.HeaderXForwardedFor, "10.0.0.1")
+
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			c.SetPath("/healthcheck")
+
+			err := mw.RateLimit(cfg)(func(c echo.Context) error { return nil })(c)
+			require.Equal(t, expectedErr, err != nil)
+		}
+	})
+
+	t.Run("API key client limit", func(t *testing.T) {
+		cfg := &mw.RateLimitConfig{
+			Store: ratelimit.NewMemoryStore(),
+			ClientLimits: map[string]ratelimit.Limit{
+				mw.APIKeyClientID: {RequestsPerSecond: 0.1, Burst: 1},
+			},
+			Metrics: &mockThrottleMetrics{throttled: map[string]int{}},
+		}
+
+		for _, expectedErr := range []bool{false, true} {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/healthcheck", nil),
+				httptest.NewRecorder())
+			c.SetPath("/healthcheck")
+			util.SetAPIKeyAuthenticated(c)
+
+			err := mw.RateLimit(cfg)(func(c echo.Context) error { return nil })(c)
+			require.Equal(t, expectedErr, err != nil)
+		}
+	})
+
+	t.Run("No limits", func(t *testing.T) {
+		cfg := &mw.RateLimitConfig{
+			Store:         &failingRateLimitStore{},
+			ProfileLimits: mockProfileRateLimits{},
+		}
+
+		_, err := serve(cfg, "/issuer/profiles/profile1/credentials/issue", issuePath, "org1",
+			"profileID", "profile1")
+		require.NoError(t, err)
+
+		_, err = serve(cfg, "/issuer/profiles/unknown/credentials/issue", issuePath, "org1",
+			"profileID", "unknown")
+		require.NoError(t, err)
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		cfg := &mw.RateLimitConfig{
+			Store:              &failingRateLimitStore{},
+			DefaultClientLimit: &ratelimit.Limit{RequestsPerSecond: 1},
+		}
+
+		_, err := serve(cfg, "/healthcheck", "/healthcheck", "org1")
+		require.NoError(t, err)
+	})
+}
+
+func TestRateLimitByIP(t *testing.T) {
+	serve := func(cfg *mw.RateLimitConfig, remoteAddr string) error {
+		req := httptest.NewRequest(http.MethodGet, "/healthcheck", nil)
+		req.RemoteAddr = remoteAddr
+
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		return mw.RateLimitByIP(cfg)(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})(c)
+	}
+
+	t.Run("IP limit", func(t *testing.T) {
+		metrics := &mockThrottleMetrics{throttled: map[string]int{}}
+
+		cfg := &mw.RateLimitConfig{
+			Store:   ratelimit.NewMemoryStore(),
+			IPLimit: &ratelimit.Limit{RequestsPerSecond: 0.1, Burst: 1},
+			Metrics: metrics,
+		}
+
+		require.NoError(t, serve(cfg, "10.0.0.1:1234"))
+		requireHTTPError(t, http.StatusTooManyRequests, serve(cfg, "10.0.0.1:5678"))
+		require.NoError(t, serve(cfg, "10.0.0.2:1234"))
+
+		require.Equal(t, 1, metrics.throttled[mw.IPRateLimit])
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		cfg := &mw.RateLimitConfig{
+			Store: &failingRateLimitStore{},
+		}
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, serve(cfg, "10.0.0.1:1234"))
+		}
+	})
+
+	t.Run("Store error", func(t *testing.T) {
+		cfg := &mw.RateLimitConfig{
+			Store:   &failingRateLimitStore{},
+			IPLimit: &ratelimit.Limit{RequestsPerSecond: 1},
+		}
+
+		require.NoError(t, serve(cfg, "10.0.0.1:1234"))
+	})
+}
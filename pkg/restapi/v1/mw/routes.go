@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw
+
+import (
+	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// BearerSecurityScheme is a name of the security scheme in OpenAPI spec that declares access token scopes.
+	BearerSecurityScheme = "bearerAuth"
+	// APIKeySecurityScheme is a name of the security scheme in OpenAPI spec that declares API key authentication.
+	APIKeySecurityScheme = "apiKeyAuth"
+)
+
+var pathParamRegex = regexp.MustCompile(`{([^}]+)}`)
+
+type routeSecurity struct {
+	public     bool
+	apiKeyOnly bool
+	scopes     []string
+}
+
+// RouteRegistry holds authentication requirements of API routes declared in OpenAPI spec. An operation with
+// empty security requirement ("security: []") is public, any other operation requires authentication. Scopes
+// of an access token are taken from the bearer security scheme requirement of the operation. An operation that
+// declares only API key security requirement is internal and accepts neither access tokens nor client certificates.
+type RouteRegistry struct {
+	routes map[string]*routeSecurity
+}
+
+// NewRouteRegistry creates route registry from OpenAPI spec.
+func NewRouteRegistry(swagger *openapi3.T) *RouteRegistry {
+	r := &RouteRegistry{
+		routes: make(map[string]*routeSecurity),
+	}
+
+	for path, item := range swagger.Paths {
+		// Echo uses ":param" notation for path parameters.
+		echoPath := pathParamRegex.ReplaceAllString(path, ":$1")
+
+		for method, op := range item.Operations() {
+			r.routes[routeKey(method, echoPath)] = newRouteSecurity(op, swagger.Security)
+		}
+	}
+
+	return r
+}
+
+func newRouteSecurity(op *openapi3.Operation, defaultSecurity openapi3.SecurityRequirements) *routeSecurity {
+	security := defaultSecurity
+	if op.Security != nil {
+		security = *op.Security
+	}
+
+	rs := &routeSecurity{
+		public:     len(security) == 0,
+		apiKeyOnly: len(security) > 0,
+	}
+
+	for _, requirement := range security {
+		rs.scopes = append(rs.scopes, requirement[BearerSecurityScheme]...)
+
+		if _, ok := requirement[APIKeySecurityScheme]; !ok || len(requirement) > 1 {
+			rs.apiKeyOnly = false
+		}
+	}
+
+	return rs
+}
+
+// IsPublic returns true if route with the given method and path does not require authentication.
+// Routes that are not declared in OpenAPI spec require authentication.
+func (r *RouteRegistry) IsPublic(method, path string) bool {
+	rs, ok := r.routes[routeKey(method, path)]
+
+	return ok && rs.public
+}
+
+// IsAPIKeyOnly returns true if route with the given method and path can be called only with API key.
+func (r *RouteRegistry) IsAPIKeyOnly(method, path string) bool {
+	rs, ok := r.routes[routeKey(method, path)]
+
+	return ok && rs.apiKeyOnly
+}
+
+// Scopes returns access token scopes required to call route with the given method and path.
+func (r *RouteRegistry) Scopes(method, path string) []string {
+	if rs, ok := r.routes[routeKey(method, path)]; ok {
+		return rs.scopes
+	}
+
+	return nil
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
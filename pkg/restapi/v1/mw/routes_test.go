@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/api/spec"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
+)
+
+func TestRouteRegistry(t *testing.T) {
+	routes := newRouteRegistry(t)
+
+	t.Run("Public routes", func(t *testing.T) {
+		for _, tc := range []struct {
+			method string
+			path   string
+		}{
+			{http.MethodGet, "/healthcheck"},
+			{http.MethodGet, "/request-object/:uuid"},
+			{http.MethodGet, "/issuer/profiles/:profileID/credentials/status/:statusID"},
+			{http.MethodPost, "/verifier/interactions/authorization-response"},
+			{http.MethodGet, "/oidc/authorize"},
+			{http.MethodGet, "/oidc/redirect"},
+			{http.MethodPost, "/oidc/token"},
+			{http.MethodPost, "/oidc/credential"},
+			{http.MethodPost, "/oidc/batch_credential"},
+			{http.MethodPost, "/oidc/deferred_credential"},
+			{http.MethodPost, "/oidc/introspect"},
+			{http.MethodPost, "/oidc/revoke"},
+			{http.MethodPost, "/oidc/register"},
+			{http.MethodGet, "/oidc/register/:clientID"},
+			{http.MethodPut, "/oidc/register/:clientID"},
+			{http.MethodDelete, "/oidc/register/:clientID"},
+			{http.MethodGet, "/issuer/profiles/:profileID/.well-known/openid-credential-issuer"},
+			{http.MethodGet, "/issuer/profiles/:profileID/.well-known/oauth-authorization-server"},
+			{http.MethodGet, "/issuer/credential-offers/:offerID"},
+		} {
+			require.True(t, routes.IsPublic(tc.method, tc.path), tc.method+" "+tc.path)
+		}
+	})
+
+	t.Run("Authenticated routes", func(t *testing.T) {
+		for _, tc := range []struct {
+			method string
+			path   string
+		}{
+			{http.MethodPost, "/issuer/profiles/:profileID/credentials/status"},
+			{http.MethodPost, "/issuer/interactions/push-authorization-request"},
+			{http.MethodPost, "/issuer/interactions/prepare-credential"},
+			{http.MethodPost, "/issuer/interactions/prepare-deferred-credential"},
+			{http.MethodPost, "/issuer/profiles/:profileID/interactions/:txID/claim-data"},
+			{http.MethodGet, "/issuer/profiles/:profileID/interactions/:txID/qr-code"},
+			{http.MethodGet, "/issuer/profiles/:profileID/interactions/:txID/status"},
+			{http.MethodPost, "/issuer/profiles/:profileID/interactions/:txID/cancel"},
+			{http.MethodGet, "/verifier/interactions/:txID/qr-code"},
+			{http.MethodGet, "/:profileType/profiles/:profileID/well-known/did-config"},
+			{http.MethodPost, "/healthcheck"},
+			{http.MethodGet, "/unknown"},
+			{http.MethodGet, ""},
+		} {
+			require.False(t, routes.IsPublic(tc.method, tc.path), tc.method+" "+tc.path)
+		}
+	})
+
+	t.Run("API key only routes", func(t *testing.T) {
+		for _, path := range internalRoutes {
+			require.True(t, routes.IsAPIKeyOnly(http.MethodPost, path), path)
+			require.False(t, routes.IsPublic(http.MethodPost, path), path)
+		}
+
+		for _, tc := range []struct {
+			method string
+			path   string
+		}{
+			{http.MethodGet, "/healthcheck"},
+			{http.MethodPost, "/oidc/token"},
+			{http.MethodPost, "/issuer/profiles/:profileID/credentials/issue"},
+			{http.MethodPost, "/issuer/profiles/:profileID/interactions/:txID/claim-data"},
+			{http.MethodGet, "/verifier/interactions/:txID/qr-code"},
+			{http.MethodGet, "/unknown"},
+		} {
+			require.False(t, routes.IsAPIKeyOnly(tc.method, tc.path), tc.method+" "+tc.path)
+		}
+	})
+
+	t.Run("Scopes", func(t *testing.T) {
+		require.Equal(t, []string{"issuer:issue"},
+			routes.Scopes(http.MethodPost, "/issuer/profiles/:profileID/credentials/issue"))
+		require.Equal(t, []string{"issuer:status"},
+			routes.Scopes(http.MethodPost, "/issuer/profiles/:profileID/credentials/status"))
+		require.Equal(t, []string{"verifier:verify"},
+			routes.Scopes(http.MethodDelete, "/verifier/interactions/:txID/claim"))
+		require.Equal(t, []string{"verifier:verify"},
+			routes.Scopes(http.MethodGet, "/verifier/interactions/:txID/qr-code"))
+		require.Equal(t, []string{"issuer:issue"},
+			routes.Scopes(http.MethodPost, "/issuer/profiles/:profileID/interactions/:txID/cancel"))
+		require.Empty(t, routes.Scopes(http.MethodPost, "/issuer/interactions/push-authorization-request"))
+		require.Empty(t, routes.Scopes(http.MethodGet, "/unknown"))
+	})
+}
+
+// internalRoutes are called only by VCS OIDC endpoints and accept only API key.
+var internalRoutes = []string{ //nolint:gochecknoglobals
+	"/issuer/interactions/push-authorization-request",
+	"/issuer/interactions/prepare-claim-data-authz-request",
+	"/issuer/interactions/store-authorization-code",
+	"/issuer/interactions/prepare-credential",
+	"/issuer/interactions/prepare-deferred-credential",
+	"/issuer/interactions/exchange-authorization-code",
+}
+
+func newRouteRegistry(t *testing.T) *mw.RouteRegistry {
+	t.Helper()
+
+	swagger, err := spec.GetSwagger()
+	require.NoError(t, err)
+
+	return mw.NewRouteRegistry(swagger)
+}
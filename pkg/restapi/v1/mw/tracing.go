@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/trustbloc/vcs/pkg/restapi/resterr"
+)
+
+var tracer = otel.Tracer("github.com/trustbloc/vcs/pkg/restapi/v1/mw")
+
+// Tracing returns a middleware that records a server span for each request. Trace context of the caller is
+// taken from W3C trace context headers.
+func Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			route := c.Path()
+			if route == "" {
+				route = req.URL.Path
+			}
+
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPMethodKey.String(req.Method),
+					semconv.HTTPRouteKey.String(route),
+					semconv.HTTPTargetKey.String(req.URL.RequestURI()),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			status := c.Response().Status
+
+			if err != nil {
+				span.RecordError(err)
+
+				if !c.Response().Committed {
+					// Error response is written by the error handler after the middleware returns.
+					status = resterr.HTTPStatus(err)
+				}
+			}
+
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mw_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/trustbloc/vcs/pkg/restapi/resterr"
+	"github.com/trustbloc/vcs/pkg/restapi/v1/mw"
+)
+
+var (
+	spanRecorder   = tracetest.NewSpanRecorder()
+	tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+)
+
+func init() {
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func TestTracing(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = resterr.HTTPErrorHandler
+	e.Use(mw.Tracing())
+
+	var handlerSpanCtx trace.SpanContext
+
+	e.GET("/profiles/:id", func(c echo.Context) error {
+		handlerSpanCtx = trace.SpanContextFromContext(c.Request().Context())
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("internal error")
+	})
+
+	e.GET("/invalid", func(c echo.Context) error {
+		return resterr.NewValidationError(resterr.InvalidValue, "id", errors.New("invalid id"))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "client")
+		defer parent.End()
+
+		req := httptest.NewRequest(http.MethodGet, "/profiles/123", http.NoBody)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		spans := spanRecorder.Ended()
+		span := spans[len(spans)-1]
+
+		require.Equal(t, "GET /profiles/:id", span.Name())
+		require.Equal(t, trace.SpanKindServer, span.SpanKind())
+		require.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Equal(t, span.SpanContext().SpanID(), handlerSpanCtx.SpanID())
+		require.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusOK))
+		require.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", http.NoBody))
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		spans := spanRecorder.Ended()
+		span := spans[len(spans)-1]
+
+		require.Equal(t, "GET /fail", span.Name())
+		require.False(t, span.Parent().IsValid())
+		require.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+		require.Equal(t, codes.Error, span.Status().Code)
+	})
+	t.Run("validation error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/invalid", http.NoBody))
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		spans := spanRecorder.Ended()
+		span := spans[len(spans)-1]
+
+		require.Equal(t, "GET /invalid", span.Name())
+		require.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusBadRequest))
+		require.Equal(t, codes.Unset, span.Status().Code)
+	})
+}
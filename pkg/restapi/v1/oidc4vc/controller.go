@@ -5,33 +5,74 @@ SPDX-License-Identifier: Apache-2.0
 */
 
 //go:generate oapi-codegen --config=openapi.cfg.yaml ../../../../docs/v1/openapi.yaml
-//go:generate mockgen -destination controller_mocks_test.go -self_package mocks -package oidc4vc_test . StateStore,OAuth2Provider,IssuerInteractionClient
+//go:generate mockgen -destination controller_mocks_test.go -self_package mocks -package oidc4vc_test . StateStore,OAuth2Provider,IssuerInteractionClient,DPoPVerifier,ClientRegistrationService
 
 package oidc4vc
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/ory/fosite"
 	"github.com/samber/lo"
 	"golang.org/x/oauth2"
 
+	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/dpop"
 	"github.com/trustbloc/vcs/pkg/restapi/resterr"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/common"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/issuer"
 	apiutil "github.com/trustbloc/vcs/pkg/restapi/v1/util"
+	"github.com/trustbloc/vcs/pkg/service/clientregistration"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/oidc4vcstatestore"
 )
 
 const (
 	sessionOpStateKey = "opState"
+	// sessionDPoPJKTKey is a session key of JWK thumbprint of the DPoP key the access token is bound to.
+	sessionDPoPJKTKey = "dpopJKT"
+	// sessionCNonceKey is a session key of the nonce the wallet should put into proof of possession JWT.
+	sessionCNonceKey = "cNonce"
+	// sessionCredentialIssuerKey is a session key of the credential issuer identifier proof of possession JWT
+	// should be addressed to.
+	sessionCredentialIssuerKey = "credentialIssuer"
+	proofTypeJWT               = "jwt"
+	// proofMaxAge is the maximum age of proof of possession JWT.
+	proofMaxAge = 5 * time.Minute
+	// proofMaxClockSkew is the maximum time proof of possession JWT may be issued in the future.
+	proofMaxClockSkew = time.Minute
+	// grantTypeRefreshToken is a grant type of the token request that re-issues access token for the refresh token.
+	grantTypeRefreshToken = "refresh_token"
+
+	errorIssuancePending = "issuance_pending"
+	errorInvalidToken    = "invalid_token"
+	errorAccessDenied    = "access_denied"
+
+	errorInvalidRedirectURI          = "invalid_redirect_uri"
+	errorInvalidClientMetadata       = "invalid_client_metadata"
+	errorInvalidSoftwareStatement    = "invalid_software_statement"
+	errorUnapprovedSoftwareStatement = "unapproved_software_statement"
+	// clientConfigurationEndpointPath is a base path of the endpoint the client manages its registration with.
+	clientConfigurationEndpointPath = "/oidc/register/"
+
+	// deferredCredentialInterval is the time in seconds the wallet should wait before polling deferred
+	// credential endpoint again.
+	deferredCredentialInterval = 5
 )
 
+var logger = log.New("oidc4vc-rest")
+
 // StateStore stores authorization request/response state.
 type StateStore interface {
 	SaveAuthorizeState(
@@ -53,12 +94,42 @@ type OAuth2Provider fosite.OAuth2Provider
 // IssuerInteractionClient defines API client for interaction with issuer private API.
 type IssuerInteractionClient issuer.ClientInterface
 
+// DPoPVerifier issues DPoP nonces and verifies DPoP proofs sent by the wallet.
+type DPoPVerifier interface {
+	NewNonce() string
+	Verify(ctx context.Context, proof, method, requestURL, accessToken string) (string, error)
+}
+
+// ClientRegistrationService registers OAuth clients of the wallets and manages their registrations.
+type ClientRegistrationService interface {
+	Register(
+		ctx context.Context,
+		initialAccessToken string,
+		metadata *clientregistration.ClientMetadata,
+	) (*clientregistration.ClientInformation, error)
+	Get(ctx context.Context, clientID, registrationAccessToken string) (*clientregistration.ClientInformation, error)
+	Update(
+		ctx context.Context,
+		clientID, registrationAccessToken string,
+		metadata *clientregistration.ClientMetadata,
+	) (*clientregistration.ClientInformation, error)
+	Delete(ctx context.Context, clientID, registrationAccessToken string) error
+}
+
 // Config holds configuration options for Controller.
 type Config struct {
 	OAuth2Provider          OAuth2Provider
 	StateStore              StateStore
 	IssuerInteractionClient IssuerInteractionClient
 	IssuerVCSPublicHost     string
+	// JWTVerifier verifies signature of proof of possession JWT sent by the wallet to credential endpoints.
+	JWTVerifier jose.SignatureVerifier
+	// DPoPVerifier verifies DPoP proofs. Access tokens are not bound to the wallet key if not set.
+	DPoPVerifier DPoPVerifier
+	// DPoPRequired rejects token requests without DPoP proof and access tokens that are not DPoP-bound.
+	DPoPRequired bool
+	// ClientRegistrationService handles dynamic client registration requests.
+	ClientRegistrationService ClientRegistrationService
 }
 
 // Controller for OIDC4VC issuance API.
@@ -67,6 +138,10 @@ type Controller struct {
 	stateStore              StateStore
 	issuerInteractionClient IssuerInteractionClient
 	issuerVCSPublicHost     string
+	jwtVerifier             jose.SignatureVerifier
+	dpopVerifier            DPoPVerifier
+	dpopRequired            bool
+	clientRegistration      ClientRegistrationService
 }
 
 // NewController creates a new Controller instance.
@@ -76,6 +151,10 @@ func NewController(config *Config) *Controller {
 		stateStore:              config.StateStore,
 		issuerInteractionClient: config.IssuerInteractionClient,
 		issuerVCSPublicHost:     config.IssuerVCSPublicHost,
+		jwtVerifier:             config.JWTVerifier,
+		dpopVerifier:            config.DPoPVerifier,
+		dpopRequired:            config.DPoPRequired,
+		clientRegistration:      config.ClientRegistrationService,
 	}
 }
 
@@ -95,26 +174,15 @@ func (c *Controller) OidcPushedAuthorizationRequest(e echo.Context) error {
 		return resterr.NewFositeError(resterr.FositePARError, e, c.oauth2Provider, err).WithAuthorizeRequester(ar)
 	}
 
-	var ad common.AuthorizationDetails
-
-	if err = json.Unmarshal([]byte(par.AuthorizationDetails), &ad); err != nil {
-		return resterr.NewValidationError(resterr.InvalidValue, "authorization_details", err)
-	}
-
-	authorizationDetails, err := common.ValidateAuthorizationDetails(&ad)
+	ad, err := parseAuthorizationDetails(par.AuthorizationDetails)
 	if err != nil {
 		return err
 	}
 
 	r, err := c.issuerInteractionClient.PushAuthorizationDetails(ctx,
 		issuer.PushAuthorizationDetailsJSONRequestBody{
-			AuthorizationDetails: common.AuthorizationDetails{
-				CredentialType: authorizationDetails.CredentialType,
-				Format:         lo.ToPtr(string(authorizationDetails.Format)),
-				Locations:      lo.ToPtr(authorizationDetails.Locations),
-				Type:           authorizationDetails.Type,
-			},
-			OpState: par.OpState,
+			AuthorizationDetails: ad,
+			OpState:              par.OpState,
 		},
 	)
 	if err != nil {
@@ -153,16 +221,24 @@ func (c *Controller) OidcAuthorize(e echo.Context, params OidcAuthorizeParams) e
 		scope = append(scope, s)
 	}
 
+	var ad *[]common.AuthorizationDetails
+
+	// authorization details are optional, all offered credentials are authorized if not set
+	if params.AuthorizationDetails != nil {
+		details, parseErr := parseAuthorizationDetails(*params.AuthorizationDetails)
+		if parseErr != nil {
+			return parseErr
+		}
+
+		ad = &details
+	}
+
 	r, err := c.issuerInteractionClient.PrepareAuthorizationRequest(ctx,
 		issuer.PrepareAuthorizationRequestJSONRequestBody{
-			AuthorizationDetails: &common.AuthorizationDetails{
-				Type:           "openid_credential",
-				CredentialType: "PermanentResidentCard", // TODO: Set from the request.
-				Format:         lo.ToPtr("ldp_vc"),
-			},
-			OpState:      params.OpState,
-			ResponseType: params.ResponseType,
-			Scope:        lo.ToPtr(scope),
+			AuthorizationDetails: ad,
+			OpState:              params.OpState,
+			ResponseType:         params.ResponseType,
+			Scope:                lo.ToPtr(scope),
 		},
 	)
 	if err != nil {
@@ -265,23 +341,795 @@ func (c *Controller) OidcToken(e echo.Context) error {
 		return resterr.NewFositeError(resterr.FositeAccessError, e, c.oauth2Provider, err).WithAccessRequester(ar)
 	}
 
-	exchangeResp, err := c.issuerInteractionClient.ExchangeAuthorizationCodeRequest(
-		ctx,
-		issuer.ExchangeAuthorizationCodeRequestJSONRequestBody{
-			OpState: ar.GetSession().(*fosite.DefaultSession).Extra[sessionOpStateKey].(string),
-		},
-	)
+	jkt, err := c.verifyTokenRequestDPoP(e, ar)
 	if err != nil {
 		return err
 	}
-	_ = exchangeResp.Body.Close()
+
+	session := ar.GetSession().(*fosite.DefaultSession) //nolint:errcheck
+
+	if jkt != "" {
+		session.Extra[sessionDPoPJKTKey] = jkt
+	}
+
+	// access token is re-issued for the refresh token without new exchange of the authorization code
+	if !ar.GetGrantTypes().ExactOne(grantTypeRefreshToken) {
+		credentialIssuer, exchangeErr := c.exchangeAuthorizationCode(ctx, session.Extra[sessionOpStateKey].(string))
+		if exchangeErr != nil {
+			return exchangeErr
+		}
+
+		session.Extra[sessionCredentialIssuerKey] = credentialIssuer
+	}
+
+	cNonce := uuid.NewString()
+	session.Extra[sessionCNonceKey] = cNonce
 
 	resp, err := c.oauth2Provider.NewAccessResponse(ctx, ar)
 	if err != nil {
 		return resterr.NewFositeError(resterr.FositeAccessError, e, c.oauth2Provider, err).WithAccessRequester(ar)
 	}
 
+	// c_nonce is valid for the lifetime of the access token
+	resp.SetExtra("c_nonce", cNonce)
+
+	if expiresIn := resp.GetExtra("expires_in"); expiresIn != nil {
+		resp.SetExtra("c_nonce_expires_in", expiresIn)
+	}
+
+	if jkt != "" {
+		resp.SetTokenType(dpop.AuthScheme)
+
+		// fresh nonce lets the wallet create DPoP proof for credential request without extra round trip
+		c.setDPoPNonce(e)
+	}
+
 	c.oauth2Provider.WriteAccessResponse(ctx, e.Response().Writer, ar, resp)
 
 	return nil
 }
+
+// exchangeAuthorizationCode exchanges issuer authorization code of the transaction and returns identifier of
+// the credential issuer.
+func (c *Controller) exchangeAuthorizationCode(ctx context.Context, opState string) (string, error) {
+	resp, err := c.issuerInteractionClient.ExchangeAuthorizationCodeRequest(ctx,
+		issuer.ExchangeAuthorizationCodeRequestJSONRequestBody{
+			OpState: opState,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("exchange authorization code: status code %d", resp.StatusCode)
+	}
+
+	var result issuer.ExchangeAuthorizationCodeResponse
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode exchange authorization code response: %w", err)
+	}
+
+	return lo.FromPtr(result.CredentialIssuer), nil
+}
+
+// OidcIntrospect handles OIDC token introspection request (POST /oidc/introspect).
+func (c *Controller) OidcIntrospect(e echo.Context) error {
+	req := e.Request()
+	ctx := req.Context()
+
+	ir, err := c.oauth2Provider.NewIntrospectionRequest(ctx, req, new(fosite.DefaultSession))
+	if err != nil {
+		return resterr.NewFositeError(resterr.FositeIntrospectionError, e, c.oauth2Provider, err)
+	}
+
+	// fosite writes session extra to the response, so it is replaced with the claims that may be exposed
+	if ar := ir.GetAccessRequester(); ir.IsActive() && ar != nil {
+		if session, ok := ar.GetSession().(*fosite.DefaultSession); ok {
+			session.Extra = introspectionClaims(session.Extra)
+		}
+	}
+
+	c.oauth2Provider.WriteIntrospectionResponse(ctx, e.Response().Writer, ir)
+
+	return nil
+}
+
+// introspectionClaims returns extra claims of the introspection response. Session extra holds internal state of
+// the issuance and is not exposed, only the key binding of DPoP-bound access token is (RFC 9449, section 6.2).
+func introspectionClaims(extra map[string]interface{}) map[string]interface{} {
+	jkt, _ := extra[sessionDPoPJKTKey].(string)
+	if jkt == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"token_type": dpop.AuthScheme,
+		"cnf": map[string]interface{}{
+			"jkt": jkt,
+		},
+	}
+}
+
+// OidcRevoke handles OIDC token revocation request (POST /oidc/revoke).
+func (c *Controller) OidcRevoke(e echo.Context) error {
+	req := e.Request()
+	ctx := req.Context()
+
+	if err := c.oauth2Provider.NewRevocationRequest(ctx, req); err != nil {
+		return resterr.NewFositeError(resterr.FositeRevocationError, e, c.oauth2Provider, err)
+	}
+
+	c.oauth2Provider.WriteRevocationResponse(ctx, e.Response().Writer, nil)
+
+	return nil
+}
+
+// verifyTokenRequestDPoP verifies DPoP proof sent to the token endpoint and returns JWK thumbprint of the wallet key
+// the access token should be bound to. Empty thumbprint is returned if DPoP is not used.
+func (c *Controller) verifyTokenRequestDPoP(e echo.Context, ar fosite.AccessRequester) (string, error) {
+	proof := e.Request().Header.Get(dpop.HeaderName)
+
+	if c.dpopVerifier == nil || proof == "" {
+		if c.dpopRequired || refreshTokenDPoPJKT(ar) != "" {
+			return "", tokenDPoPError(e, c.oauth2Provider, ar, dpop.ErrorInvalidProof,
+				errors.New("DPoP proof is required"))
+		}
+
+		return "", nil
+	}
+
+	jkt, err := c.verifyDPoPProof(e, proof, "")
+	if err != nil {
+		if errors.Is(err, dpop.ErrUseNonce) {
+			return "", tokenDPoPError(e, c.oauth2Provider, ar, dpop.ErrorUseNonce, err)
+		}
+
+		if errors.Is(err, dpop.ErrInvalidProof) {
+			return "", tokenDPoPError(e, c.oauth2Provider, ar, dpop.ErrorInvalidProof, err)
+		}
+
+		return "", err
+	}
+
+	if boundJKT := refreshTokenDPoPJKT(ar); boundJKT != "" && boundJKT != jkt {
+		return "", tokenDPoPError(e, c.oauth2Provider, ar, dpop.ErrorInvalidProof,
+			errors.New("DPoP proof key does not match the key the refresh token is bound to"))
+	}
+
+	return jkt, nil
+}
+
+// refreshTokenDPoPJKT returns JWK thumbprint of the DPoP key the refresh token of the access request is bound to.
+// Empty thumbprint is returned for other grants and for refresh tokens that are not DPoP-bound.
+func refreshTokenDPoPJKT(ar fosite.AccessRequester) string {
+	if !ar.GetGrantTypes().ExactOne(grantTypeRefreshToken) {
+		return ""
+	}
+
+	session, ok := ar.GetSession().(*fosite.DefaultSession)
+	if !ok {
+		return ""
+	}
+
+	jkt, _ := session.Extra[sessionDPoPJKTKey].(string)
+
+	return jkt
+}
+
+// tokenDPoPError returns OAuth error with DPoP error code written by the token endpoint.
+func tokenDPoPError(e echo.Context, p OAuth2Provider, ar fosite.AccessRequester, code string, err error) error {
+	return resterr.NewFositeError(resterr.FositeAccessError, e, p, &fosite.RFC6749Error{
+		ErrorField:       code,
+		DescriptionField: err.Error(),
+		CodeField:        http.StatusBadRequest,
+	}).WithAccessRequester(ar)
+}
+
+// verifyDPoPProof verifies DPoP proof of the current request. A new nonce is passed to the wallet in DPoP-Nonce
+// header if the proof does not contain valid nonce.
+func (c *Controller) verifyDPoPProof(e echo.Context, proof, accessToken string) (string, error) {
+	req := e.Request()
+
+	jkt, err := c.dpopVerifier.Verify(req.Context(), proof, req.Method, c.issuerVCSPublicHost+req.URL.Path,
+		accessToken)
+	if err != nil {
+		if errors.Is(err, dpop.ErrUseNonce) {
+			c.setDPoPNonce(e)
+		} else if !errors.Is(err, dpop.ErrInvalidProof) {
+			return "", resterr.NewSystemError("DPoPVerifier", "Verify", err)
+		}
+
+		return "", err
+	}
+
+	return jkt, nil
+}
+
+func (c *Controller) setDPoPNonce(e echo.Context) {
+	e.Response().Header().Set(dpop.NonceHeaderName, c.dpopVerifier.NewNonce())
+}
+
+// OidcCredential handles OIDC credential request (POST /oidc/credential).
+func (c *Controller) OidcCredential(e echo.Context) error {
+	req := e.Request()
+	ctx := req.Context()
+
+	session, err := c.validateAccessToken(e)
+	if err != nil {
+		return err
+	}
+
+	var body CredentialRequest
+
+	if err = e.Bind(&body); err != nil {
+		return resterr.NewValidationError(resterr.InvalidValue, "body", err)
+	}
+
+	did, err := c.validateProof(&body.Proof, session)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.prepareCredential(ctx, session.opState, &body, did, false)
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, resp)
+}
+
+// OidcBatchCredential handles OIDC batch credential request (POST /oidc/batch_credential). All credential
+// requests are validated before the first credential is issued, and credentials are issued in the order of
+// the requests. If issuance fails after some credentials are issued, the issued ones are returned and the wallet
+// may request the rest again.
+func (c *Controller) OidcBatchCredential(e echo.Context) error {
+	req := e.Request()
+	ctx := req.Context()
+
+	session, err := c.validateAccessToken(e)
+	if err != nil {
+		return err
+	}
+
+	var body BatchCredentialRequest
+
+	if err = e.Bind(&body); err != nil {
+		return resterr.NewValidationError(resterr.InvalidValue, "body", err)
+	}
+
+	if len(body.CredentialRequests) == 0 {
+		return resterr.NewValidationError(resterr.InvalidValue, "credential_requests",
+			errors.New("at least one credential request is required"))
+	}
+
+	dids := make([]string, len(body.CredentialRequests))
+
+	for i := range body.CredentialRequests {
+		if dids[i], err = c.validateProof(&body.CredentialRequests[i].Proof, session); err != nil {
+			return err
+		}
+	}
+
+	for i := range body.CredentialRequests {
+		if _, err = c.prepareCredential(ctx, session.opState, &body.CredentialRequests[i], dids[i], true); err != nil {
+			return err
+		}
+	}
+
+	result := BatchCredentialResponse{
+		CredentialResponses: make([]CredentialResponse, 0, len(body.CredentialRequests)),
+	}
+
+	for i := range body.CredentialRequests {
+		resp, prepareErr := c.prepareCredential(ctx, session.opState, &body.CredentialRequests[i], dids[i], false)
+		if prepareErr != nil {
+			if i == 0 {
+				return prepareErr
+			}
+
+			// credentials of the previous requests are already issued and can't be issued again
+			logger.WithContext(ctx).Warn("Batch credential request is issued partially",
+				log.WithTotalRequests(len(body.CredentialRequests)), log.WithResponses(i), log.WithError(prepareErr))
+
+			break
+		}
+
+		result.CredentialResponses = append(result.CredentialResponses, *resp)
+	}
+
+	return e.JSON(http.StatusOK, result)
+}
+
+// accessTokenSession contains issuance transaction data the access token is bound to.
+type accessTokenSession struct {
+	opState          string
+	cNonce           string
+	credentialIssuer string
+}
+
+// validateAccessToken validates access token issued by the token endpoint and returns data of the issuance
+// transaction the token is bound to. DPoP proof is verified if the token is bound to the wallet key.
+func (c *Controller) validateAccessToken(e echo.Context) (*accessTokenSession, error) {
+	req := e.Request()
+
+	scheme, token := accessTokenFromRequest(req)
+	if token == "" {
+		return nil, resterr.NewUnauthorizedError(errors.New("missing access token"))
+	}
+
+	_, ar, err := c.oauth2Provider.IntrospectToken(req.Context(), token, fosite.AccessToken,
+		new(fosite.DefaultSession))
+	if err != nil {
+		return nil, resterr.NewUnauthorizedError(fmt.Errorf("invalid access token: %w", err))
+	}
+
+	session, ok := ar.GetSession().(*fosite.DefaultSession)
+	if !ok {
+		return nil, resterr.NewUnauthorizedError(errors.New("invalid access token session"))
+	}
+
+	opState, ok := session.Extra[sessionOpStateKey].(string)
+	if !ok || opState == "" {
+		return nil, resterr.NewUnauthorizedError(errors.New("access token is not bound to issuance transaction"))
+	}
+
+	if err = c.validateAccessTokenBinding(e, scheme, token, session); err != nil {
+		return nil, err
+	}
+
+	cNonce, _ := session.Extra[sessionCNonceKey].(string)
+	credentialIssuer, _ := session.Extra[sessionCredentialIssuerKey].(string)
+
+	return &accessTokenSession{
+		opState:          opState,
+		cNonce:           cNonce,
+		credentialIssuer: credentialIssuer,
+	}, nil
+}
+
+// validateAccessTokenBinding checks that DPoP-bound access token is presented with DPoP proof signed by
+// the key the token is bound to.
+func (c *Controller) validateAccessTokenBinding(
+	e echo.Context,
+	scheme, token string,
+	session *fosite.DefaultSession,
+) error {
+	jkt, _ := session.Extra[sessionDPoPJKTKey].(string)
+	if jkt == "" {
+		if c.dpopRequired {
+			return dpopError(e, dpop.ErrorInvalidProof, errors.New("access token is not bound to DPoP key"))
+		}
+
+		return nil
+	}
+
+	if !strings.EqualFold(scheme, dpop.AuthScheme) {
+		return dpopError(e, dpop.ErrorInvalidProof,
+			errors.New("DPoP-bound access token must be passed with DPoP authorization scheme"))
+	}
+
+	proof := e.Request().Header.Get(dpop.HeaderName)
+	if proof == "" || c.dpopVerifier == nil {
+		return dpopError(e, dpop.ErrorInvalidProof, errors.New("missing DPoP proof"))
+	}
+
+	proofJKT, err := c.verifyDPoPProof(e, proof, token)
+	if err != nil {
+		if errors.Is(err, dpop.ErrUseNonce) {
+			return dpopError(e, dpop.ErrorUseNonce, err)
+		}
+
+		if errors.Is(err, dpop.ErrInvalidProof) {
+			return dpopError(e, dpop.ErrorInvalidProof, err)
+		}
+
+		return err
+	}
+
+	if proofJKT != jkt {
+		return dpopError(e, dpop.ErrorInvalidProof, errors.New("DPoP proof key does not match access token binding"))
+	}
+
+	return nil
+}
+
+// dpopError sets WWW-Authenticate challenge with DPoP error code and returns unauthorized error.
+func dpopError(e echo.Context, code string, err error) error {
+	e.Response().Header().Set(echo.HeaderWWWAuthenticate, fmt.Sprintf("%s error=%q", dpop.AuthScheme, code))
+
+	return resterr.NewUnauthorizedError(err)
+}
+
+// accessTokenFromRequest returns authorization scheme and access token passed in Authorization header
+// with either Bearer or DPoP scheme.
+func accessTokenFromRequest(req *http.Request) (string, string) {
+	scheme, token, ok := strings.Cut(req.Header.Get(echo.HeaderAuthorization), " ")
+	if ok && (strings.EqualFold(scheme, "bearer") || strings.EqualFold(scheme, dpop.AuthScheme)) {
+		return scheme, token
+	}
+
+	return "bearer", fosite.AccessTokenFromRequest(req)
+}
+
+// prepareCredential requests issuer to sign credential of the requested type for the holder with the given DID.
+// If validateOnly is set, issuer only checks that the credential can be issued.
+func (c *Controller) prepareCredential(
+	ctx context.Context,
+	opState string,
+	req *CredentialRequest,
+	did string,
+	validateOnly bool,
+) (*CredentialResponse, error) {
+	r, err := c.issuerInteractionClient.PrepareCredential(ctx,
+		issuer.PrepareCredentialJSONRequestBody{
+			OpState:        opState,
+			CredentialType: req.Type,
+			Format:         req.Format,
+			Did:            did,
+			ValidateOnly:   lo.ToPtr(validateOnly),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("prepare credential: %w", err)
+	}
+
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return nil, parseInteractionError(r)
+	}
+
+	var result issuer.PrepareCredentialResult
+
+	if err = json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode prepare credential result: %w", err)
+	}
+
+	return &CredentialResponse{
+		Format:          result.Format,
+		Credential:      result.Credential,
+		AcceptanceToken: result.AcceptanceToken,
+	}, nil
+}
+
+// OidcDeferredCredential handles OIDC deferred credential request (POST /oidc/deferred_credential).
+func (c *Controller) OidcDeferredCredential(e echo.Context) error {
+	req := e.Request()
+
+	acceptanceToken := fosite.AccessTokenFromRequest(req)
+	if acceptanceToken == "" {
+		return e.JSON(http.StatusUnauthorized, &CredentialErrorResponse{
+			Error:            errorInvalidToken,
+			ErrorDescription: lo.ToPtr("missing acceptance token"),
+		})
+	}
+
+	r, err := c.issuerInteractionClient.PrepareDeferredCredential(req.Context(),
+		issuer.PrepareDeferredCredentialJSONRequestBody{
+			AcceptanceToken: acceptanceToken,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("prepare deferred credential: %w", err)
+	}
+
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case http.StatusOK:
+	case http.StatusPreconditionFailed:
+		return e.JSON(http.StatusBadRequest, &CredentialErrorResponse{
+			Error:    errorIssuancePending,
+			Interval: lo.ToPtr(deferredCredentialInterval),
+		})
+	case http.StatusNotFound:
+		// acceptance token is unknown, already used or the transaction has expired
+		return e.JSON(http.StatusUnauthorized, &CredentialErrorResponse{
+			Error:            errorInvalidToken,
+			ErrorDescription: lo.ToPtr("invalid acceptance token"),
+		})
+	default:
+		return fmt.Errorf("prepare deferred credential: status code %d", r.StatusCode)
+	}
+
+	var result issuer.PrepareCredentialResult
+
+	if err = json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode prepare deferred credential result: %w", err)
+	}
+
+	return e.JSON(http.StatusOK, &CredentialResponse{
+		Format:     result.Format,
+		Credential: result.Credential,
+	})
+}
+
+// OidcRegisterClient handles OIDC dynamic client registration request (POST /oidc/register).
+func (c *Controller) OidcRegisterClient(e echo.Context) error {
+	req := e.Request()
+
+	var body ClientRegistrationRequest
+
+	if err := e.Bind(&body); err != nil {
+		return clientRegistrationError(e,
+			fmt.Errorf("%w: %s", clientregistration.ErrInvalidClientMetadata, err.Error()))
+	}
+
+	metadata := &clientregistration.ClientMetadata{
+		RedirectURIs:            lo.FromPtr(body.RedirectUris),
+		GrantTypes:              lo.FromPtr(body.GrantTypes),
+		ResponseTypes:           lo.FromPtr(body.ResponseTypes),
+		TokenEndpointAuthMethod: lo.FromPtr(body.TokenEndpointAuthMethod),
+		Name:                    lo.FromPtr(body.ClientName),
+		URI:                     lo.FromPtr(body.ClientUri),
+		Scope:                   lo.FromPtr(body.Scope),
+		Contacts:                lo.FromPtr(body.Contacts),
+		SoftwareID:              lo.FromPtr(body.SoftwareId),
+		SoftwareVersion:         lo.FromPtr(body.SoftwareVersion),
+		SoftwareStatement:       lo.FromPtr(body.SoftwareStatement),
+	}
+
+	info, err := c.clientRegistration.Register(req.Context(), fosite.AccessTokenFromRequest(req), metadata)
+	if err != nil {
+		return clientRegistrationError(e, err)
+	}
+
+	return c.writeClientInformation(e, http.StatusCreated, info)
+}
+
+// OidcGetClient handles OIDC client read request (GET /oidc/register/{clientID}).
+func (c *Controller) OidcGetClient(e echo.Context, clientID string) error {
+	req := e.Request()
+
+	info, err := c.clientRegistration.Get(req.Context(), clientID, fosite.AccessTokenFromRequest(req))
+	if err != nil {
+		return clientRegistrationError(e, err)
+	}
+
+	return c.writeClientInformation(e, http.StatusOK, info)
+}
+
+// OidcUpdateClient handles OIDC client update request (PUT /oidc/register/{clientID}).
+func (c *Controller) OidcUpdateClient(e echo.Context, clientID string) error {
+	req := e.Request()
+
+	var body ClientUpdateRequest
+
+	if err := e.Bind(&body); err != nil {
+		return clientRegistrationError(e,
+			fmt.Errorf("%w: %s", clientregistration.ErrInvalidClientMetadata, err.Error()))
+	}
+
+	if body.ClientId != clientID {
+		return clientRegistrationError(e,
+			fmt.Errorf("%w: client_id does not match the client", clientregistration.ErrInvalidClientMetadata))
+	}
+
+	metadata := &clientregistration.ClientMetadata{
+		RedirectURIs:            lo.FromPtr(body.RedirectUris),
+		GrantTypes:              lo.FromPtr(body.GrantTypes),
+		ResponseTypes:           lo.FromPtr(body.ResponseTypes),
+		TokenEndpointAuthMethod: lo.FromPtr(body.TokenEndpointAuthMethod),
+		Name:                    lo.FromPtr(body.ClientName),
+		URI:                     lo.FromPtr(body.ClientUri),
+		Scope:                   lo.FromPtr(body.Scope),
+		Contacts:                lo.FromPtr(body.Contacts),
+		SoftwareID:              lo.FromPtr(body.SoftwareId),
+		SoftwareVersion:         lo.FromPtr(body.SoftwareVersion),
+		SoftwareStatement:       lo.FromPtr(body.SoftwareStatement),
+	}
+
+	info, err := c.clientRegistration.Update(req.Context(), clientID, fosite.AccessTokenFromRequest(req), metadata)
+	if err != nil {
+		return clientRegistrationError(e, err)
+	}
+
+	return c.writeClientInformation(e, http.StatusOK, info)
+}
+
+// OidcDeleteClient handles OIDC client delete request (DELETE /oidc/register/{clientID}).
+func (c *Controller) OidcDeleteClient(e echo.Context, clientID string) error {
+	req := e.Request()
+
+	if err := c.clientRegistration.Delete(req.Context(), clientID, fosite.AccessTokenFromRequest(req)); err != nil {
+		return clientRegistrationError(e, err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// writeClientInformation writes client information response (RFC 7591, section 3.2.1). The response contains
+// credentials of the client, so it must not be cached.
+func (c *Controller) writeClientInformation(
+	e echo.Context,
+	status int,
+	info *clientregistration.ClientInformation,
+) error {
+	client := info.Client
+
+	resp := &ClientRegistrationResponse{
+		ClientId:                client.ID,
+		ClientIdIssuedAt:        lo.ToPtr(int(client.CreatedAt.Unix())),
+		ClientName:              strPtr(client.Name),
+		ClientUri:               strPtr(client.URI),
+		GrantTypes:              lo.ToPtr(client.GrantTypes),
+		RedirectUris:            lo.ToPtr(client.RedirectURIs),
+		ResponseTypes:           lo.ToPtr(client.ResponseTypes),
+		TokenEndpointAuthMethod: strPtr(client.TokenEndpointAuthMethod),
+		Scope:                   strPtr(strings.Join(client.Scopes, " ")),
+		SoftwareId:              strPtr(client.SoftwareID),
+		SoftwareVersion:         strPtr(client.SoftwareVersion),
+		SoftwareStatement:       strPtr(info.SoftwareStatement),
+		RegistrationAccessToken: strPtr(info.RegistrationAccessToken),
+		RegistrationClientUri:   lo.ToPtr(c.issuerVCSPublicHost + clientConfigurationEndpointPath + client.ID),
+	}
+
+	if len(client.Contacts) > 0 {
+		resp.Contacts = lo.ToPtr(client.Contacts)
+	}
+
+	if info.Secret != "" {
+		resp.ClientSecret = lo.ToPtr(info.Secret)
+		resp.ClientSecretExpiresAt = lo.ToPtr(0)
+	}
+
+	e.Response().Header().Set("Cache-Control", "no-store")
+
+	return e.JSON(status, resp)
+}
+
+// clientRegistrationError writes client registration error response (RFC 7591, section 3.2.2). Invalid initial
+// and registration access tokens are reported as invalid_token with 401 status, disabled registration is reported
+// as access_denied with 403 status.
+func clientRegistrationError(e echo.Context, err error) error {
+	status := http.StatusBadRequest
+
+	var code string
+
+	switch {
+	case errors.Is(err, clientregistration.ErrInvalidRedirectURI):
+		code = errorInvalidRedirectURI
+	case errors.Is(err, clientregistration.ErrInvalidClientMetadata):
+		code = errorInvalidClientMetadata
+	case errors.Is(err, clientregistration.ErrInvalidSoftwareStatement):
+		code = errorInvalidSoftwareStatement
+	case errors.Is(err, clientregistration.ErrUnapprovedSoftwareStatement):
+		code = errorUnapprovedSoftwareStatement
+	case errors.Is(err, clientregistration.ErrInvalidInitialAccessToken),
+		errors.Is(err, clientregistration.ErrInvalidRegistrationAccessToken):
+		status = http.StatusUnauthorized
+		code = errorInvalidToken
+	case errors.Is(err, clientregistration.ErrRegistrationDisabled):
+		status = http.StatusForbidden
+		code = errorAccessDenied
+	default:
+		return err
+	}
+
+	return e.JSON(status, &ClientRegistrationErrorResponse{
+		Error:            code,
+		ErrorDescription: lo.ToPtr(err.Error()),
+	})
+}
+
+// proofClaims are claims of proof of possession JWT.
+type proofClaims struct {
+	*jwt.Claims
+
+	Nonce string `json:"nonce"`
+}
+
+// validateProof verifies proof of possession JWT and returns DID of the holder taken from kid header. The proof
+// should be addressed to the credential issuer, be recently issued and contain c_nonce issued with the access token.
+func (c *Controller) validateProof(proof *JWTProof, session *accessTokenSession) (string, error) {
+	if proof.ProofType != proofTypeJWT {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.proof_type",
+			fmt.Errorf("proof type should be '%s'", proofTypeJWT))
+	}
+
+	token, err := jwt.Parse(proof.Jwt, jwt.WithSignatureVerifier(c.jwtVerifier))
+	if err != nil {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.jwt", err)
+	}
+
+	kid, _ := token.Headers.KeyID()
+
+	did := strings.Split(kid, "#")[0]
+	if !strings.HasPrefix(did, "did:") {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.jwt",
+			errors.New("kid header should be a DID URL"))
+	}
+
+	var claims proofClaims
+
+	if err = token.DecodeClaims(&claims); err != nil || claims.Claims == nil {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.jwt",
+			errors.New("invalid proof claims"))
+	}
+
+	if session.credentialIssuer == "" || !claims.Audience.Contains(session.credentialIssuer) {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.jwt.aud",
+			errors.New("proof should be addressed to the credential issuer"))
+	}
+
+	if claims.IssuedAt == nil {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.jwt.iat", errors.New("iat is required"))
+	}
+
+	now := time.Now()
+
+	if iat := claims.IssuedAt.Time(); iat.Before(now.Add(-proofMaxAge)) || iat.After(now.Add(proofMaxClockSkew)) {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.jwt.iat",
+			errors.New("proof is expired or issued in the future"))
+	}
+
+	if session.cNonce == "" || claims.Nonce != session.cNonce {
+		return "", resterr.NewValidationError(resterr.InvalidValue, "proof.jwt.nonce",
+			errors.New("proof should contain c_nonce issued with the access token"))
+	}
+
+	return did, nil
+}
+
+// parseInteractionError passes validation errors returned by issuer interaction API to the wallet.
+func parseInteractionError(r *http.Response) error {
+	if r.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("prepare credential: status code %d", r.StatusCode)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("prepare credential: status code %d", r.StatusCode)
+	}
+
+	var e struct {
+		IncorrectValue string `json:"incorrectValue"`
+		Message        string `json:"message"`
+	}
+
+	if err = json.Unmarshal(body, &e); err != nil {
+		return fmt.Errorf("prepare credential: status code %d", r.StatusCode)
+	}
+
+	return resterr.NewValidationError(resterr.InvalidValue, e.IncorrectValue, errors.New(e.Message))
+}
+
+// parseAuthorizationDetails parses authorization_details parameter. The parameter is a JSON array, a single object
+// is accepted as well.
+func parseAuthorizationDetails(s string) ([]common.AuthorizationDetails, error) {
+	var ad []common.AuthorizationDetails
+
+	if strings.HasPrefix(strings.TrimSpace(s), "[") {
+		if err := json.Unmarshal([]byte(s), &ad); err != nil {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "authorization_details", err)
+		}
+	} else {
+		var d common.AuthorizationDetails
+
+		if err := json.Unmarshal([]byte(s), &d); err != nil {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "authorization_details", err)
+		}
+
+		ad = append(ad, d)
+	}
+
+	for i := range ad {
+		if _, err := common.ValidateAuthorizationDetails(&ad[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return ad, nil
+}
+
+func strPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
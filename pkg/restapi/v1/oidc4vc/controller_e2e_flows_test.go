@@ -100,6 +100,7 @@ func TestAuthorizeCodeGrantFlow(t *testing.T) {
 		compose.OAuth2AuthorizeExplicitFactory,
 		compose.OAuth2PKCEFactory,
 		compose.PushedAuthorizeHandlerFactory,
+		compose.OAuth2TokenIntrospectionFactory,
 	)
 
 	controller := oidc4vc.NewController(&oidc4vc.Config{
@@ -147,6 +148,8 @@ func TestAuthorizeCodeGrantFlow(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, token)
 	require.NotEmpty(t, token.AccessToken)
+	require.NotEmpty(t, token.Extra("c_nonce"))
+	require.Equal(t, token.Extra("expires_in"), token.Extra("c_nonce_expires_in"))
 }
 
 func mockIssuerInteractionClient(
@@ -201,7 +204,7 @@ func mockIssuerInteractionClient(
 		issuer.ExchangeAuthorizationCodeRequestJSONRequestBody{
 			OpState: opState,
 		},
-	).Return(&http.Response{Body: io.NopCloser(bytes.NewBuffer(nil))}, nil)
+	).Return(exchangeAuthorizationCodeResponse(), nil)
 
 	return client
 }
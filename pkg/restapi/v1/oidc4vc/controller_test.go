@@ -9,28 +9,42 @@ package oidc4vc_test
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/ory/fosite"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/trustbloc/vcs/pkg/dpop"
+	"github.com/trustbloc/vcs/pkg/restapi/resterr"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/issuer"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/oidc4vc"
+	"github.com/trustbloc/vcs/pkg/service/clientregistration"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb/oidc4vcstatestore"
 )
 
+const (
+	credentialIssuer = "https://issuer.example.com/issuer/profiles/profileID"
+	cNonce           = "c-nonce"
+)
+
 //nolint:lll
 func TestController_OidcPushedAuthorizationRequest(t *testing.T) {
 	var (
@@ -66,6 +80,38 @@ func TestController_OidcPushedAuthorizationRequest(t *testing.T) {
 				require.Equal(t, http.StatusOK, rec.Code)
 			},
 		},
+		{
+			name: "success with multiple authorization details",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewPushedAuthorizeRequest(gomock.Any(), gomock.Any()).Return(&fosite.AuthorizeRequest{}, nil)
+				mockOAuthProvider.EXPECT().NewPushedAuthorizeResponse(gomock.Any(), gomock.Any(), gomock.Any()).Return(&fosite.PushedAuthorizeResponse{}, nil)
+				mockOAuthProvider.EXPECT().WritePushedAuthorizeResponse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+				mockInteractionClient.EXPECT().PushAuthorizationDetails(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(
+						ctx context.Context,
+						req issuer.PushAuthorizationDetailsJSONRequestBody,
+						reqEditors ...issuer.RequestEditorFn,
+					) (*http.Response, error) {
+						require.Len(t, req.AuthorizationDetails, 2)
+						assert.Equal(t, "UniversityDegreeCredential", req.AuthorizationDetails[0].CredentialType)
+						assert.Equal(t, "DriversLicense", req.AuthorizationDetails[1].CredentialType)
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(bytes.NewBuffer(nil)),
+						}, nil
+					})
+
+				q = url.Values{}
+				q.Add("op_state", "opState")
+				q.Add("authorization_details", `[{"type":"openid_credential","credential_type":"UniversityDegreeCredential","format":"ldp_vc"},{"type":"openid_credential","credential_type":"DriversLicense","format":"jwt_vc"}]`)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
 		{
 			name: "invalid pushed authorize request",
 			setup: func() {
@@ -245,6 +291,62 @@ func TestController_OidcAuthorize(t *testing.T) {
 				require.NotEmpty(t, rec.Header().Get("Location"))
 			},
 		},
+		{
+			name: "success with authorization details",
+			setup: func() {
+				params = oidc4vc.OidcAuthorizeParams{
+					ResponseType:         "code",
+					OpState:              "opState",
+					AuthorizationDetails: lo.ToPtr(`[{"type":"openid_credential","credential_type":"DriversLicense","format":"jwt_vc"}]`),
+				}
+
+				mockOAuthProvider.EXPECT().NewAuthorizeRequest(gomock.Any(), gomock.Any()).Return(&fosite.AuthorizeRequest{}, nil)
+				mockOAuthProvider.EXPECT().NewAuthorizeResponse(gomock.Any(), gomock.Any(), gomock.Any()).Return(&fosite.AuthorizeResponse{}, nil)
+
+				b, err := json.Marshal(&issuer.PrepareClaimDataAuthorizationResponse{
+					AuthorizationRequest: issuer.OAuthParameters{},
+				})
+				require.NoError(t, err)
+
+				mockInteractionClient.EXPECT().PrepareAuthorizationRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						ctx context.Context,
+						req issuer.PrepareAuthorizationRequestJSONRequestBody,
+						reqEditors ...issuer.RequestEditorFn,
+					) (*http.Response, error) {
+						require.NotNil(t, req.AuthorizationDetails)
+						require.Len(t, *req.AuthorizationDetails, 1)
+						assert.Equal(t, "DriversLicense", (*req.AuthorizationDetails)[0].CredentialType)
+						assert.Equal(t, lo.ToPtr("jwt_vc"), (*req.AuthorizationDetails)[0].Format)
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(bytes.NewBuffer(b)),
+						}, nil
+					})
+
+				mockStateStore.EXPECT().SaveAuthorizeState(gomock.Any(), params.OpState, gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusSeeOther, rec.Code)
+			},
+		},
+		{
+			name: "invalid authorization details",
+			setup: func() {
+				params = oidc4vc.OidcAuthorizeParams{
+					ResponseType:         "code",
+					OpState:              "opState",
+					AuthorizationDetails: lo.ToPtr(`{"type":"invalid","credential_type":"DriversLicense"}`),
+				}
+
+				mockOAuthProvider.EXPECT().NewAuthorizeRequest(gomock.Any(), gomock.Any()).Return(&fosite.AuthorizeRequest{}, nil)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "type should be 'openid_credential'")
+			},
+		},
 		{
 			name: "invalid authorize request",
 			setup: func() {
@@ -590,10 +692,51 @@ func TestController_OidcToken(t *testing.T) {
 				mockInteractionClient.EXPECT().ExchangeAuthorizationCodeRequest(gomock.Any(),
 					issuer.ExchangeAuthorizationCodeRequestJSONRequestBody{
 						OpState: opState,
-					}).Return(&http.Response{Body: io.NopCloser(bytes.NewBuffer(nil))}, nil)
+					}).Return(exchangeAuthorizationCodeResponse(), nil)
+
+				mockOAuthProvider.EXPECT().NewAccessResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, ar fosite.AccessRequester) (fosite.AccessResponder, error) {
+						extra := ar.GetSession().(*fosite.DefaultSession).Extra
+
+						require.Equal(t, credentialIssuer, extra["credentialIssuer"])
+						require.NotEmpty(t, extra["cNonce"])
+
+						resp := fosite.NewAccessResponse()
+						resp.SetExpiresIn(time.Hour)
+
+						return resp, nil
+					})
+
+				mockOAuthProvider.EXPECT().WriteAccessResponse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(ctx context.Context, rw http.ResponseWriter, ar fosite.AccessRequester, resp fosite.AccessResponder) {
+						require.Equal(t, ar.GetSession().(*fosite.DefaultSession).Extra["cNonce"], resp.GetExtra("c_nonce"))
+						require.Equal(t, int64(3600), resp.GetExtra("c_nonce_expires_in"))
+					})
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "success refresh token",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewAccessRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					&fosite.AccessRequest{
+						GrantTypes: fosite.Arguments{"refresh_token"},
+						Request: fosite.Request{
+							Session: &fosite.DefaultSession{
+								Extra: map[string]interface{}{
+									"opState": uuid.NewString(),
+								},
+							},
+						},
+					}, nil)
+
+				mockInteractionClient.EXPECT().ExchangeAuthorizationCodeRequest(gomock.Any(), gomock.Any()).Times(0)
 
 				mockOAuthProvider.EXPECT().NewAccessResponse(gomock.Any(), gomock.Any()).Return(
-					&fosite.AccessResponse{}, nil)
+					fosite.NewAccessResponse(), nil)
 
 				mockOAuthProvider.EXPECT().WriteAccessResponse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 			},
@@ -627,7 +770,7 @@ func TestController_OidcToken(t *testing.T) {
 					}, nil)
 
 				mockInteractionClient.EXPECT().ExchangeAuthorizationCodeRequest(gomock.Any(), gomock.Any()).
-					Return(&http.Response{Body: io.NopCloser(bytes.NewBuffer(nil))}, nil)
+					Return(exchangeAuthorizationCodeResponse(), nil)
 
 				mockOAuthProvider.EXPECT().NewAccessResponse(gomock.Any(), gomock.Any()).Return(
 					nil, errors.New("new access response error"))
@@ -657,6 +800,30 @@ func TestController_OidcToken(t *testing.T) {
 				require.ErrorContains(t, err, "can not exchange token")
 			},
 		},
+		{
+			name: "invalid status code for exchange token",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewAccessRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					&fosite.AccessRequest{
+						Request: fosite.Request{
+							Session: &fosite.DefaultSession{
+								Extra: map[string]interface{}{
+									"opState": "1234",
+								},
+							},
+						},
+					}, nil)
+
+				mockInteractionClient.EXPECT().ExchangeAuthorizationCodeRequest(gomock.Any(), gomock.Any()).
+					Return(&http.Response{
+						StatusCode: http.StatusBadRequest,
+						Body:       io.NopCloser(bytes.NewBuffer(nil)),
+					}, nil)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "exchange authorization code: status code 400")
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -677,3 +844,1674 @@ func TestController_OidcToken(t *testing.T) {
 		})
 	}
 }
+
+//nolint:lll
+func TestController_OidcCredential(t *testing.T) {
+	var (
+		mockOAuthProvider     = NewMockOAuth2Provider(gomock.NewController(t))
+		mockInteractionClient = NewMockIssuerInteractionClient(gomock.NewController(t))
+		body                  string
+		authorization         string
+	)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	verifier, err := jwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	proof := generateProof(t, "did:example:holder#key1", privKey)
+
+	expectIntrospect := func(opState string) {
+		mockOAuthProvider.EXPECT().IntrospectToken(gomock.Any(), "access-token", fosite.AccessToken, gomock.Any()).Return(
+			fosite.AccessToken,
+			&fosite.AccessRequest{
+				Request: fosite.Request{
+					Session: &fosite.DefaultSession{
+						Extra: map[string]interface{}{
+							"opState":          opState,
+							"cNonce":           cNonce,
+							"credentialIssuer": credentialIssuer,
+						},
+					},
+				},
+			}, nil)
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success",
+			setup: func() {
+				expectIntrospect("opState")
+
+				b, marshalErr := json.Marshal(&issuer.PrepareCredentialResult{
+					Format:     "jwt_vc",
+					Credential: lo.ToPtr[interface{}]("signed-credential"),
+				})
+				require.NoError(t, marshalErr)
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(),
+					issuer.PrepareCredentialJSONRequestBody{
+						OpState:        "opState",
+						CredentialType: "DriversLicense",
+						Format:         lo.ToPtr("jwt_vc"),
+						Did:            "did:example:holder",
+						ValidateOnly:   lo.ToPtr(false),
+					}).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(b)),
+				}, nil)
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","format":"jwt_vc","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var resp oidc4vc.CredentialResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Equal(t, "jwt_vc", resp.Format)
+				require.Equal(t, "signed-credential", *resp.Credential)
+			},
+		},
+		{
+			name: "success with deferred issuance",
+			setup: func() {
+				expectIntrospect("opState")
+
+				b, marshalErr := json.Marshal(&issuer.PrepareCredentialResult{
+					Format:          "jwt_vc",
+					AcceptanceToken: lo.ToPtr("acceptance-token"),
+				})
+				require.NoError(t, marshalErr)
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(b)),
+				}, nil)
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var resp oidc4vc.CredentialResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Equal(t, lo.ToPtr("acceptance-token"), resp.AcceptanceToken)
+				require.Nil(t, resp.Credential)
+			},
+		},
+		{
+			name: "missing access token",
+			setup: func() {
+				authorization = ""
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "missing access token")
+			},
+		},
+		{
+			name: "invalid access token",
+			setup: func() {
+				mockOAuthProvider.EXPECT().IntrospectToken(gomock.Any(), "access-token", fosite.AccessToken, gomock.Any()).Return(
+					fosite.AccessToken, nil, fosite.ErrTokenExpired)
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid access token")
+			},
+		},
+		{
+			name: "access token is not bound to transaction",
+			setup: func() {
+				expectIntrospect("")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "access token is not bound to issuance transaction")
+			},
+		},
+		{
+			name: "invalid proof type",
+			setup: func() {
+				expectIntrospect("opState")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"cwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "proof type should be 'jwt'")
+			},
+		},
+		{
+			name: "invalid proof signature",
+			setup: func() {
+				expectIntrospect("opState")
+
+				_, otherKey, keyErr := ed25519.GenerateKey(rand.Reader)
+				require.NoError(t, keyErr)
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+					generateProof(t, "did:example:holder#key1", otherKey) + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[proof.jwt]")
+			},
+		},
+		{
+			name: "proof kid is not a DID URL",
+			setup: func() {
+				expectIntrospect("opState")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+					generateProof(t, "key1", privKey) + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "kid header should be a DID URL")
+			},
+		},
+		{
+			name: "proof is not addressed to credential issuer",
+			setup: func() {
+				expectIntrospect("opState")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+					generateProofWithClaims(t, "did:example:holder#key1", privKey, map[string]interface{}{
+						"aud":   "https://issuer.example.com/issuer/profiles/otherProfileID",
+						"iat":   time.Now().Unix(),
+						"nonce": cNonce,
+					}) + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[proof.jwt.aud]")
+			},
+		},
+		{
+			name: "proof without iat",
+			setup: func() {
+				expectIntrospect("opState")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+					generateProofWithClaims(t, "did:example:holder#key1", privKey, map[string]interface{}{
+						"aud":   credentialIssuer,
+						"nonce": cNonce,
+					}) + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[proof.jwt.iat]: iat is required")
+			},
+		},
+		{
+			name: "proof is expired",
+			setup: func() {
+				expectIntrospect("opState")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+					generateProofWithClaims(t, "did:example:holder#key1", privKey, map[string]interface{}{
+						"aud":   credentialIssuer,
+						"iat":   time.Now().Add(-time.Hour).Unix(),
+						"nonce": cNonce,
+					}) + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "proof is expired or issued in the future")
+			},
+		},
+		{
+			name: "proof is issued in the future",
+			setup: func() {
+				expectIntrospect("opState")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+					generateProofWithClaims(t, "did:example:holder#key1", privKey, map[string]interface{}{
+						"aud":   credentialIssuer,
+						"iat":   time.Now().Add(time.Hour).Unix(),
+						"nonce": cNonce,
+					}) + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "proof is expired or issued in the future")
+			},
+		},
+		{
+			name: "proof with invalid nonce",
+			setup: func() {
+				expectIntrospect("opState")
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+					generateProofWithClaims(t, "did:example:holder#key1", privKey, map[string]interface{}{
+						"aud":   credentialIssuer,
+						"iat":   time.Now().Unix(),
+						"nonce": "other-nonce",
+					}) + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[proof.jwt.nonce]")
+			},
+		},
+		{
+			name: "fail to prepare credential",
+			setup: func() {
+				expectIntrospect("opState")
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Return(
+					nil, errors.New("prepare credential error"))
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "prepare credential error")
+			},
+		},
+		{
+			name: "credential type is not offered",
+			setup: func() {
+				expectIntrospect("opState")
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Return(
+					&http.Response{
+						StatusCode: http.StatusBadRequest,
+						Body: io.NopCloser(bytes.NewBufferString(
+							`{"code":"invalid-value","incorrectValue":"credential_type","message":"credential type not supported"}`)),
+					}, nil)
+
+				authorization = "Bearer access-token"
+				body = `{"type":"UniversityDegreeCredential","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[credential_type]")
+			},
+		},
+		{
+			name: "invalid status code for prepare credential",
+			setup: func() {
+				expectIntrospect("opState")
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Return(
+					&http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       io.NopCloser(bytes.NewBuffer(nil)),
+					}, nil)
+
+				authorization = "Bearer access-token"
+				body = `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "prepare credential: status code 500")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				OAuth2Provider:          mockOAuthProvider,
+				IssuerInteractionClient: mockInteractionClient,
+				JWTVerifier:             verifier,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcCredential(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func TestController_OidcTokenDPoP(t *testing.T) {
+	var (
+		mockOAuthProvider     = NewMockOAuth2Provider(gomock.NewController(t))
+		mockInteractionClient = NewMockIssuerInteractionClient(gomock.NewController(t))
+		mockDPoPVerifier      = NewMockDPoPVerifier(gomock.NewController(t))
+		dpopProof             string
+		dpopRequired          bool
+	)
+
+	expectAccessRequest := func() {
+		mockOAuthProvider.EXPECT().NewAccessRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&fosite.AccessRequest{
+				Request: fosite.Request{
+					Session: &fosite.DefaultSession{
+						Extra: map[string]interface{}{
+							"opState": "opState",
+						},
+					},
+				},
+			}, nil)
+	}
+
+	expectRefreshTokenRequest := func() {
+		mockOAuthProvider.EXPECT().NewAccessRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&fosite.AccessRequest{
+				GrantTypes: fosite.Arguments{"refresh_token"},
+				Request: fosite.Request{
+					Session: &fosite.DefaultSession{
+						Extra: map[string]interface{}{
+							"opState": "opState",
+							"dpopJKT": "jkt",
+						},
+					},
+				},
+			}, nil)
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success refresh token bound to dpop key",
+			setup: func() {
+				expectRefreshTokenRequest()
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), "dpop-proof", http.MethodPost,
+					"https://vcs.example.com/oidc/token", "").Return("jkt", nil)
+
+				mockOAuthProvider.EXPECT().NewAccessResponse(gomock.Any(), gomock.Any()).Return(
+					&fosite.AccessResponse{TokenType: "bearer", Extra: map[string]interface{}{}}, nil)
+
+				mockDPoPVerifier.EXPECT().NewNonce().Return("nonce")
+
+				mockOAuthProvider.EXPECT().WriteAccessResponse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(ctx context.Context, rw http.ResponseWriter, ar fosite.AccessRequester, resp fosite.AccessResponder) {
+						require.Equal(t, "DPoP", resp.GetTokenType())
+					})
+
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "refresh token bound to dpop key without dpop proof",
+			setup: func() {
+				expectRefreshTokenRequest()
+
+				dpopProof = ""
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid_dpop_proof")
+			},
+		},
+		{
+			name: "refresh token bound to another dpop key",
+			setup: func() {
+				expectRefreshTokenRequest()
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("another-jkt", nil)
+
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid_dpop_proof")
+			},
+		},
+		{
+			name: "success",
+			setup: func() {
+				expectAccessRequest()
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), "dpop-proof", http.MethodPost,
+					"https://vcs.example.com/oidc/token", "").Return("jkt", nil)
+
+				mockInteractionClient.EXPECT().ExchangeAuthorizationCodeRequest(gomock.Any(), gomock.Any()).
+					Return(exchangeAuthorizationCodeResponse(), nil)
+
+				mockOAuthProvider.EXPECT().NewAccessResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, ar fosite.AccessRequester) (fosite.AccessResponder, error) {
+						require.Equal(t, "jkt", ar.GetSession().(*fosite.DefaultSession).Extra["dpopJKT"])
+
+						return &fosite.AccessResponse{TokenType: "bearer", Extra: map[string]interface{}{}}, nil
+					})
+
+				mockDPoPVerifier.EXPECT().NewNonce().Return("nonce")
+
+				mockOAuthProvider.EXPECT().WriteAccessResponse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(ctx context.Context, rw http.ResponseWriter, ar fosite.AccessRequester, resp fosite.AccessResponder) {
+						require.Equal(t, "DPoP", resp.GetTokenType())
+					})
+
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "nonce", rec.Header().Get(dpop.NonceHeaderName))
+			},
+		},
+		{
+			name: "success without dpop proof",
+			setup: func() {
+				expectAccessRequest()
+
+				mockInteractionClient.EXPECT().ExchangeAuthorizationCodeRequest(gomock.Any(), gomock.Any()).
+					Return(exchangeAuthorizationCodeResponse(), nil)
+
+				mockOAuthProvider.EXPECT().NewAccessResponse(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, ar fosite.AccessRequester) (fosite.AccessResponder, error) {
+						require.NotContains(t, ar.GetSession().(*fosite.DefaultSession).Extra, "dpopJKT")
+
+						return &fosite.AccessResponse{TokenType: "bearer", Extra: map[string]interface{}{}}, nil
+					})
+
+				mockOAuthProvider.EXPECT().WriteAccessResponse(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+				dpopProof = ""
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Empty(t, rec.Header().Get(dpop.NonceHeaderName))
+			},
+		},
+		{
+			name: "dpop proof is required",
+			setup: func() {
+				expectAccessRequest()
+
+				dpopProof = ""
+				dpopRequired = true
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				var fositeErr *resterr.FositeError
+
+				require.ErrorAs(t, err, &fositeErr)
+				require.ErrorContains(t, err, "invalid_dpop_proof")
+			},
+		},
+		{
+			name: "dpop proof without nonce",
+			setup: func() {
+				expectAccessRequest()
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", dpop.ErrUseNonce)
+				mockDPoPVerifier.EXPECT().NewNonce().Return("nonce")
+
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "use_dpop_nonce")
+				require.Equal(t, "nonce", rec.Header().Get(dpop.NonceHeaderName))
+			},
+		},
+		{
+			name: "invalid dpop proof",
+			setup: func() {
+				expectAccessRequest()
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", fmt.Errorf("%w: proof is replayed", dpop.ErrInvalidProof))
+
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid_dpop_proof")
+			},
+		},
+		{
+			name: "fail to verify dpop proof",
+			setup: func() {
+				expectAccessRequest()
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", errors.New("store error"))
+
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "system-error[DPoPVerifier, Verify]: store error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpopRequired = false
+
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				OAuth2Provider:          mockOAuthProvider,
+				IssuerInteractionClient: mockInteractionClient,
+				IssuerVCSPublicHost:     "https://vcs.example.com",
+				DPoPVerifier:            mockDPoPVerifier,
+				DPoPRequired:            dpopRequired,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/oidc/token", http.NoBody)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+			if dpopProof != "" {
+				req.Header.Set(dpop.HeaderName, dpopProof)
+			}
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcToken(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func TestController_OidcIntrospect(t *testing.T) {
+	var (
+		mockOAuthProvider = NewMockOAuth2Provider(gomock.NewController(t))
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewIntrospectionRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					introspectionResponse(map[string]interface{}{
+						"opState":          "opState",
+						"cNonce":           "c-nonce",
+						"credentialIssuer": "https://issuer.example.com",
+					}), nil)
+
+				mockOAuthProvider.EXPECT().WriteIntrospectionResponse(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, _ http.ResponseWriter, ir fosite.IntrospectionResponder) {
+						require.Empty(t, ir.GetAccessRequester().GetSession().(*fosite.DefaultSession).Extra)
+					})
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "success dpop-bound token",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewIntrospectionRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					introspectionResponse(map[string]interface{}{
+						"opState": "opState",
+						"cNonce":  "c-nonce",
+						"dpopJKT": "jkt",
+					}), nil)
+
+				mockOAuthProvider.EXPECT().WriteIntrospectionResponse(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, _ http.ResponseWriter, ir fosite.IntrospectionResponder) {
+						require.Equal(t, map[string]interface{}{
+							"token_type": "DPoP",
+							"cnf":        map[string]interface{}{"jkt": "jkt"},
+						}, ir.GetAccessRequester().GetSession().(*fosite.DefaultSession).Extra)
+					})
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "inactive token",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewIntrospectionRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					&fosite.IntrospectionResponse{Active: false}, nil)
+
+				mockOAuthProvider.EXPECT().WriteIntrospectionResponse(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "fail to create new introspection request",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewIntrospectionRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					nil, fosite.ErrInvalidClient)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				var fositeErr *resterr.FositeError
+
+				require.ErrorAs(t, err, &fositeErr)
+				require.ErrorContains(t, err, "invalid_client")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				OAuth2Provider: mockOAuthProvider,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/oidc/introspect", http.NoBody)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcIntrospect(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func introspectionResponse(extra map[string]interface{}) *fosite.IntrospectionResponse {
+	return &fosite.IntrospectionResponse{
+		Active: true,
+		AccessRequester: &fosite.AccessRequest{
+			Request: fosite.Request{
+				Session: &fosite.DefaultSession{Extra: extra},
+			},
+		},
+	}
+}
+
+func TestController_OidcRevoke(t *testing.T) {
+	var (
+		mockOAuthProvider = NewMockOAuth2Provider(gomock.NewController(t))
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewRevocationRequest(gomock.Any(), gomock.Any()).Return(nil)
+
+				mockOAuthProvider.EXPECT().WriteRevocationResponse(gomock.Any(), gomock.Any(), nil)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "fail to create new revocation request",
+			setup: func() {
+				mockOAuthProvider.EXPECT().NewRevocationRequest(gomock.Any(), gomock.Any()).Return(
+					fosite.ErrInvalidClient)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				var fositeErr *resterr.FositeError
+
+				require.ErrorAs(t, err, &fositeErr)
+				require.ErrorContains(t, err, "invalid_client")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				OAuth2Provider: mockOAuthProvider,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/oidc/revoke", http.NoBody)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcRevoke(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func TestController_OidcCredentialDPoP(t *testing.T) {
+	var (
+		mockOAuthProvider     = NewMockOAuth2Provider(gomock.NewController(t))
+		mockInteractionClient = NewMockIssuerInteractionClient(gomock.NewController(t))
+		mockDPoPVerifier      = NewMockDPoPVerifier(gomock.NewController(t))
+		authorization         string
+		dpopProof             string
+		dpopRequired          bool
+	)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	verifier, err := jwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	body := `{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` +
+		generateProof(t, "did:example:holder#key1", privKey) + `"}}`
+
+	expectIntrospect := func(jkt string) {
+		extra := map[string]interface{}{
+			"opState":          "opState",
+			"cNonce":           cNonce,
+			"credentialIssuer": credentialIssuer,
+		}
+
+		if jkt != "" {
+			extra["dpopJKT"] = jkt
+		}
+
+		mockOAuthProvider.EXPECT().IntrospectToken(gomock.Any(), "access-token", fosite.AccessToken, gomock.Any()).
+			Return(fosite.AccessToken, &fosite.AccessRequest{
+				Request: fosite.Request{
+					Session: &fosite.DefaultSession{Extra: extra},
+				},
+			}, nil)
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success",
+			setup: func() {
+				expectIntrospect("jkt")
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), "dpop-proof", http.MethodPost,
+					"https://vcs.example.com/oidc/credential", "access-token").Return("jkt", nil)
+
+				b, marshalErr := json.Marshal(&issuer.PrepareCredentialResult{
+					Format:     "jwt_vc",
+					Credential: lo.ToPtr[interface{}]("signed-credential"),
+				})
+				require.NoError(t, marshalErr)
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(b)),
+				}, nil)
+
+				authorization = "DPoP access-token"
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+			},
+		},
+		{
+			name: "dpop-bound token passed as bearer token",
+			setup: func() {
+				expectIntrospect("jkt")
+
+				authorization = "Bearer access-token"
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "must be passed with DPoP authorization scheme")
+				require.Equal(t, `DPoP error="invalid_dpop_proof"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+			},
+		},
+		{
+			name: "missing dpop proof",
+			setup: func() {
+				expectIntrospect("jkt")
+
+				authorization = "DPoP access-token"
+				dpopProof = ""
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "missing DPoP proof")
+			},
+		},
+		{
+			name: "dpop proof signed by another key",
+			setup: func() {
+				expectIntrospect("jkt")
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("other-jkt", nil)
+
+				authorization = "DPoP access-token"
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "DPoP proof key does not match access token binding")
+			},
+		},
+		{
+			name: "dpop proof without nonce",
+			setup: func() {
+				expectIntrospect("jkt")
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", dpop.ErrUseNonce)
+				mockDPoPVerifier.EXPECT().NewNonce().Return("nonce")
+
+				authorization = "DPoP access-token"
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, dpop.ErrUseNonce.Error())
+				require.Equal(t, `DPoP error="use_dpop_nonce"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+				require.Equal(t, "nonce", rec.Header().Get(dpop.NonceHeaderName))
+			},
+		},
+		{
+			name: "invalid dpop proof",
+			setup: func() {
+				expectIntrospect("jkt")
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", fmt.Errorf("%w: ath claim does not match access token", dpop.ErrInvalidProof))
+
+				authorization = "DPoP access-token"
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "ath claim does not match access token")
+				require.Equal(t, `DPoP error="invalid_dpop_proof"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+			},
+		},
+		{
+			name: "fail to verify dpop proof",
+			setup: func() {
+				expectIntrospect("jkt")
+
+				mockDPoPVerifier.EXPECT().Verify(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return("", errors.New("store error"))
+
+				authorization = "DPoP access-token"
+				dpopProof = "dpop-proof"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "system-error[DPoPVerifier, Verify]: store error")
+			},
+		},
+		{
+			name: "access token is not dpop-bound",
+			setup: func() {
+				expectIntrospect("")
+
+				authorization = "Bearer access-token"
+				dpopProof = ""
+				dpopRequired = true
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "access token is not bound to DPoP key")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dpopRequired = false
+
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				OAuth2Provider:          mockOAuthProvider,
+				IssuerInteractionClient: mockInteractionClient,
+				IssuerVCSPublicHost:     "https://vcs.example.com",
+				JWTVerifier:             verifier,
+				DPoPVerifier:            mockDPoPVerifier,
+				DPoPRequired:            dpopRequired,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/oidc/credential", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderAuthorization, authorization)
+
+			if dpopProof != "" {
+				req.Header.Set(dpop.HeaderName, dpopProof)
+			}
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcCredential(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func TestController_OidcBatchCredential(t *testing.T) {
+	var (
+		mockOAuthProvider     = NewMockOAuth2Provider(gomock.NewController(t))
+		mockInteractionClient = NewMockIssuerInteractionClient(gomock.NewController(t))
+		body                  string
+	)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	verifier, err := jwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	proof := generateProof(t, "did:example:holder#key1", privKey)
+
+	expectIntrospect := func() {
+		mockOAuthProvider.EXPECT().IntrospectToken(gomock.Any(), "access-token", fosite.AccessToken, gomock.Any()).Return(
+			fosite.AccessToken,
+			&fosite.AccessRequest{
+				Request: fosite.Request{
+					Session: &fosite.DefaultSession{
+						Extra: map[string]interface{}{
+							"opState":          "opState",
+							"cNonce":           cNonce,
+							"credentialIssuer": credentialIssuer,
+						},
+					},
+				},
+			}, nil)
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success",
+			setup: func() {
+				expectIntrospect()
+
+				var validated []string
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Times(4).DoAndReturn(
+					func(
+						ctx context.Context,
+						req issuer.PrepareCredentialJSONRequestBody,
+						reqEditors ...issuer.RequestEditorFn,
+					) (*http.Response, error) {
+						assert.Equal(t, "opState", req.OpState)
+						assert.Equal(t, "did:example:holder", req.Did)
+
+						result := &issuer.PrepareCredentialResult{Format: "ldp_vc"}
+
+						if *req.ValidateOnly {
+							validated = append(validated, req.CredentialType)
+						} else {
+							// all requests are validated before the first credential is issued
+							assert.Equal(t, []string{"DriversLicense", "VehicleRegistration"}, validated)
+
+							result.Credential = lo.ToPtr[interface{}](req.CredentialType)
+						}
+
+						b, marshalErr := json.Marshal(result)
+						require.NoError(t, marshalErr)
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(bytes.NewBuffer(b)),
+						}, nil
+					})
+
+				body = `{"credential_requests":[` +
+					`{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}},` +
+					`{"type":"VehicleRegistration","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}]}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var resp oidc4vc.BatchCredentialResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.CredentialResponses, 2)
+				require.Equal(t, "DriversLicense", *resp.CredentialResponses[0].Credential)
+				require.Equal(t, "VehicleRegistration", *resp.CredentialResponses[1].Credential)
+			},
+		},
+		{
+			name: "invalid proof of second credential request",
+			setup: func() {
+				expectIntrospect()
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Times(0)
+
+				_, otherKey, keyErr := ed25519.GenerateKey(rand.Reader)
+				require.NoError(t, keyErr)
+
+				body = `{"credential_requests":[` +
+					`{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}},` +
+					`{"type":"VehicleRegistration","proof":{"proof_type":"jwt","jwt":"` +
+					generateProof(t, "did:example:holder#key1", otherKey) + `"}}]}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[proof.jwt]")
+			},
+		},
+		{
+			name: "fail to issue second credential",
+			setup: func() {
+				expectIntrospect()
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Times(4).DoAndReturn(
+					func(
+						ctx context.Context,
+						req issuer.PrepareCredentialJSONRequestBody,
+						reqEditors ...issuer.RequestEditorFn,
+					) (*http.Response, error) {
+						if !*req.ValidateOnly && req.CredentialType == "VehicleRegistration" {
+							return nil, errors.New("interaction error")
+						}
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body: io.NopCloser(bytes.NewBufferString(
+								`{"format":"ldp_vc","credential":"` + req.CredentialType + `"}`)),
+						}, nil
+					})
+
+				body = `{"credential_requests":[` +
+					`{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}},` +
+					`{"type":"VehicleRegistration","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}]}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var resp oidc4vc.BatchCredentialResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Len(t, resp.CredentialResponses, 1)
+				require.Equal(t, "DriversLicense", *resp.CredentialResponses[0].Credential)
+			},
+		},
+		{
+			name: "fail to issue first credential",
+			setup: func() {
+				expectIntrospect()
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+					func(
+						ctx context.Context,
+						req issuer.PrepareCredentialJSONRequestBody,
+						reqEditors ...issuer.RequestEditorFn,
+					) (*http.Response, error) {
+						if !*req.ValidateOnly {
+							return nil, errors.New("interaction error")
+						}
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(bytes.NewBufferString(`{"format":"ldp_vc"}`)),
+						}, nil
+					})
+
+				body = `{"credential_requests":[` +
+					`{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}},` +
+					`{"type":"VehicleRegistration","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}]}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "interaction error")
+			},
+		},
+		{
+			name: "second credential is not offered",
+			setup: func() {
+				expectIntrospect()
+
+				mockInteractionClient.EXPECT().PrepareCredential(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+					func(
+						ctx context.Context,
+						req issuer.PrepareCredentialJSONRequestBody,
+						reqEditors ...issuer.RequestEditorFn,
+					) (*http.Response, error) {
+						require.True(t, *req.ValidateOnly)
+
+						if req.CredentialType == "UniversityDegreeCredential" {
+							return &http.Response{
+								StatusCode: http.StatusBadRequest,
+								Body: io.NopCloser(bytes.NewBufferString(
+									`{"code":"invalid-value","incorrectValue":"credential_type","message":"not offered"}`)),
+							}, nil
+						}
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(bytes.NewBufferString(`{"format":"ldp_vc"}`)),
+						}, nil
+					})
+
+				body = `{"credential_requests":[` +
+					`{"type":"DriversLicense","proof":{"proof_type":"jwt","jwt":"` + proof + `"}},` +
+					`{"type":"UniversityDegreeCredential","proof":{"proof_type":"jwt","jwt":"` + proof + `"}}]}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[credential_type]")
+			},
+		},
+		{
+			name: "empty credential requests",
+			setup: func() {
+				mockOAuthProvider.EXPECT().IntrospectToken(gomock.Any(), "access-token", fosite.AccessToken, gomock.Any()).Return(
+					fosite.AccessToken,
+					&fosite.AccessRequest{
+						Request: fosite.Request{
+							Session: &fosite.DefaultSession{
+								Extra: map[string]interface{}{
+									"opState":          "opState",
+									"cNonce":           cNonce,
+									"credentialIssuer": credentialIssuer,
+								},
+							},
+						},
+					}, nil)
+
+				body = `{"credential_requests":[]}`
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "invalid-value[credential_requests]")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				OAuth2Provider:          mockOAuthProvider,
+				IssuerInteractionClient: mockInteractionClient,
+				JWTVerifier:             verifier,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("Authorization", "Bearer access-token")
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcBatchCredential(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func TestController_OidcDeferredCredential(t *testing.T) {
+	var (
+		mockInteractionClient = NewMockIssuerInteractionClient(gomock.NewController(t))
+		authorization         string
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success",
+			setup: func() {
+				b, err := json.Marshal(&issuer.PrepareCredentialResult{
+					Format:     "jwt_vc",
+					Credential: lo.ToPtr[interface{}]("signed-credential"),
+				})
+				require.NoError(t, err)
+
+				mockInteractionClient.EXPECT().PrepareDeferredCredential(gomock.Any(),
+					issuer.PrepareDeferredCredentialJSONRequestBody{
+						AcceptanceToken: "acceptance-token",
+					}).Return(&http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(b)),
+				}, nil)
+
+				authorization = "Bearer acceptance-token"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				var resp oidc4vc.CredentialResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Equal(t, "jwt_vc", resp.Format)
+				require.Equal(t, "signed-credential", *resp.Credential)
+			},
+		},
+		{
+			name: "issuance pending",
+			setup: func() {
+				mockInteractionClient.EXPECT().PrepareDeferredCredential(gomock.Any(), gomock.Any()).Return(
+					&http.Response{
+						StatusCode: http.StatusPreconditionFailed,
+						Body:       io.NopCloser(bytes.NewBuffer(nil)),
+					}, nil)
+
+				authorization = "Bearer acceptance-token"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+
+				var resp oidc4vc.CredentialErrorResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Equal(t, "issuance_pending", resp.Error)
+				require.NotNil(t, resp.Interval)
+			},
+		},
+		{
+			name: "invalid acceptance token",
+			setup: func() {
+				mockInteractionClient.EXPECT().PrepareDeferredCredential(gomock.Any(), gomock.Any()).Return(
+					&http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       io.NopCloser(bytes.NewBuffer(nil)),
+					}, nil)
+
+				authorization = "Bearer acceptance-token"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+				require.Contains(t, rec.Body.String(), "invalid_token")
+			},
+		},
+		{
+			name: "missing acceptance token",
+			setup: func() {
+				authorization = ""
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+				require.Contains(t, rec.Body.String(), "invalid_token")
+			},
+		},
+		{
+			name: "fail to prepare deferred credential",
+			setup: func() {
+				mockInteractionClient.EXPECT().PrepareDeferredCredential(gomock.Any(), gomock.Any()).Return(
+					nil, errors.New("prepare deferred credential error"))
+
+				authorization = "Bearer acceptance-token"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "prepare deferred credential error")
+			},
+		},
+		{
+			name: "invalid status code for prepare deferred credential",
+			setup: func() {
+				mockInteractionClient.EXPECT().PrepareDeferredCredential(gomock.Any(), gomock.Any()).Return(
+					&http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       io.NopCloser(bytes.NewBuffer(nil)),
+					}, nil)
+
+				authorization = "Bearer acceptance-token"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "prepare deferred credential: status code 500")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				IssuerInteractionClient: mockInteractionClient,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcDeferredCredential(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func TestController_OidcRegisterClient(t *testing.T) {
+	var (
+		mockRegistration = NewMockClientRegistrationService(gomock.NewController(t))
+		body             string
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, rec *httptest.ResponseRecorder, err error)
+	}{
+		{
+			name: "success",
+			setup: func() {
+				body = `{"redirect_uris":["https://wallet.example.com/callback"],"client_name":"Wallet",` +
+					`"contacts":["admin@wallet.example.com"]}`
+
+				mockRegistration.EXPECT().Register(gomock.Any(), "initial-token", gomock.Any()).DoAndReturn(
+					func(
+						ctx context.Context,
+						initialAccessToken string,
+						metadata *clientregistration.ClientMetadata,
+					) (*clientregistration.ClientInformation, error) {
+						require.Equal(t, []string{"https://wallet.example.com/callback"}, metadata.RedirectURIs)
+						require.Equal(t, "Wallet", metadata.Name)
+
+						return &clientregistration.ClientInformation{
+							Client: &clientregistration.Client{
+								ID:                      "client-id",
+								RedirectURIs:            metadata.RedirectURIs,
+								GrantTypes:              []string{"authorization_code"},
+								ResponseTypes:           []string{"code"},
+								Scopes:                  []string{"openid", "profile"},
+								TokenEndpointAuthMethod: "client_secret_basic",
+								Name:                    metadata.Name,
+								Contacts:                metadata.Contacts,
+							},
+							Secret:                  "client-secret",
+							RegistrationAccessToken: "registration-token",
+						}, nil
+					})
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusCreated, rec.Code)
+				require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+				var resp oidc4vc.ClientRegistrationResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Equal(t, "client-id", resp.ClientId)
+				require.Equal(t, "client-secret", lo.FromPtr(resp.ClientSecret))
+				require.Equal(t, 0, lo.FromPtr(resp.ClientSecretExpiresAt))
+				require.Equal(t, "registration-token", lo.FromPtr(resp.RegistrationAccessToken))
+				require.Equal(t, "https://vcs.example.com/oidc/register/client-id",
+					lo.FromPtr(resp.RegistrationClientUri))
+				require.Equal(t, "openid profile", lo.FromPtr(resp.Scope))
+				require.Equal(t, []string{"admin@wallet.example.com"}, lo.FromPtr(resp.Contacts))
+				require.Nil(t, resp.SoftwareStatement)
+			},
+		},
+		{
+			name: "invalid body",
+			setup: func() {
+				body = "{"
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+				require.Contains(t, rec.Body.String(), "invalid_client_metadata")
+			},
+		},
+		{
+			name: "invalid redirect uri",
+			setup: func() {
+				body = `{"redirect_uris":["/callback"]}`
+
+				mockRegistration.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					nil, fmt.Errorf("%w: /callback", clientregistration.ErrInvalidRedirectURI))
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+
+				var resp oidc4vc.ClientRegistrationErrorResponse
+
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				require.Equal(t, "invalid_redirect_uri", resp.Error)
+				require.Equal(t, "invalid redirect uri: /callback", lo.FromPtr(resp.ErrorDescription))
+			},
+		},
+		{
+			name: "invalid software statement",
+			setup: func() {
+				body = `{"software_statement":"statement"}`
+
+				mockRegistration.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					nil, clientregistration.ErrInvalidSoftwareStatement)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+				require.Contains(t, rec.Body.String(), "invalid_software_statement")
+			},
+		},
+		{
+			name: "unapproved software statement",
+			setup: func() {
+				body = `{"software_statement":"statement"}`
+
+				mockRegistration.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					nil, clientregistration.ErrUnapprovedSoftwareStatement)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusBadRequest, rec.Code)
+				require.Contains(t, rec.Body.String(), "unapproved_software_statement")
+			},
+		},
+		{
+			name: "invalid initial access token",
+			setup: func() {
+				body = `{}`
+
+				mockRegistration.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					nil, clientregistration.ErrInvalidInitialAccessToken)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusUnauthorized, rec.Code)
+				require.Contains(t, rec.Body.String(), "invalid_token")
+			},
+		},
+		{
+			name: "registration is disabled",
+			setup: func() {
+				body = `{}`
+
+				mockRegistration.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					nil, clientregistration.ErrRegistrationDisabled)
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.NoError(t, err)
+				require.Equal(t, http.StatusForbidden, rec.Code)
+				require.Contains(t, rec.Body.String(), "access_denied")
+			},
+		},
+		{
+			name: "register error",
+			setup: func() {
+				body = `{}`
+
+				mockRegistration.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					nil, errors.New("register error"))
+			},
+			check: func(t *testing.T, rec *httptest.ResponseRecorder, err error) {
+				require.ErrorContains(t, err, "register error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			controller := oidc4vc.NewController(&oidc4vc.Config{
+				IssuerVCSPublicHost:       "https://vcs.example.com",
+				ClientRegistrationService: mockRegistration,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer initial-token")
+
+			rec := httptest.NewRecorder()
+
+			err := controller.OidcRegisterClient(echo.New().NewContext(req, rec))
+			tt.check(t, rec, err)
+		})
+	}
+}
+
+func TestController_OidcManageClient(t *testing.T) {
+	mockRegistration := NewMockClientRegistrationService(gomock.NewController(t))
+
+	controller := oidc4vc.NewController(&oidc4vc.Config{
+		IssuerVCSPublicHost:       "https://vcs.example.com",
+		ClientRegistrationService: mockRegistration,
+	})
+
+	client := &clientregistration.Client{
+		ID:                      "client-id",
+		RedirectURIs:            []string{"https://wallet.example.com/callback"},
+		GrantTypes:              []string{"authorization_code"},
+		ResponseTypes:           []string{"code"},
+		Scopes:                  []string{"openid"},
+		TokenEndpointAuthMethod: "none",
+	}
+
+	newContext := func(method, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer registration-token")
+
+		rec := httptest.NewRecorder()
+
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mockRegistration.EXPECT().Get(gomock.Any(), "client-id", "registration-token").Return(
+			&clientregistration.ClientInformation{Client: client}, nil)
+
+		ctx, rec := newContext(http.MethodGet, "")
+
+		require.NoError(t, controller.OidcGetClient(ctx, "client-id"))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var resp oidc4vc.ClientRegistrationResponse
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Equal(t, "client-id", resp.ClientId)
+		require.Nil(t, resp.ClientSecret)
+		require.Nil(t, resp.RegistrationAccessToken)
+	})
+
+	t.Run("get with invalid registration access token", func(t *testing.T) {
+		mockRegistration.EXPECT().Get(gomock.Any(), "client-id", "registration-token").Return(
+			nil, clientregistration.ErrInvalidRegistrationAccessToken)
+
+		ctx, rec := newContext(http.MethodGet, "")
+
+		require.NoError(t, controller.OidcGetClient(ctx, "client-id"))
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Contains(t, rec.Body.String(), "invalid_token")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mockRegistration.EXPECT().Update(gomock.Any(), "client-id", "registration-token", gomock.Any()).DoAndReturn(
+			func(
+				ctx context.Context,
+				clientID, registrationAccessToken string,
+				metadata *clientregistration.ClientMetadata,
+			) (*clientregistration.ClientInformation, error) {
+				require.Equal(t, "client_secret_post", metadata.TokenEndpointAuthMethod)
+
+				return &clientregistration.ClientInformation{Client: client, Secret: "client-secret"}, nil
+			})
+
+		ctx, rec := newContext(http.MethodPut,
+			`{"client_id":"client-id","redirect_uris":["https://wallet.example.com/callback"],`+
+				`"token_endpoint_auth_method":"client_secret_post"}`)
+
+		require.NoError(t, controller.OidcUpdateClient(ctx, "client-id"))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), "client-secret")
+	})
+
+	t.Run("update with client id mismatch", func(t *testing.T) {
+		ctx, rec := newContext(http.MethodPut, `{"client_id":"other-client-id"}`)
+
+		require.NoError(t, controller.OidcUpdateClient(ctx, "client-id"))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "invalid_client_metadata")
+	})
+
+	t.Run("update with invalid body", func(t *testing.T) {
+		ctx, rec := newContext(http.MethodPut, "{")
+
+		require.NoError(t, controller.OidcUpdateClient(ctx, "client-id"))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Contains(t, rec.Body.String(), "invalid_client_metadata")
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		mockRegistration.EXPECT().Update(gomock.Any(), "client-id", "registration-token", gomock.Any()).Return(
+			nil, errors.New("update error"))
+
+		ctx, _ := newContext(http.MethodPut, `{"client_id":"client-id"}`)
+
+		require.ErrorContains(t, controller.OidcUpdateClient(ctx, "client-id"), "update error")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockRegistration.EXPECT().Delete(gomock.Any(), "client-id", "registration-token").Return(nil)
+
+		ctx, rec := newContext(http.MethodDelete, "")
+
+		require.NoError(t, controller.OidcDeleteClient(ctx, "client-id"))
+		require.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("delete with invalid registration access token", func(t *testing.T) {
+		mockRegistration.EXPECT().Delete(gomock.Any(), "client-id", "registration-token").Return(
+			clientregistration.ErrInvalidRegistrationAccessToken)
+
+		ctx, rec := newContext(http.MethodDelete, "")
+
+		require.NoError(t, controller.OidcDeleteClient(ctx, "client-id"))
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func exchangeAuthorizationCodeResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(bytes.NewBufferString(
+			`{"tx_id":"txID","credential_issuer":"` + credentialIssuer + `"}`)),
+	}
+}
+
+func generateProof(t *testing.T, kid string, privKey ed25519.PrivateKey) string {
+	t.Helper()
+
+	return generateProofWithClaims(t, kid, privKey, map[string]interface{}{
+		"aud":   credentialIssuer,
+		"iat":   time.Now().Unix(),
+		"nonce": cNonce,
+	})
+}
+
+func generateProofWithClaims(t *testing.T, kid string, privKey ed25519.PrivateKey, claims interface{}) string {
+	t.Helper()
+
+	token, err := jwt.NewSigned(claims, jose.Headers{jose.HeaderKeyID: kid}, jwt.NewEd25519Signer(privKey))
+	require.NoError(t, err)
+
+	jws, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return jws
+}
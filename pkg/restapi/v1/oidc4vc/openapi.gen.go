@@ -11,6 +11,11 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
 // Model for Access Token Response.
 type AccessTokenResponse struct {
 	// The access token issued by the authorization server.
@@ -31,10 +36,173 @@ type AccessTokenResponse struct {
 	// OPTIONAL, if identical to the scope requested by the client; otherwise, REQUIRED.
 	Scope *string `json:"scope,omitempty"`
 
-	// The type of the token issued.
+	// The type of the token issued. Bearer or DPoP if the token is bound to the wallet key.
 	TokenType string `json:"token_type"`
 }
 
+// Model for OIDC Batch Credential Request.
+type BatchCredentialRequest struct {
+	CredentialRequests []CredentialRequest `json:"credential_requests"`
+}
+
+// Model for OIDC Batch Credential Response.
+type BatchCredentialResponse struct {
+	CredentialResponses []CredentialResponse `json:"credential_responses"`
+}
+
+// Model for OIDC Dynamic Client Registration Error Response.
+type ClientRegistrationErrorResponse struct {
+	// Error code, e.g. invalid_redirect_uri, invalid_client_metadata or invalid_software_statement.
+	Error            string  `json:"error"`
+	ErrorDescription *string `json:"error_description,omitempty"`
+}
+
+// Model for OIDC Dynamic Client Registration Request (RFC 7591).
+type ClientRegistrationRequest struct {
+	ClientName *string   `json:"client_name,omitempty"`
+	ClientUri  *string   `json:"client_uri,omitempty"`
+	Contacts   *[]string `json:"contacts,omitempty"`
+
+	// Grant types the client uses. Defaults to authorization_code.
+	GrantTypes   *[]string `json:"grant_types,omitempty"`
+	RedirectUris *[]string `json:"redirect_uris,omitempty"`
+
+	// Response types the client uses. Defaults to code.
+	ResponseTypes *[]string `json:"response_types,omitempty"`
+
+	// Space-separated list of scopes the client can request. Defaults to openid.
+	Scope      *string `json:"scope,omitempty"`
+	SoftwareId *string `json:"software_id,omitempty"`
+
+	// JWT signed by a trusted issuer that asserts client metadata.
+	SoftwareStatement *string `json:"software_statement,omitempty"`
+	SoftwareVersion   *string `json:"software_version,omitempty"`
+
+	// Token endpoint authentication method: none, client_secret_basic or client_secret_post. Defaults to client_secret_basic.
+	TokenEndpointAuthMethod *string `json:"token_endpoint_auth_method,omitempty"`
+}
+
+// Model for OIDC Client Information Response (RFC 7591, RFC 7592).
+type ClientRegistrationResponse struct {
+	ClientId string `json:"client_id"`
+
+	// Time the client ID was issued at, in seconds since Unix epoch.
+	ClientIdIssuedAt *int    `json:"client_id_issued_at,omitempty"`
+	ClientName       *string `json:"client_name,omitempty"`
+
+	// Client secret. Returned only when the secret is issued.
+	ClientSecret *string `json:"client_secret,omitempty"`
+
+	// Time the client secret expires at, 0 if it does not expire. Returned with client secret.
+	ClientSecretExpiresAt *int      `json:"client_secret_expires_at,omitempty"`
+	ClientUri             *string   `json:"client_uri,omitempty"`
+	Contacts              *[]string `json:"contacts,omitempty"`
+
+	// Grant types the client uses. Defaults to authorization_code.
+	GrantTypes   *[]string `json:"grant_types,omitempty"`
+	RedirectUris *[]string `json:"redirect_uris,omitempty"`
+
+	// Token the client uses to read, update and delete its registration. Returned only on registration.
+	RegistrationAccessToken *string `json:"registration_access_token,omitempty"`
+
+	// URL of the client configuration endpoint.
+	RegistrationClientUri *string `json:"registration_client_uri,omitempty"`
+
+	// Response types the client uses. Defaults to code.
+	ResponseTypes *[]string `json:"response_types,omitempty"`
+
+	// Space-separated list of scopes the client can request. Defaults to openid.
+	Scope      *string `json:"scope,omitempty"`
+	SoftwareId *string `json:"software_id,omitempty"`
+
+	// JWT signed by a trusted issuer that asserts client metadata.
+	SoftwareStatement *string `json:"software_statement,omitempty"`
+	SoftwareVersion   *string `json:"software_version,omitempty"`
+
+	// Token endpoint authentication method: none, client_secret_basic or client_secret_post. Defaults to client_secret_basic.
+	TokenEndpointAuthMethod *string `json:"token_endpoint_auth_method,omitempty"`
+}
+
+// Model for OIDC Client Update Request (RFC 7592).
+type ClientUpdateRequest struct {
+	ClientId   string    `json:"client_id"`
+	ClientName *string   `json:"client_name,omitempty"`
+	ClientUri  *string   `json:"client_uri,omitempty"`
+	Contacts   *[]string `json:"contacts,omitempty"`
+
+	// Grant types the client uses. Defaults to authorization_code.
+	GrantTypes   *[]string `json:"grant_types,omitempty"`
+	RedirectUris *[]string `json:"redirect_uris,omitempty"`
+
+	// Response types the client uses. Defaults to code.
+	ResponseTypes *[]string `json:"response_types,omitempty"`
+
+	// Space-separated list of scopes the client can request. Defaults to openid.
+	Scope      *string `json:"scope,omitempty"`
+	SoftwareId *string `json:"software_id,omitempty"`
+
+	// JWT signed by a trusted issuer that asserts client metadata.
+	SoftwareStatement *string `json:"software_statement,omitempty"`
+	SoftwareVersion   *string `json:"software_version,omitempty"`
+
+	// Token endpoint authentication method: none, client_secret_basic or client_secret_post. Defaults to client_secret_basic.
+	TokenEndpointAuthMethod *string `json:"token_endpoint_auth_method,omitempty"`
+}
+
+// Model for OIDC Credential Error Response.
+type CredentialErrorResponse struct {
+	// Error code, e.g. issuance_pending or invalid_token.
+	Error            string  `json:"error"`
+	ErrorDescription *string `json:"error_description,omitempty"`
+
+	// Minimum amount of time in seconds the wallet should wait before polling the deferred credential endpoint again.
+	Interval *int `json:"interval,omitempty"`
+}
+
+// Model for OIDC Credential Request.
+type CredentialRequest struct {
+	// Format of the requested credential, jwt_vc or ldp_vc. Optional if only one credential of the given type is offered.
+	Format *string `json:"format,omitempty"`
+
+	// Proof of possession of the key material the issued credential is bound to.
+	Proof JWTProof `json:"proof"`
+
+	// Type of the requested credential.
+	Type string `json:"type"`
+}
+
+// Model for OIDC Credential Response.
+type CredentialResponse struct {
+	// Token used to obtain the credential from the deferred credential endpoint. Set if issuance is deferred.
+	AcceptanceToken *string `json:"acceptance_token,omitempty"`
+
+	// Issued credential. JSON object for ldp_vc and JWT string for jwt_vc format. Not set if issuance is deferred.
+	Credential *interface{} `json:"credential,omitempty"`
+
+	// Format of the issued credential, jwt_vc or ldp_vc.
+	Format string `json:"format"`
+}
+
+// Token introspection response (RFC 7662).
+type IntrospectionResponse struct {
+	// Whether the token is active.
+	Active    bool    `json:"active"`
+	ClientId  *string `json:"client_id,omitempty"`
+	Exp       *int    `json:"exp,omitempty"`
+	Iat       *int    `json:"iat,omitempty"`
+	Scope     *string `json:"scope,omitempty"`
+	TokenType *string `json:"token_type,omitempty"`
+}
+
+// Proof of possession of the key material the issued credential is bound to.
+type JWTProof struct {
+	// Signed JWT. The kid header is a DID URL referring to the holder key, the credential is issued to that DID.
+	Jwt string `json:"jwt"`
+
+	// Type of the proof. MUST be set to "jwt".
+	ProofType string `json:"proof_type"`
+}
+
 // Model for Pushed Authorization Response.
 type PushedAuthorizationResponse struct {
 	// A JSON number that represents the lifetime of the request URI in seconds as a positive integer. The request URI lifetime is at the discretion of the authorization server but will typically be relatively short (e.g., between 5 and 600 seconds).
@@ -80,6 +248,12 @@ type OidcAuthorizeParams struct {
 	OpState string `form:"op_state" json:"op_state"`
 }
 
+// OidcBatchCredentialJSONBody defines parameters for OidcBatchCredential.
+type OidcBatchCredentialJSONBody = BatchCredentialRequest
+
+// OidcCredentialJSONBody defines parameters for OidcCredential.
+type OidcCredentialJSONBody = CredentialRequest
+
 // OidcRedirectParams defines parameters for OidcRedirect.
 type OidcRedirectParams struct {
 	// auth code for issuer provider
@@ -89,17 +263,62 @@ type OidcRedirectParams struct {
 	State string `form:"state" json:"state"`
 }
 
+// OidcRegisterClientJSONBody defines parameters for OidcRegisterClient.
+type OidcRegisterClientJSONBody = ClientRegistrationRequest
+
+// OidcUpdateClientJSONBody defines parameters for OidcUpdateClient.
+type OidcUpdateClientJSONBody = ClientUpdateRequest
+
+// OidcBatchCredentialJSONRequestBody defines body for OidcBatchCredential for application/json ContentType.
+type OidcBatchCredentialJSONRequestBody = OidcBatchCredentialJSONBody
+
+// OidcCredentialJSONRequestBody defines body for OidcCredential for application/json ContentType.
+type OidcCredentialJSONRequestBody = OidcCredentialJSONBody
+
+// OidcRegisterClientJSONRequestBody defines body for OidcRegisterClient for application/json ContentType.
+type OidcRegisterClientJSONRequestBody = OidcRegisterClientJSONBody
+
+// OidcUpdateClientJSONRequestBody defines body for OidcUpdateClient for application/json ContentType.
+type OidcUpdateClientJSONRequestBody = OidcUpdateClientJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// OIDC Authorization Request
 	// (GET /oidc/authorize)
 	OidcAuthorize(ctx echo.Context, params OidcAuthorizeParams) error
+	// OIDC Batch Credential
+	// (POST /oidc/batch_credential)
+	OidcBatchCredential(ctx echo.Context) error
+	// OIDC Credential
+	// (POST /oidc/credential)
+	OidcCredential(ctx echo.Context) error
+	// OIDC Deferred Credential
+	// (POST /oidc/deferred_credential)
+	OidcDeferredCredential(ctx echo.Context) error
+	// OIDC Token Introspection
+	// (POST /oidc/introspect)
+	OidcIntrospect(ctx echo.Context) error
 	// OIDC Pushed Authorization Request
 	// (POST /oidc/par)
 	OidcPushedAuthorizationRequest(ctx echo.Context) error
 	// OIDC Redirect
 	// (GET /oidc/redirect)
 	OidcRedirect(ctx echo.Context, params OidcRedirectParams) error
+	// OIDC Dynamic Client Registration
+	// (POST /oidc/register)
+	OidcRegisterClient(ctx echo.Context) error
+	// OIDC Client Delete
+	// (DELETE /oidc/register/{clientID})
+	OidcDeleteClient(ctx echo.Context, clientID string) error
+	// OIDC Client Read
+	// (GET /oidc/register/{clientID})
+	OidcGetClient(ctx echo.Context, clientID string) error
+	// OIDC Client Update
+	// (PUT /oidc/register/{clientID})
+	OidcUpdateClient(ctx echo.Context, clientID string) error
+	// OIDC Token Revocation
+	// (POST /oidc/revoke)
+	OidcRevoke(ctx echo.Context) error
 	// OIDC Token Request
 	// (POST /oidc/token)
 	OidcToken(ctx echo.Context) error
@@ -198,10 +417,50 @@ func (w *ServerInterfaceWrapper) OidcAuthorize(ctx echo.Context) error {
 	return err
 }
 
+// OidcBatchCredential converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcBatchCredential(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcBatchCredential(ctx)
+	return err
+}
+
+// OidcCredential converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcCredential(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcCredential(ctx)
+	return err
+}
+
+// OidcDeferredCredential converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcDeferredCredential(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcDeferredCredential(ctx)
+	return err
+}
+
+// OidcIntrospect converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcIntrospect(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcIntrospect(ctx)
+	return err
+}
+
 // OidcPushedAuthorizationRequest converts echo context to params.
 func (w *ServerInterfaceWrapper) OidcPushedAuthorizationRequest(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{""})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.OidcPushedAuthorizationRequest(ctx)
 	return err
@@ -232,6 +491,72 @@ func (w *ServerInterfaceWrapper) OidcRedirect(ctx echo.Context) error {
 	return err
 }
 
+// OidcRegisterClient converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcRegisterClient(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcRegisterClient(ctx)
+	return err
+}
+
+// OidcDeleteClient converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcDeleteClient(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clientID" -------------
+	var clientID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "clientID", runtime.ParamLocationPath, ctx.Param("clientID"), &clientID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clientID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcDeleteClient(ctx, clientID)
+	return err
+}
+
+// OidcGetClient converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcGetClient(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clientID" -------------
+	var clientID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "clientID", runtime.ParamLocationPath, ctx.Param("clientID"), &clientID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clientID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcGetClient(ctx, clientID)
+	return err
+}
+
+// OidcUpdateClient converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcUpdateClient(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clientID" -------------
+	var clientID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "clientID", runtime.ParamLocationPath, ctx.Param("clientID"), &clientID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clientID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcUpdateClient(ctx, clientID)
+	return err
+}
+
+// OidcRevoke converts echo context to params.
+func (w *ServerInterfaceWrapper) OidcRevoke(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.OidcRevoke(ctx)
+	return err
+}
+
 // OidcToken converts echo context to params.
 func (w *ServerInterfaceWrapper) OidcToken(ctx echo.Context) error {
 	var err error
@@ -270,8 +595,17 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/oidc/authorize", wrapper.OidcAuthorize)
+	router.POST(baseURL+"/oidc/batch_credential", wrapper.OidcBatchCredential)
+	router.POST(baseURL+"/oidc/credential", wrapper.OidcCredential)
+	router.POST(baseURL+"/oidc/deferred_credential", wrapper.OidcDeferredCredential)
+	router.POST(baseURL+"/oidc/introspect", wrapper.OidcIntrospect)
 	router.POST(baseURL+"/oidc/par", wrapper.OidcPushedAuthorizationRequest)
 	router.GET(baseURL+"/oidc/redirect", wrapper.OidcRedirect)
+	router.POST(baseURL+"/oidc/register", wrapper.OidcRegisterClient)
+	router.DELETE(baseURL+"/oidc/register/:clientID", wrapper.OidcDeleteClient)
+	router.GET(baseURL+"/oidc/register/:clientID", wrapper.OidcGetClient)
+	router.PUT(baseURL+"/oidc/register/:clientID", wrapper.OidcUpdateClient)
+	router.POST(baseURL+"/oidc/revoke", wrapper.OidcRevoke)
 	router.POST(baseURL+"/oidc/token", wrapper.OidcToken)
 
 }
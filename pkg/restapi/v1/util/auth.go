@@ -16,9 +16,68 @@ import (
 
 const (
 	userHeader = "X-User"
+	orgIDKey   = "vcs.orgID"
+	subjectKey = "vcs.subject"
+	apiKeyKey  = "vcs.apiKey"
 )
 
+// SetOrgID sets organization ID derived from the authenticated access token. It takes precedence over
+// organization ID passed in request header.
+func SetOrgID(ctx echo.Context, orgID string) {
+	ctx.Set(orgIDKey, orgID)
+}
+
+// SetSubject sets subject of the authenticated access token.
+func SetSubject(ctx echo.Context, subject string) {
+	ctx.Set(subjectKey, subject)
+}
+
+// SetAPIKeyAuthenticated marks request as authenticated with API key.
+func SetAPIKeyAuthenticated(ctx echo.Context) {
+	ctx.Set(apiKeyKey, true)
+}
+
+// IsAPIKeyAuthenticated returns true if request is authenticated with API key.
+func IsAPIKeyAuthenticated(ctx echo.Context) bool {
+	authenticated, _ := ctx.Get(apiKeyKey).(bool) //nolint:errcheck
+
+	return authenticated
+}
+
+// GetAuthenticatedOrgID returns organization ID derived from the authenticated access token or client
+// certificate. Unlike GetOrgIDFromOIDC, it never falls back to the organization ID passed in request header.
+func GetAuthenticatedOrgID(ctx echo.Context) (string, bool) {
+	orgID, ok := ctx.Get(orgIDKey).(string)
+
+	return orgID, ok && orgID != ""
+}
+
+// GetActor returns identity of the caller: subject of the access token if request is authenticated with
+// access token, organization ID otherwise.
+func GetActor(ctx echo.Context) string {
+	if subject, ok := ctx.Get(subjectKey).(string); ok && subject != "" {
+		return subject
+	}
+
+	orgID, _ := GetOrgIDFromOIDC(ctx) //nolint:errcheck
+
+	return orgID
+}
+
+// GetRequestID returns ID of the request set by request ID middleware or passed by the caller.
+func GetRequestID(ctx echo.Context) string {
+	if requestID := ctx.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+		return requestID
+	}
+
+	return ctx.Request().Header.Get(echo.HeaderXRequestID)
+}
+
 func GetOrgIDFromOIDC(ctx echo.Context) (string, error) {
+	if orgID, ok := ctx.Get(orgIDKey).(string); ok && orgID != "" {
+		return orgID, nil
+	}
+
 	orgID := ctx.Request().Header.Get(userHeader)
 	if orgID == "" {
 		return "", resterr.NewUnauthorizedError(errors.New("missing authorization"))
@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/skip2/go-qrcode"
+
+	"github.com/trustbloc/vcs/pkg/restapi/resterr"
+)
+
+const (
+	QRCodeFormatPNG = "png"
+	QRCodeFormatSVG = "svg"
+
+	defaultQRCodeSize = 256
+	minQRCodeSize     = 64
+	maxQRCodeSize     = 1024
+
+	svgContentType = "image/svg+xml"
+)
+
+// WriteQRCode writes QR code image with the given content in PNG or SVG format. Format defaults to PNG,
+// size is the width and height of PNG image in pixels. SVG image is scalable and size is ignored.
+func WriteQRCode(ctx echo.Context, content string, format *string, size *int) error {
+	f := QRCodeFormatPNG
+	if format != nil {
+		f = *format
+	}
+
+	s := defaultQRCodeSize
+	if size != nil {
+		s = *size
+	}
+
+	if s < minQRCodeSize || s > maxQRCodeSize {
+		return resterr.NewValidationError(resterr.InvalidValue, "size",
+			fmt.Errorf("size should be between %d and %d", minQRCodeSize, maxQRCodeSize))
+	}
+
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return resterr.NewSystemError("qrcode", "New", err)
+	}
+
+	switch f {
+	case QRCodeFormatPNG:
+		png, pngErr := qr.PNG(s)
+		if pngErr != nil {
+			return resterr.NewSystemError("qrcode", "PNG", pngErr)
+		}
+
+		return ctx.Blob(http.StatusOK, "image/png", png)
+	case QRCodeFormatSVG:
+		return ctx.Blob(http.StatusOK, svgContentType, renderSVG(qr.Bitmap()))
+	default:
+		return resterr.NewValidationError(resterr.InvalidValue, "format",
+			fmt.Errorf("unsupported qr code format: %s", f))
+	}
+}
+
+// renderSVG renders QR code modules as a single path, one unit per module. Bitmap includes quiet zone.
+func renderSVG(bitmap [][]bool) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %[1]d %[1]d" shape-rendering="crispEdges">`,
+		len(bitmap))
+	buf.WriteString(`<rect width="100%" height="100%" fill="#fff"/><path fill="#000" d="`)
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
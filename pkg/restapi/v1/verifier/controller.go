@@ -10,14 +10,18 @@ SPDX-License-Identifier: Apache-2.0
 package verifier
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -30,6 +34,7 @@ import (
 	"github.com/trustbloc/vcs/pkg/doc/vp"
 	"github.com/trustbloc/vcs/pkg/kms"
 	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 	"github.com/trustbloc/vcs/pkg/restapi/resterr"
 	"github.com/trustbloc/vcs/pkg/restapi/v1/util"
@@ -44,14 +49,20 @@ const (
 	oidc4vpSvcComponent          = "oidc4vp.Service"
 
 	vpSubmissionProperty = "presentation_submission"
+
+	jweCompactParts = 5
 )
 
 var logger = log.New("oidc4vp")
 
 type authorizationResponse struct {
-	IDToken string
-	VPToken string
-	State   string
+	IDToken string `json:"id_token"`
+	VPToken string `json:"vp_token"`
+	State   string `json:"state"`
+	// Response is a JWT with id_token, vp_token and state claims sent in direct_post.jwt response mode.
+	Response string `json:"-"`
+	// EncryptedFor is set to the id of profile which key was used to decrypt the response.
+	EncryptedFor profileapi.ID `json:"-"`
 }
 
 type IDTokenVPToken struct {
@@ -86,24 +97,28 @@ type profileService interface {
 }
 
 type verifyCredentialSvc interface {
-	VerifyCredential(credential *verifiable.Credential, opts *verifycredential.Options,
+	VerifyCredential(ctx context.Context, credential *verifiable.Credential, opts *verifycredential.Options,
 		profile *profileapi.Verifier) ([]verifycredential.CredentialsVerificationCheckResult, error)
 }
 
 type verifyPresentationSvc interface {
-	VerifyPresentation(presentation *verifiable.Presentation, opts *verifypresentation.Options,
+	VerifyPresentation(ctx context.Context, presentation *verifiable.Presentation, opts *verifypresentation.Options,
 		profile *profileapi.Verifier) ([]verifypresentation.PresentationVerificationCheckResult, error)
 }
 
 type oidc4VPService interface {
-	InitiateOidcInteraction(presentationDefinition *presexch.PresentationDefinition, purpose string,
-		profile *profileapi.Verifier) (*oidc4vp.InteractionInfo, error)
+	InitiateOidcInteraction(ctx context.Context, presentationDefinition *presexch.PresentationDefinition,
+		purpose string, profile *profileapi.Verifier, opts *oidc4vp.InteractionOptions) (*oidc4vp.InteractionInfo, error)
 
-	VerifyOIDCVerifiablePresentation(txID oidc4vp.TxID, token *oidc4vp.ProcessedVPToken) error
+	VerifyOIDCVerifiablePresentation(ctx context.Context, txID oidc4vp.TxID, token *oidc4vp.ProcessedVPToken) error
 
 	GetTx(id oidc4vp.TxID) (*oidc4vp.Transaction, error)
 
 	RetrieveClaims(tx *oidc4vp.Transaction) map[string]oidc4vp.CredentialMetadata
+
+	RetrieveAndDeleteClaims(id oidc4vp.TxID) (map[string]oidc4vp.CredentialMetadata, error)
+
+	DeleteClaims(id oidc4vp.TxID) error
 }
 
 type Config struct {
@@ -164,7 +179,7 @@ func NewController(config *Config) *Controller {
 // PostVerifyCredentials Verify credential
 // (POST /verifier/profiles/{profileID}/credentials/verify).
 func (c *Controller) PostVerifyCredentials(ctx echo.Context, profileID string) error {
-	logger.Debug("PostVerifyCredentials begin")
+	logger.WithContext(ctx.Request().Context()).Debug("PostVerifyCredentials begin")
 	var body VerifyCredentialData
 
 	if err := util.ReadBody(ctx, &body); err != nil {
@@ -188,7 +203,7 @@ func (c *Controller) verifyCredential(ctx echo.Context, body *VerifyCredentialDa
 
 	credential, err := vc.ValidateCredential(body.Credential, profile.Checks.Credential.Format,
 		verifiable.WithPublicKeyFetcher(
-			verifiable.NewVDRKeyResolver(c.vdr).PublicKeyFetcher(),
+			verifiable.NewVDRKeyResolver(tracing.WithContext(ctx.Request().Context(), c.vdr)).PublicKeyFetcher(),
 		),
 		verifiable.WithJSONLDDocumentLoader(c.documentLoader))
 
@@ -196,19 +211,20 @@ func (c *Controller) verifyCredential(ctx echo.Context, body *VerifyCredentialDa
 		return nil, resterr.NewValidationError(resterr.InvalidValue, "credential", err)
 	}
 
-	verRes, err := c.verifyCredentialSvc.VerifyCredential(credential, getVerifyCredentialOptions(body.Options), profile)
+	verRes, err := c.verifyCredentialSvc.VerifyCredential(ctx.Request().Context(), credential,
+		getVerifyCredentialOptions(body.Options), profile)
 	if err != nil {
 		return nil, resterr.NewSystemError(verifyCredentialSvcComponent, "VerifyCredential", err)
 	}
 
-	logger.Debug("PostVerifyCredentials success")
+	logger.WithContext(ctx.Request().Context()).Debug("PostVerifyCredentials success")
 	return mapVerifyCredentialChecks(verRes), nil
 }
 
 // PostVerifyPresentation Verify presentation.
 // (POST /verifier/profiles/{profileID}/presentations/verify).
 func (c *Controller) PostVerifyPresentation(ctx echo.Context, profileID string) error {
-	logger.Debug("PostVerifyPresentation begin")
+	logger.WithContext(ctx.Request().Context()).Debug("PostVerifyPresentation begin")
 	var body VerifyPresentationData
 
 	if err := util.ReadBody(ctx, &body); err != nil {
@@ -232,7 +248,7 @@ func (c *Controller) verifyPresentation(ctx echo.Context, body *VerifyPresentati
 
 	presentation, err := vp.ValidatePresentation(body.Presentation, profile.Checks.Presentation.Format,
 		verifiable.WithPresPublicKeyFetcher(
-			verifiable.NewVDRKeyResolver(c.vdr).PublicKeyFetcher(),
+			verifiable.NewVDRKeyResolver(tracing.WithContext(ctx.Request().Context(), c.vdr)).PublicKeyFetcher(),
 		),
 		verifiable.WithPresJSONLDDocumentLoader(c.documentLoader))
 
@@ -240,18 +256,18 @@ func (c *Controller) verifyPresentation(ctx echo.Context, body *VerifyPresentati
 		return nil, resterr.NewValidationError(resterr.InvalidValue, "presentation", err)
 	}
 
-	verRes, err := c.verifyPresentationSvc.VerifyPresentation(
+	verRes, err := c.verifyPresentationSvc.VerifyPresentation(ctx.Request().Context(),
 		presentation, getVerifyPresentationOptions(body.Options), profile)
 	if err != nil {
 		return nil, resterr.NewSystemError(verifyCredentialSvcComponent, "VerifyCredential", err)
 	}
 
-	logger.Debug("PostVerifyPresentation success")
+	logger.WithContext(ctx.Request().Context()).Debug("PostVerifyPresentation success")
 	return mapVerifyPresentationChecks(verRes), nil
 }
 
 func (c *Controller) InitiateOidcInteraction(ctx echo.Context, profileID string) error {
-	logger.Debug("InitiateOidcInteraction begin")
+	logger.WithContext(ctx.Request().Context()).Debug("InitiateOidcInteraction begin")
 
 	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
 	if err != nil {
@@ -269,10 +285,10 @@ func (c *Controller) InitiateOidcInteraction(ctx echo.Context, profileID string)
 		return resterr.NewValidationError(resterr.InvalidValue, "requestBody", err)
 	}
 
-	return util.WriteOutput(ctx)(c.initiateOidcInteraction(&body, profile))
+	return util.WriteOutput(ctx)(c.initiateOidcInteraction(ctx.Request().Context(), &body, profile))
 }
 
-func (c *Controller) initiateOidcInteraction(data *InitiateOIDC4VPData,
+func (c *Controller) initiateOidcInteraction(ctx context.Context, data *InitiateOIDC4VPData,
 	profile *profileapi.Verifier) (*InitiateOIDC4VPResponse, error) {
 	if !profile.Active {
 		return nil, resterr.NewValidationError(resterr.ConditionNotMet, "profile.Active",
@@ -289,27 +305,73 @@ func (c *Controller) initiateOidcInteraction(data *InitiateOIDC4VPData,
 		return nil, resterr.NewValidationError(resterr.InvalidValue, "presentationDefinitionID", err)
 	}
 
-	logger.Debug("InitiateOidcInteraction pd find", log.WithPresDefID(pd.ID))
+	logger.WithContext(ctx).Debug("InitiateOidcInteraction pd find", log.WithPresDefID(pd.ID))
+
+	opts := &oidc4vp.InteractionOptions{
+		RedirectURI: strPtrToStr(data.RedirectURI),
+	}
+
+	if data.ResponseMode != nil {
+		opts.ResponseMode = oidc4vp.ResponseMode(*data.ResponseMode)
+	}
+
+	if opts.RedirectURI != "" {
+		if redirectURI, parseErr := url.Parse(opts.RedirectURI); parseErr != nil || !redirectURI.IsAbs() {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "redirectURI",
+				errors.New("redirect uri should be an absolute uri"))
+		}
+	}
 
-	result, err := c.oidc4VPService.InitiateOidcInteraction(pd, strPtrToStr(data.Purpose), profile)
+	if data.RequestObjectByValue != nil {
+		opts.RequestObjectByValue = *data.RequestObjectByValue
+	}
+
+	if data.RequestObjectEncryptionKey != nil {
+		opts.RequestObjectEncryptionKey, err = parseJWK(*data.RequestObjectEncryptionKey)
+		if err != nil {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "requestObjectEncryptionKey", err)
+		}
+	}
+
+	result, err := c.oidc4VPService.InitiateOidcInteraction(ctx, pd, strPtrToStr(data.Purpose), profile, opts)
 	if err != nil {
+		if errors.Is(err, oidc4vp.ErrInvalidRequestObjectEncryptionKey) {
+			return nil, resterr.NewValidationError(resterr.InvalidValue, "requestObjectEncryptionKey", err)
+		}
+
 		return nil, resterr.NewSystemError("oidc4VPService", "InitiateOidcInteraction", err)
 	}
 
-	logger.Debug("InitiateOidcInteraction success", log.WithTxID(string(result.TxID)))
+	logger.WithContext(ctx).Debug("InitiateOidcInteraction success", log.WithTxID(string(result.TxID)))
 	return &InitiateOIDC4VPResponse{
 		AuthorizationRequest: result.AuthorizationRequest,
 		TxID:                 string(result.TxID),
 	}, err
 }
 
+func parseJWK(rawKey map[string]interface{}) (*jwk.JWK, error) {
+	keyBytes, err := json.Marshal(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshal jwk: %w", err)
+	}
+
+	key := &jwk.JWK{}
+
+	if err = key.UnmarshalJSON(keyBytes); err != nil {
+		return nil, fmt.Errorf("unmarshal jwk: %w", err)
+	}
+
+	return key, nil
+}
+
 func (c *Controller) CheckAuthorizationResponse(ctx echo.Context) error {
-	logger.Debug("CheckAuthorizationResponse begin")
+	logger.WithContext(ctx.Request().Context()).Debug("CheckAuthorizationResponse begin")
 	startTime := time.Now()
 
 	defer func() {
 		c.metrics.CheckAuthorizationResponseTime(time.Since(startTime))
-		logger.Debug("CheckAuthorizationResponse end", log.WithDuration(time.Since(startTime)))
+		logger.WithContext(ctx.Request().Context()).Debug("CheckAuthorizationResponse end",
+			log.WithDuration(time.Since(startTime)))
 	}()
 
 	authResp, err := validateAuthorizationResponse(ctx)
@@ -317,47 +379,371 @@ func (c *Controller) CheckAuthorizationResponse(ctx echo.Context) error {
 		return err
 	}
 
-	processedToken, err := c.verifyAuthorizationResponseTokens(authResp)
+	if authResp.Response != "" {
+		if err = c.decodeJWTAuthorizationResponse(ctx.Request().Context(), authResp); err != nil {
+			return err
+		}
+	}
+
+	tx, err := c.accessOIDC4VPTx(ctx.Request().Context(), authResp.State)
 	if err != nil {
 		return err
 	}
 
-	err = c.oidc4VPService.VerifyOIDCVerifiablePresentation(oidc4vp.TxID(authResp.State), processedToken)
+	if err = c.checkResponseMode(tx, authResp); err != nil {
+		return err
+	}
+
+	processedToken, err := c.verifyAuthorizationResponseTokens(ctx.Request().Context(), authResp)
 	if err != nil {
 		return err
 	}
 
-	logger.Debug("CheckAuthorizationResponse succeed")
+	err = c.oidc4VPService.VerifyOIDCVerifiablePresentation(ctx.Request().Context(),
+		oidc4vp.TxID(authResp.State), processedToken)
+	if err != nil {
+		return err
+	}
+
+	redirectURI, err := oidc4vp.ResponseRedirectURI(tx)
+	if err != nil {
+		return resterr.NewSystemError(oidc4vpSvcComponent, "ResponseRedirectURI", err)
+	}
+
+	logger.WithContext(ctx.Request().Context()).Debug("CheckAuthorizationResponse succeed")
+
+	if redirectURI == "" {
+		return nil
+	}
+
+	return util.WriteOutput(ctx)(&AuthorizationResponseResult{RedirectUri: &redirectURI}, nil)
+}
+
+// decodeJWTAuthorizationResponse verifies authorization response sent in direct_post.jwt response mode
+// and extracts id_token, vp_token and state from it. Encrypted response is decrypted first using the key
+// from profile's KMS.
+func (c *Controller) decodeJWTAuthorizationResponse(ctx context.Context, authResp *authorizationResponse) error {
+	response := authResp.Response
+
+	if isJWE(response) {
+		decrypted, profileID, err := c.decryptAuthorizationResponse(response)
+		if err != nil {
+			return err
+		}
+
+		logger.WithContext(ctx).Debug("AuthorizationResponse response decrypted", log.WithProfileID(profileID))
+
+		authResp.EncryptedFor = profileID
+
+		// Encrypted response may contain either signed JWT or plain JSON with response parameters.
+		if !jwt.IsJWS(string(decrypted)) {
+			if err = json.Unmarshal(decrypted, authResp); err != nil {
+				return resterr.NewValidationError(resterr.InvalidValue, "response", err)
+			}
+
+			return checkJWTAuthorizationResponse(ctx, authResp)
+		}
+
+		response = string(decrypted)
+	}
+
+	_, err := verifyTokenSignature(response, authResp, c.jwtVerifier)
+	if err != nil {
+		return resterr.NewValidationError(resterr.InvalidValue, "response", err)
+	}
+
+	return checkJWTAuthorizationResponse(ctx, authResp)
+}
+
+func (c *Controller) decryptAuthorizationResponse(response string) ([]byte, profileapi.ID, error) {
+	jwe, err := jose.Deserialize(response)
+	if err != nil {
+		return nil, "", resterr.NewValidationError(resterr.InvalidValue, "response", err)
+	}
+
+	kid, _ := jwe.ProtectedHeaders.KeyID()
+
+	profileID, err := oidc4vp.ProfileIDFromResponseEncryptionKeyID(kid)
+	if err != nil {
+		return nil, "", resterr.NewValidationError(resterr.InvalidValue, "response.kid", err)
+	}
+
+	profile, err := c.profileSvc.GetProfile(profileID)
+	if err != nil {
+		return nil, "", resterr.NewSystemError(verifierProfileSvcComponent, "GetProfile", err)
+	}
+
+	if profile == nil || profile.OIDCConfig == nil || profile.OIDCConfig.ResponseEncryption == nil {
+		return nil, "", resterr.NewValidationError(resterr.InvalidValue, "response.kid",
+			fmt.Errorf("response encryption is not configured for profile %s", profileID))
+	}
+
+	encryptionKeyID := profile.OIDCConfig.ResponseEncryption.KeyID
+
+	if kid != oidc4vp.ResponseEncryptionKeyID(profileID, encryptionKeyID) {
+		return nil, "", resterr.NewValidationError(resterr.InvalidValue, "response.kid",
+			fmt.Errorf("response is not encrypted to the encryption key of profile %s", profileID))
+	}
+
+	keyManager, err := c.kmsRegistry.GetKeyManager(profile.KMSConfig)
+	if err != nil {
+		return nil, "", resterr.NewSystemError("kmsRegistry", "GetKeyManager", err)
+	}
+
+	decrypter, err := keyManager.NewJWEDecrypter(encryptionKeyID)
+	if err != nil {
+		return nil, "", resterr.NewSystemError("kmsManager", "NewJWEDecrypter", err)
+	}
+
+	decrypted, err := decrypter.Decrypt(jwe)
+	if err != nil {
+		return nil, "", resterr.NewValidationError(resterr.InvalidValue, "response", err)
+	}
+
+	return decrypted, profileID, nil
+}
+
+func isJWE(response string) bool {
+	return len(strings.Split(response, ".")) == jweCompactParts
+}
+
+func checkJWTAuthorizationResponse(ctx context.Context, authResp *authorizationResponse) error {
+	if authResp.IDToken == "" || authResp.VPToken == "" || authResp.State == "" {
+		return resterr.NewValidationError(resterr.InvalidValue, "response",
+			errors.New("id_token, vp_token and state are required"))
+	}
+
+	logger.WithContext(ctx).Debug("AuthorizationResponse response decoded", log.WithState(authResp.State))
+
+	return nil
+}
+
+func (c *Controller) checkResponseMode(tx *oidc4vp.Transaction, authResp *authorizationResponse) error {
+	jwtExpected := tx.ResponseMode == oidc4vp.ResponseModeDirectPostJWT
+
+	if jwtExpected != (authResp.Response != "") {
+		return resterr.NewValidationError(resterr.InvalidValue, "response_mode",
+			fmt.Errorf("authorization response doesn't match requested response mode"))
+	}
+
+	if authResp.EncryptedFor != "" && authResp.EncryptedFor != tx.ProfileID {
+		return resterr.NewValidationError(resterr.InvalidValue, "response.kid",
+			errors.New("response is encrypted with the key of another profile"))
+	}
+
+	if !jwtExpected || authResp.EncryptedFor != "" {
+		return nil
+	}
+
+	profile, err := c.profileSvc.GetProfile(tx.ProfileID)
+	if err != nil {
+		return resterr.NewSystemError(verifierProfileSvcComponent, "GetProfile", err)
+	}
+
+	if profile != nil && profile.OIDCConfig != nil && profile.OIDCConfig.ResponseEncryption != nil {
+		return resterr.NewValidationError(resterr.InvalidValue, "response",
+			errors.New("authorization response should be encrypted"))
+	}
 
 	return nil
 }
 
-func (c *Controller) RetrieveInteractionsClaim(ctx echo.Context, txID string) error {
-	logger.Debug("RetrieveInteractionsClaim begin")
+func (c *Controller) RetrieveInteractionsClaim(ctx echo.Context, txID string,
+	params RetrieveInteractionsClaimParams) error {
+	logger.WithContext(ctx.Request().Context()).Debug("RetrieveInteractionsClaim begin")
 
 	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
 	if err != nil {
 		return err
 	}
 
-	tx, err := c.accessOIDC4VPTx(txID)
+	tx, err := c.accessOIDC4VPTx(ctx.Request().Context(), txID)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.accessProfile(tx.ProfileID, oidcOrgID)
+	profile, err := c.accessProfile(tx.ProfileID, oidcOrgID)
 	if err != nil {
 		return err
 	}
 
+	// In same-device flow claims are released only to the relying party that received response code.
+	if tx.ResponseCode != "" &&
+		subtle.ConstantTimeCompare([]byte(tx.ResponseCode), []byte(strPtrToStr(params.ResponseCode))) != 1 {
+		return resterr.NewValidationError(resterr.InvalidValue, "response_code",
+			errors.New("response code is missed or invalid"))
+	}
+
+	if tx.MatchReport != nil && !tx.MatchReport.Matched {
+		return resterr.NewValidationError(resterr.ConditionNotMet, "matchReport",
+			fmt.Errorf("presentation submission doesn't match presentation definition: %s",
+				tx.MatchReport.Summary()))
+	}
+
 	claims := c.oidc4VPService.RetrieveClaims(tx)
 
-	logger.Debug("RetrieveInteractionsClaim succeed")
+	if len(claims) > 0 && deleteClaimsAfterRetrieval(profile) {
+		// claims are taken from the deleted transaction, so that concurrent callers can't both retrieve them
+		claims, err = c.oidc4VPService.RetrieveAndDeleteClaims(tx.ID)
+		if errors.Is(err, oidc4vp.ErrDataNotFound) {
+			return resterr.NewValidationError(resterr.DoesntExist, "txID",
+				fmt.Errorf("transaction with given id %s, doesn't exist", txID))
+		}
+
+		if err != nil {
+			return resterr.NewSystemError(oidc4vpSvcComponent, "RetrieveAndDeleteClaims", err)
+		}
+
+		logger.WithContext(ctx.Request().Context()).Debug("RetrieveInteractionsClaim claims deleted",
+			log.WithTxID(string(tx.ID)))
+	}
+
+	logger.WithContext(ctx.Request().Context()).Debug("RetrieveInteractionsClaim succeed")
 
 	return util.WriteOutput(ctx)(claims, nil)
 }
 
-func (c *Controller) accessOIDC4VPTx(txID string) (*oidc4vp.Transaction, error) {
+// DeleteInteractionsClaim purges transaction together with claims obtained during oidc4vp interaction.
+// (DELETE /verifier/interactions/{txID}/claim).
+func (c *Controller) DeleteInteractionsClaim(ctx echo.Context, txID string) error {
+	logger.WithContext(ctx.Request().Context()).Debug("DeleteInteractionsClaim begin")
+
+	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.accessOIDC4VPTx(ctx.Request().Context(), txID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.accessProfile(tx.ProfileID, oidcOrgID)
+	if err != nil {
+		return err
+	}
+
+	err = c.oidc4VPService.DeleteClaims(tx.ID)
+	if err != nil {
+		if errors.Is(err, oidc4vp.ErrDataNotFound) {
+			return resterr.NewValidationError(resterr.DoesntExist, "txID",
+				fmt.Errorf("transaction with given id %s, doesn't exist", txID))
+		}
+
+		return resterr.NewSystemError(oidc4vpSvcComponent, "DeleteClaims", err)
+	}
+
+	logger.WithContext(ctx.Request().Context()).Debug("DeleteInteractionsClaim succeed")
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+// RetrieveInteractionsStatus returns status of oidc4vp interaction together with presentation submission
+// match report. (GET /verifier/interactions/{txID}/status).
+func (c *Controller) RetrieveInteractionsStatus(ctx echo.Context, txID string) error {
+	logger.WithContext(ctx.Request().Context()).Debug("RetrieveInteractionsStatus begin")
+
+	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.accessOIDC4VPTx(ctx.Request().Context(), txID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.accessProfile(tx.ProfileID, oidcOrgID)
+	if err != nil {
+		return err
+	}
+
+	result := &InteractionStatus{Status: Pending}
+
+	if tx.MatchReport != nil {
+		result.Status = Failed
+		if tx.MatchReport.Matched {
+			result.Status = Succeeded
+		}
+
+		result.MatchReport = mapMatchReport(tx.MatchReport)
+	}
+
+	logger.WithContext(ctx.Request().Context()).Debug("RetrieveInteractionsStatus succeed")
+
+	return util.WriteOutput(ctx)(result, nil)
+}
+
+// GetAuthorizationRequestQrCode returns QR code image with authorization request of oidc4vp interaction.
+// (GET /verifier/interactions/{txID}/qr-code).
+func (c *Controller) GetAuthorizationRequestQrCode(ctx echo.Context, txID string,
+	params GetAuthorizationRequestQrCodeParams) error {
+	oidcOrgID, err := util.GetOrgIDFromOIDC(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.accessOIDC4VPTx(ctx.Request().Context(), txID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.accessProfile(tx.ProfileID, oidcOrgID)
+	if err != nil {
+		return err
+	}
+
+	if tx.AuthorizationRequest == "" {
+		return resterr.NewValidationError(resterr.DoesntExist, "txID",
+			fmt.Errorf("authorization request of transaction %s is not available", txID))
+	}
+
+	return util.WriteQRCode(ctx, tx.AuthorizationRequest, (*string)(params.Format), params.Size)
+}
+
+func mapMatchReport(report *oidc4vp.MatchReport) *MatchReport {
+	result := &MatchReport{
+		Matched:          report.Matched,
+		InputDescriptors: make([]InputDescriptorMatch, 0, len(report.InputDescriptors)),
+	}
+
+	for _, descriptor := range report.InputDescriptors {
+		descriptorMatch := InputDescriptorMatch{
+			Id:      descriptor.ID,
+			Matched: descriptor.Matched,
+		}
+
+		if len(descriptor.CredentialIDs) > 0 {
+			credentialIDs := descriptor.CredentialIDs
+			descriptorMatch.CredentialIDs = &credentialIDs
+		}
+
+		if len(descriptor.Failures) > 0 {
+			failures := make([]MatchFailure, 0, len(descriptor.Failures))
+
+			for _, failure := range descriptor.Failures {
+				failures = append(failures, MatchFailure{
+					Reason:  string(failure.Reason),
+					Path:    strToStrPtr(failure.Path),
+					Message: strToStrPtr(failure.Message),
+				})
+			}
+
+			descriptorMatch.Failures = &failures
+		}
+
+		result.InputDescriptors = append(result.InputDescriptors, descriptorMatch)
+	}
+
+	return result
+}
+
+func deleteClaimsAfterRetrieval(profile *profileapi.Verifier) bool {
+	return profile.OIDCConfig != nil && profile.OIDCConfig.ClaimsRetention != nil &&
+		profile.OIDCConfig.ClaimsRetention.DeleteAfterRetrieval
+}
+
+func (c *Controller) accessOIDC4VPTx(ctx context.Context, txID string) (*oidc4vp.Transaction, error) {
 	tx, err := c.oidc4VPService.GetTx(oidc4vp.TxID(txID))
 
 	if err != nil {
@@ -369,16 +755,16 @@ func (c *Controller) accessOIDC4VPTx(txID string) (*oidc4vp.Transaction, error)
 		return nil, resterr.NewSystemError(oidc4vpSvcComponent, "GetTx", err)
 	}
 
-	logger.Debug("RetrieveInteractionsClaim tx found", log.WithTxID(string(tx.ID)))
+	logger.WithContext(ctx).Debug("RetrieveInteractionsClaim tx found", log.WithTxID(string(tx.ID)))
 
 	return tx, nil
 }
 
-func (c *Controller) verifyAuthorizationResponseTokens(authResp *authorizationResponse) (
+func (c *Controller) verifyAuthorizationResponseTokens(ctx context.Context, authResp *authorizationResponse) (
 	*oidc4vp.ProcessedVPToken, error) {
 	startTime := time.Now()
 	defer func() {
-		logger.Debug("validateResponseAuthTokens", log.WithDuration(time.Since(startTime)))
+		logger.WithContext(ctx).Debug("validateResponseAuthTokens", log.WithDuration(time.Since(startTime)))
 	}()
 
 	idTokenClaims, err := validateIDToken(authResp.IDToken, c.jwtVerifier)
@@ -386,14 +772,14 @@ func (c *Controller) verifyAuthorizationResponseTokens(authResp *authorizationRe
 		return nil, err
 	}
 
-	logger.Debug("CheckAuthorizationResponse id_token verified", log.WithIDToken(authResp.IDToken))
+	logger.WithContext(ctx).Debug("CheckAuthorizationResponse id_token verified", log.WithIDToken(authResp.IDToken))
 
 	vpTokenClaims, signer, err := validateVPToken(authResp.VPToken, c.jwtVerifier)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debug("CheckAuthorizationResponse vp_token verified", log.WithVPToken(authResp.VPToken))
+	logger.WithContext(ctx).Debug("CheckAuthorizationResponse vp_token verified", log.WithVPToken(authResp.VPToken))
 
 	if vpTokenClaims.Nonce != idTokenClaims.Nonce {
 		return nil, resterr.NewValidationError(resterr.InvalidValue, "nonce",
@@ -402,7 +788,7 @@ func (c *Controller) verifyAuthorizationResponseTokens(authResp *authorizationRe
 
 	presentation, err := verifiable.ParsePresentation(vpTokenClaims.VP,
 		verifiable.WithPresPublicKeyFetcher(
-			verifiable.NewVDRKeyResolver(c.vdr).PublicKeyFetcher(),
+			verifiable.NewVDRKeyResolver(tracing.WithContext(ctx, c.vdr)).PublicKeyFetcher(),
 		),
 		verifiable.WithPresJSONLDDocumentLoader(c.documentLoader),
 	)
@@ -410,7 +796,7 @@ func (c *Controller) verifyAuthorizationResponseTokens(authResp *authorizationRe
 		return nil, resterr.NewValidationError(resterr.InvalidValue, "vp_token.vp", err)
 	}
 
-	logger.Debug("CheckAuthorizationResponse vp validated")
+	logger.WithContext(ctx).Debug("CheckAuthorizationResponse vp validated")
 
 	presentation.JWT = authResp.VPToken
 	if presentation.CustomFields == nil {
@@ -496,26 +882,39 @@ func validateAuthorizationResponse(ctx echo.Context) (*authorizationResponse, er
 
 	res := &authorizationResponse{}
 
+	if _, ok := req.PostForm["response"]; ok {
+		err = decodeFormValue(&res.Response, "response", req.PostForm)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.WithContext(ctx.Request().Context()).Debug("AuthorizationResponse response decoded")
+
+		return res, nil
+	}
+
 	err = decodeFormValue(&res.IDToken, "id_token", req.PostForm)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debug("AuthorizationResponse id_token decoded", log.WithIDToken(res.IDToken))
+	logger.WithContext(ctx.Request().Context()).Debug("AuthorizationResponse id_token decoded",
+		log.WithIDToken(res.IDToken))
 
 	err = decodeFormValue(&res.VPToken, "vp_token", req.PostForm)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debug("AuthorizationResponse vp_token decoded", log.WithVPToken(res.VPToken))
+	logger.WithContext(ctx.Request().Context()).Debug("AuthorizationResponse vp_token decoded",
+		log.WithVPToken(res.VPToken))
 
 	err = decodeFormValue(&res.State, "state", req.PostForm)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debug("AuthorizationResponse state decoded", log.WithState(res.State))
+	logger.WithContext(ctx.Request().Context()).Debug("AuthorizationResponse state decoded", log.WithState(res.State))
 
 	return res, nil
 }
@@ -646,3 +1045,11 @@ func strPtrToStr(str *string) string {
 
 	return *str
 }
+
+func strToStrPtr(str string) *string {
+	if str == "" {
+		return nil
+	}
+
+	return &str
+}
@@ -8,10 +8,13 @@ package verifier
 
 import (
 	"bytes"
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	_ "embed"
+	"encoding/json"
 	"errors"
+	"image/png"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -31,6 +34,7 @@ import (
 	"github.com/golang/mock/gomock"
 	vdrmock "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
@@ -118,7 +122,7 @@ func TestController_PostVerifyCredentials(t *testing.T) {
 	mockVerifyCredentialSvc := NewMockVerifyCredentialService(gomock.NewController(t))
 
 	mockVerifyCredentialSvc.EXPECT().
-		VerifyCredential(gomock.Any(), gomock.Any(), gomock.Any()).
+		VerifyCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().
 		Return([]verifycredential.CredentialsVerificationCheckResult{{}}, nil)
 
@@ -163,7 +167,7 @@ func TestController_VerifyCredentials(t *testing.T) {
 	mockVerifyCredentialSvc := NewMockVerifyCredentialService(gomock.NewController(t))
 
 	mockVerifyCredentialSvc.EXPECT().
-		VerifyCredential(gomock.Any(), gomock.Any(), gomock.Any()).
+		VerifyCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().
 		Return(verificationResult, nil)
 
@@ -266,7 +270,7 @@ func TestController_VerifyCredentials(t *testing.T) {
 				getVerifyCredentialSvc: func() verifyCredentialSvc {
 					failedMockVerifyCredentialSvc := NewMockVerifyCredentialService(gomock.NewController(t))
 					failedMockVerifyCredentialSvc.EXPECT().
-						VerifyCredential(gomock.Any(), gomock.Any(), gomock.Any()).
+						VerifyCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						AnyTimes().
 						Return(nil, errors.New("some error"))
 					return failedMockVerifyCredentialSvc
@@ -301,7 +305,7 @@ func TestController_PostVerifyPresentation(t *testing.T) {
 	mockVerifyPresSvc := NewMockverifyPresentationSvc(gomock.NewController(t))
 
 	mockVerifyPresSvc.EXPECT().
-		VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any()).
+		VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().
 		Return([]verifypresentation.PresentationVerificationCheckResult{{}}, nil)
 
@@ -346,7 +350,7 @@ func TestController_VerifyPresentation(t *testing.T) {
 	mockVerifyPresentationSvc := NewMockverifyPresentationSvc(gomock.NewController(t))
 
 	mockVerifyPresentationSvc.EXPECT().
-		VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any()).
+		VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().
 		Return(verificationResult, nil)
 
@@ -449,7 +453,7 @@ func TestController_VerifyPresentation(t *testing.T) {
 				getVerifyPresentationSvc: func() verifyPresentationSvc {
 					failedMockVerifyPresSvc := NewMockverifyPresentationSvc(gomock.NewController(t))
 					failedMockVerifyPresSvc.EXPECT().
-						VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any()).
+						VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						AnyTimes().
 						Return(nil, errors.New("some error"))
 					return failedMockVerifyPresSvc
@@ -500,9 +504,357 @@ func TestController_CheckAuthorizationResponse(t *testing.T) {
 	})
 
 	oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
-	oidc4VPService.EXPECT().VerifyOIDCVerifiablePresentation(oidc4vp.TxID("txid"), gomock.Any()).
+	oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+		AnyTimes().Return(&oidc4vp.Transaction{ID: "txid"}, nil)
+	oidc4VPService.EXPECT().VerifyOIDCVerifiablePresentation(gomock.Any(), oidc4vp.TxID("txid"), gomock.Any()).
 		AnyTimes().Return(nil)
 
+	validTokens := func() (string, string) {
+		idToken := generateToken(t, &IDTokenClaims{
+			VPToken: IDTokenVPToken{
+				PresentationSubmission: map[string]interface{}{}},
+			Nonce: "aaa",
+			Exp:   time.Now().Unix() + 1000,
+		}, privKey)
+
+		vpToken := generateToken(t, &vpTokenClaims{
+			VP: &verifiable.Presentation{
+				Context: []string{
+					"https://www.w3.org/2018/credentials/v1",
+					"https://identity.foundation/presentation-exchange/submission/v1",
+				},
+				Type: []string{
+					"VerifiablePresentation",
+					"PresentationSubmission",
+				},
+			},
+			Nonce: "aaa",
+			Exp:   time.Now().Unix() + 1000,
+		}, privKey)
+
+		return idToken, vpToken
+	}
+
+	t.Run("Success same-device flow", func(t *testing.T) {
+		sameDeviceSvc := NewMockOIDC4VPService(gomock.NewController(t))
+		sameDeviceSvc.EXPECT().GetTx(oidc4vp.TxID("txid")).Times(1).Return(&oidc4vp.Transaction{
+			ID:           "txid",
+			ResponseMode: oidc4vp.ResponseModeDirectPost,
+			RedirectURI:  "https://rp.example.com/cb",
+			ResponseCode: "code",
+		}, nil)
+		sameDeviceSvc.EXPECT().VerifyOIDCVerifiablePresentation(gomock.Any(), oidc4vp.TxID("txid"), gomock.Any()).
+			Times(1).Return(nil)
+
+		idToken, vpToken := validTokens()
+
+		ctx := createContextApplicationForm([]byte("vp_token=" + vpToken + "&id_token=" + idToken + "&state=txid"))
+
+		c := NewController(&Config{
+			OIDCVPService:  sameDeviceSvc,
+			JWTVerifier:    sVerifier,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.CheckAuthorizationResponse(ctx)
+		require.NoError(t, err)
+
+		rec, ok := ctx.Response().Writer.(*httptest.ResponseRecorder)
+		require.True(t, ok)
+
+		var result AuthorizationResponseResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.Equal(t, "https://rp.example.com/cb?response_code=code", *result.RedirectUri)
+	})
+
+	t.Run("Success direct_post.jwt", func(t *testing.T) {
+		jwtSvc := NewMockOIDC4VPService(gomock.NewController(t))
+		jwtSvc.EXPECT().GetTx(oidc4vp.TxID("txid")).Times(1).Return(&oidc4vp.Transaction{
+			ID:           "txid",
+			ResponseMode: oidc4vp.ResponseModeDirectPostJWT,
+		}, nil)
+		jwtSvc.EXPECT().VerifyOIDCVerifiablePresentation(gomock.Any(), oidc4vp.TxID("txid"), gomock.Any()).
+			Times(1).Return(nil)
+
+		idToken, vpToken := validTokens()
+
+		response := generateToken(t, &authorizationResponse{
+			IDToken: idToken,
+			VPToken: vpToken,
+			State:   "txid",
+		}, privKey)
+
+		ctx := createContextApplicationForm([]byte("response=" + response))
+
+		profileSvc := NewMockProfileService(gomock.NewController(t))
+		profileSvc.EXPECT().GetProfile(gomock.Any()).Times(1).Return(&profileapi.Verifier{}, nil)
+
+		c := NewController(&Config{
+			OIDCVPService:  jwtSvc,
+			ProfileSvc:     profileSvc,
+			JWTVerifier:    sVerifier,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.CheckAuthorizationResponse(ctx)
+		require.NoError(t, err)
+	})
+
+	encryptedProfile := &profileapi.Verifier{
+		ID: "p1",
+		OIDCConfig: &profileapi.OIDC4VPConfig{
+			ResponseEncryption: &profileapi.ResponseEncryption{KeyID: "key1"},
+		},
+	}
+
+	encryptResponse := func(kid string) string {
+		jwe := &jose.JSONWebEncryption{
+			ProtectedHeaders: jose.Headers{
+				jose.HeaderKeyID:     kid,
+				jose.HeaderAlgorithm: "ECDH-ES+A256KW",
+				"enc":                "A256GCM",
+			},
+			Recipients: []*jose.Recipient{{EncryptedKey: "key"}},
+			IV:         "iv",
+			Ciphertext: "ciphertext",
+			Tag:        "tag",
+		}
+
+		serialized, err := jwe.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		return serialized
+	}
+
+	newDecryptingKMSRegistry := func(payload []byte, err error) *MockKMSRegistry {
+		keyManager := mocks.NewMockVCSKeyManager(gomock.NewController(t))
+		keyManager.EXPECT().NewJWEDecrypter("key1").AnyTimes().Return(&mockJWEDecrypter{payload: payload, err: err}, nil)
+
+		registry := NewMockKMSRegistry(gomock.NewController(t))
+		registry.EXPECT().GetKeyManager(gomock.Any()).AnyTimes().Return(keyManager, nil)
+
+		return registry
+	}
+
+	t.Run("Success encrypted direct_post.jwt", func(t *testing.T) {
+		idToken, vpToken := validTokens()
+
+		signedResponse := generateToken(t, &authorizationResponse{
+			IDToken: idToken,
+			VPToken: vpToken,
+			State:   "txid",
+		}, privKey)
+
+		plainResponse, err := json.Marshal(&authorizationResponse{
+			IDToken: idToken,
+			VPToken: vpToken,
+			State:   "txid",
+		})
+		require.NoError(t, err)
+
+		for _, payload := range [][]byte{[]byte(signedResponse), plainResponse} {
+			jwtSvc := NewMockOIDC4VPService(gomock.NewController(t))
+			jwtSvc.EXPECT().GetTx(oidc4vp.TxID("txid")).Times(1).Return(&oidc4vp.Transaction{
+				ID:           "txid",
+				ProfileID:    "p1",
+				ResponseMode: oidc4vp.ResponseModeDirectPostJWT,
+			}, nil)
+			jwtSvc.EXPECT().VerifyOIDCVerifiablePresentation(gomock.Any(), oidc4vp.TxID("txid"), gomock.Any()).
+				Times(1).Return(nil)
+
+			profileSvc := NewMockProfileService(gomock.NewController(t))
+			profileSvc.EXPECT().GetProfile("p1").Times(1).Return(encryptedProfile, nil)
+
+			c := NewController(&Config{
+				OIDCVPService:  jwtSvc,
+				ProfileSvc:     profileSvc,
+				KMSRegistry:    newDecryptingKMSRegistry(payload, nil),
+				JWTVerifier:    sVerifier,
+				DocumentLoader: testutil.DocumentLoader(t),
+			})
+
+			ctx := createContextApplicationForm([]byte("response=" + encryptResponse("p1#key1")))
+
+			require.NoError(t, c.CheckAuthorizationResponse(ctx))
+		}
+	})
+
+	t.Run("Decryption failed", func(t *testing.T) {
+		profileSvc := NewMockProfileService(gomock.NewController(t))
+		profileSvc.EXPECT().GetProfile("p1").Times(1).Return(encryptedProfile, nil)
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    profileSvc,
+			KMSRegistry:   newDecryptingKMSRegistry(nil, errors.New("decrypt failed")),
+			JWTVerifier:   sVerifier,
+		})
+
+		ctx := createContextApplicationForm([]byte("response=" + encryptResponse("p1#key1")))
+
+		requireValidationError(t, resterr.InvalidValue, "response", c.CheckAuthorizationResponse(ctx))
+	})
+
+	t.Run("Encryption is not configured for profile", func(t *testing.T) {
+		profileSvc := NewMockProfileService(gomock.NewController(t))
+		profileSvc.EXPECT().GetProfile("p2").Times(1).Return(&profileapi.Verifier{ID: "p2"}, nil)
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    profileSvc,
+			JWTVerifier:   sVerifier,
+		})
+
+		ctx := createContextApplicationForm([]byte("response=" + encryptResponse("p2#key1")))
+
+		requireValidationError(t, resterr.InvalidValue, "response.kid", c.CheckAuthorizationResponse(ctx))
+	})
+
+	t.Run("Encrypted with key other than profile encryption key", func(t *testing.T) {
+		profileSvc := NewMockProfileService(gomock.NewController(t))
+		profileSvc.EXPECT().GetProfile("p1").Times(1).Return(encryptedProfile, nil)
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    profileSvc,
+			KMSRegistry:   newDecryptingKMSRegistry(nil, nil),
+			JWTVerifier:   sVerifier,
+		})
+
+		ctx := createContextApplicationForm([]byte("response=" + encryptResponse("p1#key2")))
+
+		requireValidationError(t, resterr.InvalidValue, "response.kid", c.CheckAuthorizationResponse(ctx))
+	})
+
+	t.Run("Invalid encryption kid", func(t *testing.T) {
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		ctx := createContextApplicationForm([]byte("response=" + encryptResponse("key1")))
+
+		requireValidationError(t, resterr.InvalidValue, "response.kid", c.CheckAuthorizationResponse(ctx))
+	})
+
+	t.Run("Encrypted with key of another profile", func(t *testing.T) {
+		idToken, vpToken := validTokens()
+
+		plainResponse, err := json.Marshal(&authorizationResponse{
+			IDToken: idToken,
+			VPToken: vpToken,
+			State:   "txid",
+		})
+		require.NoError(t, err)
+
+		jwtSvc := NewMockOIDC4VPService(gomock.NewController(t))
+		jwtSvc.EXPECT().GetTx(oidc4vp.TxID("txid")).Times(1).Return(&oidc4vp.Transaction{
+			ID:           "txid",
+			ProfileID:    "p2",
+			ResponseMode: oidc4vp.ResponseModeDirectPostJWT,
+		}, nil)
+
+		profileSvc := NewMockProfileService(gomock.NewController(t))
+		profileSvc.EXPECT().GetProfile("p1").Times(1).Return(encryptedProfile, nil)
+
+		c := NewController(&Config{
+			OIDCVPService: jwtSvc,
+			ProfileSvc:    profileSvc,
+			KMSRegistry:   newDecryptingKMSRegistry(plainResponse, nil),
+			JWTVerifier:   sVerifier,
+		})
+
+		ctx := createContextApplicationForm([]byte("response=" + encryptResponse("p1#key1")))
+
+		requireValidationError(t, resterr.InvalidValue, "response.kid", c.CheckAuthorizationResponse(ctx))
+	})
+
+	t.Run("Response should be encrypted", func(t *testing.T) {
+		idToken, vpToken := validTokens()
+
+		response := generateToken(t, &authorizationResponse{
+			IDToken: idToken,
+			VPToken: vpToken,
+			State:   "txid",
+		}, privKey)
+
+		jwtSvc := NewMockOIDC4VPService(gomock.NewController(t))
+		jwtSvc.EXPECT().GetTx(oidc4vp.TxID("txid")).Times(1).Return(&oidc4vp.Transaction{
+			ID:           "txid",
+			ProfileID:    "p1",
+			ResponseMode: oidc4vp.ResponseModeDirectPostJWT,
+		}, nil)
+
+		profileSvc := NewMockProfileService(gomock.NewController(t))
+		profileSvc.EXPECT().GetProfile("p1").Times(1).Return(encryptedProfile, nil)
+
+		c := NewController(&Config{
+			OIDCVPService: jwtSvc,
+			ProfileSvc:    profileSvc,
+			JWTVerifier:   sVerifier,
+		})
+
+		ctx := createContextApplicationForm([]byte("response=" + response))
+
+		requireValidationError(t, resterr.InvalidValue, "response", c.CheckAuthorizationResponse(ctx))
+	})
+
+	t.Run("direct_post.jwt missed state", func(t *testing.T) {
+		idToken, vpToken := validTokens()
+
+		response := generateToken(t, &authorizationResponse{
+			IDToken: idToken,
+			VPToken: vpToken,
+		}, privKey)
+
+		ctx := createContextApplicationForm([]byte("response=" + response))
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		err := c.CheckAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue, "response", err)
+	})
+
+	t.Run("direct_post.jwt invalid signature", func(t *testing.T) {
+		_, privKeyOther, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		response := generateToken(t, &authorizationResponse{State: "txid"}, privKeyOther)
+
+		ctx := createContextApplicationForm([]byte("response=" + response))
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		err = c.CheckAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue, "response", err)
+	})
+
+	t.Run("Response mode mismatch", func(t *testing.T) {
+		idToken, vpToken := validTokens()
+
+		response := generateToken(t, &authorizationResponse{
+			IDToken: idToken,
+			VPToken: vpToken,
+			State:   "txid",
+		}, privKey)
+
+		ctx := createContextApplicationForm([]byte("response=" + response))
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		err := c.CheckAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue, "response_mode", err)
+	})
+
 	t.Run("Success", func(t *testing.T) {
 		idToken := generateToken(t, &IDTokenClaims{
 			VPToken: IDTokenVPToken{
@@ -571,7 +923,7 @@ func TestController_CheckAuthorizationResponse(t *testing.T) {
 			DocumentLoader: testutil.DocumentLoader(t),
 		})
 
-		processedVPToken, err := c.verifyAuthorizationResponseTokens(&authorizationResponse{
+		processedVPToken, err := c.verifyAuthorizationResponseTokens(context.Background(), &authorizationResponse{
 			IDToken: idToken,
 			VPToken: vpToken,
 			State:   "txid",
@@ -690,194 +1042,674 @@ func TestController_CheckAuthorizationResponse(t *testing.T) {
 			"&id_token=" + idToken +
 			"&state=txid"
 
-		ctx := createContextApplicationForm([]byte(body))
+		ctx := createContextApplicationForm([]byte(body))
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		err = c.CheckAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue,
+			"id_token", err)
+	})
+
+	t.Run("Presentation token expired", func(t *testing.T) {
+		idToken := generateToken(t, &IDTokenClaims{
+			VPToken: IDTokenVPToken{
+				PresentationSubmission: map[string]interface{}{}},
+			Nonce: "aaa",
+			Exp:   time.Now().Unix() + 1000,
+		}, privKey)
+
+		vpToken := generateToken(t, &vpTokenClaims{
+			VP:    &verifiable.Presentation{},
+			Nonce: "aaa",
+			Exp:   0,
+		}, privKey)
+
+		body := "vp_token=" + vpToken +
+			"&id_token=" + idToken +
+			"&state=txid"
+
+		ctx := createContextApplicationForm([]byte(body))
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		err := c.CheckAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue,
+			"vp_token.exp", err)
+	})
+
+	t.Run("Presentation token invalid signature", func(t *testing.T) {
+		_, privKeyOther, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		idToken := generateToken(t, &IDTokenClaims{
+			VPToken: IDTokenVPToken{
+				PresentationSubmission: map[string]interface{}{}},
+			Nonce: "aaa",
+			Exp:   time.Now().Unix() + 1000,
+		}, privKey)
+
+		vpToken := generateToken(t, &vpTokenClaims{
+			VP:    &verifiable.Presentation{},
+			Nonce: "aaa",
+			Exp:   time.Now().Unix() + 1000,
+		}, privKeyOther)
+
+		body := "vp_token=" + vpToken +
+			"&id_token=" + idToken +
+			"&state=txid"
+
+		ctx := createContextApplicationForm([]byte(body))
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		err = c.CheckAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue,
+			"vp_token", err)
+	})
+
+	t.Run("Presentation token invalid signature", func(t *testing.T) {
+		idToken := generateToken(t, &IDTokenClaims{
+			VPToken: IDTokenVPToken{
+				PresentationSubmission: map[string]interface{}{}},
+			Nonce: "aaa",
+			Exp:   time.Now().Unix() + 1000,
+		}, privKey)
+
+		vpToken := generateToken(t, &VPTokenClaims{
+			Nonce: "aaa",
+			Exp:   time.Now().Unix() + 1000,
+		}, privKey)
+
+		body := "vp_token=" + vpToken +
+			"&id_token=" + idToken +
+			"&state=txid"
+
+		ctx := createContextApplicationForm([]byte(body))
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			JWTVerifier:   sVerifier,
+		})
+
+		err := c.CheckAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue,
+			"vp_token.vp", err)
+	})
+}
+
+func TestController_RetrieveInteractionsClaim(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(&oidc4vp.Transaction{
+			ProfileID: "p1",
+		}, nil)
+
+		oidc4VPService.EXPECT().RetrieveClaims(gomock.Any()).Times(1).Return(map[string]oidc4vp.CredentialMetadata{})
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+			Return(&profileapi.Verifier{
+				ID:             "p1",
+				OrganizationID: "orgID1",
+				Checks:         verificationChecks,
+			}, nil)
+
+		c := NewController(&Config{
+			OIDCVPService:  oidc4VPService,
+			ProfileSvc:     mockProfileSvc,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+		require.NoError(t, err)
+	})
+
+	t.Run("Same-device flow response code", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(2).Return(&oidc4vp.Transaction{
+			ProfileID:    "p1",
+			ResponseCode: "code",
+		}, nil)
+
+		oidc4VPService.EXPECT().RetrieveClaims(gomock.Any()).Times(1).Return(map[string]oidc4vp.CredentialMetadata{})
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+			Return(&profileapi.Verifier{
+				ID:             "p1",
+				OrganizationID: "orgID1",
+			}, nil)
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    mockProfileSvc,
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid",
+			RetrieveInteractionsClaimParams{ResponseCode: lo.ToPtr("invalid")})
+		requireValidationError(t, resterr.InvalidValue, "response_code", err)
+
+		err = c.RetrieveInteractionsClaim(createContext("orgID1"), "txid",
+			RetrieveInteractionsClaimParams{ResponseCode: lo.ToPtr("code")})
+		require.NoError(t, err)
+	})
+
+	t.Run("Tx not fount", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(nil, oidc4vp.ErrDataNotFound)
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		c := NewController(&Config{
+			OIDCVPService:  oidc4VPService,
+			ProfileSvc:     mockProfileSvc,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+		requireValidationError(t, resterr.DoesntExist, "txID", err)
+	})
+
+	t.Run("Get Tx system error", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(nil, errors.New("system error"))
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		c := NewController(&Config{
+			OIDCVPService:  oidc4VPService,
+			ProfileSvc:     mockProfileSvc,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+		requireSystemError(t, "oidc4vp.Service", "GetTx", err)
+	})
+
+	t.Run("GetProfile failed", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(&oidc4vp.Transaction{
+			ProfileID: "p1",
+		}, nil)
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+			Return(nil, errors.New("data not found"))
+
+		c := NewController(&Config{
+			OIDCVPService:  oidc4VPService,
+			ProfileSvc:     mockProfileSvc,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+		requireValidationError(t, resterr.DoesntExist, "profile", err)
+	})
+
+	t.Run("Success with delete after retrieval", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+		}, nil)
+
+		oidc4VPService.EXPECT().RetrieveClaims(gomock.Any()).Times(1).Return(
+			map[string]oidc4vp.CredentialMetadata{"credID": {}})
+		oidc4VPService.EXPECT().RetrieveAndDeleteClaims(oidc4vp.TxID("txid")).Times(1).Return(
+			map[string]oidc4vp.CredentialMetadata{"credID": {}}, nil)
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+			Return(&profileapi.Verifier{
+				ID:             "p1",
+				OrganizationID: "orgID1",
+				Checks:         verificationChecks,
+				OIDCConfig: &profileapi.OIDC4VPConfig{
+					ClaimsRetention: &profileapi.ClaimsRetention{DeleteAfterRetrieval: true},
+				},
+			}, nil)
+
+		c := NewController(&Config{
+			OIDCVPService:  oidc4VPService,
+			ProfileSvc:     mockProfileSvc,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete after retrieval failed", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+		}, nil)
+
+		oidc4VPService.EXPECT().RetrieveClaims(gomock.Any()).Times(1).Return(
+			map[string]oidc4vp.CredentialMetadata{"credID": {}})
+		oidc4VPService.EXPECT().RetrieveAndDeleteClaims(oidc4vp.TxID("txid")).Times(1).Return(
+			nil, errors.New("delete error"))
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+			Return(&profileapi.Verifier{
+				ID:             "p1",
+				OrganizationID: "orgID1",
+				Checks:         verificationChecks,
+				OIDCConfig: &profileapi.OIDC4VPConfig{
+					ClaimsRetention: &profileapi.ClaimsRetention{DeleteAfterRetrieval: true},
+				},
+			}, nil)
+
+		c := NewController(&Config{
+			OIDCVPService:  oidc4VPService,
+			ProfileSvc:     mockProfileSvc,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+		requireSystemError(t, "oidc4vp.Service", "RetrieveAndDeleteClaims", err)
+	})
+
+	t.Run("Claims already deleted by concurrent retrieval", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+		}, nil)
+
+		oidc4VPService.EXPECT().RetrieveClaims(gomock.Any()).Times(1).Return(
+			map[string]oidc4vp.CredentialMetadata{"credID": {}})
+		oidc4VPService.EXPECT().RetrieveAndDeleteClaims(oidc4vp.TxID("txid")).Times(1).Return(
+			nil, oidc4vp.ErrDataNotFound)
+
+		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+			Return(&profileapi.Verifier{
+				ID:             "p1",
+				OrganizationID: "orgID1",
+				Checks:         verificationChecks,
+				OIDCConfig: &profileapi.OIDC4VPConfig{
+					ClaimsRetention: &profileapi.ClaimsRetention{DeleteAfterRetrieval: true},
+				},
+			}, nil)
+
+		c := NewController(&Config{
+			OIDCVPService:  oidc4VPService,
+			ProfileSvc:     mockProfileSvc,
+			DocumentLoader: testutil.DocumentLoader(t),
+		})
+
+		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+		requireValidationError(t, resterr.DoesntExist, "txID", err)
+	})
+}
+
+func TestController_RetrieveInteractionsClaimMatchFailed(t *testing.T) {
+	oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+	oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+		Times(1).Return(&oidc4vp.Transaction{
+		ProfileID: "p1",
+		MatchReport: &oidc4vp.MatchReport{
+			InputDescriptors: []*oidc4vp.InputDescriptorMatch{{
+				ID:       "descriptor",
+				Failures: []*oidc4vp.MatchFailure{{Reason: oidc4vp.MatchFailureNoSubmission}},
+			}},
+		},
+	}, nil)
+
+	mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+	mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+		Return(&profileapi.Verifier{
+			ID:             "p1",
+			OrganizationID: "orgID1",
+		}, nil)
+
+	c := NewController(&Config{
+		OIDCVPService: oidc4VPService,
+		ProfileSvc:    mockProfileSvc,
+	})
+
+	err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid", RetrieveInteractionsClaimParams{})
+	requireValidationError(t, resterr.ConditionNotMet, "matchReport", err)
+	require.ErrorContains(t, err, "input descriptor descriptor: no_submission")
+}
+
+func TestController_RetrieveInteractionsStatus(t *testing.T) {
+	mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+	mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+		Return(&profileapi.Verifier{
+			ID:             "p1",
+			OrganizationID: "orgID1",
+		}, nil)
+
+	retrieveStatus := func(t *testing.T, tx *oidc4vp.Transaction) *InteractionStatus {
+		t.Helper()
+
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).Times(1).Return(tx, nil)
+
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    mockProfileSvc,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(userHeader, "orgID1")
+
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, c.RetrieveInteractionsStatus(echo.New().NewContext(req, rec), "txid"))
+
+		status := &InteractionStatus{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), status))
+
+		return status
+	}
+
+	t.Run("Pending", func(t *testing.T) {
+		status := retrieveStatus(t, &oidc4vp.Transaction{ID: "txid", ProfileID: "p1"})
+
+		require.Equal(t, Pending, status.Status)
+		require.Nil(t, status.MatchReport)
+	})
+
+	t.Run("Succeeded", func(t *testing.T) {
+		status := retrieveStatus(t, &oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+			MatchReport: &oidc4vp.MatchReport{
+				Matched: true,
+				InputDescriptors: []*oidc4vp.InputDescriptorMatch{{
+					ID:            "descriptor",
+					Matched:       true,
+					CredentialIDs: []string{"credID"},
+				}},
+			},
+		})
+
+		require.Equal(t, Succeeded, status.Status)
+		require.NotNil(t, status.MatchReport)
+		require.True(t, status.MatchReport.Matched)
+		require.Len(t, status.MatchReport.InputDescriptors, 1)
+		require.Equal(t, []string{"credID"}, *status.MatchReport.InputDescriptors[0].CredentialIDs)
+		require.Nil(t, status.MatchReport.InputDescriptors[0].Failures)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		status := retrieveStatus(t, &oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+			MatchReport: &oidc4vp.MatchReport{
+				InputDescriptors: []*oidc4vp.InputDescriptorMatch{{
+					ID: "descriptor",
+					Failures: []*oidc4vp.MatchFailure{{
+						Reason:  oidc4vp.MatchFailureFailedFilter,
+						Path:    "$.credentialSubject.degree",
+						Message: "does not match pattern",
+					}},
+				}},
+			},
+		})
+
+		require.Equal(t, Failed, status.Status)
+		require.NotNil(t, status.MatchReport)
+		require.False(t, status.MatchReport.Matched)
+
+		failures := *status.MatchReport.InputDescriptors[0].Failures
+		require.Len(t, failures, 1)
+		require.Equal(t, string(oidc4vp.MatchFailureFailedFilter), failures[0].Reason)
+		require.Equal(t, "$.credentialSubject.degree", *failures[0].Path)
+		require.Equal(t, "does not match pattern", *failures[0].Message)
+	})
+
+	t.Run("Tx not found", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(nil, oidc4vp.ErrDataNotFound)
 
 		c := NewController(&Config{
 			OIDCVPService: oidc4VPService,
-			JWTVerifier:   sVerifier,
+			ProfileSvc:    mockProfileSvc,
 		})
 
-		err = c.CheckAuthorizationResponse(ctx)
-		requireValidationError(t, resterr.InvalidValue,
-			"id_token", err)
+		err := c.RetrieveInteractionsStatus(createContext("orgID1"), "txid")
+		requireValidationError(t, resterr.DoesntExist, "txID", err)
 	})
 
-	t.Run("Presentation token expired", func(t *testing.T) {
-		idToken := generateToken(t, &IDTokenClaims{
-			VPToken: IDTokenVPToken{
-				PresentationSubmission: map[string]interface{}{}},
-			Nonce: "aaa",
-			Exp:   time.Now().Unix() + 1000,
-		}, privKey)
-
-		vpToken := generateToken(t, &vpTokenClaims{
-			VP:    &verifiable.Presentation{},
-			Nonce: "aaa",
-			Exp:   0,
-		}, privKey)
-
-		body := "vp_token=" + vpToken +
-			"&id_token=" + idToken +
-			"&state=txid"
-
-		ctx := createContextApplicationForm([]byte(body))
+	t.Run("Invalid org id", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+		}, nil)
 
 		c := NewController(&Config{
 			OIDCVPService: oidc4VPService,
-			JWTVerifier:   sVerifier,
+			ProfileSvc:    mockProfileSvc,
 		})
 
-		err := c.CheckAuthorizationResponse(ctx)
-		requireValidationError(t, resterr.InvalidValue,
-			"vp_token.exp", err)
+		err := c.RetrieveInteractionsStatus(createContext("orgID2"), "txid")
+		requireValidationError(t, resterr.DoesntExist, "organizationID", err)
 	})
 
-	t.Run("Presentation token invalid signature", func(t *testing.T) {
-		_, privKeyOther, err := ed25519.GenerateKey(rand.Reader)
-		require.NoError(t, err)
+	t.Run("Missed org id", func(t *testing.T) {
+		c := NewController(&Config{})
 
-		idToken := generateToken(t, &IDTokenClaims{
-			VPToken: IDTokenVPToken{
-				PresentationSubmission: map[string]interface{}{}},
-			Nonce: "aaa",
-			Exp:   time.Now().Unix() + 1000,
-		}, privKey)
+		err := c.RetrieveInteractionsStatus(createContext(""), "txid")
+		requireAuthError(t, err)
+	})
+}
 
-		vpToken := generateToken(t, &vpTokenClaims{
-			VP:    &verifiable.Presentation{},
-			Nonce: "aaa",
-			Exp:   time.Now().Unix() + 1000,
-		}, privKeyOther)
+func TestController_GetAuthorizationRequestQrCode(t *testing.T) {
+	mockProfileSvc := NewMockProfileService(gomock.NewController(t))
 
-		body := "vp_token=" + vpToken +
-			"&id_token=" + idToken +
-			"&state=txid"
+	mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+		Return(&profileapi.Verifier{
+			ID:             "p1",
+			OrganizationID: "orgID1",
+		}, nil)
 
-		ctx := createContextApplicationForm([]byte(body))
+	newController := func(t *testing.T, tx *oidc4vp.Transaction) *Controller {
+		t.Helper()
 
-		c := NewController(&Config{
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).Times(1).Return(tx, nil)
+
+		return NewController(&Config{
 			OIDCVPService: oidc4VPService,
-			JWTVerifier:   sVerifier,
+			ProfileSvc:    mockProfileSvc,
 		})
+	}
 
-		err = c.CheckAuthorizationResponse(ctx)
-		requireValidationError(t, resterr.InvalidValue,
-			"vp_token", err)
+	tx := &oidc4vp.Transaction{
+		ID:                   "txid",
+		ProfileID:            "p1",
+		AuthorizationRequest: "openid-vc://?request_uri=https://vcs.example.com/request-object/1",
+	}
+
+	t.Run("Success PNG", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(userHeader, "orgID1")
+
+		rec := httptest.NewRecorder()
+
+		err := newController(t, tx).GetAuthorizationRequestQrCode(echo.New().NewContext(req, rec), "txid",
+			GetAuthorizationRequestQrCodeParams{Size: lo.ToPtr(128)})
+		require.NoError(t, err)
+		require.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+
+		img, err := png.Decode(rec.Body)
+		require.NoError(t, err)
+		require.Equal(t, 128, img.Bounds().Dx())
 	})
 
-	t.Run("Presentation token invalid signature", func(t *testing.T) {
-		idToken := generateToken(t, &IDTokenClaims{
-			VPToken: IDTokenVPToken{
-				PresentationSubmission: map[string]interface{}{}},
-			Nonce: "aaa",
-			Exp:   time.Now().Unix() + 1000,
-		}, privKey)
+	t.Run("Success SVG", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(userHeader, "orgID1")
 
-		vpToken := generateToken(t, &VPTokenClaims{
-			Nonce: "aaa",
-			Exp:   time.Now().Unix() + 1000,
-		}, privKey)
+		rec := httptest.NewRecorder()
 
-		body := "vp_token=" + vpToken +
-			"&id_token=" + idToken +
-			"&state=txid"
+		err := newController(t, tx).GetAuthorizationRequestQrCode(echo.New().NewContext(req, rec), "txid",
+			GetAuthorizationRequestQrCodeParams{Format: lo.ToPtr(GetAuthorizationRequestQrCodeParamsFormat("svg"))})
+		require.NoError(t, err)
+		require.Equal(t, "image/svg+xml", rec.Header().Get(echo.HeaderContentType))
+		require.Contains(t, rec.Body.String(), "<svg ")
+	})
 
-		ctx := createContextApplicationForm([]byte(body))
+	t.Run("Authorization request is not available", func(t *testing.T) {
+		err := newController(t, &oidc4vp.Transaction{ID: "txid", ProfileID: "p1"}).
+			GetAuthorizationRequestQrCode(createContext("orgID1"), "txid", GetAuthorizationRequestQrCodeParams{})
+		requireValidationError(t, resterr.DoesntExist, "txID", err)
+	})
+
+	t.Run("Tx not found", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(nil, oidc4vp.ErrDataNotFound)
 
 		c := NewController(&Config{
 			OIDCVPService: oidc4VPService,
-			JWTVerifier:   sVerifier,
+			ProfileSvc:    mockProfileSvc,
 		})
 
-		err := c.CheckAuthorizationResponse(ctx)
-		requireValidationError(t, resterr.InvalidValue,
-			"vp_token.vp", err)
+		err := c.GetAuthorizationRequestQrCode(createContext("orgID1"), "txid", GetAuthorizationRequestQrCodeParams{})
+		requireValidationError(t, resterr.DoesntExist, "txID", err)
+	})
+
+	t.Run("Invalid org id", func(t *testing.T) {
+		err := newController(t, tx).
+			GetAuthorizationRequestQrCode(createContext("orgID2"), "txid", GetAuthorizationRequestQrCodeParams{})
+		requireValidationError(t, resterr.DoesntExist, "organizationID", err)
+	})
+
+	t.Run("Missed org id", func(t *testing.T) {
+		c := NewController(&Config{})
+
+		err := c.GetAuthorizationRequestQrCode(createContext(""), "txid", GetAuthorizationRequestQrCodeParams{})
+		requireAuthError(t, err)
 	})
 }
 
-func TestController_RetrieveInteractionsClaim(t *testing.T) {
+func TestController_DeleteInteractionsClaim(t *testing.T) {
+	mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+
+	mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
+		Return(&profileapi.Verifier{
+			ID:             "p1",
+			OrganizationID: "orgID1",
+		}, nil)
+
 	t.Run("Success", func(t *testing.T) {
 		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
 		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
 			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
 			ProfileID: "p1",
 		}, nil)
-
-		oidc4VPService.EXPECT().RetrieveClaims(gomock.Any()).Times(1).Return(map[string]oidc4vp.CredentialMetadata{})
-
-		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
-
-		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
-			Return(&profileapi.Verifier{
-				ID:             "p1",
-				OrganizationID: "orgID1",
-				Checks:         verificationChecks,
-			}, nil)
+		oidc4VPService.EXPECT().DeleteClaims(oidc4vp.TxID("txid")).Times(1).Return(nil)
 
 		c := NewController(&Config{
-			OIDCVPService:  oidc4VPService,
-			ProfileSvc:     mockProfileSvc,
-			DocumentLoader: testutil.DocumentLoader(t),
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    mockProfileSvc,
 		})
 
-		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid")
+		err := c.DeleteInteractionsClaim(createContext("orgID1"), "txid")
 		require.NoError(t, err)
 	})
 
-	t.Run("Tx not fount", func(t *testing.T) {
+	t.Run("Tx not found", func(t *testing.T) {
 		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
 		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
 			Times(1).Return(nil, oidc4vp.ErrDataNotFound)
 
-		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
-
 		c := NewController(&Config{
-			OIDCVPService:  oidc4VPService,
-			ProfileSvc:     mockProfileSvc,
-			DocumentLoader: testutil.DocumentLoader(t),
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    mockProfileSvc,
 		})
 
-		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid")
+		err := c.DeleteInteractionsClaim(createContext("orgID1"), "txid")
 		requireValidationError(t, resterr.DoesntExist, "txID", err)
 	})
 
-	t.Run("Get Tx system error", func(t *testing.T) {
+	t.Run("Invalid org id", func(t *testing.T) {
 		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
 		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
-			Times(1).Return(nil, errors.New("system error"))
-
-		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+		}, nil)
 
 		c := NewController(&Config{
-			OIDCVPService:  oidc4VPService,
-			ProfileSvc:     mockProfileSvc,
-			DocumentLoader: testutil.DocumentLoader(t),
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    mockProfileSvc,
 		})
 
-		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid")
-		requireSystemError(t, "oidc4vp.Service", "GetTx", err)
+		err := c.DeleteInteractionsClaim(createContext("orgID2"), "txid")
+		requireValidationError(t, resterr.DoesntExist, "organizationID", err)
 	})
 
-	t.Run("GetProfile failed", func(t *testing.T) {
+	t.Run("Tx already deleted", func(t *testing.T) {
 		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
 		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
 			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
 			ProfileID: "p1",
 		}, nil)
+		oidc4VPService.EXPECT().DeleteClaims(oidc4vp.TxID("txid")).Times(1).Return(oidc4vp.ErrDataNotFound)
 
-		mockProfileSvc := NewMockProfileService(gomock.NewController(t))
+		c := NewController(&Config{
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    mockProfileSvc,
+		})
 
-		mockProfileSvc.EXPECT().GetProfile("p1").AnyTimes().
-			Return(nil, errors.New("data not found"))
+		err := c.DeleteInteractionsClaim(createContext("orgID1"), "txid")
+		requireValidationError(t, resterr.DoesntExist, "txID", err)
+	})
+
+	t.Run("Delete failed", func(t *testing.T) {
+		oidc4VPService := NewMockOIDC4VPService(gomock.NewController(t))
+		oidc4VPService.EXPECT().GetTx(oidc4vp.TxID("txid")).
+			Times(1).Return(&oidc4vp.Transaction{
+			ID:        "txid",
+			ProfileID: "p1",
+		}, nil)
+		oidc4VPService.EXPECT().DeleteClaims(oidc4vp.TxID("txid")).Times(1).Return(errors.New("delete error"))
 
 		c := NewController(&Config{
-			OIDCVPService:  oidc4VPService,
-			ProfileSvc:     mockProfileSvc,
-			DocumentLoader: testutil.DocumentLoader(t),
+			OIDCVPService: oidc4VPService,
+			ProfileSvc:    mockProfileSvc,
 		})
 
-		err := c.RetrieveInteractionsClaim(createContext("orgID1"), "txid")
-		requireValidationError(t, resterr.DoesntExist, "profile", err)
+		err := c.DeleteInteractionsClaim(createContext("orgID1"), "txid")
+		requireSystemError(t, "oidc4vp.Service", "DeleteClaims", err)
 	})
 }
 
@@ -894,6 +1726,21 @@ func TestController_validateAuthorizationResponse(t *testing.T) {
 		require.NotNil(t, ar)
 	})
 
+	t.Run("Success with response", func(t *testing.T) {
+		ctx := createContextApplicationForm([]byte("response=jwt"))
+
+		ar, err := validateAuthorizationResponse(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "jwt", ar.Response)
+	})
+
+	t.Run("Duplicated response", func(t *testing.T) {
+		ctx := createContextApplicationForm([]byte("response=jwt1&response=jwt2"))
+
+		_, err := validateAuthorizationResponse(ctx)
+		requireValidationError(t, resterr.InvalidValue, "response", err)
+	})
+
 	t.Run("Missed id_token", func(t *testing.T) {
 		body := "vp_token=v1&" +
 			"&state=txid"
@@ -1247,7 +2094,10 @@ func TestController_AuthFailed(t *testing.T) {
 		err := controller.InitiateOidcInteraction(c, "testId")
 		requireAuthError(t, err)
 
-		err = controller.RetrieveInteractionsClaim(c, "testId")
+		err = controller.RetrieveInteractionsClaim(c, "testId", RetrieveInteractionsClaimParams{})
+		requireAuthError(t, err)
+
+		err = controller.DeleteInteractionsClaim(c, "testId")
 		requireAuthError(t, err)
 	})
 
@@ -1281,7 +2131,7 @@ func TestController_InitiateOidcInteraction(t *testing.T) {
 	}, nil)
 
 	oidc4VPSvc := NewMockOIDC4VPService(gomock.NewController(t))
-	oidc4VPSvc.EXPECT().InitiateOidcInteraction(gomock.Any(), gomock.Any(), gomock.Any()).
+	oidc4VPSvc.EXPECT().InitiateOidcInteraction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().Return(&oidc4vp.InteractionInfo{}, nil)
 
 	t.Run("Success", func(t *testing.T) {
@@ -1306,7 +2156,7 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 	mockProfileSvc := NewMockProfileService(gomock.NewController(t))
 
 	oidc4VPSvc := NewMockOIDC4VPService(gomock.NewController(t))
-	oidc4VPSvc.EXPECT().InitiateOidcInteraction(gomock.Any(), gomock.Any(), gomock.Any()).
+	oidc4VPSvc.EXPECT().InitiateOidcInteraction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().Return(&oidc4vp.InteractionInfo{}, nil)
 
 	t.Run("Success", func(t *testing.T) {
@@ -1316,7 +2166,7 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 			OIDCVPService: oidc4VPSvc,
 		})
 
-		result, err := controller.initiateOidcInteraction(&InitiateOIDC4VPData{},
+		result, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{},
 			&profileapi.Verifier{
 				OrganizationID: orgID,
 				Active:         true,
@@ -1331,6 +2181,77 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 		require.NotNil(t, result)
 	})
 
+	t.Run("Invalid redirect uri", func(t *testing.T) {
+		controller := NewController(&Config{
+			ProfileSvc:    mockProfileSvc,
+			KMSRegistry:   kmsRegistry,
+			OIDCVPService: oidc4VPSvc,
+		})
+
+		_, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{RedirectURI: lo.ToPtr("/cb")},
+			&profileapi.Verifier{
+				OrganizationID: orgID,
+				Active:         true,
+				OIDCConfig:     &profileapi.OIDC4VPConfig{},
+				SigningDID:     &profileapi.SigningDID{},
+				PresentationDefinitions: []*presexch.PresentationDefinition{
+					&presexch.PresentationDefinition{},
+				},
+			})
+
+		requireValidationError(t, resterr.InvalidValue, "redirectURI", err)
+	})
+
+	t.Run("Invalid request object encryption key", func(t *testing.T) {
+		controller := NewController(&Config{
+			ProfileSvc:    mockProfileSvc,
+			KMSRegistry:   kmsRegistry,
+			OIDCVPService: oidc4VPSvc,
+		})
+
+		_, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{
+			RequestObjectEncryptionKey: &map[string]interface{}{"kty": "unknown"},
+		},
+			&profileapi.Verifier{
+				OrganizationID: orgID,
+				Active:         true,
+				OIDCConfig:     &profileapi.OIDC4VPConfig{},
+				SigningDID:     &profileapi.SigningDID{},
+				PresentationDefinitions: []*presexch.PresentationDefinition{
+					&presexch.PresentationDefinition{},
+				},
+			})
+
+		requireValidationError(t, resterr.InvalidValue, "requestObjectEncryptionKey", err)
+	})
+
+	t.Run("Request object encryption key is not usable for encryption", func(t *testing.T) {
+		svc := NewMockOIDC4VPService(gomock.NewController(t))
+		svc.EXPECT().InitiateOidcInteraction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, oidc4vp.ErrInvalidRequestObjectEncryptionKey)
+
+		controller := NewController(&Config{
+			ProfileSvc:    mockProfileSvc,
+			KMSRegistry:   kmsRegistry,
+			OIDCVPService: svc,
+		})
+
+		_, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{
+			RequestObjectEncryptionKey: &map[string]interface{}{"kty": "oct", "k": "c2VjcmV0"},
+		},
+			&profileapi.Verifier{
+				OrganizationID: orgID,
+				Active:         true,
+				OIDCConfig:     &profileapi.OIDC4VPConfig{},
+				SigningDID:     &profileapi.SigningDID{},
+				PresentationDefinitions: []*presexch.PresentationDefinition{
+					&presexch.PresentationDefinition{},
+				},
+			})
+
+		requireValidationError(t, resterr.InvalidValue, "requestObjectEncryptionKey", err)
+	})
+
 	t.Run("Should be active", func(t *testing.T) {
 		controller := NewController(&Config{
 			ProfileSvc:    mockProfileSvc,
@@ -1338,7 +2259,7 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 			OIDCVPService: oidc4VPSvc,
 		})
 
-		_, err := controller.initiateOidcInteraction(&InitiateOIDC4VPData{},
+		_, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{},
 			&profileapi.Verifier{
 				OrganizationID: orgID,
 				Active:         false,
@@ -1356,7 +2277,7 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 			OIDCVPService: oidc4VPSvc,
 		})
 
-		_, err := controller.initiateOidcInteraction(&InitiateOIDC4VPData{},
+		_, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{},
 			&profileapi.Verifier{
 				OrganizationID: orgID,
 				Active:         true,
@@ -1376,7 +2297,7 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 			OIDCVPService: oidc4VPSvc,
 		})
 
-		_, err := controller.initiateOidcInteraction(&InitiateOIDC4VPData{},
+		_, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{},
 			&profileapi.Verifier{
 				OrganizationID: orgID,
 				Active:         true,
@@ -1389,7 +2310,7 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 
 	t.Run("oidc4VPService.InitiateOidcInteraction failed", func(t *testing.T) {
 		oidc4VPSvc := NewMockOIDC4VPService(gomock.NewController(t))
-		oidc4VPSvc.EXPECT().InitiateOidcInteraction(gomock.Any(), gomock.Any(), gomock.Any()).
+		oidc4VPSvc.EXPECT().InitiateOidcInteraction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			AnyTimes().Return(nil, errors.New("fail"))
 
 		controller := NewController(&Config{
@@ -1398,7 +2319,7 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 			OIDCVPService: oidc4VPSvc,
 		})
 
-		_, err := controller.initiateOidcInteraction(&InitiateOIDC4VPData{},
+		_, err := controller.initiateOidcInteraction(context.Background(), &InitiateOIDC4VPData{},
 			&profileapi.Verifier{
 				OrganizationID: orgID,
 				Active:         true,
@@ -1413,6 +2334,15 @@ func TestController_initiateOidcInteraction(t *testing.T) {
 	})
 }
 
+type mockJWEDecrypter struct {
+	payload []byte
+	err     error
+}
+
+func (m *mockJWEDecrypter) Decrypt(_ *jose.JSONWebEncryption) ([]byte, error) {
+	return m.payload, m.err
+}
+
 type vpTokenClaims struct {
 	VP    *verifiable.Presentation `json:"vp"`
 	Nonce string                   `json:"nonce"`
@@ -11,18 +11,98 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for InitiateOIDC4VPDataResponseMode.
+const (
+	DirectPost    InitiateOIDC4VPDataResponseMode = "direct_post"
+	DirectPostJwt InitiateOIDC4VPDataResponseMode = "direct_post.jwt"
+	Post          InitiateOIDC4VPDataResponseMode = "post"
+)
+
+// Defines values for InteractionStatusStatus.
+const (
+	Failed    InteractionStatusStatus = "failed"
+	Pending   InteractionStatusStatus = "pending"
+	Succeeded InteractionStatusStatus = "succeeded"
+)
+
+// AuthorizationResponseResult defines model for AuthorizationResponseResult.
+type AuthorizationResponseResult struct {
+	// URI containing response code the wallet should redirect user to. Set in same-device flow only.
+	RedirectUri *string `json:"redirect_uri,omitempty"`
+}
+
 // InitiateOIDC4VPData defines model for InitiateOIDC4VPData.
 type InitiateOIDC4VPData struct {
 	PresentationDefinitionId *string `json:"presentationDefinitionId,omitempty"`
 	Purpose                  *string `json:"purpose,omitempty"`
+
+	// Relying party URI the wallet is redirected to after authorization response is accepted. Enables same-device flow.
+	RedirectURI *string `json:"redirectURI,omitempty"`
+
+	// Pass request object in authorization request by value instead of request_uri.
+	RequestObjectByValue *bool `json:"requestObjectByValue,omitempty"`
+
+	// Wallet public key in JWK format. If set, signed request object is encrypted to this key.
+	RequestObjectEncryptionKey *map[string]interface{} `json:"requestObjectEncryptionKey,omitempty"`
+
+	// Response mode requested from the wallet. Defaults to post.
+	ResponseMode *InitiateOIDC4VPDataResponseMode `json:"responseMode,omitempty"`
 }
 
+// Response mode requested from the wallet. Defaults to post.
+type InitiateOIDC4VPDataResponseMode string
+
 // InitiateOIDC4VPResponse defines model for InitiateOIDC4VPResponse.
 type InitiateOIDC4VPResponse struct {
 	AuthorizationRequest string `json:"authorizationRequest"`
 	TxID                 string `json:"txID"`
 }
 
+// InputDescriptorMatch defines model for InputDescriptorMatch.
+type InputDescriptorMatch struct {
+	// IDs of credentials submitted for input descriptor.
+	CredentialIDs *[]string       `json:"credentialIDs,omitempty"`
+	Failures      *[]MatchFailure `json:"failures,omitempty"`
+
+	// Input descriptor ID.
+	Id      string `json:"id"`
+	Matched bool   `json:"matched"`
+}
+
+// InteractionStatus defines model for InteractionStatus.
+type InteractionStatus struct {
+	// Result of presentation submission match against presentation definition.
+	MatchReport *MatchReport `json:"matchReport,omitempty"`
+
+	// Interaction status. Pending until authorization response is received from the wallet.
+	Status InteractionStatusStatus `json:"status"`
+}
+
+// Interaction status. Pending until authorization response is received from the wallet.
+type InteractionStatusStatus string
+
+// MatchFailure defines model for MatchFailure.
+type MatchFailure struct {
+	Message *string `json:"message,omitempty"`
+
+	// JSON path of constraint field that failed.
+	Path *string `json:"path,omitempty"`
+
+	// One of no_submission, invalid_submission, wrong_format, subject_is_not_issuer, missing_field, failed_filter.
+	Reason string `json:"reason"`
+}
+
+// Result of presentation submission match against presentation definition.
+type MatchReport struct {
+	InputDescriptors []InputDescriptorMatch `json:"inputDescriptors"`
+	Matched          bool                   `json:"matched"`
+}
+
 // Verify credential response containing failure check details.
 type VerifyCredentialCheckResult struct {
 	// Check title.
@@ -90,6 +170,24 @@ type VerifyPresentationResponse struct {
 	Checks *[]VerifyPresentationCheckResult `json:"checks,omitempty"`
 }
 
+// RetrieveInteractionsClaimParams defines parameters for RetrieveInteractionsClaim.
+type RetrieveInteractionsClaimParams struct {
+	// Response code received by relying party in same-device flow. Required if interaction was initiated with redirectURI.
+	ResponseCode *string `form:"response_code,omitempty" json:"response_code,omitempty"`
+}
+
+// GetAuthorizationRequestQrCodeParams defines parameters for GetAuthorizationRequestQrCode.
+type GetAuthorizationRequestQrCodeParams struct {
+	// Image format of the QR code.
+	Format *GetAuthorizationRequestQrCodeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Width and height of the PNG image in pixels. Ignored for SVG.
+	Size *int `form:"size,omitempty" json:"size,omitempty"`
+}
+
+// GetAuthorizationRequestQrCodeParamsFormat defines parameters for GetAuthorizationRequestQrCode.
+type GetAuthorizationRequestQrCodeParamsFormat string
+
 // PostVerifyCredentialsJSONBody defines parameters for PostVerifyCredentials.
 type PostVerifyCredentialsJSONBody = VerifyCredentialData
 
@@ -113,9 +211,18 @@ type ServerInterface interface {
 	// Used by verifier applications to initiate OpenID presentation flow through VCS
 	// (POST /verifier/interactions/authorization-response)
 	CheckAuthorizationResponse(ctx echo.Context) error
+	// Used by verifier applications to purge transaction together with claims obtained during oidc4vp interaction.
+	// (DELETE /verifier/interactions/{txID}/claim)
+	DeleteInteractionsClaim(ctx echo.Context, txID string) error
 	// Used by verifier applications to get claims obtained during oidc4vp interaction.
 	// (GET /verifier/interactions/{txID}/claim)
-	RetrieveInteractionsClaim(ctx echo.Context, txID string) error
+	RetrieveInteractionsClaim(ctx echo.Context, txID string, params RetrieveInteractionsClaimParams) error
+	// Used by verifier applications to get QR code image with authorization request of oidc4vp interaction.
+	// (GET /verifier/interactions/{txID}/qr-code)
+	GetAuthorizationRequestQrCode(ctx echo.Context, txID string, params GetAuthorizationRequestQrCodeParams) error
+	// Used by verifier applications to get status of oidc4vp interaction together with presentation submission match report.
+	// (GET /verifier/interactions/{txID}/status)
+	RetrieveInteractionsStatus(ctx echo.Context, txID string) error
 	// Verify credential
 	// (POST /verifier/profiles/{profileID}/credentials/verify)
 	PostVerifyCredentials(ctx echo.Context, profileID string) error
@@ -141,6 +248,26 @@ func (w *ServerInterfaceWrapper) CheckAuthorizationResponse(ctx echo.Context) er
 	return err
 }
 
+// DeleteInteractionsClaim converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteInteractionsClaim(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "txID" -------------
+	var txID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txID", runtime.ParamLocationPath, ctx.Param("txID"), &txID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"verifier:verify"})
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DeleteInteractionsClaim(ctx, txID)
+	return err
+}
+
 // RetrieveInteractionsClaim converts echo context to params.
 func (w *ServerInterfaceWrapper) RetrieveInteractionsClaim(ctx echo.Context) error {
 	var err error
@@ -152,8 +279,77 @@ func (w *ServerInterfaceWrapper) RetrieveInteractionsClaim(ctx echo.Context) err
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"verifier:verify"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RetrieveInteractionsClaimParams
+	// ------------- Optional query parameter "response_code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "response_code", ctx.QueryParams(), &params.ResponseCode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter response_code: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.RetrieveInteractionsClaim(ctx, txID, params)
+	return err
+}
+
+// GetAuthorizationRequestQrCode converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAuthorizationRequestQrCode(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "txID" -------------
+	var txID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txID", runtime.ParamLocationPath, ctx.Param("txID"), &txID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"verifier:verify"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthorizationRequestQrCodeParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", ctx.QueryParams(), &params.Size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter size: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.GetAuthorizationRequestQrCode(ctx, txID, params)
+	return err
+}
+
+// RetrieveInteractionsStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) RetrieveInteractionsStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "txID" -------------
+	var txID string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "txID", runtime.ParamLocationPath, ctx.Param("txID"), &txID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter txID: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"verifier:verify"})
+
 	// Invoke the callback with all the unmarshalled arguments
-	err = w.Handler.RetrieveInteractionsClaim(ctx, txID)
+	err = w.Handler.RetrieveInteractionsStatus(ctx, txID)
 	return err
 }
 
@@ -168,6 +364,10 @@ func (w *ServerInterfaceWrapper) PostVerifyCredentials(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"verifier:verify"})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostVerifyCredentials(ctx, profileID)
 	return err
@@ -184,6 +384,10 @@ func (w *ServerInterfaceWrapper) InitiateOidcInteraction(ctx echo.Context) error
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"verifier:verify"})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.InitiateOidcInteraction(ctx, profileID)
 	return err
@@ -200,6 +404,10 @@ func (w *ServerInterfaceWrapper) PostVerifyPresentation(ctx echo.Context) error
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
 	}
 
+	ctx.Set(ApiKeyAuthScopes, []string{""})
+
+	ctx.Set(BearerAuthScopes, []string{"verifier:verify"})
+
 	// Invoke the callback with all the unmarshalled arguments
 	err = w.Handler.PostVerifyPresentation(ctx, profileID)
 	return err
@@ -234,7 +442,10 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.POST(baseURL+"/verifier/interactions/authorization-response", wrapper.CheckAuthorizationResponse)
+	router.DELETE(baseURL+"/verifier/interactions/:txID/claim", wrapper.DeleteInteractionsClaim)
 	router.GET(baseURL+"/verifier/interactions/:txID/claim", wrapper.RetrieveInteractionsClaim)
+	router.GET(baseURL+"/verifier/interactions/:txID/qr-code", wrapper.GetAuthorizationRequestQrCode)
+	router.GET(baseURL+"/verifier/interactions/:txID/status", wrapper.RetrieveInteractionsStatus)
 	router.POST(baseURL+"/verifier/profiles/:profileID/credentials/verify", wrapper.PostVerifyCredentials)
 	router.POST(baseURL+"/verifier/profiles/:profileID/interactions/initiate-oidc", wrapper.InitiateOidcInteraction)
 	router.POST(baseURL+"/verifier/profiles/:profileID/presentations/verify", wrapper.PostVerifyPresentation)
@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+type secretsManagerClient interface {
+	GetSecretValueWithContext(ctx aws.Context, input *secretsmanager.GetSecretValueInput,
+		opts ...request.Option) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// AWSProvider reads secrets from AWS Secrets Manager. Handle is the name or ARN of the secret, current
+// version of the secret is returned.
+type AWSProvider struct {
+	client secretsManagerClient
+}
+
+// NewAWSProvider creates AWSProvider.
+func NewAWSProvider(client secretsManagerClient) *AWSProvider {
+	return &AWSProvider{client: client}
+}
+
+// GetSecret returns string value of the secret.
+func (p *AWSProvider) GetSecret(ctx context.Context, handle string) (string, error) {
+	out, err := p.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(handle),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+
+		if errors.As(err, &awsErr) && awsErr.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, handle)
+		}
+
+		return "", fmt.Errorf("get secret value: %w", err)
+	}
+
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", handle)
+	}
+
+	return *out.SecretString, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/stretchr/testify/require"
+)
+
+type secretsManagerStub struct {
+	out *secretsmanager.GetSecretValueOutput
+	err error
+}
+
+func (s *secretsManagerStub) GetSecretValueWithContext(_ aws.Context, input *secretsmanager.GetSecretValueInput,
+	_ ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if aws.StringValue(input.SecretId) != "issuer-secret" {
+		return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+	}
+
+	return s.out, nil
+}
+
+func TestAWSProvider_GetSecret(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p := NewAWSProvider(&secretsManagerStub{
+			out: &secretsmanager.GetSecretValueOutput{SecretString: aws.String("secret")},
+		})
+
+		value, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.NoError(t, err)
+		require.Equal(t, "secret", value)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		p := NewAWSProvider(&secretsManagerStub{})
+
+		_, err := p.GetSecret(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("no string value", func(t *testing.T) {
+		p := NewAWSProvider(&secretsManagerStub{
+			out: &secretsmanager.GetSecretValueOutput{SecretBinary: []byte("secret")},
+		})
+
+		_, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.ErrorContains(t, err, "has no string value")
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		p := NewAWSProvider(&secretsManagerStub{err: errors.New("access denied")})
+
+		_, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.ErrorContains(t, err, "get secret value: access denied")
+	})
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvProvider reads secrets from environment variables. Variable name is the handle prefixed with the given
+// prefix, upper-cased and with characters other than letters and digits replaced by underscore, e.g. handle
+// "issuer-secret" with prefix "VC_REST_SECRET_" is read from VC_REST_SECRET_ISSUER_SECRET.
+type EnvProvider struct {
+	prefix string
+}
+
+// NewEnvProvider creates EnvProvider.
+func NewEnvProvider(prefix string) *EnvProvider {
+	return &EnvProvider{prefix: prefix}
+}
+
+// GetSecret returns value of the environment variable of the secret.
+func (p *EnvProvider) GetSecret(_ context.Context, handle string) (string, error) {
+	name := p.VarName(handle)
+
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, handle)
+	}
+
+	return value, nil
+}
+
+// VarName returns name of the environment variable the secret is read from.
+func (p *EnvProvider) VarName(handle string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return '_'
+		}
+	}, p.prefix+handle)
+}
@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvProvider_GetSecret(t *testing.T) {
+	p := NewEnvProvider("VC_REST_SECRET_")
+
+	t.Run("success", func(t *testing.T) {
+		t.Setenv("VC_REST_SECRET_ISSUER_OIDC4VC_SECRET", "secret")
+
+		value, err := p.GetSecret(context.Background(), "issuer-oidc4vc.secret")
+		require.NoError(t, err)
+		require.Equal(t, "secret", value)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := p.GetSecret(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+}
+
+func TestEnvProvider_VarName(t *testing.T) {
+	require.Equal(t, "ISSUER_SECRET_1", NewEnvProvider("").VarName("issuer-secret/1"))
+	require.Equal(t, "PREFIX_ISSUER_SECRET", NewEnvProvider("prefix_").VarName("Issuer_Secret"))
+}
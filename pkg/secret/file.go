@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider reads secrets from files in the given directory, one file per secret named by its handle.
+// Mounted Kubernetes and Docker secrets follow this layout. File is read on every call, so the secret
+// is rotated by replacing the file.
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider creates FileProvider.
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+// GetSecret returns content of the secret file with trailing whitespace removed.
+func (p *FileProvider) GetSecret(_ context.Context, handle string) (string, error) {
+	// handle must not point outside of secrets directory
+	if handle == "" || handle != filepath.Base(handle) || handle == ".." {
+		return "", fmt.Errorf("invalid secret handle: %q", handle)
+	}
+
+	content, err := os.ReadFile(filepath.Join(p.dir, handle))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, handle)
+		}
+
+		return "", fmt.Errorf("read secret file: %w", err)
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProvider_GetSecret(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "issuer-secret"), []byte("secret\n"), 0600))
+
+	p := NewFileProvider(dir)
+
+	t.Run("success", func(t *testing.T) {
+		value, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.NoError(t, err)
+		require.Equal(t, "secret", value)
+	})
+
+	t.Run("rotated", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "rotated-secret"), []byte("old"), 0600))
+
+		value, err := p.GetSecret(context.Background(), "rotated-secret")
+		require.NoError(t, err)
+		require.Equal(t, "old", value)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "rotated-secret"), []byte("new"), 0600))
+
+		value, err = p.GetSecret(context.Background(), "rotated-secret")
+		require.NoError(t, err)
+		require.Equal(t, "new", value)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := p.GetSecret(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("invalid handle", func(t *testing.T) {
+		for _, handle := range []string{"", "..", "../issuer-secret", "dir/issuer-secret"} {
+			_, err := p.GetSecret(context.Background(), handle)
+			require.ErrorContains(t, err, "invalid secret handle")
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "directory"), 0700))
+
+		_, err := p.GetSecret(context.Background(), "directory")
+		require.ErrorContains(t, err, "read secret file")
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrSecretNotFound is returned when secret with the given handle does not exist.
+var ErrSecretNotFound = errors.New("secret not found")
+
+type provider interface {
+	GetSecret(ctx context.Context, handle string) (string, error)
+}
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// CachingProvider caches secrets resolved by the underlying provider for the given period. Rotated secret
+// is picked up when cached value expires.
+type CachingProvider struct {
+	provider provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewCachingProvider creates CachingProvider.
+func NewCachingProvider(p provider, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{
+		provider: p,
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    map[string]cacheEntry{},
+	}
+}
+
+// GetSecret returns cached secret or resolves it with the underlying provider.
+func (p *CachingProvider) GetSecret(ctx context.Context, handle string) (string, error) {
+	p.mu.Lock()
+	entry, ok := p.cache[handle]
+	p.mu.Unlock()
+
+	if ok && p.now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := p.provider.GetSecret(ctx, handle)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.cache[handle] = cacheEntry{value: value, expiresAt: p.now().Add(p.ttl)}
+	p.mu.Unlock()
+
+	return value, nil
+}
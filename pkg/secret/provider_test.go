@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubProvider struct {
+	values map[string]string
+	err    error
+	calls  int
+}
+
+func (p *stubProvider) GetSecret(_ context.Context, handle string) (string, error) {
+	p.calls++
+
+	if p.err != nil {
+		return "", p.err
+	}
+
+	return p.values[handle], nil
+}
+
+func TestCachingProvider(t *testing.T) {
+	t.Run("cached until expired", func(t *testing.T) {
+		stub := &stubProvider{values: map[string]string{"handle": "secret"}}
+		now := time.Now()
+
+		p := NewCachingProvider(stub, time.Minute)
+		p.now = func() time.Time { return now }
+
+		value, err := p.GetSecret(context.Background(), "handle")
+		require.NoError(t, err)
+		require.Equal(t, "secret", value)
+
+		stub.values["handle"] = "rotated"
+
+		value, err = p.GetSecret(context.Background(), "handle")
+		require.NoError(t, err)
+		require.Equal(t, "secret", value)
+		require.Equal(t, 1, stub.calls)
+
+		now = now.Add(2 * time.Minute)
+
+		value, err = p.GetSecret(context.Background(), "handle")
+		require.NoError(t, err)
+		require.Equal(t, "rotated", value)
+		require.Equal(t, 2, stub.calls)
+	})
+
+	t.Run("error not cached", func(t *testing.T) {
+		stub := &stubProvider{err: errors.New("provider error")}
+
+		p := NewCachingProvider(stub, time.Minute)
+
+		_, err := p.GetSecret(context.Background(), "handle")
+		require.ErrorContains(t, err, "provider error")
+
+		_, err = p.GetSecret(context.Background(), "handle")
+		require.Error(t, err)
+		require.Equal(t, 2, stub.calls)
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultVaultMountPath = "secret"
+	defaultVaultKey       = "value"
+)
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// VaultConfig configures VaultProvider.
+type VaultConfig struct {
+	// Address of Vault server, e.g. https://vault.example.com:8200.
+	Address string
+	Token   string
+	// MountPath of KV version 2 secrets engine. Defaults to "secret".
+	MountPath string
+	// Key of the secret data field holding the secret value. Defaults to "value".
+	Key        string
+	HTTPClient httpClient
+}
+
+// VaultProvider reads secrets from HashiCorp Vault KV version 2 secrets engine. Handle is the path of the secret,
+// latest version of the secret is returned.
+type VaultProvider struct {
+	address    string
+	token      string
+	mountPath  string
+	key        string
+	httpClient httpClient
+}
+
+// NewVaultProvider creates VaultProvider.
+func NewVaultProvider(cfg *VaultConfig) *VaultProvider {
+	p := &VaultProvider{
+		address:    strings.TrimSuffix(cfg.Address, "/"),
+		token:      cfg.Token,
+		mountPath:  strings.Trim(cfg.MountPath, "/"),
+		key:        cfg.Key,
+		httpClient: cfg.HTTPClient,
+	}
+
+	if p.mountPath == "" {
+		p.mountPath = defaultVaultMountPath
+	}
+
+	if p.key == "" {
+		p.key = defaultVaultKey
+	}
+
+	if p.httpClient == nil {
+		p.httpClient = http.DefaultClient
+	}
+
+	return p
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+// GetSecret returns value of the configured key of the secret.
+func (p *VaultProvider) GetSecret(ctx context.Context, handle string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/v1/%s/data/%s", p.address, p.mountPath, strings.TrimPrefix(handle, "/")), http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("create vault request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("read vault secret: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, handle)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("read vault secret: unexpected status code %d", resp.StatusCode)
+	}
+
+	var kv vaultKVResponse
+
+	if err = json.NewDecoder(resp.Body).Decode(&kv); err != nil {
+		return "", fmt.Errorf("decode vault secret: %w", err)
+	}
+
+	value, ok := kv.Data.Data[p.key].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s has no %q key", ErrSecretNotFound, handle, p.key)
+	}
+
+	return value, nil
+}
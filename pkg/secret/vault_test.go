@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingHTTPClient struct{}
+
+func (c *failingHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestVaultProvider_GetSecret(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/kv/data/issuer-secret":
+			_, _ = w.Write([]byte(`{"data":{"data":{"value":"secret","other":"other-secret"}}}`))
+		case "/v1/secret/data/issuer-secret":
+			_, _ = w.Write([]byte(`{"data":{"data":{"value":"default-mount-secret"}}}`))
+		case "/v1/kv/data/invalid":
+			_, _ = w.Write([]byte(`not json`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("success", func(t *testing.T) {
+		p := NewVaultProvider(&VaultConfig{Address: srv.URL + "/", Token: "token", MountPath: "/kv/"})
+
+		value, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.NoError(t, err)
+		require.Equal(t, "secret", value)
+	})
+
+	t.Run("success with custom key and default mount", func(t *testing.T) {
+		p := NewVaultProvider(&VaultConfig{Address: srv.URL, Token: "token"})
+
+		value, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.NoError(t, err)
+		require.Equal(t, "default-mount-secret", value)
+
+		p = NewVaultProvider(&VaultConfig{Address: srv.URL, Token: "token", MountPath: "kv", Key: "other"})
+
+		value, err = p.GetSecret(context.Background(), "issuer-secret")
+		require.NoError(t, err)
+		require.Equal(t, "other-secret", value)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		p := NewVaultProvider(&VaultConfig{Address: srv.URL, Token: "token", MountPath: "kv"})
+
+		_, err := p.GetSecret(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		p := NewVaultProvider(&VaultConfig{Address: srv.URL, Token: "token", MountPath: "kv", Key: "missing"})
+
+		_, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.ErrorIs(t, err, ErrSecretNotFound)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		p := NewVaultProvider(&VaultConfig{Address: srv.URL, Token: "invalid", MountPath: "kv"})
+
+		_, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.ErrorContains(t, err, "unexpected status code 403")
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		p := NewVaultProvider(&VaultConfig{Address: srv.URL, Token: "token", MountPath: "kv"})
+
+		_, err := p.GetSecret(context.Background(), "invalid")
+		require.ErrorContains(t, err, "decode vault secret")
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		p := NewVaultProvider(&VaultConfig{Address: srv.URL, Token: "token", HTTPClient: &failingHTTPClient{}})
+
+		_, err := p.GetSecret(context.Background(), "issuer-secret")
+		require.ErrorContains(t, err, "connection refused")
+	})
+}
@@ -0,0 +1,454 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+//go:generate mockgen -destination clientregistration_service_mocks_test.go -package clientregistration_test -source=clientregistration_service.go -mock_names clientStore=MockClientStore
+
+package clientregistration
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/samber/lo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// AuthMethodNone is a token endpoint authentication method of public clients.
+	AuthMethodNone = "none"
+	// AuthMethodClientSecretBasic is a token endpoint authentication method with HTTP Basic authentication.
+	AuthMethodClientSecretBasic = "client_secret_basic"
+	// AuthMethodClientSecretPost is a token endpoint authentication method with credentials in the request body.
+	AuthMethodClientSecretPost = "client_secret_post"
+
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeRefreshToken      = "refresh_token"
+	responseTypeCode           = "code"
+	defaultScope               = "openid"
+
+	tokenSize = 32
+)
+
+var (
+	ErrDataNotFound                   = errors.New("data not found")
+	ErrRegistrationDisabled           = errors.New("dynamic client registration is disabled")
+	ErrInvalidRedirectURI             = errors.New("invalid redirect uri")
+	ErrInvalidClientMetadata          = errors.New("invalid client metadata")
+	ErrInvalidSoftwareStatement       = errors.New("invalid software statement")
+	ErrUnapprovedSoftwareStatement    = errors.New("unapproved software statement")
+	ErrInvalidInitialAccessToken      = errors.New("invalid initial access token")
+	ErrInvalidRegistrationAccessToken = errors.New("invalid registration access token")
+)
+
+// supportedGrantTypes are grant types dynamically registered clients are allowed to use.
+var supportedGrantTypes = []string{grantTypeAuthorizationCode, grantTypeRefreshToken} //nolint:gochecknoglobals
+
+// supportedResponseTypes are response types dynamically registered clients are allowed to use.
+var supportedResponseTypes = []string{responseTypeCode} //nolint:gochecknoglobals
+
+// supportedAuthMethods are token endpoint authentication methods dynamically registered clients are allowed to use.
+var supportedAuthMethods = []string{ //nolint:gochecknoglobals
+	AuthMethodNone,
+	AuthMethodClientSecretBasic,
+	AuthMethodClientSecretPost,
+}
+
+type clientStore interface {
+	InsertClient(ctx context.Context, client *Client) error
+	// GetClient returns ErrDataNotFound if the client does not exist.
+	GetClient(ctx context.Context, id string) (*Client, error)
+	// UpdateClient returns ErrDataNotFound if the client does not exist.
+	UpdateClient(ctx context.Context, client *Client) error
+	// DeleteClient returns ErrDataNotFound if the client does not exist.
+	DeleteClient(ctx context.Context, id string) error
+}
+
+// Config configures Service.
+type Config struct {
+	Store clientStore
+	// InitialAccessToken restricts registration to the wallets that present the token. Registration is
+	// disabled unless either initial access token is set or software statement is required.
+	InitialAccessToken string
+	// SoftwareStatementVerifier verifies signature of software statements.
+	SoftwareStatementVerifier jose.SignatureVerifier
+	// TrustedSoftwareStatementIssuers are DIDs of issuers whose software statements are accepted.
+	TrustedSoftwareStatementIssuers []string
+	// SoftwareStatementRequired rejects registration requests without software statement.
+	SoftwareStatementRequired bool
+	// AllowedScopes are scopes clients are allowed to register. Defaults to openid.
+	AllowedScopes []string
+}
+
+// Service registers and manages OAuth clients dynamically (RFC 7591, RFC 7592).
+type Service struct {
+	store                     clientStore
+	initialAccessToken        string
+	softwareStatementVerifier jose.SignatureVerifier
+	trustedIssuers            []string
+	softwareStatementRequired bool
+	allowedScopes             []string
+	now                       func() time.Time
+}
+
+// softwareStatementClaims are claims of software statement JWT. Client metadata in the statement takes
+// precedence over the metadata in the request.
+type softwareStatementClaims struct {
+	Issuer                  string   `json:"iss"`
+	RedirectURIs            []string `json:"redirect_uris,omitempty"`
+	GrantTypes              []string `json:"grant_types,omitempty"`
+	ResponseTypes           []string `json:"response_types,omitempty"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method,omitempty"`
+	Name                    string   `json:"client_name,omitempty"`
+	URI                     string   `json:"client_uri,omitempty"`
+	Scope                   string   `json:"scope,omitempty"`
+	Contacts                []string `json:"contacts,omitempty"`
+	SoftwareID              string   `json:"software_id,omitempty"`
+	SoftwareVersion         string   `json:"software_version,omitempty"`
+}
+
+// New creates Service.
+func New(config *Config) *Service {
+	allowedScopes := config.AllowedScopes
+	if len(allowedScopes) == 0 {
+		allowedScopes = []string{defaultScope}
+	}
+
+	return &Service{
+		store:                     config.Store,
+		initialAccessToken:        config.InitialAccessToken,
+		softwareStatementVerifier: config.SoftwareStatementVerifier,
+		trustedIssuers:            config.TrustedSoftwareStatementIssuers,
+		softwareStatementRequired: config.SoftwareStatementRequired,
+		allowedScopes:             allowedScopes,
+		now:                       time.Now,
+	}
+}
+
+// Register registers a new client. The returned client information contains registration access token
+// the client uses to manage its registration, and client secret if the client is confidential.
+// Registration is open only to the wallets that present initial access token or trusted software statement.
+func (s *Service) Register(
+	ctx context.Context,
+	initialAccessToken string,
+	metadata *ClientMetadata,
+) (*ClientInformation, error) {
+	if s.initialAccessToken == "" && !s.softwareStatementRequired {
+		return nil, ErrRegistrationDisabled
+	}
+
+	if s.initialAccessToken != "" &&
+		subtle.ConstantTimeCompare([]byte(initialAccessToken), []byte(s.initialAccessToken)) != 1 {
+		return nil, ErrInvalidInitialAccessToken
+	}
+
+	client := &Client{
+		ID:        uuid.NewString(),
+		CreatedAt: s.now().UTC(),
+	}
+
+	if err := s.applyMetadata(client, metadata); err != nil {
+		return nil, err
+	}
+
+	info := &ClientInformation{
+		Client:            client,
+		SoftwareStatement: metadata.SoftwareStatement,
+	}
+
+	if client.TokenEndpointAuthMethod != AuthMethodNone {
+		secret, err := s.issueSecret(client)
+		if err != nil {
+			return nil, err
+		}
+
+		info.Secret = secret
+	}
+
+	registrationAccessToken, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	client.RegistrationAccessTokenHash = hashToken(registrationAccessToken)
+	info.RegistrationAccessToken = registrationAccessToken
+
+	if err = s.store.InsertClient(ctx, client); err != nil {
+		return nil, fmt.Errorf("insert client: %w", err)
+	}
+
+	return info, nil
+}
+
+// Get returns information of the client authorized by the registration access token.
+func (s *Service) Get(ctx context.Context, clientID, registrationAccessToken string) (*ClientInformation, error) {
+	client, err := s.authorizedClient(ctx, clientID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientInformation{Client: client}, nil
+}
+
+// Update replaces metadata of the client authorized by the registration access token. Client secret is issued
+// if the client becomes confidential and dropped if the client becomes public.
+func (s *Service) Update(
+	ctx context.Context,
+	clientID, registrationAccessToken string,
+	metadata *ClientMetadata,
+) (*ClientInformation, error) {
+	client, err := s.authorizedClient(ctx, clientID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.applyMetadata(client, metadata); err != nil {
+		return nil, err
+	}
+
+	info := &ClientInformation{
+		Client:            client,
+		SoftwareStatement: metadata.SoftwareStatement,
+	}
+
+	switch {
+	case client.TokenEndpointAuthMethod == AuthMethodNone:
+		client.SecretHash = nil
+	case len(client.SecretHash) == 0:
+		secret, secretErr := s.issueSecret(client)
+		if secretErr != nil {
+			return nil, secretErr
+		}
+
+		info.Secret = secret
+	}
+
+	if err = s.store.UpdateClient(ctx, client); err != nil {
+		return nil, fmt.Errorf("update client: %w", err)
+	}
+
+	return info, nil
+}
+
+// Delete deregisters the client authorized by the registration access token.
+func (s *Service) Delete(ctx context.Context, clientID, registrationAccessToken string) error {
+	if _, err := s.authorizedClient(ctx, clientID, registrationAccessToken); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteClient(ctx, clientID); err != nil && !errors.Is(err, ErrDataNotFound) {
+		return fmt.Errorf("delete client: %w", err)
+	}
+
+	return nil
+}
+
+// authorizedClient returns the client if the registration access token is valid for it. Unknown clients and
+// clients registered without registration access token (e.g. loaded from file) are not disclosed.
+func (s *Service) authorizedClient(ctx context.Context, clientID, registrationAccessToken string) (*Client, error) {
+	if registrationAccessToken == "" {
+		return nil, ErrInvalidRegistrationAccessToken
+	}
+
+	client, err := s.store.GetClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, ErrDataNotFound) {
+			return nil, ErrInvalidRegistrationAccessToken
+		}
+
+		return nil, fmt.Errorf("get client: %w", err)
+	}
+
+	if len(client.RegistrationAccessTokenHash) == 0 ||
+		subtle.ConstantTimeCompare(client.RegistrationAccessTokenHash, hashToken(registrationAccessToken)) != 1 {
+		return nil, ErrInvalidRegistrationAccessToken
+	}
+
+	return client, nil
+}
+
+// applyMetadata validates the metadata and sets it to the client, applying defaults for omitted values.
+func (s *Service) applyMetadata(client *Client, metadata *ClientMetadata) error {
+	m := *metadata
+
+	if m.SoftwareStatement != "" {
+		if err := s.applySoftwareStatement(&m); err != nil {
+			return err
+		}
+	} else if s.softwareStatementRequired {
+		return fmt.Errorf("%w: software statement is required", ErrInvalidSoftwareStatement)
+	}
+
+	grantTypes := lo.Ternary(len(m.GrantTypes) > 0, m.GrantTypes, []string{grantTypeAuthorizationCode})
+	responseTypes := lo.Ternary(len(m.ResponseTypes) > 0, m.ResponseTypes, []string{responseTypeCode})
+	authMethod := lo.Ternary(m.TokenEndpointAuthMethod != "", m.TokenEndpointAuthMethod,
+		AuthMethodClientSecretBasic)
+
+	if err := validateValues("grant_types", grantTypes, supportedGrantTypes); err != nil {
+		return err
+	}
+
+	if err := validateValues("response_types", responseTypes, supportedResponseTypes); err != nil {
+		return err
+	}
+
+	if err := validateValues("token_endpoint_auth_method", []string{authMethod}, supportedAuthMethods); err != nil {
+		return err
+	}
+
+	scopes := strings.Fields(lo.Ternary(m.Scope != "", m.Scope, defaultScope))
+
+	if err := validateValues("scope", scopes, s.allowedScopes); err != nil {
+		return err
+	}
+
+	if err := validateRedirectURIs(m.RedirectURIs, grantTypes); err != nil {
+		return err
+	}
+
+	if m.URI != "" {
+		if u, err := url.Parse(m.URI); err != nil || !u.IsAbs() {
+			return fmt.Errorf("%w: client_uri must be an absolute URL", ErrInvalidClientMetadata)
+		}
+	}
+
+	client.RedirectURIs = m.RedirectURIs
+	client.GrantTypes = grantTypes
+	client.ResponseTypes = responseTypes
+	client.TokenEndpointAuthMethod = authMethod
+	client.Scopes = scopes
+	client.Name = m.Name
+	client.URI = m.URI
+	client.Contacts = m.Contacts
+	client.SoftwareID = m.SoftwareID
+	client.SoftwareVersion = m.SoftwareVersion
+
+	return nil
+}
+
+// applySoftwareStatement verifies the software statement and overrides the metadata with its claims.
+func (s *Service) applySoftwareStatement(m *ClientMetadata) error {
+	if s.softwareStatementVerifier == nil {
+		return fmt.Errorf("%w: software statements are not supported", ErrUnapprovedSoftwareStatement)
+	}
+
+	token, err := jwt.Parse(m.SoftwareStatement, jwt.WithSignatureVerifier(s.softwareStatementVerifier))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSoftwareStatement, err.Error())
+	}
+
+	var claims softwareStatementClaims
+
+	if err = token.DecodeClaims(&claims); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSoftwareStatement, err.Error())
+	}
+
+	if claims.Issuer == "" {
+		return fmt.Errorf("%w: missing iss claim", ErrInvalidSoftwareStatement)
+	}
+
+	// statement must be signed by the key of its issuer
+	if kid, _ := token.Headers.KeyID(); strings.Split(kid, "#")[0] != claims.Issuer {
+		return fmt.Errorf("%w: kid header does not belong to the issuer", ErrInvalidSoftwareStatement)
+	}
+
+	if !lo.Contains(s.trustedIssuers, claims.Issuer) {
+		return fmt.Errorf("%w: issuer %s is not trusted", ErrUnapprovedSoftwareStatement, claims.Issuer)
+	}
+
+	overrideSlice(&m.RedirectURIs, claims.RedirectURIs)
+	overrideSlice(&m.GrantTypes, claims.GrantTypes)
+	overrideSlice(&m.ResponseTypes, claims.ResponseTypes)
+	overrideSlice(&m.Contacts, claims.Contacts)
+	overrideString(&m.TokenEndpointAuthMethod, claims.TokenEndpointAuthMethod)
+	overrideString(&m.Name, claims.Name)
+	overrideString(&m.URI, claims.URI)
+	overrideString(&m.Scope, claims.Scope)
+	overrideString(&m.SoftwareID, claims.SoftwareID)
+	overrideString(&m.SoftwareVersion, claims.SoftwareVersion)
+
+	return nil
+}
+
+func (s *Service) issueSecret(client *Client) (string, error) {
+	secret, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	// secret is hashed with bcrypt as expected by the default fosite hasher
+	client.SecretHash, err = bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash client secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+func validateValues(name string, values, supported []string) error {
+	for _, v := range values {
+		if !lo.Contains(supported, v) {
+			return fmt.Errorf("%w: %s value %q is not supported", ErrInvalidClientMetadata, name, v)
+		}
+	}
+
+	return nil
+}
+
+// validateRedirectURIs checks that redirect URIs are absolute URIs without fragment (RFC 6749, section 3.1.2).
+// Custom schemes are allowed for native wallets.
+func validateRedirectURIs(redirectURIs, grantTypes []string) error {
+	if len(redirectURIs) == 0 && lo.Contains(grantTypes, grantTypeAuthorizationCode) {
+		return fmt.Errorf("%w: redirect_uris are required", ErrInvalidRedirectURI)
+	}
+
+	for _, redirectURI := range redirectURIs {
+		u, err := url.Parse(redirectURI)
+		if err != nil || !u.IsAbs() || u.Fragment != "" {
+			return fmt.Errorf("%w: %s", ErrInvalidRedirectURI, redirectURI)
+		}
+	}
+
+	return nil
+}
+
+func overrideSlice(dst *[]string, src []string) {
+	if len(src) > 0 {
+		*dst = src
+	}
+}
+
+func overrideString(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, tokenSize)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+
+	return hash[:]
+}
@@ -0,0 +1,467 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clientregistration_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/trustbloc/vcs/pkg/service/clientregistration"
+)
+
+const (
+	statementIssuer = "did:example:wallet-vendor"
+	initialToken    = "initial-access-token"
+)
+
+func TestService_Register(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	verifier, err := jwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	var (
+		mockStore *MockClientStore
+		config    *clientregistration.Config
+		metadata  *clientregistration.ClientMetadata
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, info *clientregistration.ClientInformation, err error)
+	}{
+		{
+			name: "Success confidential client",
+			setup: func() {
+				config.AllowedScopes = []string{"openid", "profile"}
+
+				metadata = &clientregistration.ClientMetadata{
+					RedirectURIs: []string{"https://wallet.example.com/callback"},
+					Name:         "Wallet",
+					Scope:        "openid profile",
+				}
+
+				mockStore.EXPECT().InsertClient(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, info.Client.ID)
+				require.NotEmpty(t, info.Secret)
+				require.NotEmpty(t, info.RegistrationAccessToken)
+				require.NoError(t, bcrypt.CompareHashAndPassword(info.Client.SecretHash, []byte(info.Secret)))
+
+				hash := sha256.Sum256([]byte(info.RegistrationAccessToken))
+				require.Equal(t, hash[:], info.Client.RegistrationAccessTokenHash)
+
+				require.Equal(t, []string{"authorization_code"}, info.Client.GrantTypes)
+				require.Equal(t, []string{"code"}, info.Client.ResponseTypes)
+				require.Equal(t, []string{"openid", "profile"}, info.Client.Scopes)
+				require.Equal(t, clientregistration.AuthMethodClientSecretBasic, info.Client.TokenEndpointAuthMethod)
+				require.Equal(t, "Wallet", info.Client.Name)
+				require.False(t, info.Client.CreatedAt.IsZero())
+			},
+		},
+		{
+			name: "Success public client with custom scheme redirect uri",
+			setup: func() {
+				metadata = &clientregistration.ClientMetadata{
+					RedirectURIs:            []string{"com.example.wallet:/callback"},
+					TokenEndpointAuthMethod: clientregistration.AuthMethodNone,
+				}
+
+				mockStore.EXPECT().InsertClient(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.NoError(t, err)
+				require.Empty(t, info.Secret)
+				require.Empty(t, info.Client.SecretHash)
+				require.Equal(t, []string{"openid"}, info.Client.Scopes)
+			},
+		},
+		{
+			name: "Success client with refresh token grant",
+			setup: func() {
+				metadata = &clientregistration.ClientMetadata{
+					RedirectURIs: []string{"https://wallet.example.com/callback"},
+					GrantTypes:   []string{"authorization_code", "refresh_token"},
+				}
+
+				mockStore.EXPECT().InsertClient(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"authorization_code", "refresh_token"}, info.Client.GrantTypes)
+			},
+		},
+		{
+			name: "Registration is disabled",
+			setup: func() {
+				config.InitialAccessToken = ""
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrRegistrationDisabled)
+			},
+		},
+		{
+			name: "Success with software statement",
+			setup: func() {
+				config.InitialAccessToken = ""
+				config.SoftwareStatementVerifier = verifier
+				config.TrustedSoftwareStatementIssuers = []string{statementIssuer}
+				config.SoftwareStatementRequired = true
+
+				metadata.Name = "Overridden"
+				metadata.SoftwareStatement = signStatement(t, privKey, statementIssuer+"#key1", map[string]interface{}{
+					"iss":         statementIssuer,
+					"client_name": "Wallet",
+					"software_id": "wallet-app",
+				})
+
+				mockStore.EXPECT().InsertClient(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "Wallet", info.Client.Name)
+				require.Equal(t, "wallet-app", info.Client.SoftwareID)
+				require.Equal(t, metadata.SoftwareStatement, info.SoftwareStatement)
+			},
+		},
+		{
+			name: "Invalid initial access token",
+			setup: func() {
+				config.InitialAccessToken = "other-token"
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidInitialAccessToken)
+			},
+		},
+		{
+			name: "Missing redirect uris",
+			setup: func() {
+				metadata.RedirectURIs = nil
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidRedirectURI)
+			},
+		},
+		{
+			name: "Redirect uri with fragment",
+			setup: func() {
+				metadata.RedirectURIs = []string{"https://wallet.example.com/callback#fragment"}
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidRedirectURI)
+			},
+		},
+		{
+			name: "Relative redirect uri",
+			setup: func() {
+				metadata.RedirectURIs = []string{"/callback"}
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidRedirectURI)
+			},
+		},
+		{
+			name: "Unsupported grant type",
+			setup: func() {
+				metadata.GrantTypes = []string{"client_credentials"}
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidClientMetadata)
+				require.ErrorContains(t, err, "grant_types")
+			},
+		},
+		{
+			name: "Scope is not allowed",
+			setup: func() {
+				config.AllowedScopes = []string{"openid", "profile"}
+				metadata.Scope = "openid admin"
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidClientMetadata)
+				require.ErrorContains(t, err, "scope value \"admin\"")
+			},
+		},
+		{
+			name: "Scope other than openid is not allowed by default",
+			setup: func() {
+				metadata.Scope = "openid profile"
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidClientMetadata)
+				require.ErrorContains(t, err, "scope value \"profile\"")
+			},
+		},
+		{
+			name: "Unsupported token endpoint auth method",
+			setup: func() {
+				metadata.TokenEndpointAuthMethod = "private_key_jwt"
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidClientMetadata)
+				require.ErrorContains(t, err, "token_endpoint_auth_method")
+			},
+		},
+		{
+			name: "Unsupported response type",
+			setup: func() {
+				metadata.ResponseTypes = []string{"token"}
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidClientMetadata)
+				require.ErrorContains(t, err, "response_types")
+			},
+		},
+		{
+			name: "Invalid client uri",
+			setup: func() {
+				metadata.URI = "wallet.example.com"
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidClientMetadata)
+				require.ErrorContains(t, err, "client_uri")
+			},
+		},
+		{
+			name: "Software statement required",
+			setup: func() {
+				config.SoftwareStatementRequired = true
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidSoftwareStatement)
+			},
+		},
+		{
+			name: "Software statements not supported",
+			setup: func() {
+				metadata.SoftwareStatement = signStatement(t, privKey, statementIssuer+"#key1", map[string]interface{}{
+					"iss": statementIssuer,
+				})
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrUnapprovedSoftwareStatement)
+			},
+		},
+		{
+			name: "Software statement with invalid signature",
+			setup: func() {
+				_, otherKey, keyErr := ed25519.GenerateKey(rand.Reader)
+				require.NoError(t, keyErr)
+
+				config.SoftwareStatementVerifier = verifier
+				config.TrustedSoftwareStatementIssuers = []string{statementIssuer}
+
+				metadata.SoftwareStatement = signStatement(t, otherKey, statementIssuer+"#key1", map[string]interface{}{
+					"iss": statementIssuer,
+				})
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidSoftwareStatement)
+			},
+		},
+		{
+			name: "Software statement without issuer",
+			setup: func() {
+				config.SoftwareStatementVerifier = verifier
+
+				metadata.SoftwareStatement = signStatement(t, privKey, statementIssuer+"#key1", map[string]interface{}{
+					"client_name": "Wallet",
+				})
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidSoftwareStatement)
+				require.ErrorContains(t, err, "missing iss claim")
+			},
+		},
+		{
+			name: "Software statement signed by key of other issuer",
+			setup: func() {
+				config.SoftwareStatementVerifier = verifier
+				config.TrustedSoftwareStatementIssuers = []string{statementIssuer}
+
+				metadata.SoftwareStatement = signStatement(t, privKey, "did:example:other#key1", map[string]interface{}{
+					"iss": statementIssuer,
+				})
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrInvalidSoftwareStatement)
+				require.ErrorContains(t, err, "kid header")
+			},
+		},
+		{
+			name: "Software statement of untrusted issuer",
+			setup: func() {
+				config.SoftwareStatementVerifier = verifier
+				config.TrustedSoftwareStatementIssuers = []string{"did:example:other"}
+
+				metadata.SoftwareStatement = signStatement(t, privKey, statementIssuer+"#key1", map[string]interface{}{
+					"iss": statementIssuer,
+				})
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorIs(t, err, clientregistration.ErrUnapprovedSoftwareStatement)
+			},
+		},
+		{
+			name: "Insert client error",
+			setup: func() {
+				mockStore.EXPECT().InsertClient(gomock.Any(), gomock.Any()).Return(errors.New("insert error"))
+			},
+			check: func(t *testing.T, info *clientregistration.ClientInformation, err error) {
+				require.ErrorContains(t, err, "insert client: insert error")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore = NewMockClientStore(gomock.NewController(t))
+
+			config = &clientregistration.Config{
+				Store:              mockStore,
+				InitialAccessToken: initialToken,
+			}
+
+			metadata = &clientregistration.ClientMetadata{
+				RedirectURIs: []string{"https://wallet.example.com/callback"},
+			}
+
+			tt.setup()
+
+			info, err := clientregistration.New(config).Register(context.Background(), initialToken, metadata)
+			tt.check(t, info, err)
+		})
+	}
+}
+
+func TestService_Manage(t *testing.T) {
+	mockStore := NewMockClientStore(gomock.NewController(t))
+
+	var registered *clientregistration.Client
+
+	mockStore.EXPECT().InsertClient(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, client *clientregistration.Client) error {
+			registered = client
+
+			return nil
+		})
+
+	srv := clientregistration.New(&clientregistration.Config{Store: mockStore, InitialAccessToken: initialToken})
+
+	info, err := srv.Register(context.Background(), initialToken, &clientregistration.ClientMetadata{
+		RedirectURIs:            []string{"https://wallet.example.com/callback"},
+		TokenEndpointAuthMethod: clientregistration.AuthMethodNone,
+	})
+	require.NoError(t, err)
+
+	clientID := info.Client.ID
+	token := info.RegistrationAccessToken
+
+	mockStore.EXPECT().GetClient(gomock.Any(), clientID).DoAndReturn(
+		func(ctx context.Context, id string) (*clientregistration.Client, error) {
+			c := *registered
+
+			return &c, nil
+		}).AnyTimes()
+	mockStore.EXPECT().GetClient(gomock.Any(), "unknown").Return(nil, clientregistration.ErrDataNotFound).AnyTimes()
+
+	t.Run("Get", func(t *testing.T) {
+		got, getErr := srv.Get(context.Background(), clientID, token)
+		require.NoError(t, getErr)
+		require.Equal(t, clientID, got.Client.ID)
+		require.Empty(t, got.Secret)
+		require.Empty(t, got.RegistrationAccessToken)
+	})
+
+	t.Run("Invalid registration access token", func(t *testing.T) {
+		_, getErr := srv.Get(context.Background(), clientID, "invalid")
+		require.ErrorIs(t, getErr, clientregistration.ErrInvalidRegistrationAccessToken)
+
+		_, getErr = srv.Get(context.Background(), clientID, "")
+		require.ErrorIs(t, getErr, clientregistration.ErrInvalidRegistrationAccessToken)
+
+		_, getErr = srv.Get(context.Background(), "unknown", token)
+		require.ErrorIs(t, getErr, clientregistration.ErrInvalidRegistrationAccessToken)
+
+		require.ErrorIs(t, srv.Delete(context.Background(), clientID, "invalid"),
+			clientregistration.ErrInvalidRegistrationAccessToken)
+	})
+
+	t.Run("Update to confidential client issues secret", func(t *testing.T) {
+		mockStore.EXPECT().UpdateClient(gomock.Any(), gomock.Any()).Return(nil)
+
+		updated, updateErr := srv.Update(context.Background(), clientID, token, &clientregistration.ClientMetadata{
+			RedirectURIs:            []string{"https://wallet.example.com/callback2"},
+			TokenEndpointAuthMethod: clientregistration.AuthMethodClientSecretPost,
+		})
+		require.NoError(t, updateErr)
+		require.NotEmpty(t, updated.Secret)
+		require.Equal(t, []string{"https://wallet.example.com/callback2"}, updated.Client.RedirectURIs)
+		require.Equal(t, registered.CreatedAt, updated.Client.CreatedAt)
+		require.Equal(t, registered.RegistrationAccessTokenHash, updated.Client.RegistrationAccessTokenHash)
+	})
+
+	t.Run("Update with invalid metadata", func(t *testing.T) {
+		_, updateErr := srv.Update(context.Background(), clientID, token, &clientregistration.ClientMetadata{})
+		require.ErrorIs(t, updateErr, clientregistration.ErrInvalidRedirectURI)
+	})
+
+	t.Run("Update error", func(t *testing.T) {
+		mockStore.EXPECT().UpdateClient(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+
+		_, updateErr := srv.Update(context.Background(), clientID, token, &clientregistration.ClientMetadata{
+			RedirectURIs: []string{"https://wallet.example.com/callback"},
+		})
+		require.ErrorContains(t, updateErr, "update client: update error")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mockStore.EXPECT().DeleteClient(gomock.Any(), clientID).Return(nil)
+
+		require.NoError(t, srv.Delete(context.Background(), clientID, token))
+	})
+
+	t.Run("Delete error", func(t *testing.T) {
+		mockStore.EXPECT().DeleteClient(gomock.Any(), clientID).Return(errors.New("delete error"))
+
+		require.ErrorContains(t, srv.Delete(context.Background(), clientID, token), "delete client: delete error")
+	})
+}
+
+func TestService_GetClientError(t *testing.T) {
+	mockStore := NewMockClientStore(gomock.NewController(t))
+	mockStore.EXPECT().GetClient(gomock.Any(), "client-id").Return(nil, errors.New("get error"))
+
+	_, err := clientregistration.New(&clientregistration.Config{Store: mockStore}).
+		Get(context.Background(), "client-id", "token")
+	require.ErrorContains(t, err, "get client: get error")
+}
+
+func signStatement(t *testing.T, privKey ed25519.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	token, err := jwt.NewSigned(claims, jose.Headers{jose.HeaderKeyID: kid}, jwt.NewEd25519Signer(privKey))
+	require.NoError(t, err)
+
+	statement, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return statement
+}
@@ -0,0 +1,53 @@
+/*
+Copyright Avast Software. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clientregistration
+
+import "time"
+
+// ClientMetadata is a client metadata sent by the wallet in registration and update requests (RFC 7591).
+type ClientMetadata struct {
+	RedirectURIs            []string
+	GrantTypes              []string
+	ResponseTypes           []string
+	TokenEndpointAuthMethod string
+	Name                    string
+	URI                     string
+	Scope                   string
+	Contacts                []string
+	SoftwareID              string
+	SoftwareVersion         string
+	SoftwareStatement       string
+}
+
+// Client is a dynamically registered OAuth client.
+type Client struct {
+	ID                          string
+	SecretHash                  []byte
+	RegistrationAccessTokenHash []byte
+	RedirectURIs                []string
+	GrantTypes                  []string
+	ResponseTypes               []string
+	Scopes                      []string
+	TokenEndpointAuthMethod     string
+	Name                        string
+	URI                         string
+	Contacts                    []string
+	SoftwareID                  string
+	SoftwareVersion             string
+	CreatedAt                   time.Time
+}
+
+// ClientInformation is a client information returned to the wallet (RFC 7591, section 3.2.1).
+type ClientInformation struct {
+	Client *Client
+	// Secret is set only when the client secret is issued.
+	Secret string
+	// RegistrationAccessToken is set only on registration.
+	RegistrationAccessToken string
+	// SoftwareStatement is the software statement the client metadata was taken from.
+	SoftwareStatement string
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package credentialstatus
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -60,7 +61,7 @@ const (
 )
 
 type crypto interface {
-	SignCredential(signerData *vc.Signer, vc *verifiable.Credential,
+	SignCredential(ctx context.Context, signerData *vc.Signer, vc *verifiable.Credential,
 		opts ...vccrypto.SigningOpts) (*verifiable.Credential, error)
 }
 
@@ -108,9 +109,9 @@ func New(cslStore cslStore, vcStore vcStore, listSize int, c crypto,
 }
 
 // CreateStatusID creates status ID.
-func (s *Service) CreateStatusID(profile *vc.Signer,
+func (s *Service) CreateStatusID(ctx context.Context, profile *vc.Signer,
 	url string) (*verifiable.TypedID, error) {
-	cslWrapper, err := s.getLatestCSLWrapper(profile, url)
+	cslWrapper, err := s.getLatestCSLWrapper(ctx, profile, url)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +146,8 @@ func (s *Service) CreateStatusID(profile *vc.Signer,
 	}, nil
 }
 
-func (s *Service) UpdateVCStatus(signer *vc.Signer, profileName, credentialID, status string) error {
+func (s *Service) UpdateVCStatus(ctx context.Context, signer *vc.Signer,
+	profileName, credentialID, status string) error {
 	vcBytes, err := s.vcStore.Get(profileName, credentialID)
 	if err != nil {
 		return err
@@ -162,12 +164,12 @@ func (s *Service) UpdateVCStatus(signer *vc.Signer, profileName, credentialID, s
 		return err
 	}
 
-	return s.UpdateVC(credential, signer, statusValue)
+	return s.UpdateVC(ctx, credential, signer, statusValue)
 }
 
 // UpdateVC updates vc.
 // nolint: gocyclo, funlen
-func (s *Service) UpdateVC(v *verifiable.Credential,
+func (s *Service) UpdateVC(ctx context.Context, v *verifiable.Credential,
 	profile *vc.Signer, status bool) error {
 	// validate vc status
 	if err := s.validateVCStatus(v.Status); err != nil {
@@ -216,7 +218,7 @@ func (s *Service) UpdateVC(v *verifiable.Credential,
 	// remove all proofs because we are updating VC
 	cslWrapper.VC.Proofs = nil
 
-	signedCredential, err := s.crypto.SignCredential(profile, cslWrapper.VC, signOpts...)
+	signedCredential, err := s.crypto.SignCredential(ctx, profile, cslWrapper.VC, signOpts...)
 	if err != nil {
 		return err
 	}
@@ -284,7 +286,7 @@ func (s *Service) getCSLWrapper(id string) (*CSLWrapper, error) {
 }
 
 //nolint:gocognit
-func (s *Service) getLatestCSLWrapper(profile *vc.Signer,
+func (s *Service) getLatestCSLWrapper(ctx context.Context, profile *vc.Signer,
 	url string) (*CSLWrapper, error) {
 	// get latest id
 	id, err := s.cslStore.GetLatestListID()
@@ -295,7 +297,7 @@ func (s *Service) getLatestCSLWrapper(profile *vc.Signer,
 			}
 
 			// create verifiable credential that encapsulates the revocation list
-			credentials, errCreateVC := s.createVC(url+"/1", profile)
+			credentials, errCreateVC := s.createVC(ctx, url+"/1", profile)
 			if errCreateVC != nil {
 				return nil, errCreateVC
 			}
@@ -317,7 +319,7 @@ func (s *Service) getLatestCSLWrapper(profile *vc.Signer,
 	if err != nil { //nolint: nestif
 		if errors.Is(err, ErrDataNotFound) {
 			// create verifiable credential that encapsulates the revocation list
-			credentials, errCreateVC := s.createVC(vcID, profile)
+			credentials, errCreateVC := s.createVC(ctx, vcID, profile)
 			if errCreateVC != nil {
 				return nil, errCreateVC
 			}
@@ -336,7 +338,7 @@ func (s *Service) getLatestCSLWrapper(profile *vc.Signer,
 	return w, nil
 }
 
-func (s *Service) createVC(vcID string,
+func (s *Service) createVC(ctx context.Context, vcID string,
 	profile *vc.Signer) (*verifiable.Credential, error) {
 	credential := &verifiable.Credential{}
 	credential.Context = []string{vcContext, Context}
@@ -377,7 +379,7 @@ func (s *Service) createVC(vcID string,
 		return nil, err
 	}
 
-	return s.crypto.SignCredential(profile, credential, signOpts...)
+	return s.crypto.SignCredential(ctx, profile, credential, signOpts...)
 }
 
 // prepareSigningOpts prepares signing opts from recently issued proof of given credential.
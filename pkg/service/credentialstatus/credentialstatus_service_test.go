@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package credentialstatus
 
 import (
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	_ "embed"
@@ -70,7 +71,7 @@ const (
 func validateVCStatus(t *testing.T, s *Service, id string, index int) {
 	t.Helper()
 
-	status, err := s.CreateStatusID(getTestProfile(), "localhost:8080/status")
+	status, err := s.CreateStatusID(context.Background(), getTestProfile(), "localhost:8080/status")
 	require.NoError(t, err)
 	require.Equal(t, StatusList2021Entry, status.Type)
 	require.Equal(t, "revocation", status.CustomFields[StatusPurpose].(string))
@@ -119,7 +120,7 @@ func TestCredentialStatusList_CreateStatusID(t *testing.T) {
 			vccrypto.New(&vdrmock.MockVDRegistry{},
 				loader), loader)
 
-		status, err := s.CreateStatusID(getTestProfile(), "localhost:8080/status")
+		status, err := s.CreateStatusID(context.Background(), getTestProfile(), "localhost:8080/status")
 		require.Error(t, err)
 		require.Nil(t, status)
 		require.Contains(t, err.Error(), "failed to get latestListID from store")
@@ -134,7 +135,7 @@ func TestCredentialStatusList_CreateStatusID(t *testing.T) {
 			vccrypto.New(&vdrmock.MockVDRegistry{},
 				loader), loader)
 
-		status, err := s.CreateStatusID(getTestProfile(), "localhost:8080/status")
+		status, err := s.CreateStatusID(context.Background(), getTestProfile(), "localhost:8080/status")
 		require.Error(t, err)
 		require.Nil(t, status)
 		require.Contains(t, err.Error(), "failed to store latest list ID in store")
@@ -149,7 +150,7 @@ func TestCredentialStatusList_CreateStatusID(t *testing.T) {
 			vccrypto.New(
 				&vdrmock.MockVDRegistry{ResolveValue: createDIDDoc("did:test:abc")}, loader), loader)
 
-		status, err := s.CreateStatusID(getTestProfile(), "localhost:8080/status")
+		status, err := s.CreateStatusID(context.Background(), getTestProfile(), "localhost:8080/status")
 		require.Error(t, err)
 		require.Nil(t, status)
 		require.Contains(t, err.Error(), "failed to store csl in store")
@@ -164,7 +165,7 @@ func TestCredentialStatusList_CreateStatusID(t *testing.T) {
 			vccrypto.New(
 				&vdrmock.MockVDRegistry{ResolveValue: createDIDDoc("did:test:abc")}, loader), loader)
 
-		status, err := s.CreateStatusID(getTestProfile(), "localhost:8080/status")
+		status, err := s.CreateStatusID(context.Background(), getTestProfile(), "localhost:8080/status")
 		require.Error(t, err)
 		require.Nil(t, status)
 		require.Contains(t, err.Error(), "failed to store latest list ID in store")
@@ -197,7 +198,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 				&vdrmock.MockVDRegistry{ResolveValue: createDIDDoc("did:test:abc")}, loader), loader)
 
 		profile := getTestProfile()
-		status, err := s.CreateStatusID(profile, "localhost:8080/status")
+		status, err := s.CreateStatusID(context.Background(), profile, "localhost:8080/status")
 		require.NoError(t, err)
 
 		cred, err := verifiable.ParseCredential([]byte(universityDegreeCred),
@@ -210,7 +211,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		err = vcStore.Put("testprofile", cred)
 		require.NoError(t, err)
 
-		require.NoError(t, s.UpdateVCStatus(getTestProfile(), "testprofile", cred.ID, "true"))
+		require.NoError(t, s.UpdateVCStatus(context.Background(), getTestProfile(), "testprofile", cred.ID, "true"))
 
 		revocationListVC, err := s.GetRevocationListVC(status.CustomFields[StatusListCredential].(string))
 		require.NoError(t, err)
@@ -231,7 +232,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		s := New(newMockCSLStore(), newMockVCStore(), 2,
 			nil, nil)
 
-		err := s.UpdateVCStatus(getTestProfile(), "testprofile", "testId", "true")
+		err := s.UpdateVCStatus(context.Background(), getTestProfile(), "testprofile", "testId", "true")
 		require.Error(t, err)
 		require.ErrorContains(t, err, "data not found")
 	})
@@ -252,7 +253,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		err = vcStore.Put("testprofile", cred)
 		require.NoError(t, err)
 
-		err = s.UpdateVCStatus(getTestProfile(), "testprofile", cred.ID, "true")
+		err = s.UpdateVCStatus(context.Background(), getTestProfile(), "testprofile", cred.ID, "true")
 		require.Error(t, err)
 		require.ErrorContains(t, err, "verifiable credential is not valid")
 	})
@@ -271,7 +272,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		err = vcStore.Put("testprofile", cred)
 		require.NoError(t, err)
 
-		err = s.UpdateVCStatus(getTestProfile(), "testprofile", cred.ID, "invalid")
+		err = s.UpdateVCStatus(context.Background(), getTestProfile(), "testprofile", cred.ID, "invalid")
 		require.Error(t, err)
 		require.ErrorContains(t, err, "invalid syntax")
 	})
@@ -288,7 +289,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		require.NoError(t, err)
 
 		cred.ID = credID
-		err = s.UpdateVC(cred, getTestProfile(), true)
+		err = s.UpdateVC(context.Background(), cred, getTestProfile(), true)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "vc status not exist")
 	})
@@ -305,7 +306,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 
 		cred.ID = credID
 		cred.Status = &verifiable.TypedID{Type: "noMatch"}
-		err = s.UpdateVC(cred, getTestProfile(), true)
+		err = s.UpdateVC(context.Background(), cred, getTestProfile(), true)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "vc status noMatch not supported")
 	})
@@ -322,7 +323,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 
 		cred.ID = credID
 		cred.Status = &verifiable.TypedID{Type: StatusList2021Entry}
-		err = s.UpdateVC(cred, getTestProfile(), true)
+		err = s.UpdateVC(context.Background(), cred, getTestProfile(), true)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "statusListIndex field not exist in vc status")
 	})
@@ -341,7 +342,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		cred.Status = &verifiable.TypedID{
 			Type: StatusList2021Entry, CustomFields: map[string]interface{}{StatusListIndex: "1"},
 		}
-		err = s.UpdateVC(cred, getTestProfile(), true)
+		err = s.UpdateVC(context.Background(), cred, getTestProfile(), true)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "statusListCredential field not exist in vc status")
 	})
@@ -360,7 +361,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		cred.Status = &verifiable.TypedID{Type: StatusList2021Entry, CustomFields: map[string]interface{}{
 			StatusListIndex: "1", StatusListCredential: 1, StatusPurpose: "test",
 		}}
-		err = s.UpdateVC(cred, getTestProfile(), true)
+		err = s.UpdateVC(context.Background(), cred, getTestProfile(), true)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to cast status statusListCredential")
 	})
@@ -379,7 +380,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 		cred.Status = &verifiable.TypedID{Type: StatusList2021Entry, CustomFields: map[string]interface{}{
 			StatusListIndex: "1", StatusListCredential: 1,
 		}}
-		err = s.UpdateVC(cred, getTestProfile(), true)
+		err = s.UpdateVC(context.Background(), cred, getTestProfile(), true)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "statusPurpose field not exist in vc status")
 	})
@@ -390,7 +391,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 			vccrypto.New(
 				&vdrmock.MockVDRegistry{ResolveValue: createDIDDoc("did:test:abc")}, loader), loader)
 
-		status, err := s.CreateStatusID(getTestProfile(), "localhost:8080/status")
+		status, err := s.CreateStatusID(context.Background(), getTestProfile(), "localhost:8080/status")
 		require.NoError(t, err)
 
 		cred, err := verifiable.ParseCredential([]byte(universityDegreeCred),
@@ -399,7 +400,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 
 		cred.ID = credID
 		cred.Status = status
-		require.NoError(t, s.UpdateVC(cred, getTestProfile(), true))
+		require.NoError(t, s.UpdateVC(context.Background(), cred, getTestProfile(), true))
 
 		revocationListVC, err := s.GetRevocationListVC(status.CustomFields[StatusListCredential].(string))
 		require.NoError(t, err)
@@ -424,7 +425,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 			vccrypto.New(
 				&vdrmock.MockVDRegistry{ResolveValue: createDIDDoc("did:test:abc")}, loader), loader)
 
-		err := s.UpdateVC(&verifiable.Credential{
+		err := s.UpdateVC(context.Background(), &verifiable.Credential{
 			ID: credID,
 			Status: &verifiable.TypedID{
 				ID: "test", Type: StatusList2021Entry,
@@ -445,7 +446,7 @@ func TestCredentialStatusList_RevokeVC(t *testing.T) {
 			vccrypto.New(
 				&vdrmock.MockVDRegistry{ResolveValue: createDIDDoc("did:test:abc")}, loader), loader)
 
-		_, err := s.CreateStatusID(getTestSignerWithCrypto(
+		_, err := s.CreateStatusID(context.Background(), getTestSignerWithCrypto(
 			&cryptomock.Crypto{SignErr: fmt.Errorf("failed to sign")}), "localhost:8080/status")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to sign vc")
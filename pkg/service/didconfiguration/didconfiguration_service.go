@@ -45,7 +45,7 @@ type kmsRegistry interface {
 }
 
 type vcCrypto interface {
-	SignCredential(signerData *vc.Signer, vc *verifiable.Credential,
+	SignCredential(ctx context.Context, signerData *vc.Signer, vc *verifiable.Credential,
 		opts ...crypto.SigningOpts) (*verifiable.Credential, error)
 }
 
@@ -170,7 +170,7 @@ func (s *Service) DidConfig(
 		return nil, resterr.NewValidationError(resterr.InvalidValue, "profileType",
 			errors.New("profileType should be verifier or issuer"))
 	}
-	cred, err := s.vcCrypto.SignCredential(signer, cred, []crypto.SigningOpts{}...)
+	cred, err := s.vcCrypto.SignCredential(ctx, signer, cred, []crypto.SigningOpts{}...)
 
 	if err != nil {
 		return nil, err
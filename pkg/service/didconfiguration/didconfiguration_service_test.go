@@ -139,8 +139,9 @@ func TestDidConfiguration(t *testing.T) {
 
 			cryptoSvc := NewMockVCCrypto(gomock.NewController(t))
 
-			cryptoSvc.EXPECT().SignCredential(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			cryptoSvc.EXPECT().SignCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
 				func(
+					ctx context.Context,
 					signer *vc.Signer,
 					credential *verifiable.Credential,
 					issuerSigningOpts ...crypto.SigningOpts,
@@ -348,7 +349,7 @@ func TestWithSignError(t *testing.T) {
 
 	cryptoSvc := NewMockVCCrypto(gomock.NewController(t))
 
-	cryptoSvc.EXPECT().SignCredential(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+	cryptoSvc.EXPECT().SignCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(
 		nil, errors.New("sign error"))
 
 	configService := New(&Config{
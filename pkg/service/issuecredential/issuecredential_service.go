@@ -9,6 +9,7 @@ SPDX-License-Identifier: Apache-2.0
 package issuecredential
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -26,12 +27,12 @@ type vcStore interface {
 }
 
 type vcStatusManager interface {
-	CreateStatusID(vcSigner *vc.Signer, url string) (*verifiable.TypedID, error)
+	CreateStatusID(ctx context.Context, vcSigner *vc.Signer, url string) (*verifiable.TypedID, error)
 	GetCredentialStatusURL(issuerProfileURL, issuerProfileID, statusID string) (string, error)
 }
 
 type vcCrypto interface {
-	SignCredential(signerData *vc.Signer, vc *verifiable.Credential,
+	SignCredential(ctx context.Context, signerData *vc.Signer, vc *verifiable.Credential,
 		opts ...crypto.SigningOpts) (*verifiable.Credential, error)
 }
 
@@ -62,7 +63,7 @@ func New(config *Config) *Service {
 	}
 }
 
-func (s *Service) IssueCredential(credential *verifiable.Credential,
+func (s *Service) IssueCredential(ctx context.Context, credential *verifiable.Credential,
 	issuerSigningOpts []crypto.SigningOpts,
 	profile *profileapi.Issuer) (*verifiable.Credential, error) {
 	kms, err := s.kmsRegistry.GetKeyManager(profile.KMSConfig)
@@ -88,7 +89,7 @@ func (s *Service) IssueCredential(credential *verifiable.Credential,
 		return nil, fmt.Errorf("failed to create status URL: %w", err)
 	}
 
-	status, err = s.vcStatusManager.CreateStatusID(signer, statusURL)
+	status, err = s.vcStatusManager.CreateStatusID(ctx, signer, statusURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add credential status: %w", err)
 	}
@@ -103,7 +104,7 @@ func (s *Service) IssueCredential(credential *verifiable.Credential,
 	vcutil.UpdateIssuer(credential, profile.SigningDID.DID, profile.Name, true)
 
 	// sign the credential
-	signedVC, err := s.crypto.SignCredential(signer, credential, issuerSigningOpts...)
+	signedVC, err := s.crypto.SignCredential(ctx, signer, credential, issuerSigningOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign credential: %w", err)
 	}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package issuecredential
 
 import (
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"errors"
@@ -34,6 +35,7 @@ import (
 	vccrypto "github.com/trustbloc/vcs/pkg/doc/vc/crypto"
 	vcs "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	"github.com/trustbloc/vcs/pkg/internal/testutil"
+	vcskms "github.com/trustbloc/vcs/pkg/kms"
 	"github.com/trustbloc/vcs/pkg/kms/signer"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 )
@@ -54,7 +56,7 @@ func TestService_IssueCredential(t *testing.T) {
 	mockVCStore.EXPECT().Put(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
 
 	mockVCStatusManager := NewMockVCStatusManager(gomock.NewController(t))
-	mockVCStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any()).AnyTimes().Return(&verifiable.TypedID{
+	mockVCStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(&verifiable.TypedID{
 		ID:   "https://www.w3.org/TR/vc-data-model/3.0/#types",
 		Type: "JsonSchemaValidator2018",
 	}, nil)
@@ -118,7 +120,7 @@ func TestService_IssueCredential(t *testing.T) {
 							VCStore:         mockVCStore,
 						})
 
-						verifiableCredentials, err := service.IssueCredential(
+						verifiableCredentials, err := service.IssueCredential(context.Background(),
 							&verifiable.Credential{},
 							nil,
 							&profileapi.Issuer{
@@ -182,7 +184,7 @@ func TestService_IssueCredential(t *testing.T) {
 					VCStore:         mockVCStore,
 				})
 
-				verifiableCredentials, err := service.IssueCredential(
+				verifiableCredentials, err := service.IssueCredential(context.Background(),
 					&verifiable.Credential{
 						ID:      "http://example.edu/credentials/1872",
 						Context: []string{verifiable.ContextURI},
@@ -226,7 +228,7 @@ func TestService_IssueCredential(t *testing.T) {
 			KMSRegistry: registry,
 		})
 
-		verifiableCredentials, err := service.IssueCredential(
+		verifiableCredentials, err := service.IssueCredential(context.Background(),
 			&verifiable.Credential{},
 			nil,
 			&profileapi.Issuer{})
@@ -238,7 +240,7 @@ func TestService_IssueCredential(t *testing.T) {
 		registry.EXPECT().GetKeyManager(gomock.Any()).Return(nil, nil)
 
 		vcStatusManager := NewMockVCStatusManager(gomock.NewController(t))
-		vcStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+		vcStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
 		vcStatusManager.EXPECT().GetCredentialStatusURL(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil)
 
 		service := New(&Config{
@@ -246,7 +248,7 @@ func TestService_IssueCredential(t *testing.T) {
 			VCStatusManager: vcStatusManager,
 		})
 
-		verifiableCredentials, err := service.IssueCredential(
+		verifiableCredentials, err := service.IssueCredential(context.Background(),
 			&verifiable.Credential{},
 			nil,
 			&profileapi.Issuer{
@@ -270,7 +272,7 @@ func TestService_IssueCredential(t *testing.T) {
 			VCStatusManager: vcStatusManager,
 		})
 
-		verifiableCredentials, err := service.IssueCredential(
+		verifiableCredentials, err := service.IssueCredential(context.Background(),
 			&verifiable.Credential{},
 			nil,
 			&profileapi.Issuer{
@@ -286,18 +288,18 @@ func TestService_IssueCredential(t *testing.T) {
 		kmRegistry.EXPECT().GetKeyManager(gomock.Any()).AnyTimes().Return(nil, nil)
 
 		vcStatusManager := NewMockVCStatusManager(gomock.NewController(t))
-		vcStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+		vcStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
 		vcStatusManager.EXPECT().GetCredentialStatusURL(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil)
 
 		cr := NewMockvcCrypto(gomock.NewController(t))
-		cr.EXPECT().SignCredential(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+		cr.EXPECT().SignCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
 		service := New(&Config{
 			KMSRegistry:     kmRegistry,
 			VCStatusManager: vcStatusManager,
 			Crypto:          cr,
 		})
 
-		verifiableCredentials, err := service.IssueCredential(
+		verifiableCredentials, err := service.IssueCredential(context.Background(),
 			&verifiable.Credential{},
 			nil,
 			&profileapi.Issuer{
@@ -313,7 +315,7 @@ func TestService_IssueCredential(t *testing.T) {
 		kmRegistry.EXPECT().GetKeyManager(gomock.Any()).AnyTimes().Return(nil, nil)
 
 		vcStatusManager := NewMockVCStatusManager(gomock.NewController(t))
-		vcStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+		vcStatusManager.EXPECT().CreateStatusID(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
 		vcStatusManager.EXPECT().GetCredentialStatusURL(
 			gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return("", nil)
 
@@ -330,7 +332,7 @@ func TestService_IssueCredential(t *testing.T) {
 			Crypto:          crypto,
 		})
 
-		verifiableCredentials, err := service.IssueCredential(
+		verifiableCredentials, err := service.IssueCredential(context.Background(),
 			&verifiable.Credential{},
 			nil,
 			&profileapi.Issuer{
@@ -360,7 +362,7 @@ func TestService_IssueCredential(t *testing.T) {
 			VCStore:         failedStore,
 		})
 
-		verifiableCredentials, err := service.IssueCredential(
+		verifiableCredentials, err := service.IssueCredential(context.Background(),
 			&verifiable.Credential{},
 			nil,
 			&profileapi.Issuer{
@@ -490,3 +492,7 @@ func (m *mockVCSKeyManager) CreateJWKKey(keyType kms.KeyType) (string, *jwk.JWK,
 func (m *mockVCSKeyManager) CreateCryptoKey(keyType kms.KeyType) (string, interface{}, error) {
 	return "", nil, nil
 }
+
+func (m *mockVCSKeyManager) NewJWEDecrypter(string) (vcskms.JWEDecrypter, error) {
+	return nil, nil
+}
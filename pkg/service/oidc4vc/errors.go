@@ -14,10 +14,22 @@ var (
 	ErrCredentialTemplateNotFound      = errors.New("credential template not found")
 	ErrCredentialTemplateNotConfigured = errors.New("credential template not configured")
 	ErrCredentialTemplateIDRequired    = errors.New("credential template ID is required")
+	ErrCredentialTemplateDuplicate     = errors.New("credential template is offered more than once")
 	ErrAuthorizedCodeFlowNotSupported  = errors.New("authorized code flow not supported")
 	ErrResponseTypeMismatch            = errors.New("response type mismatch")
 	ErrInvalidScope                    = errors.New("invalid scope")
 	ErrCredentialTypeNotSupported      = errors.New("credential type not supported")
 	ErrCredentialFormatNotSupported    = errors.New("credential format not supported")
 	ErrVCOptionsNotConfigured          = errors.New("vc options not configured")
+	ErrCredentialNotAuthorized         = errors.New("credential not authorized")
+	ErrClaimDataNotAvailable           = errors.New("claim data not available")
+	ErrCredentialIssuancePending       = errors.New("credential issuance pending")
+	ErrDeferredCredentialNotFound      = errors.New("deferred credential not found")
+	ErrInvalidClaimData                = errors.New("invalid claim data")
+	ErrSecretProviderNotConfigured     = errors.New("secret provider not configured")
+	ErrTransactionExpired              = errors.New("transaction expired")
+	ErrTransactionCancelled            = errors.New("transaction cancelled")
+	ErrTransactionNotCancellable       = errors.New("transaction can not be cancelled")
+	ErrIssuerOIDCConfigurationInvalid  = errors.New("issuer oidc configuration invalid")
+	ErrTransactionStateChanged         = errors.New("transaction state changed")
 )
@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package oidc4vc
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 )
@@ -26,21 +29,70 @@ type Transaction struct {
 
 // TransactionData is the transaction data stored in the underlying storage.
 type TransactionData struct {
-	CredentialTemplate                 *profileapi.CredentialTemplate
-	CredentialFormat                   vcsverifiable.Format
+	ProfileID string
+	// CredentialTemplates are templates of credentials offered in the transaction. Format of each template
+	// is resolved from the profile if not set in the template.
+	CredentialTemplates                []*profileapi.CredentialTemplate
 	AuthorizationEndpoint              string
 	PushedAuthorizationRequestEndpoint string
 	TokenEndpoint                      string
 	ClaimEndpoint                      string
 	ClientID                           string
-	ClientSecret                       string
+	ClientSecretHandle                 string
 	GrantType                          string
 	ResponseType                       string
 	Scope                              []string
-	AuthorizationDetails               *AuthorizationDetails
+	AuthorizationDetails               []*AuthorizationDetails
 	IssuerAuthCode                     string
 	IssuerToken                        string
 	OpState                            string
+	// InitiateIssuanceURL is the URL returned to the issuer when the transaction was initiated.
+	InitiateIssuanceURL string
+	// DeferredCredentials are credentials requested by the wallet before claim data was available.
+	DeferredCredentials []*DeferredCredential
+	// State is the state of the transaction. Empty state of transactions created before states were
+	// introduced is treated as offered.
+	State TransactionState
+	// ExpiresAt is the time credential offer expires at if the wallet doesn't obtain access token before it.
+	// Zero value means offer doesn't expire.
+	ExpiresAt time.Time
+}
+
+// TransactionState is the state of the issuance transaction.
+type TransactionState string
+
+const (
+	// TransactionStateOffered is the state of initiated transaction which credential offer is passed to the wallet.
+	TransactionStateOffered TransactionState = "offered"
+	// TransactionStateAuthorized is the state after the issuer OAuth provider authorized the wallet.
+	TransactionStateAuthorized TransactionState = "authorized"
+	// TransactionStateTokenIssued is the state after access token was issued to the wallet.
+	TransactionStateTokenIssued TransactionState = "token_issued"
+	// TransactionStateCredentialIssued is the state after credential was issued to the wallet.
+	TransactionStateCredentialIssued TransactionState = "credential_issued"
+	// TransactionStateExpired is the state of transaction which credential offer expired before access token
+	// was issued to the wallet. The state is not stored, it is derived from offer expiration time.
+	TransactionStateExpired TransactionState = "expired"
+	// TransactionStateCancelled is the state of transaction cancelled by the issuer.
+	TransactionStateCancelled TransactionState = "cancelled"
+)
+
+// TransactionStatus is the status of the issuance transaction reported to the issuer.
+type TransactionStatus struct {
+	TxID      TxID
+	State     TransactionState
+	ExpiresAt time.Time
+}
+
+// DeferredCredential is a credential which issuance was deferred until the issuer provides claim data.
+// The wallet polls the deferred credential endpoint with the acceptance token until the credential is issued.
+type DeferredCredential struct {
+	AcceptanceToken string
+	CredentialType  string
+	Format          vcsverifiable.Format
+	DID             string
+	// ClaimData is a JSON object with claims of the credential subject. Empty until the issuer provides it.
+	ClaimData json.RawMessage
 }
 
 // AuthorizationDetails are the VC-related details for VC issuance.
@@ -64,7 +116,9 @@ type OIDCConfiguration struct {
 
 // InitiateIssuanceRequest is the request used by the Issuer to initiate the OIDC VC issuance interaction.
 type InitiateIssuanceRequest struct {
-	CredentialTemplateID      string
+	// CredentialTemplateIDs are IDs of templates of credentials offered to the wallet. Can be empty if profile
+	// has only one credential template.
+	CredentialTemplateIDs     []string
 	ClientInitiateIssuanceURL string
 	ClientWellKnownURL        string
 	ClaimEndpoint             string
@@ -72,12 +126,29 @@ type InitiateIssuanceRequest struct {
 	ResponseType              string
 	Scope                     []string
 	OpState                   string
+	// CredentialOfferByReference enables passing credential offer in credential_offer_uri parameter
+	// of initiate issuance URL. The offer is stored and fetched by the wallet.
+	CredentialOfferByReference bool
 }
 
 // InitiateIssuanceResponse is the response from the Issuer to the Wallet with initiate issuance URL.
 type InitiateIssuanceResponse struct {
 	InitiateIssuanceURL string
 	TxID                TxID
+	// CredentialOfferURI is set if credential offer is passed by reference.
+	CredentialOfferURI string
+	// ExpiresAt is the time credential offer expires at.
+	ExpiresAt time.Time
+	// ClientWellKnownError is set if initiate issuance endpoint could not be resolved from the client
+	// well-known configuration and the default one is used.
+	ClientWellKnownError string
+}
+
+// CredentialOffer contains parameters of initiate issuance request passed to the wallet by reference.
+type CredentialOffer struct {
+	Issuer          string   `json:"issuer"`
+	CredentialTypes []string `json:"credential_type"`
+	OpState         string   `json:"op_state"`
 }
 
 // PrepareClaimDataAuthorizationRequest is the request to prepare the claim data authorization request.
@@ -85,7 +156,13 @@ type PrepareClaimDataAuthorizationRequest struct {
 	ResponseType         string
 	Scope                []string
 	OpState              string
-	AuthorizationDetails *AuthorizationDetails
+	AuthorizationDetails []*AuthorizationDetails
+}
+
+// ExchangeAuthorizationCodeResult contains the transaction the issuer authorization code was exchanged for.
+type ExchangeAuthorizationCodeResult struct {
+	TxID      TxID
+	ProfileID string
 }
 
 type PrepareClaimDataAuthorizationResponse struct {
@@ -102,6 +179,38 @@ type OAuthParameters struct {
 	Scope        []string
 }
 
+// PrepareCredentialRequest is the request to prepare credential for the wallet.
+type PrepareCredentialRequest struct {
+	OpState        string
+	CredentialType string
+	// Format is an optional format of the requested credential. Offered credential of the given type is used
+	// if not set.
+	Format vcsverifiable.Format
+	// DID of the holder the credential is issued to.
+	DID string
+	// ValidateOnly checks that the credential can be issued in the transaction without preparing it.
+	ValidateOnly bool
+}
+
+// PrepareCredentialResponse contains unsigned credential with claims obtained from issuer. If claim data
+// is not available yet, credential is not set and the response contains acceptance token instead.
+type PrepareCredentialResponse struct {
+	ProfileID       string
+	TxID            TxID
+	Format          vcsverifiable.Format
+	Credential      *verifiable.Credential
+	AcceptanceToken string
+}
+
+// StoreDeferredClaimDataRequest is the request used by the issuer to provide claim data of the deferred credential.
+type StoreDeferredClaimDataRequest struct {
+	ProfileID      string
+	TxID           TxID
+	CredentialType string
+	ClaimData      json.RawMessage
+}
+
 type InsertOptions struct {
-	TTL time.Duration
+	TTL  time.Duration
+	TxID TxID
 }
@@ -4,24 +4,33 @@ Copyright SecureKey Technologies Inc. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-//go:generate mockgen -destination oidc4vc_service_mocks_test.go -self_package mocks -package oidc4vc_test -source=oidc4vc_service.go -mock_names transactionStore=MockTransactionStore,wellKnownService=MockWellKnownService,oAuth2Client=MockOAuth2Client,oAuth2ClientFactory=MockOAuth2ClientFactory
+//go:generate mockgen -destination oidc4vc_service_mocks_test.go -self_package mocks -package oidc4vc_test -source=oidc4vc_service.go -mock_names transactionStore=MockTransactionStore,credentialOfferStore=MockCredentialOfferStore,wellKnownService=MockWellKnownService,oAuth2Client=MockOAuth2Client,oAuth2ClientFactory=MockOAuth2ClientFactory,httpClient=MockHTTPClient,secretProvider=MockSecretProvider,metricsProvider=MockMetricsProvider
 
 package oidc4vc
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 
 	"github.com/trustbloc/vcs/internal/pkg/log"
+	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
+	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
+	profileapi "github.com/trustbloc/vcs/pkg/profile"
 )
 
 const (
 	defaultGrantType    = "authorization_code"
 	defaultResponseType = "token"
 	defaultScope        = "openid"
+
+	defaultCredentialOfferTTL = 15 * time.Minute
+	defaultOfferTTL           = 24 * time.Hour
 )
 
 var logger = log.New("oidc4vc")
@@ -38,10 +47,46 @@ type transactionStore interface {
 		opState string,
 	) (*Transaction, error)
 
+	Get(
+		ctx context.Context,
+		txID TxID,
+	) (*Transaction, error)
+
+	FindByAcceptanceToken(
+		ctx context.Context,
+		acceptanceToken string,
+	) (*Transaction, error)
+
 	Update(
 		ctx context.Context,
 		tx *Transaction,
+		expectedState TransactionState,
+	) error
+
+	AddDeferredCredential(
+		ctx context.Context,
+		txID TxID,
+		deferred *DeferredCredential,
 	) error
+
+	StoreDeferredClaimData(
+		ctx context.Context,
+		txID TxID,
+		credentialType string,
+		claimData json.RawMessage,
+	) error
+
+	RemoveDeferredCredential(
+		ctx context.Context,
+		txID TxID,
+		acceptanceToken string,
+		state TransactionState,
+	) error
+}
+
+type credentialOfferStore interface {
+	Create(ctx context.Context, offer *CredentialOffer, ttl time.Duration) (string, error)
+	Find(ctx context.Context, id string) (*CredentialOffer, error)
 }
 
 type oAuth2ClientFactory interface {
@@ -52,42 +97,141 @@ type wellKnownService interface {
 	GetOIDCConfiguration(ctx context.Context, url string) (*OIDCConfiguration, error)
 }
 
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type secretProvider interface {
+	GetSecret(ctx context.Context, handle string) (string, error)
+}
+
+type metricsProvider interface {
+	OIDC4VCIOperationTime(profileID, operation string, success bool, value time.Duration)
+}
+
+// Transaction operations reported in metrics.
+const (
+	operationInitiateIssuance                     = "initiate_issuance"
+	operationPushAuthorizationDetails             = "push_authorization_details"
+	operationPrepareClaimDataAuthorizationRequest = "prepare_claim_data_authorization_request"
+	operationStoreAuthorizationCode               = "store_authorization_code"
+	operationExchangeAuthorizationCode            = "exchange_authorization_code"
+	operationPrepareCredential                    = "prepare_credential"
+	operationStoreDeferredClaimData               = "store_deferred_claim_data"
+	operationPrepareDeferredCredential            = "prepare_deferred_credential"
+	operationCancelIssuance                       = "cancel_issuance"
+)
+
 // Config holds configuration options and dependencies for Service.
 type Config struct {
 	TransactionStore    transactionStore
 	WellKnownService    wellKnownService
 	IssuerVCSPublicHost string
 	OAuth2ClientFactory oAuth2ClientFactory
+	// CredentialOfferStore stores credential offers passed to the wallet by reference.
+	CredentialOfferStore credentialOfferStore
+	// CredentialOfferEndpoint is the public URL the wallet fetches credential offers from. Offer ID is appended.
+	CredentialOfferEndpoint string
+	// CredentialOfferTTL is the time after which stored credential offer expires. Defaults to 15 minutes.
+	CredentialOfferTTL time.Duration
+	// DefaultOfferTTL is lifetime of credential offer used if profile doesn't define one. The wallet should obtain
+	// access token before the offer expires. Defaults to 24 hours.
+	DefaultOfferTTL time.Duration
+	// HTTPClient is used to fetch claim data from issuer claim endpoint.
+	HTTPClient httpClient
+	// SecretProvider resolves client secret handles of the profiles.
+	SecretProvider secretProvider
+	Metrics        metricsProvider
 }
 
 // Service implements VCS credential interaction API for OIDC4VC issuance.
 type Service struct {
-	store               transactionStore
-	wellKnownService    wellKnownService
-	issuerVCSPublicHost string
-	oAuth2ClientFactory oAuth2ClientFactory
+	store                   transactionStore
+	wellKnownService        wellKnownService
+	issuerVCSPublicHost     string
+	oAuth2ClientFactory     oAuth2ClientFactory
+	credentialOfferStore    credentialOfferStore
+	credentialOfferEndpoint string
+	credentialOfferTTL      time.Duration
+	defaultOfferTTL         time.Duration
+	httpClient              httpClient
+	secretProvider          secretProvider
+	metrics                 metricsProvider
 }
 
 // NewService returns a new Service instance.
 func NewService(config *Config) (*Service, error) {
+	metrics := config.Metrics
+
+	if metrics == nil {
+		metrics = &noopMetricsProvider.NoMetrics{}
+	}
+
+	client := config.HTTPClient
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	credentialOfferTTL := config.CredentialOfferTTL
+
+	if credentialOfferTTL == 0 {
+		credentialOfferTTL = defaultCredentialOfferTTL
+	}
+
+	offerTTL := config.DefaultOfferTTL
+
+	if offerTTL == 0 {
+		offerTTL = defaultOfferTTL
+	}
+
 	return &Service{
-		store:               config.TransactionStore,
-		wellKnownService:    config.WellKnownService,
-		issuerVCSPublicHost: config.IssuerVCSPublicHost,
-		oAuth2ClientFactory: config.OAuth2ClientFactory,
+		store:                   config.TransactionStore,
+		wellKnownService:        config.WellKnownService,
+		issuerVCSPublicHost:     config.IssuerVCSPublicHost,
+		oAuth2ClientFactory:     config.OAuth2ClientFactory,
+		credentialOfferStore:    config.CredentialOfferStore,
+		credentialOfferEndpoint: config.CredentialOfferEndpoint,
+		credentialOfferTTL:      credentialOfferTTL,
+		defaultOfferTTL:         offerTTL,
+		httpClient:              client,
+		secretProvider:          config.SecretProvider,
+		metrics:                 metrics,
 	}, nil
 }
 
+// observeOperation records duration and outcome of transaction operation. Profile is not known
+// if transaction is not found.
+func (s *Service) observeOperation(operation string, tx *Transaction, startTime time.Time, err error) {
+	var profileID string
+
+	if tx != nil {
+		profileID = tx.ProfileID
+	}
+
+	s.metrics.OIDC4VCIOperationTime(profileID, operation, err == nil, time.Since(startTime))
+}
+
 func (s *Service) PushAuthorizationDetails(
 	ctx context.Context,
 	opState string,
-	ad *AuthorizationDetails,
-) error {
-	tx, err := s.store.FindByOpState(ctx, opState)
+	ad []*AuthorizationDetails,
+) (err error) {
+	var tx *Transaction
+
+	defer func(startTime time.Time) {
+		s.observeOperation(operationPushAuthorizationDetails, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.store.FindByOpState(ctx, opState)
 	if err != nil {
 		return fmt.Errorf("find tx by op state: %w", err)
 	}
 
+	if err = checkTransactionActive(tx); err != nil {
+		return err
+	}
+
 	if err = s.updateAuthorizationDetails(ctx, ad, tx); err != nil {
 		return err
 	}
@@ -98,12 +242,22 @@ func (s *Service) PushAuthorizationDetails(
 func (s *Service) PrepareClaimDataAuthorizationRequest(
 	ctx context.Context,
 	req *PrepareClaimDataAuthorizationRequest,
-) (*PrepareClaimDataAuthorizationResponse, error) {
-	tx, err := s.store.FindByOpState(ctx, req.OpState)
+) (_ *PrepareClaimDataAuthorizationResponse, err error) {
+	var tx *Transaction
+
+	defer func(startTime time.Time) {
+		s.observeOperation(operationPrepareClaimDataAuthorizationRequest, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.store.FindByOpState(ctx, req.OpState)
 	if err != nil {
 		return nil, fmt.Errorf("find tx by op state: %w", err)
 	}
 
+	if err = checkTransactionActive(tx); err != nil {
+		return nil, err
+	}
+
 	if req.ResponseType != tx.ResponseType {
 		return nil, ErrResponseTypeMismatch
 	}
@@ -130,16 +284,21 @@ func (s *Service) PrepareClaimDataAuthorizationRequest(
 		return nil, ErrInvalidScope
 	}
 
-	if req.AuthorizationDetails != nil {
+	if len(req.AuthorizationDetails) > 0 {
 		if err = s.updateAuthorizationDetails(ctx, req.AuthorizationDetails, tx); err != nil {
 			return nil, err
 		}
 	}
 
+	clientSecret, err := s.resolveClientSecret(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PrepareClaimDataAuthorizationResponse{
 		AuthorizationParameters: &OAuthParameters{
 			ClientID:     tx.ClientID,
-			ClientSecret: tx.ClientSecret,
+			ClientSecret: clientSecret,
 			ResponseType: req.ResponseType,
 			Scope:        req.Scope,
 		},
@@ -149,24 +308,71 @@ func (s *Service) PrepareClaimDataAuthorizationRequest(
 	}, nil
 }
 
-func (s *Service) updateAuthorizationDetails(ctx context.Context, ad *AuthorizationDetails, tx *Transaction) error {
-	if tx.CredentialTemplate == nil {
-		return ErrCredentialTemplateNotConfigured
+// resolveClientSecret resolves client secret of the transaction with the secret provider. Secret is resolved
+// on every use, so rotated secret is picked up without reloading the profile.
+func (s *Service) resolveClientSecret(ctx context.Context, tx *Transaction) (string, error) {
+	if tx.ClientSecretHandle == "" {
+		return "", nil
 	}
 
-	if !strings.EqualFold(ad.CredentialType, tx.CredentialTemplate.Type) {
-		return ErrCredentialTypeNotSupported
+	if s.secretProvider == nil {
+		return "", ErrSecretProviderNotConfigured
 	}
 
-	if ad.Format != tx.CredentialFormat {
-		return ErrCredentialFormatNotSupported
+	secret, err := s.secretProvider.GetSecret(ctx, tx.ClientSecretHandle)
+	if err != nil {
+		return "", fmt.Errorf("resolve client secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+// updateAuthorizationDetails stores authorization details requested by the wallet. Each of them should refer
+// to one of the credentials offered in the transaction.
+func (s *Service) updateAuthorizationDetails(ctx context.Context, ad []*AuthorizationDetails, tx *Transaction) error {
+	if len(tx.CredentialTemplates) == 0 {
+		return ErrCredentialTemplateNotConfigured
+	}
+
+	for _, d := range ad {
+		if _, err := findOfferedTemplate(tx, d.CredentialType, d.Format); err != nil {
+			return err
+		}
 	}
 
 	tx.AuthorizationDetails = ad
 
-	if err := s.store.Update(ctx, tx); err != nil {
+	if err := s.store.Update(ctx, tx, tx.State); err != nil {
 		return fmt.Errorf("update tx: %w", err)
 	}
 
 	return nil
 }
+
+// findOfferedTemplate returns template of the credential of the given type offered in the transaction. Format
+// is optional, if set it should match the format of the offered credential.
+func findOfferedTemplate(
+	tx *Transaction,
+	credentialType string,
+	format vcsverifiable.Format,
+) (*profileapi.CredentialTemplate, error) {
+	typeSupported := false
+
+	for _, t := range tx.CredentialTemplates {
+		if !strings.EqualFold(credentialType, t.Type) {
+			continue
+		}
+
+		typeSupported = true
+
+		if format == "" || format == t.Format {
+			return t, nil
+		}
+	}
+
+	if typeSupported {
+		return nil, ErrCredentialFormatNotSupported
+	}
+
+	return nil, ErrCredentialTypeNotSupported
+}
@@ -9,19 +9,38 @@ package oidc4vc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"golang.org/x/oauth2"
 )
 
-func (s *Service) ExchangeAuthorizationCode(ctx context.Context, opState string) (TxID, error) {
-	tx, err := s.store.FindByOpState(ctx, opState)
+func (s *Service) ExchangeAuthorizationCode(
+	ctx context.Context,
+	opState string,
+) (_ *ExchangeAuthorizationCodeResult, err error) {
+	var tx *Transaction
+
+	defer func(startTime time.Time) {
+		s.observeOperation(operationExchangeAuthorizationCode, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.store.FindByOpState(ctx, opState)
+	if err != nil {
+		return nil, fmt.Errorf("get transaction by opstate: %w", err)
+	}
+
+	if err = checkTransactionActive(tx); err != nil {
+		return nil, err
+	}
+
+	clientSecret, err := s.resolveClientSecret(ctx, tx)
 	if err != nil {
-		return "", fmt.Errorf("get transaction by opstate: %w", err)
+		return nil, err
 	}
 
 	resp, err := s.oAuth2ClientFactory.GetClient(oauth2.Config{
 		ClientID:     tx.ClientID,
-		ClientSecret: tx.ClientSecret,
+		ClientSecret: clientSecret,
 		Endpoint: oauth2.Endpoint{
 			AuthURL:   tx.AuthorizationEndpoint,
 			TokenURL:  tx.TokenEndpoint,
@@ -31,14 +50,20 @@ func (s *Service) ExchangeAuthorizationCode(ctx context.Context, opState string)
 	}).Exchange(ctx, tx.IssuerAuthCode)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	prevState := tx.State
+
 	tx.IssuerToken = resp.AccessToken
+	tx.State = TransactionStateTokenIssued
 
-	if err = s.store.Update(ctx, tx); err != nil {
-		return "", err
+	if err = s.store.Update(ctx, tx, prevState); err != nil {
+		return nil, err
 	}
 
-	return tx.ID, nil
+	return &ExchangeAuthorizationCodeResult{
+		TxID:      tx.ID,
+		ProfileID: tx.ProfileID,
+	}, nil
 }
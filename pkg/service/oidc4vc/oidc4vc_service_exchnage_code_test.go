@@ -17,8 +17,16 @@ func TestExchangeCode(t *testing.T) {
 	store := NewMockTransactionStore(gomock.NewController(t))
 	factory := NewMockOAuth2ClientFactory(gomock.NewController(t))
 	oauth2Client := NewMockOAuth2Client(gomock.NewController(t))
-
-	srv, err := oidc4vc.NewService(&oidc4vc.Config{TransactionStore: store, OAuth2ClientFactory: factory})
+	secretProvider := NewMockSecretProvider(gomock.NewController(t))
+	metrics := NewMockMetricsProvider(gomock.NewController(t))
+	metrics.EXPECT().OIDC4VCIOperationTime("profileID", "exchange_authorization_code", true, gomock.Any())
+
+	srv, err := oidc4vc.NewService(&oidc4vc.Config{
+		TransactionStore:    store,
+		OAuth2ClientFactory: factory,
+		SecretProvider:      secretProvider,
+		Metrics:             metrics,
+	})
 	assert.NoError(t, err)
 
 	opState := uuid.NewString()
@@ -27,23 +35,28 @@ func TestExchangeCode(t *testing.T) {
 	baseTx := &oidc4vc.Transaction{
 		ID: oidc4vc.TxID("id"),
 		TransactionData: oidc4vc.TransactionData{
-			TokenEndpoint:  "https://localhost/token",
-			IssuerAuthCode: authCode,
+			ProfileID:          "profileID",
+			TokenEndpoint:      "https://localhost/token",
+			ClientID:           "client-id",
+			ClientSecretHandle: "client-secret-handle",
+			IssuerAuthCode:     authCode,
 		},
 	}
 
 	store.EXPECT().FindByOpState(gomock.Any(), opState).Return(baseTx, nil)
-	store.EXPECT().Update(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, tx *oidc4vc.Transaction) error {
+	secretProvider.EXPECT().GetSecret(gomock.Any(), "client-secret-handle").Return("client-secret", nil)
+	store.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tx *oidc4vc.Transaction, expectedState oidc4vc.TransactionState) error {
 			assert.Equal(t, baseTx, tx)
 			assert.Equal(t, "SlAV32hkKG", tx.IssuerToken)
+			assert.Equal(t, oidc4vc.TransactionStateTokenIssued, tx.State)
 
 			return nil
 		})
 
 	factory.EXPECT().GetClient(oauth2.Config{
 		ClientID:     baseTx.ClientID,
-		ClientSecret: baseTx.ClientSecret,
+		ClientSecret: "client-secret",
 		Endpoint: oauth2.Endpoint{
 			AuthURL:   baseTx.AuthorizationEndpoint,
 			TokenURL:  baseTx.TokenEndpoint,
@@ -57,12 +70,16 @@ func TestExchangeCode(t *testing.T) {
 
 	resp, err := srv.ExchangeAuthorizationCode(context.TODO(), opState)
 	assert.NoError(t, err)
-	assert.NotEmpty(t, resp)
+	assert.Equal(t, oidc4vc.TxID("id"), resp.TxID)
+	assert.Equal(t, "profileID", resp.ProfileID)
 }
 
 func TestExchangeCodeErrFindTx(t *testing.T) {
 	store := NewMockTransactionStore(gomock.NewController(t))
-	srv, err := oidc4vc.NewService(&oidc4vc.Config{TransactionStore: store})
+	metrics := NewMockMetricsProvider(gomock.NewController(t))
+	metrics.EXPECT().OIDC4VCIOperationTime("", "exchange_authorization_code", false, gomock.Any())
+
+	srv, err := oidc4vc.NewService(&oidc4vc.Config{TransactionStore: store, Metrics: metrics})
 	assert.NoError(t, err)
 
 	store.EXPECT().FindByOpState(gomock.Any(), gomock.Any()).Return(nil, errors.New("tx not found"))
@@ -71,6 +88,61 @@ func TestExchangeCodeErrFindTx(t *testing.T) {
 	assert.ErrorContains(t, err, "tx not found")
 }
 
+func TestExchangeCodeResolveClientSecretErr(t *testing.T) {
+	t.Run("secret provider error", func(t *testing.T) {
+		store := NewMockTransactionStore(gomock.NewController(t))
+		secretProvider := NewMockSecretProvider(gomock.NewController(t))
+
+		srv, err := oidc4vc.NewService(&oidc4vc.Config{TransactionStore: store, SecretProvider: secretProvider})
+		assert.NoError(t, err)
+
+		store.EXPECT().FindByOpState(gomock.Any(), gomock.Any()).Return(&oidc4vc.Transaction{
+			TransactionData: oidc4vc.TransactionData{
+				ClientSecretHandle: "client-secret-handle",
+			},
+		}, nil)
+		secretProvider.EXPECT().GetSecret(gomock.Any(), "client-secret-handle").Return("", errors.New("not found"))
+
+		resp, err := srv.ExchangeAuthorizationCode(context.TODO(), "opState")
+		assert.Empty(t, resp)
+		assert.ErrorContains(t, err, "resolve client secret: not found")
+	})
+
+	t.Run("secret provider not configured", func(t *testing.T) {
+		store := NewMockTransactionStore(gomock.NewController(t))
+
+		srv, err := oidc4vc.NewService(&oidc4vc.Config{TransactionStore: store})
+		assert.NoError(t, err)
+
+		store.EXPECT().FindByOpState(gomock.Any(), gomock.Any()).Return(&oidc4vc.Transaction{
+			TransactionData: oidc4vc.TransactionData{
+				ClientSecretHandle: "client-secret-handle",
+			},
+		}, nil)
+
+		resp, err := srv.ExchangeAuthorizationCode(context.TODO(), "opState")
+		assert.Empty(t, resp)
+		assert.ErrorIs(t, err, oidc4vc.ErrSecretProviderNotConfigured)
+	})
+}
+
+func TestExchangeCodeTransactionCancelled(t *testing.T) {
+	store := NewMockTransactionStore(gomock.NewController(t))
+
+	srv, err := oidc4vc.NewService(&oidc4vc.Config{TransactionStore: store})
+	assert.NoError(t, err)
+
+	store.EXPECT().FindByOpState(gomock.Any(), gomock.Any()).Return(&oidc4vc.Transaction{
+		TransactionData: oidc4vc.TransactionData{
+			State: oidc4vc.TransactionStateCancelled,
+		},
+	}, nil)
+
+	resp, err := srv.ExchangeAuthorizationCode(context.TODO(), "opState")
+	assert.Empty(t, resp)
+	assert.ErrorIs(t, err, oidc4vc.ErrTransactionCancelled)
+}
+
 func TestExchangeCodeIssuerError(t *testing.T) {
 	store := NewMockTransactionStore(gomock.NewController(t))
 	factory := NewMockOAuth2ClientFactory(gomock.NewController(t))
@@ -124,7 +196,7 @@ func TestExchangeCodeStoreUpdateErr(t *testing.T) {
 	)
 
 	store.EXPECT().FindByOpState(gomock.Any(), opState).Return(baseTx, nil)
-	store.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+	store.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("update error"))
 
 	resp, err := srv.ExchangeAuthorizationCode(context.TODO(), opState)
 	assert.ErrorContains(t, err, "update error")
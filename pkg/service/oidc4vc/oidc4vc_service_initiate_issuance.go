@@ -8,19 +8,30 @@ package oidc4vc
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/trustbloc/vcs/internal/pkg/log"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 )
 
+// txIDSize is the size of transaction ID in bytes, same as the size of MongoDB ObjectID.
+const txIDSize = 12
+
 // InitiateIssuance creates credential issuance transaction and builds initiate issuance URL.
 func (s *Service) InitiateIssuance(
 	ctx context.Context,
 	req *InitiateIssuanceRequest,
 	profile *profileapi.Issuer,
-) (*InitiateIssuanceResponse, error) {
+) (_ *InitiateIssuanceResponse, err error) {
+	defer func(startTime time.Time) {
+		s.metrics.OIDC4VCIOperationTime(profile.ID, operationInitiateIssuance, err == nil, time.Since(startTime))
+	}(time.Now())
+
 	if !profile.Active {
 		return nil, ErrProfileNotActive
 	}
@@ -33,7 +44,7 @@ func (s *Service) InitiateIssuance(
 		return nil, ErrVCOptionsNotConfigured
 	}
 
-	template, err := findCredentialTemplate(profile.CredentialTemplates, req.CredentialTemplateID)
+	templates, err := findCredentialTemplates(profile, req.CredentialTemplateIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -43,18 +54,26 @@ func (s *Service) InitiateIssuance(
 		return nil, fmt.Errorf("get oidc configuration from well-known: %w", err)
 	}
 
+	if oidcConfig.AuthorizationEndpoint == "" || oidcConfig.TokenEndpoint == "" {
+		return nil, fmt.Errorf("%w: authorization_endpoint and token_endpoint are required",
+			ErrIssuerOIDCConfigurationInvalid)
+	}
+
 	data := &TransactionData{
-		CredentialTemplate:                 template,
-		CredentialFormat:                   profile.VCConfig.Format,
+		ProfileID:                          profile.ID,
+		CredentialTemplates:                templates,
 		AuthorizationEndpoint:              oidcConfig.AuthorizationEndpoint,
 		PushedAuthorizationRequestEndpoint: oidcConfig.PushedAuthorizationRequestEndpoint,
 		TokenEndpoint:                      oidcConfig.TokenEndpoint,
 		ClaimEndpoint:                      req.ClaimEndpoint,
 		ClientID:                           profile.OIDCConfig.ClientID,
+		ClientSecretHandle:                 profile.OIDCConfig.ClientSecretHandle,
 		GrantType:                          req.GrantType,
 		ResponseType:                       req.ResponseType,
 		Scope:                              req.Scope,
 		OpState:                            req.OpState,
+		State:                              TransactionStateOffered,
+		ExpiresAt:                          time.Now().UTC().Add(s.offerTTL(profile)),
 	}
 
 	if data.GrantType == "" {
@@ -69,31 +88,123 @@ func (s *Service) InitiateIssuance(
 		data.Scope = []string{defaultScope}
 	}
 
-	tx, err := s.store.Create(ctx, data)
+	txID, err := newTxID()
 	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.buildInitiateIssuanceResponse(ctx, req, templates, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	// initiate issuance URL is stored to render it as QR code later
+	data.InitiateIssuanceURL = resp.InitiateIssuanceURL
+
+	if _, err = s.store.Create(ctx, data, WithTxID(txID)); err != nil {
 		return nil, fmt.Errorf("store tx: %w", err)
 	}
 
-	return &InitiateIssuanceResponse{
-		InitiateIssuanceURL: s.buildInitiateIssuanceURL(ctx, req, template, tx.ID),
-		TxID:                tx.ID,
-	}, nil
+	resp.ExpiresAt = data.ExpiresAt
+
+	return resp, nil
 }
 
-func findCredentialTemplate(
-	credentialTemplates []*profileapi.CredentialTemplate,
-	templateID string,
-) (*profileapi.CredentialTemplate, error) {
+// newTxID generates random transaction ID. Transaction ID is a part of the issuer URL passed to the wallet, so it is
+// generated before the transaction is stored.
+func newTxID() (TxID, error) {
+	b := make([]byte, txIDSize)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate tx id: %w", err)
+	}
+
+	return TxID(hex.EncodeToString(b)), nil
+}
+
+// offerTTL returns lifetime of credential offer configured in the profile or the default one.
+func (s *Service) offerTTL(profile *profileapi.Issuer) time.Duration {
+	if profile.OIDCConfig.OfferTTL > 0 {
+		return time.Duration(profile.OIDCConfig.OfferTTL) * time.Second
+	}
+
+	return s.defaultOfferTTL
+}
+
+// GetCredentialOffer returns credential offer passed to the wallet by reference.
+func (s *Service) GetCredentialOffer(ctx context.Context, offerID string) (*CredentialOffer, error) {
+	offer, err := s.credentialOfferStore.Find(ctx, offerID)
+	if err != nil {
+		return nil, fmt.Errorf("find credential offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// GetInitiateIssuanceURL returns initiate issuance URL of the transaction initiated by the given profile.
+func (s *Service) GetInitiateIssuanceURL(ctx context.Context, profileID string, txID TxID) (string, error) {
+	tx, err := s.getProfileTx(ctx, profileID, txID)
+	if err != nil {
+		return "", err
+	}
+
+	if tx.InitiateIssuanceURL == "" {
+		return "", fmt.Errorf("get tx: %w", ErrDataNotFound)
+	}
+
+	return tx.InitiateIssuanceURL, nil
+}
+
+// findCredentialTemplates returns copies of offered credential templates with format resolved from the profile.
+func findCredentialTemplates(
+	profile *profileapi.Issuer,
+	templateIDs []string,
+) ([]*profileapi.CredentialTemplate, error) {
+	credentialTemplates := profile.CredentialTemplates
+
 	// profile should define at least one credential template
 	if len(credentialTemplates) == 0 || credentialTemplates[0].ID == "" {
 		return nil, ErrCredentialTemplateNotConfigured
 	}
 
-	// credential template ID is required if profile has more than one credential template defined
-	if len(credentialTemplates) > 1 && templateID == "" {
-		return nil, ErrCredentialTemplateIDRequired
+	if len(templateIDs) == 0 {
+		// credential template ID is required if profile has more than one credential template defined
+		if len(credentialTemplates) > 1 {
+			return nil, ErrCredentialTemplateIDRequired
+		}
+
+		templateIDs = []string{credentialTemplates[0].ID}
+	}
+
+	templates := make([]*profileapi.CredentialTemplate, 0, len(templateIDs))
+
+	for _, id := range templateIDs {
+		template, err := findCredentialTemplate(credentialTemplates, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range templates {
+			if t.ID == id {
+				return nil, ErrCredentialTemplateDuplicate
+			}
+		}
+
+		t := *template
+		if t.Format == "" {
+			t.Format = profile.VCConfig.Format
+		}
+
+		templates = append(templates, &t)
 	}
 
+	return templates, nil
+}
+
+func findCredentialTemplate(
+	credentialTemplates []*profileapi.CredentialTemplate,
+	templateID string,
+) (*profileapi.CredentialTemplate, error) {
 	for _, t := range credentialTemplates {
 		if t.ID == templateID {
 			return t, nil
@@ -103,23 +214,29 @@ func findCredentialTemplate(
 	return nil, ErrCredentialTemplateNotFound
 }
 
-func (s *Service) buildInitiateIssuanceURL(
+func (s *Service) buildInitiateIssuanceResponse(
 	ctx context.Context,
 	req *InitiateIssuanceRequest,
-	template *profileapi.CredentialTemplate,
+	templates []*profileapi.CredentialTemplate,
 	txID TxID,
-) string {
-	var initiateIssuanceURL string
+) (*InitiateIssuanceResponse, error) {
+	var (
+		initiateIssuanceURL  string
+		clientWellKnownError string
+	)
 
 	if req.ClientInitiateIssuanceURL != "" {
 		initiateIssuanceURL = req.ClientInitiateIssuanceURL
 	} else if req.ClientWellKnownURL != "" {
-		c, err := s.wellKnownService.GetOIDCConfiguration(ctx, req.ClientWellKnownURL)
+		var err error
+
+		initiateIssuanceURL, err = s.getClientInitiateIssuanceURL(ctx, req.ClientWellKnownURL)
 		if err != nil {
-			logger.Error(fmt.Sprintf("Failed to get OIDC configuration from well-known %q", req.ClientWellKnownURL),
+			logger.WithContext(ctx).Error(
+				fmt.Sprintf("Failed to get OIDC configuration from well-known %q", req.ClientWellKnownURL),
 				log.WithError(err))
-		} else {
-			initiateIssuanceURL = c.InitiateIssuanceEndpoint
+
+			clientWellKnownError = err.Error()
 		}
 	}
 
@@ -127,10 +244,56 @@ func (s *Service) buildInitiateIssuanceURL(
 		initiateIssuanceURL = "openid-initiate-issuance://"
 	}
 
+	offer := &CredentialOffer{
+		Issuer:          s.issuerVCSPublicHost + "/" + string(txID),
+		CredentialTypes: make([]string, 0, len(templates)),
+		OpState:         req.OpState,
+	}
+
+	for _, t := range templates {
+		offer.CredentialTypes = append(offer.CredentialTypes, t.Type)
+	}
+
+	resp := &InitiateIssuanceResponse{
+		TxID:                 txID,
+		ClientWellKnownError: clientWellKnownError,
+	}
 	q := url.Values{}
-	q.Set("issuer", s.issuerVCSPublicHost+"/"+string(txID))
-	q.Set("credential_type", template.Type)
-	q.Set("op_state", req.OpState)
 
-	return initiateIssuanceURL + "?" + q.Encode()
+	if req.CredentialOfferByReference {
+		offerID, err := s.credentialOfferStore.Create(ctx, offer, s.credentialOfferTTL)
+		if err != nil {
+			return nil, fmt.Errorf("store credential offer: %w", err)
+		}
+
+		resp.CredentialOfferURI = s.credentialOfferEndpoint + offerID
+
+		q.Set("credential_offer_uri", resp.CredentialOfferURI)
+	} else {
+		q.Set("issuer", offer.Issuer)
+
+		for _, t := range offer.CredentialTypes {
+			q.Add("credential_type", t)
+		}
+
+		q.Set("op_state", offer.OpState)
+	}
+
+	resp.InitiateIssuanceURL = initiateIssuanceURL + "?" + q.Encode()
+
+	return resp, nil
+}
+
+// getClientInitiateIssuanceURL returns initiate issuance endpoint from the client (wallet) well-known configuration.
+func (s *Service) getClientInitiateIssuanceURL(ctx context.Context, wellKnownURL string) (string, error) {
+	c, err := s.wellKnownService.GetOIDCConfiguration(ctx, wellKnownURL)
+	if err != nil {
+		return "", fmt.Errorf("get oidc configuration from client well-known: %w", err)
+	}
+
+	if c.InitiateIssuanceEndpoint == "" {
+		return "", errors.New("initiate_issuance_endpoint is not set in client well-known configuration")
+	}
+
+	return c.InitiateIssuanceEndpoint, nil
 }
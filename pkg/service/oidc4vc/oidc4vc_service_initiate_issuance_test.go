@@ -12,11 +12,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
 )
@@ -30,10 +34,16 @@ const (
 //go:embed testdata/issuer_profile.json
 var profileJSON []byte
 
+var issuerOIDCConfig = &oidc4vc.OIDCConfiguration{
+	AuthorizationEndpoint: "https://issuer.example.com/oauth2/auth",
+	TokenEndpoint:         "https://issuer.example.com/oauth2/token",
+}
+
 func TestService_InitiateIssuance(t *testing.T) {
 	var (
 		mockTransactionStore = NewMockTransactionStore(gomock.NewController(t))
 		mockWellKnownService = NewMockWellKnownService(gomock.NewController(t))
+		mockOfferStore       = NewMockCredentialOfferStore(gomock.NewController(t))
 		issuanceReq          *oidc4vc.InitiateIssuanceRequest
 		profile              *profileapi.Issuer
 	)
@@ -53,14 +63,16 @@ func TestService_InitiateIssuance(t *testing.T) {
 					&oidc4vc.Transaction{
 						ID: "txID",
 						TransactionData: oidc4vc.TransactionData{
-							CredentialTemplate: &profileapi.CredentialTemplate{
-								ID: "templateID",
+							CredentialTemplates: []*profileapi.CredentialTemplate{
+								{
+									ID: "templateID",
+								},
 							},
 						},
 					}, nil)
 
 				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
-					&oidc4vc.OIDCConfiguration{}, nil)
+					issuerOIDCConfig, nil)
 
 				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), walletWellKnownURL).Return(
 					&oidc4vc.OIDCConfiguration{
@@ -68,10 +80,10 @@ func TestService_InitiateIssuance(t *testing.T) {
 					}, nil)
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID: "templateID",
-					ClientWellKnownURL:   walletWellKnownURL,
-					ClaimEndpoint:        "https://vcs.pb.example.com/claim",
-					OpState:              "eyJhbGciOiJSU0Et",
+					CredentialTemplateIDs: []string{"templateID"},
+					ClientWellKnownURL:    walletWellKnownURL,
+					ClaimEndpoint:         "https://vcs.pb.example.com/claim",
+					OpState:               "eyJhbGciOiJSU0Et",
 				}
 
 				profile = &testProfile
@@ -79,13 +91,94 @@ func TestService_InitiateIssuance(t *testing.T) {
 			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
 				require.NoError(t, err)
 				require.Contains(t, resp.InitiateIssuanceURL, "https://wallet.example.com/initiate_issuance")
+				require.Len(t, resp.TxID, 24)
+			},
+		},
+		{
+			name: "Success with multiple credential templates",
+			setup: func() {
+				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(
+						ctx context.Context,
+						data *oidc4vc.TransactionData,
+						params ...func(insertOptions *oidc4vc.InsertOptions),
+					) (*oidc4vc.Transaction, error) {
+						require.Len(t, data.CredentialTemplates, 2)
+						require.Equal(t, "templateID", data.CredentialTemplates[0].ID)
+						require.Equal(t, vcsverifiable.Ldp, data.CredentialTemplates[0].Format)
+						require.Equal(t, "templateID2", data.CredentialTemplates[1].ID)
+						require.Equal(t, vcsverifiable.Jwt, data.CredentialTemplates[1].Format)
+						require.Equal(t, "test_issuer_client_secret_handle", data.ClientSecretHandle)
+						require.Equal(t, oidc4vc.TransactionStateOffered, data.State)
+						require.WithinDuration(t, time.Now().Add(24*time.Hour), data.ExpiresAt, time.Minute)
+
+						return &oidc4vc.Transaction{ID: "txID", TransactionData: *data}, nil
+					})
+
+				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
+					issuerOIDCConfig, nil)
+
+				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
+					CredentialTemplateIDs:     []string{"templateID", "templateID2"},
+					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
+					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
+					OpState:                   "eyJhbGciOiJSU0Et",
+				}
+
+				profile = &testProfile
+			},
+			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
+				require.NoError(t, err)
+
+				u, err := url.Parse(resp.InitiateIssuanceURL)
+				require.NoError(t, err)
+				require.Equal(t, []string{"PermanentResidentCard", "UniversityDegreeCredential"},
+					u.Query()["credential_type"])
+
+				// profile templates are not modified
+				require.Empty(t, testProfile.CredentialTemplates[0].Format)
+			},
+		},
+		{
+			name: "Success with offer TTL from profile",
+			setup: func() {
+				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(
+						ctx context.Context,
+						data *oidc4vc.TransactionData,
+						params ...func(insertOptions *oidc4vc.InsertOptions),
+					) (*oidc4vc.Transaction, error) {
+						require.WithinDuration(t, time.Now().Add(10*time.Minute), data.ExpiresAt, time.Minute)
+
+						return &oidc4vc.Transaction{ID: "txID", TransactionData: *data}, nil
+					})
+
+				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
+					issuerOIDCConfig, nil)
+
+				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
+					CredentialTemplateIDs: []string{"templateID"},
+					OpState:               "eyJhbGciOiJSU0Et",
+				}
+
+				oidcConfig := *testProfile.OIDCConfig
+				oidcConfig.OfferTTL = 600
+
+				p := testProfile
+				p.OIDCConfig = &oidcConfig
+
+				profile = &p
+			},
+			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
+				require.NoError(t, err)
+				require.WithinDuration(t, time.Now().Add(10*time.Minute), resp.ExpiresAt, time.Minute)
 			},
 		},
 		{
 			name: "Profile is not active",
 			setup: func() {
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID",
+					CredentialTemplateIDs:     []string{"templateID"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -106,7 +199,7 @@ func TestService_InitiateIssuance(t *testing.T) {
 			name: "OIDC4VC authorized code flow not supported",
 			setup: func() {
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID",
+					CredentialTemplateIDs:     []string{"templateID"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -126,7 +219,7 @@ func TestService_InitiateIssuance(t *testing.T) {
 			name: "VC options not configured",
 			setup: func() {
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID",
+					CredentialTemplateIDs:     []string{"templateID"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -148,7 +241,7 @@ func TestService_InitiateIssuance(t *testing.T) {
 				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID",
+					CredentialTemplateIDs:     []string{"templateID"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -171,7 +264,6 @@ func TestService_InitiateIssuance(t *testing.T) {
 				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "",
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -190,7 +282,7 @@ func TestService_InitiateIssuance(t *testing.T) {
 				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID3",
+					CredentialTemplateIDs:     []string{"templateID3"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -203,18 +295,37 @@ func TestService_InitiateIssuance(t *testing.T) {
 				require.ErrorIs(t, err, oidc4vc.ErrCredentialTemplateNotFound)
 			},
 		},
+		{
+			name: "Credential template is offered more than once",
+			setup: func() {
+				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
+					CredentialTemplateIDs:     []string{"templateID", "templateID"},
+					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
+					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
+					OpState:                   "eyJhbGciOiJSU0Et",
+				}
+
+				profile = &testProfile
+			},
+			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
+				require.Nil(t, resp)
+				require.ErrorIs(t, err, oidc4vc.ErrCredentialTemplateDuplicate)
+			},
+		},
 		{
 			name: "Client initiate issuance URL takes precedence over client well-known parameter",
 			setup: func() {
 				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(&oidc4vc.Transaction{}, nil)
 
 				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
-					&oidc4vc.OIDCConfiguration{}, nil)
+					issuerOIDCConfig, nil)
 
 				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), walletWellKnownURL).Times(0)
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID",
+					CredentialTemplateIDs:     []string{"templateID"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClientWellKnownURL:        walletWellKnownURL,
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
@@ -235,16 +346,44 @@ func TestService_InitiateIssuance(t *testing.T) {
 					&oidc4vc.Transaction{}, nil)
 
 				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
-					&oidc4vc.OIDCConfiguration{}, nil)
+					issuerOIDCConfig, nil)
 
 				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), walletWellKnownURL).Return(
 					nil, errors.New("invalid json"))
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID: "templateID",
-					ClientWellKnownURL:   walletWellKnownURL,
-					ClaimEndpoint:        "https://vcs.pb.example.com/claim",
-					OpState:              "eyJhbGciOiJSU0Et",
+					CredentialTemplateIDs: []string{"templateID"},
+					ClientWellKnownURL:    walletWellKnownURL,
+					ClaimEndpoint:         "https://vcs.pb.example.com/claim",
+					OpState:               "eyJhbGciOiJSU0Et",
+				}
+
+				profile = &testProfile
+			},
+			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
+				require.NoError(t, err)
+				require.Contains(t, resp.InitiateIssuanceURL, "openid-initiate-issuance://")
+				require.Contains(t, resp.ClientWellKnownError,
+					"get oidc configuration from client well-known: invalid json")
+			},
+		},
+		{
+			name: "Custom initiate issuance URL when client well-known has no initiate issuance endpoint",
+			setup: func() {
+				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					&oidc4vc.Transaction{}, nil)
+
+				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
+					issuerOIDCConfig, nil)
+
+				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), walletWellKnownURL).Return(
+					&oidc4vc.OIDCConfiguration{}, nil)
+
+				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
+					CredentialTemplateIDs: []string{"templateID"},
+					ClientWellKnownURL:    walletWellKnownURL,
+					ClaimEndpoint:         "https://vcs.pb.example.com/claim",
+					OpState:               "eyJhbGciOiJSU0Et",
 				}
 
 				profile = &testProfile
@@ -252,6 +391,29 @@ func TestService_InitiateIssuance(t *testing.T) {
 			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
 				require.NoError(t, err)
 				require.Contains(t, resp.InitiateIssuanceURL, "openid-initiate-issuance://")
+				require.Contains(t, resp.ClientWellKnownError, "initiate_issuance_endpoint is not set")
+			},
+		},
+		{
+			name: "Issuer OIDC configuration has no token endpoint",
+			setup: func() {
+				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
+					&oidc4vc.OIDCConfiguration{
+						AuthorizationEndpoint: "https://issuer.example.com/oauth2/auth",
+					}, nil)
+
+				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
+					CredentialTemplateIDs:     []string{"templateID"},
+					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
+					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
+					OpState:                   "eyJhbGciOiJSU0Et",
+				}
+
+				profile = &testProfile
+			},
+			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
+				require.Nil(t, resp)
+				require.ErrorIs(t, err, oidc4vc.ErrIssuerOIDCConfigurationInvalid)
 			},
 		},
 		{
@@ -261,7 +423,7 @@ func TestService_InitiateIssuance(t *testing.T) {
 					nil, errors.New("well known service error"))
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID",
+					CredentialTemplateIDs:     []string{"templateID"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -282,10 +444,10 @@ func TestService_InitiateIssuance(t *testing.T) {
 					nil, fmt.Errorf("store error"))
 
 				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
-					&oidc4vc.OIDCConfiguration{}, nil)
+					issuerOIDCConfig, nil)
 
 				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
-					CredentialTemplateID:      "templateID",
+					CredentialTemplateIDs:     []string{"templateID"},
 					ClientInitiateIssuanceURL: "https://wallet.example.com/initiate_issuance",
 					ClaimEndpoint:             "https://vcs.pb.example.com/claim",
 					OpState:                   "eyJhbGciOiJSU0Et",
@@ -299,15 +461,99 @@ func TestService_InitiateIssuance(t *testing.T) {
 				require.Contains(t, err.Error(), "store error")
 			},
 		},
+		{
+			name: "Success with credential offer by reference",
+			setup: func() {
+				var txID oidc4vc.TxID
+
+				mockOfferStore.EXPECT().Create(gomock.Any(), gomock.Any(), 15*time.Minute).DoAndReturn(
+					func(ctx context.Context, offer *oidc4vc.CredentialOffer, ttl time.Duration) (string, error) {
+						require.True(t, strings.HasPrefix(offer.Issuer, issuerVCSPublicHost+"/"))
+						require.Equal(t, []string{"PermanentResidentCard"}, offer.CredentialTypes)
+						require.Equal(t, "eyJhbGciOiJSU0Et", offer.OpState)
+
+						txID = oidc4vc.TxID(strings.TrimPrefix(offer.Issuer, issuerVCSPublicHost+"/"))
+
+						return "offerID", nil
+					})
+
+				// initiate issuance URL is stored with the transaction in a single write
+				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(
+						ctx context.Context,
+						data *oidc4vc.TransactionData,
+						params ...func(insertOptions *oidc4vc.InsertOptions),
+					) (*oidc4vc.Transaction, error) {
+						require.Equal(t, "https://wallet.example.com/initiate_issuance?credential_offer_uri="+
+							url.QueryEscape("https://vcs.pb.example.com/issuer/credential-offers/offerID"),
+							data.InitiateIssuanceURL)
+
+						opts := &oidc4vc.InsertOptions{}
+						for _, p := range params {
+							p(opts)
+						}
+
+						require.Equal(t, txID, opts.TxID)
+
+						return &oidc4vc.Transaction{ID: opts.TxID, TransactionData: *data}, nil
+					})
+
+				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
+					issuerOIDCConfig, nil)
+
+				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
+					CredentialTemplateIDs:      []string{"templateID"},
+					ClientInitiateIssuanceURL:  "https://wallet.example.com/initiate_issuance",
+					OpState:                    "eyJhbGciOiJSU0Et",
+					CredentialOfferByReference: true,
+				}
+
+				profile = &testProfile
+			},
+			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://vcs.pb.example.com/issuer/credential-offers/offerID", resp.CredentialOfferURI)
+
+				u, err := url.Parse(resp.InitiateIssuanceURL)
+				require.NoError(t, err)
+				require.Equal(t, url.Values{"credential_offer_uri": {resp.CredentialOfferURI}}, u.Query())
+			},
+		},
+		{
+			name: "Fail to store credential offer",
+			setup: func() {
+				mockTransactionStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+				mockOfferStore.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					"", errors.New("offer store error"))
+
+				mockWellKnownService.EXPECT().GetOIDCConfiguration(gomock.Any(), issuerWellKnownURL).Return(
+					issuerOIDCConfig, nil)
+
+				issuanceReq = &oidc4vc.InitiateIssuanceRequest{
+					CredentialTemplateIDs:      []string{"templateID"},
+					OpState:                    "eyJhbGciOiJSU0Et",
+					CredentialOfferByReference: true,
+				}
+
+				profile = &testProfile
+			},
+			check: func(t *testing.T, resp *oidc4vc.InitiateIssuanceResponse, err error) {
+				require.Nil(t, resp)
+				require.ErrorContains(t, err, "store credential offer: offer store error")
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
 
 			svc, err := oidc4vc.NewService(&oidc4vc.Config{
-				TransactionStore:    mockTransactionStore,
-				WellKnownService:    mockWellKnownService,
-				IssuerVCSPublicHost: issuerVCSPublicHost,
+				TransactionStore:        mockTransactionStore,
+				WellKnownService:        mockWellKnownService,
+				IssuerVCSPublicHost:     issuerVCSPublicHost,
+				CredentialOfferStore:    mockOfferStore,
+				CredentialOfferEndpoint: "https://vcs.pb.example.com/issuer/credential-offers/",
 			})
 			require.NoError(t, err)
 
@@ -316,3 +562,58 @@ func TestService_InitiateIssuance(t *testing.T) {
 		})
 	}
 }
+
+func TestService_GetCredentialOffer(t *testing.T) {
+	mockOfferStore := NewMockCredentialOfferStore(gomock.NewController(t))
+
+	svc, err := oidc4vc.NewService(&oidc4vc.Config{
+		CredentialOfferStore: mockOfferStore,
+	})
+	require.NoError(t, err)
+
+	offer := &oidc4vc.CredentialOffer{
+		Issuer:          issuerVCSPublicHost + "/txID",
+		CredentialTypes: []string{"PermanentResidentCard"},
+		OpState:         "eyJhbGciOiJSU0Et",
+	}
+
+	mockOfferStore.EXPECT().Find(gomock.Any(), "offerID").Return(offer, nil)
+
+	resp, err := svc.GetCredentialOffer(context.Background(), "offerID")
+	require.NoError(t, err)
+	require.Equal(t, offer, resp)
+
+	mockOfferStore.EXPECT().Find(gomock.Any(), "expired").Return(nil, oidc4vc.ErrDataNotFound)
+
+	_, err = svc.GetCredentialOffer(context.Background(), "expired")
+	require.ErrorIs(t, err, oidc4vc.ErrDataNotFound)
+}
+
+func TestService_GetInitiateIssuanceURL(t *testing.T) {
+	mockTransactionStore := NewMockTransactionStore(gomock.NewController(t))
+
+	svc, err := oidc4vc.NewService(&oidc4vc.Config{
+		TransactionStore: mockTransactionStore,
+	})
+	require.NoError(t, err)
+
+	mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(&oidc4vc.Transaction{
+		ID: "txID",
+		TransactionData: oidc4vc.TransactionData{
+			ProfileID:           "profileID",
+			InitiateIssuanceURL: "openid-initiate-issuance://?op_state=state",
+		},
+	}, nil).Times(2)
+
+	initiateURL, err := svc.GetInitiateIssuanceURL(context.Background(), "profileID", "txID")
+	require.NoError(t, err)
+	require.Equal(t, "openid-initiate-issuance://?op_state=state", initiateURL)
+
+	_, err = svc.GetInitiateIssuanceURL(context.Background(), "otherProfileID", "txID")
+	require.ErrorIs(t, err, oidc4vc.ErrDataNotFound)
+
+	mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(nil, errors.New("get error"))
+
+	_, err = svc.GetInitiateIssuanceURL(context.Background(), "profileID", "txID")
+	require.ErrorContains(t, err, "get tx: get error")
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetIssuanceStatus returns status of the transaction initiated by the given profile.
+func (s *Service) GetIssuanceStatus(ctx context.Context, profileID string, txID TxID) (*TransactionStatus, error) {
+	tx, err := s.getProfileTx(ctx, profileID, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionStatus{
+		TxID:      tx.ID,
+		State:     transactionState(tx, time.Now()),
+		ExpiresAt: tx.ExpiresAt,
+	}, nil
+}
+
+// CancelIssuance cancels the transaction initiated by the given profile. The wallet can't proceed with cancelled
+// transaction. Transaction can't be cancelled after credential was issued or after credential offer expired.
+func (s *Service) CancelIssuance(ctx context.Context, profileID string, txID TxID) (err error) {
+	var tx *Transaction
+
+	defer func(startTime time.Time) {
+		s.observeOperation(operationCancelIssuance, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.getProfileTx(ctx, profileID, txID)
+	if err != nil {
+		return err
+	}
+
+	switch state := transactionState(tx, time.Now()); state { //nolint:exhaustive
+	case TransactionStateCancelled:
+		return nil
+	case TransactionStateCredentialIssued, TransactionStateExpired:
+		return fmt.Errorf("%w: transaction is %s", ErrTransactionNotCancellable, state)
+	}
+
+	prevState := tx.State
+	tx.State = TransactionStateCancelled
+
+	if err = s.store.Update(ctx, tx, prevState); err != nil {
+		return fmt.Errorf("update tx: %w", err)
+	}
+
+	return nil
+}
+
+// getProfileTx returns transaction initiated by the given profile.
+func (s *Service) getProfileTx(ctx context.Context, profileID string, txID TxID) (*Transaction, error) {
+	tx, err := s.store.Get(ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf("get tx: %w", err)
+	}
+
+	// transaction of another profile is reported as not found
+	if tx.ProfileID != profileID {
+		return nil, fmt.Errorf("get tx: %w", ErrDataNotFound)
+	}
+
+	return tx, nil
+}
+
+// transactionState returns state of the transaction at the given time. Credential offer expires if access token
+// was not issued to the wallet before offer expiration time.
+func transactionState(tx *Transaction, now time.Time) TransactionState {
+	state := tx.State
+
+	if state == "" {
+		state = TransactionStateOffered
+	}
+
+	if (state == TransactionStateOffered || state == TransactionStateAuthorized) &&
+		!tx.ExpiresAt.IsZero() && now.After(tx.ExpiresAt) {
+		return TransactionStateExpired
+	}
+
+	return state
+}
+
+// checkTransactionActive returns error if the transaction was cancelled or its credential offer expired.
+func checkTransactionActive(tx *Transaction) error {
+	switch transactionState(tx, time.Now()) { //nolint:exhaustive
+	case TransactionStateExpired:
+		return ErrTransactionExpired
+	case TransactionStateCancelled:
+		return ErrTransactionCancelled
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
+)
+
+func TestService_GetIssuanceStatus(t *testing.T) {
+	var (
+		mockTransactionStore = NewMockTransactionStore(gomock.NewController(t))
+		expiresAt            = time.Now().Add(time.Hour)
+	)
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, status *oidc4vc.TransactionStatus, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						ProfileID: "profileID",
+						State:     oidc4vc.TransactionStateTokenIssued,
+						ExpiresAt: expiresAt,
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, status *oidc4vc.TransactionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, oidc4vc.TxID("txID"), status.TxID)
+				require.Equal(t, oidc4vc.TransactionStateTokenIssued, status.State)
+				require.Equal(t, expiresAt, status.ExpiresAt)
+			},
+		},
+		{
+			name: "Transaction without state is offered",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						ProfileID: "profileID",
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, status *oidc4vc.TransactionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, oidc4vc.TransactionStateOffered, status.State)
+			},
+		},
+		{
+			name: "Offer expired",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						ProfileID: "profileID",
+						State:     oidc4vc.TransactionStateAuthorized,
+						ExpiresAt: time.Now().Add(-time.Minute),
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, status *oidc4vc.TransactionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, oidc4vc.TransactionStateExpired, status.State)
+			},
+		},
+		{
+			name: "Offer expiration doesn't affect issued token",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						ProfileID: "profileID",
+						State:     oidc4vc.TransactionStateTokenIssued,
+						ExpiresAt: time.Now().Add(-time.Minute),
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, status *oidc4vc.TransactionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, oidc4vc.TransactionStateTokenIssued, status.State)
+			},
+		},
+		{
+			name: "Transaction of another profile",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						ProfileID: "otherProfileID",
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, status *oidc4vc.TransactionStatus, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDataNotFound)
+				require.Nil(t, status)
+			},
+		},
+		{
+			name: "Fail to get transaction",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(
+					nil, errors.New("get error"))
+			},
+			check: func(t *testing.T, status *oidc4vc.TransactionStatus, err error) {
+				require.ErrorContains(t, err, "get tx: get error")
+				require.Nil(t, status)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			svc, err := oidc4vc.NewService(&oidc4vc.Config{
+				TransactionStore: mockTransactionStore,
+			})
+			require.NoError(t, err)
+
+			status, err := svc.GetIssuanceStatus(context.Background(), "profileID", "txID")
+			tt.check(t, status, err)
+		})
+	}
+}
+
+func TestService_CancelIssuance(t *testing.T) {
+	var (
+		mockTransactionStore = NewMockTransactionStore(gomock.NewController(t))
+		mockMetrics          = NewMockMetricsProvider(gomock.NewController(t))
+	)
+
+	newTx := func(state oidc4vc.TransactionState) *oidc4vc.Transaction {
+		return &oidc4vc.Transaction{
+			ID: "txID",
+			TransactionData: oidc4vc.TransactionData{
+				ProfileID: "profileID",
+				State:     state,
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(
+					newTx(oidc4vc.TransactionStateOffered), nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx *oidc4vc.Transaction, expectedState oidc4vc.TransactionState) error {
+						require.Equal(t, oidc4vc.TransactionStateCancelled, tx.State)
+						require.Equal(t, oidc4vc.TransactionStateOffered, expectedState)
+
+						return nil
+					})
+				mockMetrics.EXPECT().OIDC4VCIOperationTime("profileID", "cancel_issuance", true, gomock.Any())
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Already cancelled",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(
+					newTx(oidc4vc.TransactionStateCancelled), nil)
+				mockMetrics.EXPECT().OIDC4VCIOperationTime("profileID", "cancel_issuance", true, gomock.Any())
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Credential issued",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(
+					newTx(oidc4vc.TransactionStateCredentialIssued), nil)
+				mockMetrics.EXPECT().OIDC4VCIOperationTime("profileID", "cancel_issuance", false, gomock.Any())
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrTransactionNotCancellable)
+				require.ErrorContains(t, err, "transaction is credential_issued")
+			},
+		},
+		{
+			name: "Offer expired",
+			setup: func() {
+				tx := newTx(oidc4vc.TransactionStateOffered)
+				tx.ExpiresAt = time.Now().Add(-time.Minute)
+
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(tx, nil)
+				mockMetrics.EXPECT().OIDC4VCIOperationTime("profileID", "cancel_issuance", false, gomock.Any())
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrTransactionNotCancellable)
+				require.ErrorContains(t, err, "transaction is expired")
+			},
+		},
+		{
+			name: "Transaction of another profile",
+			setup: func() {
+				tx := newTx(oidc4vc.TransactionStateOffered)
+				tx.ProfileID = "otherProfileID"
+
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(tx, nil)
+				mockMetrics.EXPECT().OIDC4VCIOperationTime("", "cancel_issuance", false, gomock.Any())
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDataNotFound)
+			},
+		},
+		{
+			name: "Fail to update transaction",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(
+					newTx(oidc4vc.TransactionStateTokenIssued), nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					errors.New("update error"))
+				mockMetrics.EXPECT().OIDC4VCIOperationTime("profileID", "cancel_issuance", false, gomock.Any())
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "update tx: update error")
+			},
+		},
+		{
+			name: "Transaction state changed concurrently",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(
+					newTx(oidc4vc.TransactionStateTokenIssued), nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), oidc4vc.TransactionStateTokenIssued).
+					Return(oidc4vc.ErrTransactionStateChanged)
+				mockMetrics.EXPECT().OIDC4VCIOperationTime("profileID", "cancel_issuance", false, gomock.Any())
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrTransactionStateChanged)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			svc, err := oidc4vc.NewService(&oidc4vc.Config{
+				TransactionStore: mockTransactionStore,
+				Metrics:          mockMetrics,
+			})
+			require.NoError(t, err)
+
+			tt.check(t, svc.CancelIssuance(context.Background(), "profileID", "txID"))
+		})
+	}
+}
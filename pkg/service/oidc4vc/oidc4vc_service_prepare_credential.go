@@ -0,0 +1,366 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/samber/lo"
+
+	profileapi "github.com/trustbloc/vcs/pkg/profile"
+)
+
+const (
+	w3CredentialsURL           = "https://www.w3.org/2018/credentials/v1"
+	vcTypeVerifiableCredential = "VerifiableCredential"
+)
+
+var errClaimDataNotReady = errors.New("claim data not ready")
+
+// PrepareCredential builds unsigned credential of the requested type offered in the transaction. Claims are taken
+// from the credential template and from the issuer claim endpoint, if configured. The wallet may request any of
+// the offered credentials, or only the ones it has requested authorization for. If claim data is not ready yet,
+// issuance is deferred and the response contains acceptance token instead of the credential. If ValidateOnly is set,
+// only checks that the credential can be issued, the response contains neither credential nor acceptance token.
+func (s *Service) PrepareCredential(
+	ctx context.Context,
+	req *PrepareCredentialRequest,
+) (_ *PrepareCredentialResponse, err error) {
+	var tx *Transaction
+
+	defer func(startTime time.Time) {
+		s.observeOperation(operationPrepareCredential, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.store.FindByOpState(ctx, req.OpState)
+	if err != nil {
+		return nil, fmt.Errorf("find tx by op state: %w", err)
+	}
+
+	if err = checkTransactionActive(tx); err != nil {
+		return nil, err
+	}
+
+	template, err := findOfferedTemplate(tx, req.CredentialType, req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAuthorized(tx, template) {
+		return nil, ErrCredentialNotAuthorized
+	}
+
+	if req.ValidateOnly {
+		return &PrepareCredentialResponse{
+			ProfileID: tx.ProfileID,
+			TxID:      tx.ID,
+			Format:    template.Format,
+		}, nil
+	}
+
+	var claimData map[string]interface{}
+
+	if tx.ClaimEndpoint != "" {
+		claimData, err = s.getClaimData(ctx, tx, template)
+		if errors.Is(err, errClaimDataNotReady) {
+			return s.deferCredential(ctx, tx, template, req.DID)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	credential, err := buildCredential(template, req.DID, claimData)
+	if err != nil {
+		return nil, err
+	}
+
+	prevState := tx.State
+	tx.State = TransactionStateCredentialIssued
+
+	if err = s.store.Update(ctx, tx, prevState); err != nil {
+		return nil, fmt.Errorf("update tx: %w", err)
+	}
+
+	return &PrepareCredentialResponse{
+		ProfileID:  tx.ProfileID,
+		TxID:       tx.ID,
+		Format:     template.Format,
+		Credential: credential,
+	}, nil
+}
+
+// deferCredential stores deferred credential in the transaction and returns acceptance token the wallet uses
+// to obtain the credential when the issuer provides claim data.
+func (s *Service) deferCredential(
+	ctx context.Context,
+	tx *Transaction,
+	template *profileapi.CredentialTemplate,
+	did string,
+) (*PrepareCredentialResponse, error) {
+	deferred := &DeferredCredential{
+		AcceptanceToken: uuid.NewString(),
+		CredentialType:  template.Type,
+		Format:          template.Format,
+		DID:             did,
+	}
+
+	if err := s.store.AddDeferredCredential(ctx, tx.ID, deferred); err != nil {
+		return nil, fmt.Errorf("add deferred credential: %w", err)
+	}
+
+	return &PrepareCredentialResponse{
+		ProfileID:       tx.ProfileID,
+		TxID:            tx.ID,
+		Format:          template.Format,
+		AcceptanceToken: deferred.AcceptanceToken,
+	}, nil
+}
+
+// StoreDeferredClaimData stores claim data provided by the issuer for credentials of the given type which issuance
+// was deferred.
+func (s *Service) StoreDeferredClaimData(ctx context.Context, req *StoreDeferredClaimDataRequest) (err error) {
+	var tx *Transaction
+
+	defer func(startTime time.Time) {
+		s.observeOperation(operationStoreDeferredClaimData, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.getProfileTx(ctx, req.ProfileID, req.TxID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkTransactionActive(tx); err != nil {
+		return err
+	}
+
+	var claims map[string]interface{}
+
+	if err = json.Unmarshal(req.ClaimData, &claims); err != nil || claims == nil {
+		return fmt.Errorf("%w: claim data should be a JSON object", ErrInvalidClaimData)
+	}
+
+	deferred, ok := lo.Find(tx.DeferredCredentials, func(d *DeferredCredential) bool {
+		return strings.EqualFold(d.CredentialType, req.CredentialType)
+	})
+	if !ok {
+		return ErrDeferredCredentialNotFound
+	}
+
+	err = s.store.StoreDeferredClaimData(ctx, tx.ID, deferred.CredentialType, req.ClaimData)
+	if errors.Is(err, ErrDataNotFound) {
+		return ErrDeferredCredentialNotFound
+	}
+
+	if err != nil {
+		return fmt.Errorf("store deferred claim data: %w", err)
+	}
+
+	return nil
+}
+
+// PrepareDeferredCredential builds unsigned credential which issuance was deferred. Returns
+// ErrCredentialIssuancePending if the issuer has not provided claim data yet. The acceptance token can be used
+// only once, deferred credential is removed from the transaction after it is prepared.
+func (s *Service) PrepareDeferredCredential(
+	ctx context.Context,
+	acceptanceToken string,
+) (_ *PrepareCredentialResponse, err error) {
+	var tx *Transaction
+
+	defer func(startTime time.Time) {
+		s.observeOperation(operationPrepareDeferredCredential, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.store.FindByAcceptanceToken(ctx, acceptanceToken)
+	if err != nil {
+		if errors.Is(err, ErrDataNotFound) {
+			return nil, ErrDeferredCredentialNotFound
+		}
+
+		return nil, fmt.Errorf("find tx by acceptance token: %w", err)
+	}
+
+	if err = checkTransactionActive(tx); err != nil {
+		return nil, err
+	}
+
+	deferred, ok := lo.Find(tx.DeferredCredentials, func(d *DeferredCredential) bool {
+		return d.AcceptanceToken == acceptanceToken
+	})
+	if !ok {
+		return nil, ErrDeferredCredentialNotFound
+	}
+
+	if len(deferred.ClaimData) == 0 {
+		return nil, ErrCredentialIssuancePending
+	}
+
+	template, err := findOfferedTemplate(tx, deferred.CredentialType, deferred.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimData map[string]interface{}
+
+	if err = json.Unmarshal(deferred.ClaimData, &claimData); err != nil {
+		return nil, fmt.Errorf("parse claim data: %w", err)
+	}
+
+	credential, err := buildCredential(template, deferred.DID, claimData)
+	if err != nil {
+		return nil, err
+	}
+
+	// the credential is returned only if this request removed the deferred credential, a concurrent request
+	// with the same acceptance token gets ErrDeferredCredentialNotFound
+	err = s.store.RemoveDeferredCredential(ctx, tx.ID, acceptanceToken, TransactionStateCredentialIssued)
+	if errors.Is(err, ErrDataNotFound) {
+		return nil, ErrDeferredCredentialNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("remove deferred credential: %w", err)
+	}
+
+	tx.State = TransactionStateCredentialIssued
+
+	return &PrepareCredentialResponse{
+		ProfileID:  tx.ProfileID,
+		TxID:       tx.ID,
+		Format:     template.Format,
+		Credential: credential,
+	}, nil
+}
+
+// buildCredential builds unsigned credential from the template. Claims from the template are overridden by
+// the claim data provided by the issuer.
+func buildCredential(
+	template *profileapi.CredentialTemplate,
+	did string,
+	claimData map[string]interface{},
+) (*verifiable.Credential, error) {
+	claims := map[string]interface{}{}
+
+	if len(template.CredentialSubject) > 0 {
+		if err := json.Unmarshal(template.CredentialSubject, &claims); err != nil {
+			return nil, fmt.Errorf("parse credential subject of template %s: %w", template.ID, err)
+		}
+	}
+
+	for k, v := range claimData {
+		claims[k] = v
+	}
+
+	// subject ID is set from the holder DID
+	delete(claims, "id")
+
+	contexts := template.Contexts
+	if len(contexts) == 0 {
+		contexts = []string{w3CredentialsURL}
+	}
+
+	return &verifiable.Credential{
+		Context: contexts,
+		ID:      "urn:uuid:" + uuid.NewString(),
+		Types:   []string{vcTypeVerifiableCredential, template.Type},
+		Issuer:  verifiable.Issuer{ID: template.Issuer},
+		Issued:  util.NewTime(time.Now()),
+		Subject: []verifiable.Subject{{
+			ID:           did,
+			CustomFields: claims,
+		}},
+	}, nil
+}
+
+// isAuthorized returns true if the credential was requested in authorization details. All offered credentials
+// are authorized if the wallet didn't provide authorization details.
+func isAuthorized(tx *Transaction, template *profileapi.CredentialTemplate) bool {
+	if len(tx.AuthorizationDetails) == 0 {
+		return true
+	}
+
+	for _, ad := range tx.AuthorizationDetails {
+		if strings.EqualFold(ad.CredentialType, template.Type) && (ad.Format == "" || ad.Format == template.Format) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getClaimData requests claims of the credential from issuer claim endpoint using access token obtained from
+// issuer OAuth provider. Claim endpoint responds with 202 Accepted if claim data is not ready yet, e.g. it
+// requires approval, in this case issuance of the credential is deferred.
+func (s *Service) getClaimData(
+	ctx context.Context,
+	tx *Transaction,
+	template *profileapi.CredentialTemplate,
+) (map[string]interface{}, error) {
+	if tx.IssuerToken == "" {
+		return nil, ErrClaimDataNotAvailable
+	}
+
+	claimURL, err := url.Parse(tx.ClaimEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse claim endpoint: %w", err)
+	}
+
+	q := claimURL.Query()
+	q.Set("credential_type", template.Type)
+	claimURL.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, claimURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create claim data request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+tx.IssuerToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get claim data: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusAccepted {
+		return nil, errClaimDataNotReady
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get claim data: unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read claim data: %w", err)
+	}
+
+	var claims map[string]interface{}
+
+	if err = json.Unmarshal(body, &claims); err != nil {
+		return nil, fmt.Errorf("decode claim data: %w", err)
+	}
+
+	return claims, nil
+}
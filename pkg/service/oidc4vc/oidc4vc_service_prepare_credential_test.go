@@ -0,0 +1,640 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/stretchr/testify/require"
+
+	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
+	profileapi "github.com/trustbloc/vcs/pkg/profile"
+	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
+)
+
+func TestService_PrepareCredential(t *testing.T) {
+	var (
+		mockTransactionStore = NewMockTransactionStore(gomock.NewController(t))
+		mockHTTPClient       = NewMockHTTPClient(gomock.NewController(t))
+		req                  *oidc4vc.PrepareCredentialRequest
+	)
+
+	newTx := func() *oidc4vc.Transaction {
+		return &oidc4vc.Transaction{
+			ID: "txID",
+			TransactionData: oidc4vc.TransactionData{
+				ProfileID: "profileID",
+				CredentialTemplates: []*profileapi.CredentialTemplate{
+					{
+						ID:                "driversLicense",
+						Type:              "DriversLicense",
+						Contexts:          []string{"https://www.w3.org/2018/credentials/v1"},
+						Issuer:            "did:example:issuer",
+						CredentialSubject: []byte(`{"id":"did:example:other","class":"B"}`),
+						Format:            vcsverifiable.Jwt,
+					},
+					{
+						ID:     "vehicleRegistration",
+						Type:   "VehicleRegistration",
+						Format: vcsverifiable.Ldp,
+					},
+				},
+				ClaimEndpoint: "https://issuer.example.com/claim?user=1",
+				IssuerToken:   "issuer-token",
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(newTx(), nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx *oidc4vc.Transaction, expectedState oidc4vc.TransactionState) error {
+						require.Equal(t, oidc4vc.TransactionStateCredentialIssued, tx.State)
+
+						return nil
+					})
+
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+					require.Equal(t, "Bearer issuer-token", r.Header.Get("Authorization"))
+					require.Equal(t, "DriversLicense", r.URL.Query().Get("credential_type"))
+					require.Equal(t, "1", r.URL.Query().Get("user"))
+
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(`{"name":"John Doe","class":"C"}`)),
+					}, nil
+				})
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+					DID:            "did:example:holder",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "profileID", resp.ProfileID)
+				require.Equal(t, oidc4vc.TxID("txID"), resp.TxID)
+				require.Equal(t, vcsverifiable.Jwt, resp.Format)
+
+				vc := resp.Credential
+				require.Equal(t, []string{"VerifiableCredential", "DriversLicense"}, vc.Types)
+				require.Equal(t, "did:example:issuer", vc.Issuer.ID)
+				require.NotEmpty(t, vc.ID)
+				require.NotNil(t, vc.Issued)
+
+				subject, ok := vc.Subject.([]verifiable.Subject)
+				require.True(t, ok)
+				require.Len(t, subject, 1)
+				require.Equal(t, "did:example:holder", subject[0].ID)
+				require.Equal(t, verifiable.CustomFields{"name": "John Doe", "class": "C"}, subject[0].CustomFields)
+			},
+		},
+		{
+			name: "Success without claim endpoint",
+			setup: func() {
+				tx := newTx()
+				tx.ClaimEndpoint = ""
+
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(tx, nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "VehicleRegistration",
+					Format:         vcsverifiable.Ldp,
+					DID:            "did:example:holder",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, vcsverifiable.Ldp, resp.Format)
+				require.Equal(t, []string{"https://www.w3.org/2018/credentials/v1"}, resp.Credential.Context)
+			},
+		},
+		{
+			name: "Validate only",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(newTx(), nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Times(0)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+					DID:            "did:example:holder",
+					ValidateOnly:   true,
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "profileID", resp.ProfileID)
+				require.Equal(t, vcsverifiable.Jwt, resp.Format)
+				require.Nil(t, resp.Credential)
+				require.Empty(t, resp.AcceptanceToken)
+			},
+		},
+		{
+			name: "Issuance deferred",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(newTx(), nil)
+
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusAccepted,
+					Body:       io.NopCloser(bytes.NewBuffer(nil)),
+				}, nil)
+
+				mockTransactionStore.EXPECT().AddDeferredCredential(gomock.Any(), oidc4vc.TxID("txID"), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, txID oidc4vc.TxID, deferred *oidc4vc.DeferredCredential) error {
+						require.NotEmpty(t, deferred.AcceptanceToken)
+						require.Equal(t, "DriversLicense", deferred.CredentialType)
+						require.Equal(t, vcsverifiable.Jwt, deferred.Format)
+						require.Equal(t, "did:example:holder", deferred.DID)
+						require.Empty(t, deferred.ClaimData)
+
+						return nil
+					})
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+					DID:            "did:example:holder",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.NoError(t, err)
+				require.Nil(t, resp.Credential)
+				require.NotEmpty(t, resp.AcceptanceToken)
+				require.Equal(t, vcsverifiable.Jwt, resp.Format)
+			},
+		},
+		{
+			name: "Fail to store deferred credential",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(newTx(), nil)
+
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusAccepted,
+					Body:       io.NopCloser(bytes.NewBuffer(nil)),
+				}, nil)
+
+				mockTransactionStore.EXPECT().AddDeferredCredential(gomock.Any(), oidc4vc.TxID("txID"), gomock.Any()).
+					Return(errors.New("add error"))
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorContains(t, err, "add error")
+			},
+		},
+		{
+			name: "Credential is not authorized",
+			setup: func() {
+				tx := newTx()
+				tx.AuthorizationDetails = []*oidc4vc.AuthorizationDetails{{CredentialType: "VehicleRegistration"}}
+
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(tx, nil)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrCredentialNotAuthorized)
+				require.Nil(t, resp)
+			},
+		},
+		{
+			name: "Credential type not supported",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(newTx(), nil)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "UniversityDegreeCredential",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrCredentialTypeNotSupported)
+			},
+		},
+		{
+			name: "Credential format not supported",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(newTx(), nil)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+					Format:         vcsverifiable.Ldp,
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrCredentialFormatNotSupported)
+			},
+		},
+		{
+			name: "Claim data not available",
+			setup: func() {
+				tx := newTx()
+				tx.IssuerToken = ""
+
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(tx, nil)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrClaimDataNotAvailable)
+			},
+		},
+		{
+			name: "Claim endpoint returns error",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(newTx(), nil)
+
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusUnauthorized,
+					Body:       io.NopCloser(bytes.NewBuffer(nil)),
+				}, nil)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorContains(t, err, "unexpected status code 401")
+			},
+		},
+		{
+			name: "Fail to update transaction",
+			setup: func() {
+				tx := newTx()
+				tx.ClaimEndpoint = ""
+
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(tx, nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "VehicleRegistration",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorContains(t, err, "update tx: update error")
+			},
+		},
+		{
+			name: "Transaction cancelled",
+			setup: func() {
+				tx := newTx()
+				tx.State = oidc4vc.TransactionStateCancelled
+
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(tx, nil)
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState:        "opState",
+					CredentialType: "DriversLicense",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrTransactionCancelled)
+			},
+		},
+		{
+			name: "Fail to find transaction by op state",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(
+					nil, errors.New("find tx error"))
+
+				req = &oidc4vc.PrepareCredentialRequest{
+					OpState: "opState",
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorContains(t, err, "find tx by op state")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			svc, err := oidc4vc.NewService(&oidc4vc.Config{
+				TransactionStore: mockTransactionStore,
+				HTTPClient:       mockHTTPClient,
+			})
+			require.NoError(t, err)
+
+			resp, err := svc.PrepareCredential(context.Background(), req)
+			tt.check(t, resp, err)
+		})
+	}
+}
+
+func TestService_StoreDeferredClaimData(t *testing.T) {
+	var (
+		mockTransactionStore = NewMockTransactionStore(gomock.NewController(t))
+		req                  *oidc4vc.StoreDeferredClaimDataRequest
+	)
+
+	newTx := func() *oidc4vc.Transaction {
+		return &oidc4vc.Transaction{
+			ID: "txID",
+			TransactionData: oidc4vc.TransactionData{
+				ProfileID: "profileID",
+				DeferredCredentials: []*oidc4vc.DeferredCredential{
+					{AcceptanceToken: "token1", CredentialType: "DriversLicense"},
+					{AcceptanceToken: "token2", CredentialType: "VehicleRegistration"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(newTx(), nil)
+				mockTransactionStore.EXPECT().StoreDeferredClaimData(gomock.Any(), oidc4vc.TxID("txID"),
+					"DriversLicense", json.RawMessage(`{"name":"John Doe"}`)).Return(nil)
+
+				req = &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID:      "profileID",
+					TxID:           "txID",
+					CredentialType: "driverslicense",
+					ClaimData:      []byte(`{"name":"John Doe"}`),
+				}
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Transaction of another profile",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(newTx(), nil)
+
+				req = &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID:      "otherProfileID",
+					TxID:           "txID",
+					CredentialType: "DriversLicense",
+					ClaimData:      []byte(`{"name":"John Doe"}`),
+				}
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDataNotFound)
+			},
+		},
+		{
+			name: "Invalid claim data",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(newTx(), nil)
+
+				req = &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID:      "profileID",
+					TxID:           "txID",
+					CredentialType: "DriversLicense",
+					ClaimData:      []byte(`null`),
+				}
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrInvalidClaimData)
+			},
+		},
+		{
+			name: "Credential is not deferred",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(newTx(), nil)
+
+				req = &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID:      "profileID",
+					TxID:           "txID",
+					CredentialType: "UniversityDegreeCredential",
+					ClaimData:      []byte(`{"name":"John Doe"}`),
+				}
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDeferredCredentialNotFound)
+			},
+		},
+		{
+			name: "Fail to get transaction",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(nil, oidc4vc.ErrDataNotFound)
+
+				req = &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID: "profileID",
+					TxID:      "txID",
+				}
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDataNotFound)
+			},
+		},
+		{
+			name: "Fail to update transaction",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(newTx(), nil)
+				mockTransactionStore.EXPECT().StoreDeferredClaimData(gomock.Any(), oidc4vc.TxID("txID"),
+					"DriversLicense", gomock.Any()).Return(errors.New("store error"))
+
+				req = &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID:      "profileID",
+					TxID:           "txID",
+					CredentialType: "DriversLicense",
+					ClaimData:      []byte(`{"name":"John Doe"}`),
+				}
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "store error")
+			},
+		},
+		{
+			name: "Deferred credential redeemed concurrently",
+			setup: func() {
+				mockTransactionStore.EXPECT().Get(gomock.Any(), oidc4vc.TxID("txID")).Return(newTx(), nil)
+				mockTransactionStore.EXPECT().StoreDeferredClaimData(gomock.Any(), oidc4vc.TxID("txID"),
+					"DriversLicense", gomock.Any()).Return(oidc4vc.ErrDataNotFound)
+
+				req = &oidc4vc.StoreDeferredClaimDataRequest{
+					ProfileID:      "profileID",
+					TxID:           "txID",
+					CredentialType: "DriversLicense",
+					ClaimData:      []byte(`{"name":"John Doe"}`),
+				}
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDeferredCredentialNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			svc, err := oidc4vc.NewService(&oidc4vc.Config{
+				TransactionStore: mockTransactionStore,
+			})
+			require.NoError(t, err)
+
+			tt.check(t, svc.StoreDeferredClaimData(context.Background(), req))
+		})
+	}
+}
+
+func TestService_PrepareDeferredCredential(t *testing.T) {
+	mockTransactionStore := NewMockTransactionStore(gomock.NewController(t))
+
+	newTx := func(claimData []byte) *oidc4vc.Transaction {
+		return &oidc4vc.Transaction{
+			ID: "txID",
+			TransactionData: oidc4vc.TransactionData{
+				ProfileID: "profileID",
+				CredentialTemplates: []*profileapi.CredentialTemplate{
+					{
+						ID:                "driversLicense",
+						Type:              "DriversLicense",
+						Issuer:            "did:example:issuer",
+						CredentialSubject: []byte(`{"class":"B"}`),
+						Format:            vcsverifiable.Jwt,
+					},
+				},
+				DeferredCredentials: []*oidc4vc.DeferredCredential{
+					{
+						AcceptanceToken: "token",
+						CredentialType:  "DriversLicense",
+						Format:          vcsverifiable.Jwt,
+						DID:             "did:example:holder",
+						ClaimData:       claimData,
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		check func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error)
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByAcceptanceToken(gomock.Any(), "token").Return(
+					newTx([]byte(`{"name":"John Doe"}`)), nil)
+				mockTransactionStore.EXPECT().RemoveDeferredCredential(gomock.Any(), oidc4vc.TxID("txID"), "token",
+					oidc4vc.TransactionStateCredentialIssued).Return(nil)
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "profileID", resp.ProfileID)
+				require.Equal(t, vcsverifiable.Jwt, resp.Format)
+				require.Empty(t, resp.AcceptanceToken)
+
+				subject, ok := resp.Credential.Subject.([]verifiable.Subject)
+				require.True(t, ok)
+				require.Equal(t, "did:example:holder", subject[0].ID)
+				require.Equal(t, verifiable.CustomFields{"name": "John Doe", "class": "B"}, subject[0].CustomFields)
+			},
+		},
+		{
+			name: "Acceptance token redeemed concurrently",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByAcceptanceToken(gomock.Any(), "token").Return(
+					newTx([]byte(`{"name":"John Doe"}`)), nil)
+				mockTransactionStore.EXPECT().RemoveDeferredCredential(gomock.Any(), oidc4vc.TxID("txID"), "token",
+					oidc4vc.TransactionStateCredentialIssued).Return(oidc4vc.ErrDataNotFound)
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDeferredCredentialNotFound)
+				require.Nil(t, resp)
+			},
+		},
+		{
+			name: "Issuance pending",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByAcceptanceToken(gomock.Any(), "token").Return(newTx(nil), nil)
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrCredentialIssuancePending)
+				require.Nil(t, resp)
+			},
+		},
+		{
+			name: "Transaction expired",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByAcceptanceToken(gomock.Any(), "token").Return(
+					nil, oidc4vc.ErrDataNotFound)
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrDeferredCredentialNotFound)
+			},
+		},
+		{
+			name: "Fail to find transaction",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByAcceptanceToken(gomock.Any(), "token").Return(
+					nil, errors.New("find error"))
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorContains(t, err, "find error")
+			},
+		},
+		{
+			name: "Fail to update transaction",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByAcceptanceToken(gomock.Any(), "token").Return(
+					newTx([]byte(`{"name":"John Doe"}`)), nil)
+				mockTransactionStore.EXPECT().RemoveDeferredCredential(gomock.Any(), oidc4vc.TxID("txID"), "token",
+					oidc4vc.TransactionStateCredentialIssued).Return(errors.New("remove error"))
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareCredentialResponse, err error) {
+				require.ErrorContains(t, err, "remove error")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			svc, err := oidc4vc.NewService(&oidc4vc.Config{
+				TransactionStore: mockTransactionStore,
+			})
+			require.NoError(t, err)
+
+			resp, err := svc.PrepareDeferredCredential(context.Background(), "token")
+			tt.check(t, resp, err)
+		})
+	}
+}
@@ -9,6 +9,7 @@ package oidc4vc
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // StoreAuthorizationCode stores authorization code from issuer provider.
@@ -16,14 +17,26 @@ func (s *Service) StoreAuthorizationCode(
 	ctx context.Context,
 	opState string,
 	code string,
-) (TxID, error) {
-	tx, err := s.store.FindByOpState(ctx, opState)
+) (_ TxID, err error) {
+	var tx *Transaction
 
+	defer func(startTime time.Time) {
+		s.observeOperation(operationStoreAuthorizationCode, tx, startTime, err)
+	}(time.Now())
+
+	tx, err = s.store.FindByOpState(ctx, opState)
 	if err != nil {
 		return "", fmt.Errorf("get transaction by opstate: %w", err)
 	}
 
+	if err = checkTransactionActive(tx); err != nil {
+		return "", err
+	}
+
+	prevState := tx.State
+
 	tx.IssuerAuthCode = code
+	tx.State = TransactionStateAuthorized
 
-	return tx.ID, s.store.Update(ctx, tx)
+	return tx.ID, s.store.Update(ctx, tx, prevState)
 }
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -46,10 +47,12 @@ func TestStoreAuthCode(t *testing.T) {
 
 		store.EXPECT().FindByOpState(gomock.Any(), opState).
 			Return(&tx, nil)
-		store.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
-			func(ctx context.Context, req *oidc4vc.Transaction) error {
+		store.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, req *oidc4vc.Transaction, expectedState oidc4vc.TransactionState) error {
 				assert.Equal(t, tx.ID, req.ID)
+				assert.Empty(t, expectedState)
 				assert.Equal(t, code, req.IssuerAuthCode)
+				assert.Equal(t, oidc4vc.TransactionStateAuthorized, req.State)
 
 				return nil
 			})
@@ -58,4 +61,38 @@ func TestStoreAuthCode(t *testing.T) {
 		assert.NoError(t, storeErr)
 		assert.Equal(t, tx.ID, resp)
 	})
+
+	t.Run("transaction state changed concurrently", func(t *testing.T) {
+		opState := uuid.NewString()
+
+		store.EXPECT().FindByOpState(gomock.Any(), opState).
+			Return(&oidc4vc.Transaction{
+				ID: oidc4vc.TxID(uuid.NewString()),
+				TransactionData: oidc4vc.TransactionData{
+					State: oidc4vc.TransactionStateOffered,
+				},
+			}, nil)
+		store.EXPECT().Update(gomock.Any(), gomock.Any(), oidc4vc.TransactionStateOffered).
+			Return(oidc4vc.ErrTransactionStateChanged)
+
+		_, storeErr := srv.StoreAuthorizationCode(context.TODO(), opState, "1234")
+		assert.ErrorIs(t, storeErr, oidc4vc.ErrTransactionStateChanged)
+	})
+
+	t.Run("offer expired", func(t *testing.T) {
+		opState := uuid.NewString()
+
+		store.EXPECT().FindByOpState(gomock.Any(), opState).
+			Return(&oidc4vc.Transaction{
+				ID: oidc4vc.TxID(uuid.NewString()),
+				TransactionData: oidc4vc.TransactionData{
+					State:     oidc4vc.TransactionStateOffered,
+					ExpiresAt: time.Now().Add(-time.Minute),
+				},
+			}, nil)
+
+		resp, storeErr := srv.StoreAuthorizationCode(context.TODO(), opState, "1234")
+		assert.Empty(t, resp)
+		assert.ErrorIs(t, storeErr, oidc4vc.ErrTransactionExpired)
+	})
 }
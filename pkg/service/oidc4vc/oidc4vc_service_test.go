@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -22,7 +23,7 @@ import (
 func TestService_PushAuthorizationDetails(t *testing.T) {
 	var (
 		mockTransactionStore = NewMockTransactionStore(gomock.NewController(t))
-		ad                   *oidc4vc.AuthorizationDetails
+		ad                   []*oidc4vc.AuthorizationDetails
 	)
 
 	tests := []struct {
@@ -36,34 +37,86 @@ func TestService_PushAuthorizationDetails(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential", Format: vcsverifiable.Ldp},
 						},
-						CredentialFormat: vcsverifiable.Ldp,
 					},
 				}, nil)
 
-				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
-				ad = &oidc4vc.AuthorizationDetails{
+				ad = []*oidc4vc.AuthorizationDetails{{
 					CredentialType: "universitydegreecredential",
 					Format:         vcsverifiable.Ldp,
+				}}
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Success with multiple credentials",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "DriversLicense", Format: vcsverifiable.Jwt},
+							{Type: "VehicleRegistration", Format: vcsverifiable.Ldp},
+						},
+					},
+				}, nil)
+
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, tx *oidc4vc.Transaction, expectedState oidc4vc.TransactionState) error {
+						require.Len(t, tx.AuthorizationDetails, 2)
+
+						return nil
+					})
+
+				ad = []*oidc4vc.AuthorizationDetails{
+					{
+						CredentialType: "DriversLicense",
+						Format:         vcsverifiable.Jwt,
+					},
+					{
+						CredentialType: "VehicleRegistration",
+					},
 				}
 			},
 			check: func(t *testing.T, err error) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "Transaction cancelled",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						State: oidc4vc.TransactionStateCancelled,
+					},
+				}, nil)
+
+				ad = []*oidc4vc.AuthorizationDetails{{
+					CredentialType: "UniversityDegreeCredential",
+					Format:         vcsverifiable.Ldp,
+				}}
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrTransactionCancelled)
+			},
+		},
 		{
 			name: "Fail to find transaction by op state",
 			setup: func() {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(
 					nil, errors.New("find tx error"))
 
-				ad = &oidc4vc.AuthorizationDetails{
+				ad = []*oidc4vc.AuthorizationDetails{{
 					CredentialType: "UniversityDegreeCredential",
 					Format:         vcsverifiable.Ldp,
-				}
+				}}
 			},
 			check: func(t *testing.T, err error) {
 				require.ErrorContains(t, err, "find tx by op state")
@@ -73,16 +126,14 @@ func TestService_PushAuthorizationDetails(t *testing.T) {
 			name: "Credential template not configured",
 			setup: func() {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
-					ID: "txID",
-					TransactionData: oidc4vc.TransactionData{
-						CredentialFormat: vcsverifiable.Ldp,
-					},
+					ID:              "txID",
+					TransactionData: oidc4vc.TransactionData{},
 				}, nil)
 
-				ad = &oidc4vc.AuthorizationDetails{
+				ad = []*oidc4vc.AuthorizationDetails{{
 					CredentialType: "UniversityDegreeCredential",
 					Format:         vcsverifiable.Ldp,
-				}
+				}}
 			},
 			check: func(t *testing.T, err error) {
 				require.ErrorIs(t, err, oidc4vc.ErrCredentialTemplateNotConfigured)
@@ -94,17 +145,16 @@ func TestService_PushAuthorizationDetails(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential", Format: vcsverifiable.Ldp},
 						},
-						CredentialFormat: vcsverifiable.Ldp,
 					},
 				}, nil)
 
-				ad = &oidc4vc.AuthorizationDetails{
+				ad = []*oidc4vc.AuthorizationDetails{{
 					CredentialType: "NotSupportedCredentialType",
 					Format:         vcsverifiable.Ldp,
-				}
+				}}
 			},
 			check: func(t *testing.T, err error) {
 				require.ErrorIs(t, err, oidc4vc.ErrCredentialTypeNotSupported)
@@ -116,17 +166,16 @@ func TestService_PushAuthorizationDetails(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential", Format: vcsverifiable.Ldp},
 						},
-						CredentialFormat: vcsverifiable.Ldp,
 					},
 				}, nil)
 
-				ad = &oidc4vc.AuthorizationDetails{
+				ad = []*oidc4vc.AuthorizationDetails{{
 					CredentialType: "UniversityDegreeCredential",
 					Format:         vcsverifiable.Jwt,
-				}
+				}}
 			},
 			check: func(t *testing.T, err error) {
 				require.ErrorIs(t, err, oidc4vc.ErrCredentialFormatNotSupported)
@@ -138,19 +187,18 @@ func TestService_PushAuthorizationDetails(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential", Format: vcsverifiable.Ldp},
 						},
-						CredentialFormat: vcsverifiable.Ldp,
 					},
 				}, nil)
 
-				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("update error"))
 
-				ad = &oidc4vc.AuthorizationDetails{
+				ad = []*oidc4vc.AuthorizationDetails{{
 					CredentialType: "UniversityDegreeCredential",
 					Format:         vcsverifiable.Ldp,
-				}
+				}}
 			},
 			check: func(t *testing.T, err error) {
 				require.ErrorContains(t, err, "update tx")
@@ -175,6 +223,7 @@ func TestService_PushAuthorizationDetails(t *testing.T) {
 func TestService_PrepareClaimDataAuthorizationRequest(t *testing.T) {
 	var (
 		mockTransactionStore = NewMockTransactionStore(gomock.NewController(t))
+		mockSecretProvider   = NewMockSecretProvider(gomock.NewController(t))
 		req                  *oidc4vc.PrepareClaimDataAuthorizationRequest
 	)
 
@@ -189,30 +238,61 @@ func TestService_PrepareClaimDataAuthorizationRequest(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential", Format: vcsverifiable.Ldp},
 						},
-						CredentialFormat: vcsverifiable.Ldp,
-						ResponseType:     "code",
-						Scope:            []string{"openid", "profile", "address"},
+						ClientID:           "client-id",
+						ClientSecretHandle: "client-secret-handle",
+						ResponseType:       "code",
+						Scope:              []string{"openid", "profile", "address"},
 					},
 				}, nil)
 
-				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockSecretProvider.EXPECT().GetSecret(gomock.Any(), "client-secret-handle").
+					Return("client-secret", nil)
 
 				req = &oidc4vc.PrepareClaimDataAuthorizationRequest{
 					OpState:      "opState",
 					ResponseType: "code",
 					Scope:        []string{"openid", "profile"},
-					AuthorizationDetails: &oidc4vc.AuthorizationDetails{
+					AuthorizationDetails: []*oidc4vc.AuthorizationDetails{{
 						CredentialType: "UniversityDegreeCredential",
 						Format:         vcsverifiable.Ldp,
-					},
+					}},
 				}
 			},
 			check: func(t *testing.T, resp *oidc4vc.PrepareClaimDataAuthorizationResponse, err error) {
 				require.NoError(t, err)
 				require.NotNil(t, resp)
+				require.Equal(t, "client-id", resp.AuthorizationParameters.ClientID)
+				require.Equal(t, "client-secret", resp.AuthorizationParameters.ClientSecret)
+			},
+		},
+		{
+			name: "Fail to resolve client secret",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						ClientSecretHandle: "client-secret-handle",
+						ResponseType:       "code",
+						Scope:              []string{"openid"},
+					},
+				}, nil)
+
+				mockSecretProvider.EXPECT().GetSecret(gomock.Any(), "client-secret-handle").
+					Return("", errors.New("secret not found"))
+
+				req = &oidc4vc.PrepareClaimDataAuthorizationRequest{
+					OpState:      "opState",
+					ResponseType: "code",
+					Scope:        []string{"openid"},
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareClaimDataAuthorizationResponse, err error) {
+				require.ErrorContains(t, err, "resolve client secret: secret not found")
+				require.Nil(t, resp)
 			},
 		},
 		{
@@ -221,8 +301,8 @@ func TestService_PrepareClaimDataAuthorizationRequest(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential"},
 						},
 						ResponseType: "code",
 						Scope:        []string{"openid"},
@@ -245,8 +325,8 @@ func TestService_PrepareClaimDataAuthorizationRequest(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential"},
 						},
 						ResponseType: "code",
 						Scope:        []string{"openid", "profile"},
@@ -263,6 +343,30 @@ func TestService_PrepareClaimDataAuthorizationRequest(t *testing.T) {
 				require.ErrorIs(t, err, oidc4vc.ErrInvalidScope)
 			},
 		},
+		{
+			name: "Offer expired",
+			setup: func() {
+				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
+					ID: "txID",
+					TransactionData: oidc4vc.TransactionData{
+						ResponseType: "code",
+						Scope:        []string{"openid"},
+						State:        oidc4vc.TransactionStateOffered,
+						ExpiresAt:    time.Now().Add(-time.Minute),
+					},
+				}, nil)
+
+				req = &oidc4vc.PrepareClaimDataAuthorizationRequest{
+					OpState:      "opState",
+					ResponseType: "code",
+					Scope:        []string{"openid"},
+				}
+			},
+			check: func(t *testing.T, resp *oidc4vc.PrepareClaimDataAuthorizationResponse, err error) {
+				require.ErrorIs(t, err, oidc4vc.ErrTransactionExpired)
+				require.Nil(t, resp)
+			},
+		},
 		{
 			name: "Fail to find transaction by op state",
 			setup: func() {
@@ -284,25 +388,24 @@ func TestService_PrepareClaimDataAuthorizationRequest(t *testing.T) {
 				mockTransactionStore.EXPECT().FindByOpState(gomock.Any(), "opState").Return(&oidc4vc.Transaction{
 					ID: "txID",
 					TransactionData: oidc4vc.TransactionData{
-						CredentialTemplate: &profileapi.CredentialTemplate{
-							Type: "UniversityDegreeCredential",
+						CredentialTemplates: []*profileapi.CredentialTemplate{
+							{Type: "UniversityDegreeCredential", Format: vcsverifiable.Ldp},
 						},
-						CredentialFormat: vcsverifiable.Ldp,
-						ResponseType:     "code",
-						Scope:            []string{"openid"},
+						ResponseType: "code",
+						Scope:        []string{"openid"},
 					},
 				}, nil)
 
-				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("update error"))
+				mockTransactionStore.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("update error"))
 
 				req = &oidc4vc.PrepareClaimDataAuthorizationRequest{
 					OpState:      "opState",
 					ResponseType: "code",
 					Scope:        []string{"openid"},
-					AuthorizationDetails: &oidc4vc.AuthorizationDetails{
+					AuthorizationDetails: []*oidc4vc.AuthorizationDetails{{
 						CredentialType: "UniversityDegreeCredential",
 						Format:         vcsverifiable.Ldp,
-					},
+					}},
 				}
 			},
 			check: func(t *testing.T, resp *oidc4vc.PrepareClaimDataAuthorizationResponse, err error) {
@@ -317,6 +420,7 @@ func TestService_PrepareClaimDataAuthorizationRequest(t *testing.T) {
 
 			svc, err := oidc4vc.NewService(&oidc4vc.Config{
 				TransactionStore: mockTransactionStore,
+				SecretProvider:   mockSecretProvider,
 			})
 			require.NoError(t, err)
 
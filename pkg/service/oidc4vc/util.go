@@ -13,3 +13,10 @@ func WithDocumentTTL(ttl time.Duration) func(insertOptions *InsertOptions) {
 		insertOptions.TTL = ttl
 	}
 }
+
+// WithTxID sets ID of the transaction to create.
+func WithTxID(txID TxID) func(insertOptions *InsertOptions) {
+	return func(insertOptions *InsertOptions) {
+		insertOptions.TxID = txID
+	}
+}
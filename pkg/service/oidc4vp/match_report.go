@@ -0,0 +1,320 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oidc4vp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	submissionProperty    = "presentation_submission"
+	descriptorMapProperty = "descriptor_map"
+)
+
+// MatchFailureReason describes why input descriptor is not satisfied by presentation submission.
+type MatchFailureReason string
+
+const (
+	// MatchFailureNoSubmission means presentation submission has no credential for input descriptor.
+	MatchFailureNoSubmission MatchFailureReason = "no_submission"
+	// MatchFailureInvalidSubmission means credential can't be selected from presentation by descriptor map.
+	MatchFailureInvalidSubmission MatchFailureReason = "invalid_submission"
+	// MatchFailureWrongFormat means credential format is not accepted by input descriptor.
+	MatchFailureWrongFormat MatchFailureReason = "wrong_format"
+	// MatchFailureSubjectIsNotIssuer means input descriptor requires self-issued credential.
+	MatchFailureSubjectIsNotIssuer MatchFailureReason = "subject_is_not_issuer"
+	// MatchFailureMissingField means none of constraint field paths is present in credential.
+	MatchFailureMissingField MatchFailureReason = "missing_field"
+	// MatchFailureFailedFilter means credential field doesn't pass constraint field filter.
+	MatchFailureFailedFilter MatchFailureReason = "failed_filter"
+)
+
+// MatchReport is a result of presentation submission match against presentation definition. Matched is the
+// outcome of the whole submission check, input descriptor results are diagnostic details.
+type MatchReport struct {
+	Matched          bool                    `json:"matched"`
+	InputDescriptors []*InputDescriptorMatch `json:"inputDescriptors"`
+	// Error is the reason the submission is rejected.
+	Error string `json:"error,omitempty"`
+}
+
+// InputDescriptorMatch is a match result of the single input descriptor.
+type InputDescriptorMatch struct {
+	ID            string          `json:"id"`
+	Matched       bool            `json:"matched"`
+	CredentialIDs []string        `json:"credentialIDs,omitempty"`
+	Failures      []*MatchFailure `json:"failures,omitempty"`
+}
+
+// MatchFailure describes failed input descriptor constraint.
+type MatchFailure struct {
+	Reason  MatchFailureReason `json:"reason"`
+	Path    string             `json:"path,omitempty"`
+	Message string             `json:"message,omitempty"`
+}
+
+// Summary returns human-readable description of failed input descriptors. The rejection reason is returned if
+// all input descriptors are satisfied.
+func (r *MatchReport) Summary() string {
+	var failed []string
+
+	for _, descriptor := range r.InputDescriptors {
+		if descriptor.Matched {
+			continue
+		}
+
+		reasons := make([]string, 0, len(descriptor.Failures))
+
+		for _, failure := range descriptor.Failures {
+			reason := string(failure.Reason)
+			if failure.Path != "" {
+				reason += " " + failure.Path
+			}
+
+			reasons = append(reasons, reason)
+		}
+
+		failed = append(failed, fmt.Sprintf("input descriptor %s: %s", descriptor.ID, strings.Join(reasons, ", ")))
+	}
+
+	if len(failed) == 0 {
+		return r.Error
+	}
+
+	return strings.Join(failed, "; ")
+}
+
+// buildMatchReport matches every input descriptor of presentation definition separately, so the report
+// contains all failed descriptors rather than the first one. Matched of the report is set by the caller.
+func (s *Service) buildMatchReport(pd *presexch.PresentationDefinition,
+	vp *verifiable.Presentation) *MatchReport {
+	report := &MatchReport{}
+
+	mappings, mappingsErr := descriptorMappings(vp)
+
+	for _, descriptor := range pd.InputDescriptors {
+		result := &InputDescriptorMatch{ID: descriptor.ID}
+
+		switch {
+		case mappingsErr != nil:
+			result.Failures = append(result.Failures,
+				&MatchFailure{Reason: MatchFailureNoSubmission, Message: mappingsErr.Error()})
+		case len(mappings[descriptor.ID]) == 0:
+			result.Failures = append(result.Failures, &MatchFailure{Reason: MatchFailureNoSubmission})
+		default:
+			for _, mapping := range mappings[descriptor.ID] {
+				s.matchDescriptorMapping(pd, descriptor, vp, mapping, result)
+			}
+		}
+
+		result.Matched = len(result.Failures) == 0
+		report.InputDescriptors = append(report.InputDescriptors, result)
+	}
+
+	return report
+}
+
+func (s *Service) matchDescriptorMapping(pd *presexch.PresentationDefinition, descriptor *presexch.InputDescriptor,
+	vp *verifiable.Presentation, mapping interface{}, result *InputDescriptorMatch) {
+	singleDescriptorPD := &presexch.PresentationDefinition{
+		ID:               pd.ID,
+		InputDescriptors: []*presexch.InputDescriptor{descriptor},
+	}
+
+	singleMappingVP := *vp
+	singleMappingVP.CustomFields = verifiable.CustomFields{}
+
+	for k, v := range vp.CustomFields {
+		singleMappingVP.CustomFields[k] = v
+	}
+
+	singleMappingVP.CustomFields[submissionProperty] = map[string]interface{}{
+		descriptorMapProperty: []interface{}{mapping},
+	}
+
+	credentials, err := singleDescriptorPD.Match(&singleMappingVP, s.documentLoader,
+		presexch.WithCredentialOptions(
+			verifiable.WithJSONLDDocumentLoader(s.documentLoader),
+			verifiable.WithPublicKeyFetcher(s.publicKeyFetcher),
+		), presexch.WithDisableSchemaValidation())
+	if err != nil {
+		result.Failures = append(result.Failures,
+			&MatchFailure{Reason: MatchFailureInvalidSubmission, Message: err.Error()})
+
+		return
+	}
+
+	cred := credentials[descriptor.ID]
+
+	result.CredentialIDs = append(result.CredentialIDs, cred.ID)
+	result.Failures = append(result.Failures, checkFormat(pd, descriptor, cred)...)
+	result.Failures = append(result.Failures, checkConstraints(descriptor.Constraints, cred)...)
+}
+
+func descriptorMappings(vp *verifiable.Presentation) (map[string][]interface{}, error) {
+	submission, ok := vp.CustomFields[submissionProperty].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing '%s' on verifiable presentation", submissionProperty)
+	}
+
+	descriptorMap, ok := submission[descriptorMapProperty].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("missing '%s' on verifiable presentation", descriptorMapProperty)
+	}
+
+	mappings := map[string][]interface{}{}
+
+	for _, mapping := range descriptorMap {
+		mappingObj, isObj := mapping.(map[string]interface{})
+		if !isObj {
+			continue
+		}
+
+		id, _ := mappingObj["id"].(string) //nolint:errcheck
+
+		mappings[id] = append(mappings[id], mapping)
+	}
+
+	return mappings, nil
+}
+
+func checkFormat(pd *presexch.PresentationDefinition, descriptor *presexch.InputDescriptor,
+	cred *verifiable.Credential) []*MatchFailure {
+	format := descriptor.Format
+	if format == nil {
+		format = pd.Format
+	}
+
+	if format == nil {
+		return nil
+	}
+
+	jwtAccepted := format.Jwt != nil || format.JwtVC != nil || format.JwtVP != nil
+	ldpAccepted := format.Ldp != nil || format.LdpVC != nil || format.LdpVP != nil
+
+	if !jwtAccepted && !ldpAccepted {
+		return nil
+	}
+
+	if cred.JWT != "" && !jwtAccepted {
+		return []*MatchFailure{{Reason: MatchFailureWrongFormat, Message: "jwt credential is not accepted"}}
+	}
+
+	if cred.JWT == "" && !ldpAccepted {
+		return []*MatchFailure{{Reason: MatchFailureWrongFormat, Message: "ldp credential is not accepted"}}
+	}
+
+	return nil
+}
+
+func checkConstraints(constraints *presexch.Constraints, cred *verifiable.Credential) []*MatchFailure {
+	if constraints == nil {
+		return nil
+	}
+
+	var failures []*MatchFailure
+
+	if constraints.SubjectIsIssuer != nil && *constraints.SubjectIsIssuer == presexch.Required &&
+		!subjectIsIssuer(cred) {
+		failures = append(failures, &MatchFailure{Reason: MatchFailureSubjectIsNotIssuer})
+	}
+
+	if len(constraints.Fields) == 0 {
+		return failures
+	}
+
+	credentialMap, err := credentialToMap(cred)
+	if err != nil {
+		return append(failures, &MatchFailure{Reason: MatchFailureInvalidSubmission, Message: err.Error()})
+	}
+
+	for _, field := range constraints.Fields {
+		if failure := checkField(field, credentialMap); failure != nil {
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
+
+func checkField(field *presexch.Field, credential map[string]interface{}) *MatchFailure {
+	failure := &MatchFailure{Reason: MatchFailureMissingField, Path: strings.Join(field.Path, ",")}
+
+	for _, path := range field.Path {
+		value, err := jsonpath.Get(path, credential)
+		if err != nil {
+			continue
+		}
+
+		if field.Filter == nil {
+			return nil
+		}
+
+		err = validateFilter(field.Filter, value)
+		if err == nil {
+			return nil
+		}
+
+		failure = &MatchFailure{Reason: MatchFailureFailedFilter, Path: path, Message: err.Error()}
+	}
+
+	return failure
+}
+
+func validateFilter(filter *presexch.Filter, value interface{}) error {
+	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(*filter), gojsonschema.NewGoLoader(value))
+	if err != nil {
+		return err
+	}
+
+	if !result.Valid() {
+		errs := make([]string, 0, len(result.Errors()))
+
+		for _, resultErr := range result.Errors() {
+			errs = append(errs, resultErr.String())
+		}
+
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func credentialToMap(cred *verifiable.Credential) (map[string]interface{}, error) {
+	// if JWT is set, credential is marshalled to JWT string.
+	credCopy := *cred
+	credCopy.JWT = ""
+
+	credBytes, err := json.Marshal(&credCopy)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	var credMap map[string]interface{}
+
+	if err = json.Unmarshal(credBytes, &credMap); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	return credMap, nil
+}
+
+func subjectIsIssuer(cred *verifiable.Credential) bool {
+	subjectID, err := verifiable.SubjectID(cred.Subject)
+	if err != nil {
+		return false
+	}
+
+	return subjectID != "" && subjectID == cred.Issuer.ID
+}
@@ -4,18 +4,25 @@ Copyright SecureKey Technologies Inc. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-//go:generate mockgen -destination oidc4vp_service_mocks_test.go -self_package mocks -package oidc4vp_test -source=oidc4vp_service.go -mock_names transactionManager=MockTransactionManager,events=MockEvents,kmsRegistry=MockKMSRegistry,requestObjectPublicStore=MockRequestObjectPublicStore,profileService=MockProfileService,presentationVerifier=MockPresentationVerifier
+//go:generate mockgen -destination oidc4vp_service_mocks_test.go -self_package mocks -package oidc4vp_test -source=oidc4vp_service.go -mock_names transactionManager=MockTransactionManager,events=MockEvents,kmsRegistry=MockKMSRegistry,requestObjectPublicStore=MockRequestObjectPublicStore,profileService=MockProfileService,presentationVerifier=MockPresentationVerifier,metricsProvider=MockMetricsProvider
 
 package oidc4vp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -27,6 +34,7 @@ import (
 	"github.com/trustbloc/vcs/pkg/event/spi"
 	vcskms "github.com/trustbloc/vcs/pkg/kms"
 	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 	"github.com/trustbloc/vcs/pkg/service/verifypresentation"
 )
@@ -35,20 +43,58 @@ var logger = log.New("oidc4vp-service")
 
 var ErrDataNotFound = errors.New("data not found")
 
+// ErrInvalidRequestObjectEncryptionKey is returned if request object can't be encrypted to the wallet key.
+var ErrInvalidRequestObjectEncryptionKey = errors.New("invalid request object encryption key")
+
+const (
+	responseEncryptionAlg = "ECDH-ES+A256KW"
+	responseEncryptionEnc = "A256GCM"
+
+	requestObjectContentType = "JWT"
+)
+
 type InteractionInfo struct {
 	AuthorizationRequest string
 	TxID                 TxID
 }
 
+// ResponseMode defines how the wallet sends authorization response to the verifier.
+type ResponseMode string
+
+const (
+	ResponseModePost          ResponseMode = "post"
+	ResponseModeDirectPost    ResponseMode = "direct_post"
+	ResponseModeDirectPostJWT ResponseMode = "direct_post.jwt"
+)
+
+// InteractionOptions contains optional parameters of oidc4vp interaction.
+type InteractionOptions struct {
+	// ResponseMode requested from the wallet. Defaults to ResponseModePost.
+	ResponseMode ResponseMode
+	// RedirectURI enables same-device flow. After authorization response is accepted, the wallet is redirected
+	// to this uri with response code that relying party exchanges for claims.
+	RedirectURI string
+	// RequestObjectByValue passes request object in authorization request instead of publishing it
+	// and passing request_uri.
+	RequestObjectByValue bool
+	// RequestObjectEncryptionKey is a wallet key signed request object is encrypted to. Request object
+	// is not encrypted if the key is not set.
+	RequestObjectEncryptionKey *jwk.JWK
+}
+
 type eventService interface {
 	Publish(topic string, messages ...*spi.Event) error
 }
 
 type transactionManager interface {
-	CreateTx(pd *presexch.PresentationDefinition, profileID string) (*Transaction, string, error)
-	StoreReceivedClaims(txID TxID, claims *ReceivedClaims) error
+	CreateTx(pd *presexch.PresentationDefinition, profileID string, params *TxParams) (*Transaction, string, error)
+	StoreReceivedClaims(txID TxID, claims *ReceivedClaims, claimsTTL time.Duration) error
+	StoreMatchReport(txID TxID, report *MatchReport) error
+	StoreAuthorizationRequest(txID TxID, authorizationRequest string) error
 	GetByOneTimeToken(nonce string) (*Transaction, bool, error)
 	Get(txID TxID) (*Transaction, error)
+	Delete(txID TxID) error
+	GetAndDelete(txID TxID) (*Transaction, error)
 }
 
 type requestObjectPublicStore interface {
@@ -65,6 +111,7 @@ type profileService interface {
 
 type presentationVerifier interface {
 	VerifyPresentation(
+		ctx context.Context,
 		presentation *verifiable.Presentation,
 		opts *verifypresentation.Options,
 		profile *profileapi.Verifier) ([]verifypresentation.PresentationVerificationCheckResult, error)
@@ -88,7 +135,8 @@ type RequestObject struct {
 	Scope        string                    `json:"scope"`
 	Nonce        string                    `json:"nonce"`
 	ClientID     string                    `json:"client_id"`
-	RedirectURI  string                    `json:"redirect_uri"`
+	RedirectURI  string                    `json:"redirect_uri,omitempty"`
+	ResponseURI  string                    `json:"response_uri,omitempty"`
 	State        string                    `json:"state"`
 	Exp          int64                     `json:"exp"`
 	Registration RequestObjectRegistration `json:"registration"`
@@ -124,8 +172,15 @@ type ProcessedVPToken struct {
 
 type metricsProvider interface {
 	VerifyOIDCVerifiablePresentationTime(value time.Duration)
+	OIDC4VPOperationTime(profileID, operation string, success bool, value time.Duration)
 }
 
+// Transaction operations reported in metrics.
+const (
+	operationInitiateInteraction = "initiate_interaction"
+	operationVerifyPresentation  = "verify_presentation"
+)
+
 type Service struct {
 	eventSvc                 eventService
 	transactionManager       transactionManager
@@ -143,15 +198,26 @@ type Service struct {
 }
 
 type RequestObjectRegistration struct {
-	ClientName                  string           `json:"client_name"`
-	SubjectSyntaxTypesSupported []string         `json:"subject_syntax_types_supported"`
-	VPFormats                   *presexch.Format `json:"vp_formats"`
-	ClientPurpose               string           `json:"client_purpose"`
+	ClientName                        string             `json:"client_name"`
+	SubjectSyntaxTypesSupported       []string           `json:"subject_syntax_types_supported"`
+	VPFormats                         *presexch.Format   `json:"vp_formats"`
+	ClientPurpose                     string             `json:"client_purpose"`
+	AuthorizationEncryptedResponseAlg string             `json:"authorization_encrypted_response_alg,omitempty"`
+	AuthorizationEncryptedResponseEnc string             `json:"authorization_encrypted_response_enc,omitempty"`
+	JWKS                              *RequestObjectJWKS `json:"jwks,omitempty"`
+}
+
+// RequestObjectJWKS contains verifier keys the wallet uses to encrypt authorization response.
+type RequestObjectJWKS struct {
+	Keys []*jwk.JWK `json:"keys"`
 }
 
 type eventPayload struct {
-	TxID    string `json:"txID"`
-	WebHook string `json:"webHook,omitempty"`
+	TxID        string       `json:"txID"`
+	ProfileID   string       `json:"profileID,omitempty"`
+	WebHook     string       `json:"webHook,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	MatchReport *MatchReport `json:"matchReport,omitempty"`
 }
 
 type jwtVCClaims struct {
@@ -182,41 +248,97 @@ func NewService(cfg *Config) *Service {
 
 func (s *Service) createEvent(tx *Transaction, profile *profileapi.Verifier,
 	eventType spi.EventType) (*spi.Event, error) {
-	payload, err := json.Marshal(eventPayload{
-		TxID:    string(tx.ID),
-		WebHook: profile.WebHook,
+	return newEvent(profile, eventType, &eventPayload{
+		TxID:      string(tx.ID),
+		ProfileID: profile.ID,
+		WebHook:   profile.WebHook,
 	})
+}
+
+func newEvent(profile *profileapi.Verifier, eventType spi.EventType, ep *eventPayload) (*spi.Event, error) {
+	payload, err := json.Marshal(ep)
 	if err != nil {
 		return nil, err
 	}
 	return spi.NewEvent(uuid.NewString(), profile.URL, eventType, payload), nil
 }
 
-func (s *Service) sendEvent(tx *Transaction, profile *profileapi.Verifier, eventType spi.EventType) error {
+func (s *Service) sendEvent(
+	ctx context.Context,
+	tx *Transaction,
+	profile *profileapi.Verifier,
+	eventType spi.EventType,
+) error {
 	event, err := s.createEvent(tx, profile, eventType)
 	if err != nil {
 		return err
 	}
 
+	tracing.InjectEvent(ctx, event)
+
 	return s.eventSvc.Publish(spi.VerifierEventTopic, event)
 }
 
-func (s *Service) InitiateOidcInteraction(presentationDefinition *presexch.PresentationDefinition, purpose string,
-	profile *profileapi.Verifier) (*InteractionInfo, error) {
-	logger.Debug("InitiateOidcInteraction begin")
+func (s *Service) sendFailedEvent(
+	ctx context.Context,
+	tx *Transaction,
+	profile *profileapi.Verifier,
+	report *MatchReport,
+	interactionErr error,
+) error {
+	event, err := newEvent(profile, spi.VerifierOIDCInteractionFailed, &eventPayload{
+		TxID:        string(tx.ID),
+		ProfileID:   profile.ID,
+		WebHook:     profile.WebHook,
+		Error:       interactionErr.Error(),
+		MatchReport: report,
+	})
+	if err != nil {
+		return err
+	}
+
+	tracing.InjectEvent(ctx, event)
+
+	return s.eventSvc.Publish(spi.VerifierEventTopic, event)
+}
+
+func (s *Service) InitiateOidcInteraction(ctx context.Context,
+	presentationDefinition *presexch.PresentationDefinition, purpose string, profile *profileapi.Verifier,
+	opts *InteractionOptions) (_ *InteractionInfo, err error) {
+	logger.WithContext(ctx).Debug("InitiateOidcInteraction begin")
+
+	defer func(startTime time.Time) {
+		s.metrics.OIDC4VPOperationTime(profile.ID, operationInitiateInteraction, err == nil, time.Since(startTime))
+	}(time.Now())
 
 	if profile.SigningDID == nil {
 		return nil, errors.New("profile signing did can't be nil")
 	}
 
-	tx, nonce, err := s.transactionManager.CreateTx(presentationDefinition, profile.ID)
+	txParams, err := getTxParams(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// encryption key is checked before the transaction is created, so that invalid key does not leave
+	// orphaned transaction
+	var encryptionKey *cryptoapi.PublicKey
+
+	if opts != nil && opts.RequestObjectEncryptionKey != nil {
+		encryptionKey, err = jwksupport.PublicKeyFromJWK(opts.RequestObjectEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("initiate oidc interaction: %w: %v", ErrInvalidRequestObjectEncryptionKey, err)
+		}
+	}
+
+	tx, nonce, err := s.transactionManager.CreateTx(presentationDefinition, profile.ID, txParams)
 	if err != nil {
 		return nil, fmt.Errorf("fail to create oidc tx: %w", err)
 	}
 
-	logger.Debug("InitiateOidcInteraction tx created", log.WithTxID(string(tx.ID)))
+	logger.WithContext(ctx).Debug("InitiateOidcInteraction tx created", log.WithTxID(string(tx.ID)))
 
-	if errSendEvent := s.sendEvent(tx, profile, spi.VerifierOIDCInteractionInitiated); errSendEvent != nil {
+	if errSendEvent := s.sendEvent(ctx, tx, profile, spi.VerifierOIDCInteractionInitiated); errSendEvent != nil {
 		return nil, errSendEvent
 	}
 
@@ -225,37 +347,127 @@ func (s *Service) InitiateOidcInteraction(presentationDefinition *presexch.Prese
 		return nil, err
 	}
 
-	logger.Info("InitiateOidcInteraction request object created", log.WithJSON(token))
+	logger.WithContext(ctx).Info("InitiateOidcInteraction request object created", log.WithJSON(token))
 
-	accessRequestObjectEvent, err := s.createEvent(tx, profile, spi.VerifierOIDCInteractionQRScanned)
-	if err != nil {
-		return nil, err
+	if encryptionKey != nil {
+		token, err = encryptRequestObject(token, encryptionKey)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.WithContext(ctx).Debug("InitiateOidcInteraction request object encrypted")
 	}
 
-	requestURI, err := s.requestObjectPublicStore.Publish(token, accessRequestObjectEvent)
+	authorizationRequest, err := s.buildAuthorizationRequest(ctx, tx, profile, token, opts)
 	if err != nil {
-		return nil, fmt.Errorf("fail publish request object: %w", err)
+		return nil, err
 	}
 
-	logger.Info("InitiateOidcInteraction request object published", log.WithURL(token))
+	// authorization request is stored to render it as QR code later
+	if err = s.transactionManager.StoreAuthorizationRequest(tx.ID, authorizationRequest); err != nil {
+		return nil, fmt.Errorf("fail to store authorization request: %w", err)
+	}
 
-	logger.Debug("InitiateOidcInteraction succeed")
+	logger.WithContext(ctx).Debug("InitiateOidcInteraction succeed")
 
 	return &InteractionInfo{
-		AuthorizationRequest: "openid-vc://?request_uri=" + requestURI,
+		AuthorizationRequest: authorizationRequest,
 		TxID:                 tx.ID,
 	}, nil
 }
 
-func (s *Service) VerifyOIDCVerifiablePresentation(txID TxID, token *ProcessedVPToken) error {
-	logger.Debug("VerifyOIDCVerifiablePresentation begin")
+func (s *Service) buildAuthorizationRequest(ctx context.Context, tx *Transaction, profile *profileapi.Verifier,
+	token string, opts *InteractionOptions) (string, error) {
+	// Request object passed by value is never fetched by the wallet, so QR scanned event is not sent.
+	if opts != nil && opts.RequestObjectByValue {
+		return "openid-vc://?request=" + url.QueryEscape(token), nil
+	}
+
+	accessRequestObjectEvent, err := s.createEvent(tx, profile, spi.VerifierOIDCInteractionQRScanned)
+	if err != nil {
+		return "", err
+	}
+
+	// Event is published when the wallet fetches request object, and is linked to the interaction initiation.
+	tracing.InjectEvent(ctx, accessRequestObjectEvent)
+
+	requestURI, err := s.requestObjectPublicStore.Publish(token, accessRequestObjectEvent)
+	if err != nil {
+		return "", fmt.Errorf("fail publish request object: %w", err)
+	}
+
+	logger.WithContext(ctx).Info("InitiateOidcInteraction request object published", log.WithURL(token))
+
+	return "openid-vc://?request_uri=" + requestURI, nil
+}
+
+func getTxParams(opts *InteractionOptions) (*TxParams, error) {
+	params := &TxParams{ResponseMode: ResponseModePost}
+
+	if opts == nil {
+		return params, nil
+	}
+
+	switch opts.ResponseMode {
+	case "":
+	case ResponseModePost, ResponseModeDirectPost, ResponseModeDirectPostJWT:
+		params.ResponseMode = opts.ResponseMode
+	default:
+		return nil, fmt.Errorf("unsupported response mode: %s", opts.ResponseMode)
+	}
+
+	if opts.RedirectURI != "" {
+		redirectURI, err := url.Parse(opts.RedirectURI)
+		if err != nil || !redirectURI.IsAbs() {
+			return nil, fmt.Errorf("invalid redirect uri: %s", opts.RedirectURI)
+		}
+
+		params.RedirectURI = opts.RedirectURI
+	}
+
+	return params, nil
+}
+
+// ResponseRedirectURI returns uri the wallet should redirect user to after authorization response is accepted.
+// Empty string is returned if transaction was not initiated for same-device flow.
+func ResponseRedirectURI(tx *Transaction) (string, error) {
+	if tx.RedirectURI == "" {
+		return "", nil
+	}
+
+	redirectURI, err := url.Parse(tx.RedirectURI)
+	if err != nil {
+		return "", fmt.Errorf("parse redirect uri: %w", err)
+	}
+
+	query := redirectURI.Query()
+	query.Set("response_code", tx.ResponseCode)
+	redirectURI.RawQuery = query.Encode()
+
+	return redirectURI.String(), nil
+}
+
+func (s *Service) VerifyOIDCVerifiablePresentation(ctx context.Context, txID TxID,
+	token *ProcessedVPToken) (err error) {
+	logger.WithContext(ctx).Debug("VerifyOIDCVerifiablePresentation begin")
 	startTime := time.Now()
 
+	var tx *Transaction
+
 	defer func() {
-		logger.Debug("VerifyOIDCVerifiablePresentation", log.WithDuration(time.Since(startTime)))
+		var profileID string
+		if tx != nil {
+			profileID = tx.ProfileID
+		}
+
+		s.metrics.OIDC4VPOperationTime(profileID, operationVerifyPresentation, err == nil, time.Since(startTime))
+
+		logger.WithContext(ctx).Debug("VerifyOIDCVerifiablePresentation", log.WithDuration(time.Since(startTime)))
 	}()
 
-	tx, validNonce, err := s.transactionManager.GetByOneTimeToken(token.Nonce)
+	var validNonce bool
+
+	tx, validNonce, err = s.transactionManager.GetByOneTimeToken(token.Nonce)
 	if err != nil {
 		return fmt.Errorf("get tx by nonce failed: %w", err)
 	}
@@ -264,24 +476,24 @@ func (s *Service) VerifyOIDCVerifiablePresentation(txID TxID, token *ProcessedVP
 		return fmt.Errorf("invalid nonce")
 	}
 
-	logger.Debug("VerifyOIDCVerifiablePresentation nonce verified")
+	logger.WithContext(ctx).Debug("VerifyOIDCVerifiablePresentation nonce verified")
 
 	profile, err := s.profileService.GetProfile(tx.ProfileID)
 	if err != nil {
 		return fmt.Errorf("inconsistent transaction state %w", err)
 	}
 
-	logger.Debug("VerifyOIDCVerifiablePresentation profile fetched", log.WithProfileID(profile.ID))
+	logger.WithContext(ctx).Debug("VerifyOIDCVerifiablePresentation profile fetched", log.WithProfileID(profile.ID))
 
 	vpBytes, err := token.Presentation.MarshalJSON()
 	if err != nil {
 		return err
 	}
 
-	logger.Debug(" VerifyOIDCVerifiablePresentation vp string", log.WithJSON(string(vpBytes)))
+	logger.WithContext(ctx).Debug(" VerifyOIDCVerifiablePresentation vp string", log.WithJSON(string(vpBytes)))
 
 	// TODO: should domain and challenge be verified?
-	vr, err := s.presentationVerifier.VerifyPresentation(token.Presentation, nil, profile)
+	vr, err := s.presentationVerifier.VerifyPresentation(ctx, token.Presentation, nil, profile)
 	if err != nil {
 		return fmt.Errorf("presentation verification failed: %w", err)
 	}
@@ -290,14 +502,14 @@ func (s *Service) VerifyOIDCVerifiablePresentation(txID TxID, token *ProcessedVP
 		return fmt.Errorf("presentation verification checks failed: %s", vr[0].Error)
 	}
 
-	logger.Debug(" VerifyOIDCVerifiablePresentation verified", log.WithJSON(string(vpBytes)))
+	logger.WithContext(ctx).Debug(" VerifyOIDCVerifiablePresentation verified", log.WithJSON(string(vpBytes)))
 
-	err = s.extractClaimData(tx, token, profile)
+	err = s.extractClaimData(ctx, tx, token, profile)
 	if err != nil {
 		return err
 	}
 
-	logger.Debug("VerifyOIDCVerifiablePresentation succeed")
+	logger.WithContext(ctx).Debug("VerifyOIDCVerifiablePresentation succeed")
 	return nil
 }
 
@@ -305,9 +517,29 @@ func (s *Service) GetTx(id TxID) (*Transaction, error) {
 	return s.transactionManager.Get(id)
 }
 
+// DeleteClaims purges transaction together with claims received from the wallet.
+func (s *Service) DeleteClaims(id TxID) error {
+	return s.transactionManager.Delete(id)
+}
+
+// RetrieveAndDeleteClaims purges transaction and returns claims of the purged transaction. Claims are released
+// only once: ErrDataNotFound is returned if the transaction is already purged.
+func (s *Service) RetrieveAndDeleteClaims(id TxID) (map[string]CredentialMetadata, error) {
+	tx, err := s.transactionManager.GetAndDelete(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.RetrieveClaims(tx), nil
+}
+
 func (s *Service) RetrieveClaims(tx *Transaction) map[string]CredentialMetadata {
 	result := map[string]CredentialMetadata{}
 
+	if tx.ReceivedClaims == nil {
+		return result
+	}
+
 	for _, cred := range tx.ReceivedClaims.Credentials {
 		credType := "ldp"
 		if cred.JWT != "" {
@@ -323,7 +555,8 @@ func (s *Service) RetrieveClaims(tx *Transaction) map[string]CredentialMetadata
 	return result
 }
 
-func (s *Service) extractClaimData(tx *Transaction, token *ProcessedVPToken, profile *profileapi.Verifier) error {
+func (s *Service) extractClaimData(ctx context.Context, tx *Transaction, token *ProcessedVPToken,
+	profile *profileapi.Verifier) error {
 	// TODO: think about better solution. If jwt is set, its wrap vp into sub object "vp" and this breaks Match
 	token.Presentation.JWT = ""
 
@@ -332,7 +565,7 @@ func (s *Service) extractClaimData(tx *Transaction, token *ProcessedVPToken, pro
 		return err
 	}
 
-	logger.Debug("extractClaimData vp", log.WithJSON(string(bytes)))
+	logger.WithContext(ctx).Debug("extractClaimData vp", log.WithJSON(string(bytes)))
 
 	credentials, err := tx.PresentationDefinition.Match(token.Presentation, s.documentLoader,
 		presexch.WithCredentialOptions(
@@ -340,35 +573,82 @@ func (s *Service) extractClaimData(tx *Transaction, token *ProcessedVPToken, pro
 			verifiable.WithPublicKeyFetcher(s.publicKeyFetcher),
 		), presexch.WithDisableSchemaValidation())
 
+	// report is diagnostic only, presentation definition match decides whether the submission is accepted
+	report := s.buildMatchReport(tx.PresentationDefinition, token.Presentation)
+
 	if err != nil {
-		return fmt.Errorf("extract claims: match: %w", err)
+		return s.failSubmission(ctx, tx, profile, report, fmt.Errorf("extract claims: match: %w", err))
 	}
 
-	logger.Debug("extractClaimData pd matched")
+	logger.WithContext(ctx).Debug("extractClaimData pd matched")
 
 	if profile.Checks != nil && profile.Checks.Presentation != nil && profile.Checks.Presentation.VCSubject {
 		err = checkVCSubject(credentials, token)
 		if err != nil {
-			return err
+			return s.failSubmission(ctx, tx, profile, report, err)
 		}
 
-		logger.Debug("extractClaimData vc subject verified")
+		logger.WithContext(ctx).Debug("extractClaimData vc subject verified")
 	}
 
-	err = s.transactionManager.StoreReceivedClaims(tx.ID, &ReceivedClaims{Credentials: credentials})
+	err = s.transactionManager.StoreReceivedClaims(tx.ID, &ReceivedClaims{Credentials: credentials},
+		getClaimsTTL(profile))
 	if err != nil {
-		return fmt.Errorf("extract claims: store: %w", err)
+		return s.failSubmission(ctx, tx, profile, report, fmt.Errorf("extract claims: store: %w", err))
 	}
 
-	logger.Debug("extractClaimData claims stored")
+	logger.WithContext(ctx).Debug("extractClaimData claims stored")
 
-	if err = s.sendEvent(tx, profile, spi.VerifierOIDCInteractionSucceeded); err != nil {
+	report.Matched = true
+
+	if err = s.transactionManager.StoreMatchReport(tx.ID, report); err != nil {
+		return fmt.Errorf("extract claims: store match report: %w", err)
+	}
+
+	if err = s.sendEvent(ctx, tx, profile, spi.VerifierOIDCInteractionSucceeded); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// failSubmission stores match report of the rejected submission and notifies about failed interaction.
+func (s *Service) failSubmission(ctx context.Context, tx *Transaction, profile *profileapi.Verifier,
+	report *MatchReport, interactionErr error) error {
+	report.Matched = false
+	report.Error = interactionErr.Error()
+
+	if err := s.transactionManager.StoreMatchReport(tx.ID, report); err != nil {
+		return fmt.Errorf("extract claims: store match report: %w", err)
+	}
+
+	return s.failInteraction(ctx, tx, profile, report, interactionErr)
+}
+
+// failInteraction notifies about failed interaction and returns interaction error.
+func (s *Service) failInteraction(
+	ctx context.Context,
+	tx *Transaction,
+	profile *profileapi.Verifier,
+	report *MatchReport,
+	interactionErr error,
+) error {
+	if err := s.sendFailedEvent(ctx, tx, profile, report, interactionErr); err != nil {
+		logger.WithContext(ctx).Warn("Failed to send interaction failed event",
+			log.WithTxID(string(tx.ID)), log.WithError(err))
+	}
+
+	return interactionErr
+}
+
+func getClaimsTTL(profile *profileapi.Verifier) time.Duration {
+	if profile.OIDCConfig == nil || profile.OIDCConfig.ClaimsRetention == nil {
+		return 0
+	}
+
+	return time.Duration(profile.OIDCConfig.ClaimsRetention.TTL) * time.Second
+}
+
 func checkVCSubject(credentials map[string]*verifiable.Credential, token *ProcessedVPToken) error {
 	for _, cred := range credentials {
 		var subjectID string
@@ -429,6 +709,31 @@ func (s *Service) createRequestObjectJWT(presentationDefinition *presexch.Presen
 	return singRequestObject(ro, profile, vcsSigner)
 }
 
+func encryptRequestObject(token string, recipientKey *cryptoapi.PublicKey) (string, error) {
+	cr, err := tinkcrypto.New()
+	if err != nil {
+		return "", fmt.Errorf("initiate oidc interaction: create crypto failed: %w", err)
+	}
+
+	encrypter, err := jose.NewJWEEncrypt(jose.A256GCM, "", requestObjectContentType, "", nil,
+		[]*cryptoapi.PublicKey{recipientKey}, cr)
+	if err != nil {
+		return "", fmt.Errorf("initiate oidc interaction: create request object encrypter failed: %w", err)
+	}
+
+	jwe, err := encrypter.Encrypt([]byte(token))
+	if err != nil {
+		return "", fmt.Errorf("initiate oidc interaction: encrypt request object failed: %w", err)
+	}
+
+	encrypted, err := jwe.CompactSerialize(json.Marshal)
+	if err != nil {
+		return "", fmt.Errorf("initiate oidc interaction: serialize encrypted request object failed: %w", err)
+	}
+
+	return encrypted, nil
+}
+
 func singRequestObject(ro *RequestObject, profile *profileapi.Verifier, vcsSigner vc.SignerAlgorithm) (string, error) {
 	signer := NewJWSSigner(profile.SigningDID.Creator, vcsSigner)
 
@@ -472,16 +777,21 @@ func (s *Service) createRequestObject(
 	profile *profileapi.Verifier) *RequestObject {
 	tokenLifetime := s.tokenLifetime
 	now := time.Now()
-	return &RequestObject{
+
+	responseMode := tx.ResponseMode
+	if responseMode == "" {
+		responseMode = ResponseModePost
+	}
+
+	ro := &RequestObject{
 		JTI:          uuid.New().String(),
 		IAT:          now.Unix(),
 		ISS:          profile.SigningDID.DID,
 		ResponseType: "id_token",
-		ResponseMode: "post",
+		ResponseMode: string(responseMode),
 		Scope:        "openid",
 		Nonce:        nonce,
 		ClientID:     profile.SigningDID.DID,
-		RedirectURI:  s.redirectURL,
 		State:        string(tx.ID),
 		Exp:          now.Add(tokenLifetime).Unix(),
 		Registration: RequestObjectRegistration{
@@ -494,6 +804,52 @@ func (s *Service) createRequestObject(
 			presentationDefinition,
 		}},
 	}
+
+	// direct_post response modes use response_uri instead of redirect_uri.
+	if responseMode == ResponseModePost {
+		ro.RedirectURI = s.redirectURL
+	} else {
+		ro.ResponseURI = s.redirectURL
+	}
+
+	if responseMode == ResponseModeDirectPostJWT {
+		setResponseEncryption(&ro.Registration, profile)
+	}
+
+	return ro
+}
+
+func setResponseEncryption(registration *RequestObjectRegistration, profile *profileapi.Verifier) {
+	if profile.OIDCConfig == nil || profile.OIDCConfig.ResponseEncryption == nil ||
+		profile.OIDCConfig.ResponseEncryption.JWK == nil {
+		return
+	}
+
+	encryption := profile.OIDCConfig.ResponseEncryption
+
+	encryptionKey := *encryption.JWK
+	encryptionKey.KeyID = ResponseEncryptionKeyID(profile.ID, encryption.KeyID)
+	encryptionKey.Use = "enc"
+	encryptionKey.Algorithm = responseEncryptionAlg
+
+	registration.AuthorizationEncryptedResponseAlg = responseEncryptionAlg
+	registration.AuthorizationEncryptedResponseEnc = responseEncryptionEnc
+	registration.JWKS = &RequestObjectJWKS{Keys: []*jwk.JWK{&encryptionKey}}
+}
+
+// ResponseEncryptionKeyID returns kid of the key advertised to the wallet for authorization response encryption.
+func ResponseEncryptionKeyID(profileID profileapi.ID, keyID string) string {
+	return profileID + "#" + keyID
+}
+
+// ProfileIDFromResponseEncryptionKeyID extracts profile id from kid of the response encryption key.
+func ProfileIDFromResponseEncryptionKeyID(kid string) (profileapi.ID, error) {
+	idx := strings.LastIndex(kid, "#")
+	if idx <= 0 {
+		return "", fmt.Errorf("invalid response encryption kid: %s", kid)
+	}
+
+	return kid[:idx], nil
 }
 
 type JWSSigner struct {
@@ -7,17 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package oidc4vp_test
 
 import (
+	"context"
 	_ "embed"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
-	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
+	ariescontext "github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 
 	"github.com/trustbloc/vcs/pkg/event/spi"
@@ -26,11 +30,13 @@ import (
 	"github.com/google/uuid"
 	ariescrypto "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -38,11 +44,13 @@ import (
 	ariesmockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/jinzhu/copier"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/vcs/pkg/doc/vc"
 	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	"github.com/trustbloc/vcs/pkg/internal/testutil"
+	vcskms "github.com/trustbloc/vcs/pkg/kms"
 	"github.com/trustbloc/vcs/pkg/kms/signer"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vp"
@@ -66,17 +74,21 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 		&mockVCSKeyManager{crypto: customCrypto, kms: customKMS}, nil)
 
 	txManager := NewMockTransactionManager(gomock.NewController(t))
-	txManager.EXPECT().CreateTx(gomock.Any(), gomock.Any()).AnyTimes().Return(&oidc4vp.Transaction{
+	txManager.EXPECT().CreateTx(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(&oidc4vp.Transaction{
 		ID:                     "TxID1",
 		ProfileID:              "test4",
 		PresentationDefinition: &presexch.PresentationDefinition{},
 	}, "nonce1", nil)
+	txManager.EXPECT().StoreAuthorizationRequest(oidc4vp.TxID("TxID1"), gomock.Any()).AnyTimes().Return(nil)
 	requestObjectPublicStore := NewMockRequestObjectPublicStore(gomock.NewController(t))
 	requestObjectPublicStore.EXPECT().Publish(gomock.Any(), gomock.Any()).
 		AnyTimes().DoAndReturn(func(token string, event *spi.Event) (string, error) {
 		return "someurl/abc", nil
 	})
 
+	metrics := NewMockMetricsProvider(gomock.NewController(t))
+	metrics.EXPECT().OIDC4VPOperationTime("test1", "initiate_interaction", gomock.Any(), gomock.Any()).AnyTimes()
+
 	s := oidc4vp.NewService(&oidc4vp.Config{
 		EventSvc:                 &mockEvent{},
 		TransactionManager:       txManager,
@@ -84,6 +96,7 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 		KMSRegistry:              kmsRegistry,
 		RedirectURL:              "test://redirect",
 		TokenLifetime:            time.Second * 100,
+		Metrics:                  metrics,
 	})
 
 	keyID, _, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
@@ -105,12 +118,227 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		info, err := s.InitiateOidcInteraction(&presexch.PresentationDefinition{
+		info, err := s.InitiateOidcInteraction(context.Background(), &presexch.PresentationDefinition{
 			ID: "test",
-		}, "test", correctProfile)
+		}, "test", correctProfile, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, info)
+	})
+
+	t.Run("Success direct_post same-device", func(t *testing.T) {
+		var requestObject string
+
+		txManagerSameDevice := NewMockTransactionManager(gomock.NewController(t))
+		txManagerSameDevice.EXPECT().CreateTx(gomock.Any(), gomock.Any(), &oidc4vp.TxParams{
+			ResponseMode: oidc4vp.ResponseModeDirectPost,
+			RedirectURI:  "https://rp.example.com/cb",
+		}).Times(1).Return(&oidc4vp.Transaction{
+			ID:           "TxID1",
+			ProfileID:    "test4",
+			ResponseMode: oidc4vp.ResponseModeDirectPost,
+			RedirectURI:  "https://rp.example.com/cb",
+			ResponseCode: "code",
+		}, "nonce1", nil)
+		txManagerSameDevice.EXPECT().StoreAuthorizationRequest(gomock.Any(), gomock.Any()).Return(nil)
+
+		roStore := NewMockRequestObjectPublicStore(gomock.NewController(t))
+		roStore.EXPECT().Publish(gomock.Any(), gomock.Any()).
+			Times(1).DoAndReturn(func(token string, event *spi.Event) (string, error) {
+			requestObject = token
+			return "someurl/abc", nil
+		})
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:                 &mockEvent{},
+			TransactionManager:       txManagerSameDevice,
+			RequestObjectPublicStore: roStore,
+			KMSRegistry:              kmsRegistry,
+			RedirectURL:              "test://redirect",
+			TokenLifetime:            time.Second * 100,
+		})
+
+		info, err := svc.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile,
+			&oidc4vp.InteractionOptions{
+				ResponseMode: oidc4vp.ResponseModeDirectPost,
+				RedirectURI:  "https://rp.example.com/cb",
+			})
+		require.NoError(t, err)
+		require.NotNil(t, info)
+
+		parts := strings.Split(requestObject, ".")
+		require.Len(t, parts, 3)
+
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+
+		ro := &oidc4vp.RequestObject{}
+		require.NoError(t, json.Unmarshal(payload, ro))
+		require.Equal(t, "direct_post", ro.ResponseMode)
+		require.Equal(t, "test://redirect", ro.ResponseURI)
+		require.Empty(t, ro.RedirectURI)
+	})
+
+	t.Run("Success direct_post.jwt with response encryption", func(t *testing.T) {
+		var requestObject string
+
+		txManagerJWT := NewMockTransactionManager(gomock.NewController(t))
+		txManagerJWT.EXPECT().CreateTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(
+			&oidc4vp.Transaction{
+				ID:           "TxID1",
+				ProfileID:    "test1",
+				ResponseMode: oidc4vp.ResponseModeDirectPostJWT,
+			}, "nonce1", nil)
+		txManagerJWT.EXPECT().StoreAuthorizationRequest(gomock.Any(), gomock.Any()).Return(nil)
+
+		roStore := NewMockRequestObjectPublicStore(gomock.NewController(t))
+		roStore.EXPECT().Publish(gomock.Any(), gomock.Any()).
+			Times(1).DoAndReturn(func(token string, event *spi.Event) (string, error) {
+			requestObject = token
+			return "someurl/abc", nil
+		})
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:                 &mockEvent{},
+			TransactionManager:       txManagerJWT,
+			RequestObjectPublicStore: roStore,
+			KMSRegistry:              kmsRegistry,
+			RedirectURL:              "test://redirect",
+			TokenLifetime:            time.Second * 100,
+		})
+
+		encKeyID, encKeyBytes, err := customKMS.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		encJWK, err := jwkkid.BuildJWK(encKeyBytes, kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		encryptedProfile := &profileapi.Verifier{}
+		require.NoError(t, copier.Copy(encryptedProfile, correctProfile))
+		encryptedProfile.OIDCConfig = &profileapi.OIDC4VPConfig{
+			KeyType: kms.ED25519Type,
+			ResponseEncryption: &profileapi.ResponseEncryption{
+				KeyType: kms.NISTP256ECDHKWType,
+				KeyID:   encKeyID,
+				JWK:     encJWK,
+			},
+		}
+
+		info, err := svc.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", encryptedProfile,
+			&oidc4vp.InteractionOptions{ResponseMode: oidc4vp.ResponseModeDirectPostJWT})
+		require.NoError(t, err)
+		require.NotNil(t, info)
+
+		parts := strings.Split(requestObject, ".")
+		require.Len(t, parts, 3)
+
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+
+		ro := &oidc4vp.RequestObject{}
+		require.NoError(t, json.Unmarshal(payload, ro))
+		require.Equal(t, "direct_post.jwt", ro.ResponseMode)
+		require.Equal(t, "ECDH-ES+A256KW", ro.Registration.AuthorizationEncryptedResponseAlg)
+		require.Equal(t, "A256GCM", ro.Registration.AuthorizationEncryptedResponseEnc)
+		require.Len(t, ro.Registration.JWKS.Keys, 1)
+		require.Equal(t, "test1#"+encKeyID, ro.Registration.JWKS.Keys[0].KeyID)
+		require.Equal(t, "enc", ro.Registration.JWKS.Keys[0].Use)
+
+		profileID, err := oidc4vp.ProfileIDFromResponseEncryptionKeyID(ro.Registration.JWKS.Keys[0].KeyID)
+		require.NoError(t, err)
+		require.Equal(t, "test1", profileID)
+
+		// profile key is not modified
+		require.Empty(t, encJWK.KeyID)
+	})
+
+	t.Run("Unsupported response mode", func(t *testing.T) {
+		info, err := s.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile,
+			&oidc4vp.InteractionOptions{ResponseMode: "query"})
+
+		require.ErrorContains(t, err, "unsupported response mode")
+		require.Nil(t, info)
+	})
+
+	t.Run("Invalid redirect uri", func(t *testing.T) {
+		info, err := s.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile,
+			&oidc4vp.InteractionOptions{RedirectURI: "/relative"})
+
+		require.ErrorContains(t, err, "invalid redirect uri")
+		require.Nil(t, info)
+	})
+
+	t.Run("Success request object by value", func(t *testing.T) {
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:                 &mockEvent{},
+			TransactionManager:       txManager,
+			RequestObjectPublicStore: NewMockRequestObjectPublicStore(gomock.NewController(t)),
+			KMSRegistry:              kmsRegistry,
+			RedirectURL:              "test://redirect",
+			TokenLifetime:            time.Second * 100,
+		})
+
+		info, err := svc.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile,
+			&oidc4vp.InteractionOptions{RequestObjectByValue: true})
+		require.NoError(t, err)
+		require.NotNil(t, info)
+
+		authRequest, err := url.Parse(info.AuthorizationRequest)
+		require.NoError(t, err)
+		require.Empty(t, authRequest.Query().Get("request_uri"))
+		require.Len(t, strings.Split(authRequest.Query().Get("request"), "."), 3)
+	})
+
+	t.Run("Success encrypted request object by value", func(t *testing.T) {
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:                 &mockEvent{},
+			TransactionManager:       txManager,
+			RequestObjectPublicStore: NewMockRequestObjectPublicStore(gomock.NewController(t)),
+			KMSRegistry:              kmsRegistry,
+			RedirectURL:              "test://redirect",
+			TokenLifetime:            time.Second * 100,
+		})
+
+		walletKeyID, walletKeyBytes, err := customKMS.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
 
+		walletJWK, err := jwkkid.BuildJWK(walletKeyBytes, kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		walletJWK.KeyID = walletKeyID
+
+		info, err := svc.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile,
+			&oidc4vp.InteractionOptions{
+				RequestObjectByValue:       true,
+				RequestObjectEncryptionKey: walletJWK,
+			})
 		require.NoError(t, err)
 		require.NotNil(t, info)
+
+		authRequest, err := url.Parse(info.AuthorizationRequest)
+		require.NoError(t, err)
+
+		encrypted, err := jose.Deserialize(authRequest.Query().Get("request"))
+		require.NoError(t, err)
+
+		decrypted, err := jose.NewJWEDecrypt(nil, customCrypto, customKMS).Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Len(t, strings.Split(string(decrypted), "."), 3)
+	})
+
+	t.Run("Invalid request object encryption key", func(t *testing.T) {
+		info, err := s.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile,
+			&oidc4vp.InteractionOptions{RequestObjectEncryptionKey: &jwk.JWK{}})
+
+		require.ErrorIs(t, err, oidc4vp.ErrInvalidRequestObjectEncryptionKey)
+		require.Nil(t, info)
 	})
 
 	t.Run("No signature did", func(t *testing.T) {
@@ -118,7 +346,8 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 		require.NoError(t, copier.Copy(incorrectProfile, correctProfile))
 		incorrectProfile.SigningDID = nil
 
-		info, err := s.InitiateOidcInteraction(&presexch.PresentationDefinition{}, "test", incorrectProfile)
+		info, err := s.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", incorrectProfile, nil)
 
 		require.Error(t, err)
 		require.Nil(t, info)
@@ -126,7 +355,8 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 
 	t.Run("Tx create failed", func(t *testing.T) {
 		txManagerErr := NewMockTransactionManager(gomock.NewController(t))
-		txManagerErr.EXPECT().CreateTx(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, "", errors.New("fail"))
+		txManagerErr.EXPECT().CreateTx(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+			Return(nil, "", errors.New("fail"))
 
 		withError := oidc4vp.NewService(&oidc4vp.Config{
 			EventSvc:                 &mockEvent{},
@@ -136,12 +366,37 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 			RedirectURL:              "test://redirect",
 		})
 
-		info, err := withError.InitiateOidcInteraction(&presexch.PresentationDefinition{}, "test", correctProfile)
+		info, err := withError.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile, nil)
 
 		require.Contains(t, err.Error(), "create oidc tx")
 		require.Nil(t, info)
 	})
 
+	t.Run("store authorization request failed", func(t *testing.T) {
+		txManagerErr := NewMockTransactionManager(gomock.NewController(t))
+		txManagerErr.EXPECT().CreateTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(&oidc4vp.Transaction{
+			ID:        "TxID1",
+			ProfileID: "test4",
+		}, "nonce1", nil)
+		txManagerErr.EXPECT().StoreAuthorizationRequest(oidc4vp.TxID("TxID1"), "openid-vc://?request_uri=someurl/abc").
+			Return(errors.New("fail"))
+
+		withError := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:                 &mockEvent{},
+			TransactionManager:       txManagerErr,
+			RequestObjectPublicStore: requestObjectPublicStore,
+			KMSRegistry:              kmsRegistry,
+			RedirectURL:              "test://redirect",
+		})
+
+		info, err := withError.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile, nil)
+
+		require.ErrorContains(t, err, "fail to store authorization request")
+		require.Nil(t, info)
+	})
+
 	t.Run("publish request object failed", func(t *testing.T) {
 		requestObjectPublicStoreErr := NewMockRequestObjectPublicStore(gomock.NewController(t))
 		requestObjectPublicStoreErr.EXPECT().Publish(gomock.Any(), gomock.Any()).
@@ -155,7 +410,8 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 			RedirectURL:              "test://redirect",
 		})
 
-		info, err := withError.InitiateOidcInteraction(&presexch.PresentationDefinition{}, "test", correctProfile)
+		info, err := withError.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile, nil)
 
 		require.Contains(t, err.Error(), "publish request object")
 		require.Nil(t, info)
@@ -173,7 +429,8 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 			RedirectURL:              "test://redirect",
 		})
 
-		info, err := withError.InitiateOidcInteraction(&presexch.PresentationDefinition{}, "test", correctProfile)
+		info, err := withError.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", correctProfile, nil)
 
 		require.Contains(t, err.Error(), "get key manager")
 		require.Nil(t, info)
@@ -184,7 +441,8 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 		require.NoError(t, copier.Copy(incorrectProfile, correctProfile))
 		incorrectProfile.SigningDID.Creator = "invalid"
 
-		info, err := s.InitiateOidcInteraction(&presexch.PresentationDefinition{}, "test", incorrectProfile)
+		info, err := s.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", incorrectProfile, nil)
 
 		require.Error(t, err)
 		require.Nil(t, info)
@@ -195,7 +453,8 @@ func TestService_InitiateOidcInteraction(t *testing.T) {
 		require.NoError(t, copier.Copy(incorrectProfile, correctProfile))
 		incorrectProfile.OIDCConfig.KeyType = "invalid"
 
-		info, err := s.InitiateOidcInteraction(&presexch.PresentationDefinition{}, "test", incorrectProfile)
+		info, err := s.InitiateOidcInteraction(context.Background(),
+			&presexch.PresentationDefinition{}, "test", incorrectProfile, nil)
 
 		require.Error(t, err)
 		require.Nil(t, info)
@@ -210,6 +469,9 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 	presentationVerifier := NewMockPresentationVerifier(gomock.NewController(t))
 	vp, pd, pubKeyFetcher, loader := newVPWithPD(t, agent)
 
+	metrics := NewMockMetricsProvider(gomock.NewController(t))
+	metrics.EXPECT().OIDC4VPOperationTime("testP1", "verify_presentation", gomock.Any(), gomock.Any()).AnyTimes()
+
 	s := oidc4vp.NewService(&oidc4vp.Config{
 		EventSvc:             &mockEvent{},
 		TransactionManager:   txManager,
@@ -217,6 +479,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 		ProfileService:       profileService,
 		DocumentLoader:       loader,
 		PublicKeyFetcher:     pubKeyFetcher,
+		Metrics:              metrics,
 	})
 
 	txManager.EXPECT().GetByOneTimeToken("nonce1").AnyTimes().Return(&oidc4vp.Transaction{
@@ -225,7 +488,8 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 		PresentationDefinition: pd,
 	}, true, nil)
 
-	txManager.EXPECT().StoreReceivedClaims(oidc4vp.TxID("txID1"), gomock.Any()).AnyTimes().Return(nil)
+	txManager.EXPECT().StoreReceivedClaims(oidc4vp.TxID("txID1"), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	txManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).AnyTimes().Return(nil)
 
 	profileService.EXPECT().GetProfile("testP1").AnyTimes().Return(&profileapi.Verifier{
 		ID:     "testP1",
@@ -237,11 +501,11 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 		},
 	}, nil)
 
-	presentationVerifier.EXPECT().VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any()).
+	presentationVerifier.EXPECT().VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().Return(nil, nil)
 
 	t.Run("Success", func(t *testing.T) {
-		err := s.VerifyOIDCVerifiablePresentation("txID1",
+		err := s.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: vp,
@@ -252,7 +516,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 	})
 
 	t.Run("VC subject is not much with vp signer", func(t *testing.T) {
-		err := s.VerifyOIDCVerifiablePresentation("txID1",
+		err := s.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: vp,
@@ -275,7 +539,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 			DocumentLoader:       loader,
 		})
 
-		err := withError.VerifyOIDCVerifiablePresentation("txID1",
+		err := withError.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: vp,
@@ -286,7 +550,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 	})
 
 	t.Run("Invalid Nonce 2", func(t *testing.T) {
-		err := s.VerifyOIDCVerifiablePresentation("txID2",
+		err := s.VerifyOIDCVerifiablePresentation(context.Background(), "txID2",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: vp,
@@ -309,7 +573,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 			DocumentLoader:       loader,
 		})
 
-		err := withError.VerifyOIDCVerifiablePresentation("txID1",
+		err := withError.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: vp,
@@ -321,7 +585,8 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 
 	t.Run("verification failed", func(t *testing.T) {
 		errPresentationVerifier := NewMockPresentationVerifier(gomock.NewController(t))
-		errPresentationVerifier.EXPECT().VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		errPresentationVerifier.EXPECT().
+			VerifyPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
 			Return(nil, errors.New("verification failed"))
 		withError := oidc4vp.NewService(&oidc4vp.Config{
 			EventSvc:             &mockEvent{},
@@ -331,7 +596,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 			DocumentLoader:       loader,
 		})
 
-		err := withError.VerifyOIDCVerifiablePresentation("txID1",
+		err := withError.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: vp,
@@ -342,7 +607,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 	})
 
 	t.Run("Match failed", func(t *testing.T) {
-		err := s.VerifyOIDCVerifiablePresentation("txID1",
+		err := s.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: &verifiable.Presentation{},
@@ -358,8 +623,9 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 			PresentationDefinition: pd,
 		}, true, nil)
 
-		errTxManager.EXPECT().StoreReceivedClaims(oidc4vp.TxID("txID1"), gomock.Any()).
+		errTxManager.EXPECT().StoreReceivedClaims(oidc4vp.TxID("txID1"), gomock.Any(), gomock.Any()).
 			Return(errors.New("store error"))
+		errTxManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).Return(nil)
 
 		withError := oidc4vp.NewService(&oidc4vp.Config{
 			EventSvc:             &mockEvent{},
@@ -370,7 +636,7 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 			PublicKeyFetcher:     pubKeyFetcher,
 		})
 
-		err := withError.VerifyOIDCVerifiablePresentation("txID1",
+		err := withError.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
 			&oidc4vp.ProcessedVPToken{
 				Nonce:        "nonce1",
 				Presentation: vp,
@@ -379,6 +645,308 @@ func TestService_VerifyOIDCVerifiablePresentation(t *testing.T) {
 
 		require.Contains(t, err.Error(), "store error")
 	})
+
+	t.Run("Match report success", func(t *testing.T) {
+		var report *oidc4vp.MatchReport
+
+		reportTxManager := NewMockTransactionManager(gomock.NewController(t))
+		reportTxManager.EXPECT().GetByOneTimeToken("nonce1").Return(&oidc4vp.Transaction{
+			ID:                     "txID1",
+			ProfileID:              "testP1",
+			PresentationDefinition: pd,
+		}, true, nil)
+		reportTxManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).DoAndReturn(
+			func(txID oidc4vp.TxID, r *oidc4vp.MatchReport) error {
+				report = r
+				return nil
+			})
+		reportTxManager.EXPECT().StoreReceivedClaims(oidc4vp.TxID("txID1"), gomock.Any(), gomock.Any()).Return(nil)
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:             &mockEvent{},
+			TransactionManager:   reportTxManager,
+			PresentationVerifier: presentationVerifier,
+			ProfileService:       profileService,
+			DocumentLoader:       loader,
+			PublicKeyFetcher:     pubKeyFetcher,
+		})
+
+		err := svc.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
+			&oidc4vp.ProcessedVPToken{
+				Nonce:        "nonce1",
+				Presentation: vp,
+				Signer:       "did:example123:ebfeb1f712ebc6f1c276e12ec21",
+			})
+		require.NoError(t, err)
+
+		require.NotNil(t, report)
+		require.True(t, report.Matched)
+		require.Len(t, report.InputDescriptors, 1)
+		require.True(t, report.InputDescriptors[0].Matched)
+		require.Equal(t, []string{"http://test.credential.com/123"}, report.InputDescriptors[0].CredentialIDs)
+	})
+
+	t.Run("Match report with failed input descriptors", func(t *testing.T) {
+		var report *oidc4vp.MatchReport
+
+		failingPD := &presexch.PresentationDefinition{
+			InputDescriptors: []*presexch.InputDescriptor{
+				{
+					ID:     pd.InputDescriptors[0].ID,
+					Format: &presexch.Format{LdpVC: &presexch.LdpType{}},
+					Constraints: &presexch.Constraints{
+						Fields: []*presexch.Field{
+							{Path: []string{"$.credentialSubject.degree"}},
+							{
+								Path:   []string{"$.id"},
+								Filter: &presexch.Filter{Type: lo.ToPtr("string"), Pattern: "^urn:"},
+							},
+						},
+					},
+				},
+				{ID: "not-submitted"},
+			},
+		}
+
+		reportTxManager := NewMockTransactionManager(gomock.NewController(t))
+		reportTxManager.EXPECT().GetByOneTimeToken("nonce1").Return(&oidc4vp.Transaction{
+			ID:                     "txID1",
+			ProfileID:              "testP1",
+			PresentationDefinition: failingPD,
+		}, true, nil)
+		reportTxManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).DoAndReturn(
+			func(txID oidc4vp.TxID, r *oidc4vp.MatchReport) error {
+				report = r
+				return nil
+			})
+
+		eventSvc := &mockEvent{}
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:             eventSvc,
+			TransactionManager:   reportTxManager,
+			PresentationVerifier: presentationVerifier,
+			ProfileService:       profileService,
+			DocumentLoader:       loader,
+			PublicKeyFetcher:     pubKeyFetcher,
+		})
+
+		err := svc.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
+			&oidc4vp.ProcessedVPToken{
+				Nonce:        "nonce1",
+				Presentation: vp,
+				Signer:       "did:example123:ebfeb1f712ebc6f1c276e12ec21",
+			})
+		require.ErrorContains(t, err, "extract claims: match:")
+
+		require.NotNil(t, report)
+		require.False(t, report.Matched)
+		require.Len(t, report.InputDescriptors, 2)
+
+		submitted := report.InputDescriptors[0]
+		require.False(t, submitted.Matched)
+		require.Equal(t, []string{"http://test.credential.com/123"}, submitted.CredentialIDs)
+		require.Len(t, submitted.Failures, 3)
+		require.Equal(t, oidc4vp.MatchFailureWrongFormat, submitted.Failures[0].Reason)
+		require.Equal(t, oidc4vp.MatchFailureMissingField, submitted.Failures[1].Reason)
+		require.Equal(t, "$.credentialSubject.degree", submitted.Failures[1].Path)
+		require.Equal(t, oidc4vp.MatchFailureFailedFilter, submitted.Failures[2].Reason)
+		require.Equal(t, "$.id", submitted.Failures[2].Path)
+
+		notSubmitted := report.InputDescriptors[1]
+		require.Equal(t, "not-submitted", notSubmitted.ID)
+		require.False(t, notSubmitted.Matched)
+		require.Len(t, notSubmitted.Failures, 1)
+		require.Equal(t, oidc4vp.MatchFailureNoSubmission, notSubmitted.Failures[0].Reason)
+
+		require.Contains(t, report.Summary(), "input descriptor not-submitted: no_submission")
+
+		require.Len(t, eventSvc.events, 1)
+		require.Equal(t, spi.EventType(spi.VerifierOIDCInteractionFailed), eventSvc.events[0].Type)
+
+		payload := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(*eventSvc.events[0].Data, &payload))
+		require.Equal(t, "txID1", payload["txID"])
+		require.NotEmpty(t, payload["error"])
+		require.NotNil(t, payload["matchReport"])
+	})
+
+	t.Run("Presentation definition matched while report has failed input descriptors", func(t *testing.T) {
+		var report *oidc4vp.MatchReport
+
+		formatPD := &presexch.PresentationDefinition{
+			InputDescriptors: []*presexch.InputDescriptor{
+				{
+					ID:          pd.InputDescriptors[0].ID,
+					Format:      &presexch.Format{LdpVC: &presexch.LdpType{}},
+					Constraints: pd.InputDescriptors[0].Constraints,
+				},
+			},
+		}
+
+		reportTxManager := NewMockTransactionManager(gomock.NewController(t))
+		reportTxManager.EXPECT().GetByOneTimeToken("nonce1").Return(&oidc4vp.Transaction{
+			ID:                     "txID1",
+			ProfileID:              "testP1",
+			PresentationDefinition: formatPD,
+		}, true, nil)
+		reportTxManager.EXPECT().StoreReceivedClaims(oidc4vp.TxID("txID1"), gomock.Any(), gomock.Any()).Return(nil)
+		reportTxManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).DoAndReturn(
+			func(txID oidc4vp.TxID, r *oidc4vp.MatchReport) error {
+				report = r
+				return nil
+			})
+
+		eventSvc := &mockEvent{}
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:             eventSvc,
+			TransactionManager:   reportTxManager,
+			PresentationVerifier: presentationVerifier,
+			ProfileService:       profileService,
+			DocumentLoader:       loader,
+			PublicKeyFetcher:     pubKeyFetcher,
+		})
+
+		err := svc.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
+			&oidc4vp.ProcessedVPToken{
+				Nonce:        "nonce1",
+				Presentation: vp,
+				Signer:       "did:example123:ebfeb1f712ebc6f1c276e12ec21",
+			})
+		require.NoError(t, err)
+
+		require.NotNil(t, report)
+		require.True(t, report.Matched)
+		require.Len(t, report.InputDescriptors, 1)
+		require.False(t, report.InputDescriptors[0].Matched)
+		require.Equal(t, oidc4vp.MatchFailureWrongFormat, report.InputDescriptors[0].Failures[0].Reason)
+
+		require.Len(t, eventSvc.events, 1)
+		require.Equal(t, spi.EventType(spi.VerifierOIDCInteractionSucceeded), eventSvc.events[0].Type)
+	})
+
+	t.Run("Presentation definition not matched while report input descriptors matched", func(t *testing.T) {
+		var report *oidc4vp.MatchReport
+
+		extraVP := *vp
+		extraVP.CustomFields = map[string]interface{}{
+			"presentation_submission": toMap(t, &presexch.PresentationSubmission{
+				DescriptorMap: []*presexch.InputDescriptorMapping{
+					{ID: pd.InputDescriptors[0].ID, Path: "$.verifiableCredential[0]"},
+					{ID: "unknown", Path: "$.verifiableCredential[0]"},
+				},
+			}),
+		}
+
+		reportTxManager := NewMockTransactionManager(gomock.NewController(t))
+		reportTxManager.EXPECT().GetByOneTimeToken("nonce1").Return(&oidc4vp.Transaction{
+			ID:                     "txID1",
+			ProfileID:              "testP1",
+			PresentationDefinition: pd,
+		}, true, nil)
+		reportTxManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).DoAndReturn(
+			func(txID oidc4vp.TxID, r *oidc4vp.MatchReport) error {
+				report = r
+				return nil
+			})
+
+		eventSvc := &mockEvent{}
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:             eventSvc,
+			TransactionManager:   reportTxManager,
+			PresentationVerifier: presentationVerifier,
+			ProfileService:       profileService,
+			DocumentLoader:       loader,
+			PublicKeyFetcher:     pubKeyFetcher,
+		})
+
+		err := svc.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
+			&oidc4vp.ProcessedVPToken{
+				Nonce:        "nonce1",
+				Presentation: &extraVP,
+				Signer:       "did:example123:ebfeb1f712ebc6f1c276e12ec21",
+			})
+		require.ErrorContains(t, err, "extract claims: match:")
+
+		require.NotNil(t, report)
+		require.False(t, report.Matched)
+		require.Len(t, report.InputDescriptors, 1)
+		require.True(t, report.InputDescriptors[0].Matched)
+		require.Contains(t, report.Summary(), "extract claims: match:")
+
+		require.Len(t, eventSvc.events, 1)
+		require.Equal(t, spi.EventType(spi.VerifierOIDCInteractionFailed), eventSvc.events[0].Type)
+	})
+
+	t.Run("Match report of submission with VC subject not matching vp signer", func(t *testing.T) {
+		var report *oidc4vp.MatchReport
+
+		reportTxManager := NewMockTransactionManager(gomock.NewController(t))
+		reportTxManager.EXPECT().GetByOneTimeToken("nonce1").Return(&oidc4vp.Transaction{
+			ID:                     "txID1",
+			ProfileID:              "testP1",
+			PresentationDefinition: pd,
+		}, true, nil)
+		reportTxManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).DoAndReturn(
+			func(txID oidc4vp.TxID, r *oidc4vp.MatchReport) error {
+				report = r
+				return nil
+			})
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:             &mockEvent{},
+			TransactionManager:   reportTxManager,
+			PresentationVerifier: presentationVerifier,
+			ProfileService:       profileService,
+			DocumentLoader:       loader,
+			PublicKeyFetcher:     pubKeyFetcher,
+		})
+
+		err := svc.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
+			&oidc4vp.ProcessedVPToken{
+				Nonce:        "nonce1",
+				Presentation: vp,
+				Signer:       "did:example1:ebfeb1f712ebc6f1c276e12ec21",
+			})
+		require.ErrorContains(t, err, "is not much with vp signer")
+
+		require.NotNil(t, report)
+		require.False(t, report.Matched)
+		require.True(t, report.InputDescriptors[0].Matched)
+		require.Contains(t, report.Summary(), "is not much with vp signer")
+	})
+
+	t.Run("Store match report error", func(t *testing.T) {
+		errTxManager := NewMockTransactionManager(gomock.NewController(t))
+		errTxManager.EXPECT().GetByOneTimeToken("nonce1").Return(&oidc4vp.Transaction{
+			ID:                     "txID1",
+			ProfileID:              "testP1",
+			PresentationDefinition: pd,
+		}, true, nil)
+		errTxManager.EXPECT().StoreReceivedClaims(oidc4vp.TxID("txID1"), gomock.Any(), gomock.Any()).Return(nil)
+		errTxManager.EXPECT().StoreMatchReport(oidc4vp.TxID("txID1"), gomock.Any()).
+			Return(errors.New("store report error"))
+
+		withError := oidc4vp.NewService(&oidc4vp.Config{
+			EventSvc:             &mockEvent{},
+			TransactionManager:   errTxManager,
+			PresentationVerifier: presentationVerifier,
+			ProfileService:       profileService,
+			DocumentLoader:       loader,
+			PublicKeyFetcher:     pubKeyFetcher,
+		})
+
+		err := withError.VerifyOIDCVerifiablePresentation(context.Background(), "txID1",
+			&oidc4vp.ProcessedVPToken{
+				Nonce:        "nonce1",
+				Presentation: vp,
+				Signer:       "did:example123:ebfeb1f712ebc6f1c276e12ec21",
+			})
+
+		require.ErrorContains(t, err, "store report error")
+	})
 }
 
 func TestService_GetTx(t *testing.T) {
@@ -399,6 +967,40 @@ func TestService_GetTx(t *testing.T) {
 	})
 }
 
+func TestResponseRedirectURI(t *testing.T) {
+	t.Run("Same-device flow", func(t *testing.T) {
+		uri, err := oidc4vp.ResponseRedirectURI(&oidc4vp.Transaction{
+			RedirectURI:  "https://rp.example.com/cb?session=1",
+			ResponseCode: "code",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "https://rp.example.com/cb?response_code=code&session=1", uri)
+	})
+
+	t.Run("Cross-device flow", func(t *testing.T) {
+		uri, err := oidc4vp.ResponseRedirectURI(&oidc4vp.Transaction{})
+
+		require.NoError(t, err)
+		require.Empty(t, uri)
+	})
+
+	t.Run("Invalid redirect uri", func(t *testing.T) {
+		_, err := oidc4vp.ResponseRedirectURI(&oidc4vp.Transaction{RedirectURI: ":invalid"})
+
+		require.ErrorContains(t, err, "parse redirect uri")
+	})
+}
+
+func TestProfileIDFromResponseEncryptionKeyID(t *testing.T) {
+	profileID, err := oidc4vp.ProfileIDFromResponseEncryptionKeyID(oidc4vp.ResponseEncryptionKeyID("p1", "key1"))
+	require.NoError(t, err)
+	require.Equal(t, "p1", profileID)
+
+	_, err = oidc4vp.ProfileIDFromResponseEncryptionKeyID("key1")
+	require.ErrorContains(t, err, "invalid response encryption kid")
+}
+
 func TestService_RetrieveClaims(t *testing.T) {
 	svc := oidc4vp.NewService(&oidc4vp.Config{})
 	loader := testutil.DocumentLoader(t)
@@ -440,6 +1042,59 @@ func TestService_RetrieveClaims(t *testing.T) {
 		require.True(t, ok)
 		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subjects[0].ID)
 	})
+
+	t.Run("No claims received", func(t *testing.T) {
+		claims := svc.RetrieveClaims(&oidc4vp.Transaction{})
+
+		require.NotNil(t, claims)
+		require.Empty(t, claims)
+	})
+}
+
+func TestService_DeleteClaims(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		txManager := NewMockTransactionManager(gomock.NewController(t))
+		txManager.EXPECT().Delete(oidc4vp.TxID("txID1")).Times(1).Return(nil)
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{TransactionManager: txManager})
+
+		require.NoError(t, svc.DeleteClaims("txID1"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		txManager := NewMockTransactionManager(gomock.NewController(t))
+		txManager.EXPECT().Delete(oidc4vp.TxID("txID1")).Times(1).Return(oidc4vp.ErrDataNotFound)
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{TransactionManager: txManager})
+
+		require.ErrorIs(t, svc.DeleteClaims("txID1"), oidc4vp.ErrDataNotFound)
+	})
+}
+
+func TestService_RetrieveAndDeleteClaims(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		txManager := NewMockTransactionManager(gomock.NewController(t))
+		txManager.EXPECT().GetAndDelete(oidc4vp.TxID("txID1")).Times(1).Return(&oidc4vp.Transaction{
+			ID:             "txID1",
+			ReceivedClaims: &oidc4vp.ReceivedClaims{},
+		}, nil)
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{TransactionManager: txManager})
+
+		claims, err := svc.RetrieveAndDeleteClaims("txID1")
+		require.NoError(t, err)
+		require.Empty(t, claims)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		txManager := NewMockTransactionManager(gomock.NewController(t))
+		txManager.EXPECT().GetAndDelete(oidc4vp.TxID("txID1")).Times(1).Return(nil, oidc4vp.ErrDataNotFound)
+
+		svc := oidc4vp.NewService(&oidc4vp.Config{TransactionManager: txManager})
+
+		_, err := svc.RetrieveAndDeleteClaims("txID1")
+		require.ErrorIs(t, err, oidc4vp.ErrDataNotFound)
+	})
 }
 
 func createKMS(t *testing.T) *localkms.LocalKMS {
@@ -474,8 +1129,13 @@ func (m *mockVCSKeyManager) CreateCryptoKey(keyType kms.KeyType) (string, interf
 	return "", nil, nil
 }
 
+func (m *mockVCSKeyManager) NewJWEDecrypter(string) (vcskms.JWEDecrypter, error) {
+	return nil, nil
+}
+
 type mockEvent struct {
-	err error
+	err    error
+	events []*spi.Event
 }
 
 func (m *mockEvent) Publish(topic string, messages ...*spi.Event) error {
@@ -483,10 +1143,12 @@ func (m *mockEvent) Publish(topic string, messages ...*spi.Event) error {
 		return m.err
 	}
 
+	m.events = append(m.events, messages...)
+
 	return nil
 }
 
-func newVPWithPD(t *testing.T, agent *context.Provider) (
+func newVPWithPD(t *testing.T, agent *ariescontext.Provider) (
 	*verifiable.Presentation, *presexch.PresentationDefinition, verifiable.PublicKeyFetcher, *ld.DocumentLoader) {
 	uri := randomURI()
 
@@ -553,7 +1215,7 @@ func newVC(ctx []string) *verifiable.Credential {
 }
 
 func newSignedJWTVC(t *testing.T,
-	agent *context.Provider, ctx []string) (*verifiable.Credential, verifiable.PublicKeyFetcher) {
+	agent *ariescontext.Provider, ctx []string) (*verifiable.Credential, verifiable.PublicKeyFetcher) {
 	t.Helper()
 
 	vc := newVC(ctx)
@@ -617,7 +1279,7 @@ func createTestDocumentLoader(t *testing.T, contextURL string, types ...string)
 	return loader
 }
 
-func newAgent(t *testing.T) *context.Provider {
+func newAgent(t *testing.T) *ariescontext.Provider {
 	t.Helper()
 
 	a, err := aries.New(aries.WithStoreProvider(mem.NewProvider()))
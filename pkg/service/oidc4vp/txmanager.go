@@ -20,8 +20,9 @@ import (
 )
 
 const (
-	nonceSize  = 10
-	maxRetries = 10
+	nonceSize        = 10
+	responseCodeSize = 32
+	maxRetries       = 10
 )
 
 type TxID string
@@ -31,6 +32,22 @@ type Transaction struct {
 	ProfileID              string
 	PresentationDefinition *presexch.PresentationDefinition
 	ReceivedClaims         *ReceivedClaims
+	ResponseMode           ResponseMode
+	// RedirectURI is relying party URI the wallet is redirected to in same-device flow.
+	RedirectURI string
+	// ResponseCode is appended to RedirectURI and must be presented by relying party to retrieve claims.
+	ResponseCode string
+	// MatchReport is a result of presentation submission match. Nil if authorization response is not received yet.
+	MatchReport *MatchReport
+	// AuthorizationRequest is the authorization request returned to the verifier when the interaction was initiated.
+	AuthorizationRequest string
+}
+
+// TxParams contains optional parameters of the transaction.
+type TxParams struct {
+	ResponseMode ResponseMode
+	RedirectURI  string
+	ResponseCode string
 }
 
 type ReceivedClaims struct {
@@ -40,12 +57,19 @@ type ReceivedClaims struct {
 type TransactionUpdate struct {
 	ID             TxID
 	ReceivedClaims *ReceivedClaims
+	MatchReport    *MatchReport
+	// AuthorizationRequest is stored if not empty.
+	AuthorizationRequest string
+	// ExpireAt is the time after which transaction with received claims is removed. Zero value means no expiration.
+	ExpireAt time.Time
 }
 
 type txStore interface {
-	Create(pd *presexch.PresentationDefinition, profileID string) (TxID, error)
+	Create(pd *presexch.PresentationDefinition, profileID string, params *TxParams) (TxID, error)
 	Update(update TransactionUpdate) error
 	Get(txID TxID) (*Transaction, error)
+	Delete(txID TxID) error
+	GetAndDelete(txID TxID) (*Transaction, error)
 }
 
 type txNonceStore interface {
@@ -69,9 +93,25 @@ func NewTxManager(store txNonceStore, txStore txStore, interactionLiveTime time.
 	}
 }
 
-// CreateTx creates transaction and generate one time access token.
-func (tm *TxManager) CreateTx(pd *presexch.PresentationDefinition, profileID string) (*Transaction, string, error) {
-	txID, err := tm.txStore.Create(pd, profileID)
+// CreateTx creates transaction and generate one time access token. If redirect uri is set in params,
+// response code for same-device flow is generated as well.
+func (tm *TxManager) CreateTx(pd *presexch.PresentationDefinition, profileID string,
+	params *TxParams) (*Transaction, string, error) {
+	txParams := TxParams{}
+	if params != nil {
+		txParams = *params
+	}
+
+	if txParams.RedirectURI != "" {
+		responseCode, err := genResponseCode()
+		if err != nil {
+			return nil, "", fmt.Errorf("oidc tx response code create failed: %w", err)
+		}
+
+		txParams.ResponseCode = responseCode
+	}
+
+	txID, err := tm.txStore.Create(pd, profileID, &txParams)
 	if err != nil {
 		return nil, "", fmt.Errorf("oidc tx create failed: %w", err)
 	}
@@ -89,8 +129,55 @@ func (tm *TxManager) CreateTx(pd *presexch.PresentationDefinition, profileID str
 	return tx, nonce, nil
 }
 
-func (tm *TxManager) StoreReceivedClaims(txID TxID, claims *ReceivedClaims) error {
-	return tm.txStore.Update(TransactionUpdate{ID: txID, ReceivedClaims: claims})
+// StoreReceivedClaims stores claims received from the wallet. If claimsTTL is positive, transaction
+// is removed after the given period.
+func (tm *TxManager) StoreReceivedClaims(txID TxID, claims *ReceivedClaims, claimsTTL time.Duration) error {
+	update := TransactionUpdate{ID: txID, ReceivedClaims: claims}
+
+	if claimsTTL > 0 {
+		update.ExpireAt = time.Now().UTC().Add(claimsTTL)
+	}
+
+	return tm.txStore.Update(update)
+}
+
+// StoreMatchReport stores result of presentation submission match.
+func (tm *TxManager) StoreMatchReport(txID TxID, report *MatchReport) error {
+	return tm.txStore.Update(TransactionUpdate{ID: txID, MatchReport: report})
+}
+
+// StoreAuthorizationRequest stores authorization request passed to the wallet.
+func (tm *TxManager) StoreAuthorizationRequest(txID TxID, authorizationRequest string) error {
+	return tm.txStore.Update(TransactionUpdate{ID: txID, AuthorizationRequest: authorizationRequest})
+}
+
+// Delete deletes transaction together with received claims.
+func (tm *TxManager) Delete(txID TxID) error {
+	err := tm.txStore.Delete(txID)
+	if errors.Is(err, ErrDataNotFound) {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("oidc delete tx by id failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetAndDelete deletes transaction together with received claims and returns the deleted transaction.
+// ErrDataNotFound is returned if the transaction is already deleted.
+func (tm *TxManager) GetAndDelete(txID TxID) (*Transaction, error) {
+	tx, err := tm.txStore.GetAndDelete(txID)
+	if errors.Is(err, ErrDataNotFound) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc get and delete tx by id failed: %w", err)
+	}
+
+	return tx, nil
 }
 
 // Get transaction id.
@@ -154,3 +241,14 @@ func genNonce() (string, error) {
 
 	return base64.URLEncoding.EncodeToString(nonceBytes), nil
 }
+
+func genResponseCode() (string, error) {
+	codeBytes := make([]byte, responseCodeSize)
+
+	_, err := rand.Read(codeBytes)
+	if err != nil {
+		return "", fmt.Errorf("response code generating random failed: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(codeBytes), nil
+}
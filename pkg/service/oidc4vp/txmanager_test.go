@@ -21,7 +21,7 @@ import (
 func TestTxManager_CreateTx(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		store := NewMockTxStore(gomock.NewController(t))
-		store.EXPECT().Create(gomock.Any(), gomock.Any()).Return(oidc4vp.TxID("txID"), nil)
+		store.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(oidc4vp.TxID("txID"), nil)
 		store.EXPECT().Get(oidc4vp.TxID("txID")).Return(&oidc4vp.Transaction{ID: "txID", ProfileID: "org_id"}, nil)
 
 		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
@@ -29,7 +29,7 @@ func TestTxManager_CreateTx(t *testing.T) {
 
 		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
 
-		tx, nonce, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id")
+		tx, nonce, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id", nil)
 
 		require.NoError(t, err)
 		require.NotEmpty(t, nonce)
@@ -37,22 +37,46 @@ func TestTxManager_CreateTx(t *testing.T) {
 		require.Equal(t, "org_id", tx.ProfileID)
 	})
 
+	t.Run("Success same-device", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(pd *presexch.PresentationDefinition, profileID string, params *oidc4vp.TxParams) (oidc4vp.TxID, error) {
+				require.Equal(t, "https://rp.example.com/cb", params.RedirectURI)
+				require.NotEmpty(t, params.ResponseCode)
+
+				return "txID", nil
+			})
+		store.EXPECT().Get(oidc4vp.TxID("txID")).Return(&oidc4vp.Transaction{ID: "txID", ProfileID: "org_id"}, nil)
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+		nonceStore.EXPECT().SetIfNotExist(gomock.Any(), oidc4vp.TxID("txID"), 100*time.Second).Times(1).Return(true, nil)
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		tx, _, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id",
+			&oidc4vp.TxParams{RedirectURI: "https://rp.example.com/cb"})
+
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+	})
+
 	t.Run("Fail", func(t *testing.T) {
 		store := NewMockTxStore(gomock.NewController(t))
-		store.EXPECT().Create(gomock.Any(), gomock.Any()).Return(oidc4vp.TxID(""), errors.New("test error"))
+		store.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(oidc4vp.TxID(""), errors.New("test error"))
 
 		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
 
 		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
 
-		_, _, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id")
+		_, _, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id", nil)
 
 		require.Contains(t, err.Error(), "test error")
 	})
 
 	t.Run("Fail", func(t *testing.T) {
 		store := NewMockTxStore(gomock.NewController(t))
-		store.EXPECT().Create(gomock.Any(), gomock.Any()).Return(oidc4vp.TxID("txID"), nil)
+		store.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(oidc4vp.TxID("txID"), nil)
 
 		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
 		nonceStore.EXPECT().SetIfNotExist(gomock.Any(), oidc4vp.TxID("txID"), 100*time.Second).
@@ -60,14 +84,14 @@ func TestTxManager_CreateTx(t *testing.T) {
 
 		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
 
-		_, _, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id")
+		_, _, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id", nil)
 
 		require.Contains(t, err.Error(), "test error")
 	})
 
 	t.Run("Fail", func(t *testing.T) {
 		store := NewMockTxStore(gomock.NewController(t))
-		store.EXPECT().Create(gomock.Any(), gomock.Any()).Return(oidc4vp.TxID("txID"), nil)
+		store.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(oidc4vp.TxID("txID"), nil)
 		store.EXPECT().Get(oidc4vp.TxID("txID")).Return(nil, errors.New("test error"))
 
 		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
@@ -75,7 +99,7 @@ func TestTxManager_CreateTx(t *testing.T) {
 
 		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
 
-		_, _, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id")
+		_, _, err := manager.CreateTx(&presexch.PresentationDefinition{}, "org_id", nil)
 
 		require.Contains(t, err.Error(), "test error")
 	})
@@ -181,8 +205,133 @@ func TestTxManagerStoreReceivedClaims(t *testing.T) {
 
 		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
 
-		err := manager.StoreReceivedClaims("txID", &oidc4vp.ReceivedClaims{})
+		err := manager.StoreReceivedClaims("txID", &oidc4vp.ReceivedClaims{}, 0)
 
 		require.NoError(t, err)
 	})
+
+	t.Run("Success with TTL", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().Update(gomock.Any()).DoAndReturn(func(update oidc4vp.TransactionUpdate) error {
+			require.True(t, update.ExpireAt.After(time.Now()))
+
+			return nil
+		})
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		err := manager.StoreReceivedClaims("txID", &oidc4vp.ReceivedClaims{}, time.Minute)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestTxManagerStoreMatchReport(t *testing.T) {
+	report := &oidc4vp.MatchReport{Matched: true}
+
+	store := NewMockTxStore(gomock.NewController(t))
+	store.EXPECT().Update(gomock.Any()).DoAndReturn(func(update oidc4vp.TransactionUpdate) error {
+		require.Equal(t, oidc4vp.TxID("txID"), update.ID)
+		require.Equal(t, report, update.MatchReport)
+		require.Nil(t, update.ReceivedClaims)
+
+		return nil
+	})
+
+	nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+	manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+	require.NoError(t, manager.StoreMatchReport("txID", report))
+}
+
+func TestTxManagerStoreAuthorizationRequest(t *testing.T) {
+	store := NewMockTxStore(gomock.NewController(t))
+	store.EXPECT().Update(oidc4vp.TransactionUpdate{
+		ID:                   "txID",
+		AuthorizationRequest: "openid-vc://?request_uri=https://vcs.example.com/request-object/1",
+	}).Return(nil)
+
+	nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+	manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+	require.NoError(t, manager.StoreAuthorizationRequest("txID",
+		"openid-vc://?request_uri=https://vcs.example.com/request-object/1"))
+}
+
+func TestTxManagerDelete(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().Delete(oidc4vp.TxID("txID")).Return(nil)
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		require.NoError(t, manager.Delete("txID"))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().Delete(oidc4vp.TxID("txID")).Return(oidc4vp.ErrDataNotFound)
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		require.ErrorIs(t, manager.Delete("txID"), oidc4vp.ErrDataNotFound)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().Delete(oidc4vp.TxID("txID")).Return(errors.New("delete error"))
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		require.ErrorContains(t, manager.Delete("txID"), "delete error")
+	})
+}
+
+func TestTxManagerGetAndDelete(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().GetAndDelete(oidc4vp.TxID("txID")).Return(&oidc4vp.Transaction{ID: "txID"}, nil)
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		tx, err := manager.GetAndDelete("txID")
+		require.NoError(t, err)
+		require.Equal(t, oidc4vp.TxID("txID"), tx.ID)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().GetAndDelete(oidc4vp.TxID("txID")).Return(nil, oidc4vp.ErrDataNotFound)
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		_, err := manager.GetAndDelete("txID")
+		require.ErrorIs(t, err, oidc4vp.ErrDataNotFound)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		store := NewMockTxStore(gomock.NewController(t))
+		store.EXPECT().GetAndDelete(oidc4vp.TxID("txID")).Return(nil, errors.New("get and delete error"))
+
+		nonceStore := NewMockTxNonceStore(gomock.NewController(t))
+
+		manager := oidc4vp.NewTxManager(nonceStore, store, 100*time.Second)
+
+		_, err := manager.GetAndDelete("txID")
+		require.ErrorContains(t, err, "get and delete error")
+	})
 }
@@ -19,6 +19,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 )
 
 const (
@@ -61,8 +63,8 @@ type identityHubRequestMeta struct {
 	payload  []byte
 }
 
-func (s *Service) resolveDIDRelativeURL(didRelativeURL string) ([]byte, error) {
-	didDoc, err := s.resolveDID(didRelativeURL)
+func (s *Service) resolveDIDRelativeURL(ctx context.Context, didRelativeURL string) ([]byte, error) {
+	didDoc, err := s.resolveDID(ctx, didRelativeURL)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +85,7 @@ func (s *Service) resolveDIDRelativeURL(didRelativeURL string) ([]byte, error) {
 	}
 
 	req, err := http.NewRequestWithContext(
-		context.Background(), http.MethodGet, serviceEndpoint, bytes.NewReader(requestMeta.payload))
+		ctx, http.MethodGet, serviceEndpoint, bytes.NewReader(requestMeta.payload))
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request to identity hub: %w", err)
 	}
@@ -135,9 +137,9 @@ func (s *Service) getIdentityHubServiceEndpoint(did *did.Doc) (string, error) {
 	return "", errors.New("no identity hub service supplied")
 }
 
-func (s *Service) resolveDID(didRelativeURL string) (*did.Doc, error) {
+func (s *Service) resolveDID(ctx context.Context, didRelativeURL string) (*did.Doc, error) {
 	didID := strings.Split(didRelativeURL, "?")[0]
-	didResolution, err := s.vdr.Resolve(didID)
+	didResolution, err := tracing.WithContext(ctx, s.vdr).Resolve(didID)
 	if err != nil {
 		return nil, fmt.Errorf("unable to resolve did url: %w", err)
 	}
@@ -8,6 +8,7 @@ package revocation
 
 import (
 	"bytes"
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	_ "embed"
@@ -221,7 +222,7 @@ func TestService_resolveDID(t *testing.T) {
 	s := &Service{
 		vdr: vdrResolver,
 	}
-	didDoc, err := s.resolveDID(didRelativeURL)
+	didDoc, err := s.resolveDID(context.Background(), didRelativeURL)
 	require.NoError(t, err)
 	require.Equal(t, didDoc.ID, createDIDDoc(didID).ID)
 
@@ -230,7 +231,7 @@ func TestService_resolveDID(t *testing.T) {
 			ResolveErr: errors.New("some error"),
 		},
 	}
-	didDoc, err = s.resolveDID(didRelativeURL)
+	didDoc, err = s.resolveDID(context.Background(), didRelativeURL)
 	require.Error(t, err)
 	require.Nil(t, didDoc)
 }
@@ -426,7 +427,7 @@ func TestService_resolveDIDRelativeUrl(t *testing.T) {
 				vdr:        tt.fields.getVDR(),
 				httpClient: tt.fields.getHTTPClient(),
 			}
-			got, err := s.resolveDIDRelativeURL(tt.args.didRelativeURL)
+			got, err := s.resolveDIDRelativeURL(context.Background(), tt.args.didRelativeURL)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("resolveDIDRelativeURL() error = %v, wantErr %v", err, tt.wantErr)
 				return
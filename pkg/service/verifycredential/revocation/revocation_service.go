@@ -19,6 +19,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 
 	"github.com/trustbloc/vcs/internal/pkg/log"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 )
 
 var logger = log.New("vcs-revocation-service")
@@ -57,21 +58,21 @@ func New(config *Config) *Service {
 
 // GetRevocationVC returns revocation VC identified by statusURI.
 // statusURI might be either HTTP URL or DID URL.
-func (s *Service) GetRevocationVC(statusURI string) (*verifiable.Credential, error) {
+func (s *Service) GetRevocationVC(ctx context.Context, statusURI string) (*verifiable.Credential, error) {
 	var vcBytes []byte
 	var err error
 	switch {
 	case strings.HasPrefix(statusURI, "did:"):
-		vcBytes, err = s.resolveDIDRelativeURL(statusURI)
+		vcBytes, err = s.resolveDIDRelativeURL(ctx, statusURI)
 	default:
-		vcBytes, err = s.resolveHTTPUrl(statusURI)
+		vcBytes, err = s.resolveHTTPUrl(ctx, statusURI)
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to resolve revocation VC URI: %w", err)
 	}
 
-	revocationListVC, err := s.parseAndVerifyVC(vcBytes)
+	revocationListVC, err := s.parseAndVerifyVC(ctx, vcBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse and verify status vc: %w", err)
 	}
@@ -79,8 +80,8 @@ func (s *Service) GetRevocationVC(statusURI string) (*verifiable.Credential, err
 	return revocationListVC, nil
 }
 
-func (s *Service) resolveHTTPUrl(url string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+func (s *Service) resolveHTTPUrl(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -93,11 +94,11 @@ func (s *Service) resolveHTTPUrl(url string) ([]byte, error) {
 	return resp, nil
 }
 
-func (s *Service) parseAndVerifyVC(vcBytes []byte) (*verifiable.Credential, error) {
+func (s *Service) parseAndVerifyVC(ctx context.Context, vcBytes []byte) (*verifiable.Credential, error) {
 	return verifiable.ParseCredential(
 		vcBytes,
 		verifiable.WithPublicKeyFetcher(
-			verifiable.NewVDRKeyResolver(s.vdr).PublicKeyFetcher(),
+			verifiable.NewVDRKeyResolver(tracing.WithContext(ctx, s.vdr)).PublicKeyFetcher(),
 		),
 		verifiable.WithJSONLDDocumentLoader(s.documentLoader),
 	)
@@ -116,13 +117,14 @@ func (s *Service) sendHTTPRequest(req *http.Request, status int, token string) (
 	defer func() {
 		err = resp.Body.Close()
 		if err != nil {
-			logger.Warn("failed to close response body")
+			logger.WithContext(req.Context()).Warn("failed to close response body")
 		}
 	}()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		logger.Warn("Unable to read response", log.WithHTTPStatus(resp.StatusCode), log.WithError(err))
+		logger.WithContext(req.Context()).Warn("Unable to read response",
+			log.WithHTTPStatus(resp.StatusCode), log.WithError(err))
 	}
 
 	if resp.StatusCode != status {
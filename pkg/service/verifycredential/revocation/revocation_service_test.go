@@ -8,6 +8,7 @@ package revocation
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	_ "embed"
@@ -217,7 +218,7 @@ func TestService_GetRevocationListVC(t *testing.T) {
 					cslRequestTokenName: "abc",
 				},
 			}
-			got, err := s.GetRevocationVC(tt.args.statusURL)
+			got, err := s.GetRevocationVC(context.Background(), tt.args.statusURL)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetRevocationListVC() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -4,11 +4,12 @@ Copyright SecureKey Technologies Inc. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-//go:generate mockgen -destination service_mocks_test.go -self_package mocks -package verifycredential -source=verifycredential_service.go -mock_names revocationVCGetter=MockRevocationVCGetter
+//go:generate mockgen -destination service_mocks_test.go -self_package mocks -package verifycredential -source=verifycredential_service.go -mock_names revocationVCGetter=MockRevocationVCGetter,metricsProvider=MockMetricsProvider
 
 package verifycredential
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +22,8 @@ import (
 	"github.com/trustbloc/vcs/pkg/doc/vc/crypto"
 	"github.com/trustbloc/vcs/pkg/internal/common/diddoc"
 	"github.com/trustbloc/vcs/pkg/internal/common/utils"
+	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 	"github.com/trustbloc/vcs/pkg/service/credentialstatus"
 )
@@ -30,7 +33,7 @@ const (
 )
 
 type revocationVCGetter interface {
-	GetRevocationVC(statusURL string) (*verifiable.Credential, error)
+	GetRevocationVC(ctx context.Context, statusURL string) (*verifiable.Credential, error)
 }
 
 // CredentialsVerificationCheckResult resp containing failure check details.
@@ -49,27 +52,40 @@ type Options struct {
 	Domain string
 }
 
+type metricsProvider interface {
+	VerificationCheck(profileID, check string, success bool)
+}
+
 type Config struct {
 	RevocationVCGetter revocationVCGetter
 	DocumentLoader     ld.DocumentLoader
 	VDR                vdrapi.Registry
+	Metrics            metricsProvider
 }
 
 type Service struct {
 	revocationVCGetter revocationVCGetter
 	documentLoader     ld.DocumentLoader
 	vdr                vdrapi.Registry
+	metrics            metricsProvider
 }
 
 func New(config *Config) *Service {
+	metrics := config.Metrics
+
+	if metrics == nil {
+		metrics = &noopMetricsProvider.NoMetrics{}
+	}
+
 	return &Service{
 		revocationVCGetter: config.RevocationVCGetter,
 		documentLoader:     config.DocumentLoader,
 		vdr:                config.VDR,
+		metrics:            metrics,
 	}
 }
 
-func (s *Service) VerifyCredential(credential *verifiable.Credential, opts *Options,
+func (s *Service) VerifyCredential(ctx context.Context, credential *verifiable.Credential, opts *Options,
 	profile *profileapi.Verifier) ([]CredentialsVerificationCheckResult, error) {
 	checks := profile.Checks.Credential
 
@@ -81,7 +97,10 @@ func (s *Service) VerifyCredential(credential *verifiable.Credential, opts *Opti
 			return nil, fmt.Errorf("unexpected error on credential marshal: %w", err)
 		}
 
-		err = s.ValidateCredentialProof(vcBytes, opts.Challenge, opts.Domain, false, credential.JWT != "")
+		err = s.ValidateCredentialProof(ctx, vcBytes, opts.Challenge, opts.Domain, false, credential.JWT != "")
+
+		s.metrics.VerificationCheck(profile.ID, "proof", err == nil)
+
 		if err != nil {
 			result = append(result, CredentialsVerificationCheckResult{
 				Check: "proof",
@@ -90,7 +109,10 @@ func (s *Service) VerifyCredential(credential *verifiable.Credential, opts *Opti
 		}
 	}
 	if checks.Status {
-		err := s.ValidateVCStatus(credential.Status, credential.Issuer.ID)
+		err := s.ValidateVCStatus(ctx, credential.Status, credential.Issuer.ID)
+
+		s.metrics.VerificationCheck(profile.ID, "credentialStatus", err == nil)
+
 		if err != nil {
 			result = append(result, CredentialsVerificationCheckResult{
 				Check: "credentialStatus",
@@ -102,11 +124,12 @@ func (s *Service) VerifyCredential(credential *verifiable.Credential, opts *Opti
 	return result, nil
 }
 
-func (s *Service) parseAndVerifyVC(vcBytes []byte, isJWT bool) (*verifiable.Credential, error) {
+func (s *Service) parseAndVerifyVC(ctx context.Context, vcBytes []byte,
+	isJWT bool) (*verifiable.Credential, error) {
 	opts := make([]verifiable.CredentialOpt, 0)
 
 	opts = append(opts, verifiable.WithPublicKeyFetcher(
-		verifiable.NewVDRKeyResolver(s.vdr).PublicKeyFetcher(),
+		verifiable.NewVDRKeyResolver(tracing.WithContext(ctx, s.vdr)).PublicKeyFetcher(),
 	),
 		verifiable.WithJSONLDDocumentLoader(s.documentLoader))
 
@@ -122,9 +145,9 @@ func (s *Service) parseAndVerifyVC(vcBytes []byte, isJWT bool) (*verifiable.Cred
 }
 
 // ValidateCredentialProof validate credential proof.
-func (s *Service) ValidateCredentialProof(vcByte []byte, proofChallenge, proofDomain string, vcInVPValidation,
-	isJWT bool) error { // nolint: lll,gocyclo
-	credential, err := s.parseAndVerifyVC(vcByte, isJWT)
+func (s *Service) ValidateCredentialProof(ctx context.Context, vcByte []byte, proofChallenge, proofDomain string,
+	vcInVPValidation, isJWT bool) error { // nolint: lll,gocyclo
+	credential, err := s.parseAndVerifyVC(ctx, vcByte, isJWT)
 	if err != nil {
 		return fmt.Errorf("verifiable credential proof validation error : %w", err)
 	}
@@ -159,7 +182,7 @@ func (s *Service) ValidateCredentialProof(vcByte []byte, proofChallenge, proofDo
 	}
 
 	// get the did doc from verification method
-	didDoc, err := diddoc.GetDIDDocFromVerificationMethod(verificationMethod, s.vdr)
+	didDoc, err := diddoc.GetDIDDocFromVerificationMethod(verificationMethod, tracing.WithContext(ctx, s.vdr))
 	if err != nil {
 		return err
 	}
@@ -177,7 +200,7 @@ func (s *Service) ValidateCredentialProof(vcByte []byte, proofChallenge, proofDo
 	return nil
 }
 
-func (s *Service) ValidateVCStatus(vcStatus *verifiable.TypedID, issuer string) error {
+func (s *Service) ValidateVCStatus(ctx context.Context, vcStatus *verifiable.TypedID, issuer string) error {
 	// validate vc status
 	if err := s.validateVCStatus(vcStatus); err != nil {
 		return err
@@ -188,7 +211,7 @@ func (s *Service) ValidateVCStatus(vcStatus *verifiable.TypedID, issuer string)
 		return err
 	}
 
-	revocationVC, err := s.revocationVCGetter.GetRevocationVC(
+	revocationVC, err := s.revocationVCGetter.GetRevocationVC(ctx,
 		vcStatus.CustomFields[credentialstatus.StatusListCredential].(string))
 	if err != nil {
 		return err
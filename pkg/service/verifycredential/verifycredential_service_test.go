@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifycredential
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"testing"
@@ -59,19 +60,20 @@ func TestService_VerifyCredential(t *testing.T) {
 		t.Parallel()
 		loader := testutil.DocumentLoader(t)
 		mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-		mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-			Subject: []verifiable.Subject{{
-				ID: "",
-				CustomFields: map[string]interface{}{
-					"statusListIndex": "1",
-					"statusPurpose":   "2",
-					"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+		mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+			Return(&verifiable.Credential{
+				Subject: []verifiable.Subject{{
+					ID: "",
+					CustomFields: map[string]interface{}{
+						"statusListIndex": "1",
+						"statusPurpose":   "2",
+						"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+					},
+				}},
+				Issuer: verifiable.Issuer{
+					ID: "did:trustblock:abc",
 				},
-			}},
-			Issuer: verifiable.Issuer{
-				ID: "did:trustblock:abc",
-			},
-		}, nil)
+			}, nil)
 
 		tests := []struct {
 			name string
@@ -138,7 +140,7 @@ func TestService_VerifyCredential(t *testing.T) {
 									DocumentLoader:     loader,
 								})
 
-								res, err := op.VerifyCredential(vc, &Options{
+								res, err := op.VerifyCredential(context.Background(), vc, &Options{
 									Challenge: crypto.Challenge,
 									Domain:    crypto.Domain,
 								}, testProfile)
@@ -166,28 +168,34 @@ func TestService_VerifyCredential(t *testing.T) {
 
 		t.Run("Proof", func(t *testing.T) {
 			mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-			mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-				Subject: []verifiable.Subject{{
-					ID: "",
-					CustomFields: map[string]interface{}{
-						"statusListIndex": "1",
-						"statusPurpose":   "2",
-						"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+			mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+				Return(&verifiable.Credential{
+					Subject: []verifiable.Subject{{
+						ID: "",
+						CustomFields: map[string]interface{}{
+							"statusListIndex": "1",
+							"statusPurpose":   "2",
+							"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+						},
+					}},
+					Issuer: verifiable.Issuer{
+						ID: "did:trustblock:abc",
 					},
-				}},
-				Issuer: verifiable.Issuer{
-					ID: "did:trustblock:abc",
-				},
-			}, nil)
+				}, nil)
+			metrics := NewMockMetricsProvider(gomock.NewController(t))
+			metrics.EXPECT().VerificationCheck(testProfile.ID, "proof", false).Times(1)
+			metrics.EXPECT().VerificationCheck(testProfile.ID, "credentialStatus", true).Times(1)
+
 			service := New(&Config{
 				RevocationVCGetter: mockRevocationVCGetter,
 				VDR:                mockVDRRegistry,
 				DocumentLoader:     loader,
+				Metrics:            metrics,
 			})
 
 			var res []CredentialsVerificationCheckResult
 
-			res, err = service.VerifyCredential(vc, &Options{
+			res, err = service.VerifyCredential(context.Background(), vc, &Options{
 				Challenge: crypto.Challenge,
 				Domain:    crypto.Domain,
 			}, testProfile)
@@ -199,25 +207,26 @@ func TestService_VerifyCredential(t *testing.T) {
 		t.Run("Proof and Status", func(t *testing.T) {
 			require.NoError(t, err)
 			failedRevocationListGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-			failedRevocationListGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-				Subject: []verifiable.Subject{{
-					ID: "",
-					CustomFields: map[string]interface{}{
-						"statusListIndex": "1",
-						"statusPurpose":   "2",
-						"encodedList":     "H4sIAAAAAAAA_2ICBAAA__-hjgw8AQAAAA",
+			failedRevocationListGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+				Return(&verifiable.Credential{
+					Subject: []verifiable.Subject{{
+						ID: "",
+						CustomFields: map[string]interface{}{
+							"statusListIndex": "1",
+							"statusPurpose":   "2",
+							"encodedList":     "H4sIAAAAAAAA_2ICBAAA__-hjgw8AQAAAA",
+						},
+					}},
+					Issuer: verifiable.Issuer{
+						ID: "did:trustblock:abc",
 					},
-				}},
-				Issuer: verifiable.Issuer{
-					ID: "did:trustblock:abc",
-				},
-			}, nil)
+				}, nil)
 			service := New(&Config{
 				RevocationVCGetter: failedRevocationListGetter,
 				VDR:                mockVDRRegistry,
 				DocumentLoader:     loader,
 			})
-			res, err := service.VerifyCredential(vc, &Options{
+			res, err := service.VerifyCredential(context.Background(), vc, &Options{
 				Challenge: crypto.Challenge,
 				Domain:    crypto.Domain,
 			}, testProfile)
@@ -257,19 +266,20 @@ func TestService_checkVCStatus(t *testing.T) {
 			fields: fields{
 				getRevocationVCGetter: func() revocationVCGetter {
 					mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-						Subject: []verifiable.Subject{{
-							ID: "",
-							CustomFields: map[string]interface{}{
-								"statusListIndex": "1",
-								"statusPurpose":   "2",
-								"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+						Return(&verifiable.Credential{
+							Subject: []verifiable.Subject{{
+								ID: "",
+								CustomFields: map[string]interface{}{
+									"statusListIndex": "1",
+									"statusPurpose":   "2",
+									"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+								},
+							}},
+							Issuer: verifiable.Issuer{
+								ID: "did:trustblock:abc",
 							},
-						}},
-						Issuer: verifiable.Issuer{
-							ID: "did:trustblock:abc",
-						},
-					}, nil)
+						}, nil)
 
 					return mockRevocationVCGetter
 				},
@@ -324,8 +334,9 @@ func TestService_checkVCStatus(t *testing.T) {
 			fields: fields{
 				getRevocationVCGetter: func() revocationVCGetter {
 					mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(
-						nil, errors.New("some error"))
+					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+						Return(
+							nil, errors.New("some error"))
 					return mockRevocationVCGetter
 				},
 			},
@@ -342,12 +353,13 @@ func TestService_checkVCStatus(t *testing.T) {
 			fields: fields{
 				getRevocationVCGetter: func() revocationVCGetter {
 					mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-						Subject: []verifiable.Subject{},
-						Issuer: verifiable.Issuer{
-							ID: "did:trustblock:123",
-						},
-					}, nil)
+					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+						Return(&verifiable.Credential{
+							Subject: []verifiable.Subject{},
+							Issuer: verifiable.Issuer{
+								ID: "did:trustblock:123",
+							},
+						}, nil)
 
 					return mockRevocationVCGetter
 				},
@@ -365,12 +377,13 @@ func TestService_checkVCStatus(t *testing.T) {
 			fields: fields{
 				getRevocationVCGetter: func() revocationVCGetter {
 					mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-						Subject: verifiable.Subject{},
-						Issuer: verifiable.Issuer{
-							ID: "did:trustblock:abc",
-						},
-					}, nil)
+					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+						Return(&verifiable.Credential{
+							Subject: verifiable.Subject{},
+							Issuer: verifiable.Issuer{
+								ID: "did:trustblock:abc",
+							},
+						}, nil)
 
 					return mockRevocationVCGetter
 				},
@@ -388,19 +401,20 @@ func TestService_checkVCStatus(t *testing.T) {
 			fields: fields{
 				getRevocationVCGetter: func() revocationVCGetter {
 					mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-						Subject: []verifiable.Subject{{
-							ID: "",
-							CustomFields: map[string]interface{}{
-								"statusListIndex": "1",
-								"statusPurpose":   "abc",
-								"encodedList":     "",
+					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+						Return(&verifiable.Credential{
+							Subject: []verifiable.Subject{{
+								ID: "",
+								CustomFields: map[string]interface{}{
+									"statusListIndex": "1",
+									"statusPurpose":   "abc",
+									"encodedList":     "",
+								},
+							}},
+							Issuer: verifiable.Issuer{
+								ID: "did:trustblock:abc",
 							},
-						}},
-						Issuer: verifiable.Issuer{
-							ID: "did:trustblock:abc",
-						},
-					}, nil)
+						}, nil)
 
 					return mockRevocationVCGetter
 				},
@@ -418,19 +432,20 @@ func TestService_checkVCStatus(t *testing.T) {
 			fields: fields{
 				getRevocationVCGetter: func() revocationVCGetter {
 					mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-						Subject: []verifiable.Subject{{
-							ID: "",
-							CustomFields: map[string]interface{}{
-								"statusListIndex": "1",
-								"statusPurpose":   "2",
-								"encodedList":     "",
+					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+						Return(&verifiable.Credential{
+							Subject: []verifiable.Subject{{
+								ID: "",
+								CustomFields: map[string]interface{}{
+									"statusListIndex": "1",
+									"statusPurpose":   "2",
+									"encodedList":     "",
+								},
+							}},
+							Issuer: verifiable.Issuer{
+								ID: "did:trustblock:abc",
 							},
-						}},
-						Issuer: verifiable.Issuer{
-							ID: "did:trustblock:abc",
-						},
-					}, nil)
+						}, nil)
 
 					return mockRevocationVCGetter
 				},
@@ -448,19 +463,20 @@ func TestService_checkVCStatus(t *testing.T) {
 			fields: fields{
 				getRevocationVCGetter: func() revocationVCGetter {
 					mockRevocationVCGetter := NewMockRevocationVCGetter(gomock.NewController(t))
-					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any()).AnyTimes().Return(&verifiable.Credential{
-						Subject: []verifiable.Subject{{
-							ID: "",
-							CustomFields: map[string]interface{}{
-								"statusListIndex": "1",
-								"statusPurpose":   "2",
-								"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+					mockRevocationVCGetter.EXPECT().GetRevocationVC(gomock.Any(), gomock.Any()).AnyTimes().
+						Return(&verifiable.Credential{
+							Subject: []verifiable.Subject{{
+								ID: "",
+								CustomFields: map[string]interface{}{
+									"statusListIndex": "1",
+									"statusPurpose":   "2",
+									"encodedList":     "H4sIAAAAAAAA_2IABAAA__-N7wLSAQAAAA",
+								},
+							}},
+							Issuer: verifiable.Issuer{
+								ID: "did:trustblock:abc",
 							},
-						}},
-						Issuer: verifiable.Issuer{
-							ID: "did:trustblock:abc",
-						},
-					}, nil)
+						}, nil)
 
 					return mockRevocationVCGetter
 				},
@@ -488,7 +504,7 @@ func TestService_checkVCStatus(t *testing.T) {
 			s := &Service{
 				revocationVCGetter: tt.fields.getRevocationVCGetter(),
 			}
-			err := s.ValidateVCStatus(tt.args.getVcStatus(), tt.args.issuer)
+			err := s.ValidateVCStatus(context.Background(), tt.args.getVcStatus(), tt.args.issuer)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ValidateVCStatus() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -637,7 +653,7 @@ func TestService_ValidateCredentialProof(t *testing.T) {
 				documentLoader: loader,
 				vdr:            vdr,
 			}
-			if err := s.ValidateCredentialProof(
+			if err := s.ValidateCredentialProof(context.Background(),
 				tt.args.getVcByte(),
 				tt.args.proofChallenge,
 				tt.args.proofDomain,
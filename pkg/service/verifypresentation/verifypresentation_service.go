@@ -4,11 +4,12 @@ Copyright SecureKey Technologies Inc. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-//go:generate mockgen -destination service_mocks_test.go -self_package mocks -package verifypresentation -source=verifypresentation_service.go -mock_names vcVerifier=MockVcVerifier
+//go:generate mockgen -destination service_mocks_test.go -self_package mocks -package verifypresentation -source=verifypresentation_service.go -mock_names vcVerifier=MockVcVerifier,metricsProvider=MockMetricsProvider
 
 package verifypresentation
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,24 +20,33 @@ import (
 
 	"github.com/trustbloc/vcs/pkg/doc/vc/crypto"
 	"github.com/trustbloc/vcs/pkg/internal/common/diddoc"
+	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
+	"github.com/trustbloc/vcs/pkg/observability/tracing"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 )
 
 type vcVerifier interface {
-	ValidateCredentialProof(vcByte []byte, proofChallenge, proofDomain string, vcInVPValidation, isJWT bool) error
-	ValidateVCStatus(vcStatus *verifiable.TypedID, issuer string) error
+	ValidateCredentialProof(ctx context.Context, vcByte []byte, proofChallenge, proofDomain string,
+		vcInVPValidation, isJWT bool) error
+	ValidateVCStatus(ctx context.Context, vcStatus *verifiable.TypedID, issuer string) error
+}
+
+type metricsProvider interface {
+	VerificationCheck(profileID, check string, success bool)
 }
 
 type Config struct {
 	VDR            vdrapi.Registry
 	DocumentLoader ld.DocumentLoader
 	VcVerifier     vcVerifier
+	Metrics        metricsProvider
 }
 
 type Service struct {
 	vdr            vdrapi.Registry
 	documentLoader ld.DocumentLoader
 	vcVerifier     vcVerifier
+	metrics        metricsProvider
 }
 
 type Options struct {
@@ -51,14 +61,22 @@ type PresentationVerificationCheckResult struct {
 }
 
 func New(config *Config) *Service {
+	metrics := config.Metrics
+
+	if metrics == nil {
+		metrics = &noopMetricsProvider.NoMetrics{}
+	}
+
 	return &Service{
 		vdr:            config.VDR,
 		documentLoader: config.DocumentLoader,
 		vcVerifier:     config.VcVerifier,
+		metrics:        metrics,
 	}
 }
 
 func (s *Service) VerifyPresentation(
+	ctx context.Context,
 	presentation *verifiable.Presentation,
 	opts *Options,
 	profile *profileapi.Verifier) ([]PresentationVerificationCheckResult, error) {
@@ -75,7 +93,10 @@ func (s *Service) VerifyPresentation(
 			}
 		}
 
-		err = s.validatePresentationProof(vpBytes, opts)
+		err = s.validatePresentationProof(ctx, vpBytes, opts)
+
+		s.metrics.VerificationCheck(profile.ID, "proof", err == nil)
+
 		if err != nil {
 			result = append(result, PresentationVerificationCheckResult{
 				Check: "proof",
@@ -85,7 +106,10 @@ func (s *Service) VerifyPresentation(
 	}
 
 	if profile.Checks.Credential.Proof {
-		err := s.validateCredentialsProof(presentation)
+		err := s.validateCredentialsProof(ctx, presentation)
+
+		s.metrics.VerificationCheck(profile.ID, "credentialProof", err == nil)
+
 		if err != nil {
 			result = append(result, PresentationVerificationCheckResult{
 				Check: "credentialProof",
@@ -95,7 +119,10 @@ func (s *Service) VerifyPresentation(
 	}
 
 	if profile.Checks.Credential.Status {
-		err := s.validateCredentialsStatus(presentation)
+		err := s.validateCredentialsStatus(ctx, presentation)
+
+		s.metrics.VerificationCheck(profile.ID, "credentialStatus", err == nil)
+
 		if err != nil {
 			result = append(result, PresentationVerificationCheckResult{
 				Check: "credentialStatus",
@@ -107,11 +134,11 @@ func (s *Service) VerifyPresentation(
 	return result, nil
 }
 
-func (s *Service) validatePresentationProof(vpBytes []byte, opts *Options) error {
+func (s *Service) validatePresentationProof(ctx context.Context, vpBytes []byte, opts *Options) error {
 	vp, err := verifiable.ParsePresentation(
 		vpBytes,
 		verifiable.WithPresPublicKeyFetcher(
-			verifiable.NewVDRKeyResolver(s.vdr).PublicKeyFetcher(),
+			verifiable.NewVDRKeyResolver(tracing.WithContext(ctx, s.vdr)).PublicKeyFetcher(),
 		),
 		verifiable.WithPresJSONLDDocumentLoader(s.documentLoader),
 	)
@@ -119,12 +146,12 @@ func (s *Service) validatePresentationProof(vpBytes []byte, opts *Options) error
 		return fmt.Errorf("verifiable presentation proof validation error : %w", err)
 	}
 	if vp.JWT == "" {
-		return s.validateProofData(vp, opts)
+		return s.validateProofData(ctx, vp, opts)
 	}
 	return nil
 }
 
-func (s *Service) validateProofData(vp *verifiable.Presentation, opts *Options) error {
+func (s *Service) validateProofData(ctx context.Context, vp *verifiable.Presentation, opts *Options) error {
 	if opts == nil {
 		opts = &Options{}
 	}
@@ -153,7 +180,7 @@ func (s *Service) validateProofData(vp *verifiable.Presentation, opts *Options)
 	}
 
 	// get the did doc from verification method
-	didDoc, err := diddoc.GetDIDDocFromVerificationMethod(verificationMethod, s.vdr)
+	didDoc, err := diddoc.GetDIDDocFromVerificationMethod(verificationMethod, tracing.WithContext(ctx, s.vdr))
 	if err != nil {
 		return err
 	}
@@ -171,14 +198,14 @@ func (s *Service) validateProofData(vp *verifiable.Presentation, opts *Options)
 	return nil
 }
 
-func (s *Service) validateCredentialsProof(vp *verifiable.Presentation) error {
+func (s *Service) validateCredentialsProof(ctx context.Context, vp *verifiable.Presentation) error {
 	for _, cred := range vp.Credentials() {
 		vcBytes, err := json.Marshal(cred)
 		if err != nil {
 			return err
 		}
 
-		err = s.vcVerifier.ValidateCredentialProof(vcBytes, "", "", true, vp.JWT != "")
+		err = s.vcVerifier.ValidateCredentialProof(ctx, vcBytes, "", "", true, vp.JWT != "")
 		if err != nil {
 			return err
 		}
@@ -187,7 +214,7 @@ func (s *Service) validateCredentialsProof(vp *verifiable.Presentation) error {
 	return nil
 }
 
-func (s *Service) validateCredentialsStatus(vp *verifiable.Presentation) error {
+func (s *Service) validateCredentialsStatus(ctx context.Context, vp *verifiable.Presentation) error {
 	for _, cred := range vp.Credentials() {
 		vcBytes, err := json.Marshal(cred)
 		if err != nil {
@@ -201,7 +228,7 @@ func (s *Service) validateCredentialsStatus(vp *verifiable.Presentation) error {
 			return err
 		}
 
-		err = s.vcVerifier.ValidateVCStatus(vc.Status, vc.Issuer.ID)
+		err = s.vcVerifier.ValidateVCStatus(ctx, vc.Status, vc.Issuer.ID)
 		if err != nil {
 			return err
 		}
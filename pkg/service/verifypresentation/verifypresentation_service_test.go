@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifypresentation
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"reflect"
@@ -19,6 +20,7 @@ import (
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/trustbloc/vcs/pkg/doc/vc/crypto"
 	"github.com/trustbloc/vcs/pkg/internal/testutil"
+	noopMetricsProvider "github.com/trustbloc/vcs/pkg/observability/metrics/noop"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 )
 
@@ -49,6 +51,7 @@ func TestNew(t *testing.T) {
 				vdr:            &mockvdr.MockVDRegistry{},
 				documentLoader: testutil.DocumentLoader(t),
 				vcVerifier:     NewMockVcVerifier(gomock.NewController(t)),
+				metrics:        &noopMetricsProvider.NoMetrics{},
 			},
 		},
 	}
@@ -90,13 +93,13 @@ func TestService_VerifyPresentation(t *testing.T) {
 				},
 				getVcVerifier: func() vcVerifier {
 					mockVerifier := NewMockVcVerifier(gomock.NewController(t))
-					mockVerifier.EXPECT().ValidateCredentialProof(
+					mockVerifier.EXPECT().ValidateCredentialProof(gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any()).Times(1).Return(nil)
-					mockVerifier.EXPECT().ValidateVCStatus(
+					mockVerifier.EXPECT().ValidateVCStatus(gomock.Any(),
 						gomock.Any(),
 						gomock.Any()).Times(1).Return(nil)
 					return mockVerifier
@@ -246,13 +249,13 @@ func TestService_VerifyPresentation(t *testing.T) {
 				},
 				getVcVerifier: func() vcVerifier {
 					mockVerifier := NewMockVcVerifier(gomock.NewController(t))
-					mockVerifier.EXPECT().ValidateCredentialProof(
+					mockVerifier.EXPECT().ValidateCredentialProof(gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any()).Times(1).Return(errors.New("some error"))
-					mockVerifier.EXPECT().ValidateVCStatus(
+					mockVerifier.EXPECT().ValidateVCStatus(gomock.Any(),
 						gomock.Any(),
 						gomock.Any()).Times(1).Return(errors.New("some error"))
 					return mockVerifier
@@ -299,8 +302,10 @@ func TestService_VerifyPresentation(t *testing.T) {
 				vdr:            tt.fields.getVDR(),
 				documentLoader: loader,
 				vcVerifier:     tt.fields.getVcVerifier(),
+				metrics:        &noopMetricsProvider.NoMetrics{},
 			}
-			got, err := s.VerifyPresentation(tt.args.getPresentation(), tt.args.opts, tt.args.profile)
+			got, err := s.VerifyPresentation(context.Background(), tt.args.getPresentation(), tt.args.opts,
+				tt.args.profile)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("VerifyPresentation() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -312,6 +317,46 @@ func TestService_VerifyPresentation(t *testing.T) {
 	}
 }
 
+func TestService_VerifyPresentation_Metrics(t *testing.T) {
+	loader := testutil.DocumentLoader(t)
+	signedVP, vdr := testutil.SignedVP(
+		t, []byte(sampleVPJsonLD), kmskeytypes.ED25519Type, verifiable.SignatureProofValue, loader, crypto.AssertionMethod)
+
+	mockVerifier := NewMockVcVerifier(gomock.NewController(t))
+	mockVerifier.EXPECT().ValidateCredentialProof(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Return(errors.New("invalid proof"))
+	mockVerifier.EXPECT().ValidateVCStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	metrics := NewMockMetricsProvider(gomock.NewController(t))
+	metrics.EXPECT().VerificationCheck("profile1", "credentialProof", false).Times(1)
+	metrics.EXPECT().VerificationCheck("profile1", "credentialStatus", true).Times(1)
+
+	s := New(&Config{
+		VDR:            vdr,
+		DocumentLoader: loader,
+		VcVerifier:     mockVerifier,
+		Metrics:        metrics,
+	})
+
+	result, err := s.VerifyPresentation(context.Background(), signedVP, nil, &profileapi.Verifier{
+		ID: "profile1",
+		Checks: &profileapi.VerificationChecks{
+			Presentation: &profileapi.PresentationChecks{},
+			Credential: profileapi.CredentialChecks{
+				Proof:  true,
+				Status: true,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("VerifyPresentation() error = %v", err)
+	}
+
+	if len(result) != 1 || result[0].Check != "credentialProof" {
+		t.Errorf("VerifyPresentation() got = %v, want credentialProof check failure", result)
+	}
+}
+
 func TestService_validatePresentationProof(t *testing.T) {
 	loader := testutil.DocumentLoader(t)
 	signedVP, vdr := testutil.SignedVP(
@@ -394,7 +439,7 @@ func TestService_validatePresentationProof(t *testing.T) {
 				vdr:            tt.fields.getVDR(),
 				documentLoader: loader,
 			}
-			err := s.validatePresentationProof(
+			err := s.validatePresentationProof(context.Background(),
 				tt.args.getVpBytes(),
 				tt.args.getOpts(),
 			)
@@ -619,7 +664,8 @@ func TestService_validateProofData(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &Service{vdr: tt.fields.vdr}
-			if err := s.validateProofData(tt.args.getVP(), tt.args.opts); (err != nil) != tt.wantErr {
+			err := s.validateProofData(context.Background(), tt.args.getVP(), tt.args.opts)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("validateProofData() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -652,7 +698,7 @@ func TestService_validateCredentialsProof(t *testing.T) {
 				},
 				getVcVerifier: func() vcVerifier {
 					mockVerifier := NewMockVcVerifier(gomock.NewController(t))
-					mockVerifier.EXPECT().ValidateCredentialProof(
+					mockVerifier.EXPECT().ValidateCredentialProof(gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
@@ -676,7 +722,7 @@ func TestService_validateCredentialsProof(t *testing.T) {
 				},
 				getVcVerifier: func() vcVerifier {
 					mockVerifier := NewMockVcVerifier(gomock.NewController(t))
-					mockVerifier.EXPECT().ValidateCredentialProof(
+					mockVerifier.EXPECT().ValidateCredentialProof(gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
 						gomock.Any(),
@@ -700,7 +746,7 @@ func TestService_validateCredentialsProof(t *testing.T) {
 				documentLoader: loader,
 				vcVerifier:     tt.fields.getVcVerifier(),
 			}
-			if err := s.validateCredentialsProof(tt.args.getVp()); (err != nil) != tt.wantErr {
+			if err := s.validateCredentialsProof(context.Background(), tt.args.getVp()); (err != nil) != tt.wantErr {
 				t.Errorf("validateCredentialsProof() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -733,7 +779,7 @@ func TestService_validateCredentialsStatus(t *testing.T) {
 				},
 				getVcVerifier: func() vcVerifier {
 					mockVerifier := NewMockVcVerifier(gomock.NewController(t))
-					mockVerifier.EXPECT().ValidateVCStatus(
+					mockVerifier.EXPECT().ValidateVCStatus(gomock.Any(),
 						gomock.Any(),
 						gomock.Any()).Times(1).Return(nil)
 					return mockVerifier
@@ -754,7 +800,7 @@ func TestService_validateCredentialsStatus(t *testing.T) {
 				},
 				getVcVerifier: func() vcVerifier {
 					mockVerifier := NewMockVcVerifier(gomock.NewController(t))
-					mockVerifier.EXPECT().ValidateVCStatus(
+					mockVerifier.EXPECT().ValidateVCStatus(gomock.Any(),
 						gomock.Any(),
 						gomock.Any()).Times(1).Return(errors.New("some error"))
 					return mockVerifier
@@ -775,7 +821,7 @@ func TestService_validateCredentialsStatus(t *testing.T) {
 				documentLoader: loader,
 				vcVerifier:     tt.fields.getVcVerifier(),
 			}
-			if err := s.validateCredentialsStatus(tt.args.getVp()); (err != nil) != tt.wantErr {
+			if err := s.validateCredentialsStatus(context.Background(), tt.args.getVp()); (err != nil) != tt.wantErr {
 				t.Errorf("validateCredentialsStatus() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
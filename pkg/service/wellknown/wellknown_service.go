@@ -11,32 +11,167 @@ package wellknown
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/trustbloc/vcs/internal/pkg/log"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
 )
 
+const (
+	defaultCacheTTL = 5 * time.Minute
+)
+
+var logger = log.New("wellknown-service")
+
+// ErrInvalidConfiguration is returned when OIDC configuration returned by the well-known endpoint is not valid.
+var ErrInvalidConfiguration = errors.New("invalid oidc configuration")
+
 type httpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+type cacheEntry struct {
+	conf       *oidc4vc.OIDCConfiguration
+	etag       string
+	expiresAt  time.Time
+	lastAccess atomic.Int64 // unix nano
+}
+
+func (e *cacheEntry) touch(now time.Time) {
+	e.lastAccess.Store(now.UnixNano())
+}
+
+// unusedSince returns true if the entry was not accessed since the given time.
+func (e *cacheEntry) unusedSince(t time.Time) bool {
+	return e.lastAccess.Load() < t.UnixNano()
+}
+
+// Service fetches OIDC configuration from the well-known endpoints. Fetched configurations are cached for
+// the configured TTL (or less if the provider sets a lower Cache-Control max-age) and revalidated using ETag.
+// Entries not accessed for longer than the TTL are evicted, so that the cache doesn't grow with URLs which
+// are no longer in use.
 type Service struct {
-	client httpClient
+	client          httpClient
+	cacheTTL        time.Duration
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mutex sync.RWMutex
+	cache map[string]*cacheEntry
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// Opt configures Service.
+type Opt func(s *Service)
+
+// WithCacheTTL sets the maximum time OIDC configuration is cached for. Zero disables caching.
+func WithCacheTTL(ttl time.Duration) Opt {
+	return func(s *Service) {
+		s.cacheTTL = ttl
+	}
+}
+
+// WithRefreshInterval enables background refresh of the cached OIDC configurations. Entries that expire
+// before the next refresh are revalidated in advance so that requests are served from the cache.
+func WithRefreshInterval(interval time.Duration) Opt {
+	return func(s *Service) {
+		s.refreshInterval = interval
+	}
+}
+
+// WithTimeProvider sets the function used to get the current time.
+func WithTimeProvider(now func() time.Time) Opt {
+	return func(s *Service) {
+		s.now = now
+	}
 }
 
-func NewService(client httpClient) *Service {
-	return &Service{
-		client: client,
+func NewService(client httpClient, opts ...Opt) *Service {
+	s := &Service{
+		client:   client,
+		cacheTTL: defaultCacheTTL,
+		now:      time.Now,
+		cache:    map[string]*cacheEntry{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	if s.refreshInterval > 0 && s.cacheTTL > 0 {
+		go s.refreshLoop()
+	} else {
+		close(s.done)
+	}
+
+	return s
+}
+
+// Close stops background refresh of the cached OIDC configurations.
+func (s *Service) Close() error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	<-s.done
+
+	return nil
 }
 
 func (s *Service) GetOIDCConfiguration(ctx context.Context, url string) (*oidc4vc.OIDCConfiguration, error) {
-	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	s.mutex.RLock()
+	entry, ok := s.cache[url]
+	s.mutex.RUnlock()
 
-	resp, err := s.client.Do(req)
+	if ok {
+		now := s.now()
+
+		entry.touch(now)
+
+		if now.Before(entry.expiresAt) {
+			conf := *entry.conf
+
+			return &conf, nil
+		}
+	}
 
+	entry, err := s.fetch(ctx, url, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := *entry.conf
+
+	return &conf, nil
+}
+
+// fetch requests OIDC configuration from the well-known endpoint and updates the cache. If prev entry is set,
+// the request is conditional and the cached configuration is reused on 304 Not Modified.
+func (s *Service) fetch(ctx context.Context, url string, prev *cacheEntry) (*cacheEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	if prev != nil && prev.etag != "" {
+		req.Header.Set("If-None-Match", prev.etag)
+	}
+
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -47,20 +182,168 @@ func (s *Service) GetOIDCConfiguration(ctx context.Context, url string) (*oidc4v
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
+	entry := &cacheEntry{
+		etag:      resp.Header.Get("ETag"),
+		expiresAt: s.now().Add(s.ttl(resp.Header)),
+	}
+
+	// access time is kept on revalidation, so that background refresh doesn't count as access
+	if prev != nil {
+		entry.lastAccess.Store(prev.lastAccess.Load())
+	} else {
+		entry.touch(s.now())
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && prev != nil:
+		entry.conf = prev.conf
+
+		if entry.etag == "" {
+			entry.etag = prev.etag
+		}
+	case resp.StatusCode == http.StatusOK:
+		body, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		var conf oidc4vc.OIDCConfiguration
+
+		if err = json.Unmarshal(body, &conf); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfiguration, err)
+		}
+
+		if err = validate(&conf); err != nil {
+			return nil, err
+		}
+
+		entry.conf = &conf
+	default:
 		return nil, fmt.Errorf("got unexpected status code: %v", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+
+	if entry.expiresAt.After(now) {
+		s.cache[url] = entry
+	} else {
+		delete(s.cache, url)
 	}
 
-	var conf oidc4vc.OIDCConfiguration
+	s.evictUnused(now)
 
-	if err = json.Unmarshal(body, &conf); err != nil {
-		return nil, err
+	return entry, nil
+}
+
+// evictUnused removes entries not accessed for longer than the cache TTL. Must be called with the write lock held.
+func (s *Service) evictUnused(now time.Time) {
+	threshold := now.Add(-s.cacheTTL)
+
+	for u, entry := range s.cache {
+		if entry.unusedSince(threshold) {
+			delete(s.cache, u)
+		}
 	}
+}
 
-	return &conf, nil
+// ttl returns the time OIDC configuration may be cached for. Cache-Control max-age is honoured if it is lower
+// than the configured TTL; no-store and no-cache disable caching.
+func (s *Service) ttl(header http.Header) time.Duration {
+	ttl := s.cacheTTL
+
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			return 0
+		case strings.HasPrefix(directive, "max-age="):
+			maxAge, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err == nil && time.Duration(maxAge)*time.Second < ttl {
+				ttl = time.Duration(maxAge) * time.Second
+			}
+		}
+	}
+
+	return ttl
+}
+
+func (s *Service) refreshLoop() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.refresh()
+		}
+	}
+}
+
+// refresh revalidates recently used cached entries that expire before the next refresh, unused entries are
+// evicted. Failed entries are kept until they expire, after which the configuration is fetched on the next request.
+func (s *Service) refresh() {
+	now := s.now()
+	threshold := now.Add(s.refreshInterval)
+
+	s.mutex.Lock()
+	s.evictUnused(now)
+
+	stale := make(map[string]*cacheEntry)
+
+	for u, entry := range s.cache {
+		if entry.expiresAt.Before(threshold) {
+			stale[u] = entry
+		}
+	}
+	s.mutex.Unlock()
+
+	for u, entry := range stale {
+		ctx, cancel := context.WithTimeout(context.Background(), s.refreshInterval)
+
+		if _, err := s.fetch(ctx, u, entry); err != nil {
+			logger.Warn("Failed to refresh OIDC configuration", log.WithURL(u), log.WithError(err))
+		}
+
+		cancel()
+	}
+}
+
+// validate checks that endpoints in the OIDC configuration are valid absolute URLs.
+func validate(conf *oidc4vc.OIDCConfiguration) error {
+	endpoints := []struct {
+		name  string
+		value string
+	}{
+		{name: "authorization_endpoint", value: conf.AuthorizationEndpoint},
+		{name: "pushed_authorization_request_endpoint", value: conf.PushedAuthorizationRequestEndpoint},
+		{name: "token_endpoint", value: conf.TokenEndpoint},
+	}
+
+	for _, e := range endpoints {
+		if e.value == "" {
+			continue
+		}
+
+		u, err := url.Parse(e.value)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%w: %s must be an absolute http(s) URL", ErrInvalidConfiguration, e.name)
+		}
+	}
+
+	if conf.InitiateIssuanceEndpoint != "" {
+		u, err := url.Parse(conf.InitiateIssuanceEndpoint)
+		if err != nil || u.Scheme == "" {
+			return fmt.Errorf("%w: initiate_issuance_endpoint must be an absolute URL", ErrInvalidConfiguration)
+		}
+	}
+
+	return nil
 }
@@ -12,11 +12,14 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"testing/iotest"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/trustbloc/vcs/pkg/service/wellknown"
 )
@@ -65,6 +68,7 @@ func TestWellKnownInvalidJson(t *testing.T) {
 	resp, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
 	assert.Nil(t, resp)
 	assert.ErrorContains(t, err, "unexpected end of JSON input")
+	assert.ErrorIs(t, err, wellknown.ErrInvalidConfiguration)
 }
 
 func TestClientError(t *testing.T) {
@@ -95,3 +99,308 @@ func TestClientBodyErr(t *testing.T) {
 	assert.Nil(t, resp)
 	assert.ErrorContains(t, err, text)
 }
+
+const oidcConfigJSON = `{
+  "authorization_endpoint": "https://issuer.example.com/oauth2/auth",
+  "token_endpoint": "https://issuer.example.com/oauth2/token"
+}`
+
+func okResponse(header http.Header) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(oidcConfigJSON)),
+	}
+}
+
+func TestWellKnownCache(t *testing.T) {
+	httpClient := NewMockHTTPClient(gomock.NewController(t))
+	httpClient.EXPECT().Do(gomock.Any()).Times(1).Return(okResponse(nil), nil)
+
+	srv := wellknown.NewService(httpClient)
+
+	for i := 0; i < 3; i++ {
+		resp, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+		require.NoError(t, err)
+		require.Equal(t, "https://issuer.example.com/oauth2/token", resp.TokenEndpoint)
+	}
+}
+
+func TestWellKnownETag(t *testing.T) {
+	now := time.Now()
+
+	httpClient := NewMockHTTPClient(gomock.NewController(t))
+	httpClient.EXPECT().Do(gomock.Any()).Return(okResponse(http.Header{"Etag": []string{`"v1"`}}), nil)
+
+	srv := wellknown.NewService(httpClient,
+		wellknown.WithCacheTTL(time.Minute),
+		wellknown.WithTimeProvider(func() time.Time { return now }),
+	)
+
+	_, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+
+	httpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, `"v1"`, req.Header.Get("If-None-Match"))
+
+		return &http.Response{StatusCode: http.StatusNotModified}, nil
+	})
+
+	resp, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+	require.NoError(t, err)
+	require.Equal(t, "https://issuer.example.com/oauth2/auth", resp.AuthorizationEndpoint)
+
+	// configuration is cached again after revalidation
+	resp, err = srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+	require.NoError(t, err)
+	require.Equal(t, "https://issuer.example.com/oauth2/auth", resp.AuthorizationEndpoint)
+}
+
+func TestWellKnownNotModifiedWithoutCache(t *testing.T) {
+	httpClient := NewMockHTTPClient(gomock.NewController(t))
+	httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{StatusCode: http.StatusNotModified}, nil)
+
+	srv := wellknown.NewService(httpClient)
+
+	resp, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+	require.Nil(t, resp)
+	require.ErrorContains(t, err, "got unexpected status code: 304")
+}
+
+func TestWellKnownCacheControl(t *testing.T) {
+	t.Run("max-age lower than cache ttl", func(t *testing.T) {
+		now := time.Now()
+
+		httpClient := NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Do(gomock.Any()).Times(2).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return okResponse(http.Header{"Cache-Control": []string{"public, max-age=10"}}), nil
+		})
+
+		srv := wellknown.NewService(httpClient, wellknown.WithTimeProvider(func() time.Time { return now }))
+
+		_, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+		require.NoError(t, err)
+
+		now = now.Add(5 * time.Second)
+
+		_, err = srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+		require.NoError(t, err)
+
+		now = now.Add(10 * time.Second)
+
+		_, err = srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+		require.NoError(t, err)
+	})
+
+	t.Run("no-store", func(t *testing.T) {
+		httpClient := NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Do(gomock.Any()).Times(2).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return okResponse(http.Header{"Cache-Control": []string{"no-store"}}), nil
+		})
+
+		srv := wellknown.NewService(httpClient)
+
+		for i := 0; i < 2; i++ {
+			_, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("caching disabled", func(t *testing.T) {
+		httpClient := NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Do(gomock.Any()).Times(2).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			return okResponse(nil), nil
+		})
+
+		srv := wellknown.NewService(httpClient, wellknown.WithCacheTTL(0))
+
+		for i := 0; i < 2; i++ {
+			_, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestWellKnownInvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  string
+	}{
+		{
+			name: "relative authorization endpoint",
+			body: `{"authorization_endpoint": "/oauth2/auth"}`,
+			err:  "authorization_endpoint must be an absolute http(s) URL",
+		},
+		{
+			name: "unsupported scheme of token endpoint",
+			body: `{"token_endpoint": "ftp://issuer.example.com/oauth2/token"}`,
+			err:  "token_endpoint must be an absolute http(s) URL",
+		},
+		{
+			name: "invalid pushed authorization request endpoint",
+			body: `{"pushed_authorization_request_endpoint": "https://%zz"}`,
+			err:  "pushed_authorization_request_endpoint must be an absolute http(s) URL",
+		},
+		{
+			name: "relative initiate issuance endpoint",
+			body: `{"initiate_issuance_endpoint": "initiate_issuance"}`,
+			err:  "initiate_issuance_endpoint must be an absolute URL",
+		},
+		{
+			name: "invalid type of scopes",
+			body: `{"scopes_supported": "openid"}`,
+			err:  "cannot unmarshal string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := NewMockHTTPClient(gomock.NewController(t))
+			httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}, nil)
+
+			srv := wellknown.NewService(httpClient)
+
+			resp, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+			require.Nil(t, resp)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	t.Run("custom scheme of initiate issuance endpoint", func(t *testing.T) {
+		httpClient := NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"initiate_issuance_endpoint": "openid-initiate-issuance://"}`)),
+		}, nil)
+
+		srv := wellknown.NewService(httpClient)
+
+		resp, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+		require.NoError(t, err)
+		require.Equal(t, "openid-initiate-issuance://", resp.InitiateIssuanceEndpoint)
+	})
+
+	t.Run("error is ErrInvalidConfiguration", func(t *testing.T) {
+		httpClient := NewMockHTTPClient(gomock.NewController(t))
+		httpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"token_endpoint": "token"}`)),
+		}, nil)
+
+		srv := wellknown.NewService(httpClient)
+
+		_, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+		require.ErrorIs(t, err, wellknown.ErrInvalidConfiguration)
+	})
+}
+
+func TestWellKnownBackgroundRefresh(t *testing.T) {
+	var calls atomic.Int32
+
+	httpClient := NewMockHTTPClient(gomock.NewController(t))
+	httpClient.EXPECT().Do(gomock.Any()).AnyTimes().DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) == 1 {
+			return okResponse(http.Header{"Etag": []string{`"v1"`}}), nil
+		}
+
+		if calls.Load() == 2 {
+			return nil, errors.New("refresh error")
+		}
+
+		return &http.Response{StatusCode: http.StatusNotModified}, nil
+	})
+
+	srv := wellknown.NewService(httpClient,
+		wellknown.WithCacheTTL(50*time.Millisecond),
+		wellknown.WithRefreshInterval(10*time.Millisecond),
+	)
+
+	_, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+	require.NoError(t, err)
+
+	// entry is refreshed while it is in use
+	require.Eventually(t, func() bool {
+		_, getErr := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+		require.NoError(t, getErr)
+
+		return calls.Load() >= 4
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, srv.Close())
+	require.NoError(t, srv.Close())
+
+	resp, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+	require.NoError(t, err)
+	require.Equal(t, "https://issuer.example.com/oauth2/token", resp.TokenEndpoint)
+}
+
+func TestWellKnownUnusedEntryEviction(t *testing.T) {
+	now := time.Now()
+
+	var ifNoneMatch []string
+
+	httpClient := NewMockHTTPClient(gomock.NewController(t))
+	httpClient.EXPECT().Do(gomock.Any()).Times(3).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+
+		return okResponse(http.Header{"Etag": []string{`"v1"`}}), nil
+	})
+
+	srv := wellknown.NewService(httpClient,
+		wellknown.WithCacheTTL(time.Minute),
+		wellknown.WithTimeProvider(func() time.Time { return now }),
+	)
+
+	_, err := srv.GetOIDCConfiguration(context.TODO(), "https://unused.example.com")
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+
+	// fetching another configuration evicts the entry unused for longer than cache TTL
+	_, err = srv.GetOIDCConfiguration(context.TODO(), "https://other.example.com")
+	require.NoError(t, err)
+
+	// evicted configuration is fetched again without ETag
+	_, err = srv.GetOIDCConfiguration(context.TODO(), "https://unused.example.com")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"", "", ""}, ifNoneMatch)
+}
+
+func TestWellKnownBackgroundRefreshOfUnusedEntry(t *testing.T) {
+	var calls atomic.Int32
+
+	httpClient := NewMockHTTPClient(gomock.NewController(t))
+	httpClient.EXPECT().Do(gomock.Any()).AnyTimes().DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+
+		return okResponse(nil), nil
+	})
+
+	srv := wellknown.NewService(httpClient,
+		wellknown.WithCacheTTL(50*time.Millisecond),
+		wellknown.WithRefreshInterval(10*time.Millisecond),
+	)
+
+	defer func() {
+		require.NoError(t, srv.Close())
+	}()
+
+	_, err := srv.GetOIDCConfiguration(context.TODO(), "https://any.com")
+	require.NoError(t, err)
+
+	// unused entry is evicted and is not refreshed anymore
+	time.Sleep(200 * time.Millisecond)
+
+	refreshed := calls.Load()
+
+	time.Sleep(100 * time.Millisecond)
+
+	require.Equal(t, refreshed, calls.Load())
+}
@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auditstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/trustbloc/vcs/pkg/audit"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+)
+
+const (
+	collectionName       = "audit_log"
+	anchorCollectionName = "audit_log_anchor"
+)
+
+type mongoDocument struct {
+	Seq             int64     `bson:"seq"`
+	Timestamp       time.Time `bson:"timestamp"`
+	Actor           string    `bson:"actor,omitempty"`
+	OrgID           string    `bson:"orgID,omitempty"`
+	ProfileID       string    `bson:"profileID,omitempty"`
+	Operation       string    `bson:"operation"`
+	CredentialID    string    `bson:"credentialID,omitempty"`
+	StatusListIndex string    `bson:"statusListIndex,omitempty"`
+	Status          string    `bson:"status,omitempty"`
+	RequestID       string    `bson:"requestID,omitempty"`
+	PrevHash        string    `bson:"prevHash"`
+	Hash            string    `bson:"hash"`
+}
+
+type anchorDocument struct {
+	OrgID     string `bson:"_id"`
+	Seq       int64  `bson:"seq"`
+	Hash      string `bson:"hash"`
+	Signature string `bson:"signature"`
+}
+
+// Store stores audit log entries in mongo. Organization ID and sequence number of the entry are unique, so
+// concurrent appends of the entry with the same sequence number to the organization log are rejected.
+type Store struct {
+	mongoClient *mongodb.Client
+}
+
+// New creates Store.
+func New(ctx context.Context, mongoClient *mongodb.Client) (*Store, error) {
+	s := &Store{
+		mongoClient: mongoClient,
+	}
+
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Store) migrate(ctx context.Context) error {
+	if _, err := s.mongoClient.Database().Collection(collectionName).Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "orgID", Value: 1}, {Key: "seq", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "credentialID", Value: 1}},
+			},
+		}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Append stores audit entry.
+func (s *Store) Append(ctx context.Context, entry *audit.Entry) error {
+	collection := s.mongoClient.Database().Collection(collectionName)
+
+	_, err := collection.InsertOne(ctx, &mongoDocument{
+		Seq:             entry.Seq,
+		Timestamp:       entry.Timestamp,
+		Actor:           entry.Actor,
+		OrgID:           entry.OrgID,
+		ProfileID:       entry.ProfileID,
+		Operation:       string(entry.Operation),
+		CredentialID:    entry.CredentialID,
+		StatusListIndex: entry.StatusListIndex,
+		Status:          entry.Status,
+		RequestID:       entry.RequestID,
+		PrevHash:        entry.PrevHash,
+		Hash:            entry.Hash,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return audit.ErrDuplicateSequence
+	}
+
+	if err != nil {
+		return fmt.Errorf("insert audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// Last returns the last audit entry of the organization or nil if audit log is empty.
+func (s *Store) Last(ctx context.Context, orgID string) (*audit.Entry, error) {
+	collection := s.mongoClient.Database().Collection(collectionName)
+
+	var doc mongoDocument
+
+	err := collection.FindOne(ctx, bson.M{"orgID": orgID},
+		options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil //nolint:nilnil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("find last audit entry: %w", err)
+	}
+
+	return entryFromDocument(&doc), nil
+}
+
+// Iterate calls fn for audit entries selected by filter in ascending order of sequence numbers.
+func (s *Store) Iterate(ctx context.Context, filter *audit.Filter, fn func(entry *audit.Entry) error) error {
+	collection := s.mongoClient.Database().Collection(collectionName)
+
+	// Entries of different organizations with the same sequence number are ordered by insertion.
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}, {Key: "_id", Value: 1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+
+	cursor, err := collection.Find(ctx, buildQuery(filter), opts)
+	if err != nil {
+		return fmt.Errorf("find audit entries: %w", err)
+	}
+
+	defer cursor.Close(ctx) //nolint:errcheck
+
+	for cursor.Next(ctx) {
+		var doc mongoDocument
+
+		if err = cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("decode audit entry: %w", err)
+		}
+
+		if err = fn(entryFromDocument(&doc)); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// SaveAnchor stores anchor of the organization audit log unless anchor of a later entry is stored.
+func (s *Store) SaveAnchor(ctx context.Context, anchor *audit.Anchor) error {
+	collection := s.mongoClient.Database().Collection(anchorCollectionName)
+
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": anchor.OrgID, "seq": bson.M{"$lt": anchor.Seq}},
+		bson.M{"$set": bson.M{"seq": anchor.Seq, "hash": anchor.Hash, "signature": anchor.Signature}},
+		options.Update().SetUpsert(true))
+	// Anchor of a later entry exists, so the filter doesn't match and upsert fails on document ID.
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("update audit anchor: %w", err)
+	}
+
+	return nil
+}
+
+// GetAnchor returns anchor of the organization audit log or nil if there is none.
+func (s *Store) GetAnchor(ctx context.Context, orgID string) (*audit.Anchor, error) {
+	collection := s.mongoClient.Database().Collection(anchorCollectionName)
+
+	var doc anchorDocument
+
+	err := collection.FindOne(ctx, bson.M{"_id": orgID}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil //nolint:nilnil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("find audit anchor: %w", err)
+	}
+
+	return &audit.Anchor{
+		OrgID:     doc.OrgID,
+		Seq:       doc.Seq,
+		Hash:      doc.Hash,
+		Signature: doc.Signature,
+	}, nil
+}
+
+func buildQuery(filter *audit.Filter) bson.M {
+	query := bson.M{}
+
+	if filter.OrgID != "" {
+		query["orgID"] = filter.OrgID
+	}
+
+	if filter.ProfileID != "" {
+		query["profileID"] = filter.ProfileID
+	}
+
+	if filter.Operation != "" {
+		query["operation"] = string(filter.Operation)
+	}
+
+	if filter.CredentialID != "" {
+		query["credentialID"] = filter.CredentialID
+	}
+
+	if filter.Actor != "" {
+		query["actor"] = filter.Actor
+	}
+
+	if filter.AfterSeq > 0 {
+		query["seq"] = bson.M{"$gt": filter.AfterSeq}
+	}
+
+	timestamp := bson.M{}
+
+	if filter.From != nil {
+		timestamp["$gte"] = *filter.From
+	}
+
+	if filter.To != nil {
+		timestamp["$lt"] = *filter.To
+	}
+
+	if len(timestamp) > 0 {
+		query["timestamp"] = timestamp
+	}
+
+	return query
+}
+
+func entryFromDocument(doc *mongoDocument) *audit.Entry {
+	return &audit.Entry{
+		Seq:             doc.Seq,
+		Timestamp:       doc.Timestamp.UTC(),
+		Actor:           doc.Actor,
+		OrgID:           doc.OrgID,
+		ProfileID:       doc.ProfileID,
+		Operation:       audit.Operation(doc.Operation),
+		CredentialID:    doc.CredentialID,
+		StatusListIndex: doc.StatusListIndex,
+		Status:          doc.Status,
+		RequestID:       doc.RequestID,
+		PrevHash:        doc.PrevHash,
+		Hash:            doc.Hash,
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auditstore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	dctest "github.com/ory/dockertest/v3"
+	dc "github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/trustbloc/vcs/pkg/audit"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb/auditstore"
+)
+
+const (
+	mongoDBConnString  = "mongodb://localhost:27031"
+	dockerMongoDBImage = "mongo"
+	dockerMongoDBTag   = "4.0.0"
+)
+
+func TestStore(t *testing.T) {
+	pool, mongoDBResource := startMongoDBContainer(t)
+	defer func() {
+		require.NoError(t, pool.Purge(mongoDBResource), "failed to purge MongoDB resource")
+	}()
+
+	client, err := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
+	require.NoError(t, err)
+
+	store, err := auditstore.New(context.Background(), client)
+	require.NoError(t, err)
+
+	svc := audit.NewService(&audit.Config{Store: store, AnchorKey: []byte("key")})
+
+	last, err := store.Last(context.Background(), "org1")
+	require.NoError(t, err)
+	require.Nil(t, last)
+
+	for _, entry := range []*audit.Entry{
+		{OrgID: "org1", ProfileID: "p1", Operation: audit.OperationIssueCredential, CredentialID: "c1"},
+		{OrgID: "org2", ProfileID: "p2", Operation: audit.OperationIssueCredential, CredentialID: "c2"},
+		{OrgID: "org1", ProfileID: "p1", Operation: audit.OperationUpdateStatus, CredentialID: "c1", Status: "true"},
+	} {
+		require.NoError(t, svc.Record(context.Background(), entry))
+	}
+
+	t.Run("Last", func(t *testing.T) {
+		last, err := store.Last(context.Background(), "org1")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), last.Seq)
+		require.Equal(t, audit.OperationUpdateStatus, last.Operation)
+	})
+
+	t.Run("Duplicate sequence", func(t *testing.T) {
+		err := store.Append(context.Background(), &audit.Entry{
+			OrgID:     "org1",
+			Seq:       2,
+			Operation: audit.OperationIssueCredential,
+		})
+		require.ErrorIs(t, err, audit.ErrDuplicateSequence)
+	})
+
+	t.Run("Anchor", func(t *testing.T) {
+		anchor, err := store.GetAnchor(context.Background(), "org1")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), anchor.Seq)
+		require.NotEmpty(t, anchor.Signature)
+
+		// Anchor of an earlier entry doesn't replace the stored one.
+		require.NoError(t, store.SaveAnchor(context.Background(), &audit.Anchor{OrgID: "org1", Seq: 1}))
+
+		anchor, err = store.GetAnchor(context.Background(), "org1")
+		require.NoError(t, err)
+		require.Equal(t, int64(2), anchor.Seq)
+
+		anchor, err = store.GetAnchor(context.Background(), "org3")
+		require.NoError(t, err)
+		require.Nil(t, anchor)
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		entries, err := svc.Query(context.Background(), &audit.Filter{OrgID: "org1"})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, int64(1), entries[0].Seq)
+		require.Equal(t, int64(2), entries[1].Seq)
+
+		entries, err = svc.Query(context.Background(), &audit.Filter{OrgID: "org1", AfterSeq: 1})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		entries, err = svc.Query(context.Background(), &audit.Filter{
+			Operation: audit.OperationIssueCredential,
+			Limit:     1,
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, "c1", entries[0].CredentialID)
+
+		from := time.Now().Add(time.Hour)
+
+		entries, err = svc.Query(context.Background(), &audit.Filter{From: &from})
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		result, err := svc.Verify(context.Background(), "org1")
+		require.NoError(t, err)
+		require.True(t, result.Valid)
+		require.True(t, result.Anchored)
+		require.Equal(t, int64(2), result.Entries)
+	})
+}
+
+func startMongoDBContainer(t *testing.T) (*dctest.Pool, *dctest.Resource) {
+	t.Helper()
+
+	pool, err := dctest.NewPool("")
+	require.NoError(t, err)
+
+	mongoDBResource, err := pool.RunWithOptions(&dctest.RunOptions{
+		Repository: dockerMongoDBImage,
+		Tag:        dockerMongoDBTag,
+		PortBindings: map[dc.Port][]dc.PortBinding{
+			"27017/tcp": {{HostIP: "", HostPort: "27031"}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, waitForMongoDBToBeUp())
+
+	return pool, mongoDBResource
+}
+
+func waitForMongoDBToBeUp() error {
+	return backoff.Retry(pingMongoDB, backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), 30))
+}
+
+func pingMongoDB() error {
+	mongoClient, err := mongo.NewClient(options.Client().ApplyURI(mongoDBConnString))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err = mongoClient.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	return mongoClient.Ping(ctx, nil)
+}
@@ -13,6 +13,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/mongo"
 	mongooptions "go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type Client struct {
@@ -22,7 +23,7 @@ type Client struct {
 }
 
 func New(connString string, databaseName string, timeout time.Duration) (*Client, error) {
-	client, err := mongo.NewClient(mongooptions.Client().ApplyURI(connString))
+	client, err := mongo.NewClient(mongooptions.Client().ApplyURI(connString).SetMonitor(newCommandMonitor()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a new MongoDB client: %w", err)
 	}
@@ -50,6 +51,11 @@ func (c *Client) ContextWithTimeout() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), c.timeout)
 }
 
+// Ping checks that MongoDB server is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx, readpref.Primary())
+}
+
 func (c *Client) Close() error {
 	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
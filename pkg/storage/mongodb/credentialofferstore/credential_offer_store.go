@@ -0,0 +1,135 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package credentialofferstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+)
+
+const (
+	collectionName = "oidc4vc_credential_offer"
+
+	// offerIDSize is the size of credential offer ID in bytes. Credential offer is served by an unauthenticated
+	// endpoint, so its ID must be unguessable.
+	offerIDSize = 32
+)
+
+type mongoDocument struct {
+	ID       string    `bson:"_id"`
+	ExpireAt time.Time `bson:"expireAt"`
+
+	Issuer          string   `bson:"issuer"`
+	CredentialTypes []string `bson:"credentialTypes"`
+	OpState         string   `bson:"opState"`
+}
+
+// Store stores credential offers passed to the wallet by reference.
+type Store struct {
+	mongoClient *mongodb.Client
+}
+
+// New creates Store.
+func New(ctx context.Context, mongoClient *mongodb.Client) (*Store, error) {
+	s := &Store{
+		mongoClient: mongoClient,
+	}
+
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Store) migrate(ctx context.Context) error {
+	if _, err := s.mongoClient.Database().Collection(collectionName).Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{ // ttl index https://www.mongodb.com/community/forums/t/ttl-index-internals/4086/2
+				Keys: map[string]interface{}{
+					"expireAt": 1,
+				},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Create stores credential offer which expires after the given ttl and returns its ID.
+func (s *Store) Create(ctx context.Context, offer *oidc4vc.CredentialOffer, ttl time.Duration) (string, error) {
+	id, err := newOfferID()
+	if err != nil {
+		return "", err
+	}
+
+	collection := s.mongoClient.Database().Collection(collectionName)
+
+	_, err = collection.InsertOne(ctx, &mongoDocument{
+		ID:              id,
+		ExpireAt:        time.Now().UTC().Add(ttl),
+		Issuer:          offer.Issuer,
+		CredentialTypes: offer.CredentialTypes,
+		OpState:         offer.OpState,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Find finds credential offer by ID.
+func (s *Store) Find(ctx context.Context, id string) (*oidc4vc.CredentialOffer, error) {
+	collection := s.mongoClient.Database().Collection(collectionName)
+
+	var doc mongoDocument
+
+	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, oidc4vc.ErrDataNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.ExpireAt.Before(time.Now().UTC()) {
+		// due to nature of mongodb ttlIndex works every minute, so it can be a situation when we receive expired doc
+		return nil, oidc4vc.ErrDataNotFound
+	}
+
+	return &oidc4vc.CredentialOffer{
+		Issuer:          doc.Issuer,
+		CredentialTypes: doc.CredentialTypes,
+		OpState:         doc.OpState,
+	}, nil
+}
+
+func newOfferID() (string, error) {
+	b := make([]byte, offerIDSize)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate credential offer id: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package credentialofferstore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	dctest "github.com/ory/dockertest/v3"
+	dc "github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+)
+
+const (
+	mongoDBConnString  = "mongodb://localhost:27032"
+	dockerMongoDBImage = "mongo"
+	dockerMongoDBTag   = "4.0.0"
+)
+
+func TestStore(t *testing.T) {
+	pool, mongoDBResource := startMongoDBContainer(t)
+
+	defer func() {
+		require.NoError(t, pool.Purge(mongoDBResource), "failed to purge MongoDB resource")
+	}()
+
+	client, err := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
+	require.NoError(t, err)
+
+	store, err := New(context.Background(), client)
+	require.NoError(t, err)
+
+	t.Run("create and find", func(t *testing.T) {
+		offer := &oidc4vc.CredentialOffer{
+			Issuer:          "https://vcs.example.com/oidc/idp/tx1",
+			CredentialTypes: []string{"PermanentResidentCard", "UniversityDegreeCredential"},
+			OpState:         uuid.NewString(),
+		}
+
+		id, createErr := store.Create(context.Background(), offer, time.Minute)
+		require.NoError(t, createErr)
+		require.NotEmpty(t, id)
+
+		decoded, decodeErr := base64.RawURLEncoding.DecodeString(id)
+		require.NoError(t, decodeErr)
+		require.Len(t, decoded, offerIDSize)
+
+		found, findErr := store.Find(context.Background(), id)
+		require.NoError(t, findErr)
+		assert.Equal(t, offer, found)
+
+		otherID, createErr := store.Create(context.Background(), offer, time.Minute)
+		require.NoError(t, createErr)
+		require.NotEqual(t, id, otherID)
+	})
+
+	t.Run("expired offer", func(t *testing.T) {
+		id, createErr := store.Create(context.Background(), &oidc4vc.CredentialOffer{OpState: uuid.NewString()},
+			-time.Second)
+		require.NoError(t, createErr)
+
+		found, findErr := store.Find(context.Background(), id)
+		assert.Nil(t, found)
+		assert.ErrorIs(t, findErr, oidc4vc.ErrDataNotFound)
+	})
+
+	t.Run("find non existing offer", func(t *testing.T) {
+		_, findErr := store.Find(context.Background(), "63a1bc4f5c2a9e9d5e4c2f01")
+		assert.ErrorIs(t, findErr, oidc4vc.ErrDataNotFound)
+
+		_, findErr = store.Find(context.Background(), "invalid")
+		assert.ErrorIs(t, findErr, oidc4vc.ErrDataNotFound)
+	})
+}
+
+func startMongoDBContainer(t *testing.T) (*dctest.Pool, *dctest.Resource) {
+	t.Helper()
+
+	pool, err := dctest.NewPool("")
+	require.NoError(t, err)
+
+	mongoDBResource, err := pool.RunWithOptions(&dctest.RunOptions{
+		Repository: dockerMongoDBImage,
+		Tag:        dockerMongoDBTag,
+		PortBindings: map[dc.Port][]dc.PortBinding{
+			"27017/tcp": {{HostIP: "", HostPort: "27032"}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, waitForMongoDBToBeUp())
+
+	return pool, mongoDBResource
+}
+
+func waitForMongoDBToBeUp() error {
+	return backoff.Retry(pingMongoDB, backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), 30))
+}
+
+func pingMongoDB() error {
+	var err error
+
+	tM := reflect.TypeOf(bson.M{})
+	reg := bson.NewRegistryBuilder().RegisterTypeMapEntry(bsontype.EmbeddedDocument, tM).Build()
+	clientOpts := options.Client().SetRegistry(reg).ApplyURI(mongoDBConnString)
+
+	mongoClient, err := mongo.NewClient(clientOpts)
+	if err != nil {
+		return err
+	}
+
+	err = mongoClient.Connect(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	db := mongoClient.Database("test")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	return db.Client().Ping(ctx, nil)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dpopstore
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+)
+
+const (
+	proofCollection = "dpop_proof"
+)
+
+type mongoDocument struct {
+	ID       string    `bson:"_id"`
+	ExpireAt time.Time `bson:"expireAt"`
+}
+
+// Store stores IDs of used DPoP proofs for replay protection.
+type Store struct {
+	mongoClient *mongodb.Client
+}
+
+// New creates Store.
+func New(ctx context.Context, mongoClient *mongodb.Client) (*Store, error) {
+	s := &Store{
+		mongoClient: mongoClient,
+	}
+
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Store) migrate(ctx context.Context) error {
+	if _, err := s.mongoClient.Database().Collection(proofCollection).Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{ // ttl index https://www.mongodb.com/community/forums/t/ttl-index-internals/4086/2
+				Keys: map[string]interface{}{
+					"expireAt": 1,
+				},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SaveProofID stores ID of the used DPoP proof for the given ttl. Returns false if the ID is already stored,
+// i.e. the proof is replayed.
+func (s *Store) SaveProofID(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	_, err := s.mongoClient.Database().Collection(proofCollection).InsertOne(ctx, &mongoDocument{
+		ID:       id,
+		ExpireAt: time.Now().UTC().Add(ttl),
+	})
+
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -8,6 +8,7 @@ package oidc4vcstore
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -16,7 +17,6 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
-	vcsverifiable "github.com/trustbloc/vcs/pkg/doc/verifiable"
 	profileapi "github.com/trustbloc/vcs/pkg/profile"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vc"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb"
@@ -25,15 +25,18 @@ import (
 const (
 	collectionName    = "oidc4vcnoncestore"
 	defaultExpiration = 24 * time.Hour
+
+	deferredCredentialsField = "deferredCredentials"
+	acceptanceTokenField     = "deferredCredentials.acceptancetoken"
 )
 
 type mongoDocument struct {
 	ID       primitive.ObjectID `bson:"_id,omitempty"`
-	ExpireAt time.Time          `bson:"expireAt"`
+	ExpireAt time.Time          `bson:"expireAt,omitempty"`
 
 	OpState                            string `bson:"opState,omitempty"`
-	CredentialTemplate                 *profileapi.CredentialTemplate
-	CredentialFormat                   vcsverifiable.Format
+	ProfileID                          string
+	CredentialTemplates                []*profileapi.CredentialTemplate
 	ClaimEndpoint                      string
 	GrantType                          string
 	ResponseType                       string
@@ -41,9 +44,15 @@ type mongoDocument struct {
 	AuthorizationEndpoint              string
 	PushedAuthorizationRequestEndpoint string
 	TokenEndpoint                      string
-	AuthorizationDetails               *oidc4vc.AuthorizationDetails
+	AuthorizationDetails               []*oidc4vc.AuthorizationDetails
 	ClientID                           string
-	ClientSecret                       string
+	ClientSecretHandle                 string
+	IssuerAuthCode                     string
+	IssuerToken                        string
+	InitiateIssuanceURL                string
+	DeferredCredentials                []*oidc4vc.DeferredCredential `bson:"deferredCredentials,omitempty"`
+	State                              oidc4vc.TransactionState      `bson:"state,omitempty"`
+	OfferExpiresAt                     time.Time                     `bson:"offerExpiresAt,omitempty"`
 }
 
 // Store stores oidc transactions in mongo.
@@ -73,6 +82,12 @@ func (s *Store) migrate(ctx context.Context) error {
 				},
 				Options: options.Index().SetUnique(true),
 			},
+			{
+				Keys: map[string]interface{}{
+					acceptanceTokenField: 1,
+				},
+				Options: options.Index().SetSparse(true),
+			},
 			{ // ttl index https://www.mongodb.com/community/forums/t/ttl-index-internals/4086/2
 				Keys: map[string]interface{}{
 					"expireAt": 1,
@@ -98,6 +113,15 @@ func (s *Store) Create(
 
 	obj := s.mapTransactionDataToMongoDocument(data)
 
+	if insertCfg.TxID != "" {
+		id, err := primitive.ObjectIDFromHex(string(insertCfg.TxID))
+		if err != nil {
+			return nil, err
+		}
+
+		obj.ID = id
+	}
+
 	if insertCfg.TTL != 0 {
 		obj.ExpireAt = time.Now().UTC().Add(insertCfg.TTL)
 	}
@@ -123,13 +147,36 @@ func (s *Store) Create(
 }
 
 func (s *Store) FindByOpState(ctx context.Context, opState string) (*oidc4vc.Transaction, error) {
+	return s.findOne(ctx, bson.M{
+		"opState": opState,
+	})
+}
+
+// Get finds transaction by ID.
+func (s *Store) Get(ctx context.Context, txID oidc4vc.TxID) (*oidc4vc.Transaction, error) {
+	id, err := primitive.ObjectIDFromHex(string(txID))
+	if err != nil {
+		return nil, oidc4vc.ErrDataNotFound
+	}
+
+	return s.findOne(ctx, bson.M{
+		"_id": id,
+	})
+}
+
+// FindByAcceptanceToken finds transaction with deferred credential identified by the given acceptance token.
+func (s *Store) FindByAcceptanceToken(ctx context.Context, acceptanceToken string) (*oidc4vc.Transaction, error) {
+	return s.findOne(ctx, bson.M{
+		acceptanceTokenField: acceptanceToken,
+	})
+}
+
+func (s *Store) findOne(ctx context.Context, filter bson.M) (*oidc4vc.Transaction, error) {
 	collection := s.mongoClient.Database().Collection(collectionName)
 
 	var doc mongoDocument
 
-	err := collection.FindOne(ctx, bson.M{
-		"opState": opState,
-	}).Decode(&doc)
+	err := collection.FindOne(ctx, filter).Decode(&doc)
 
 	if errors.Is(err, mongo.ErrNoDocuments) {
 		return nil, oidc4vc.ErrDataNotFound
@@ -145,19 +192,25 @@ func (s *Store) FindByOpState(ctx context.Context, opState string) (*oidc4vc.Tra
 	}
 
 	mapped := oidc4vc.TransactionData{
-		CredentialTemplate:                 doc.CredentialTemplate,
-		CredentialFormat:                   doc.CredentialFormat,
+		ProfileID:                          doc.ProfileID,
+		CredentialTemplates:                doc.CredentialTemplates,
 		AuthorizationEndpoint:              doc.AuthorizationEndpoint,
 		PushedAuthorizationRequestEndpoint: doc.PushedAuthorizationRequestEndpoint,
 		TokenEndpoint:                      doc.TokenEndpoint,
 		ClaimEndpoint:                      doc.ClaimEndpoint,
 		ClientID:                           doc.ClientID,
-		ClientSecret:                       doc.ClientSecret,
+		ClientSecretHandle:                 doc.ClientSecretHandle,
 		GrantType:                          doc.GrantType,
 		ResponseType:                       doc.ResponseType,
 		Scope:                              doc.Scope,
 		AuthorizationDetails:               doc.AuthorizationDetails,
+		IssuerAuthCode:                     doc.IssuerAuthCode,
+		IssuerToken:                        doc.IssuerToken,
 		OpState:                            doc.OpState,
+		InitiateIssuanceURL:                doc.InitiateIssuanceURL,
+		DeferredCredentials:                doc.DeferredCredentials,
+		State:                              doc.State,
+		ExpiresAt:                          doc.OfferExpiresAt,
 	}
 
 	return &oidc4vc.Transaction{
@@ -166,7 +219,13 @@ func (s *Store) FindByOpState(ctx context.Context, opState string) (*oidc4vc.Tra
 	}, nil
 }
 
-func (s *Store) Update(ctx context.Context, tx *oidc4vc.Transaction) error {
+// Update updates the transaction if it is still in the expected state, i.e. in the state it was read with.
+// Returns oidc4vc.ErrTransactionStateChanged if the transaction state was changed concurrently.
+func (s *Store) Update(
+	ctx context.Context,
+	tx *oidc4vc.Transaction,
+	expectedState oidc4vc.TransactionState,
+) error {
 	collection := s.mongoClient.Database().Collection(collectionName)
 
 	id, err := primitive.ObjectIDFromHex(string(tx.ID))
@@ -177,19 +236,145 @@ func (s *Store) Update(ctx context.Context, tx *oidc4vc.Transaction) error {
 	doc := s.mapTransactionDataToMongoDocument(&tx.TransactionData)
 
 	doc.ID = id
-	_, err = collection.UpdateByID(ctx, id, bson.M{
-		"$set": doc,
+	// expiration is set once on creation, deferred credentials are modified only by the dedicated atomic updates,
+	// so that a stale copy of the transaction doesn't restore already redeemed deferred credential
+	doc.ExpireAt = time.Time{}
+	doc.DeferredCredentials = nil
+
+	var state interface{} = expectedState
+	if expectedState == "" {
+		// matches transactions created without state
+		state = nil
+	}
+
+	result, err := collection.UpdateOne(ctx,
+		bson.M{
+			"_id":   id,
+			"state": state,
+		},
+		bson.M{
+			"$set": doc,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return oidc4vc.ErrTransactionStateChanged
+	}
+
+	return nil
+}
+
+// AddDeferredCredential adds deferred credential to the transaction.
+func (s *Store) AddDeferredCredential(
+	ctx context.Context,
+	txID oidc4vc.TxID,
+	deferred *oidc4vc.DeferredCredential,
+) error {
+	id, err := primitive.ObjectIDFromHex(string(txID))
+	if err != nil {
+		return oidc4vc.ErrDataNotFound
+	}
+
+	result, err := s.mongoClient.Database().Collection(collectionName).UpdateByID(ctx, id, bson.M{
+		"$push": bson.M{deferredCredentialsField: deferred},
 	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return oidc4vc.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// StoreDeferredClaimData sets claim data of the deferred credentials of the given type.
+// Returns oidc4vc.ErrDataNotFound if the transaction has no deferred credentials of that type.
+func (s *Store) StoreDeferredClaimData(
+	ctx context.Context,
+	txID oidc4vc.TxID,
+	credentialType string,
+	claimData json.RawMessage,
+) error {
+	id, err := primitive.ObjectIDFromHex(string(txID))
+	if err != nil {
+		return oidc4vc.ErrDataNotFound
+	}
+
+	result, err := s.mongoClient.Database().Collection(collectionName).UpdateOne(ctx,
+		bson.M{
+			"_id": id,
+			deferredCredentialsField + ".credentialtype": credentialType,
+		},
+		bson.M{
+			"$set": bson.M{deferredCredentialsField + ".$[deferred].claimdata": claimData},
+		},
+		options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: []interface{}{bson.M{"deferred.credentialtype": credentialType}},
+		}),
+	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if result.MatchedCount == 0 {
+		return oidc4vc.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// RemoveDeferredCredential removes deferred credential identified by the acceptance token from the transaction
+// and sets the transaction state. Returns oidc4vc.ErrDataNotFound if the deferred credential has already been
+// removed, e.g. by a concurrent request, so that the acceptance token can be redeemed only once.
+func (s *Store) RemoveDeferredCredential(
+	ctx context.Context,
+	txID oidc4vc.TxID,
+	acceptanceToken string,
+	state oidc4vc.TransactionState,
+) error {
+	id, err := primitive.ObjectIDFromHex(string(txID))
+	if err != nil {
+		return oidc4vc.ErrDataNotFound
+	}
+
+	result, err := s.mongoClient.Database().Collection(collectionName).UpdateOne(ctx,
+		bson.M{
+			"_id":                id,
+			acceptanceTokenField: acceptanceToken,
+		},
+		bson.M{
+			"$pull": bson.M{deferredCredentialsField: bson.M{"acceptancetoken": acceptanceToken}},
+			"$set":  bson.M{"state": state},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.ModifiedCount == 0 {
+		return oidc4vc.ErrDataNotFound
+	}
+
+	return nil
 }
 
 func (s *Store) mapTransactionDataToMongoDocument(data *oidc4vc.TransactionData) *mongoDocument {
+	// transaction is kept after credential offer expires, so that issuer can get its status
+	expireAt := time.Now().UTC()
+	if data.ExpiresAt.After(expireAt) {
+		expireAt = data.ExpiresAt
+	}
+
 	return &mongoDocument{
-		ExpireAt:                           time.Now().UTC().Add(defaultExpiration),
+		ExpireAt:                           expireAt.Add(defaultExpiration),
 		OpState:                            data.OpState,
-		CredentialTemplate:                 data.CredentialTemplate,
-		CredentialFormat:                   data.CredentialFormat,
+		ProfileID:                          data.ProfileID,
+		CredentialTemplates:                data.CredentialTemplates,
 		ClaimEndpoint:                      data.ClaimEndpoint,
 		GrantType:                          data.GrantType,
 		ResponseType:                       data.ResponseType,
@@ -199,6 +384,12 @@ func (s *Store) mapTransactionDataToMongoDocument(data *oidc4vc.TransactionData)
 		TokenEndpoint:                      data.TokenEndpoint,
 		AuthorizationDetails:               data.AuthorizationDetails,
 		ClientID:                           data.ClientID,
-		ClientSecret:                       data.ClientSecret,
+		ClientSecretHandle:                 data.ClientSecretHandle,
+		IssuerAuthCode:                     data.IssuerAuthCode,
+		IssuerToken:                        data.IssuerToken,
+		InitiateIssuanceURL:                data.InitiateIssuanceURL,
+		DeferredCredentials:                data.DeferredCredentials,
+		State:                              data.State,
+		OfferExpiresAt:                     data.ExpiresAt,
 	}
 }
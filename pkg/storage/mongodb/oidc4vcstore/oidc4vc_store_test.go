@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -87,30 +88,46 @@ func TestStore(t *testing.T) {
 		id := uuid.New().String()
 
 		toInsert := &oidc4vc.TransactionData{
-			CredentialTemplate: &profileapi.CredentialTemplate{
-				Contexts:          []string{"https://www.w3.org/2018/credentials/v1", "https://w3id.org/citizenship/v1"},
-				ID:                "templateID",
-				Type:              "PermanentResidentCard",
-				Issuer:            "test_issuer",
-				CredentialSubject: []byte(`{"sub_1" : "abcd"}`),
+			ProfileID: "profileID",
+			CredentialTemplates: []*profileapi.CredentialTemplate{
+				{
+					Contexts:          []string{"https://www.w3.org/2018/credentials/v1", "https://w3id.org/citizenship/v1"},
+					ID:                "templateID",
+					Type:              "PermanentResidentCard",
+					Issuer:            "test_issuer",
+					CredentialSubject: []byte(`{"sub_1" : "abcd"}`),
+					Format:            vcsverifiable.Ldp,
+				},
+				{
+					ID:     "templateID2",
+					Type:   "UniversityDegreeCredential",
+					Format: vcsverifiable.Jwt,
+				},
 			},
-			CredentialFormat:                   vcsverifiable.Ldp,
 			AuthorizationEndpoint:              "authEndpoint",
 			PushedAuthorizationRequestEndpoint: "pushedAuth",
 			TokenEndpoint:                      "tokenEndpoint",
 			ClaimEndpoint:                      "432",
 			ClientID:                           "321",
-			ClientSecret:                       "secret",
+			ClientSecretHandle:                 "secret-handle",
 			GrantType:                          "342",
 			ResponseType:                       "123",
 			Scope:                              []string{"213", "321"},
-			AuthorizationDetails: &oidc4vc.AuthorizationDetails{
-				Type:           "321",
-				CredentialType: "fdsfsd",
-				Format:         "vxcxzcz",
-				Locations:      []string{"loc1", "loc2"},
+			AuthorizationDetails: []*oidc4vc.AuthorizationDetails{
+				{
+					Type:           "321",
+					CredentialType: "fdsfsd",
+					Format:         "vxcxzcz",
+					Locations:      []string{"loc1", "loc2"},
+				},
 			},
-			OpState: id,
+			IssuerAuthCode:      "authCode",
+			IssuerToken:         "issuerToken",
+			OpState:             id,
+			InitiateIssuanceURL: "openid-initiate-issuance://?op_state=" + id,
+			State:               oidc4vc.TransactionStateOffered,
+			// mongo stores time with millisecond precision
+			ExpiresAt: time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond),
 		}
 
 		resp1, err1 := store.Create(context.Background(), toInsert)
@@ -144,13 +161,12 @@ func TestStore(t *testing.T) {
 		id := uuid.NewString()
 
 		toInsert := &oidc4vc.TransactionData{
-			CredentialTemplate:   nil,
-			CredentialFormat:     vcsverifiable.Jwt,
+			CredentialTemplates:  nil,
 			ClaimEndpoint:        "432",
 			GrantType:            "342",
 			ResponseType:         "123",
 			Scope:                []string{"213", "321"},
-			AuthorizationDetails: &oidc4vc.AuthorizationDetails{Type: "321"},
+			AuthorizationDetails: []*oidc4vc.AuthorizationDetails{{Type: "321"}},
 			OpState:              id,
 		}
 
@@ -162,11 +178,120 @@ func TestStore(t *testing.T) {
 		assert.NoError(t, err)
 
 		resp.ClaimEndpoint = "test_endpoint"
+		resp.IssuerToken = "issuerToken"
+		resp.State = oidc4vc.TransactionStateCancelled
 
-		assert.NoError(t, store.Update(context.TODO(), resp))
+		assert.NoError(t, store.Update(context.TODO(), resp, ""))
 		found, err2 := store.FindByOpState(context.TODO(), id)
 		assert.NoError(t, err2)
 		assert.Equal(t, resp.ClaimEndpoint, found.ClaimEndpoint)
+		assert.Equal(t, resp.IssuerToken, found.IssuerToken)
+		assert.Equal(t, oidc4vc.TransactionStateCancelled, found.State)
+
+		var before, after mongoDocument
+
+		collection := client.Database().Collection(collectionName)
+		objectID, _ := primitive.ObjectIDFromHex(string(resp.ID))
+
+		assert.NoError(t, collection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&before))
+		assert.NoError(t, store.Update(context.TODO(), resp, oidc4vc.TransactionStateCancelled))
+		assert.NoError(t, collection.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&after))
+		assert.Equal(t, before.ExpireAt, after.ExpireAt)
+
+		// transaction is not updated if its state was changed concurrently
+		resp.State = oidc4vc.TransactionStateTokenIssued
+
+		assert.ErrorIs(t, store.Update(context.TODO(), resp, oidc4vc.TransactionStateOffered),
+			oidc4vc.ErrTransactionStateChanged)
+
+		found, err2 = store.FindByOpState(context.TODO(), id)
+		assert.NoError(t, err2)
+		assert.Equal(t, oidc4vc.TransactionStateCancelled, found.State)
+	})
+
+	t.Run("create with tx id", func(t *testing.T) {
+		txID := oidc4vc.TxID(primitive.NewObjectID().Hex())
+
+		resp, createErr := store.Create(context.TODO(), &oidc4vc.TransactionData{
+			OpState:             uuid.NewString(),
+			InitiateIssuanceURL: "openid-initiate-issuance://?op_state=123",
+		}, oidc4vc.WithTxID(txID))
+		assert.NoError(t, createErr)
+		assert.Equal(t, txID, resp.ID)
+
+		found, getErr := store.Get(context.TODO(), txID)
+		assert.NoError(t, getErr)
+		assert.Equal(t, "openid-initiate-issuance://?op_state=123", found.InitiateIssuanceURL)
+
+		_, createErr = store.Create(context.TODO(), &oidc4vc.TransactionData{
+			OpState: uuid.NewString(),
+		}, oidc4vc.WithTxID("invalid"))
+		assert.Error(t, createErr)
+	})
+
+	t.Run("transaction with expired offer is kept", func(t *testing.T) {
+		resp, createErr := store.Create(context.TODO(), &oidc4vc.TransactionData{
+			OpState:   uuid.NewString(),
+			ExpiresAt: time.Now().UTC().Add(-time.Hour),
+		})
+		assert.NoError(t, createErr)
+
+		found, getErr := store.Get(context.TODO(), resp.ID)
+		assert.NoError(t, getErr)
+		assert.Equal(t, resp.OpState, found.OpState)
+	})
+
+	t.Run("find by id and acceptance token", func(t *testing.T) {
+		acceptanceToken := uuid.NewString()
+
+		resp, createErr := store.Create(context.TODO(), &oidc4vc.TransactionData{
+			OpState: uuid.NewString(),
+		})
+		assert.NoError(t, createErr)
+
+		found, getErr := store.Get(context.TODO(), resp.ID)
+		assert.NoError(t, getErr)
+		assert.Equal(t, resp.OpState, found.OpState)
+
+		deferred := &oidc4vc.DeferredCredential{
+			AcceptanceToken: acceptanceToken,
+			CredentialType:  "DriversLicense",
+			Format:          vcsverifiable.Jwt,
+			DID:             "did:example:holder",
+		}
+
+		assert.NoError(t, store.AddDeferredCredential(context.TODO(), resp.ID, deferred))
+		assert.NoError(t, store.StoreDeferredClaimData(context.TODO(), resp.ID, "DriversLicense",
+			[]byte(`{"name":"John Doe"}`)))
+		assert.ErrorIs(t, store.StoreDeferredClaimData(context.TODO(), resp.ID, "VehicleRegistration",
+			[]byte(`{"name":"John Doe"}`)), oidc4vc.ErrDataNotFound)
+
+		found, getErr = store.FindByAcceptanceToken(context.TODO(), acceptanceToken)
+		assert.NoError(t, getErr)
+		assert.Equal(t, resp.ID, found.ID)
+		assert.Len(t, found.DeferredCredentials, 1)
+		assert.Equal(t, `{"name":"John Doe"}`, string(found.DeferredCredentials[0].ClaimData))
+
+		// update with a stale copy of the transaction keeps deferred credentials
+		assert.NoError(t, store.Update(context.TODO(), resp, ""))
+
+		_, getErr = store.FindByAcceptanceToken(context.TODO(), acceptanceToken)
+		assert.NoError(t, getErr)
+
+		assert.NoError(t, store.RemoveDeferredCredential(context.TODO(), resp.ID, acceptanceToken,
+			oidc4vc.TransactionStateCredentialIssued))
+		assert.ErrorIs(t, store.RemoveDeferredCredential(context.TODO(), resp.ID, acceptanceToken,
+			oidc4vc.TransactionStateCredentialIssued), oidc4vc.ErrDataNotFound)
+
+		found, getErr = store.Get(context.TODO(), resp.ID)
+		assert.NoError(t, getErr)
+		assert.Equal(t, oidc4vc.TransactionStateCredentialIssued, found.State)
+
+		_, getErr = store.FindByAcceptanceToken(context.TODO(), acceptanceToken)
+		assert.ErrorIs(t, getErr, oidc4vc.ErrDataNotFound)
+
+		_, getErr = store.Get(context.TODO(), "invalid")
+		assert.ErrorIs(t, getErr, oidc4vc.ErrDataNotFound)
 	})
 
 	t.Run("find non existing document", func(t *testing.T) {
@@ -214,9 +339,18 @@ func TestWithTimeouts(t *testing.T) {
 	})
 
 	t.Run("Update InvalidKey", func(t *testing.T) {
-		err := store.Update(context.TODO(), &oidc4vc.Transaction{ID: "1"})
+		err := store.Update(context.TODO(), &oidc4vc.Transaction{ID: "1"}, "")
 		assert.ErrorContains(t, err, "the provided hex string is not a valid ObjectID")
 	})
+
+	t.Run("Deferred credential InvalidKey", func(t *testing.T) {
+		assert.ErrorIs(t, store.AddDeferredCredential(context.TODO(), "1", &oidc4vc.DeferredCredential{}),
+			oidc4vc.ErrDataNotFound)
+		assert.ErrorIs(t, store.StoreDeferredClaimData(context.TODO(), "1", "DriversLicense", nil),
+			oidc4vc.ErrDataNotFound)
+		assert.ErrorIs(t, store.RemoveDeferredCredential(context.TODO(), "1", "token",
+			oidc4vc.TransactionStateCredentialIssued), oidc4vc.ErrDataNotFound)
+	})
 }
 
 func TestMigrate(t *testing.T) {
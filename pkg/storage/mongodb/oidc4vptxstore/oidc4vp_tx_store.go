@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -17,6 +18,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/trustbloc/vcs/pkg/service/oidc4vp"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb"
@@ -31,25 +33,82 @@ type txDocument struct {
 	ProfileID              string                 `bson:"profileIDID"`
 	PresentationDefinition map[string]interface{} `bson:"presentationDefinition"`
 	ReceivedClaims         map[string][]byte      `bson:"receivedClaims"`
+	ClaimsEncrypted        bool                   `bson:"claimsEncrypted,omitempty"`
+	ExpireAt               *time.Time             `bson:"expireAt,omitempty"`
+	ResponseMode           string                 `bson:"responseMode,omitempty"`
+	RedirectURI            string                 `bson:"redirectURI,omitempty"`
+	ResponseCode           string                 `bson:"responseCode,omitempty"`
+	MatchReport            map[string]interface{} `bson:"matchReport,omitempty"`
+	AuthorizationRequest   string                 `bson:"authorizationRequest,omitempty"`
 }
 
 type txUpdateDocument struct {
-	ReceivedClaims map[string][]byte `bson:"receivedClaims"`
+	ReceivedClaims       map[string][]byte      `bson:"receivedClaims"`
+	ClaimsEncrypted      bool                   `bson:"claimsEncrypted"`
+	MatchReport          map[string]interface{} `bson:"matchReport,omitempty"`
+	AuthorizationRequest string                 `bson:"authorizationRequest,omitempty"`
+	ExpireAt             *time.Time             `bson:"expireAt,omitempty"`
+}
+
+type dataProtector interface {
+	Encrypt(data []byte) ([]byte, error)
+	Decrypt(data []byte) ([]byte, error)
+}
+
+// Opt configures TxStore.
+type Opt func(store *TxStore)
+
+// WithClaimsProtector enables encryption of received claims before they are stored in a database.
+func WithClaimsProtector(protector dataProtector) Opt {
+	return func(store *TxStore) {
+		store.claimsProtector = protector
+	}
 }
 
 // TxStore manages profile in mongodb.
 type TxStore struct {
-	mongoClient    *mongodb.Client
-	documentLoader jsonld.DocumentLoader
+	mongoClient     *mongodb.Client
+	documentLoader  jsonld.DocumentLoader
+	claimsProtector dataProtector
 }
 
 // NewTxStore creates TxStore.
-func NewTxStore(mongoClient *mongodb.Client, documentLoader jsonld.DocumentLoader) *TxStore {
-	return &TxStore{mongoClient: mongoClient, documentLoader: documentLoader}
+func NewTxStore(mongoClient *mongodb.Client, documentLoader jsonld.DocumentLoader, opts ...Opt) (*TxStore, error) {
+	s := &TxStore{mongoClient: mongoClient, documentLoader: documentLoader}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (p *TxStore) migrate() error {
+	ctxWithTimeout, cancel := p.mongoClient.ContextWithTimeout()
+	defer cancel()
+
+	if _, err := p.mongoClient.Database().Collection(txCollection).Indexes().
+		CreateMany(ctxWithTimeout, []mongo.IndexModel{
+			{ // ttl index https://www.mongodb.com/community/forums/t/ttl-index-internals/4086/2
+				Keys: map[string]interface{}{
+					"expireAt": 1,
+				},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		}); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // Create creates transaction document in a database.
-func (p *TxStore) Create(pd *presexch.PresentationDefinition, profileID string) (oidc4vp.TxID, error) {
+func (p *TxStore) Create(pd *presexch.PresentationDefinition, profileID string,
+	params *oidc4vp.TxParams) (oidc4vp.TxID, error) {
 	ctxWithTimeout, cancel := p.mongoClient.ContextWithTimeout()
 	defer cancel()
 
@@ -65,6 +124,12 @@ func (p *TxStore) Create(pd *presexch.PresentationDefinition, profileID string)
 		PresentationDefinition: pdContent,
 	}
 
+	if params != nil {
+		txDoc.ResponseMode = string(params.ResponseMode)
+		txDoc.RedirectURI = params.RedirectURI
+		txDoc.ResponseCode = params.ResponseCode
+	}
+
 	result, err := collection.InsertOne(ctxWithTimeout, txDoc)
 	if err != nil {
 		return "", err
@@ -99,6 +164,79 @@ func (p *TxStore) Get(strID oidc4vp.TxID) (*oidc4vp.Transaction, error) {
 		return nil, fmt.Errorf("tx find failed: %w", err)
 	}
 
+	if txDoc.ExpireAt != nil && txDoc.ExpireAt.Before(time.Now().UTC()) {
+		// due to nature of mongodb ttlIndex works every minute, so it can be a situation when we receive expired doc
+		return nil, oidc4vp.ErrDataNotFound
+	}
+
+	if txDoc.ClaimsEncrypted {
+		if err = p.decryptClaims(txDoc); err != nil {
+			return nil, err
+		}
+	}
+
+	return txFromDocument(txDoc, p.documentLoader)
+}
+
+// Delete deletes transaction with given id together with received claims.
+func (p *TxStore) Delete(strID oidc4vp.TxID) error {
+	ctxWithTimeout, cancel := p.mongoClient.ContextWithTimeout()
+	defer cancel()
+
+	collection := p.mongoClient.Database().Collection(txCollection)
+
+	id, err := txIDFromString(strID)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.DeleteOne(ctxWithTimeout, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("tx delete failed: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return oidc4vp.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// GetAndDelete atomically deletes transaction with given id and returns the deleted transaction, so that only one
+// of concurrent callers receives it.
+func (p *TxStore) GetAndDelete(strID oidc4vp.TxID) (*oidc4vp.Transaction, error) {
+	ctxWithTimeout, cancel := p.mongoClient.ContextWithTimeout()
+	defer cancel()
+
+	collection := p.mongoClient.Database().Collection(txCollection)
+
+	id, err := txIDFromString(strID)
+	if err != nil {
+		return nil, err
+	}
+
+	txDoc := &txDocument{}
+
+	err = collection.FindOneAndDelete(ctxWithTimeout, bson.M{"_id": id}).Decode(txDoc)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, oidc4vp.ErrDataNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("tx find and delete failed: %w", err)
+	}
+
+	if txDoc.ExpireAt != nil && txDoc.ExpireAt.Before(time.Now().UTC()) {
+		return nil, oidc4vp.ErrDataNotFound
+	}
+
+	if txDoc.ClaimsEncrypted {
+		if err = p.decryptClaims(txDoc); err != nil {
+			return nil, err
+		}
+	}
+
 	return txFromDocument(txDoc, p.documentLoader)
 }
 
@@ -121,14 +259,36 @@ func (p *TxStore) Update(update oidc4vp.TransactionUpdate) error {
 			if err != nil {
 				return fmt.Errorf("update tx doc: encode received claims %w", err)
 			}
+
+			if p.claimsProtector != nil {
+				receivedClaims[key], err = p.claimsProtector.Encrypt(receivedClaims[key])
+				if err != nil {
+					return fmt.Errorf("update tx doc: encrypt received claims %w", err)
+				}
+			}
+		}
+	}
+
+	updateDoc := txUpdateDocument{
+		ReceivedClaims:       receivedClaims,
+		ClaimsEncrypted:      p.claimsProtector != nil,
+		AuthorizationRequest: update.AuthorizationRequest,
+	}
+
+	if update.MatchReport != nil {
+		updateDoc.MatchReport, err = mongodb.StructureToMap(update.MatchReport)
+		if err != nil {
+			return fmt.Errorf("update tx doc: encode match report %w", err)
 		}
 	}
 
+	if !update.ExpireAt.IsZero() {
+		updateDoc.ExpireAt = &update.ExpireAt
+	}
+
 	//nolint: govet
 	result, err := collection.UpdateOne(ctxWithTimeout,
-		bson.D{{"_id", id}}, bson.D{{"$set", txUpdateDocument{
-			ReceivedClaims: receivedClaims,
-		}}})
+		bson.D{{"_id", id}}, bson.D{{"$set", updateDoc}})
 	if err != nil {
 		return err
 	}
@@ -140,6 +300,23 @@ func (p *TxStore) Update(update oidc4vp.TransactionUpdate) error {
 	return nil
 }
 
+func (p *TxStore) decryptClaims(txDoc *txDocument) error {
+	if p.claimsProtector == nil {
+		return errors.New("tx claims are encrypted but claims protector is not configured")
+	}
+
+	for key, cred := range txDoc.ReceivedClaims {
+		decrypted, err := p.claimsProtector.Decrypt(cred)
+		if err != nil {
+			return fmt.Errorf("oidc4vp tx manager: received claims decryption failed: %w", err)
+		}
+
+		txDoc.ReceivedClaims[key] = decrypted
+	}
+
+	return nil
+}
+
 func txIDFromString(strID oidc4vp.TxID) (primitive.ObjectID, error) {
 	if strID == "" {
 		return primitive.NilObjectID, nil
@@ -175,10 +352,25 @@ func txFromDocument(txDoc *txDocument, documentLoader jsonld.DocumentLoader) (*o
 		}
 	}
 
+	var matchReport *oidc4vp.MatchReport
+
+	if txDoc.MatchReport != nil {
+		matchReport = &oidc4vp.MatchReport{}
+
+		if err = mongodb.MapToStructure(txDoc.MatchReport, matchReport); err != nil {
+			return nil, fmt.Errorf("oidc4vp tx manager: match report deserialization failed: %w", err)
+		}
+	}
+
 	return &oidc4vp.Transaction{
 		ID:                     oidc4vp.TxID(txDoc.ID.Hex()),
 		ProfileID:              txDoc.ProfileID,
 		PresentationDefinition: pd,
 		ReceivedClaims:         receivedClaims,
+		ResponseMode:           oidc4vp.ResponseMode(txDoc.ResponseMode),
+		RedirectURI:            txDoc.RedirectURI,
+		ResponseCode:           txDoc.ResponseCode,
+		MatchReport:            matchReport,
+		AuthorizationRequest:   txDoc.AuthorizationRequest,
 	}, nil
 }
@@ -26,6 +26,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/trustbloc/vcs/pkg/dataprotect"
 	"github.com/trustbloc/vcs/pkg/internal/testutil"
 	"github.com/trustbloc/vcs/pkg/service/oidc4vp"
 	"github.com/trustbloc/vcs/pkg/storage/mongodb"
@@ -54,20 +55,21 @@ func TestTxStore_Success(t *testing.T) {
 	client, err := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
 	require.NoError(t, err)
 
-	store := NewTxStore(client, testutil.DocumentLoader(t))
+	store, err := NewTxStore(client, testutil.DocumentLoader(t))
+	require.NoError(t, err)
 	require.NotNil(t, store)
 	defer func() {
 		require.NoError(t, client.Close(), "failed to close mongodb client")
 	}()
 
 	t.Run("Create tx", func(t *testing.T) {
-		id, err := store.Create(&presexch.PresentationDefinition{}, "test")
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
 		require.NoError(t, err)
 		require.NotNil(t, id)
 	})
 
 	t.Run("Create tx then Get by id", func(t *testing.T) {
-		id, err := store.Create(&presexch.PresentationDefinition{}, "test")
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, id)
@@ -77,8 +79,23 @@ func TestTxStore_Success(t *testing.T) {
 		require.NotNil(t, tx)
 	})
 
+	t.Run("Create same-device tx then Get by id", func(t *testing.T) {
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", &oidc4vp.TxParams{
+			ResponseMode: oidc4vp.ResponseModeDirectPost,
+			RedirectURI:  "https://rp.example.com/cb",
+			ResponseCode: "response-code",
+		})
+		require.NoError(t, err)
+
+		tx, err := store.Get(id)
+		require.NoError(t, err)
+		require.Equal(t, oidc4vp.ResponseModeDirectPost, tx.ResponseMode)
+		require.Equal(t, "https://rp.example.com/cb", tx.RedirectURI)
+		require.Equal(t, "response-code", tx.ResponseCode)
+	})
+
 	t.Run("Create tx then update with jwt vc", func(t *testing.T) {
-		id, err := store.Create(&presexch.PresentationDefinition{}, "test")
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, id)
@@ -104,7 +121,7 @@ func TestTxStore_Success(t *testing.T) {
 	})
 
 	t.Run("Create tx then update with ld vc", func(t *testing.T) {
-		id, err := store.Create(&presexch.PresentationDefinition{}, "test")
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, id)
@@ -128,6 +145,127 @@ func TestTxStore_Success(t *testing.T) {
 		require.NotNil(t, tx.ReceivedClaims.Credentials["credID"])
 		require.Equal(t, "http://example.gov/credentials/3732", tx.ReceivedClaims.Credentials["credID"].ID)
 	})
+
+	t.Run("Create tx then update with match report", func(t *testing.T) {
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
+		require.NoError(t, err)
+
+		report := &oidc4vp.MatchReport{
+			Matched: false,
+			InputDescriptors: []*oidc4vp.InputDescriptorMatch{{
+				ID:            "descriptor",
+				CredentialIDs: []string{"credID"},
+				Failures: []*oidc4vp.MatchFailure{{
+					Reason: oidc4vp.MatchFailureMissingField,
+					Path:   "$.credentialSubject.degree",
+				}},
+			}},
+		}
+
+		err = store.Update(oidc4vp.TransactionUpdate{
+			ID:          id,
+			MatchReport: report,
+		})
+		require.NoError(t, err)
+
+		tx, err := store.Get(id)
+		require.NoError(t, err)
+		require.Equal(t, report, tx.MatchReport)
+	})
+
+	t.Run("Create tx then update with authorization request", func(t *testing.T) {
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
+		require.NoError(t, err)
+
+		err = store.Update(oidc4vp.TransactionUpdate{
+			ID:                   id,
+			AuthorizationRequest: "openid-vc://?request_uri=https://vcs.example.com/request-object/1",
+		})
+		require.NoError(t, err)
+
+		err = store.Update(oidc4vp.TransactionUpdate{
+			ID:          id,
+			MatchReport: &oidc4vp.MatchReport{Matched: true},
+		})
+		require.NoError(t, err)
+
+		tx, err := store.Get(id)
+		require.NoError(t, err)
+		require.Equal(t, "openid-vc://?request_uri=https://vcs.example.com/request-object/1", tx.AuthorizationRequest)
+	})
+
+	t.Run("Create tx then update with expired claims", func(t *testing.T) {
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
+		require.NoError(t, err)
+
+		err = store.Update(oidc4vp.TransactionUpdate{
+			ID:             id,
+			ReceivedClaims: &oidc4vp.ReceivedClaims{},
+			ExpireAt:       time.Now().UTC().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		_, err = store.Get(id)
+		require.ErrorIs(t, err, oidc4vp.ErrDataNotFound)
+	})
+
+	t.Run("Create tx then delete", func(t *testing.T) {
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Delete(id))
+
+		_, err = store.Get(id)
+		require.ErrorIs(t, err, oidc4vp.ErrDataNotFound)
+
+		require.ErrorIs(t, store.Delete(id), oidc4vp.ErrDataNotFound)
+	})
+
+	t.Run("Create tx then get and delete", func(t *testing.T) {
+		id, err := store.Create(&presexch.PresentationDefinition{}, "test", nil)
+		require.NoError(t, err)
+
+		tx, err := store.GetAndDelete(id)
+		require.NoError(t, err)
+		require.Equal(t, id, tx.ID)
+
+		_, err = store.Get(id)
+		require.ErrorIs(t, err, oidc4vp.ErrDataNotFound)
+
+		_, err = store.GetAndDelete(id)
+		require.ErrorIs(t, err, oidc4vp.ErrDataNotFound)
+	})
+
+	t.Run("Encrypted claims", func(t *testing.T) {
+		protector, err := dataprotect.NewAESProtector([]byte("0123456789abcdef0123456789abcdef"))
+		require.NoError(t, err)
+
+		encryptedStore, err := NewTxStore(client, testutil.DocumentLoader(t), WithClaimsProtector(protector))
+		require.NoError(t, err)
+
+		id, err := encryptedStore.Create(&presexch.PresentationDefinition{}, "test", nil)
+		require.NoError(t, err)
+
+		jwtvc, err := verifiable.ParseCredential([]byte(sampleVCJWT),
+			verifiable.WithJSONLDDocumentLoader(testutil.DocumentLoader(t)),
+			verifiable.WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		err = encryptedStore.Update(oidc4vp.TransactionUpdate{
+			ID: id,
+			ReceivedClaims: &oidc4vp.ReceivedClaims{
+				Credentials: map[string]*verifiable.Credential{"credID": jwtvc},
+			},
+		})
+		require.NoError(t, err)
+
+		tx, err := encryptedStore.Get(id)
+		require.NoError(t, err)
+		require.Equal(t, "http://example.gov/credentials/3732", tx.ReceivedClaims.Credentials["credID"].ID)
+
+		_, err = store.Get(id)
+		require.ErrorContains(t, err, "claims protector is not configured")
+	})
 }
 
 func TestTxStore_Fails(t *testing.T) {
@@ -140,7 +278,8 @@ func TestTxStore_Fails(t *testing.T) {
 	client, err := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
 	require.NoError(t, err)
 
-	store := NewTxStore(client, testutil.DocumentLoader(t))
+	store, err := NewTxStore(client, testutil.DocumentLoader(t))
+	require.NoError(t, err)
 	require.NotNil(t, store)
 	defer func() {
 		require.NoError(t, client.Close(), "failed to close mongodb client")
@@ -182,6 +321,16 @@ func TestTxStore_Fails(t *testing.T) {
 		require.EqualError(t, err, "profile with given id not found")
 	})
 
+	t.Run("Delete invalid tx id", func(t *testing.T) {
+		err := store.Delete("invalid")
+		require.Contains(t, err.Error(), "tx invalid id")
+	})
+
+	t.Run("Get and delete invalid tx id", func(t *testing.T) {
+		_, err := store.GetAndDelete("invalid")
+		require.Contains(t, err.Error(), "tx invalid id")
+	})
+
 	t.Run("invalid doc content", func(t *testing.T) {
 		_, err := txFromDocument(&txDocument{
 			ID: primitive.ObjectID{},
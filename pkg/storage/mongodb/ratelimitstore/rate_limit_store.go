@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimitstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/trustbloc/vcs/pkg/ratelimit"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+)
+
+const (
+	collectionName = "rate_limits"
+	// idleTTL is how long the bucket is kept after it becomes full.
+	idleTTL = time.Minute
+)
+
+type bucketDocument struct {
+	Tokens  float64 `bson:"tokens"`
+	Allowed bool    `bson:"allowed"`
+}
+
+// Store keeps token buckets in MongoDB, so limits are shared by all VCS instances. Buckets are updated
+// atomically using update pipeline and server time, which requires MongoDB 4.2 or later.
+type Store struct {
+	mongoClient *mongodb.Client
+}
+
+// New creates Store.
+func New(ctx context.Context, mongoClient *mongodb.Client) (*Store, error) {
+	s := &Store{
+		mongoClient: mongoClient,
+	}
+
+	if err := s.migrate(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Store) migrate(ctx context.Context) error {
+	if _, err := s.mongoClient.Database().Collection(collectionName).Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{ // ttl index https://www.mongodb.com/community/forums/t/ttl-index-internals/4086/2
+				Keys: map[string]interface{}{
+					"expireAt": 1,
+				},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Take takes a token from the bucket identified by the key.
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error) {
+	collection := s.mongoClient.Database().Collection(collectionName)
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	doc := &bucketDocument{}
+
+	err := collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, takePipeline(limit), opts).Decode(doc)
+	if mongo.IsDuplicateKeyError(err) {
+		// Concurrent request has created the bucket, so the update succeeds on retry.
+		err = collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, takePipeline(limit), opts).Decode(doc)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("take token from bucket: %w", err)
+	}
+
+	return ratelimit.NewResult(doc.Allowed, doc.Tokens, limit), nil
+}
+
+// takePipeline refills the bucket for the time elapsed since the last update and takes a token if available.
+func takePipeline(limit ratelimit.Limit) mongo.Pipeline {
+	capacity := limit.Capacity()
+
+	elapsedSeconds := bson.M{"$divide": bson.A{
+		bson.M{"$subtract": bson.A{"$$NOW", bson.M{"$ifNull": bson.A{"$updatedAt", "$$NOW"}}}},
+		time.Second.Milliseconds(),
+	}}
+
+	refillMillis := int64(capacity/limit.RequestsPerSecond*float64(time.Second.Milliseconds())) +
+		idleTTL.Milliseconds()
+
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"tokens": bson.M{"$min": bson.A{
+				capacity,
+				bson.M{"$add": bson.A{
+					bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+					bson.M{"$multiply": bson.A{elapsedSeconds, limit.RequestsPerSecond}},
+				}},
+			}},
+			"updatedAt": "$$NOW",
+			"expireAt":  bson.M{"$add": bson.A{"$$NOW", refillMillis}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed": bson.M{"$gte": bson.A{"$tokens", 1}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"tokens": bson.M{"$cond": bson.A{"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+		}}},
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimitstore_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	dctest "github.com/ory/dockertest/v3"
+	dc "github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/trustbloc/vcs/pkg/ratelimit"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb"
+	"github.com/trustbloc/vcs/pkg/storage/mongodb/ratelimitstore"
+)
+
+const (
+	mongoDBConnString  = "mongodb://localhost:27029"
+	dockerMongoDBImage = "mongo"
+	dockerMongoDBTag   = "4.4"
+)
+
+func TestStore_Take(t *testing.T) {
+	pool, mongoDBResource := startMongoDBContainer(t)
+	defer func() {
+		require.NoError(t, pool.Purge(mongoDBResource), "failed to purge MongoDB resource")
+	}()
+
+	client, err := mongodb.New(mongoDBConnString, "testdb", time.Second*10)
+	require.NoError(t, err)
+
+	store, err := ratelimitstore.New(context.Background(), client)
+	require.NoError(t, err)
+
+	t.Run("Burst is allowed", func(t *testing.T) {
+		limit := ratelimit.Limit{RequestsPerSecond: 0.1, Burst: 2}
+
+		for i := 0; i < 2; i++ {
+			res, err := store.Take(context.Background(), "key1", limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+		}
+
+		res, err := store.Take(context.Background(), "key1", limit)
+		require.NoError(t, err)
+		require.False(t, res.Allowed)
+		require.Greater(t, res.RetryAfter, 9*time.Second)
+	})
+
+	t.Run("Bucket is refilled", func(t *testing.T) {
+		limit := ratelimit.Limit{RequestsPerSecond: 10, Burst: 1}
+
+		res, err := store.Take(context.Background(), "key2", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+
+		time.Sleep(200 * time.Millisecond)
+
+		res, err = store.Take(context.Background(), "key2", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+	})
+
+	t.Run("Concurrent requests share bucket", func(t *testing.T) {
+		limit := ratelimit.Limit{RequestsPerSecond: 0.1, Burst: 5}
+
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			allowed int
+		)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				res, err := store.Take(context.Background(), "key3", limit)
+				require.NoError(t, err)
+
+				if res.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		require.Equal(t, 5, allowed)
+	})
+}
+
+func TestStore_Fail(t *testing.T) {
+	client, err := mongodb.New("mongodb://localhost:27030", "testdb", time.Second)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = ratelimitstore.New(ctx, client)
+	require.Error(t, err)
+}
+
+func startMongoDBContainer(t *testing.T) (*dctest.Pool, *dctest.Resource) {
+	t.Helper()
+
+	pool, err := dctest.NewPool("")
+	require.NoError(t, err)
+
+	mongoDBResource, err := pool.RunWithOptions(&dctest.RunOptions{
+		Repository: dockerMongoDBImage,
+		Tag:        dockerMongoDBTag,
+		PortBindings: map[dc.Port][]dc.PortBinding{
+			"27017/tcp": {{HostIP: "", HostPort: "27029"}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, waitForMongoDBToBeUp())
+
+	return pool, mongoDBResource
+}
+
+func waitForMongoDBToBeUp() error {
+	return backoff.Retry(pingMongoDB, backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), 30))
+}
+
+func pingMongoDB() error {
+	mongoClient, err := mongo.NewClient(options.Client().ApplyURI(mongoDBConnString))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err = mongoClient.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	return mongoClient.Ping(ctx, nil)
+}
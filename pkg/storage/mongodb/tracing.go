@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/trustbloc/vcs/pkg/storage/mongodb")
+
+// commandTracer records a client span for each MongoDB command. Span is a child of the span from the context
+// passed to the store operation.
+type commandTracer struct {
+	spans sync.Map // request ID -> trace.Span
+}
+
+func newCommandMonitor() *event.CommandMonitor {
+	t := &commandTracer{}
+
+	return &event.CommandMonitor{
+		Started:   t.started,
+		Succeeded: t.succeeded,
+		Failed:    t.failed,
+	}
+}
+
+func (t *commandTracer) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	attrs := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMongoDB,
+			semconv.DBNameKey.String(evt.DatabaseName),
+			semconv.DBOperationKey.String(evt.CommandName),
+		),
+	}
+
+	name := "mongodb." + evt.CommandName
+
+	if collection, ok := evt.Command.Lookup(evt.CommandName).StringValueOK(); ok {
+		attrs = append(attrs, trace.WithAttributes(semconv.DBMongoDBCollectionKey.String(collection)))
+		name = collection + "." + evt.CommandName
+	}
+
+	_, span := tracer.Start(ctx, name, attrs...)
+
+	t.spans.Store(evt.RequestID, span)
+}
+
+func (t *commandTracer) succeeded(_ context.Context, evt *event.CommandSucceededEvent) {
+	if span, ok := t.spans.LoadAndDelete(evt.RequestID); ok {
+		span.(trace.Span).End()
+	}
+}
+
+func (t *commandTracer) failed(_ context.Context, evt *event.CommandFailedEvent) {
+	if s, ok := t.spans.LoadAndDelete(evt.RequestID); ok {
+		span := s.(trace.Span) //nolint:errcheck
+
+		span.RecordError(errors.New(evt.Failure))
+		span.SetStatus(codes.Error, evt.Failure)
+		span.End()
+	}
+}
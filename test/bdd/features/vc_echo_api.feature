@@ -11,3 +11,10 @@ Feature: VC REST API using Echo web framework
     When I make an HTTP GET to "http://localhost:8075/healthcheck"
     Then I receive response with status code "200"
      And response contains "status" with value "success"
+
+  Scenario: VC server is live and ready
+    When I make an HTTP GET to "http://localhost:8075/healthcheck/live"
+    Then I receive response with status code "200"
+     And response contains "status" with value "ok"
+    When I make an HTTP GET to "http://localhost:8075/healthcheck/ready"
+    Then I receive response with status code "200"